@@ -236,6 +236,8 @@ func GetRPCClient(cmd *cobra.Command, targetRepo rr.LocalRepo) (*client.RPCClien
 	rpcAddress := viper.GetString("remote.address")
 	rpcUser := viper.GetString("rpc.user")
 	rpcPassword := viper.GetString("rpc.password")
+	rpcMaxIdleConns := viper.GetInt("rpc.maxidleconns")
+	rpcIdleConnTimeout := viper.GetDuration("rpc.idleconntimeout")
 
 	var err error
 	var host, port string
@@ -257,10 +259,12 @@ func GetRPCClient(cmd *cobra.Command, targetRepo rr.LocalRepo) (*client.RPCClien
 
 create:
 	c := client.NewClient(&types2.Options{
-		Host:     host,
-		Port:     cast.ToInt(port),
-		User:     rpcUser,
-		Password: rpcPassword,
+		Host:            host,
+		Port:            cast.ToInt(port),
+		User:            rpcUser,
+		Password:        rpcPassword,
+		MaxIdleConns:    rpcMaxIdleConns,
+		IdleConnTimeout: rpcIdleConnTimeout,
 	})
 
 	return c, nil
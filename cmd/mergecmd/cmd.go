@@ -56,6 +56,7 @@ var mergeReqCreateCmd = &cobra.Command{
 		targetBranch, _ := cmd.Flags().GetString("target")
 		targetBranchHash, _ := cmd.Flags().GetString("targetHash")
 		force, _ := cmd.Flags().GetBool("force")
+		reviewers, _ := cmd.Flags().GetStringSlice("reviewers")
 
 		r, err := repo.GetAtWorkingDir(cfg.Node.GitBinPath)
 		if err != nil {
@@ -162,6 +163,10 @@ var mergeReqCreateCmd = &cobra.Command{
 			mrCreateArgs.Close = &cls
 		}
 
+		if cmd.Flags().Changed("reviewers") {
+			mrCreateArgs.Reviewers = funk.UniqString(reviewers)
+		}
+
 		if _, err := MergeRequestCreateCmd(r, mrCreateArgs); err != nil {
 			log.Fatal(err.Error())
 		}
@@ -411,6 +416,7 @@ func init() {
 	mergeReqCreateCmd.Flags().String("baseHash", "", "Specify the current hash of the base branch")
 	mergeReqCreateCmd.Flags().String("target", "", "Specify the target branch name")
 	mergeReqCreateCmd.Flags().String("targetHash", "", "Specify the hash of the target branch")
+	mergeReqCreateCmd.Flags().StringSlice("reviewers", nil, "Specify push keys of reviewers to request (max. 10)")
 	mergeReqCreateCmd.Flags().BoolP("force", "f", false, "Forcefully create the close comment (uncommitted changes will be lost)")
 
 	mergeReqReadCmd.Flags().Bool("no-close-status", false, "Hide the close status indicator")
@@ -14,6 +14,7 @@ import (
 	"github.com/make-os/kit/remote/plumbing"
 	"github.com/make-os/kit/util"
 	fmt2 "github.com/make-os/kit/util/colorfmt"
+	"github.com/make-os/kit/util/crypto"
 	io2 "github.com/make-os/kit/util/io"
 	"github.com/pkg/errors"
 )
@@ -47,6 +48,16 @@ type MergeRequestCreateArgs struct {
 	// TargetHash is the target hash name
 	TargetHash string
 
+	// Reviewers are the push keys requested to review the merge request
+	Reviewers []string
+
+	// DefaultReviewers are the push keys assigned as reviewers of
+	// a new merge request when Reviewers is not set
+	DefaultReviewers []string
+
+	// MergeStrategy is the repository's preferred merge strategy
+	MergeStrategy string
+
 	// UseEditor indicates that the body of the Issue should be collected using a text editor.
 	UseEditor bool
 
@@ -80,6 +91,9 @@ type MergeRequestCreateArgs struct {
 
 type MergeRequestCreateResult struct {
 	Reference string
+
+	// MergeStrategy is the repository's preferred merge strategy
+	MergeStrategy string
 }
 
 // MergeRequestCreateCmdFunc describes the MergeRequestCreateCmd function signature
@@ -152,6 +166,13 @@ func MergeRequestCreateCmd(r plumbing.LocalRepo, args *MergeRequestCreateArgs) (
 		}
 	}
 
+	// Ensure reviewers are valid push address
+	for _, reviewer := range args.Reviewers {
+		if !crypto.IsValidPushAddr(reviewer) {
+			return nil, fmt.Errorf("reviewer (%s) is not a valid push key address", reviewer)
+		}
+	}
+
 	// When intent is to reply to a comment, a merge request number is required
 	if args.ID == 0 && args.ReplyHash != "" {
 		return nil, fmt.Errorf("merge request number is required when adding a comment")
@@ -203,6 +224,12 @@ func MergeRequestCreateCmd(r plumbing.LocalRepo, args *MergeRequestCreateArgs) (
 		return nil, common.ErrBodyRequired
 	}
 
+	// Assign the default reviewers to a new merge request with no reviewer
+	reviewers := args.Reviewers
+	if reviewers == nil && nComments == 0 && args.ReplyHash == "" {
+		reviewers = args.DefaultReviewers
+	}
+
 	// Create the post body
 	postBody := plumbing.PostBodyToString(&plumbing.PostBody{
 		Content:   []byte(args.Body),
@@ -214,6 +241,7 @@ func MergeRequestCreateCmd(r plumbing.LocalRepo, args *MergeRequestCreateArgs) (
 			BaseBranchHash:   args.BaseHash,
 			TargetBranch:     args.Target,
 			TargetBranchHash: args.TargetHash,
+			Reviewers:        reviewers,
 		},
 		Close: args.Close,
 	})
@@ -234,12 +262,16 @@ func MergeRequestCreateCmd(r plumbing.LocalRepo, args *MergeRequestCreateArgs) (
 	if newPost {
 		fmt.Fprintln(args.StdOut, fmt2.NewColor(aurora.Green, aurora.Bold).Sprint("✅ New merge request created!"))
 		fmt.Fprintln(args.StdOut, fmt.Sprintf("%s#0", ref))
+		if args.MergeStrategy != "" {
+			fmt.Fprintln(args.StdOut, fmt.Sprintf("Preferred merge strategy: %s", args.MergeStrategy))
+		}
 	} else {
 		fmt.Fprintln(args.StdOut, fmt2.NewColor(aurora.Green, aurora.Bold).Sprint("✅ New comment added!"))
 		fmt.Fprintln(args.StdOut, fmt.Sprintf("%s#%d", ref, nComments))
 	}
 
 	return &MergeRequestCreateResult{
-		Reference: ref,
+		Reference:     ref,
+		MergeStrategy: args.MergeStrategy,
 	}, nil
 }
@@ -5,14 +5,17 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/golang/mock/gomock"
 	"github.com/make-os/kit/cmd/common"
 	"github.com/make-os/kit/cmd/mergecmd"
 	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/crypto/ed25519"
 	"github.com/make-os/kit/mocks"
 	"github.com/make-os/kit/remote/plumbing"
 	"github.com/make-os/kit/testutil"
+	"github.com/make-os/kit/util"
 	io2 "github.com/make-os/kit/util/io"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -268,6 +271,84 @@ var _ = Describe("MergeRequestCreate", func() {
 			})
 		})
 
+		When("reviewers are considered", func() {
+			var reviewer, defaultReviewer string
+			var createdBody string
+			var postCreator = func(targetRepo plumbing.LocalRepo, args *plumbing.CreatePostCommitArgs) (bool, string, error) {
+				createdBody = args.Body
+				return true, "refs/heads/merges/1", nil
+			}
+
+			BeforeEach(func() {
+				reviewer = ed25519.NewKeyFromIntSeed(1).PushAddr().String()
+				defaultReviewer = ed25519.NewKeyFromIntSeed(2).PushAddr().String()
+				createdBody = ""
+			})
+
+			getReviewers := func() []string {
+				cfm, err := util.ParseContentFrontMatter(strings.NewReader(createdBody))
+				Expect(err).To(BeNil())
+				return plumbing.PostBodyFromContentFrontMatter(&cfm).Reviewers
+			}
+
+			It("should return error when a reviewer is not a valid push key address", func() {
+				args := &mergecmd.MergeRequestCreateArgs{Reviewers: []string{"invalid"}}
+				_, err := mergecmd.MergeRequestCreateCmd(mockRepo, args)
+				Expect(err).To(MatchError("reviewer (invalid) is not a valid push key address"))
+			})
+
+			It("should assign the default reviewers when reviewers are not provided", func() {
+				args := &mergecmd.MergeRequestCreateArgs{Title: "title", Body: "body", StdOut: bytes.NewBuffer(nil),
+					DefaultReviewers: []string{defaultReviewer}, PostCommentCreator: postCreator}
+				_, err := mergecmd.MergeRequestCreateCmd(mockRepo, args)
+				Expect(err).To(BeNil())
+				Expect(getReviewers()).To(Equal([]string{defaultReviewer}))
+			})
+
+			It("should not assign the default reviewers when reviewers are provided", func() {
+				args := &mergecmd.MergeRequestCreateArgs{Title: "title", Body: "body", StdOut: bytes.NewBuffer(nil),
+					Reviewers: []string{reviewer}, DefaultReviewers: []string{defaultReviewer}, PostCommentCreator: postCreator}
+				_, err := mergecmd.MergeRequestCreateCmd(mockRepo, args)
+				Expect(err).To(BeNil())
+				Expect(getReviewers()).To(Equal([]string{reviewer}))
+			})
+
+			It("should not assign the default reviewers to a comment", func() {
+				ref := plumbing.MakeMergeRequestReference(1)
+				mockRepo.EXPECT().RefGet(ref).Return("ref_hash", nil)
+				mockRepo.EXPECT().NumCommits(ref, false).Return(1, nil)
+				args := &mergecmd.MergeRequestCreateArgs{ID: 1, Body: "body", StdOut: bytes.NewBuffer(nil),
+					DefaultReviewers: []string{defaultReviewer}, PostCommentCreator: postCreator}
+				_, err := mergecmd.MergeRequestCreateCmd(mockRepo, args)
+				Expect(err).To(BeNil())
+				Expect(getReviewers()).To(BeEmpty())
+			})
+		})
+
+		When("the repository has a preferred merge strategy", func() {
+			var postCreator = func(targetRepo plumbing.LocalRepo, args *plumbing.CreatePostCommitArgs) (bool, string, error) {
+				return true, "refs/heads/merges/1", nil
+			}
+
+			It("should surface the merge strategy in the result", func() {
+				out := bytes.NewBuffer(nil)
+				args := &mergecmd.MergeRequestCreateArgs{Title: "title", Body: "body", StdOut: out,
+					MergeStrategy: "squash", PostCommentCreator: postCreator}
+				res, err := mergecmd.MergeRequestCreateCmd(mockRepo, args)
+				Expect(err).To(BeNil())
+				Expect(res.MergeStrategy).To(Equal("squash"))
+				Expect(out.String()).To(ContainSubstring("Preferred merge strategy: squash"))
+			})
+
+			It("should return an empty merge strategy when the repository has none", func() {
+				args := &mergecmd.MergeRequestCreateArgs{Title: "title", Body: "body", StdOut: bytes.NewBuffer(nil),
+					PostCommentCreator: postCreator}
+				res, err := mergecmd.MergeRequestCreateCmd(mockRepo, args)
+				Expect(err).To(BeNil())
+				Expect(res.MergeStrategy).To(BeEmpty())
+			})
+		})
+
 		It("should return error when unable to create comment", func() {
 			args := &mergecmd.MergeRequestCreateArgs{
 				StdOut:             bytes.NewBuffer(nil),
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/make-os/kit/logic"
+	"github.com/make-os/kit/storage"
+	fmt2 "github.com/make-os/kit/util/colorfmt"
+	"github.com/spf13/cobra"
+)
+
+// reindex rebuilds the given secondary indexes of the node.
+// The node must not be running.
+func reindex(indexes []string) error {
+
+	db, err := storage.NewBadger(cfg.GetAppDBDir(), storage.Options{
+		Compression: cfg.Node.DBCompression,
+	})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	stateDB, err := storage.NewBadgerTMDB(cfg.GetStateTreeDBDir())
+	if err != nil {
+		return err
+	}
+	defer stateDB.Close()
+
+	var last string
+	err = logic.New(db, stateDB, cfg).Reindex(indexes, func(index string, n int) {
+		if index != last && last != "" {
+			fmt.Fprintln(os.Stdout)
+		}
+		last = index
+		fmt.Fprintf(os.Stdout, "\rRebuilding %s: %d entries", index, n)
+	})
+	if last != "" {
+		fmt.Fprintln(os.Stdout)
+	}
+
+	return err
+}
+
+// reindexCmd represents the reindex command
+var reindexCmd = &cobra.Command{
+	Use:   "reindex",
+	Short: "Rebuild the secondary indexes of the node.",
+	Long: `This command rebuilds secondary indexes from the current state. It is
+safe to run multiple times. The node must be stopped before running it.
+
+Indexes: ` + strings.Join(logic.Indexes, ", "),
+	Run: func(cmd *cobra.Command, args []string) {
+		indexes, _ := cmd.Flags().GetStringSlice("index")
+		if err := reindex(indexes); err != nil {
+			log.Fatal(err.Error())
+		}
+		_, _ = fmt.Fprintln(os.Stdout, fmt2.NewColor(aurora.Green, aurora.Bold).Sprint("✅ Reindex completed!"))
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(reindexCmd)
+	reindexCmd.Flags().StringSliceP("index", "i", nil, "Specify the indexes to rebuild (default: all)")
+}
@@ -150,7 +150,7 @@ func preRun(cmd *cobra.Command) {
 	}
 
 	// Skip git exec check for certain commands
-	if !funk.ContainsString([]string{"init", "start", "console", "sign", "attach", "config"}, cmd.CalledAs()) {
+	if !funk.ContainsString([]string{"init", "start", "console", "sign", "attach", "config", "reindex"}, cmd.CalledAs()) {
 		return
 	}
 
@@ -236,6 +236,8 @@ func init() {
 	// Remote API connection flags
 	RootCmd.PersistentFlags().String("rpc.user", "", "Set the RPC username")
 	RootCmd.PersistentFlags().String("rpc.password", "", "Set the RPC password")
+	RootCmd.PersistentFlags().Int("rpc.maxidleconns", 0, "Set the maximum number of idle RPC connections kept for reuse")
+	RootCmd.PersistentFlags().Duration("rpc.idleconntimeout", 0, "Set how long an idle RPC connection is kept for reuse")
 	RootCmd.PersistentFlags().String("remote.address", config.DefaultRemoteServerAddress, "Set the RPC server address")
 	RootCmd.PersistentFlags().String("remote", "origin", "Set the default remote name")
 
@@ -250,6 +252,8 @@ func init() {
 	_ = viper.BindPFlag("no-colors", RootCmd.PersistentFlags().Lookup("no-colors"))
 	_ = viper.BindPFlag("rpc.user", RootCmd.PersistentFlags().Lookup("rpc.user"))
 	_ = viper.BindPFlag("rpc.password", RootCmd.PersistentFlags().Lookup("rpc.password"))
+	_ = viper.BindPFlag("rpc.maxidleconns", RootCmd.PersistentFlags().Lookup("rpc.maxidleconns"))
+	_ = viper.BindPFlag("rpc.idleconntimeout", RootCmd.PersistentFlags().Lookup("rpc.idleconntimeout"))
 	_ = viper.BindPFlag("remote.address", RootCmd.PersistentFlags().Lookup("remote.address"))
 	_ = viper.BindPFlag("remote.name", RootCmd.PersistentFlags().Lookup("remote"))
 	_ = viper.BindEnv("node.ignoreSeeds")
@@ -38,6 +38,8 @@ var signCommitCmd = &cobra.Command{
 		signingKey, _ := cmd.Flags().GetString("signing-key")
 		mergeID, _ := cmd.Flags().GetString("merge-id")
 		head, _ := cmd.Flags().GetString("head")
+		meta, _ := cmd.Flags().GetStringToString("meta")
+		expiresIn, _ := cmd.Flags().GetDuration("expires-in")
 		signingKeyPass, _ := cmd.Flags().GetString("signing-key-pass")
 		targetRemotes, _ := cmd.Flags().GetString("remote")
 		resetRemoteTokens, _ := cmd.Flags().GetBool("reset")
@@ -53,6 +55,8 @@ var signCommitCmd = &cobra.Command{
 			Value:                        value,
 			MergeID:                      mergeID,
 			Head:                         head,
+			Meta:                         meta,
+			ExpiresIn:                    expiresIn,
 			SigningKey:                   signingKey,
 			PushKeyPass:                  signingKeyPass,
 			Remote:                       targetRemotes,
@@ -154,6 +158,8 @@ var signNoteCmd = &cobra.Command{
 func setupSignCommitCmd(cmd *cobra.Command) {
 	cmd.Flags().StringP("merge-id", "m", "", "Provide a merge proposal ID for merge fulfilment")
 	cmd.Flags().String("head", "", "Specify the branch to use as git HEAD")
+	cmd.Flags().StringToString("meta", nil, "Add extra information to the push token (e.g --meta ciRunId=123)")
+	cmd.Flags().Duration("expires-in", 0, "Set how long the push token remains valid (e.g --expires-in 1h)")
 }
 
 func init() {
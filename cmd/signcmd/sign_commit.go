@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/make-os/kit/cmd/common"
@@ -89,6 +90,12 @@ func SignCommitCmd(cfg *config.AppConfig, repo pl.LocalRepo, args *types3.SignCo
 		return errors2.Wrapf(err, "failed to find reference: %s", head)
 	}
 
+	// Determine when the push token expires
+	var expiresAt int64
+	if args.ExpiresIn > 0 {
+		expiresAt = time.Now().Add(args.ExpiresIn).Unix()
+	}
+
 	if err = args.CreateApplyPushTokenToRemote(repo, &server.MakeAndApplyPushTokenToRemoteArgs{
 		TargetRemote: args.Remote,
 		PushKey:      key,
@@ -102,6 +109,8 @@ func SignCommitCmd(cfg *config.AppConfig, repo pl.LocalRepo, args *types3.SignCo
 			MergeProposalID: args.MergeID,
 			Reference:       head,
 			Head:            headRef.Hash().String(),
+			Meta:            args.Meta,
+			ExpiresAt:       expiresAt,
 		},
 	}); err != nil {
 		return err
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/golang/mock/gomock"
@@ -254,5 +255,42 @@ var _ = Describe("SignCommit", func() {
 			err = SignCommitCmd(cfg, mockRepo, args)
 			Expect(err).To(BeNil())
 		})
+
+		It("should include metadata in the transaction detail", func() {
+			refName := plumbing.ReferenceName("refs/heads/master")
+			ref := plumbing.NewHashReference(refName, plumbing.NewHash("5cb1af69935120f4944a8cd515f008e12290de52"))
+			mockRepo.EXPECT().GetGitConfigOption(gomock.Any()).AnyTimes()
+			meta := map[string]string{"clientVersion": "v0.1.0", "ciRunId": "1234"}
+			args := &types3.SignCommitArgs{Fee: "1", SigningKey: key.PushAddr().String(), GetNextNonce: testGetNextNonce, Meta: meta}
+			mockStoredKey := mocks.NewMockStoredKey(ctrl)
+			args.KeyUnlocker = testPushKeyUnlocker(mockStoredKey, nil)
+			mockStoredKey.EXPECT().GetPushKeyAddress().Return(key.PushAddr().String())
+			mockRepo.EXPECT().Head().Return(refName.String(), nil)
+			mockRepo.EXPECT().Reference(refName, false).Return(ref, nil)
+			args.CreateApplyPushTokenToRemote = func(targetRepo remotetypes.LocalRepo, args *server.MakeAndApplyPushTokenToRemoteArgs) error {
+				Expect(args.TxDetail.Meta).To(Equal(meta))
+				return nil
+			}
+			err = SignCommitCmd(cfg, mockRepo, args)
+			Expect(err).To(BeNil())
+		})
+
+		It("should set the expiry time of the transaction detail when ExpiresIn is set", func() {
+			refName := plumbing.ReferenceName("refs/heads/master")
+			ref := plumbing.NewHashReference(refName, plumbing.NewHash("5cb1af69935120f4944a8cd515f008e12290de52"))
+			mockRepo.EXPECT().GetGitConfigOption(gomock.Any()).AnyTimes()
+			args := &types3.SignCommitArgs{Fee: "1", SigningKey: key.PushAddr().String(), GetNextNonce: testGetNextNonce, ExpiresIn: time.Hour}
+			mockStoredKey := mocks.NewMockStoredKey(ctrl)
+			args.KeyUnlocker = testPushKeyUnlocker(mockStoredKey, nil)
+			mockStoredKey.EXPECT().GetPushKeyAddress().Return(key.PushAddr().String())
+			mockRepo.EXPECT().Head().Return(refName.String(), nil)
+			mockRepo.EXPECT().Reference(refName, false).Return(ref, nil)
+			args.CreateApplyPushTokenToRemote = func(targetRepo remotetypes.LocalRepo, args *server.MakeAndApplyPushTokenToRemoteArgs) error {
+				Expect(args.TxDetail.ExpiresAt).To(BeNumerically("~", time.Now().Add(time.Hour).Unix(), 5))
+				return nil
+			}
+			err = SignCommitCmd(cfg, mockRepo, args)
+			Expect(err).To(BeNil())
+		})
 	})
 })
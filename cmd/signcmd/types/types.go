@@ -2,6 +2,7 @@ package types
 
 import (
 	"io"
+	"time"
 
 	"github.com/make-os/kit/cmd/common"
 	"github.com/make-os/kit/config"
@@ -29,6 +30,12 @@ type SignCommitArgs struct {
 	// Head specifies a reference to use in the transaction info instead of the signed branch reference
 	Head string
 
+	// Meta contains extra information (e.g client version, CI run id) to include in the push token
+	Meta map[string]string
+
+	// ExpiresIn is the duration after which the push token expires. Zero means it never expires.
+	ExpiresIn time.Duration
+
 	// PushKeyID is the signers push key ID
 	SigningKey string
 
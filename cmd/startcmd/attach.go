@@ -33,10 +33,12 @@ func connectToServer(cfg *config.AppConfig) (types.Client, []rpc.MethodInfo, err
 	}
 
 	cl := client.NewClient(&types.Options{
-		Host:     host,
-		Port:     cast.ToInt(port),
-		User:     cfg.RPC.User,
-		Password: cfg.RPC.Password,
+		Host:            host,
+		Port:            cast.ToInt(port),
+		User:            cfg.RPC.User,
+		Password:        cfg.RPC.Password,
+		MaxIdleConns:    cfg.RPC.MaxIdleConns,
+		IdleConnTimeout: cfg.RPC.IdleConnTimeout,
 	})
 
 	methods, err := cl.RPC().GetMethods()
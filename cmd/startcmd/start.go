@@ -70,6 +70,7 @@ func setStartFlags(cmd *cobra.Command) {
 	f.StringSliceP("repo.track", "t", []string{}, "Specify one or more repositories to track")
 	f.StringSliceP("repo.untrack", "u", []string{}, "Untrack one or more repositories")
 	f.BoolP("repo.untrackall", "x", false, "Untrack all previously tracked repositories")
+	f.StringSlice("repo.autotrack", []string{}, "Automatically track discovered repositories matching these patterns")
 
 	// Light node primary
 	f.Bool("node.light", false, "Run the node in light mode")
@@ -28,6 +28,7 @@ func Get(version string) Info {
 type Info interface {
 	GetVersion() string
 	GetName() string
+	GetGenesisTime() uint64
 	Configure(cfg *AppConfig, tmc *tmcfg.Config)
 }
 
@@ -52,6 +53,11 @@ func (ci *ChainInfo) GetName() string {
 	return ci.Name
 }
 
+// GetGenesisTime returns the unix time of the chain's genesis
+func (ci *ChainInfo) GetGenesisTime() uint64 {
+	return ci.GenesisTime
+}
+
 // GetVersion returns the chain's numeric version
 func (ci *ChainInfo) GetVersion() string {
 	return ci.NetVersion
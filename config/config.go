@@ -37,6 +37,9 @@ var (
 	// KeystoreDirName is the name of the directory where accounts are stored
 	KeystoreDirName = "keystore"
 
+	// AliasesFileName is the name of the file where address aliases are stored
+	AliasesFileName = "aliases.json"
+
 	// AppEnvPrefix is used as the prefix for environment variables
 	AppEnvPrefix = AppName
 
@@ -101,6 +104,33 @@ func setDefaultViperConfig() {
 	viper.SetDefault("mempool.cacheSize", 10000)
 	viper.SetDefault("mempool.maxTxSize", 1024*1024)       // 1MB
 	viper.SetDefault("mempool.maxTxsSize", 1024*1024*1024) // 1GB
+	viper.SetDefault("repo.cacheSize", 100)
+	viper.SetDefault("repo.commitGraphCacheSize", 10000)
+	viper.SetDefault("repo.maxRequestBodySize", 1024*1024*512) // 512MB
+	viper.SetDefault("repo.maxArchiveSize", 1024*1024*100)     // 100MB
+	viper.SetDefault("repo.endorsementTimeout", 45*time.Second)
+	viper.SetDefault("repo.cloneTimeout", 60*time.Second)
+	viper.SetDefault("repo.pushValidationWorkers", 4)
+	viper.SetDefault("repo.pushDiskMargin", 1024*1024*100) // 100MB
+	viper.SetDefault("repo.maxCloneDepth", 0)
+	viper.SetDefault("repo.duplicatePushWindow", 30*time.Second)
+	viper.SetDefault("repo.cloneParallelism", 4)
+	viper.SetDefault("repo.noteDedupTTL", 5*time.Minute)
+	viper.SetDefault("node.dbCompression", "none")
+	viper.SetDefault("node.snapshotInterval", 0)
+	viper.SetDefault("node.snapshotKeepRecent", 2)
+	viper.SetDefault("dht.dialAttempts", 3)
+	viper.SetDefault("dht.dialBackoff", 3*time.Second)
+	viper.SetDefault("dht.maxStreamsPerPeer", 10)
+	viper.SetDefault("dht.providerPreference", "none")
+	viper.SetDefault("dht.packDeltaWindow", 0)
+	viper.SetDefault("dht.republishInterval", 5*time.Hour)
+	viper.SetDefault("dht.announceRetryLimit", 5)
+	viper.SetDefault("dht.announceRetryBackoff", time.Minute)
+	viper.SetDefault("dht.maxServeRate", 0)
+	viper.SetDefault("dht.maxStreamServeRate", 0)
+	viper.SetDefault("dht.prefetchDepth", 0)
+	viper.SetDefault("dht.shutdownDrainTimeout", 0)
 }
 
 // readTendermintConfig reads tendermint config into a tendermint config object
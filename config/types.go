@@ -2,6 +2,7 @@ package config
 
 import (
 	"path/filepath"
+	"time"
 
 	"github.com/make-os/kit/pkgs/logger"
 	"github.com/spf13/viper"
@@ -43,6 +44,17 @@ type NodeConfig struct {
 	// IgnoreSeeds will prevent seed address from being used
 	IgnoreSeeds bool `json:"ignoreSeeds" mapstructure:"ignoreSeeds"`
 
+	// DBCompression is the algorithm used to compress values written
+	// to the app database (none, snappy or zstd)
+	DBCompression string `json:"dbCompression" mapstructure:"dbCompression"`
+
+	// SnapshotInterval is the number of blocks between snapshots of the state
+	// tree offered to state syncing peers. Snapshots are not taken when zero.
+	SnapshotInterval int64 `json:"snapshotInterval" mapstructure:"snapshotInterval"`
+
+	// SnapshotKeepRecent is the number of most recent state snapshots to keep
+	SnapshotKeepRecent int `json:"snapshotKeepRecent" mapstructure:"snapshotKeepRecent"`
+
 	// *** Light Node Options ***
 
 	// Light indicates whether to run the node in light mode
@@ -87,6 +99,67 @@ type RepoConfig struct {
 
 	// UntrackAll indicates that all currently tracked repositories are to be untracked
 	UntrackAll bool `json:"untrackall" mapstructure:"untrackall"`
+
+	// AutoTrack contains patterns matched against repositories learned about
+	// through gossiped push notes. A matching repository is tracked automatically.
+	// A pattern is matched against the repository name and, for namespaced
+	// pushes, also against "namespace/domain" (e.g. "myns/*").
+	AutoTrack []string `json:"autoTrack" mapstructure:"autoTrack"`
+
+	// CacheSize is the max number of opened repository handles to keep in memory.
+	// Caching is disabled when zero.
+	CacheSize int `json:"cacheSize" mapstructure:"cacheSize"`
+
+	// CommitGraphCacheSize is the max number of commits whose parent links are
+	// kept in the commit graph of a cached repository handle. The commit graph
+	// is used to answer ancestry queries and is disabled when zero.
+	CommitGraphCacheSize int `json:"commitGraphCacheSize" mapstructure:"commitGraphCacheSize"`
+
+	// MaxRequestBodySize is the max size (in bytes) of a git request body
+	// accepted by the remote server. The limit is disabled when zero.
+	MaxRequestBodySize int64 `json:"maxRequestBodySize" mapstructure:"maxRequestBodySize"`
+
+	// MaxArchiveSize is the max size (in bytes) of a compressed repository
+	// archive served by the remote server or returned by the repo module.
+	// The limit is disabled when zero.
+	MaxArchiveSize int64 `json:"maxArchiveSize" mapstructure:"maxArchiveSize"`
+
+	// EndorsementTimeout is the max duration to wait for a push note to receive
+	// a quorum of endorsements before it is dropped. The timeout is disabled when zero.
+	EndorsementTimeout time.Duration `json:"endorsementTimeout" mapstructure:"endorsementTimeout"`
+
+	// CloneTimeout is the max duration a repository clone performed by a module
+	// operation may take before it is aborted. The timeout is disabled when zero.
+	CloneTimeout time.Duration `json:"cloneTimeout" mapstructure:"cloneTimeout"`
+
+	// PushValidationWorkers is the max number of references of a push note
+	// that are validated concurrently. References are validated one after
+	// the other when less than two.
+	PushValidationWorkers int `json:"pushValidationWorkers" mapstructure:"pushValidationWorkers"`
+
+	// PushDiskMargin is the number of bytes of disk space that must remain free
+	// after the objects of a received push note are stored. Push notes whose
+	// objects would exceed the available disk space minus the margin are rejected.
+	PushDiskMargin uint64 `json:"pushDiskMargin" mapstructure:"pushDiskMargin"`
+
+	// MaxCloneDepth is the max depth of the history a clone or fetch request
+	// may ask for. Requests for a deeper history receive a history truncated
+	// to this depth. The limit is disabled when zero.
+	MaxCloneDepth int `json:"maxCloneDepth" mapstructure:"maxCloneDepth"`
+
+	// DuplicatePushWindow is the duration within which a push identical (same
+	// references and hashes) to a recently accepted push is not processed again.
+	// The hash of the earlier push transaction is returned instead. Disabled when zero.
+	DuplicatePushWindow time.Duration `json:"duplicatePushWindow" mapstructure:"duplicatePushWindow"`
+
+	// CloneParallelism is the max number of repositories cloned from the
+	// DHT at the same time by a bulk clone operation.
+	CloneParallelism int `json:"cloneParallelism" mapstructure:"cloneParallelism"`
+
+	// NoteDedupTTL is the duration a gossiped push note is remembered as seen.
+	// A note received again within it is dropped; after it, the note may be
+	// accepted again. Seen notes are remembered until evicted when zero.
+	NoteDedupTTL time.Duration `json:"noteDedupTTL" mapstructure:"noteDedupTTL"`
 }
 
 // VersionInfo describes the clients
@@ -139,13 +212,75 @@ type RPCConfig struct {
 	DisableAuth   bool   `json:"disableauth" mapstructure:"disableauth"`
 	AuthPubMethod bool   `json:"authpubmethod" mapstructure:"authpubmethod"`
 	TMRPCAddress  string `json:"tmaddress" mapstructure:"tmaddress"`
+
+	// MaxIdleConns is the maximum number of idle connections an RPC client
+	// keeps for reuse. Zero means the client default is used.
+	MaxIdleConns int `json:"maxidleconns" mapstructure:"maxidleconns"`
+
+	// IdleConnTimeout is the duration an RPC client keeps an idle connection
+	// for reuse. Zero means the client default is used.
+	IdleConnTimeout time.Duration `json:"idleconntimeout" mapstructure:"idleconntimeout"`
 }
 
 // DHTConfig describes DHT config parameters
 type DHTConfig struct {
-	On             bool   `json:"on" mapstructure:"on"`
-	Address        string `json:"address" mapstructure:"address"`
-	BootstrapPeers string `json:"addpeer" mapstructure:"addpeer"`
+	On             bool          `json:"on" mapstructure:"on"`
+	Address        string        `json:"address" mapstructure:"address"`
+	BootstrapPeers string        `json:"addpeer" mapstructure:"addpeer"`
+	DialAttempts   int           `json:"dialAttempts" mapstructure:"dialAttempts"`
+	DialBackoff    time.Duration `json:"dialBackoff" mapstructure:"dialBackoff"`
+
+	// MaxStreamsPerPeer is the maximum number of object streams a remote peer
+	// can have in-flight concurrently. Zero means no limit.
+	MaxStreamsPerPeer int `json:"maxStreamsPerPeer" mapstructure:"maxStreamsPerPeer"`
+
+	// ProviderPreference is the policy used to order the providers of an object
+	// before they are requested (none, latency, subnet or tracker).
+	ProviderPreference string `json:"providerPreference" mapstructure:"providerPreference"`
+
+	// PackDeltaWindow is the delta window used when packing objects served
+	// to peers. Larger windows trade CPU for smaller transfers. Zero disables
+	// delta compression.
+	PackDeltaWindow uint `json:"packDeltaWindow" mapstructure:"packDeltaWindow"`
+
+	// RepublishInterval is the duration between re-announcements of the
+	// objects provided by the node. A random jitter is subtracted from it
+	// so records are refreshed before they expire.
+	RepublishInterval time.Duration `json:"republishInterval" mapstructure:"republishInterval"`
+
+	// AnnounceRetryLimit is the number of times a failed announcement is
+	// queued to be attempted again. Zero disables retries.
+	AnnounceRetryLimit int `json:"announceRetryLimit" mapstructure:"announceRetryLimit"`
+
+	// AnnounceRetryBackoff is the delay before a failed announcement is
+	// attempted again. It is doubled after every failed retry.
+	AnnounceRetryBackoff time.Duration `json:"announceRetryBackoff" mapstructure:"announceRetryBackoff"`
+
+	// SignRequests enables signing of object requests with the node's validator
+	// key, allowing providers to identify the node when serving private repositories.
+	SignRequests bool `json:"signRequests" mapstructure:"signRequests"`
+
+	// AuthorizeRequests enables access checks on object requests for private
+	// repositories. Only owners, allowed push keys, validators and top hosts
+	// are served. Requesters must sign their requests (see SignRequests).
+	AuthorizeRequests bool `json:"authorizeRequests" mapstructure:"authorizeRequests"`
+
+	// MaxServeRate is the maximum number of bytes per second the node writes
+	// when serving objects to all peers. Zero means no limit.
+	MaxServeRate int64 `json:"maxServeRate" mapstructure:"maxServeRate"`
+
+	// MaxStreamServeRate is the maximum number of bytes per second the node
+	// writes when serving objects on a single stream. Zero means no limit.
+	MaxStreamServeRate int64 `json:"maxStreamServeRate" mapstructure:"maxStreamServeRate"`
+
+	// PrefetchDepth is the number of ancestor generations of a fetched commit
+	// that are speculatively fetched in the background. Zero disables prefetching.
+	PrefetchDepth int `json:"prefetchDepth" mapstructure:"prefetchDepth"`
+
+	// ShutdownDrainTimeout is how long the node waits for in-flight object
+	// streams to finish on shutdown. New streams are refused while waiting.
+	// Zero closes the streams immediately.
+	ShutdownDrainTimeout time.Duration `json:"shutdownDrainTimeout" mapstructure:"shutdownDrainTimeout"`
 }
 
 // RemoteConfig describes repository manager config parameters
@@ -266,6 +401,11 @@ func (c *AppConfig) KeystoreDir() string {
 	return c.keystoreDir
 }
 
+// GetAliasesPath returns the path of the file where address aliases are stored
+func (c *AppConfig) GetAliasesPath() string {
+	return filepath.Join(c.DataDir(), AliasesFileName)
+}
+
 // SetDataDir sets the application's data directory
 func (c *AppConfig) SetDataDir(d string) {
 	c.dataDir = d
@@ -332,6 +472,16 @@ func (c *AppConfig) GetDHTStoreDir() string {
 	return filepath.Join(c.GetDBRootDir(), "dht.db")
 }
 
+// GetUploadsDir returns the path where partially uploaded push requests are stored
+func (c *AppConfig) GetUploadsDir() string {
+	return filepath.Join(c.NetDataDir(), "uploads")
+}
+
+// GetSnapshotDir returns the path where state snapshots are stored
+func (c *AppConfig) GetSnapshotDir() string {
+	return filepath.Join(c.NetDataDir(), "snapshots")
+}
+
 // GetStateTreeDBDir returns the path where state's database files are stored
 func (c *AppConfig) GetStateTreeDBDir() string {
 	return filepath.Join(c.GetDBRootDir(), "appstate.db")
@@ -34,6 +34,7 @@ require (
 	github.com/gogo/protobuf v1.3.2
 	github.com/gohugoio/hugo v0.88.1
 	github.com/golang/mock v1.6.0
+	github.com/golang/snappy v0.0.3
 	github.com/google/go-cmp v0.5.6
 	github.com/gorilla/rpc v1.2.0
 	github.com/gorilla/websocket v1.4.2
@@ -117,7 +118,6 @@ require (
 	github.com/gobwas/glob v0.2.3 // indirect
 	github.com/godbus/dbus/v5 v5.0.4 // indirect
 	github.com/golang/protobuf v1.5.2 // indirect
-	github.com/golang/snappy v0.0.3 // indirect
 	github.com/google/btree v1.0.0 // indirect
 	github.com/google/gopacket v1.1.18 // indirect
 	github.com/google/uuid v1.1.2 // indirect
@@ -0,0 +1,140 @@
+package keystore
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/make-os/kit/crypto/ed25519"
+	"github.com/make-os/kit/util/errors"
+	"github.com/make-os/kit/util/identifier"
+)
+
+var (
+	ErrAliasUnknown = fmt.Errorf("alias not found")
+	ErrAliasExists  = fmt.Errorf("alias already exists")
+)
+
+// AliasStore is a local address book that maps human-readable
+// names to user addresses and push key addresses. Aliases are
+// persisted as a JSON object in a file.
+type AliasStore struct {
+	path string
+	lck  *sync.RWMutex
+}
+
+// NewAliasStore creates an instance of AliasStore.
+//  - path: The file where aliases are stored. It is created on first write.
+func NewAliasStore(path string) *AliasStore {
+	return &AliasStore{path: path, lck: &sync.RWMutex{}}
+}
+
+// read loads the aliases from the store file.
+// Returns an empty map if the file does not exist.
+func (s *AliasStore) read() (map[string]string, error) {
+	aliases := map[string]string{}
+	bz, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return aliases, nil
+		}
+		return nil, err
+	}
+	if err = json.Unmarshal(bz, &aliases); err != nil {
+		return nil, fmt.Errorf("malformed alias file: %s", err)
+	}
+	return aliases, nil
+}
+
+// write persists aliases to the store file
+func (s *AliasStore) write(aliases map[string]string) error {
+	bz, err := json.MarshalIndent(aliases, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(s.path, bz, 0600)
+}
+
+// Set adds an alias pointing to a user or push key address.
+//  - name: The alias. It must be a valid identifier and must not
+//    itself be a user or push key address or a key index.
+//  - target: The user or push key address the alias points to.
+//  - overwrite: When true, an existing alias with the same name is replaced;
+//    otherwise ErrAliasExists is returned.
+func (s *AliasStore) Set(name, target string, overwrite bool) error {
+	if err := identifier.IsValidResourceNameNoMinLen(name); err != nil {
+		return errors.FieldError("name", err.Error())
+	}
+	if isAddress(name) {
+		return errors.FieldError("name", "alias must not be an address")
+	}
+	if _, err := strconv.Atoi(name); err == nil {
+		return errors.FieldError("name", "alias must not be a number")
+	}
+	if !isAddress(target) {
+		return errors.FieldError("target", "target must be a user or push key address")
+	}
+
+	s.lck.Lock()
+	defer s.lck.Unlock()
+
+	aliases, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	if cur, ok := aliases[name]; ok && cur != target && !overwrite {
+		return ErrAliasExists
+	}
+
+	aliases[name] = target
+	return s.write(aliases)
+}
+
+// Get returns the address an alias points to.
+// Returns ErrAliasUnknown if the alias does not exist.
+//  - name: The alias.
+func (s *AliasStore) Get(name string) (string, error) {
+	s.lck.RLock()
+	defer s.lck.RUnlock()
+
+	aliases, err := s.read()
+	if err != nil {
+		return "", err
+	}
+
+	target, ok := aliases[name]
+	if !ok {
+		return "", ErrAliasUnknown
+	}
+
+	return target, nil
+}
+
+// List returns all aliases and their targets
+func (s *AliasStore) List() (map[string]string, error) {
+	s.lck.RLock()
+	defer s.lck.RUnlock()
+	return s.read()
+}
+
+// Resolve returns the target of nameOrAddr if it is a known alias.
+// Otherwise, nameOrAddr is returned unchanged.
+//  - nameOrAddr: An alias or an address.
+func (s *AliasStore) Resolve(nameOrAddr string) string {
+	if nameOrAddr == "" || isAddress(nameOrAddr) {
+		return nameOrAddr
+	}
+	if target, err := s.Get(nameOrAddr); err == nil {
+		return target
+	}
+	return nameOrAddr
+}
+
+// isAddress checks whether str is a user or push key address
+func isAddress(str string) bool {
+	return identifier.IsValidUserAddr(str) == nil || ed25519.IsValidPushAddr(str) == nil
+}
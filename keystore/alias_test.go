@@ -0,0 +1,116 @@
+package keystore
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/make-os/kit/crypto/ed25519"
+	"github.com/make-os/kit/util/errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AliasStore", func() {
+	var err error
+	var store *AliasStore
+	path := filepath.Join("./", "test_cfg")
+	key := ed25519.NewKeyFromIntSeed(1)
+	key2 := ed25519.NewKeyFromIntSeed(2)
+
+	BeforeEach(func() {
+		err = os.MkdirAll(path, 0700)
+		Expect(err).To(BeNil())
+		store = NewAliasStore(filepath.Join(path, "aliases.json"))
+	})
+
+	AfterEach(func() {
+		err = os.RemoveAll(path)
+		Expect(err).To(BeNil())
+	})
+
+	Describe(".Set", func() {
+		It("should return error when name is not a valid identifier", func() {
+			err = store.Set("bad name", key.Addr().String(), false)
+			Expect(err).To(MatchError(errors.FieldError("name", "invalid identifier; only alphanumeric, _, and - characters are allowed")))
+		})
+
+		It("should return error when name is an address", func() {
+			err = store.Set(key2.Addr().String(), key.Addr().String(), false)
+			Expect(err).To(MatchError(errors.FieldError("name", "alias must not be an address")))
+		})
+
+		It("should return error when name is a number", func() {
+			err = store.Set("1", key.Addr().String(), false)
+			Expect(err).To(MatchError(errors.FieldError("name", "alias must not be a number")))
+		})
+
+		It("should return error when target is not an address", func() {
+			err = store.Set("alice", "abc", false)
+			Expect(err).To(MatchError(errors.FieldError("target", "target must be a user or push key address")))
+		})
+
+		It("should return ErrAliasExists when name points to a different target", func() {
+			Expect(store.Set("alice", key.Addr().String(), false)).To(BeNil())
+			err = store.Set("alice", key2.Addr().String(), false)
+			Expect(err).To(Equal(ErrAliasExists))
+		})
+
+		It("should not return error when name is set to the same target again", func() {
+			Expect(store.Set("alice", key.Addr().String(), false)).To(BeNil())
+			Expect(store.Set("alice", key.Addr().String(), false)).To(BeNil())
+		})
+
+		It("should replace existing alias when overwrite is true", func() {
+			Expect(store.Set("alice", key.Addr().String(), false)).To(BeNil())
+			Expect(store.Set("alice", key2.Addr().String(), true)).To(BeNil())
+			target, err := store.Get("alice")
+			Expect(err).To(BeNil())
+			Expect(target).To(Equal(key2.Addr().String()))
+		})
+	})
+
+	Describe(".Get", func() {
+		It("should return ErrAliasUnknown when alias does not exist", func() {
+			_, err = store.Get("alice")
+			Expect(err).To(Equal(ErrAliasUnknown))
+		})
+
+		It("should return target of alias persisted by another store instance", func() {
+			Expect(store.Set("alice", key.PushAddr().String(), false)).To(BeNil())
+			target, err := NewAliasStore(filepath.Join(path, "aliases.json")).Get("alice")
+			Expect(err).To(BeNil())
+			Expect(target).To(Equal(key.PushAddr().String()))
+		})
+	})
+
+	Describe(".List", func() {
+		It("should return empty map when no alias exists", func() {
+			aliases, err := store.List()
+			Expect(err).To(BeNil())
+			Expect(aliases).To(BeEmpty())
+		})
+
+		It("should return all aliases", func() {
+			Expect(store.Set("alice", key.Addr().String(), false)).To(BeNil())
+			Expect(store.Set("bob", key2.PushAddr().String(), false)).To(BeNil())
+			aliases, err := store.List()
+			Expect(err).To(BeNil())
+			Expect(aliases).To(Equal(map[string]string{
+				"alice": key.Addr().String(),
+				"bob":   key2.PushAddr().String(),
+			}))
+		})
+	})
+
+	Describe(".Resolve", func() {
+		It("should return the target of a known alias", func() {
+			Expect(store.Set("alice", key.Addr().String(), false)).To(BeNil())
+			Expect(store.Resolve("alice")).To(Equal(key.Addr().String()))
+		})
+
+		It("should return the input when it is not a known alias", func() {
+			Expect(store.Resolve("unknown")).To(Equal("unknown"))
+			Expect(store.Resolve(key.Addr().String())).To(Equal(key.Addr().String()))
+		})
+	})
+})
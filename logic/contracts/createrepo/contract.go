@@ -5,12 +5,14 @@ import (
 	"github.com/make-os/kit/crypto/ed25519"
 	"github.com/make-os/kit/logic/contracts/common"
 	"github.com/make-os/kit/logic/contracts/registerpushkey"
+	"github.com/make-os/kit/params"
 	"github.com/make-os/kit/remote/policy"
 	"github.com/make-os/kit/types"
 	"github.com/make-os/kit/types/core"
 	"github.com/make-os/kit/types/state"
 	"github.com/make-os/kit/types/txns"
 	"github.com/make-os/kit/util"
+	"github.com/make-os/kit/util/identifier"
 )
 
 // Contract implements core.SystemContract. It is a system contract for creating a repository.
@@ -58,8 +60,15 @@ func (c *Contract) Exec() error {
 		policy.AddDefaultPolicies(newRepo.Config)
 	}
 
-	// Add transaction value to repo balance
-	if !c.tx.Value.IsZero() {
+	// Send the transaction value to the treasury if repository creation
+	// has a price; otherwise, add it to the repo balance
+	if params.RepoCreateFee.IsPositive() {
+		treasuryAcct := c.AccountKeeper().Get(identifier.Address(params.TreasuryAddress), c.chainHeight)
+		treasuryBal := treasuryAcct.Balance.Decimal()
+		treasuryAcct.Balance = util.String(treasuryBal.Add(c.tx.Value.Decimal()).String())
+		treasuryAcct.Clean(c.chainHeight)
+		c.AccountKeeper().Update(identifier.Address(params.TreasuryAddress), treasuryAcct)
+	} else if !c.tx.Value.IsZero() {
 		newRepoBal := newRepo.Balance.Decimal().Add(c.tx.Value.Decimal())
 		newRepo.Balance = util.String(newRepoBal.String())
 	}
@@ -10,14 +10,17 @@ import (
 	"github.com/make-os/kit/crypto/ed25519"
 	logic2 "github.com/make-os/kit/logic"
 	"github.com/make-os/kit/logic/contracts/createrepo"
+	"github.com/make-os/kit/params"
 	"github.com/make-os/kit/remote/policy"
 	storagetypes "github.com/make-os/kit/storage/types"
 	"github.com/make-os/kit/testutil"
 	"github.com/make-os/kit/types/state"
 	"github.com/make-os/kit/types/txns"
 	"github.com/make-os/kit/util"
+	"github.com/make-os/kit/util/identifier"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/shopspring/decimal"
 	tmdb "github.com/tendermint/tm-db"
 )
 
@@ -208,6 +211,30 @@ var _ = Describe("CreateRepoContract", func() {
 			})
 		})
 
+		When("repo creation has a price", func() {
+			BeforeEach(func() {
+				params.RepoCreateFee = decimal.NewFromFloat(4)
+				err = createrepo.NewContract().Init(logic, tx, 0).Exec()
+				Expect(err).To(BeNil())
+			})
+
+			AfterEach(func() {
+				params.RepoCreateFee = decimal.Zero
+			})
+
+			Specify("that the tx value is paid to the treasury", func() {
+				treasury := logic.AccountKeeper().Get(identifier.Address(params.TreasuryAddress))
+				Expect(treasury.Balance).To(Equal(util.String("4")))
+				repo := logic.RepoKeeper().Get("repo")
+				Expect(repo.Balance).To(Equal(util.String("0")))
+			})
+
+			Specify("that fee + value is deducted from sender account", func() {
+				acct := logic.AccountKeeper().Get(sender.Addr())
+				Expect(acct.GetBalance()).To(Equal(util.String("4.5")))
+			})
+		})
+
 		When("governance.CreatorAsContributor is false", func() {
 			BeforeEach(func() {
 				repoCfg.Gov.CreatorAsContributor = pointer.ToBool(false)
@@ -53,10 +53,11 @@ func (c *Contract) execReference(repo *state.Repository, repoName string, ref *p
 		return nil
 	}
 
-	// Set pusher as creator if reference is new
+	// Set pusher as creator and record the creation height if reference is new
 	isNewRef := r.IsNil()
 	if isNewRef {
 		r.Creator = c.tx.Note.GetPusherKeyID()
+		r.CreatedAt = util.UInt64(c.chainHeight + 1)
 	}
 
 	// Set issue data for issue reference
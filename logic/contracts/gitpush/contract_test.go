@@ -100,6 +100,17 @@ var _ = Describe("Contract", func() {
 				rep := logic.RepoKeeper().Get(repo)
 				Expect(rep.References.Get("refs/heads/dev").Creator).To(Equal(ed25519.PushKey(rawPkID)))
 			})
+
+			It("should record the block height at which the reference was created", func() {
+				refs = []*types.PushedReference{{Name: "refs/heads/dev", Fee: "1"}}
+				err = gitpush.NewContract().Init(logic, &txns.TxPush{
+					TxCommon: &txns.TxCommon{SenderPubKey: sender.PubKey().ToPublicKey()},
+					Note:     &types.Note{RepoName: repo, References: refs, PushKeyID: rawPkID},
+				}, 9).Exec()
+				Expect(err).To(BeNil())
+				rep := logic.RepoKeeper().Get(repo)
+				Expect(rep.References.Get("refs/heads/dev").CreatedAt).To(Equal(util.UInt64(10)))
+			})
 		})
 
 		When("pushed reference new hash is zero (meaning delete is required)", func() {
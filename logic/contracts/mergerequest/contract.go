@@ -85,7 +85,7 @@ func (c *Contract) Exec() error {
 
 	// Create new proposal if it does not exist already
 	if proposal == nil {
-		proposal = proposals.MakeProposal(c.data.CreatorAddress.String(), c.data.Repo, id, c.data.ProposerFee, c.chainHeight)
+		proposal = proposals.MakeProposal(c.Keepers, c.data.CreatorAddress.String(), c.data.Repo, id, c.data.ProposerFee, c.chainHeight)
 		proposal.Action = txns.TxTypeMergeRequestProposalAction
 		proposal.ActionData = map[string]util.Bytes{
 			constants.ActionDataKeyBaseBranch:   []byte(c.data.BaseBranch),
@@ -49,7 +49,7 @@ func (c *Contract) Exec() error {
 
 	// Create a proposal
 	spk, _ := ed25519.PubKeyFromBytes(c.tx.SenderPubKey.Bytes())
-	proposal := proposals.MakeProposal(spk.Addr().String(), repo, c.tx.ID, c.tx.Value, c.chainHeight)
+	proposal := proposals.MakeProposal(c.Keepers, spk.Addr().String(), repo, c.tx.ID, c.tx.Value, c.chainHeight)
 	proposal.Action = txns.TxTypeRepoProposalRegisterPushKey
 	proposal.ActionData = map[string]util.Bytes{
 		constants.ActionDataKeyIDs:      util.ToBytes(c.tx.PushKeys),
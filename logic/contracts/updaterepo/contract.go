@@ -48,7 +48,7 @@ func (c *Contract) Exec() error {
 
 	// Create a proposal
 	spk, _ := ed25519.PubKeyFromBytes(c.tx.SenderPubKey.Bytes())
-	proposal := proposals.MakeProposal(spk.Addr().String(), repo, c.tx.ID, c.tx.Value, c.chainHeight)
+	proposal := proposals.MakeProposal(c.Keepers, spk.Addr().String(), repo, c.tx.ID, c.tx.Value, c.chainHeight)
 	proposal.Action = txns.TxTypeRepoProposalUpdate
 	if !c.tx.Config.IsEmpty() {
 		proposal.ActionData[constants.ActionDataKeyCFG] = util.ToBytes(c.tx.Config)
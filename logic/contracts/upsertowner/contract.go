@@ -48,7 +48,7 @@ func (c *Contract) Exec() error {
 
 	// Create a proposal
 	spk, _ := ed25519.PubKeyFromBytes(c.tx.SenderPubKey.Bytes())
-	proposal := common2.MakeProposal(spk.Addr().String(), repo, c.tx.ID, c.tx.Value, c.chainHeight)
+	proposal := common2.MakeProposal(c.Keepers, spk.Addr().String(), repo, c.tx.ID, c.tx.Value, c.chainHeight)
 	proposal.Action = txns.TxTypeRepoProposalUpsertOwner
 	proposal.ActionData = map[string]util.Bytes{
 		constants.ActionDataKeyAddrs: util.ToBytes(c.tx.Addresses),
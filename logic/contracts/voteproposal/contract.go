@@ -7,6 +7,8 @@ import (
 	"github.com/make-os/kit/types/core"
 	"github.com/make-os/kit/types/state"
 	"github.com/make-os/kit/types/txns"
+	"github.com/make-os/kit/util"
+	"github.com/make-os/kit/util/identifier"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 )
@@ -39,7 +41,6 @@ func (c *Contract) Init(keepers core.Keepers, tx types.BaseTx, curChainHeight ui
 // Exec executes the contract
 func (c *Contract) Exec() error {
 
-	var err error
 	spk, _ := ed25519.PubKeyFromBytes(c.tx.SenderPubKey.Bytes())
 
 	// Get the repo
@@ -47,13 +48,88 @@ func (c *Contract) Exec() error {
 	repo := repoKeeper.Get(c.tx.RepoName)
 	prop := repo.Proposals.Get(c.tx.ProposalID)
 
-	increments := float64(0)
+	// Determine the voter's voting power. For net-stake tallies, tickets
+	// delegated by the voter whose proposer already voted are moved from
+	// the proposer's vote to the voter's vote.
+	increments, err := GetVotingPower(c.Keepers, c.tx.RepoName, c.tx.ProposalID, prop,
+		spk.Addr(), c.tx.SenderPubKey.ToBytes32(), c.chainHeight,
+		func(vote int, value decimal.Decimal) {
+			switch vote {
+			case state.ProposalVoteYes:
+				newYes := decimal.NewFromFloat(prop.Yes)
+				newYes = newYes.Sub(value)
+				prop.Yes, _ = newYes.Float64()
+
+			case state.ProposalVoteNo:
+				newNo := decimal.NewFromFloat(prop.No)
+				newNo = newNo.Sub(value)
+				prop.Yes, _ = newNo.Float64()
+
+			case state.ProposalVoteNoWithVeto:
+				newNoWithVeto := decimal.NewFromFloat(prop.NoWithVeto)
+				newNoWithVeto = newNoWithVeto.Sub(value)
+				prop.NoWithVeto, _ = newNoWithVeto.Float64()
+
+			case state.ProposalVoteAbstain:
+				newAbstain := decimal.NewFromFloat(prop.Abstain)
+				newAbstain = newAbstain.Sub(value)
+				prop.Abstain, _ = newAbstain.Float64()
+			}
+		})
+	if err != nil {
+		return err
+	}
+
+	switch c.tx.Vote {
+	case state.ProposalVoteYes:
+		prop.Yes += increments
+	case state.ProposalVoteNo:
+		prop.No += increments
+	case state.ProposalVoteAbstain:
+		prop.Abstain += increments
+	case state.ProposalVoteNoWithVeto:
+		prop.NoWithVeto += increments
+
+		// Also, if the proposer type for the proposal is stakeholders and veto
+		// owners and voter is an owner, increment NoWithVetoByOwners by 1
+		voterOwnerObj := repo.Owners.Get(spk.Addr().String())
+		isStakeholderAndVetoOwnerProposer := *prop.Config.Voter == *state.VoterNetStakersAndVetoOwner.Ptr()
+		if isStakeholderAndVetoOwnerProposer && voterOwnerObj != nil && voterOwnerObj.Veto {
+			prop.NoWithVetoByOwners = 1
+		}
+	}
+
+	// Update the repo
+	repoKeeper.Update(c.tx.RepoName, repo)
+
+	// Deduct fee from sender
+	common.DebitAccount(c, spk, c.tx.Fee.Decimal(), c.chainHeight)
+
+	return nil
+}
+
+// GetVotingPower returns the voting power of a voter on a proposal
+// according to the proposal's tally method. The voter's public key is
+// only required by net-stake tally methods.
+//
+// For net-stake tallies, onProposerVoted (if set) is called with the vote
+// and value of each ticket delegated by the voter whose proposer has
+// already voted on the proposal.
+func GetVotingPower(
+	keepers core.Keepers,
+	repoName, propID string,
+	prop *state.RepoProposal,
+	voterAddr identifier.Address,
+	voterPubKey util.Bytes32,
+	chainHeight uint64,
+	onProposerVoted func(vote int, value decimal.Decimal),
+) (power float64, err error) {
 
 	// When proposers are the owners, and tally method is ProposalTallyMethodIdentity
 	// each proposer will have 1 voting power.
 	if *prop.Config.Voter == *state.VoterOwner.Ptr() &&
 		*prop.Config.PropTallyMethod == *state.ProposalTallyMethodIdentity.Ptr() {
-		increments = 1
+		power = 1
 	}
 
 	// When proposers are the owners, and tally method is ProposalTallyMethodCoinWeighted
@@ -61,27 +137,27 @@ func (c *Contract) Exec() error {
 	// as their voting power.
 	if *prop.Config.Voter == *state.VoterOwner.Ptr() &&
 		*prop.Config.PropTallyMethod == *state.ProposalTallyMethodCoinWeighted.Ptr() {
-		senderAcct := c.AccountKeeper().Get(spk.Addr())
-		increments = senderAcct.GetAvailableBalance(c.chainHeight).Float()
+		senderAcct := keepers.AccountKeeper().Get(voterAddr)
+		power = senderAcct.GetAvailableBalance(chainHeight).Float()
 	}
 
 	// For network staked-weighted votes, use the total value of coins directly
 	// staked by the voter as their vote power
 	if *prop.Config.PropTallyMethod == *state.ProposalTallyMethodNetStakeNonDelegated.Ptr() {
-		increments, err = c.GetTicketManager().
-			ValueOfNonDelegatedTickets(c.tx.SenderPubKey.ToBytes32(), prop.PowerAge.UInt64())
+		power, err = keepers.GetTicketManager().
+			ValueOfNonDelegatedTickets(voterPubKey, prop.PowerAge.UInt64())
 		if err != nil {
-			return errors.Wrap(err, "failed to get value of non-delegated tickets of sender")
+			return 0, errors.Wrap(err, "failed to get value of non-delegated tickets of sender")
 		}
 	}
 
 	// For network staked-weighted votes, use the total value of coins delegated
 	// to the voter as their vote power
 	if *prop.Config.PropTallyMethod == *state.ProposalTallyMethodNetStakeOfDelegators.Ptr() {
-		increments, err = c.GetTicketManager().
-			ValueOfDelegatedTickets(c.tx.SenderPubKey.ToBytes32(), prop.PowerAge.UInt64())
+		power, err = keepers.GetTicketManager().
+			ValueOfDelegatedTickets(voterPubKey, prop.PowerAge.UInt64())
 		if err != nil {
-			return errors.Wrap(err, "failed to get value of delegated tickets of sender")
+			return 0, errors.Wrap(err, "failed to get value of delegated tickets of sender")
 		}
 	}
 
@@ -89,10 +165,10 @@ func (c *Contract) Exec() error {
 	// to the voter as their vote power
 	if *prop.Config.PropTallyMethod == *state.ProposalTallyMethodNetStake.Ptr() {
 
-		tickets, err := c.GetTicketManager().GetUnExpiredTickets(c.tx.SenderPubKey.ToBytes32(),
+		tickets, err := keepers.GetTicketManager().GetUnExpiredTickets(voterPubKey,
 			prop.PowerAge.UInt64())
 		if err != nil {
-			return errors.Wrap(err, "failed to get unexpired tickets assigned to sender")
+			return 0, errors.Wrap(err, "failed to get unexpired tickets assigned to sender")
 		}
 
 		// Calculate the sum of value of all tickets.
@@ -104,8 +180,8 @@ func (c *Contract) Exec() error {
 			proposerPK := ticket.ProposerPubKey
 
 			// Count the ticket if it is not delegated or the delegator is also the voter
-			if ticket.Delegator == "" || (ticket.Delegator == spk.Addr().String() &&
-				proposerPK.Equal(c.tx.SenderPubKey.ToBytes32())) {
+			if ticket.Delegator == "" || (ticket.Delegator == voterAddr.String() &&
+				proposerPK.Equal(voterPubKey)) {
 				sumValue = sumValue.Add(ticket.Value.Decimal())
 				continue
 			}
@@ -113,10 +189,10 @@ func (c *Contract) Exec() error {
 			// For tickets not delegated by the voter, determine whether the
 			// delegator has used their ticket to vote on this same proposal.
 			// If yes, we will not count it.
-			if ticket.Delegator != spk.Addr().String() {
-				_, voted, err := repoKeeper.GetProposalVote(c.tx.RepoName, c.tx.ProposalID, ticket.Delegator)
+			if ticket.Delegator != voterAddr.String() {
+				_, voted, err := keepers.RepoKeeper().GetProposalVote(repoName, propID, ticket.Delegator)
 				if err != nil {
-					return errors.Wrap(err, "failed to check ticket's delegator vote status")
+					return 0, errors.Wrap(err, "failed to check ticket's delegator vote status")
 				}
 				if !voted {
 					sumValue = sumValue.Add(ticket.Value.Decimal())
@@ -127,70 +203,21 @@ func (c *Contract) Exec() error {
 			// For tickets delegated by the voter to a different user,
 			// determine if ticket proposer has voted in this same proposal.
 			// If yes, deduct the vote and apply to the delegator's choice vote option
-			if ticket.Delegator == spk.Addr().String() {
+			if ticket.Delegator == voterAddr.String() {
 				proposerAddr := ed25519.MustPubKeyFromBytes(proposerPK.Bytes()).Addr().String()
-				vote, voted, err := repoKeeper.GetProposalVote(c.tx.RepoName, c.tx.ProposalID, proposerAddr)
+				vote, voted, err := keepers.RepoKeeper().GetProposalVote(repoName, propID, proposerAddr)
 				if err != nil {
-					return errors.Wrap(err, "failed to check ticket's proposer vote status")
+					return 0, errors.Wrap(err, "failed to check ticket's proposer vote status")
 				}
-				if !voted {
-					sumValue = sumValue.Add(ticket.Value.Decimal())
-					continue
-				}
-
-				switch vote {
-				case state.ProposalVoteYes:
-					newYes := decimal.NewFromFloat(prop.Yes)
-					newYes = newYes.Sub(ticket.Value.Decimal())
-					prop.Yes, _ = newYes.Float64()
-
-				case state.ProposalVoteNo:
-					newNo := decimal.NewFromFloat(prop.No)
-					newNo = newNo.Sub(ticket.Value.Decimal())
-					prop.Yes, _ = newNo.Float64()
-
-				case state.ProposalVoteNoWithVeto:
-					newNoWithVeto := decimal.NewFromFloat(prop.NoWithVeto)
-					newNoWithVeto = newNoWithVeto.Sub(ticket.Value.Decimal())
-					prop.NoWithVeto, _ = newNoWithVeto.Float64()
-
-				case state.ProposalVoteAbstain:
-					newAbstain := decimal.NewFromFloat(prop.Abstain)
-					newAbstain = newAbstain.Sub(ticket.Value.Decimal())
-					prop.Abstain, _ = newAbstain.Float64()
+				if voted && onProposerVoted != nil {
+					onProposerVoted(vote, ticket.Value.Decimal())
 				}
-
 				sumValue = sumValue.Add(ticket.Value.Decimal())
 			}
 		}
 
-		increments, _ = sumValue.Float64()
+		power, _ = sumValue.Float64()
 	}
 
-	switch c.tx.Vote {
-	case state.ProposalVoteYes:
-		prop.Yes += increments
-	case state.ProposalVoteNo:
-		prop.No += increments
-	case state.ProposalVoteAbstain:
-		prop.Abstain += increments
-	case state.ProposalVoteNoWithVeto:
-		prop.NoWithVeto += increments
-
-		// Also, if the proposer type for the proposal is stakeholders and veto
-		// owners and voter is an owner, increment NoWithVetoByOwners by 1
-		voterOwnerObj := repo.Owners.Get(spk.Addr().String())
-		isStakeholderAndVetoOwnerProposer := *prop.Config.Voter == *state.VoterNetStakersAndVetoOwner.Ptr()
-		if isStakeholderAndVetoOwnerProposer && voterOwnerObj != nil && voterOwnerObj.Veto {
-			prop.NoWithVetoByOwners = 1
-		}
-	}
-
-	// Update the repo
-	repoKeeper.Update(c.tx.RepoName, repo)
-
-	// Deduct fee from sender
-	common.DebitAccount(c, spk, c.tx.Fee.Decimal(), c.chainHeight)
-
-	return nil
+	return power, nil
 }
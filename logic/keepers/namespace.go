@@ -4,20 +4,24 @@ import (
 	"fmt"
 
 	"github.com/make-os/kit/pkgs/tree"
+	"github.com/make-os/kit/storage/common"
+	storagetypes "github.com/make-os/kit/storage/types"
 	"github.com/make-os/kit/types/state"
 	"github.com/make-os/kit/util"
 	"github.com/make-os/kit/util/crypto"
+	"github.com/make-os/kit/util/identifier"
 	"github.com/pkg/errors"
 )
 
 // NamespaceKeeper manages namespaces.
 type NamespaceKeeper struct {
 	state *tree.SafeTree
+	db    storagetypes.Tx
 }
 
 // NewNamespaceKeeper creates an instance of NamespaceKeeper
-func NewNamespaceKeeper(state *tree.SafeTree) *NamespaceKeeper {
-	return &NamespaceKeeper{state: state}
+func NewNamespaceKeeper(state *tree.SafeTree, db storagetypes.Tx) *NamespaceKeeper {
+	return &NamespaceKeeper{state: state, db: db}
 }
 
 // Get finds a namespace by name.
@@ -89,9 +93,43 @@ func (a *NamespaceKeeper) GetTarget(path string, blockNum ...uint64) (string, er
 }
 
 // Update sets a new object at the given name.
+// It also updates the repo->namespace index of domains targeting repositories.
 //  ARGS:
 //  - name: The name of the namespace to update
 //  - udp: The updated namespace object to replace the existing object.
 func (a *NamespaceKeeper) Update(name string, upd *state.Namespace) {
+
+	// Remove index entries of domains of the current namespace
+	// that no longer target the same repository.
+	for domain, target := range a.Get(name).Domains {
+		if identifier.IsWholeNativeRepoURI(target) && upd.Domains.Get(domain) != target {
+			_ = a.db.Del(MakeRepoNamespaceIndexKey(identifier.GetDomain(target), name, domain))
+		}
+	}
+
 	a.state.Set(MakeNamespaceKey(name), upd.Bytes())
+
+	// Index domains that target repositories
+	for domain, target := range upd.Domains {
+		if identifier.IsWholeNativeRepoURI(target) {
+			key := MakeRepoNamespaceIndexKey(identifier.GetDomain(target), name, domain)
+			_ = a.db.Put(common.NewFromKeyValue(key, []byte{}))
+		}
+	}
+}
+
+// GetRepoNamespaces returns the namespace domains that target a repo
+//  ARGS:
+//  - repo: The name of the repository
+func (a *NamespaceKeeper) GetRepoNamespaces(repo string) []*state.RepoNamespace {
+	var res []*state.RepoNamespace
+	a.db.NewTx(true, true).Iterate(MakeQueryRepoNamespacesKey(repo), true, func(rec *common.Record) bool {
+		parts := common.SplitPrefix(rec.Key)
+		res = append(res, &state.RepoNamespace{
+			Namespace: string(parts[len(parts)-2]),
+			Domain:    string(parts[len(parts)-1]),
+		})
+		return false
+	})
+	return res
 }
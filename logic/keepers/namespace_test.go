@@ -1,7 +1,12 @@
 package keepers
 
 import (
+	"os"
+
+	"github.com/make-os/kit/config"
 	"github.com/make-os/kit/pkgs/tree"
+	storagetypes "github.com/make-os/kit/storage/types"
+	"github.com/make-os/kit/testutil"
 	state2 "github.com/make-os/kit/types/state"
 	"github.com/make-os/kit/util/crypto"
 	. "github.com/onsi/ginkgo"
@@ -11,13 +16,24 @@ import (
 
 var _ = Describe("NamespaceKeeper", func() {
 	var state *tree.SafeTree
+	var appDB storagetypes.Engine
+	var cfg *config.AppConfig
+	var err error
 	var nsKp *NamespaceKeeper
 
 	BeforeEach(func() {
-		var err error
+		cfg, err = testutil.SetTestCfg()
+		Expect(err).To(BeNil())
+		appDB, _ = testutil.GetDB()
 		state, err = tree.NewSafeTree(tmdb.NewMemDB(), 128)
 		Expect(err).To(BeNil())
-		nsKp = NewNamespaceKeeper(state)
+		nsKp = NewNamespaceKeeper(state, appDB.NewTx(true, true))
+	})
+
+	AfterEach(func() {
+		Expect(appDB.Close()).To(BeNil())
+		err = os.RemoveAll(cfg.DataDir())
+		Expect(err).To(BeNil())
 	})
 
 	Describe(".Get", func() {
@@ -60,6 +76,35 @@ var _ = Describe("NamespaceKeeper", func() {
 		})
 	})
 
+	Describe(".GetRepoNamespaces", func() {
+		It("should return empty result when no namespace domain targets the repo", func() {
+			Expect(nsKp.GetRepoNamespaces("repo1")).To(BeEmpty())
+		})
+
+		It("should return namespace domains that target the repo", func() {
+			nsKp.Update("ns1", &state2.Namespace{Domains: map[string]string{"dom1": "r/repo1", "dom2": "r/repo2", "dom3": "a/os1abc"}})
+			nsKp.Update("ns2", &state2.Namespace{Domains: map[string]string{"dom1": "r/repo1"}})
+			res := nsKp.GetRepoNamespaces("repo1")
+			Expect(res).To(HaveLen(2))
+			Expect(res).To(ContainElement(&state2.RepoNamespace{Namespace: "ns1", Domain: "dom1"}))
+			Expect(res).To(ContainElement(&state2.RepoNamespace{Namespace: "ns2", Domain: "dom1"}))
+			Expect(nsKp.GetRepoNamespaces("repo2")).To(Equal([]*state2.RepoNamespace{{Namespace: "ns1", Domain: "dom2"}}))
+		})
+
+		It("should not match repos whose names share a prefix with the repo", func() {
+			nsKp.Update("ns1", &state2.Namespace{Domains: map[string]string{"dom1": "r/repo12"}})
+			Expect(nsKp.GetRepoNamespaces("repo1")).To(BeEmpty())
+		})
+
+		It("should remove index of domains that no longer target the repo", func() {
+			nsKp.Update("ns1", &state2.Namespace{Domains: map[string]string{"dom1": "r/repo1", "dom2": "r/repo1"}})
+			Expect(nsKp.GetRepoNamespaces("repo1")).To(HaveLen(2))
+			nsKp.Update("ns1", &state2.Namespace{Domains: map[string]string{"dom1": "r/repo2"}})
+			Expect(nsKp.GetRepoNamespaces("repo1")).To(BeEmpty())
+			Expect(nsKp.GetRepoNamespaces("repo2")).To(Equal([]*state2.RepoNamespace{{Namespace: "ns1", Domain: "dom1"}}))
+		})
+	})
+
 	Describe(".GetTarget", func() {
 		When("path is not valid", func() {
 			It("should return err", func() {
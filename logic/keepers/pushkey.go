@@ -70,11 +70,15 @@ func (g *PushKeyKeeper) Update(pushKeyID string, upd *state.PushKey) error {
 	return g.db.Put(idx)
 }
 
-// Remove removes a push key by its id
+// Remove removes a push key by its id.
+// It also removes the address->pubID index of the push key.
 //
 // ARGS:
 // pushKeyID: The public key unique ID
 func (g *PushKeyKeeper) Remove(pushKeyID string) bool {
+	if pk := g.Get(pushKeyID); !pk.IsNil() {
+		_ = g.db.Del(MakeAddrPushKeyIDIndexKey(pk.Address.String(), pushKeyID))
+	}
 	key := MakePushKeyKey(pushKeyID)
 	return g.state.Remove(key)
 }
@@ -92,3 +96,46 @@ func (g *PushKeyKeeper) GetByAddress(address string) []string {
 	})
 	return pushKeyIDs
 }
+
+// Reindex rebuilds the address->pubID index from the push keys in the state tree.
+// Existing index entries are removed before the index is rebuilt.
+//
+// ARGS:
+// progress: Called with the number of push keys indexed so far (optional)
+func (g *PushKeyKeeper) Reindex(progress func(n int)) (int, error) {
+
+	// Remove existing index entries
+	var stale [][]byte
+	g.db.NewTx(true, true).Iterate(common.MakePrefix([]byte(TagAddressPushKeyID), []byte{}), true, func(rec *common.Record) bool {
+		stale = append(stale, rec.GetKey())
+		return false
+	})
+	for _, key := range stale {
+		if err := g.db.Del(key); err != nil {
+			return 0, errors.Wrap(err, "failed to remove index entry")
+		}
+	}
+
+	// Index every push key found in the state tree
+	var n int
+	var err error
+	prefix := common.MakePrefix([]byte(TagPushKey), []byte{})
+	g.state.IteratePrefix(prefix, func(key, value []byte) bool {
+		var pushKey *state.PushKey
+		if pushKey, err = state.NewPushKeyFromBytes(value); err != nil {
+			err = errors.Wrap(err, "failed to decode")
+			return true
+		}
+		idx := common.NewFromKeyValue(MakeAddrPushKeyIDIndexKey(pushKey.Address.String(), string(key[len(prefix):])), []byte{})
+		if err = g.db.Put(idx); err != nil {
+			return true
+		}
+		n++
+		if progress != nil {
+			progress(n)
+		}
+		return false
+	})
+
+	return n, err
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 
 	"github.com/make-os/kit/crypto/ed25519"
+	"github.com/make-os/kit/storage/common"
 	storagetypes "github.com/make-os/kit/storage/types"
 	state2 "github.com/make-os/kit/types/state"
 
@@ -90,6 +91,12 @@ var _ = Describe("PushKeyKeeper", func() {
 			Expect(removed).To(BeTrue())
 			Expect(pushKeyKeeper.Get("pk_id").IsNil()).To(BeTrue())
 		})
+
+		It("should remove the address->pk id index", func() {
+			Expect(pushKeyKeeper.GetByAddress("addr")).To(ConsistOf("pk_id"))
+			pushKeyKeeper.Remove("pk_id")
+			Expect(pushKeyKeeper.GetByAddress("addr")).To(BeEmpty())
+		})
 	})
 
 	Describe(".GetByAddress", func() {
@@ -106,4 +113,33 @@ var _ = Describe("PushKeyKeeper", func() {
 			Expect(pushKeyIDs).To(ConsistOf("pk_id", "pk_id2"))
 		})
 	})
+
+	Describe(".Reindex", func() {
+		BeforeEach(func() {
+			err = pushKeyKeeper.Update("pk_id", &state2.PushKey{PubKey: ed25519.StrToPublicKey("pub_key"), Address: "addr"})
+			Expect(err).To(BeNil())
+			err = pushKeyKeeper.Update("pk_id2", &state2.PushKey{PubKey: ed25519.StrToPublicKey("pub_key"), Address: "other_addr"})
+			Expect(err).To(BeNil())
+		})
+
+		It("should rebuild a cleared index", func() {
+			Expect(pushKeyKeeper.db.Del(MakeAddrPushKeyIDIndexKey("addr", "pk_id"))).To(BeNil())
+			Expect(pushKeyKeeper.GetByAddress("addr")).To(BeEmpty())
+
+			var progress []int
+			n, err := pushKeyKeeper.Reindex(func(n int) { progress = append(progress, n) })
+			Expect(err).To(BeNil())
+			Expect(n).To(Equal(2))
+			Expect(progress).To(Equal([]int{1, 2}))
+			Expect(pushKeyKeeper.GetByAddress("addr")).To(ConsistOf("pk_id"))
+			Expect(pushKeyKeeper.GetByAddress("other_addr")).To(ConsistOf("pk_id2"))
+		})
+
+		It("should remove index entries of push keys that do not exist", func() {
+			Expect(pushKeyKeeper.db.Put(common.NewFromKeyValue(MakeAddrPushKeyIDIndexKey("addr", "pk_id3"), []byte{}))).To(BeNil())
+			_, err := pushKeyKeeper.Reindex(nil)
+			Expect(err).To(BeNil())
+			Expect(pushKeyKeeper.GetByAddress("addr")).To(ConsistOf("pk_id"))
+		})
+	})
 })
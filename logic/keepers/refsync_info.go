@@ -43,7 +43,7 @@ func (t *RepoSyncInfoKeeper) Track(targets string, height ...uint64) error {
 		if identifier.IsUserNamespaceURI(target) {
 			nsName := identifier.GetNamespace(target)
 			nsDomain := identifier.GetDomain(target)
-			ns := NewNamespaceKeeper(t.state).Get(crypto.MakeNamespaceHash(nsName))
+			ns := NewNamespaceKeeper(t.state, t.db).Get(crypto.MakeNamespaceHash(nsName))
 			if ns.IsNil() {
 				return fmt.Errorf("namespace (%s) not found", nsName)
 			}
@@ -125,7 +125,7 @@ func (t *RepoSyncInfoKeeper) UnTrack(targets string) error {
 		if identifier.IsUserNamespaceURI(target) {
 			nsName := identifier.GetNamespace(target)
 			nsDomain := identifier.GetDomain(target)
-			ns := NewNamespaceKeeper(t.state).Get(crypto.MakeNamespaceHash(nsName))
+			ns := NewNamespaceKeeper(t.state, t.db).Get(crypto.MakeNamespaceHash(nsName))
 			if ns.IsNil() {
 				return fmt.Errorf("namespace (%s) not found", nsName)
 			}
@@ -178,3 +178,47 @@ func (t *RepoSyncInfoKeeper) GetRefLastSyncHeight(repo, ref string) (uint64, err
 	}
 	return util.DecodeNumber(rec.Value), nil
 }
+
+// UpdateBranchActivity sets the tip and last activity time of a repo's branch.
+func (t *RepoSyncInfoKeeper) UpdateBranchActivity(repo, branch string, activity *core.BranchActivity) error {
+	rec := common.NewFromKeyValue(MakeRepoBranchActivityKey(repo, branch), util.ToBytes(activity))
+	return t.db.Put(rec)
+}
+
+// RemoveBranchActivity removes the activity info of a repo's branch.
+func (t *RepoSyncInfoKeeper) RemoveBranchActivity(repo, branch string) error {
+	return t.db.Del(MakeRepoBranchActivityKey(repo, branch))
+}
+
+// ClearBranchActivities removes the activity info of all branches of a repo.
+func (t *RepoSyncInfoKeeper) ClearBranchActivities(repo string) error {
+	var keys [][]byte
+	t.db.NewTx(true, true).Iterate(MakeQueryRepoBranchActivityKey(repo), false, func(r *common.Record) bool {
+		keys = append(keys, r.GetKey())
+		return false
+	})
+	for _, key := range keys {
+		if err := t.db.Del(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetBranchActivities returns the activity info of all indexed branches of a repo.
+func (t *RepoSyncInfoKeeper) GetBranchActivities(repo string) (map[string]*core.BranchActivity, error) {
+	var err error
+	res := make(map[string]*core.BranchActivity)
+	t.db.NewTx(true, true).Iterate(MakeQueryRepoBranchActivityKey(repo), false, func(r *common.Record) bool {
+		var ba core.BranchActivity
+		if err = r.Scan(&ba); err != nil {
+			return true
+		}
+		res[string(common.SplitPrefix(r.GetKey())[2])] = &ba
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -46,7 +46,7 @@ var _ = Describe("Tracklist", func() {
 
 	Describe(".Track", func() {
 		It("should add all repository targets if argument is a namespace with no domain", func() {
-			nsKeeper := NewNamespaceKeeper(state)
+			nsKeeper := NewNamespaceKeeper(state, keeper.db)
 			nsKeeper.Update(crypto.MakeNamespaceHash("ns1"), &state2.Namespace{Domains: map[string]string{
 				"domain1": "r/abc",
 				"domain2": "r/xyz",
@@ -62,7 +62,7 @@ var _ = Describe("Tracklist", func() {
 		})
 
 		It("should add only repository of namespace target if namespace point to a repository target", func() {
-			nsKeeper := NewNamespaceKeeper(state)
+			nsKeeper := NewNamespaceKeeper(state, keeper.db)
 			nsKeeper.Update(crypto.MakeNamespaceHash("ns1"), &state2.Namespace{Domains: map[string]string{
 				"domain1": "r/abc",
 				"domain2": "r/xyz",
@@ -78,7 +78,7 @@ var _ = Describe("Tracklist", func() {
 		})
 
 		It("should return error if namespace domain does not exist", func() {
-			nsKeeper := NewNamespaceKeeper(state)
+			nsKeeper := NewNamespaceKeeper(state, keeper.db)
 			nsKeeper.Update(crypto.MakeNamespaceHash("ns1"), &state2.Namespace{Domains: map[string]string{
 				"domain1": "r/abc",
 			}})
@@ -169,7 +169,7 @@ var _ = Describe("Tracklist", func() {
 		})
 
 		It("should remove repository targets if argument is a namespace", func() {
-			nsKeeper := NewNamespaceKeeper(state)
+			nsKeeper := NewNamespaceKeeper(state, keeper.db)
 			nsKeeper.Update(crypto.MakeNamespaceHash("ns1"), &state2.Namespace{Domains: map[string]string{
 				"domain1": "r/abc",
 				"domain2": "r/xyz",
@@ -184,7 +184,7 @@ var _ = Describe("Tracklist", func() {
 		})
 
 		It("should remove namespace target if namespace is whole", func() {
-			nsKeeper := NewNamespaceKeeper(state)
+			nsKeeper := NewNamespaceKeeper(state, keeper.db)
 			nsKeeper.Update(crypto.MakeNamespaceHash("ns1"), &state2.Namespace{Domains: map[string]string{
 				"domain1": "r/abc",
 				"domain2": "r/xyz",
@@ -199,7 +199,7 @@ var _ = Describe("Tracklist", func() {
 		})
 
 		It("should return error if namespace domain does not exist", func() {
-			nsKeeper := NewNamespaceKeeper(state)
+			nsKeeper := NewNamespaceKeeper(state, keeper.db)
 			nsKeeper.Update(crypto.MakeNamespaceHash("ns1"), &state2.Namespace{Domains: map[string]string{
 				"domain1": "r/abc",
 			}})
@@ -242,4 +242,60 @@ var _ = Describe("Tracklist", func() {
 			Expect(height).To(Equal(uint64(10)))
 		})
 	})
+
+	Describe(".UpdateBranchActivity", func() {
+		It("should add branch activity", func() {
+			err := keeper.UpdateBranchActivity("repo1", "refs/heads/master", &core.BranchActivity{Hash: "abc", LastCommitTime: 100})
+			Expect(err).To(BeNil())
+			rec, err := appDB.Get(MakeRepoBranchActivityKey("repo1", "refs/heads/master"))
+			Expect(err).To(BeNil())
+			Expect(rec).ToNot(BeNil())
+		})
+	})
+
+	Describe(".GetBranchActivities", func() {
+		It("should return activities of the repo's branches only", func() {
+			Expect(keeper.UpdateBranchActivity("repo1", "refs/heads/master", &core.BranchActivity{Hash: "abc", LastCommitTime: 100})).To(BeNil())
+			Expect(keeper.UpdateBranchActivity("repo1", "refs/heads/dev", &core.BranchActivity{Hash: "xyz", LastCommitTime: 200})).To(BeNil())
+			Expect(keeper.UpdateBranchActivity("repo10", "refs/heads/master", &core.BranchActivity{Hash: "123", LastCommitTime: 300})).To(BeNil())
+			res, err := keeper.GetBranchActivities("repo1")
+			Expect(err).To(BeNil())
+			Expect(res).To(HaveLen(2))
+			Expect(res["refs/heads/master"]).To(Equal(&core.BranchActivity{Hash: "abc", LastCommitTime: 100}))
+			Expect(res["refs/heads/dev"]).To(Equal(&core.BranchActivity{Hash: "xyz", LastCommitTime: 200}))
+		})
+
+		It("should return the latest activity of a branch", func() {
+			Expect(keeper.UpdateBranchActivity("repo1", "refs/heads/master", &core.BranchActivity{Hash: "abc", LastCommitTime: 100})).To(BeNil())
+			Expect(keeper.UpdateBranchActivity("repo1", "refs/heads/master", &core.BranchActivity{Hash: "xyz", LastCommitTime: 200})).To(BeNil())
+			res, err := keeper.GetBranchActivities("repo1")
+			Expect(err).To(BeNil())
+			Expect(res["refs/heads/master"]).To(Equal(&core.BranchActivity{Hash: "xyz", LastCommitTime: 200}))
+		})
+	})
+
+	Describe(".RemoveBranchActivity", func() {
+		It("should remove branch activity", func() {
+			Expect(keeper.UpdateBranchActivity("repo1", "refs/heads/master", &core.BranchActivity{Hash: "abc", LastCommitTime: 100})).To(BeNil())
+			Expect(keeper.RemoveBranchActivity("repo1", "refs/heads/master")).To(BeNil())
+			res, err := keeper.GetBranchActivities("repo1")
+			Expect(err).To(BeNil())
+			Expect(res).To(BeEmpty())
+		})
+	})
+
+	Describe(".ClearBranchActivities", func() {
+		It("should remove activities of the repo's branches only", func() {
+			Expect(keeper.UpdateBranchActivity("repo1", "refs/heads/master", &core.BranchActivity{Hash: "abc", LastCommitTime: 100})).To(BeNil())
+			Expect(keeper.UpdateBranchActivity("repo1", "refs/heads/dev", &core.BranchActivity{Hash: "xyz", LastCommitTime: 200})).To(BeNil())
+			Expect(keeper.UpdateBranchActivity("repo10", "refs/heads/master", &core.BranchActivity{Hash: "123", LastCommitTime: 300})).To(BeNil())
+			Expect(keeper.ClearBranchActivities("repo1")).To(BeNil())
+			res, err := keeper.GetBranchActivities("repo1")
+			Expect(err).To(BeNil())
+			Expect(res).To(BeEmpty())
+			res, err = keeper.GetBranchActivities("repo10")
+			Expect(err).To(BeNil())
+			Expect(res).To(HaveLen(1))
+		})
+	})
 })
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/make-os/kit/crypto/ed25519"
 	"github.com/make-os/kit/storage"
 	"github.com/make-os/kit/storage/common"
 	storagetypes "github.com/make-os/kit/storage/types"
@@ -90,6 +91,19 @@ func (rk *RepoKeeper) Update(name string, upd *state.Repository) {
 	rk.state.Set(MakeRepoKey(name), upd.Bytes())
 }
 
+// Iterate calls fn for each repository in the state tree.
+// Iteration stops if fn returns true.
+func (rk *RepoKeeper) Iterate(fn func(name string, repo *state.Repository) bool) {
+	prefix := MakeRepoKey("")
+	rk.state.IteratePrefix(prefix, func(key, value []byte) bool {
+		repo, err := state.NewRepositoryFromBytes(value)
+		if err != nil {
+			panic(errors.Wrap(err, "failed to decode repo"))
+		}
+		return fn(string(key[len(prefix):]), repo)
+	})
+}
+
 // IndexProposalVote implements RepoKeeper
 func (rk *RepoKeeper) IndexProposalVote(name, propID, voterAddr string, vote int) error {
 	key := MakeRepoProposalVoteKey(name, propID, voterAddr)
@@ -189,3 +203,32 @@ func (rk *RepoKeeper) GetReposCreatedByAddress(address []byte) (res []string, er
 	})
 	return res, nil
 }
+
+// ReindexCreatedByAddress rebuilds the address->repo index from the creators
+// of the repositories in the state tree. The creator of a repository is only
+// known if they were registered as an owner; other index entries are kept.
+//
+// ARGS:
+// progress: Called with the number of repositories indexed so far (optional)
+func (rk *RepoKeeper) ReindexCreatedByAddress(progress func(n int)) (n int, err error) {
+	rk.Iterate(func(name string, repo *state.Repository) bool {
+		for address, owner := range repo.Owners {
+			if !owner.Creator {
+				continue
+			}
+			var addr [20]byte
+			if addr, err = ed25519.DecodeAddr(address); err != nil {
+				return true
+			}
+			if err = rk.IndexRepoCreatedByAddress(addr[:], name); err != nil {
+				return true
+			}
+			n++
+			if progress != nil {
+				progress(n)
+			}
+		}
+		return false
+	})
+	return n, err
+}
@@ -282,4 +282,40 @@ var _ = Describe("RepoKeeper", func() {
 			Expect(repos).To(BeEmpty())
 		})
 	})
+
+	Describe(".Iterate", func() {
+		It("should call fn for each repository", func() {
+			rk.Update("repo1", state2.BareRepository())
+			rk.Update("repo2", state2.BareRepository())
+			var names []string
+			rk.Iterate(func(name string, repo *state2.Repository) bool {
+				names = append(names, name)
+				return false
+			})
+			Expect(names).To(Equal([]string{"repo1", "repo2"}))
+		})
+	})
+
+	Describe(".ReindexCreatedByAddress", func() {
+		It("should index repositories by the address of their creator owner", func() {
+			key := crypto2.NewKeyFromIntSeed(1)
+			key2 := crypto2.NewKeyFromIntSeed(2)
+			repo := state2.BareRepository()
+			repo.AddOwner(key.Addr().String(), &state2.RepoOwner{Creator: true})
+			repo.AddOwner(key2.Addr().String(), &state2.RepoOwner{})
+			rk.Update("repo1", repo)
+			rk.Update("repo2", state2.BareRepository())
+
+			n, err := rk.ReindexCreatedByAddress(nil)
+			Expect(err).To(BeNil())
+			Expect(n).To(Equal(1))
+
+			repos, err := rk.GetReposCreatedByAddress(key.PubKey().AddrRaw())
+			Expect(err).To(BeNil())
+			Expect(repos).To(Equal([]string{"repo1"}))
+			repos, err = rk.GetReposCreatedByAddress(key2.PubKey().AddrRaw())
+			Expect(err).To(BeNil())
+			Expect(repos).To(BeEmpty())
+		})
+	})
 })
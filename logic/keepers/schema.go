@@ -21,6 +21,8 @@ const (
 	TagAnnouncementScheduleKey = "ak"
 	TagRepoRefLastSyncHeight   = "rrh"
 	TagAddressRepoPairKey      = "ar"
+	TagRepoBranchActivity      = "rba"
+	TagRepoNamespace           = "rns"
 )
 
 // MakeRepoRefLastSyncHeightKey creates a key for storing a repo's reference last successful synchronized height.
@@ -28,6 +30,16 @@ func MakeRepoRefLastSyncHeightKey(repo, reference string) []byte {
 	return common.MakePrefix([]byte(TagRepoRefLastSyncHeight), []byte(repo), []byte(reference))
 }
 
+// MakeRepoBranchActivityKey creates a key for storing the activity info of a repo's branch.
+func MakeRepoBranchActivityKey(repo, branch string) []byte {
+	return common.MakePrefix([]byte(TagRepoBranchActivity), []byte(repo), []byte(branch))
+}
+
+// MakeQueryRepoBranchActivityKey creates a key for querying the activity info of all branches of a repo.
+func MakeQueryRepoBranchActivityKey(repo string) []byte {
+	return common.MakePrefix([]byte(TagRepoBranchActivity), []byte(repo), []byte{})
+}
+
 // MakeTrackedRepoKey creates a key for accessing a tracked repo.
 func MakeTrackedRepoKey(name string) []byte {
 	return common.MakePrefix([]byte(TagTrackedRepo), []byte(name))
@@ -92,6 +104,16 @@ func MakeNamespaceKey(name string) []byte {
 	return common.MakePrefix([]byte(TagNS), []byte(name))
 }
 
+// MakeRepoNamespaceIndexKey creates a key for indexing a namespace domain that targets a repo
+func MakeRepoNamespaceIndexKey(repo, namespace, domain string) []byte {
+	return common.MakePrefix([]byte(TagRepoNamespace), []byte(repo), []byte(namespace), []byte(domain))
+}
+
+// MakeQueryRepoNamespacesKey creates a key for querying the namespace domains that target a repo
+func MakeQueryRepoNamespacesKey(repo string) []byte {
+	return common.MakePrefix([]byte(TagRepoNamespace), []byte(repo), []byte{})
+}
+
 // MakeKeyBlockInfo creates a key for accessing/storing committed block data.
 func MakeKeyBlockInfo(height int64) []byte {
 	return common.MakeKey(util.EncodeNumber(uint64(height)), []byte(TagBlockInfo))
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/AlekSi/pointer"
 	"github.com/make-os/kit/config"
 	"github.com/make-os/kit/logic/contracts"
 	"github.com/make-os/kit/logic/contracts/transfercoin"
@@ -125,7 +126,7 @@ func newLogicWithTx(dbTx storagetypes.Tx, stateTreeDBTx tmdb.DB, cfg *config.App
 	l.validatorKeeper = keepers.NewValidatorKeeper(dbTx)
 	l.repoKeeper = keepers.NewRepoKeeper(safeTree, dbTx)
 	l.pushKeyKeeper = keepers.NewPushKeyKeeper(safeTree, dbTx)
-	l.nsKeeper = keepers.NewNamespaceKeeper(safeTree)
+	l.nsKeeper = keepers.NewNamespaceKeeper(safeTree, dbTx)
 
 	return l
 }
@@ -343,9 +344,10 @@ func (l *Logic) ApplyProposals(block *state.BlockInfo) error {
 		if repo.IsEmpty() {
 			return fmt.Errorf("repo not found") // should never happen
 		}
+		proposal := repo.Proposals.Get(ep.ProposalID)
 		_, err := proposals.MaybeApplyProposal(&proposals.ApplyProposalArgs{
 			Keepers:     l,
-			Proposal:    repo.Proposals.Get(ep.ProposalID),
+			Proposal:    proposal,
 			Repo:        repo,
 			ChainHeight: nextChainHeight - 1,
 			Contracts:   contracts.SystemContracts,
@@ -354,6 +356,20 @@ func (l *Logic) ApplyProposals(block *state.BlockInfo) error {
 			return err
 		}
 		repoKeeper.Update(ep.RepoName, repo)
+
+		// When the repo closes proposals at their deadline, mark the finalized
+		// proposal as closed. Accepted merge request proposals are left open
+		// since they are closed by the push that performs the merge.
+		closeTrigger := state.ProposalCloseTrigger(pointer.GetInt(repo.Config.Gov.PropCloseTrigger))
+		if closeTrigger != state.ProposalCloseTriggerDeadline || !proposal.IsFinalized() {
+			continue
+		}
+		if proposal.Action == txns.TxTypeMergeRequestProposalAction && proposal.IsAccepted() {
+			continue
+		}
+		if err = repoKeeper.MarkProposalAsClosed(ep.RepoName, ep.ProposalID); err != nil {
+			return err
+		}
 	}
 
 	return nil
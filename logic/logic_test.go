@@ -5,7 +5,10 @@ import (
 	"os"
 	"testing"
 
+	"github.com/AlekSi/pointer"
 	storagetypes "github.com/make-os/kit/storage/types"
+	"github.com/make-os/kit/types/state"
+	"github.com/make-os/kit/types/txns"
 	"github.com/make-os/kit/util"
 	"github.com/make-os/kit/util/identifier"
 	tmdb "github.com/tendermint/tm-db"
@@ -44,6 +47,68 @@ var _ = Describe("Logic", func() {
 		Expect(err).To(BeNil())
 	})
 
+	Describe(".ApplyProposals", func() {
+		var repo *state.Repository
+
+		BeforeEach(func() {
+			repo = state.BareRepository()
+			repo.AddOwner("addr1", &state.RepoOwner{})
+			repo.AddOwner("addr2", &state.RepoOwner{})
+			prop := state.BareRepoProposal()
+			prop.Creator = "addr1"
+			prop.Action = txns.TxTypeRepoProposalUpdate
+			prop.EndAt = 10
+			repo.Proposals.Add("1", prop)
+			repo.Config.Gov.Voter = state.VoterOwner.Ptr()
+			repo.Config.Gov.PropQuorum = pointer.ToString("40")
+			repo.Config.Gov.PropThreshold = pointer.ToString("51")
+		})
+
+		It("should finalize the proposal without marking it closed when close trigger is manual", func() {
+			repo.Config.Gov.PropCloseTrigger = state.ProposalCloseTriggerManual.Ptr()
+			logic.RepoKeeper().Update("repo1", repo)
+			Expect(logic.RepoKeeper().IndexProposalEnd("repo1", "1", 10)).To(BeNil())
+
+			err = logic.ApplyProposals(&state.BlockInfo{Height: 10})
+			Expect(err).To(BeNil())
+			prop := logic.RepoKeeper().Get("repo1").Proposals.Get("1")
+			Expect(prop.Outcome).To(Equal(state.ProposalOutcomeQuorumNotMet))
+			closed, err := logic.RepoKeeper().IsProposalClosed("repo1", "1")
+			Expect(err).To(BeNil())
+			Expect(closed).To(BeFalse())
+		})
+
+		It("should finalize the proposal and mark it closed when close trigger is deadline", func() {
+			repo.Config.Gov.PropCloseTrigger = state.ProposalCloseTriggerDeadline.Ptr()
+			logic.RepoKeeper().Update("repo1", repo)
+			Expect(logic.RepoKeeper().IndexProposalEnd("repo1", "1", 10)).To(BeNil())
+
+			err = logic.ApplyProposals(&state.BlockInfo{Height: 10})
+			Expect(err).To(BeNil())
+			prop := logic.RepoKeeper().Get("repo1").Proposals.Get("1")
+			Expect(prop.Outcome).To(Equal(state.ProposalOutcomeQuorumNotMet))
+			closed, err := logic.RepoKeeper().IsProposalClosed("repo1", "1")
+			Expect(err).To(BeNil())
+			Expect(closed).To(BeTrue())
+		})
+
+		It("should not close an accepted merge request proposal when close trigger is deadline", func() {
+			repo.Config.Gov.PropCloseTrigger = state.ProposalCloseTriggerDeadline.Ptr()
+			repo.Proposals.Get("1").Action = txns.TxTypeMergeRequestProposalAction
+			repo.Proposals.Get("1").Yes = 2
+			logic.RepoKeeper().Update("repo1", repo)
+			Expect(logic.RepoKeeper().IndexProposalEnd("repo1", "1", 10)).To(BeNil())
+
+			err = logic.ApplyProposals(&state.BlockInfo{Height: 10})
+			Expect(err).To(BeNil())
+			prop := logic.RepoKeeper().Get("repo1").Proposals.Get("1")
+			Expect(prop.Outcome).To(Equal(state.ProposalOutcomeAccepted))
+			closed, err := logic.RepoKeeper().IsProposalClosed("repo1", "1")
+			Expect(err).To(BeNil())
+			Expect(closed).To(BeFalse())
+		})
+	})
+
 	Describe(".ApplyGenesisState", func() {
 		var testGenData = []*config.GenDataEntry{
 			{Type: config.GenDataTypeAccount, Address: "addr1", Balance: "100"},
@@ -17,6 +17,7 @@ import (
 )
 
 func MakeProposal(
+	keepers core.Keepers,
 	creatorAddress string,
 	repo *state.Repository,
 	id string,
@@ -43,6 +44,12 @@ func MakeProposal(
 		proposal.PowerAge = util.UInt64(chainHeight) + 1
 	}
 
+	// Snapshot the total voting power if the quorum is to be
+	// computed from the voting power at proposal creation.
+	if proposal.GetQuorumMode() == state.ProposalQuorumModeSnapshot {
+		proposal.QuorumPower = getTotalPower(keepers.GetTicketManager(), proposal, repo)
+	}
+
 	// Set the fee deposit end height and also update the proposal end height to
 	// be after the fee deposit height
 	if cast.ToFloat64(pointer.GetString(repo.Config.Gov.PropFeeDepositDur)) > 0 {
@@ -56,12 +63,8 @@ func MakeProposal(
 	return proposal
 }
 
-// GetProposalOutcome returns the current outcome of a proposal
-// whose voters are only network stakeholders; If the proposal requires
-// a proposer max join height, only stakeholders whose tickets became mature
-// before the proposer max join height
-func GetProposalOutcome(tickmgr tickettypes.TicketManager, prop state.Proposal,
-	repo *state.Repository) state.ProposalOutcome {
+// getTotalPower returns the total voting power of the voters of a proposal
+func getTotalPower(tickmgr tickettypes.TicketManager, prop state.Proposal, repo *state.Repository) float64 {
 
 	var err error
 	totalPower := float64(0)
@@ -90,6 +93,26 @@ func GetProposalOutcome(tickmgr tickettypes.TicketManager, prop state.Proposal,
 		}
 	}
 
+	return totalPower
+}
+
+// GetProposalOutcome returns the current outcome of a proposal
+// whose voters are only network stakeholders; If the proposal requires
+// a proposer max join height, only stakeholders whose tickets became mature
+// before the proposer max join height.
+//
+// In snapshot quorum mode, the quorum is computed from the total voting
+// power at the time the proposal was created.
+func GetProposalOutcome(tickmgr tickettypes.TicketManager, prop state.Proposal,
+	repo *state.Repository) state.ProposalOutcome {
+
+	var totalPower float64
+	if prop.GetQuorumMode() == state.ProposalQuorumModeSnapshot {
+		totalPower = prop.GetQuorumPower()
+	} else {
+		totalPower = getTotalPower(tickmgr, prop, repo)
+	}
+
 	nAcceptedVotes := prop.GetAccepted()
 	nRejectedVotes := prop.GetRejected()
 	nRejectedWithVetoVotes := prop.GetRejectedWithVeto()
@@ -473,6 +473,59 @@ var _ = Describe("ProposalHandler", func() {
 		})
 	})
 
+	Describe(".GetProposalOutcome (quorum mode)", func() {
+		var ticketsValue float64
+
+		// makeProposal creates a proposal voted on by network stakeholders
+		// while the value of all tickets is 500. The value of all tickets
+		// increases to 1000 afterwards.
+		makeProposal := func(mode state.ProposalQuorumMode) *state.RepoProposal {
+			repo.Config = state.MakeDefaultRepoConfig()
+			repo.Config.Gov.Voter = state.VoterNetStakers.Ptr()
+			repo.Config.Gov.PropQuorum = pointer.ToString("40")
+			repo.Config.Gov.PropQuorumMode = mode.Ptr()
+			ticketsValue = 500
+			proposal := proposals.MakeProposal(logic, key.Addr().String(), repo, "1", "0", 1)
+			proposal.Yes = 250
+			ticketsValue = 1000
+			return proposal
+		}
+
+		BeforeEach(func() {
+			mockTickMgr := mocks.NewMockTicketManager(ctrl)
+			mockTickMgr.EXPECT().ValueOfAllTickets(uint64(0)).DoAndReturn(func(uint64) (float64, error) {
+				return ticketsValue, nil
+			}).AnyTimes()
+			logic.SetTicketManager(mockTickMgr)
+		})
+
+		When("quorum mode is snapshot", func() {
+			It("should snapshot the total voting power at proposal creation", func() {
+				proposal := makeProposal(state.ProposalQuorumModeSnapshot)
+				Expect(proposal.QuorumPower).To(Equal(float64(500)))
+			})
+
+			It("should compute quorum from the voting power at proposal creation", func() {
+				proposal := makeProposal(state.ProposalQuorumModeSnapshot)
+				out := proposals.GetProposalOutcome(logic.GetTicketManager(), proposal, repo)
+				Expect(out).To(Equal(state.ProposalOutcomeAccepted))
+			})
+		})
+
+		When("quorum mode is live", func() {
+			It("should not snapshot the total voting power at proposal creation", func() {
+				proposal := makeProposal(state.ProposalQuorumModeLive)
+				Expect(proposal.QuorumPower).To(BeZero())
+			})
+
+			It("should compute quorum from the current voting power", func() {
+				proposal := makeProposal(state.ProposalQuorumModeLive)
+				out := proposals.GetProposalOutcome(logic.GetTicketManager(), proposal, repo)
+				Expect(out).To(Equal(state.ProposalOutcomeQuorumNotMet))
+			})
+		})
+	})
+
 	Describe(".MaybeProcessProposalFee", func() {
 		var proposal *state.RepoProposal
 		var addr = identifier.Address("addr1")
@@ -0,0 +1,136 @@
+package logic
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/make-os/kit/remote/repo"
+	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/state"
+	"github.com/pkg/errors"
+)
+
+// Secondary indexes that can be rebuilt by Reindex
+const (
+	// IndexPushKeyByAddress indexes push keys by the address of their owner
+	IndexPushKeyByAddress = "push-key-by-address"
+
+	// IndexRepoByCreator indexes repositories by the address of their creator
+	IndexRepoByCreator = "repo-by-creator"
+
+	// IndexBranchActivity indexes the tip and last commit time of branches
+	IndexBranchActivity = "branch-activity"
+)
+
+// Indexes is the list of secondary indexes that can be rebuilt by Reindex
+var Indexes = []string{IndexPushKeyByAddress, IndexRepoByCreator, IndexBranchActivity}
+
+// ReindexProgressFunc is called with the name of the index being rebuilt
+// and the number of entries indexed so far.
+type ReindexProgressFunc func(index string, n int)
+
+// Reindex rebuilds secondary indexes from the current state.
+// It is safe to run multiple times; the result is always the same.
+//  - indexes: The indexes to rebuild. If empty, all indexes are rebuilt.
+//  - progress: Called as entries are indexed (optional).
+func (l *Logic) Reindex(indexes []string, progress ReindexProgressFunc) error {
+
+	if l.stateTree == nil {
+		return fmt.Errorf("state tree is not available in light mode")
+	}
+
+	if len(indexes) == 0 {
+		indexes = Indexes
+	}
+
+	for _, index := range indexes {
+		index := index
+		cb := func(n int) {
+			if progress != nil {
+				progress(index, n)
+			}
+		}
+
+		var err error
+		switch index {
+		case IndexPushKeyByAddress:
+			_, err = l.pushKeyKeeper.Reindex(cb)
+		case IndexRepoByCreator:
+			_, err = l.repoKeeper.ReindexCreatedByAddress(cb)
+		case IndexBranchActivity:
+			_, err = l.reindexBranchActivity(cb)
+		default:
+			return fmt.Errorf("unknown index (%s)", index)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to rebuild index (%s)", index)
+		}
+	}
+
+	return nil
+}
+
+// reindexBranchActivity rebuilds the branch activity index of repositories
+// that exist locally. The activity of a branch is computed from its tip commit.
+func (l *Logic) reindexBranchActivity(progress func(n int)) (n int, err error) {
+	l.repoKeeper.Iterate(func(name string, _ *state.Repository) bool {
+		var r *repo.Repo
+		if r, err = l.openRepo(name); err != nil {
+			if err == git.ErrRepositoryNotExists {
+				err = nil
+				return false
+			}
+			return true
+		}
+
+		if err = l.repoSyncInfoKeeper.ClearBranchActivities(name); err != nil {
+			return true
+		}
+
+		var branches []string
+		if branches, err = r.GetBranches(); err != nil {
+			return true
+		}
+
+		for _, branch := range branches {
+			ref := plumbing.NewBranchReferenceName(branch)
+			var hash string
+			if hash, err = r.RefGet(ref.String()); err != nil {
+				return true
+			}
+			var activity *core.BranchActivity
+			if activity, err = getBranchActivity(r, hash); err != nil {
+				return true
+			}
+			if err = l.repoSyncInfoKeeper.UpdateBranchActivity(name, ref.String(), activity); err != nil {
+				return true
+			}
+			n++
+			if progress != nil {
+				progress(n)
+			}
+		}
+
+		return false
+	})
+	return n, err
+}
+
+// openRepo opens a local repository
+func (l *Logic) openRepo(name string) (*repo.Repo, error) {
+	r, err := repo.GetWithGitModule(l.cfg.Node.GitBinPath, l.cfg.GetRepoPath(name))
+	if err != nil {
+		return nil, err
+	}
+	return r.(*repo.Repo), nil
+}
+
+// getBranchActivity returns the activity info of a branch tip
+func getBranchActivity(r *repo.Repo, hash string) (*core.BranchActivity, error) {
+	commit, err := r.CommitObject(plumbing.NewHash(hash))
+	if err != nil {
+		return nil, err
+	}
+	return &core.BranchActivity{Hash: hash, LastCommitTime: commit.Committer.When.Unix()}, nil
+}
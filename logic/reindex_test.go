@@ -0,0 +1,108 @@
+package logic_test
+
+import (
+	"os"
+
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/crypto/ed25519"
+	l "github.com/make-os/kit/logic"
+	"github.com/make-os/kit/logic/keepers"
+	testutil2 "github.com/make-os/kit/remote/testutil"
+	"github.com/make-os/kit/storage/common"
+	storagetypes "github.com/make-os/kit/storage/types"
+	"github.com/make-os/kit/testutil"
+	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/state"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	tmdb "github.com/tendermint/tm-db"
+)
+
+var _ = Describe("Reindex", func() {
+	var appDB storagetypes.Engine
+	var stateTreeDB tmdb.DB
+	var err error
+	var cfg *config.AppConfig
+	var logic *l.Logic
+	var key = ed25519.NewKeyFromIntSeed(1)
+
+	BeforeEach(func() {
+		cfg, err = testutil.SetTestCfg()
+		Expect(err).To(BeNil())
+		appDB, stateTreeDB = testutil.GetDB()
+		logic = l.New(appDB, stateTreeDB, cfg)
+	})
+
+	AfterEach(func() {
+		Expect(appDB.Close()).To(BeNil())
+		Expect(stateTreeDB.Close()).To(BeNil())
+		err = os.RemoveAll(cfg.DataDir())
+		Expect(err).To(BeNil())
+	})
+
+	BeforeEach(func() {
+		err = logic.PushKeyKeeper().Update("pk_id", &state.PushKey{PubKey: key.PubKey().ToPublicKey(), Address: key.Addr()})
+		Expect(err).To(BeNil())
+
+		repo := state.BareRepository()
+		repo.AddOwner(key.Addr().String(), &state.RepoOwner{Creator: true})
+		logic.RepoKeeper().Update("repo1", repo)
+
+		testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+		testutil2.AppendCommit(cfg.GetRepoPath("repo1"), "file.txt", "line 1", "commit 1")
+	})
+
+	It("should return error when index is unknown", func() {
+		err = logic.Reindex([]string{"unknown"}, nil)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("unknown index (unknown)"))
+	})
+
+	It("should rebuild cleared indexes and be idempotent", func() {
+		db := logic.DB().NewTx(true, true)
+		Expect(db.Del(keepers.MakeAddrPushKeyIDIndexKey(key.Addr().String(), "pk_id"))).To(BeNil())
+		Expect(db.Put(common.NewFromKeyValue(keepers.MakeAddrPushKeyIDIndexKey(key.Addr().String(), "pk_id2"), []byte{}))).To(BeNil())
+		activityKeeper := logic.RepoSyncInfoKeeper()
+		Expect(activityKeeper.UpdateBranchActivity("repo1", "refs/heads/deleted", &core.BranchActivity{Hash: "abc"})).To(BeNil())
+
+		Expect(logic.PushKeyKeeper().GetByAddress(key.Addr().String())).To(ConsistOf("pk_id2"))
+		repos, err := logic.RepoKeeper().GetReposCreatedByAddress(key.PubKey().AddrRaw())
+		Expect(err).To(BeNil())
+		Expect(repos).To(BeEmpty())
+
+		for i := 0; i < 2; i++ {
+			progress := map[string]int{}
+			err = logic.Reindex(nil, func(index string, n int) { progress[index] = n })
+			Expect(err).To(BeNil())
+			Expect(progress).To(Equal(map[string]int{
+				l.IndexPushKeyByAddress: 1,
+				l.IndexRepoByCreator:    1,
+				l.IndexBranchActivity:   1,
+			}))
+
+			Expect(logic.PushKeyKeeper().GetByAddress(key.Addr().String())).To(ConsistOf("pk_id"))
+
+			repos, err = logic.RepoKeeper().GetReposCreatedByAddress(key.PubKey().AddrRaw())
+			Expect(err).To(BeNil())
+			Expect(repos).To(Equal([]string{"repo1"}))
+
+			activities, err := activityKeeper.GetBranchActivities("repo1")
+			Expect(err).To(BeNil())
+			Expect(activities).To(HaveLen(1))
+			Expect(activities["refs/heads/master"].Hash).To(Equal(testutil2.GetRecentCommitHash(cfg.GetRepoPath("repo1"), "HEAD")))
+		}
+	})
+
+	It("should rebuild only the given indexes", func() {
+		Expect(logic.DB().NewTx(true, true).Del(keepers.MakeAddrPushKeyIDIndexKey(key.Addr().String(), "pk_id"))).To(BeNil())
+		err = logic.Reindex([]string{l.IndexRepoByCreator}, nil)
+		Expect(err).To(BeNil())
+		Expect(logic.PushKeyKeeper().GetByAddress(key.Addr().String())).To(BeEmpty())
+	})
+
+	It("should skip branch activity of repositories that do not exist locally", func() {
+		logic.RepoKeeper().Update("repo2", state.BareRepository())
+		err = logic.Reindex([]string{l.IndexBranchActivity}, nil)
+		Expect(err).To(BeNil())
+	})
+})
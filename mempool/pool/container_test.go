@@ -6,6 +6,7 @@ import (
 	"github.com/make-os/kit/crypto/ed25519"
 	types2 "github.com/make-os/kit/mempool/types"
 	"github.com/make-os/kit/params"
+	pushtypes "github.com/make-os/kit/remote/push/types"
 	"github.com/make-os/kit/types"
 	"github.com/make-os/kit/types/txns"
 	"github.com/make-os/kit/util"
@@ -283,6 +284,35 @@ var _ = Describe("Container", func() {
 					Expect(q.Get(2).Tx).To(Equal(tx2))
 				})
 			})
+
+			When("container has push transactions from different pushers", func() {
+				makePushTx := func(pusher *ed25519.Key, fee string, size uint64) *txns.TxPush {
+					tx := txns.NewBareTxPush()
+					tx.Note = &pushtypes.Note{
+						PusherAddress:   pusher.Addr(),
+						PusherAcctNonce: 1,
+						Size:            size,
+						Timestamp:       time.Now().Unix(),
+						References:      []*pushtypes.PushedReference{{Name: "refs/heads/master", Fee: util.String(fee)}},
+					}
+					return tx
+				}
+
+				It("after sorting, the first transaction must be the one with the highest fee per byte", func() {
+					sender3 := ed25519.NewKeyFromIntSeed(3)
+					q := NewContainer(3, emitter.New(1), zeroNonceGetter)
+					basePush := makePushTx(sender, "1", 1000)
+					priorityPush := makePushTx(sender2, "2", 1000)
+					largePush := makePushTx(sender3, "3", 100000)
+					q.Add(basePush)
+					q.Add(largePush)
+					q.Add(priorityPush)
+					Expect(q.Size()).To(Equal(3))
+					Expect(q.Get(0).Tx).To(Equal(priorityPush))
+					Expect(q.Get(1).Tx).To(Equal(basePush))
+					Expect(q.Get(2).Tx).To(Equal(largePush))
+				})
+			})
 		})
 	})
 
@@ -362,6 +362,21 @@ func (m *MockRepoSyncInfoKeeper) EXPECT() *MockRepoSyncInfoKeeperMockRecorder {
 	return m.recorder
 }
 
+// GetBranchActivities mocks base method.
+func (m *MockRepoSyncInfoKeeper) GetBranchActivities(repo string) (map[string]*core.BranchActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBranchActivities", repo)
+	ret0, _ := ret[0].(map[string]*core.BranchActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBranchActivities indicates an expected call of GetBranchActivities.
+func (mr *MockRepoSyncInfoKeeperMockRecorder) GetBranchActivities(repo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranchActivities", reflect.TypeOf((*MockRepoSyncInfoKeeper)(nil).GetBranchActivities), repo)
+}
+
 // GetRefLastSyncHeight mocks base method.
 func (m *MockRepoSyncInfoKeeper) GetRefLastSyncHeight(repo, ref string) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -391,6 +406,20 @@ func (mr *MockRepoSyncInfoKeeperMockRecorder) GetTracked(name interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracked", reflect.TypeOf((*MockRepoSyncInfoKeeper)(nil).GetTracked), name)
 }
 
+// RemoveBranchActivity mocks base method.
+func (m *MockRepoSyncInfoKeeper) RemoveBranchActivity(repo, branch string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveBranchActivity", repo, branch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveBranchActivity indicates an expected call of RemoveBranchActivity.
+func (mr *MockRepoSyncInfoKeeperMockRecorder) RemoveBranchActivity(repo, branch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBranchActivity", reflect.TypeOf((*MockRepoSyncInfoKeeper)(nil).RemoveBranchActivity), repo, branch)
+}
+
 // Track mocks base method.
 func (m *MockRepoSyncInfoKeeper) Track(repos string, height ...uint64) error {
 	m.ctrl.T.Helper()
@@ -438,6 +467,20 @@ func (mr *MockRepoSyncInfoKeeperMockRecorder) UnTrack(repos interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnTrack", reflect.TypeOf((*MockRepoSyncInfoKeeper)(nil).UnTrack), repos)
 }
 
+// UpdateBranchActivity mocks base method.
+func (m *MockRepoSyncInfoKeeper) UpdateBranchActivity(repo, branch string, activity *core.BranchActivity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBranchActivity", repo, branch, activity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBranchActivity indicates an expected call of UpdateBranchActivity.
+func (mr *MockRepoSyncInfoKeeperMockRecorder) UpdateBranchActivity(repo, branch, activity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBranchActivity", reflect.TypeOf((*MockRepoSyncInfoKeeper)(nil).UpdateBranchActivity), repo, branch, activity)
+}
+
 // UpdateRefLastSyncHeight mocks base method.
 func (m *MockRepoSyncInfoKeeper) UpdateRefLastSyncHeight(repo, ref string, height uint64) error {
 	m.ctrl.T.Helper()
@@ -615,6 +658,18 @@ func (mr *MockRepoKeeperMockRecorder) IsProposalClosed(name, propID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsProposalClosed", reflect.TypeOf((*MockRepoKeeper)(nil).IsProposalClosed), name, propID)
 }
 
+// Iterate mocks base method.
+func (m *MockRepoKeeper) Iterate(fn func(string, *state.Repository) bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Iterate", fn)
+}
+
+// Iterate indicates an expected call of Iterate.
+func (mr *MockRepoKeeperMockRecorder) Iterate(fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Iterate", reflect.TypeOf((*MockRepoKeeper)(nil).Iterate), fn)
+}
+
 // MarkProposalAsClosed mocks base method.
 func (m *MockRepoKeeper) MarkProposalAsClosed(name, propID string) error {
 	m.ctrl.T.Helper()
@@ -683,6 +738,20 @@ func (mr *MockNamespaceKeeperMockRecorder) Get(name interface{}, blockNum ...int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockNamespaceKeeper)(nil).Get), varargs...)
 }
 
+// GetRepoNamespaces mocks base method.
+func (m *MockNamespaceKeeper) GetRepoNamespaces(repo string) []*state.RepoNamespace {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepoNamespaces", repo)
+	ret0, _ := ret[0].([]*state.RepoNamespace)
+	return ret0
+}
+
+// GetRepoNamespaces indicates an expected call of GetRepoNamespaces.
+func (mr *MockNamespaceKeeperMockRecorder) GetRepoNamespaces(repo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepoNamespaces", reflect.TypeOf((*MockNamespaceKeeper)(nil).GetRepoNamespaces), repo)
+}
+
 // GetTarget mocks base method.
 func (m *MockNamespaceKeeper) GetTarget(path string, blockNum ...uint64) (string, error) {
 	m.ctrl.T.Helper()
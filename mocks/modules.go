@@ -209,6 +209,34 @@ func (mr *MockNodeModuleMockRecorder) GetEpoch(height interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEpoch", reflect.TypeOf((*MockNodeModule)(nil).GetEpoch), height)
 }
 
+// GetNetworkInfo mocks base method.
+func (m *MockNodeModule) GetNetworkInfo() util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkInfo")
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// GetNetworkInfo indicates an expected call of GetNetworkInfo.
+func (mr *MockNodeModuleMockRecorder) GetNetworkInfo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkInfo", reflect.TypeOf((*MockNodeModule)(nil).GetNetworkInfo))
+}
+
+// GetNetworkParams mocks base method.
+func (m *MockNodeModule) GetNetworkParams() util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkParams")
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// GetNetworkParams indicates an expected call of GetNetworkParams.
+func (mr *MockNodeModuleMockRecorder) GetNetworkParams() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkParams", reflect.TypeOf((*MockNodeModule)(nil).GetNetworkParams))
+}
+
 // GetValidators mocks base method.
 func (m *MockNodeModule) GetValidators(height string) []util.Map {
 	m.ctrl.T.Helper()
@@ -302,6 +330,20 @@ func (mr *MockTxModuleMockRecorder) SendPayload(params interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPayload", reflect.TypeOf((*MockTxModule)(nil).SendPayload), params)
 }
 
+// SubmitRawTx mocks base method.
+func (m *MockTxModule) SubmitRawTx(rawTx string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitRawTx", rawTx)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// SubmitRawTx indicates an expected call of SubmitRawTx.
+func (mr *MockTxModuleMockRecorder) SubmitRawTx(rawTx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitRawTx", reflect.TypeOf((*MockTxModule)(nil).SubmitRawTx), rawTx)
+}
+
 // MockPoolModule is a mock of PoolModule interface.
 type MockPoolModule struct {
 	ctrl     *gomock.Controller
@@ -437,6 +479,20 @@ func (mr *MockUserModuleMockRecorder) GetAccount(address interface{}, height ...
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockUserModule)(nil).GetAccount), varargs...)
 }
 
+// GetAlias mocks base method.
+func (m *MockUserModule) GetAlias(name string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlias", name)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetAlias indicates an expected call of GetAlias.
+func (mr *MockUserModuleMockRecorder) GetAlias(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlias", reflect.TypeOf((*MockUserModule)(nil).GetAlias), name)
+}
+
 // GetAvailableBalance mocks base method.
 func (m *MockUserModule) GetAvailableBalance(address string, height ...uint64) string {
 	m.ctrl.T.Helper()
@@ -564,6 +620,20 @@ func (mr *MockUserModuleMockRecorder) GetValidator(includePrivKey ...interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidator", reflect.TypeOf((*MockUserModule)(nil).GetValidator), includePrivKey...)
 }
 
+// ListAliases mocks base method.
+func (m *MockUserModule) ListAliases() util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAliases")
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// ListAliases indicates an expected call of ListAliases.
+func (mr *MockUserModuleMockRecorder) ListAliases() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAliases", reflect.TypeOf((*MockUserModule)(nil).ListAliases))
+}
+
 // SendCoin mocks base method.
 func (m *MockUserModule) SendCoin(params map[string]interface{}, options ...interface{}) util.Map {
 	m.ctrl.T.Helper()
@@ -583,6 +653,23 @@ func (mr *MockUserModuleMockRecorder) SendCoin(params interface{}, options ...in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCoin", reflect.TypeOf((*MockUserModule)(nil).SendCoin), varargs...)
 }
 
+// SetAlias mocks base method.
+func (m *MockUserModule) SetAlias(name, target string, overwrite ...bool) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{name, target}
+	for _, a := range overwrite {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "SetAlias", varargs...)
+}
+
+// SetAlias indicates an expected call of SetAlias.
+func (mr *MockUserModuleMockRecorder) SetAlias(name, target interface{}, overwrite ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{name, target}, overwrite...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAlias", reflect.TypeOf((*MockUserModule)(nil).SetAlias), varargs...)
+}
+
 // SetCommission mocks base method.
 func (m *MockUserModule) SetCommission(params map[string]interface{}, options ...interface{}) util.Map {
 	m.ctrl.T.Helper()
@@ -625,6 +712,20 @@ func (m *MockPushKeyModule) EXPECT() *MockPushKeyModuleMockRecorder {
 	return m.recorder
 }
 
+// CheckScope mocks base method.
+func (m *MockPushKeyModule) CheckScope(pushKeyID, repo, namespace string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckScope", pushKeyID, repo, namespace)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CheckScope indicates an expected call of CheckScope.
+func (mr *MockPushKeyModuleMockRecorder) CheckScope(pushKeyID, repo, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckScope", reflect.TypeOf((*MockPushKeyModule)(nil).CheckScope), pushKeyID, repo, namespace)
+}
+
 // ConfigureVM mocks base method.
 func (m *MockPushKeyModule) ConfigureVM(vm *otto.Otto) prompt.Completer {
 	m.ctrl.T.Helper()
@@ -691,6 +792,53 @@ func (mr *MockPushKeyModuleMockRecorder) GetByAddress(address interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAddress", reflect.TypeOf((*MockPushKeyModule)(nil).GetByAddress), address)
 }
 
+// GetPushKeyReach mocks base method.
+func (m *MockPushKeyModule) GetPushKeyReach(pushKeyID string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPushKeyReach", pushKeyID)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// GetPushKeyReach indicates an expected call of GetPushKeyReach.
+func (mr *MockPushKeyModuleMockRecorder) GetPushKeyReach(pushKeyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPushKeyReach", reflect.TypeOf((*MockPushKeyModule)(nil).GetPushKeyReach), pushKeyID)
+}
+
+// GetPushKeysByAddress mocks base method.
+func (m *MockPushKeyModule) GetPushKeysByAddress(address string) []util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPushKeysByAddress", address)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// GetPushKeysByAddress indicates an expected call of GetPushKeysByAddress.
+func (mr *MockPushKeyModuleMockRecorder) GetPushKeysByAddress(address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPushKeysByAddress", reflect.TypeOf((*MockPushKeyModule)(nil).GetPushKeysByAddress), address)
+}
+
+// MakePushToken mocks base method.
+func (m *MockPushKeyModule) MakePushToken(repo, pushKeyID string, opts ...types.MakePushTokenOptions) string {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{repo, pushKeyID}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MakePushToken", varargs...)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// MakePushToken indicates an expected call of MakePushToken.
+func (mr *MockPushKeyModuleMockRecorder) MakePushToken(repo, pushKeyID interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{repo, pushKeyID}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakePushToken", reflect.TypeOf((*MockPushKeyModule)(nil).MakePushToken), varargs...)
+}
+
 // Register mocks base method.
 func (m *MockPushKeyModule) Register(params map[string]interface{}, options ...interface{}) util.Map {
 	m.ctrl.T.Helper()
@@ -710,6 +858,25 @@ func (mr *MockPushKeyModuleMockRecorder) Register(params interface{}, options ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockPushKeyModule)(nil).Register), varargs...)
 }
 
+// SignMessage mocks base method.
+func (m *MockPushKeyModule) SignMessage(message, pushKeyID string, passphrase ...string) string {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{message, pushKeyID}
+	for _, a := range passphrase {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SignMessage", varargs...)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// SignMessage indicates an expected call of SignMessage.
+func (mr *MockPushKeyModuleMockRecorder) SignMessage(message, pushKeyID interface{}, passphrase ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{message, pushKeyID}, passphrase...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignMessage", reflect.TypeOf((*MockPushKeyModule)(nil).SignMessage), varargs...)
+}
+
 // Unregister mocks base method.
 func (m *MockPushKeyModule) Unregister(params map[string]interface{}, options ...interface{}) util.Map {
 	m.ctrl.T.Helper()
@@ -748,6 +915,20 @@ func (mr *MockPushKeyModuleMockRecorder) Update(params interface{}, options ...i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPushKeyModule)(nil).Update), varargs...)
 }
 
+// VerifyMessage mocks base method.
+func (m *MockPushKeyModule) VerifyMessage(message, signature, pushKeyID string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyMessage", message, signature, pushKeyID)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// VerifyMessage indicates an expected call of VerifyMessage.
+func (mr *MockPushKeyModuleMockRecorder) VerifyMessage(message, signature, pushKeyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMessage", reflect.TypeOf((*MockPushKeyModule)(nil).VerifyMessage), message, signature, pushKeyID)
+}
+
 // MockConsoleUtilModule is a mock of ConsoleUtilModule interface.
 type MockConsoleUtilModule struct {
 	ctrl     *gomock.Controller
@@ -1010,6 +1191,24 @@ func (mr *MockTicketModuleMockRecorder) GetAll(limit ...interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTicketModule)(nil).GetAll), limit...)
 }
 
+// GetHostSet mocks base method.
+func (m *MockTicketModule) GetHostSet(limit ...int) []util.Map {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range limit {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetHostSet", varargs...)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// GetHostSet indicates an expected call of GetHostSet.
+func (mr *MockTicketModuleMockRecorder) GetHostSet(limit ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostSet", reflect.TypeOf((*MockTicketModule)(nil).GetHostSet), limit...)
+}
+
 // GetHostTicketsByProposer mocks base method.
 func (m *MockTicketModule) GetHostTicketsByProposer(proposerPubKey string, queryOpts ...util.Map) []util.Map {
 	m.ctrl.T.Helper()
@@ -1029,6 +1228,24 @@ func (mr *MockTicketModuleMockRecorder) GetHostTicketsByProposer(proposerPubKey
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostTicketsByProposer", reflect.TypeOf((*MockTicketModule)(nil).GetHostTicketsByProposer), varargs...)
 }
 
+// GetHosts mocks base method.
+func (m *MockTicketModule) GetHosts(opts ...types.GetHostsOptions) []util.Map {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetHosts", varargs...)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// GetHosts indicates an expected call of GetHosts.
+func (mr *MockTicketModuleMockRecorder) GetHosts(opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHosts", reflect.TypeOf((*MockTicketModule)(nil).GetHosts), opts...)
+}
+
 // GetStats mocks base method.
 func (m *MockTicketModule) GetStats(proposerPubKey ...string) util.Map {
 	m.ctrl.T.Helper()
@@ -1163,6 +1380,86 @@ func (mr *MockRepoModuleMockRecorder) AddContributor(params interface{}, options
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddContributor", reflect.TypeOf((*MockRepoModule)(nil).AddContributor), varargs...)
 }
 
+// AnalyzeRepack mocks base method.
+func (m *MockRepoModule) AnalyzeRepack(name string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnalyzeRepack", name)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// AnalyzeRepack indicates an expected call of AnalyzeRepack.
+func (mr *MockRepoModuleMockRecorder) AnalyzeRepack(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnalyzeRepack", reflect.TypeOf((*MockRepoModule)(nil).AnalyzeRepack), name)
+}
+
+// ApplyPostRetention mocks base method.
+func (m *MockRepoModule) ApplyPostRetention(name string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyPostRetention", name)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// ApplyPostRetention indicates an expected call of ApplyPostRetention.
+func (mr *MockRepoModuleMockRecorder) ApplyPostRetention(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyPostRetention", reflect.TypeOf((*MockRepoModule)(nil).ApplyPostRetention), name)
+}
+
+// Archive mocks base method.
+func (m *MockRepoModule) Archive(name string, revision ...string) string {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{name}
+	for _, a := range revision {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Archive", varargs...)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockRepoModuleMockRecorder) Archive(name interface{}, revision ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{name}, revision...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockRepoModule)(nil).Archive), varargs...)
+}
+
+// AuditRepoSignatures mocks base method.
+func (m *MockRepoModule) AuditRepoSignatures(name string) []util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuditRepoSignatures", name)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// AuditRepoSignatures indicates an expected call of AuditRepoSignatures.
+func (mr *MockRepoModuleMockRecorder) AuditRepoSignatures(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditRepoSignatures", reflect.TypeOf((*MockRepoModule)(nil).AuditRepoSignatures), name)
+}
+
+// CloneRepos mocks base method.
+func (m *MockRepoModule) CloneRepos(names []string, opts ...types.CloneReposOptions) []util.Map {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{names}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CloneRepos", varargs...)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// CloneRepos indicates an expected call of CloneRepos.
+func (mr *MockRepoModuleMockRecorder) CloneRepos(names interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{names}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloneRepos", reflect.TypeOf((*MockRepoModule)(nil).CloneRepos), varargs...)
+}
+
 // CloseIssue mocks base method.
 func (m *MockRepoModule) CloseIssue(name, reference string) util.Map {
 	m.ctrl.T.Helper()
@@ -1191,6 +1488,48 @@ func (mr *MockRepoModuleMockRecorder) CloseMergeRequest(name, reference interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseMergeRequest", reflect.TypeOf((*MockRepoModule)(nil).CloseMergeRequest), name, reference)
 }
 
+// CompareRepos mocks base method.
+func (m *MockRepoModule) CompareRepos(baseRepo, headRepo, branch string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareRepos", baseRepo, headRepo, branch)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// CompareRepos indicates an expected call of CompareRepos.
+func (mr *MockRepoModuleMockRecorder) CompareRepos(baseRepo, headRepo, branch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareRepos", reflect.TypeOf((*MockRepoModule)(nil).CompareRepos), baseRepo, headRepo, branch)
+}
+
+// CompareTags mocks base method.
+func (m *MockRepoModule) CompareTags(name, fromTag, toTag string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareTags", name, fromTag, toTag)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// CompareTags indicates an expected call of CompareTags.
+func (mr *MockRepoModuleMockRecorder) CompareTags(name, fromTag, toTag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareTags", reflect.TypeOf((*MockRepoModule)(nil).CompareTags), name, fromTag, toTag)
+}
+
+// ComputePushID mocks base method.
+func (m *MockRepoModule) ComputePushID(id string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ComputePushID", id)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// ComputePushID indicates an expected call of ComputePushID.
+func (mr *MockRepoModuleMockRecorder) ComputePushID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ComputePushID", reflect.TypeOf((*MockRepoModule)(nil).ComputePushID), id)
+}
+
 // ConfigureVM mocks base method.
 func (m *MockRepoModule) ConfigureVM(vm *otto.Otto) prompt.Completer {
 	m.ctrl.T.Helper()
@@ -1266,6 +1605,25 @@ func (mr *MockRepoModuleMockRecorder) CreateMergeRequest(name, params interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMergeRequest", reflect.TypeOf((*MockRepoModule)(nil).CreateMergeRequest), name, params)
 }
 
+// DecodePushToken mocks base method.
+func (m *MockRepoModule) DecodePushToken(token string, pushKeyPubKey ...string) util.Map {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{token}
+	for _, a := range pushKeyPubKey {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DecodePushToken", varargs...)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// DecodePushToken indicates an expected call of DecodePushToken.
+func (mr *MockRepoModuleMockRecorder) DecodePushToken(token interface{}, pushKeyPubKey ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{token}, pushKeyPubKey...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecodePushToken", reflect.TypeOf((*MockRepoModule)(nil).DecodePushToken), varargs...)
+}
+
 // DepositProposalFee mocks base method.
 func (m *MockRepoModule) DepositProposalFee(params map[string]interface{}, options ...interface{}) util.Map {
 	m.ctrl.T.Helper()
@@ -1285,78 +1643,261 @@ func (mr *MockRepoModuleMockRecorder) DepositProposalFee(params interface{}, opt
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DepositProposalFee", reflect.TypeOf((*MockRepoModule)(nil).DepositProposalFee), varargs...)
 }
 
-// Get mocks base method.
-func (m *MockRepoModule) Get(name string, opts ...types.GetOptions) util.Map {
+// DoRepack mocks base method.
+func (m *MockRepoModule) DoRepack(name string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DoRepack", name)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// DoRepack indicates an expected call of DoRepack.
+func (mr *MockRepoModuleMockRecorder) DoRepack(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoRepack", reflect.TypeOf((*MockRepoModule)(nil).DoRepack), name)
+}
+
+// EstimateCloneSize mocks base method.
+func (m *MockRepoModule) EstimateCloneSize(name string, opts ...types.CloneOptions) util.Map {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{name}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "Get", varargs...)
+	ret := m.ctrl.Call(m, "EstimateCloneSize", varargs...)
 	ret0, _ := ret[0].(util.Map)
 	return ret0
 }
 
-// Get indicates an expected call of Get.
-func (mr *MockRepoModuleMockRecorder) Get(name interface{}, opts ...interface{}) *gomock.Call {
+// EstimateCloneSize indicates an expected call of EstimateCloneSize.
+func (mr *MockRepoModuleMockRecorder) EstimateCloneSize(name interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]interface{}{name}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepoModule)(nil).Get), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateCloneSize", reflect.TypeOf((*MockRepoModule)(nil).EstimateCloneSize), varargs...)
 }
 
-// GetBranches mocks base method.
-func (m *MockRepoModule) GetBranches(name string) []string {
+// EstimatePushSize mocks base method.
+func (m *MockRepoModule) EstimatePushSize(id string) util.Map {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBranches", name)
-	ret0, _ := ret[0].([]string)
+	ret := m.ctrl.Call(m, "EstimatePushSize", id)
+	ret0, _ := ret[0].(util.Map)
 	return ret0
 }
 
-// GetBranches indicates an expected call of GetBranches.
-func (mr *MockRepoModuleMockRecorder) GetBranches(name interface{}) *gomock.Call {
+// EstimatePushSize indicates an expected call of EstimatePushSize.
+func (mr *MockRepoModuleMockRecorder) EstimatePushSize(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranches", reflect.TypeOf((*MockRepoModule)(nil).GetBranches), name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimatePushSize", reflect.TypeOf((*MockRepoModule)(nil).EstimatePushSize), id)
 }
 
-// GetCommit mocks base method.
-func (m *MockRepoModule) GetCommit(name, hash string) util.Map {
+// ExportPatchSeries mocks base method.
+func (m *MockRepoModule) ExportPatchSeries(name, from, to string) []string {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCommit", name, hash)
-	ret0, _ := ret[0].(util.Map)
+	ret := m.ctrl.Call(m, "ExportPatchSeries", name, from, to)
+	ret0, _ := ret[0].([]string)
 	return ret0
 }
 
-// GetCommit indicates an expected call of GetCommit.
-func (mr *MockRepoModuleMockRecorder) GetCommit(name, hash interface{}) *gomock.Call {
+// ExportPatchSeries indicates an expected call of ExportPatchSeries.
+func (mr *MockRepoModuleMockRecorder) ExportPatchSeries(name, from, to interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommit", reflect.TypeOf((*MockRepoModule)(nil).GetCommit), name, hash)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportPatchSeries", reflect.TypeOf((*MockRepoModule)(nil).ExportPatchSeries), name, from, to)
 }
 
-// GetCommitAncestors mocks base method.
-func (m *MockRepoModule) GetCommitAncestors(name, commitHash string, limit ...int) []util.Map {
+// ExportPosts mocks base method.
+func (m *MockRepoModule) ExportPosts(name, kind string) string {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{name, commitHash}
-	for _, a := range limit {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "GetCommitAncestors", varargs...)
-	ret0, _ := ret[0].([]util.Map)
+	ret := m.ctrl.Call(m, "ExportPosts", name, kind)
+	ret0, _ := ret[0].(string)
 	return ret0
 }
 
-// GetCommitAncestors indicates an expected call of GetCommitAncestors.
-func (mr *MockRepoModuleMockRecorder) GetCommitAncestors(name, commitHash interface{}, limit ...interface{}) *gomock.Call {
+// ExportPosts indicates an expected call of ExportPosts.
+func (mr *MockRepoModuleMockRecorder) ExportPosts(name, kind interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{name, commitHash}, limit...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitAncestors", reflect.TypeOf((*MockRepoModule)(nil).GetCommitAncestors), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportPosts", reflect.TypeOf((*MockRepoModule)(nil).ExportPosts), name, kind)
 }
 
-// GetCommits mocks base method.
-func (m *MockRepoModule) GetCommits(reference, branch string, limit ...int) []util.Map {
+// Get mocks base method.
+func (m *MockRepoModule) Get(name string, opts ...types.GetOptions) util.Map {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{reference, branch}
-	for _, a := range limit {
-		varargs = append(varargs, a)
+	varargs := []interface{}{name}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Get", varargs...)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepoModuleMockRecorder) Get(name interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{name}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepoModule)(nil).Get), varargs...)
+}
+
+// GetBranchDivergence mocks base method.
+func (m *MockRepoModule) GetBranchDivergence(name, branch string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBranchDivergence", name, branch)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// GetBranchDivergence indicates an expected call of GetBranchDivergence.
+func (mr *MockRepoModuleMockRecorder) GetBranchDivergence(name, branch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranchDivergence", reflect.TypeOf((*MockRepoModule)(nil).GetBranchDivergence), name, branch)
+}
+
+// GetBranches mocks base method.
+func (m *MockRepoModule) GetBranches(name string) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBranches", name)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetBranches indicates an expected call of GetBranches.
+func (mr *MockRepoModuleMockRecorder) GetBranches(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranches", reflect.TypeOf((*MockRepoModule)(nil).GetBranches), name)
+}
+
+// GetBranchesWithMeta mocks base method.
+func (m *MockRepoModule) GetBranchesWithMeta(name string) []util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBranchesWithMeta", name)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// GetBranchesWithMeta indicates an expected call of GetBranchesWithMeta.
+func (mr *MockRepoModuleMockRecorder) GetBranchesWithMeta(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranchesWithMeta", reflect.TypeOf((*MockRepoModule)(nil).GetBranchesWithMeta), name)
+}
+
+// GetClosedProposals mocks base method.
+func (m *MockRepoModule) GetClosedProposals(name string, opts ...types.ClosedProposalsOptions) []util.Map {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{name}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetClosedProposals", varargs...)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// GetClosedProposals indicates an expected call of GetClosedProposals.
+func (mr *MockRepoModuleMockRecorder) GetClosedProposals(name interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{name}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClosedProposals", reflect.TypeOf((*MockRepoModule)(nil).GetClosedProposals), varargs...)
+}
+
+// GetCodeOwnership mocks base method.
+func (m *MockRepoModule) GetCodeOwnership(name, path string, revision ...string) []util.Map {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{name, path}
+	for _, a := range revision {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetCodeOwnership", varargs...)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// GetCodeOwnership indicates an expected call of GetCodeOwnership.
+func (mr *MockRepoModuleMockRecorder) GetCodeOwnership(name, path interface{}, revision ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{name, path}, revision...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCodeOwnership", reflect.TypeOf((*MockRepoModule)(nil).GetCodeOwnership), varargs...)
+}
+
+// GetCommit mocks base method.
+func (m *MockRepoModule) GetCommit(name, hash string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommit", name, hash)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// GetCommit indicates an expected call of GetCommit.
+func (mr *MockRepoModuleMockRecorder) GetCommit(name, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommit", reflect.TypeOf((*MockRepoModule)(nil).GetCommit), name, hash)
+}
+
+// GetCommitAncestors mocks base method.
+func (m *MockRepoModule) GetCommitAncestors(name, commitHash string, limit ...int) []util.Map {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{name, commitHash}
+	for _, a := range limit {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetCommitAncestors", varargs...)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// GetCommitAncestors indicates an expected call of GetCommitAncestors.
+func (mr *MockRepoModuleMockRecorder) GetCommitAncestors(name, commitHash interface{}, limit ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{name, commitHash}, limit...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitAncestors", reflect.TypeOf((*MockRepoModule)(nil).GetCommitAncestors), varargs...)
+}
+
+// GetCommitGraphDOT mocks base method.
+func (m *MockRepoModule) GetCommitGraphDOT(name, branch string, limit int) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommitGraphDOT", name, branch, limit)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetCommitGraphDOT indicates an expected call of GetCommitGraphDOT.
+func (mr *MockRepoModuleMockRecorder) GetCommitGraphDOT(name, branch, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitGraphDOT", reflect.TypeOf((*MockRepoModule)(nil).GetCommitGraphDOT), name, branch, limit)
+}
+
+// GetCommitNotes mocks base method.
+func (m *MockRepoModule) GetCommitNotes(name, commitHash, notesRef string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommitNotes", name, commitHash, notesRef)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetCommitNotes indicates an expected call of GetCommitNotes.
+func (mr *MockRepoModuleMockRecorder) GetCommitNotes(name, commitHash, notesRef interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitNotes", reflect.TypeOf((*MockRepoModule)(nil).GetCommitNotes), name, commitHash, notesRef)
+}
+
+// GetCommitTree mocks base method.
+func (m *MockRepoModule) GetCommitTree(name, commitHash string) []util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommitTree", name, commitHash)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// GetCommitTree indicates an expected call of GetCommitTree.
+func (mr *MockRepoModuleMockRecorder) GetCommitTree(name, commitHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitTree", reflect.TypeOf((*MockRepoModule)(nil).GetCommitTree), name, commitHash)
+}
+
+// GetCommits mocks base method.
+func (m *MockRepoModule) GetCommits(name, branch string, opts ...types.GetCommitsOptions) []util.Map {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{name, branch}
+	for _, a := range opts {
+		varargs = append(varargs, a)
 	}
 	ret := m.ctrl.Call(m, "GetCommits", varargs...)
 	ret0, _ := ret[0].([]util.Map)
@@ -1364,12 +1905,68 @@ func (m *MockRepoModule) GetCommits(reference, branch string, limit ...int) []ut
 }
 
 // GetCommits indicates an expected call of GetCommits.
-func (mr *MockRepoModuleMockRecorder) GetCommits(reference, branch interface{}, limit ...interface{}) *gomock.Call {
+func (mr *MockRepoModuleMockRecorder) GetCommits(name, branch interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{reference, branch}, limit...)
+	varargs := append([]interface{}{name, branch}, opts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommits", reflect.TypeOf((*MockRepoModule)(nil).GetCommits), varargs...)
 }
 
+// GetDefaultBranch mocks base method.
+func (m *MockRepoModule) GetDefaultBranch(name string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultBranch", name)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetDefaultBranch indicates an expected call of GetDefaultBranch.
+func (mr *MockRepoModuleMockRecorder) GetDefaultBranch(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultBranch", reflect.TypeOf((*MockRepoModule)(nil).GetDefaultBranch), name)
+}
+
+// GetEffectivePolicies mocks base method.
+func (m *MockRepoModule) GetEffectivePolicies(name, pushKeyID string) []util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEffectivePolicies", name, pushKeyID)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// GetEffectivePolicies indicates an expected call of GetEffectivePolicies.
+func (mr *MockRepoModuleMockRecorder) GetEffectivePolicies(name, pushKeyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEffectivePolicies", reflect.TypeOf((*MockRepoModule)(nil).GetEffectivePolicies), name, pushKeyID)
+}
+
+// GetFileHistory mocks base method.
+func (m *MockRepoModule) GetFileHistory(name, filePath string, limit int) []util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileHistory", name, filePath, limit)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// GetFileHistory indicates an expected call of GetFileHistory.
+func (mr *MockRepoModuleMockRecorder) GetFileHistory(name, filePath, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileHistory", reflect.TypeOf((*MockRepoModule)(nil).GetFileHistory), name, filePath, limit)
+}
+
+// GetGovernance mocks base method.
+func (m *MockRepoModule) GetGovernance(name string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGovernance", name)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// GetGovernance indicates an expected call of GetGovernance.
+func (mr *MockRepoModuleMockRecorder) GetGovernance(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGovernance", reflect.TypeOf((*MockRepoModule)(nil).GetGovernance), name)
+}
+
 // GetLatestBranchCommit mocks base method.
 func (m *MockRepoModule) GetLatestBranchCommit(name, branch string) util.Map {
 	m.ctrl.T.Helper()
@@ -1384,6 +1981,48 @@ func (mr *MockRepoModuleMockRecorder) GetLatestBranchCommit(name, branch interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestBranchCommit", reflect.TypeOf((*MockRepoModule)(nil).GetLatestBranchCommit), name, branch)
 }
 
+// GetMergeBase mocks base method.
+func (m *MockRepoModule) GetMergeBase(name, commitA, commitB string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMergeBase", name, commitA, commitB)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetMergeBase indicates an expected call of GetMergeBase.
+func (mr *MockRepoModuleMockRecorder) GetMergeBase(name, commitA, commitB interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMergeBase", reflect.TypeOf((*MockRepoModule)(nil).GetMergeBase), name, commitA, commitB)
+}
+
+// GetMissingObjects mocks base method.
+func (m *MockRepoModule) GetMissingObjects(name, ref string) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMissingObjects", name, ref)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetMissingObjects indicates an expected call of GetMissingObjects.
+func (mr *MockRepoModuleMockRecorder) GetMissingObjects(name, ref interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMissingObjects", reflect.TypeOf((*MockRepoModule)(nil).GetMissingObjects), name, ref)
+}
+
+// GetObjectStats mocks base method.
+func (m *MockRepoModule) GetObjectStats(name string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectStats", name)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// GetObjectStats indicates an expected call of GetObjectStats.
+func (mr *MockRepoModuleMockRecorder) GetObjectStats(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectStats", reflect.TypeOf((*MockRepoModule)(nil).GetObjectStats), name)
+}
+
 // GetParentsAndCommitDiff mocks base method.
 func (m *MockRepoModule) GetParentsAndCommitDiff(name, commitHash string) util.Map {
 	m.ctrl.T.Helper()
@@ -1398,6 +2037,212 @@ func (mr *MockRepoModuleMockRecorder) GetParentsAndCommitDiff(name, commitHash i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParentsAndCommitDiff", reflect.TypeOf((*MockRepoModule)(nil).GetParentsAndCommitDiff), name, commitHash)
 }
 
+// GetProposalConfigDiff mocks base method.
+func (m *MockRepoModule) GetProposalConfigDiff(name, id string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProposalConfigDiff", name, id)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// GetProposalConfigDiff indicates an expected call of GetProposalConfigDiff.
+func (mr *MockRepoModuleMockRecorder) GetProposalConfigDiff(name, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProposalConfigDiff", reflect.TypeOf((*MockRepoModule)(nil).GetProposalConfigDiff), name, id)
+}
+
+// GetProvenanceChain mocks base method.
+func (m *MockRepoModule) GetProvenanceChain(name, commitHash string, limit int) []util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProvenanceChain", name, commitHash, limit)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// GetProvenanceChain indicates an expected call of GetProvenanceChain.
+func (mr *MockRepoModuleMockRecorder) GetProvenanceChain(name, commitHash, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProvenanceChain", reflect.TypeOf((*MockRepoModule)(nil).GetProvenanceChain), name, commitHash, limit)
+}
+
+// GetPushEndorsements mocks base method.
+func (m *MockRepoModule) GetPushEndorsements(hash string) []util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPushEndorsements", hash)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// GetPushEndorsements indicates an expected call of GetPushEndorsements.
+func (mr *MockRepoModuleMockRecorder) GetPushEndorsements(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPushEndorsements", reflect.TypeOf((*MockRepoModule)(nil).GetPushEndorsements), hash)
+}
+
+// GetPendingPushes mocks base method.
+func (m *MockRepoModule) GetPendingPushes() []util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingPushes")
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// GetPendingPushes indicates an expected call of GetPendingPushes.
+func (mr *MockRepoModuleMockRecorder) GetPendingPushes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingPushes", reflect.TypeOf((*MockRepoModule)(nil).GetPendingPushes))
+}
+
+// GetPushedRefs mocks base method.
+func (m *MockRepoModule) GetPushedRefs(hash string) []util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPushedRefs", hash)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// GetPushedRefs indicates an expected call of GetPushedRefs.
+func (mr *MockRepoModuleMockRecorder) GetPushedRefs(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPushedRefs", reflect.TypeOf((*MockRepoModule)(nil).GetPushedRefs), hash)
+}
+
+// GetReadme mocks base method.
+func (m *MockRepoModule) GetReadme(name, branch string, preferredNames ...string) util.Map {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{name, branch}
+	for _, a := range preferredNames {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetReadme", varargs...)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// GetReadme indicates an expected call of GetReadme.
+func (mr *MockRepoModuleMockRecorder) GetReadme(name, branch interface{}, preferredNames ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{name, branch}, preferredNames...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReadme", reflect.TypeOf((*MockRepoModule)(nil).GetReadme), varargs...)
+}
+
+// GetReferenceNonce mocks base method.
+func (m *MockRepoModule) GetReferenceNonce(name, ref string) uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReferenceNonce", name, ref)
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// GetReferenceNonce indicates an expected call of GetReferenceNonce.
+func (mr *MockRepoModuleMockRecorder) GetReferenceNonce(name, ref interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReferenceNonce", reflect.TypeOf((*MockRepoModule)(nil).GetReferenceNonce), name, ref)
+}
+
+// GetRefsContaining mocks base method.
+func (m *MockRepoModule) GetRefsContaining(name, commitHash string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefsContaining", name, commitHash)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// GetRefsContaining indicates an expected call of GetRefsContaining.
+func (mr *MockRepoModuleMockRecorder) GetRefsContaining(name, commitHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefsContaining", reflect.TypeOf((*MockRepoModule)(nil).GetRefsContaining), name, commitHash)
+}
+
+// GetRepoConfigHistory mocks base method.
+func (m *MockRepoModule) GetRepoConfigHistory(name string, fromHeight, toHeight uint64) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepoConfigHistory", name, fromHeight, toHeight)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// GetRepoConfigHistory indicates an expected call of GetRepoConfigHistory.
+func (mr *MockRepoModuleMockRecorder) GetRepoConfigHistory(name, fromHeight, toHeight interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepoConfigHistory", reflect.TypeOf((*MockRepoModule)(nil).GetRepoConfigHistory), name, fromHeight, toHeight)
+}
+
+// GetRepoContentHash mocks base method.
+func (m *MockRepoModule) GetRepoContentHash(name, ref string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepoContentHash", name, ref)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetRepoContentHash indicates an expected call of GetRepoContentHash.
+func (mr *MockRepoModuleMockRecorder) GetRepoContentHash(name, ref interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepoContentHash", reflect.TypeOf((*MockRepoModule)(nil).GetRepoContentHash), name, ref)
+}
+
+// GetRepoMeta mocks base method.
+func (m *MockRepoModule) GetRepoMeta(name string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepoMeta", name)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// GetRepoMeta indicates an expected call of GetRepoMeta.
+func (mr *MockRepoModuleMockRecorder) GetRepoMeta(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepoMeta", reflect.TypeOf((*MockRepoModule)(nil).GetRepoMeta), name)
+}
+
+// GetRepoNamespaces mocks base method.
+func (m *MockRepoModule) GetRepoNamespaces(name string) []util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepoNamespaces", name)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// GetRepoNamespaces indicates an expected call of GetRepoNamespaces.
+func (mr *MockRepoModuleMockRecorder) GetRepoNamespaces(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepoNamespaces", reflect.TypeOf((*MockRepoModule)(nil).GetRepoNamespaces), name)
+}
+
+// GetRepoStats mocks base method.
+func (m *MockRepoModule) GetRepoStats(name string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepoStats", name)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// GetRepoStats indicates an expected call of GetRepoStats.
+func (mr *MockRepoModuleMockRecorder) GetRepoStats(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepoStats", reflect.TypeOf((*MockRepoModule)(nil).GetRepoStats), name)
+}
+
+// GetRepoTimeline mocks base method.
+func (m *MockRepoModule) GetRepoTimeline(name string, opts ...types.RepoTimelineOptions) []util.Map {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{name}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetRepoTimeline", varargs...)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// GetRepoTimeline indicates an expected call of GetRepoTimeline.
+func (mr *MockRepoModuleMockRecorder) GetRepoTimeline(name interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{name}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepoTimeline", reflect.TypeOf((*MockRepoModule)(nil).GetRepoTimeline), varargs...)
+}
+
 // GetReposCreatedByAddress mocks base method.
 func (m *MockRepoModule) GetReposCreatedByAddress(address string) []string {
 	m.ctrl.T.Helper()
@@ -1412,6 +2257,34 @@ func (mr *MockRepoModuleMockRecorder) GetReposCreatedByAddress(address interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReposCreatedByAddress", reflect.TypeOf((*MockRepoModule)(nil).GetReposCreatedByAddress), address)
 }
 
+// GetStaleBranches mocks base method.
+func (m *MockRepoModule) GetStaleBranches(name, age string) []util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStaleBranches", name, age)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// GetStaleBranches indicates an expected call of GetStaleBranches.
+func (mr *MockRepoModuleMockRecorder) GetStaleBranches(name, age interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStaleBranches", reflect.TypeOf((*MockRepoModule)(nil).GetStaleBranches), name, age)
+}
+
+// GetTagSignatureInfo mocks base method.
+func (m *MockRepoModule) GetTagSignatureInfo(name, tag string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagSignatureInfo", name, tag)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// GetTagSignatureInfo indicates an expected call of GetTagSignatureInfo.
+func (mr *MockRepoModuleMockRecorder) GetTagSignatureInfo(name, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagSignatureInfo", reflect.TypeOf((*MockRepoModule)(nil).GetTagSignatureInfo), name, tag)
+}
+
 // GetTracked mocks base method.
 func (m *MockRepoModule) GetTracked() util.Map {
 	m.ctrl.T.Helper()
@@ -1426,32 +2299,84 @@ func (mr *MockRepoModuleMockRecorder) GetTracked() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracked", reflect.TypeOf((*MockRepoModule)(nil).GetTracked))
 }
 
+// GetTrackedStatus mocks base method.
+func (m *MockRepoModule) GetTrackedStatus() []util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrackedStatus")
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// GetTrackedStatus indicates an expected call of GetTrackedStatus.
+func (mr *MockRepoModuleMockRecorder) GetTrackedStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrackedStatus", reflect.TypeOf((*MockRepoModule)(nil).GetTrackedStatus))
+}
+
+// GetVotingPower mocks base method.
+func (m *MockRepoModule) GetVotingPower(name, id, address string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVotingPower", name, id, address)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// GetVotingPower indicates an expected call of GetVotingPower.
+func (mr *MockRepoModuleMockRecorder) GetVotingPower(name, id, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVotingPower", reflect.TypeOf((*MockRepoModule)(nil).GetVotingPower), name, id, address)
+}
+
+// ImportPosts mocks base method.
+func (m *MockRepoModule) ImportPosts(name, bundle string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportPosts", name, bundle)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// ImportPosts indicates an expected call of ImportPosts.
+func (mr *MockRepoModuleMockRecorder) ImportPosts(name, bundle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportPosts", reflect.TypeOf((*MockRepoModule)(nil).ImportPosts), name, bundle)
+}
+
 // ListIssues mocks base method.
-func (m *MockRepoModule) ListIssues(name string) []util.Map {
+func (m *MockRepoModule) ListIssues(name string, opts ...types.ListPostsOptions) []util.Map {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListIssues", name)
+	varargs := []interface{}{name}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListIssues", varargs...)
 	ret0, _ := ret[0].([]util.Map)
 	return ret0
 }
 
 // ListIssues indicates an expected call of ListIssues.
-func (mr *MockRepoModuleMockRecorder) ListIssues(name interface{}) *gomock.Call {
+func (mr *MockRepoModuleMockRecorder) ListIssues(name interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIssues", reflect.TypeOf((*MockRepoModule)(nil).ListIssues), name)
+	varargs := append([]interface{}{name}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIssues", reflect.TypeOf((*MockRepoModule)(nil).ListIssues), varargs...)
 }
 
 // ListMergeRequests mocks base method.
-func (m *MockRepoModule) ListMergeRequests(name string) []util.Map {
+func (m *MockRepoModule) ListMergeRequests(name string, opts ...types.ListPostsOptions) []util.Map {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListMergeRequests", name)
+	varargs := []interface{}{name}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListMergeRequests", varargs...)
 	ret0, _ := ret[0].([]util.Map)
 	return ret0
 }
 
 // ListMergeRequests indicates an expected call of ListMergeRequests.
-func (mr *MockRepoModuleMockRecorder) ListMergeRequests(name interface{}) *gomock.Call {
+func (mr *MockRepoModuleMockRecorder) ListMergeRequests(name interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMergeRequests", reflect.TypeOf((*MockRepoModule)(nil).ListMergeRequests), name)
+	varargs := append([]interface{}{name}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMergeRequests", reflect.TypeOf((*MockRepoModule)(nil).ListMergeRequests), varargs...)
 }
 
 // ListPath mocks base method.
@@ -1473,6 +2398,72 @@ func (mr *MockRepoModuleMockRecorder) ListPath(name, path interface{}, revision
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPath", reflect.TypeOf((*MockRepoModule)(nil).ListPath), varargs...)
 }
 
+// ListProposals mocks base method.
+func (m *MockRepoModule) ListProposals(name string, opts ...types.ListProposalsOptions) []util.Map {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{name}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListProposals", varargs...)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// ListProposals indicates an expected call of ListProposals.
+func (mr *MockRepoModuleMockRecorder) ListProposals(name interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{name}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProposals", reflect.TypeOf((*MockRepoModule)(nil).ListProposals), varargs...)
+}
+
+// ListRefs mocks base method.
+func (m *MockRepoModule) ListRefs(name string, pattern ...string) []util.Map {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{name}
+	for _, a := range pattern {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListRefs", varargs...)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// ListRefs indicates an expected call of ListRefs.
+func (mr *MockRepoModuleMockRecorder) ListRefs(name interface{}, pattern ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{name}, pattern...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRefs", reflect.TypeOf((*MockRepoModule)(nil).ListRefs), varargs...)
+}
+
+// NormalizeRepoName mocks base method.
+func (m *MockRepoModule) NormalizeRepoName(name string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NormalizeRepoName", name)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// NormalizeRepoName indicates an expected call of NormalizeRepoName.
+func (mr *MockRepoModuleMockRecorder) NormalizeRepoName(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NormalizeRepoName", reflect.TypeOf((*MockRepoModule)(nil).NormalizeRepoName), name)
+}
+
+// PreviewPostChange mocks base method.
+func (m *MockRepoModule) PreviewPostChange(id, reference string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewPostChange", id, reference)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// PreviewPostChange indicates an expected call of PreviewPostChange.
+func (mr *MockRepoModuleMockRecorder) PreviewPostChange(id, reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewPostChange", reflect.TypeOf((*MockRepoModule)(nil).PreviewPostChange), id, reference)
+}
+
 // Push mocks base method.
 func (m *MockRepoModule) Push(params map[string]interface{}, privateKeyOrPushToken string) string {
 	m.ctrl.T.Helper()
@@ -1506,6 +2497,25 @@ func (mr *MockRepoModuleMockRecorder) ReadFile(name, filePath interface{}, revis
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadFile", reflect.TypeOf((*MockRepoModule)(nil).ReadFile), varargs...)
 }
 
+// ReadFileChunk mocks base method.
+func (m *MockRepoModule) ReadFileChunk(name, filePath string, offset, length int64, revision ...string) util.Map {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{name, filePath, offset, length}
+	for _, a := range revision {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReadFileChunk", varargs...)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// ReadFileChunk indicates an expected call of ReadFileChunk.
+func (mr *MockRepoModuleMockRecorder) ReadFileChunk(name, filePath, offset, length interface{}, revision ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{name, filePath, offset, length}, revision...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadFileChunk", reflect.TypeOf((*MockRepoModule)(nil).ReadFileChunk), varargs...)
+}
+
 // ReadFileLines mocks base method.
 func (m *MockRepoModule) ReadFileLines(name, filePath string, revision ...string) []string {
 	m.ctrl.T.Helper()
@@ -1539,6 +2549,20 @@ func (mr *MockRepoModuleMockRecorder) ReadIssue(name, reference interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadIssue", reflect.TypeOf((*MockRepoModule)(nil).ReadIssue), name, reference)
 }
 
+// ReadIssueThread mocks base method.
+func (m *MockRepoModule) ReadIssueThread(name, reference string) []util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadIssueThread", name, reference)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// ReadIssueThread indicates an expected call of ReadIssueThread.
+func (mr *MockRepoModuleMockRecorder) ReadIssueThread(name, reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadIssueThread", reflect.TypeOf((*MockRepoModule)(nil).ReadIssueThread), name, reference)
+}
+
 // ReadMergeRequest mocks base method.
 func (m *MockRepoModule) ReadMergeRequest(name, reference string) []util.Map {
 	m.ctrl.T.Helper()
@@ -1553,6 +2577,34 @@ func (mr *MockRepoModuleMockRecorder) ReadMergeRequest(name, reference interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadMergeRequest", reflect.TypeOf((*MockRepoModule)(nil).ReadMergeRequest), name, reference)
 }
 
+// ReadMergeRequestThread mocks base method.
+func (m *MockRepoModule) ReadMergeRequestThread(name, reference string) []util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadMergeRequestThread", name, reference)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// ReadMergeRequestThread indicates an expected call of ReadMergeRequestThread.
+func (mr *MockRepoModuleMockRecorder) ReadMergeRequestThread(name, reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadMergeRequestThread", reflect.TypeOf((*MockRepoModule)(nil).ReadMergeRequestThread), name, reference)
+}
+
+// ReconcileRepo mocks base method.
+func (m *MockRepoModule) ReconcileRepo(name string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileRepo", name)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// ReconcileRepo indicates an expected call of ReconcileRepo.
+func (mr *MockRepoModuleMockRecorder) ReconcileRepo(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileRepo", reflect.TypeOf((*MockRepoModule)(nil).ReconcileRepo), name)
+}
+
 // ReopenIssue mocks base method.
 func (m *MockRepoModule) ReopenIssue(name, reference string) util.Map {
 	m.ctrl.T.Helper()
@@ -1581,6 +2633,88 @@ func (mr *MockRepoModuleMockRecorder) ReopenMergeRequest(name, reference interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReopenMergeRequest", reflect.TypeOf((*MockRepoModule)(nil).ReopenMergeRequest), name, reference)
 }
 
+// ResignRefs mocks base method.
+func (m *MockRepoModule) ResignRefs(params map[string]interface{}, privateKey string) []util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResignRefs", params, privateKey)
+	ret0, _ := ret[0].([]util.Map)
+	return ret0
+}
+
+// ResignRefs indicates an expected call of ResignRefs.
+func (mr *MockRepoModuleMockRecorder) ResignRefs(params, privateKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResignRefs", reflect.TypeOf((*MockRepoModule)(nil).ResignRefs), params, privateKey)
+}
+
+// ResolveURI mocks base method.
+func (m *MockRepoModule) ResolveURI(uri string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveURI", uri)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// ResolveURI indicates an expected call of ResolveURI.
+func (mr *MockRepoModuleMockRecorder) ResolveURI(uri interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveURI", reflect.TypeOf((*MockRepoModule)(nil).ResolveURI), uri)
+}
+
+// RestoreTempRepo mocks base method.
+func (m *MockRepoModule) RestoreTempRepo(data string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTempRepo", data)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// RestoreTempRepo indicates an expected call of RestoreTempRepo.
+func (mr *MockRepoModuleMockRecorder) RestoreTempRepo(data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTempRepo", reflect.TypeOf((*MockRepoModule)(nil).RestoreTempRepo), data)
+}
+
+// SnapshotTempRepo mocks base method.
+func (m *MockRepoModule) SnapshotTempRepo(id string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotTempRepo", id)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// SnapshotTempRepo indicates an expected call of SnapshotTempRepo.
+func (mr *MockRepoModuleMockRecorder) SnapshotTempRepo(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotTempRepo", reflect.TypeOf((*MockRepoModule)(nil).SnapshotTempRepo), id)
+}
+
+// StreamParentsAndCommitDiff mocks base method.
+func (m *MockRepoModule) StreamParentsAndCommitDiff(name, commitHash string, cb func(string, string) error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "StreamParentsAndCommitDiff", name, commitHash, cb)
+}
+
+// StreamParentsAndCommitDiff indicates an expected call of StreamParentsAndCommitDiff.
+func (mr *MockRepoModuleMockRecorder) StreamParentsAndCommitDiff(name, commitHash, cb interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamParentsAndCommitDiff", reflect.TypeOf((*MockRepoModule)(nil).StreamParentsAndCommitDiff), name, commitHash, cb)
+}
+
+// SyncFromUpstream mocks base method.
+func (m *MockRepoModule) SyncFromUpstream(name string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncFromUpstream", name)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// SyncFromUpstream indicates an expected call of SyncFromUpstream.
+func (mr *MockRepoModuleMockRecorder) SyncFromUpstream(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncFromUpstream", reflect.TypeOf((*MockRepoModule)(nil).SyncFromUpstream), name)
+}
+
 // Track mocks base method.
 func (m *MockRepoModule) Track(names string, height ...uint64) {
 	m.ctrl.T.Helper()
@@ -1648,6 +2782,53 @@ func (mr *MockRepoModuleMockRecorder) UpsertOwner(params interface{}, options ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertOwner", reflect.TypeOf((*MockRepoModule)(nil).UpsertOwner), varargs...)
 }
 
+// ValidateRepoConfig mocks base method.
+func (m *MockRepoModule) ValidateRepoConfig(config map[string]interface{}) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateRepoConfig", config)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// ValidateRepoConfig indicates an expected call of ValidateRepoConfig.
+func (mr *MockRepoModuleMockRecorder) ValidateRepoConfig(config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateRepoConfig", reflect.TypeOf((*MockRepoModule)(nil).ValidateRepoConfig), config)
+}
+
+// VerifyBranchSignatures mocks base method.
+func (m *MockRepoModule) VerifyBranchSignatures(name, branch string, limit ...int) util.Map {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{name, branch}
+	for _, a := range limit {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "VerifyBranchSignatures", varargs...)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// VerifyBranchSignatures indicates an expected call of VerifyBranchSignatures.
+func (mr *MockRepoModuleMockRecorder) VerifyBranchSignatures(name, branch interface{}, limit ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{name, branch}, limit...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyBranchSignatures", reflect.TypeOf((*MockRepoModule)(nil).VerifyBranchSignatures), varargs...)
+}
+
+// VerifyRepoIntegrity mocks base method.
+func (m *MockRepoModule) VerifyRepoIntegrity(name string) util.Map {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyRepoIntegrity", name)
+	ret0, _ := ret[0].(util.Map)
+	return ret0
+}
+
+// VerifyRepoIntegrity indicates an expected call of VerifyRepoIntegrity.
+func (mr *MockRepoModuleMockRecorder) VerifyRepoIntegrity(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyRepoIntegrity", reflect.TypeOf((*MockRepoModule)(nil).VerifyRepoIntegrity), name)
+}
+
 // Vote mocks base method.
 func (m *MockRepoModule) Vote(params map[string]interface{}, options ...interface{}) util.Map {
 	m.ctrl.T.Helper()
@@ -1667,6 +2848,20 @@ func (mr *MockRepoModuleMockRecorder) Vote(params interface{}, options ...interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vote", reflect.TypeOf((*MockRepoModule)(nil).Vote), varargs...)
 }
 
+// WithReadToken mocks base method.
+func (m *MockRepoModule) WithReadToken(token string) types.RepoModule {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithReadToken", token)
+	ret0, _ := ret[0].(types.RepoModule)
+	return ret0
+}
+
+// WithReadToken indicates an expected call of WithReadToken.
+func (mr *MockRepoModuleMockRecorder) WithReadToken(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithReadToken", reflect.TypeOf((*MockRepoModule)(nil).WithReadToken), token)
+}
+
 // MockNamespaceModule is a mock of NamespaceModule interface.
 type MockNamespaceModule struct {
 	ctrl     *gomock.Controller
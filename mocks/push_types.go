@@ -7,6 +7,7 @@ package mocks
 import (
 	io "io"
 	reflect "reflect"
+	time "time"
 
 	pktline "github.com/go-git/go-git/v5/plumbing/format/pktline"
 	packp "github.com/go-git/go-git/v5/plumbing/protocol/packp"
@@ -288,6 +289,18 @@ func (mr *MockPushPoolMockRecorder) Len() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Len", reflect.TypeOf((*MockPushPool)(nil).Len))
 }
 
+// MarkEndorsed mocks base method.
+func (m *MockPushPool) MarkEndorsed(noteID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MarkEndorsed", noteID)
+}
+
+// MarkEndorsed indicates an expected call of MarkEndorsed.
+func (mr *MockPushPoolMockRecorder) MarkEndorsed(noteID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEndorsed", reflect.TypeOf((*MockPushPool)(nil).MarkEndorsed), noteID)
+}
+
 // Remove mocks base method.
 func (m *MockPushPool) Remove(pushNote types.PushNote) {
 	m.ctrl.T.Helper()
@@ -300,6 +313,34 @@ func (mr *MockPushPoolMockRecorder) Remove(pushNote interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockPushPool)(nil).Remove), pushNote)
 }
 
+// RemoveUnendorsed mocks base method.
+func (m *MockPushPool) RemoveUnendorsed(timeout time.Duration) []types.PushNote {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUnendorsed", timeout)
+	ret0, _ := ret[0].([]types.PushNote)
+	return ret0
+}
+
+// RemoveUnendorsed indicates an expected call of RemoveUnendorsed.
+func (mr *MockPushPoolMockRecorder) RemoveUnendorsed(timeout interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUnendorsed", reflect.TypeOf((*MockPushPool)(nil).RemoveUnendorsed), timeout)
+}
+
+// GetUnendorsed mocks base method.
+func (m *MockPushPool) GetUnendorsed() []*types.PendingNote {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnendorsed")
+	ret0, _ := ret[0].([]*types.PendingNote)
+	return ret0
+}
+
+// GetUnendorsed indicates an expected call of GetUnendorsed.
+func (mr *MockPushPoolMockRecorder) GetUnendorsed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnendorsed", reflect.TypeOf((*MockPushPool)(nil).GetUnendorsed))
+}
+
 // MockPushNote is a mock of PushNote interface.
 type MockPushNote struct {
 	ctrl     *gomock.Controller
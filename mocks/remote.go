@@ -177,6 +177,20 @@ func (mr *MockRemoteServerMockRecorder) GetDHT() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDHT", reflect.TypeOf((*MockRemoteServer)(nil).GetDHT))
 }
 
+// GetNoteEndorsements mocks base method.
+func (m *MockRemoteServer) GetNoteEndorsements(noteID string) []*types.PushEndorsement {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNoteEndorsements", noteID)
+	ret0, _ := ret[0].([]*types.PushEndorsement)
+	return ret0
+}
+
+// GetNoteEndorsements indicates an expected call of GetNoteEndorsements.
+func (mr *MockRemoteServerMockRecorder) GetNoteEndorsements(noteID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNoteEndorsements", reflect.TypeOf((*MockRemoteServer)(nil).GetNoteEndorsements), noteID)
+}
+
 // GetFetcher mocks base method.
 func (m *MockRemoteServer) GetFetcher() fetcher.ObjectFetcher {
 	m.ctrl.T.Helper()
@@ -275,6 +289,20 @@ func (mr *MockRemoteServerMockRecorder) GetRPCHandler() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRPCHandler", reflect.TypeOf((*MockRemoteServer)(nil).GetRPCHandler))
 }
 
+// GetRecentPushTx mocks base method.
+func (m *MockRemoteServer) GetRecentPushTx(key string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentPushTx", key)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetRecentPushTx indicates an expected call of GetRecentPushTx.
+func (mr *MockRemoteServerMockRecorder) GetRecentPushTx(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentPushTx", reflect.TypeOf((*MockRemoteServer)(nil).GetRecentPushTx), key)
+}
+
 // GetRepo mocks base method.
 func (m *MockRemoteServer) GetRepo(name string) (plumbing.LocalRepo, error) {
 	m.ctrl.T.Helper()
@@ -324,6 +352,20 @@ func (mr *MockRemoteServerMockRecorder) GetTempRepoManager() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTempRepoManager", reflect.TypeOf((*MockRemoteServer)(nil).GetTempRepoManager))
 }
 
+// HasRepository mocks base method.
+func (m *MockRemoteServer) HasRepository(name string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasRepository", name)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasRepository indicates an expected call of HasRepository.
+func (mr *MockRemoteServerMockRecorder) HasRepository(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasRepository", reflect.TypeOf((*MockRemoteServer)(nil).HasRepository), name)
+}
+
 // InitRepository mocks base method.
 func (m *MockRemoteServer) InitRepository(name string) error {
 	m.ctrl.T.Helper()
@@ -352,6 +394,18 @@ func (mr *MockRemoteServerMockRecorder) Log() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Log", reflect.TypeOf((*MockRemoteServer)(nil).Log))
 }
 
+// RegisterRecentPush mocks base method.
+func (m *MockRemoteServer) RegisterRecentPush(key, txHash string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterRecentPush", key, txHash)
+}
+
+// RegisterRecentPush indicates an expected call of RegisterRecentPush.
+func (mr *MockRemoteServerMockRecorder) RegisterRecentPush(key, txHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterRecentPush", reflect.TypeOf((*MockRemoteServer)(nil).RegisterRecentPush), key, txHash)
+}
+
 // Shutdown mocks base method.
 func (m *MockRemoteServer) Shutdown(ctx context.Context) {
 	m.ctrl.T.Helper()
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	bytes "bytes"
+	io "io"
 	reflect "reflect"
 	time "time"
 
@@ -94,6 +95,21 @@ func (mr *MockGitModuleMockRecorder) Checkout(arg0, arg1, arg2 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockGitModule)(nil).Checkout), arg0, arg1, arg2)
 }
 
+// CountObjects mocks base method.
+func (m *MockGitModule) CountObjects() (*plumbing0.ObjectCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountObjects")
+	ret0, _ := ret[0].(*plumbing0.ObjectCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountObjects indicates an expected call of CountObjects.
+func (mr *MockGitModuleMockRecorder) CountObjects() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountObjects", reflect.TypeOf((*MockGitModule)(nil).CountObjects))
+}
+
 // CreateBlob mocks base method.
 func (m *MockGitModule) CreateBlob(arg0 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -177,6 +193,20 @@ func (mr *MockGitModuleMockRecorder) DiffCommits(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffCommits", reflect.TypeOf((*MockGitModule)(nil).DiffCommits), arg0, arg1)
 }
 
+// DiffCommitsStream mocks base method.
+func (m *MockGitModule) DiffCommitsStream(arg0, arg1 string, arg2 func(string) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffCommitsStream", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DiffCommitsStream indicates an expected call of DiffCommitsStream.
+func (mr *MockGitModuleMockRecorder) DiffCommitsStream(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffCommitsStream", reflect.TypeOf((*MockGitModule)(nil).DiffCommitsStream), arg0, arg1, arg2)
+}
+
 // ExpandShortHash mocks base method.
 func (m *MockGitModule) ExpandShortHash(arg0 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -385,6 +415,21 @@ func (mr *MockGitModuleMockRecorder) NumCommits(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NumCommits", reflect.TypeOf((*MockGitModule)(nil).NumCommits), arg0, arg1)
 }
 
+// ProjectedPackSize mocks base method.
+func (m *MockGitModule) ProjectedPackSize() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectedPackSize")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectedPackSize indicates an expected call of ProjectedPackSize.
+func (mr *MockGitModuleMockRecorder) ProjectedPackSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectedPackSize", reflect.TypeOf((*MockGitModule)(nil).ProjectedPackSize))
+}
+
 // RefDelete mocks base method.
 func (m *MockGitModule) RefDelete(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -641,6 +686,21 @@ func (mr *MockLocalRepoMockRecorder) CommitObjects() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitObjects", reflect.TypeOf((*MockLocalRepo)(nil).CommitObjects))
 }
 
+// CompareTags mocks base method.
+func (m *MockLocalRepo) CompareTags(arg0, arg1 string) (*plumbing0.CompareTagsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareTags", arg0, arg1)
+	ret0, _ := ret[0].(*plumbing0.CompareTagsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareTags indicates an expected call of CompareTags.
+func (mr *MockLocalRepoMockRecorder) CompareTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareTags", reflect.TypeOf((*MockLocalRepo)(nil).CompareTags), arg0, arg1)
+}
+
 // Config mocks base method.
 func (m *MockLocalRepo) Config() (*config.Config, error) {
 	m.ctrl.T.Helper()
@@ -656,6 +716,21 @@ func (mr *MockLocalRepoMockRecorder) Config() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Config", reflect.TypeOf((*MockLocalRepo)(nil).Config))
 }
 
+// CountObjects mocks base method.
+func (m *MockLocalRepo) CountObjects() (*plumbing0.ObjectCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountObjects")
+	ret0, _ := ret[0].(*plumbing0.ObjectCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountObjects indicates an expected call of CountObjects.
+func (mr *MockLocalRepoMockRecorder) CountObjects() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountObjects", reflect.TypeOf((*MockLocalRepo)(nil).CountObjects))
+}
+
 // CreateBlob mocks base method.
 func (m *MockLocalRepo) CreateBlob(arg0 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -767,6 +842,35 @@ func (mr *MockLocalRepoMockRecorder) DiffCommits(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffCommits", reflect.TypeOf((*MockLocalRepo)(nil).DiffCommits), arg0, arg1)
 }
 
+// DiffCommitsStream mocks base method.
+func (m *MockLocalRepo) DiffCommitsStream(arg0, arg1 string, arg2 func(string) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffCommitsStream", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DiffCommitsStream indicates an expected call of DiffCommitsStream.
+func (mr *MockLocalRepoMockRecorder) DiffCommitsStream(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffCommitsStream", reflect.TypeOf((*MockLocalRepo)(nil).DiffCommitsStream), arg0, arg1, arg2)
+}
+
+// EstimateCloneSize mocks base method.
+func (m *MockLocalRepo) EstimateCloneSize(arg0 string, arg1 int, arg2 time.Time) (*plumbing0.CloneSizeEstimate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateCloneSize", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*plumbing0.CloneSizeEstimate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateCloneSize indicates an expected call of EstimateCloneSize.
+func (mr *MockLocalRepoMockRecorder) EstimateCloneSize(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateCloneSize", reflect.TypeOf((*MockLocalRepo)(nil).EstimateCloneSize), arg0, arg1, arg2)
+}
+
 // ExpandShortHash mocks base method.
 func (m *MockLocalRepo) ExpandShortHash(arg0 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -782,6 +886,21 @@ func (mr *MockLocalRepoMockRecorder) ExpandShortHash(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpandShortHash", reflect.TypeOf((*MockLocalRepo)(nil).ExpandShortHash), arg0)
 }
 
+// ExportPatchSeries mocks base method.
+func (m *MockLocalRepo) ExportPatchSeries(arg0, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportPatchSeries", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportPatchSeries indicates an expected call of ExportPatchSeries.
+func (mr *MockLocalRepoMockRecorder) ExportPatchSeries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportPatchSeries", reflect.TypeOf((*MockLocalRepo)(nil).ExportPatchSeries), arg0, arg1)
+}
+
 // GC mocks base method.
 func (m *MockLocalRepo) GC(arg0 ...string) error {
 	m.ctrl.T.Helper()
@@ -860,19 +979,79 @@ func (mr *MockLocalRepoMockRecorder) GetCommitAncestors(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitAncestors", reflect.TypeOf((*MockLocalRepo)(nil).GetCommitAncestors), arg0, arg1)
 }
 
+// GetCodeOwnership mocks base method.
+func (m *MockLocalRepo) GetCodeOwnership(arg0, arg1 string) ([]*plumbing0.CodeOwner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCodeOwnership", arg0, arg1)
+	ret0, _ := ret[0].([]*plumbing0.CodeOwner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCodeOwnership indicates an expected call of GetCodeOwnership.
+func (mr *MockLocalRepoMockRecorder) GetCodeOwnership(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCodeOwnership", reflect.TypeOf((*MockLocalRepo)(nil).GetCodeOwnership), arg0, arg1)
+}
+
+// GetCommitTree mocks base method.
+func (m *MockLocalRepo) GetCommitTree(arg0 string) ([]*plumbing0.CommitTreeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommitTree", arg0)
+	ret0, _ := ret[0].([]*plumbing0.CommitTreeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommitTree indicates an expected call of GetCommitTree.
+func (mr *MockLocalRepoMockRecorder) GetCommitTree(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitTree", reflect.TypeOf((*MockLocalRepo)(nil).GetCommitTree), arg0)
+}
+
 // GetCommits mocks base method.
-func (m *MockLocalRepo) GetCommits(arg0 string, arg1 int) ([]*plumbing0.CommitResult, error) {
+func (m *MockLocalRepo) GetCommits(arg0 string, arg1 int, arg2 plumbing0.CommitOrder) ([]*plumbing0.CommitResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCommits", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetCommits", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]*plumbing0.CommitResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCommits indicates an expected call of GetCommits.
-func (mr *MockLocalRepoMockRecorder) GetCommits(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockLocalRepoMockRecorder) GetCommits(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommits", reflect.TypeOf((*MockLocalRepo)(nil).GetCommits), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommits", reflect.TypeOf((*MockLocalRepo)(nil).GetCommits), arg0, arg1, arg2)
+}
+
+// GetCommitsBetween mocks base method.
+func (m *MockLocalRepo) GetCommitsBetween(arg0, arg1 string) ([]*plumbing0.CommitResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommitsBetween", arg0, arg1)
+	ret0, _ := ret[0].([]*plumbing0.CommitResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommitsBetween indicates an expected call of GetCommitsBetween.
+func (mr *MockLocalRepoMockRecorder) GetCommitsBetween(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitsBetween", reflect.TypeOf((*MockLocalRepo)(nil).GetCommitsBetween), arg0, arg1)
+}
+
+// GetContentHash mocks base method.
+func (m *MockLocalRepo) GetContentHash(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContentHash", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContentHash indicates an expected call of GetContentHash.
+func (mr *MockLocalRepoMockRecorder) GetContentHash(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContentHash", reflect.TypeOf((*MockLocalRepo)(nil).GetContentHash), arg0)
 }
 
 // GetFile mocks base method.
@@ -890,6 +1069,52 @@ func (mr *MockLocalRepoMockRecorder) GetFile(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFile", reflect.TypeOf((*MockLocalRepo)(nil).GetFile), arg0, arg1)
 }
 
+// GetFileBlame mocks base method.
+func (m *MockLocalRepo) GetFileBlame(arg0, arg1 string) ([]*plumbing0.BlameLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileBlame", arg0, arg1)
+	ret0, _ := ret[0].([]*plumbing0.BlameLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFileBlame indicates an expected call of GetFileBlame.
+func (mr *MockLocalRepoMockRecorder) GetFileBlame(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileBlame", reflect.TypeOf((*MockLocalRepo)(nil).GetFileBlame), arg0, arg1)
+}
+
+// GetFileChunk mocks base method.
+func (m *MockLocalRepo) GetFileChunk(arg0, arg1 string, arg2, arg3 int64) ([]byte, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileChunk", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFileChunk indicates an expected call of GetFileChunk.
+func (mr *MockLocalRepoMockRecorder) GetFileChunk(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileChunk", reflect.TypeOf((*MockLocalRepo)(nil).GetFileChunk), arg0, arg1, arg2, arg3)
+}
+
+// GetFileHistory mocks base method.
+func (m *MockLocalRepo) GetFileHistory(arg0, arg1 string, arg2 int) ([]*plumbing0.FileHistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*plumbing0.FileHistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFileHistory indicates an expected call of GetFileHistory.
+func (mr *MockLocalRepoMockRecorder) GetFileHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileHistory", reflect.TypeOf((*MockLocalRepo)(nil).GetFileHistory), arg0, arg1, arg2)
+}
+
 // GetFileLines mocks base method.
 func (m *MockLocalRepo) GetFileLines(arg0, arg1 string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -949,6 +1174,21 @@ func (mr *MockLocalRepoMockRecorder) GetLatestCommit(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestCommit", reflect.TypeOf((*MockLocalRepo)(nil).GetLatestCommit), arg0)
 }
 
+// GetMergeBase mocks base method.
+func (m *MockLocalRepo) GetMergeBase(arg0, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMergeBase", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMergeBase indicates an expected call of GetMergeBase.
+func (mr *MockLocalRepoMockRecorder) GetMergeBase(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMergeBase", reflect.TypeOf((*MockLocalRepo)(nil).GetMergeBase), arg0, arg1)
+}
+
 // GetMergeCommits mocks base method.
 func (m *MockLocalRepo) GetMergeCommits(arg0 string, arg1 ...string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -1041,6 +1281,35 @@ func (mr *MockLocalRepoMockRecorder) GetObjectSize(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectSize", reflect.TypeOf((*MockLocalRepo)(nil).GetObjectSize), arg0)
 }
 
+// GetObjectStats mocks base method.
+func (m *MockLocalRepo) GetObjectStats() (*plumbing0.ObjectStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectStats")
+	ret0, _ := ret[0].(*plumbing0.ObjectStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectStats indicates an expected call of GetObjectStats.
+func (mr *MockLocalRepoMockRecorder) GetObjectStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectStats", reflect.TypeOf((*MockLocalRepo)(nil).GetObjectStats))
+}
+
+// GetObjectStore mocks base method.
+func (m *MockLocalRepo) GetObjectStore() plumbing0.ObjectStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectStore")
+	ret0, _ := ret[0].(plumbing0.ObjectStore)
+	return ret0
+}
+
+// GetObjectStore indicates an expected call of GetObjectStore.
+func (mr *MockLocalRepoMockRecorder) GetObjectStore() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectStore", reflect.TypeOf((*MockLocalRepo)(nil).GetObjectStore))
+}
+
 // GetParentAndChildCommitDiff mocks base method.
 func (m *MockLocalRepo) GetParentAndChildCommitDiff(arg0 string) (*plumbing0.GetCommitDiffResult, error) {
 	m.ctrl.T.Helper()
@@ -1150,6 +1419,21 @@ func (mr *MockLocalRepoMockRecorder) GetReferences() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReferences", reflect.TypeOf((*MockLocalRepo)(nil).GetReferences))
 }
 
+// GetRefsContaining mocks base method.
+func (m *MockLocalRepo) GetRefsContaining(arg0 string) (*plumbing0.RefsContainingResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefsContaining", arg0)
+	ret0, _ := ret[0].(*plumbing0.RefsContainingResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefsContaining indicates an expected call of GetRefsContaining.
+func (mr *MockLocalRepoMockRecorder) GetRefsContaining(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefsContaining", reflect.TypeOf((*MockLocalRepo)(nil).GetRefsContaining), arg0)
+}
+
 // GetRemoteURLs mocks base method.
 func (m *MockLocalRepo) GetRemoteURLs(arg0 ...string) []string {
 	m.ctrl.T.Helper()
@@ -1433,6 +1717,20 @@ func (mr *MockLocalRepoMockRecorder) Objects() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Objects", reflect.TypeOf((*MockLocalRepo)(nil).Objects))
 }
 
+// ObjectsExist mocks base method.
+func (m *MockLocalRepo) ObjectsExist(arg0 []string) map[string]bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ObjectsExist", arg0)
+	ret0, _ := ret[0].(map[string]bool)
+	return ret0
+}
+
+// ObjectsExist indicates an expected call of ObjectsExist.
+func (mr *MockLocalRepoMockRecorder) ObjectsExist(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObjectsExist", reflect.TypeOf((*MockLocalRepo)(nil).ObjectsExist), arg0)
+}
+
 // ObjectsOfCommit mocks base method.
 func (m *MockLocalRepo) ObjectsOfCommit(arg0 string) ([]plumbing.Hash, error) {
 	m.ctrl.T.Helper()
@@ -1448,6 +1746,21 @@ func (mr *MockLocalRepoMockRecorder) ObjectsOfCommit(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObjectsOfCommit", reflect.TypeOf((*MockLocalRepo)(nil).ObjectsOfCommit), arg0)
 }
 
+// ProjectedPackSize mocks base method.
+func (m *MockLocalRepo) ProjectedPackSize() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectedPackSize")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectedPackSize indicates an expected call of ProjectedPackSize.
+func (mr *MockLocalRepoMockRecorder) ProjectedPackSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectedPackSize", reflect.TypeOf((*MockLocalRepo)(nil).ProjectedPackSize))
+}
+
 // Prune mocks base method.
 func (m *MockLocalRepo) Prune(arg0 time.Time) error {
 	m.ctrl.T.Helper()
@@ -1627,6 +1940,18 @@ func (mr *MockLocalRepoMockRecorder) SetConfig(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetConfig", reflect.TypeOf((*MockLocalRepo)(nil).SetConfig), arg0)
 }
 
+// SetObjectStore mocks base method.
+func (m *MockLocalRepo) SetObjectStore(arg0 plumbing0.ObjectStore) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetObjectStore", arg0)
+}
+
+// SetObjectStore indicates an expected call of SetObjectStore.
+func (mr *MockLocalRepoMockRecorder) SetObjectStore(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetObjectStore", reflect.TypeOf((*MockLocalRepo)(nil).SetObjectStore), arg0)
+}
+
 // SetPath mocks base method.
 func (m *MockLocalRepo) SetPath(arg0 string) {
 	m.ctrl.T.Helper()
@@ -1666,6 +1991,20 @@ func (mr *MockLocalRepoMockRecorder) Size() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Size", reflect.TypeOf((*MockLocalRepo)(nil).Size))
 }
 
+// StreamParentAndChildCommitDiff mocks base method.
+func (m *MockLocalRepo) StreamParentAndChildCommitDiff(arg0 string, arg1 func(string, string) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamParentAndChildCommitDiff", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamParentAndChildCommitDiff indicates an expected call of StreamParentAndChildCommitDiff.
+func (mr *MockLocalRepoMockRecorder) StreamParentAndChildCommitDiff(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamParentAndChildCommitDiff", reflect.TypeOf((*MockLocalRepo)(nil).StreamParentAndChildCommitDiff), arg0, arg1)
+}
+
 // Tag mocks base method.
 func (m *MockLocalRepo) Tag(arg0 string) (*plumbing.Reference, error) {
 	m.ctrl.T.Helper()
@@ -1769,6 +2108,35 @@ func (mr *MockLocalRepoMockRecorder) WrappedCommitObject(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WrappedCommitObject", reflect.TypeOf((*MockLocalRepo)(nil).WrappedCommitObject), arg0)
 }
 
+// WriteArchive mocks base method.
+func (m *MockLocalRepo) WriteArchive(arg0 string, arg1 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteArchive", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteArchive indicates an expected call of WriteArchive.
+func (mr *MockLocalRepoMockRecorder) WriteArchive(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteArchive", reflect.TypeOf((*MockLocalRepo)(nil).WriteArchive), arg0, arg1)
+}
+
+// WriteObject mocks base method.
+func (m *MockLocalRepo) WriteObject(arg0 plumbing.EncodedObject) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteObject", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WriteObject indicates an expected call of WriteObject.
+func (mr *MockLocalRepoMockRecorder) WriteObject(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteObject", reflect.TypeOf((*MockLocalRepo)(nil).WriteObject), arg0)
+}
+
 // MockCommit is a mock of Commit interface.
 type MockCommit struct {
 	ctrl     *gomock.Controller
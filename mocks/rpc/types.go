@@ -586,6 +586,21 @@ func (mr *MockRepoMockRecorder) Get(name interface{}, opts ...interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepo)(nil).Get), varargs...)
 }
 
+// StreamDiffOfCommitAndParents mocks base method.
+func (m *MockRepo) StreamDiffOfCommitAndParents(name, commitHash string) (types.CommitDiffIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamDiffOfCommitAndParents", name, commitHash)
+	ret0, _ := ret[0].(types.CommitDiffIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamDiffOfCommitAndParents indicates an expected call of StreamDiffOfCommitAndParents.
+func (mr *MockRepoMockRecorder) StreamDiffOfCommitAndParents(name, commitHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamDiffOfCommitAndParents", reflect.TypeOf((*MockRepo)(nil).StreamDiffOfCommitAndParents), name, commitHash)
+}
+
 // VoteProposal mocks base method.
 func (m *MockRepo) VoteProposal(body *api.BodyRepoVote) (*api.ResultHash, error) {
 	m.ctrl.T.Helper()
@@ -601,6 +616,58 @@ func (mr *MockRepoMockRecorder) VoteProposal(body interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoteProposal", reflect.TypeOf((*MockRepo)(nil).VoteProposal), body)
 }
 
+// MockCommitDiffIterator is a mock of CommitDiffIterator interface.
+type MockCommitDiffIterator struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommitDiffIteratorMockRecorder
+}
+
+// MockCommitDiffIteratorMockRecorder is the mock recorder for MockCommitDiffIterator.
+type MockCommitDiffIteratorMockRecorder struct {
+	mock *MockCommitDiffIterator
+}
+
+// NewMockCommitDiffIterator creates a new mock instance.
+func NewMockCommitDiffIterator(ctrl *gomock.Controller) *MockCommitDiffIterator {
+	mock := &MockCommitDiffIterator{ctrl: ctrl}
+	mock.recorder = &MockCommitDiffIteratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommitDiffIterator) EXPECT() *MockCommitDiffIteratorMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockCommitDiffIterator) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockCommitDiffIteratorMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockCommitDiffIterator)(nil).Close))
+}
+
+// Next mocks base method.
+func (m *MockCommitDiffIterator) Next() (*api.ResultFileDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Next")
+	ret0, _ := ret[0].(*api.ResultFileDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Next indicates an expected call of Next.
+func (mr *MockCommitDiffIteratorMockRecorder) Next() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Next", reflect.TypeOf((*MockCommitDiffIterator)(nil).Next))
+}
+
 // MockRPC is a mock of RPC interface.
 type MockRPC struct {
 	ctrl     *gomock.Controller
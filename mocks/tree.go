@@ -8,6 +8,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	tree "github.com/make-os/kit/pkgs/tree"
 )
 
 // MockTree is a mock of Tree interface.
@@ -106,6 +107,21 @@ func (mr *MockTreeMockRecorder) Remove(key interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockTree)(nil).Remove), key)
 }
 
+// RestoreSnapshot mocks base method.
+func (m *MockTree) RestoreSnapshot(dir string, version int64, rootHash []byte) (*tree.SnapshotManifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreSnapshot", dir, version, rootHash)
+	ret0, _ := ret[0].(*tree.SnapshotManifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreSnapshot indicates an expected call of RestoreSnapshot.
+func (mr *MockTreeMockRecorder) RestoreSnapshot(dir, version, rootHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreSnapshot", reflect.TypeOf((*MockTree)(nil).RestoreSnapshot), dir, version, rootHash)
+}
+
 // SaveVersion mocks base method.
 func (m *MockTree) SaveVersion() ([]byte, int64, error) {
 	m.ctrl.T.Helper()
@@ -163,3 +179,18 @@ func (mr *MockTreeMockRecorder) WorkingHash() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkingHash", reflect.TypeOf((*MockTree)(nil).WorkingHash))
 }
+
+// WriteSnapshot mocks base method.
+func (m *MockTree) WriteSnapshot(version int64, dir string, chunkSize int) (*tree.SnapshotManifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteSnapshot", version, dir, chunkSize)
+	ret0, _ := ret[0].(*tree.SnapshotManifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WriteSnapshot indicates an expected call of WriteSnapshot.
+func (mr *MockTreeMockRecorder) WriteSnapshot(version, dir, chunkSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteSnapshot", reflect.TypeOf((*MockTree)(nil).WriteSnapshot), version, dir, chunkSize)
+}
@@ -20,22 +20,32 @@ const (
 	StatusCodeInvalidPass           = "invalid_passphrase"
 	StatusCodeAddressRequire        = "addr_required"
 	StatusCodeAccountNotFound       = "account_not_found"
+	StatusCodeAliasNotFound         = "alias_not_found"
+	StatusCodeAliasExists           = "alias_exists"
 	StatusCodeInvalidParam          = "invalid_param"
 	StatusCodeInvalidProposerPubKey = "invalid_proposer_pub_key"
 	StatusCodeMempoolAddFail        = "err_mempool"
 	StatusCodePushKeyNotFound       = "push_key_not_found"
 	StatusCodeRepoNotFound          = "repo_not_found"
+	StatusCodeNamespaceNotFound     = "namespace_not_found"
+	StatusCodeProposalNotFound      = "proposal_not_found"
 	StatusCodeIssueNotFound         = "issue_not_found"
 	StatusCodeMergeRequestNotFound  = "merge_request_not_found"
 	StatusCodePathNotFound          = "path_not_found"
 	StatusCodePathNotAFile          = "path_not_file"
+	StatusCodeReadmeNotFound        = "readme_not_found"
 	StatusCodeBranchNotFound        = "branch_not_found"
 	StatusCodeCommitNotFound        = "commit_not_found"
+	StatusCodeTagNotFound           = "tag_not_found"
+	StatusCodeNoteNotFound          = "note_not_found"
 	StatusCodeTxNotFound            = "tx_not_found"
 	StatusCodeInvalidTempRepoID     = "invalid_temp_repo_id"
 	StatusCodeInvalidReferenceName  = "invalid_reference_name"
+	StatusCodeReferenceNotFound     = "reference_not_found"
 	StatusCodeInvalidPrivateKey     = "invalid_private_key"
 	StatusCodePushFailure           = "push_failure"
+	StatusCodeCloneTimeout          = "clone_timeout"
+	StatusCodeReadAccessDenied      = "read_access_denied"
 )
 
 var se = errors2.ReqErr
@@ -87,13 +97,17 @@ func parseOptions(options ...interface{}) (pk *ed25519.PrivKey, payloadOnly bool
 //
 //  - If nonce is not set, it will use the keepers to query the compute the next nonce.
 //  - If nonce and keepers are not set, it will use rpcClient to query and compute the next nonce.
+//  - Computed nonces account for transactions already submitted in the session
+//    (tracked by nonces) so that multiple transactions from the same account
+//    receive sequential nonces. The caller must commit the nonce to nonces
+//    once the transaction has been submitted.
 //  - It will not alter fields already set.
 //  - It will not sign the tx if keeper is not set but RPC client is; This means the
 //    call will have to sign the tx with the client.
 //
 //  - options[0]: <string|bool> 	- key or payloadOnly request
 //  - options[1]: [<bool>] 		- payload request
-func finalizeTx(tx types.BaseTx, keepers core.Keepers, rpcClient types2.Client, options ...interface{}) (bool, *ed25519.PrivKey) {
+func finalizeTx(tx types.BaseTx, keepers core.Keepers, rpcClient types2.Client, nonces *NonceManager, options ...interface{}) (bool, *ed25519.PrivKey) {
 
 	key, payloadOnly := parseOptions(options...)
 
@@ -114,7 +128,7 @@ func finalizeTx(tx types.BaseTx, keepers core.Keepers, rpcClient types2.Client,
 		if senderAcct.IsNil() {
 			panic(se(400, StatusCodeInvalidParam, "senderPubKey", "sender account was not found"))
 		}
-		tx.SetNonce(senderAcct.Nonce.UInt64() + 1)
+		tx.SetNonce(nonces.Next(tx.GetFrom(), senderAcct.Nonce.UInt64()))
 	}
 
 	// If nonce is still unset and an RPC client is provided, compute next nonce by
@@ -124,7 +138,7 @@ func finalizeTx(tx types.BaseTx, keepers core.Keepers, rpcClient types2.Client,
 		if err != nil {
 			panic(err)
 		}
-		tx.SetNonce(senderAcct.Nonce.UInt64() + 1)
+		tx.SetNonce(nonces.Next(tx.GetFrom(), senderAcct.Nonce.UInt64()))
 	}
 
 	// Sign the tx only if unsigned, if we have a key and keepers
@@ -26,12 +26,14 @@ var _ = Describe("Common", func() {
 	var ctrl *gomock.Controller
 	var mockKeepers *mocks.MockKeepers
 	var mockAcctKeeper *mocks.MockAccountKeeper
+	var nonces *NonceManager
 
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		mockAcctKeeper = mocks.NewMockAccountKeeper(ctrl)
 		mockKeepers = mocks.NewMockKeepers(ctrl)
 		mockKeepers.EXPECT().AccountKeeper().Return(mockAcctKeeper).AnyTimes()
+		nonces = NewNonceManager()
 	})
 
 	AfterEach(func() {
@@ -77,7 +79,7 @@ var _ = Describe("Common", func() {
 	Describe(".finalizeTx", func() {
 		It("should not sign the tx or set sender public key when key is not provided", func() {
 			tx := txns.NewBareTxCoinTransfer()
-			payloadOnly, _ := finalizeTx(tx, mockKeepers, nil)
+			payloadOnly, _ := finalizeTx(tx, mockKeepers, nil, nonces)
 			Expect(payloadOnly).To(BeFalse())
 			Expect(tx.SenderPubKey.IsEmpty()).To(BeTrue())
 			Expect(tx.Sig).To(BeEmpty())
@@ -85,14 +87,14 @@ var _ = Describe("Common", func() {
 
 		It("should not set nonce when key is not provided", func() {
 			tx := txns.NewBareTxCoinTransfer()
-			finalizeTx(tx, mockKeepers, nil)
+			finalizeTx(tx, mockKeepers, nil, nonces)
 			Expect(tx.Nonce).To(BeZero())
 		})
 
 		It("should set timestamp if not set", func() {
 			tx := txns.NewBareTxCoinTransfer()
 			Expect(tx.Timestamp).To(BeZero())
-			finalizeTx(tx, mockKeepers, nil)
+			finalizeTx(tx, mockKeepers, nil, nonces)
 			Expect(tx.Timestamp).ToNot(BeZero())
 		})
 
@@ -100,7 +102,7 @@ var _ = Describe("Common", func() {
 			key := ed25519.NewKeyFromIntSeed(1)
 			mockAcctKeeper.EXPECT().Get(key.Addr()).Return(&state.Account{Nonce: 1})
 			tx := txns.NewBareTxCoinTransfer()
-			payloadOnly, pk := finalizeTx(tx, mockKeepers, nil, key.PrivKey().Base58())
+			payloadOnly, pk := finalizeTx(tx, mockKeepers, nil, nonces, key.PrivKey().Base58())
 			Expect(pk).ToNot(BeNil())
 			Expect(pk.Base58()).To(Equal(key.PrivKey().Base58()))
 			Expect(payloadOnly).To(BeFalse())
@@ -109,12 +111,52 @@ var _ = Describe("Common", func() {
 			Expect(tx.Nonce).To(Equal(uint64(2)))
 		})
 
+		It("should set sequential nonces when multiple txs are created by the same account", func() {
+			key := ed25519.NewKeyFromIntSeed(1)
+			mockAcctKeeper.EXPECT().Get(key.Addr()).Return(&state.Account{Balance: "10", Nonce: 0}).Times(3)
+			var allocated []uint64
+			for i := 0; i < 3; i++ {
+				tx := txns.NewBareTxCoinTransfer()
+				finalizeTx(tx, mockKeepers, nil, nonces, key.PrivKey().Base58())
+				nonces.Commit(tx.GetFrom(), tx.GetNonce())
+				allocated = append(allocated, tx.Nonce)
+			}
+			Expect(allocated).To(Equal([]uint64{1, 2, 3}))
+		})
+
+		It("should not allocate a new nonce when the previous tx nonce was not committed", func() {
+			key := ed25519.NewKeyFromIntSeed(1)
+			mockAcctKeeper.EXPECT().Get(key.Addr()).Return(&state.Account{Balance: "10", Nonce: 0}).Times(2)
+			tx := txns.NewBareTxCoinTransfer()
+			finalizeTx(tx, mockKeepers, nil, nonces, key.PrivKey().Base58(), true)
+			Expect(tx.Nonce).To(Equal(uint64(1)))
+			tx2 := txns.NewBareTxCoinTransfer()
+			finalizeTx(tx2, mockKeepers, nil, nonces, key.PrivKey().Base58())
+			Expect(tx2.Nonce).To(Equal(uint64(1)))
+		})
+
+		It("should use the account nonce when no nonce manager is provided", func() {
+			key := ed25519.NewKeyFromIntSeed(1)
+			mockAcctKeeper.EXPECT().Get(key.Addr()).Return(&state.Account{Balance: "10", Nonce: 4})
+			tx := txns.NewBareTxCoinTransfer()
+			finalizeTx(tx, mockKeepers, nil, nil, key.PrivKey().Base58())
+			Expect(tx.Nonce).To(Equal(uint64(5)))
+		})
+
+		It("should not change nonce that is already set", func() {
+			key := ed25519.NewKeyFromIntSeed(1)
+			tx := txns.NewBareTxCoinTransfer()
+			tx.Nonce = 10
+			finalizeTx(tx, mockKeepers, nil, nonces, key.PrivKey().Base58())
+			Expect(tx.Nonce).To(Equal(uint64(10)))
+		})
+
 		It("should panic if account keeper returns empty account", func() {
 			key := ed25519.NewKeyFromIntSeed(1)
 			mockAcctKeeper.EXPECT().Get(key.Addr()).Return(state.NewBareAccount())
 			tx := txns.NewBareTxCoinTransfer()
 			Expect(func() {
-				finalizeTx(tx, mockKeepers, nil, key.PrivKey().Base58())
+				finalizeTx(tx, mockKeepers, nil, nonces, key.PrivKey().Base58())
 			}).To(Panic())
 		})
 
@@ -128,7 +170,7 @@ var _ = Describe("Common", func() {
 				tx := txns.NewBareTxCoinTransfer()
 				mockUserClient.EXPECT().Get(key.Addr().String()).Return(&api.ResultAccount{Account: &state.Account{Nonce: 1}}, nil)
 
-				payloadOnly, pk := finalizeTx(tx, nil, mockRPCClient, key.PrivKey().Base58())
+				payloadOnly, pk := finalizeTx(tx, nil, mockRPCClient, nonces, key.PrivKey().Base58())
 				Expect(pk).ToNot(BeNil())
 				Expect(pk.Base58()).To(Equal(key.PrivKey().Base58()))
 				Expect(payloadOnly).To(BeFalse())
@@ -145,7 +187,7 @@ var _ = Describe("Common", func() {
 				tx := txns.NewBareTxCoinTransfer()
 				mockUserClient.EXPECT().Get(key.Addr().String()).Return(&api.ResultAccount{Account: &state.Account{Nonce: 1}}, nil)
 
-				finalizeTx(tx, nil, mockRPCClient, key.PrivKey().Base58())
+				finalizeTx(tx, nil, mockRPCClient, nonces, key.PrivKey().Base58())
 				Expect(tx.Sig).To(BeEmpty())
 			})
 		})
@@ -159,7 +201,7 @@ var _ = Describe("Common", func() {
 			mockUserClient.EXPECT().Get(key.Addr().String()).Return(nil, fmt.Errorf("error"))
 
 			Expect(func() {
-				finalizeTx(tx, nil, mockRPCClient, key.PrivKey().Base58())
+				finalizeTx(tx, nil, mockRPCClient, nonces, key.PrivKey().Base58())
 			}).To(Panic())
 		})
 	})
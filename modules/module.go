@@ -29,13 +29,13 @@ func New(cfg *config.AppConfig, acctmgr *keystore.Keystore, service services.Ser
 	mempoolReactor *mempool.Reactor, ticketmgr types2.TicketManager, dht dht2.DHT,
 	extMgr *extensions.Manager, remoteSvr core.RemoteServer) *Module {
 
-	return &Module{
+	m := &Module{
 		cfg: cfg,
 		Modules: &modulestypes.Modules{
 			Tx:      NewTxModule(service, logic),
-			Chain:   NewChainModule(service, logic),
+			Chain:   NewChainModule(cfg, service, logic),
 			User:    NewUserModule(cfg, acctmgr, service, logic),
-			PushKey: NewPushKeyModule(cfg, service, logic),
+			PushKey: NewPushKeyModule(cfg, acctmgr, service, logic),
 			Ticket:  NewTicketModule(service, logic, ticketmgr),
 			Repo:    NewRepoModule(service, remoteSvr, logic),
 			NS:      NewNamespaceModule(service, remoteSvr, logic),
@@ -47,18 +47,20 @@ func New(cfg *config.AppConfig, acctmgr *keystore.Keystore, service services.Ser
 			Dev:     NewDevModule(),
 		},
 	}
+	m.shareNonceManager(NewNonceManager())
+	return m
 }
 
 // NewAttachable creates an instance of Module configured for attach mode.
 func NewAttachable(cfg *config.AppConfig, client types3.Client, ks *keystore.Keystore) *Module {
-	return &Module{
+	m := &Module{
 		cfg:        cfg,
 		attachMode: cfg.IsAttachMode(),
 		Modules: &modulestypes.Modules{
 			Tx:      NewAttachableTxModule(client),
 			Chain:   NewAttachableChainModule(client),
 			User:    NewAttachableUserModule(cfg, client, ks),
-			PushKey: NewAttachablePushKeyModule(cfg, client),
+			PushKey: NewAttachablePushKeyModule(cfg, client, ks),
 			Ticket:  NewAttachableTicketModule(client),
 			Repo:    NewAttachableRepoModule(client),
 			NS:      NewAttachableNamespaceModule(client),
@@ -69,6 +71,19 @@ func NewAttachable(cfg *config.AppConfig, client types3.Client, ks *keystore.Key
 			Dev:     NewDevModule(),
 		},
 	}
+	m.shareNonceManager(NewNonceManager())
+	return m
+}
+
+// shareNonceManager makes the transaction-creating modules track submitted
+// nonces using the same nonce manager, so that transactions sent by an
+// account through different modules in a session get sequential nonces.
+func (m *Module) shareNonceManager(nonces *NonceManager) {
+	m.Modules.User.(*UserModule).nonces = nonces
+	m.Modules.PushKey.(*PushKeyModule).nonces = nonces
+	m.Modules.Ticket.(*TicketModule).nonces = nonces
+	m.Modules.Repo.(*RepoModule).nonces = nonces
+	m.Modules.NS.(*NamespaceModule).nonces = nonces
 }
 
 // GetModules returns all sub-modules
@@ -22,6 +22,7 @@ type NamespaceModule struct {
 	logic   core.Logic
 	service services.Service
 	repoMgr core.RemoteServer
+	nonces  *NonceManager
 }
 
 // NewAttachableNamespaceModule creates an instance of NamespaceModule suitable in attach mode
@@ -184,7 +185,7 @@ func (m *NamespaceModule) Register(params map[string]interface{}, options ...int
 	// Hash the name
 	tx.Name = crypto.MakeNamespaceHash(tx.Name)
 
-	if printPayload, _ := finalizeTx(tx, m.logic, nil, options...); printPayload {
+	if printPayload, _ := finalizeTx(tx, m.logic, nil, m.nonces, options...); printPayload {
 		return tx.ToMap()
 	}
 
@@ -192,6 +193,7 @@ func (m *NamespaceModule) Register(params map[string]interface{}, options ...int
 	if err != nil {
 		panic(errors.ReqErr(400, StatusCodeMempoolAddFail, "", err.Error()))
 	}
+	m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
 
 	return map[string]interface{}{
 		"hash": hash,
@@ -225,7 +227,7 @@ func (m *NamespaceModule) UpdateDomain(params map[string]interface{}, options ..
 	// Hash the name
 	tx.Name = crypto.MakeNamespaceHash(tx.Name)
 
-	if printPayload, _ := finalizeTx(tx, m.logic, nil, options...); printPayload {
+	if printPayload, _ := finalizeTx(tx, m.logic, nil, m.nonces, options...); printPayload {
 		return tx.ToMap()
 	}
 
@@ -233,6 +235,7 @@ func (m *NamespaceModule) UpdateDomain(params map[string]interface{}, options ..
 	if err != nil {
 		panic(errors.ReqErr(400, StatusCodeMempoolAddFail, "", err.Error()))
 	}
+	m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
 
 	return map[string]interface{}{
 		"hash": hash,
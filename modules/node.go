@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/logic/keepers"
 	"github.com/make-os/kit/modules/types"
 	"github.com/make-os/kit/node/services"
+	"github.com/make-os/kit/params"
 	types2 "github.com/make-os/kit/rpc/types"
 	"github.com/make-os/kit/types/constants"
 	"github.com/make-os/kit/types/core"
@@ -26,13 +29,14 @@ import (
 // NodeModule provides access to chain information
 type NodeModule struct {
 	types.ModuleCommon
+	cfg     *config.AppConfig
 	service services.Service
 	keepers core.Keepers
 }
 
 // NewChainModule creates an instance of NodeModule
-func NewChainModule(service services.Service, keepers core.Keepers) *NodeModule {
-	return &NodeModule{service: service, keepers: keepers}
+func NewChainModule(cfg *config.AppConfig, service services.Service, keepers core.Keepers) *NodeModule {
+	return &NodeModule{cfg: cfg, service: service, keepers: keepers}
 }
 
 // NewAttachableChainModule creates an instance of NodeModule suitable in attach mode
@@ -55,6 +59,8 @@ func (m *NodeModule) methods() []*types.VMMember {
 		{Name: "isSyncing", Value: m.IsSyncing, Description: "Check if the node is synchronizing with peers"},
 		{Name: "getCurEpoch", Value: m.GetCurrentEpoch, Description: "Get the current epoch"},
 		{Name: "getEpoch", Value: m.GetEpoch, Description: "Get the epoch of a block height"},
+		{Name: "getNetworkParams", Value: m.GetNetworkParams, Description: "Get the fee schedule and protocol parameters of the network"},
+		{Name: "getNetworkInfo", Value: m.GetNetworkInfo, Description: "Get the chain ID, genesis time and name of the network"},
 	}
 }
 
@@ -222,3 +228,82 @@ func (m *NodeModule) GetCurrentEpoch() string {
 func (m *NodeModule) GetEpoch(height int64) string {
 	return cast.ToString(epoch.GetEpochAt(height))
 }
+
+// GetNetworkParams returns the fee schedule and protocol parameters of the network.
+//
+// RETURNS object <map>
+//  - feePerByte <string>: The cost per byte of a transaction
+//  - minProposalFee <string>: The minimum fee of a repo proposal
+//  - namespaceRegFee <string>: The registration fee of a namespace
+//  - namespacePriceTiers <[]map>: The registration fees of short namespaces
+//  - repoCreateFee <string>: The price of creating a repository (zero if free)
+//  - minValidatorTicketPrice <string>: The minimum price of a validator ticket
+//  - minHostStake <string>: The minimum stake of a host ticket
+//  - minDelegatorCommission <string>: The minimum delegator commission (in percentage)
+//  - pushEndorseQuorumSize <number>: The number of endorsements a push note requires
+//  - repoProposalTTL <number>: The number of blocks a repo proposal remains active
+//  - namespaceTTL <number>: The number of blocks of a namespace life span
+//  - namespaceGraceDur <number>: The number of blocks before a namespace expires
+//  - numBlocksPerEpoch <number>: The number of blocks in an epoch
+//  - maxPushFileSize <number>: The maximum size of files in a push request
+//  - maxRepoSize <number>: The maximum size of a repository
+func (m *NodeModule) GetNetworkParams() util.Map {
+
+	var tiers = []util.Map{}
+	for _, tier := range params.NamespacePriceTiers {
+		tiers = append(tiers, util.Map{"maxLen": tier.MaxLen, "fee": tier.Fee.String()})
+	}
+
+	return util.Map{
+		"feePerByte":              params.FeePerByte.String(),
+		"minProposalFee":          cast.ToString(params.DefaultMinProposalFee),
+		"namespaceRegFee":         params.NamespaceRegFee.String(),
+		"namespacePriceTiers":     tiers,
+		"repoCreateFee":           params.RepoCreateFee.String(),
+		"minValidatorTicketPrice": cast.ToString(params.MinValidatorsTicketPrice),
+		"minHostStake":            params.MinHostStake.String(),
+		"minDelegatorCommission":  params.MinDelegatorCommission.String(),
+		"pushEndorseQuorumSize":   params.PushEndorseQuorumSize,
+		"repoProposalTTL":         params.RepoProposalTTL,
+		"namespaceTTL":            params.NamespaceTTL,
+		"namespaceGraceDur":       params.NamespaceGraceDur,
+		"numBlocksPerEpoch":       params.NumBlocksPerEpoch,
+		"maxPushFileSize":         params.MaxPushFileSize,
+		"maxRepoSize":             params.MaxRepoSize,
+	}
+}
+
+// GetNetworkInfo returns information that identifies the network.
+// Clients can use it to confirm they are connected to the expected
+// network before signing transactions.
+//
+// RETURNS object <map>
+//  - chainId <string>: The ID of the chain
+//  - genesisTime <string>: The unix time of the genesis block
+//  - name <string>: The name of the network (empty if unknown)
+//  - protocolVersion <string>: The protocol version of the node
+func (m *NodeModule) GetNetworkInfo() util.Map {
+	chainID := cast.ToString(m.cfg.Net.Version)
+
+	var name string
+	var genesisTime uint64
+	if info := config.Get(chainID); info != nil {
+		name = info.GetName()
+		genesisTime = info.GetGenesisTime()
+	}
+
+	// The time of the first block is the genesis time of the chain
+	bi, err := m.keepers.SysKeeper().GetBlockInfo(1)
+	if err != nil && err != keepers.ErrBlockInfoNotFound {
+		panic(errors.ReqErr(500, StatusCodeServerErr, "", err.Error()))
+	} else if bi != nil {
+		genesisTime = uint64(bi.Time)
+	}
+
+	return util.Map{
+		"chainId":         chainID,
+		"genesisTime":     cast.ToString(genesisTime),
+		"name":            name,
+		"protocolVersion": cast.ToString(config.GetNetVersion()),
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	"github.com/golang/mock/gomock"
+	"github.com/make-os/kit/config"
 	"github.com/make-os/kit/crypto/ed25519"
+	"github.com/make-os/kit/logic/keepers"
 	"github.com/make-os/kit/mocks"
 	"github.com/make-os/kit/modules"
 	"github.com/make-os/kit/params"
@@ -17,6 +19,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/robertkrimen/otto"
+	"github.com/spf13/cast"
 	"github.com/stretchr/testify/assert"
 	core_types "github.com/tendermint/tendermint/rpc/core/types"
 	"github.com/tendermint/tendermint/types"
@@ -29,8 +32,11 @@ var _ = Describe("NodeModule", func() {
 	var mockKeepers *mocks.MockKeepers
 	var mockSysKeeper *mocks.MockSystemKeeper
 	var mockValKeeper *mocks.MockValidatorKeeper
+	var cfg *config.AppConfig
 
 	BeforeEach(func() {
+		cfg = config.EmptyAppConfig()
+		cfg.Net.Version = 2000
 		ctrl = gomock.NewController(GinkgoT())
 		mockService = mocks.NewMockService(ctrl)
 		mockSysKeeper = mocks.NewMockSystemKeeper(ctrl)
@@ -38,7 +44,7 @@ var _ = Describe("NodeModule", func() {
 		mockValKeeper = mocks.NewMockValidatorKeeper(ctrl)
 		mockKeepers.EXPECT().SysKeeper().Return(mockSysKeeper).AnyTimes()
 		mockKeepers.EXPECT().ValidatorKeeper().Return(mockValKeeper).AnyTimes()
-		m = modules.NewChainModule(mockService, mockKeepers)
+		m = modules.NewChainModule(cfg, mockService, mockKeepers)
 	})
 
 	AfterEach(func() {
@@ -170,4 +176,57 @@ var _ = Describe("NodeModule", func() {
 			Expect(m.GetEpoch(6)).To(Equal("2"))
 		})
 	})
+
+	Describe(".GetNetworkParams", func() {
+		It("should return the current network parameters", func() {
+			res := m.GetNetworkParams()
+			Expect(res).To(HaveKeyWithValue("feePerByte", params.FeePerByte.String()))
+			Expect(res).To(HaveKeyWithValue("minProposalFee", cast.ToString(params.DefaultMinProposalFee)))
+			Expect(res).To(HaveKeyWithValue("namespaceRegFee", params.NamespaceRegFee.String()))
+			Expect(res).To(HaveKeyWithValue("repoCreateFee", params.RepoCreateFee.String()))
+			Expect(res).To(HaveKeyWithValue("minHostStake", params.MinHostStake.String()))
+			Expect(res).To(HaveKeyWithValue("pushEndorseQuorumSize", params.PushEndorseQuorumSize))
+			Expect(res).To(HaveKeyWithValue("repoProposalTTL", params.RepoProposalTTL))
+			Expect(res).To(HaveKey("minValidatorTicketPrice"))
+			Expect(res).To(HaveKey("minDelegatorCommission"))
+			Expect(res).To(HaveKey("namespaceTTL"))
+			Expect(res).To(HaveKey("numBlocksPerEpoch"))
+			Expect(res["namespacePriceTiers"]).To(HaveLen(len(params.NamespacePriceTiers)))
+		})
+	})
+	Describe(".GetNetworkInfo", func() {
+		It("should panic when unable to get the genesis block info", func() {
+			mockSysKeeper.EXPECT().GetBlockInfo(int64(1)).Return(nil, fmt.Errorf("error"))
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetNetworkInfo()
+			})
+		})
+
+		It("should use the configured genesis time of the network when the genesis block is unknown", func() {
+			mockSysKeeper.EXPECT().GetBlockInfo(int64(1)).Return(nil, keepers.ErrBlockInfoNotFound)
+			res := m.GetNetworkInfo()
+			Expect(res).To(Equal(util.Map{
+				"chainId":         "2000",
+				"genesisTime":     cast.ToString(config.TestnetChainV1.GenesisTime),
+				"name":            config.TestnetChainV1.Name,
+				"protocolVersion": cast.ToString(config.GetNetVersion()),
+			}))
+		})
+
+		It("should use the time of the genesis block when it is known", func() {
+			mockSysKeeper.EXPECT().GetBlockInfo(int64(1)).Return(&state.BlockInfo{Height: 1, Time: 1600000000}, nil)
+			res := m.GetNetworkInfo()
+			Expect(res["chainId"]).To(Equal("2000"))
+			Expect(res["genesisTime"]).To(Equal("1600000000"))
+		})
+
+		It("should return empty name for an unknown network", func() {
+			cfg.Net.Version = 12345
+			mockSysKeeper.EXPECT().GetBlockInfo(int64(1)).Return(&state.BlockInfo{Height: 1, Time: 1600000000}, nil)
+			res := m.GetNetworkInfo()
+			Expect(res["chainId"]).To(Equal("12345"))
+			Expect(res["name"]).To(Equal(""))
+		})
+	})
 })
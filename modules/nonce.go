@@ -0,0 +1,82 @@
+package modules
+
+import (
+	"sync"
+
+	"github.com/make-os/kit/util/identifier"
+)
+
+// NonceManager tracks the nonces of transactions submitted in a session.
+//
+// It remembers the highest nonce submitted for each account so that
+// transactions created in quick succession (before earlier ones are
+// included in a block) do not end up with the same nonce. A nonce is
+// only recorded once its transaction has been submitted successfully.
+//
+// A nil NonceManager is valid; It does not track any nonce.
+type NonceManager struct {
+	lck    sync.Mutex
+	nonces map[identifier.Address]uint64
+}
+
+// NewNonceManager creates an instance of NonceManager
+func NewNonceManager() *NonceManager {
+	return &NonceManager{nonces: make(map[identifier.Address]uint64)}
+}
+
+// Next returns the next nonce of an account. The nonce is not recorded
+// until Commit is called with it.
+//  - addr: The address of the account.
+//  - current: The current nonce of the account as known by the network.
+func (n *NonceManager) Next(addr identifier.Address, current uint64) uint64 {
+	if n == nil {
+		return current + 1
+	}
+
+	n.lck.Lock()
+	defer n.lck.Unlock()
+
+	// Reconcile with the network; If the account nonce has moved past
+	// the last submitted nonce, continue from the account nonce.
+	last := n.nonces[addr]
+	if current > last {
+		last = current
+	}
+
+	return last + 1
+}
+
+// Commit records the nonce of a transaction of an account
+// that was successfully submitted.
+func (n *NonceManager) Commit(addr identifier.Address, nonce uint64) {
+	if n == nil {
+		return
+	}
+
+	n.lck.Lock()
+	defer n.lck.Unlock()
+
+	if nonce > n.nonces[addr] {
+		n.nonces[addr] = nonce
+	}
+}
+
+// Reset forgets the nonce submitted by an account.
+// If no address is given, all accounts are forgotten.
+func (n *NonceManager) Reset(addr ...identifier.Address) {
+	if n == nil {
+		return
+	}
+
+	n.lck.Lock()
+	defer n.lck.Unlock()
+
+	if len(addr) == 0 {
+		n.nonces = make(map[identifier.Address]uint64)
+		return
+	}
+
+	for _, a := range addr {
+		delete(n.nonces, a)
+	}
+}
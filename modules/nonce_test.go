@@ -0,0 +1,83 @@
+package modules
+
+import (
+	"github.com/make-os/kit/crypto/ed25519"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NonceManager", func() {
+	var nm *NonceManager
+	var key = ed25519.NewKeyFromIntSeed(1)
+	var key2 = ed25519.NewKeyFromIntSeed(2)
+
+	BeforeEach(func() {
+		nm = NewNonceManager()
+	})
+
+	Describe(".Next", func() {
+		It("should return the next nonce after the current account nonce on first use", func() {
+			Expect(nm.Next(key.Addr(), 5)).To(Equal(uint64(6)))
+		})
+
+		It("should not record the returned nonce", func() {
+			Expect(nm.Next(key.Addr(), 0)).To(Equal(uint64(1)))
+			Expect(nm.Next(key.Addr(), 0)).To(Equal(uint64(1)))
+		})
+
+		It("should return sequential nonces when committed nonces are not yet reflected in the account nonce", func() {
+			for i := uint64(1); i <= 3; i++ {
+				nonce := nm.Next(key.Addr(), 0)
+				Expect(nonce).To(Equal(i))
+				nm.Commit(key.Addr(), nonce)
+			}
+		})
+
+		It("should continue from the account nonce if it is higher than the last committed nonce", func() {
+			nm.Commit(key.Addr(), 1)
+			Expect(nm.Next(key.Addr(), 10)).To(Equal(uint64(11)))
+		})
+
+		It("should track accounts separately", func() {
+			nm.Commit(key.Addr(), 1)
+			Expect(nm.Next(key2.Addr(), 0)).To(Equal(uint64(1)))
+			Expect(nm.Next(key.Addr(), 0)).To(Equal(uint64(2)))
+		})
+
+		It("should return the next account nonce when manager is nil", func() {
+			var nilNM *NonceManager
+			Expect(nilNM.Next(key.Addr(), 3)).To(Equal(uint64(4)))
+		})
+	})
+
+	Describe(".Commit", func() {
+		It("should not lower the recorded nonce", func() {
+			nm.Commit(key.Addr(), 5)
+			nm.Commit(key.Addr(), 2)
+			Expect(nm.Next(key.Addr(), 0)).To(Equal(uint64(6)))
+		})
+
+		It("should do nothing when manager is nil", func() {
+			var nilNM *NonceManager
+			Expect(func() { nilNM.Commit(key.Addr(), 1) }).ToNot(Panic())
+		})
+	})
+
+	Describe(".Reset", func() {
+		It("should forget the nonce of the given account", func() {
+			nm.Commit(key.Addr(), 1)
+			nm.Commit(key2.Addr(), 1)
+			nm.Reset(key.Addr())
+			Expect(nm.Next(key.Addr(), 0)).To(Equal(uint64(1)))
+			Expect(nm.Next(key2.Addr(), 0)).To(Equal(uint64(2)))
+		})
+
+		It("should forget all accounts when no address is given", func() {
+			nm.Commit(key.Addr(), 1)
+			nm.Commit(key2.Addr(), 1)
+			nm.Reset()
+			Expect(nm.Next(key.Addr(), 0)).To(Equal(uint64(1)))
+			Expect(nm.Next(key2.Addr(), 0)).To(Equal(uint64(1)))
+		})
+	})
+})
@@ -2,20 +2,30 @@ package modules
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/make-os/kit/config"
 	"github.com/make-os/kit/crypto/ed25519"
+	"github.com/make-os/kit/keystore"
+	kstypes "github.com/make-os/kit/keystore/types"
 	modulestypes "github.com/make-os/kit/modules/types"
 	"github.com/make-os/kit/node/services"
+	remotetypes "github.com/make-os/kit/remote/types"
+	"github.com/make-os/kit/remote/validation"
 	types2 "github.com/make-os/kit/rpc/types"
 	"github.com/make-os/kit/types"
 	"github.com/make-os/kit/types/api"
 	"github.com/make-os/kit/types/constants"
 	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/state"
 	"github.com/make-os/kit/types/txns"
 	"github.com/make-os/kit/util"
+	"github.com/make-os/kit/util/crypto"
 	"github.com/make-os/kit/util/errors"
 	"github.com/make-os/kit/util/identifier"
+	"github.com/make-os/kit/util/pushtoken"
+	"github.com/mr-tron/base58"
 	"github.com/spf13/cast"
 
 	"github.com/c-bata/go-prompt"
@@ -25,19 +35,27 @@ import (
 // PushKeyModule manages and provides access to push keys.
 type PushKeyModule struct {
 	modulestypes.ModuleCommon
-	cfg     *config.AppConfig
-	service services.Service
-	logic   core.Logic
+	cfg      *config.AppConfig
+	keystore kstypes.Keystore
+	service  services.Service
+	logic    core.Logic
+	aliases  *keystore.AliasStore
+	nonces   *NonceManager
 }
 
 // NewAttachablePushKeyModule creates an instance of PushKeyModule suitable in attach mode
-func NewAttachablePushKeyModule(cfg *config.AppConfig, client types2.Client) *PushKeyModule {
-	return &PushKeyModule{ModuleCommon: modulestypes.ModuleCommon{Client: client}, cfg: cfg}
+func NewAttachablePushKeyModule(cfg *config.AppConfig, client types2.Client, ks kstypes.Keystore) *PushKeyModule {
+	return &PushKeyModule{
+		ModuleCommon: modulestypes.ModuleCommon{Client: client},
+		cfg:          cfg,
+		keystore:     ks,
+		aliases:      keystore.NewAliasStore(cfg.GetAliasesPath()),
+	}
 }
 
 // NewPushKeyModule creates an instance of PushKeyModule
-func NewPushKeyModule(cfg *config.AppConfig, service services.Service, logic core.Logic) *PushKeyModule {
-	return &PushKeyModule{cfg: cfg, service: service, logic: logic}
+func NewPushKeyModule(cfg *config.AppConfig, ks kstypes.Keystore, service services.Service, logic core.Logic) *PushKeyModule {
+	return &PushKeyModule{cfg: cfg, keystore: ks, service: service, logic: logic, aliases: keystore.NewAliasStore(cfg.GetAliasesPath())}
 }
 
 // methods are functions exposed in the special namespace of this module.
@@ -47,7 +65,13 @@ func (m *PushKeyModule) methods() []*modulestypes.VMMember {
 		{Name: "update", Value: m.Update, Description: "Update a previously registered push key"},
 		{Name: "find", Value: m.Find, Description: "Find a push key"},
 		{Name: "getByAddress", Value: m.GetByAddress, Description: "Get push keys belonging to a user address"},
+		{Name: "getKeysByAddress", Value: m.GetPushKeysByAddress, Description: "Get push keys (with details) belonging to a user address"},
 		{Name: "getOwner", Value: m.GetAccountOfOwner, Description: "Get the account of a push key owner"},
+		{Name: "checkScope", Value: m.CheckScope, Description: "Check whether a push key's scopes permit pushing to a repository"},
+		{Name: "getReach", Value: m.GetPushKeyReach, Description: "Get the repositories and namespace domains a push key's scopes permit pushing to"},
+		{Name: "signMessage", Value: m.SignMessage, Description: "Sign a message with a local push key (supports interactive mode)"},
+		{Name: "verifyMessage", Value: m.VerifyMessage, Description: "Verify a message signature against a registered push key"},
+		{Name: "makePushToken", Value: m.MakePushToken, Description: "Create a push token signed by a local push key (supports interactive mode)"},
 	}
 }
 
@@ -106,7 +130,7 @@ func (m *PushKeyModule) Register(params map[string]interface{}, options ...inter
 		panic(errors.ReqErr(400, StatusCodeInvalidParam, "params", err.Error()))
 	}
 
-	printPayload, signingKey := finalizeTx(tx, m.logic, m.Client, options...)
+	printPayload, signingKey := finalizeTx(tx, m.logic, m.Client, m.nonces, options...)
 	if printPayload {
 		return tx.ToMap()
 	}
@@ -123,6 +147,7 @@ func (m *PushKeyModule) Register(params map[string]interface{}, options ...inter
 		if err != nil {
 			panic(err)
 		}
+		m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
 		return util.ToMap(resp)
 	}
 
@@ -130,6 +155,7 @@ func (m *PushKeyModule) Register(params map[string]interface{}, options ...inter
 	if err != nil {
 		panic(errors.ReqErr(400, StatusCodeMempoolAddFail, "", err.Error()))
 	}
+	m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
 
 	pk := ed25519.MustPubKeyFromBytes(tx.PublicKey.Bytes())
 
@@ -167,7 +193,7 @@ func (m *PushKeyModule) Update(params map[string]interface{}, options ...interfa
 	}
 	tx.Delete = false
 
-	if printPayload, _ := finalizeTx(tx, m.logic, nil, options...); printPayload {
+	if printPayload, _ := finalizeTx(tx, m.logic, nil, m.nonces, options...); printPayload {
 		return tx.ToMap()
 	}
 
@@ -176,6 +202,7 @@ func (m *PushKeyModule) Update(params map[string]interface{}, options ...interfa
 	if err != nil {
 		panic(errors.ReqErr(400, StatusCodeMempoolAddFail, "", err.Error()))
 	}
+	m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
 
 	return map[string]interface{}{
 		"hash": hash,
@@ -210,7 +237,7 @@ func (m *PushKeyModule) Unregister(params map[string]interface{}, options ...int
 	tx.AddScopes = nil
 	tx.RemoveScopes = nil
 
-	if printPayload, _ := finalizeTx(tx, m.logic, nil, options...); printPayload {
+	if printPayload, _ := finalizeTx(tx, m.logic, nil, m.nonces, options...); printPayload {
 		return tx.ToMap()
 	}
 
@@ -219,6 +246,7 @@ func (m *PushKeyModule) Unregister(params map[string]interface{}, options ...int
 	if err != nil {
 		panic(errors.ReqErr(400, StatusCodeMempoolAddFail, "", err.Error()))
 	}
+	m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
 
 	return map[string]interface{}{
 		"hash": hash,
@@ -233,6 +261,7 @@ func (m *PushKeyModule) Unregister(params map[string]interface{}, options ...int
 //
 // RETURNS state.PushKey
 func (m *PushKeyModule) Find(address string, height ...uint64) util.Map {
+	address = m.aliases.Resolve(address)
 
 	if address == "" {
 		panic(errors.ReqErr(400, StatusCodeInvalidParam, "id", "push key id is required"))
@@ -258,7 +287,40 @@ func (m *PushKeyModule) Find(address string, height ...uint64) util.Map {
 //
 // RETURNS: List of push key ids
 func (m *PushKeyModule) GetByAddress(address string) []string {
-	return m.logic.PushKeyKeeper().GetByAddress(address)
+	return m.logic.PushKeyKeeper().GetByAddress(m.aliases.Resolve(address))
+}
+
+// GetPushKeysByAddress returns the push keys owned by the given user address
+//
+// ARGS:
+// address: An address of an account
+//
+// RETURNS: List of push keys
+// - id <string>: The push key ID
+// - pubKey <string>: The public key
+// - address <string>: The address of the owner
+// - scopes <[]string>: The repo or namespace where the key can be used
+// - feeCap <string>: The max. amount of fee the key can spend
+// - feeUsed <string>: The amount of fee spent by the key
+func (m *PushKeyModule) GetPushKeysByAddress(address string) []util.Map {
+	address = m.aliases.Resolve(address)
+	if err := identifier.IsValidUserAddr(address); err != nil {
+		panic(errors.ReqErr(400, StatusCodeInvalidParam, "address", "address is not valid"))
+	}
+
+	var res = []util.Map{}
+	keeper := m.logic.PushKeyKeeper()
+	for _, id := range keeper.GetByAddress(address) {
+		pk := keeper.Get(id)
+		if pk.IsNil() {
+			continue
+		}
+		data := util.ToMap(pk)
+		data["id"] = id
+		res = append(res, data)
+	}
+
+	return res
 }
 
 // GetAccountOfOwner returns the account of the key owner
@@ -269,6 +331,7 @@ func (m *PushKeyModule) GetByAddress(address string) []string {
 //
 // RETURNS state.Account
 func (m *PushKeyModule) GetAccountOfOwner(address string, height ...uint64) util.Map {
+	address = m.aliases.Resolve(address)
 
 	h := uint64(0)
 	if len(height) > 0 {
@@ -291,3 +354,297 @@ func (m *PushKeyModule) GetAccountOfOwner(address string, height ...uint64) util
 
 	return util.ToMap(acct)
 }
+
+// CheckScope checks whether the scopes of a push key permit pushing
+// to a repository. A key without scopes can push to any repository.
+//
+// ARGS:
+// pushKeyID: The push key address
+// repo: The name of the target repository or namespace domain
+// [namespace]: The namespace of the target repository
+//
+// RETURNS: true if the push key can push to the repository
+func (m *PushKeyModule) CheckScope(pushKeyID, repo, namespace string) bool {
+	pushKeyID = m.aliases.Resolve(pushKeyID)
+
+	if pushKeyID == "" {
+		panic(errors.ReqErr(400, StatusCodeInvalidParam, "pkID", "push key id is required"))
+	}
+
+	if repo == "" {
+		panic(errors.ReqErr(400, StatusCodeInvalidParam, "repo", "repo name is required"))
+	}
+
+	pushKey := m.logic.PushKeyKeeper().Get(pushKeyID)
+	if pushKey.IsNil() {
+		panic(errors.ReqErr(404, StatusCodePushKeyNotFound, "pkID", types.ErrPushKeyUnknown.Error()))
+	}
+
+	if len(pushKey.Scopes) == 0 {
+		return true
+	}
+
+	var ns = state.BareNamespace()
+	if namespace != "" {
+		ns = m.logic.NamespaceKeeper().Get(crypto.MakeNamespaceHash(namespace))
+		if ns.IsNil() {
+			panic(errors.ReqErr(404, StatusCodeNamespaceNotFound, "namespace",
+				fmt.Sprintf("namespace (%s) is unknown", namespace)))
+		}
+	}
+
+	detail := &remotetypes.TxDetail{RepoName: repo, RepoNamespace: namespace}
+	return !validation.IsBlockedByScope(pushKey.Scopes, detail, ns)
+}
+
+// GetPushKeyReach returns the repositories and namespace domains the scopes
+// of a push key currently permit pushing to. A key without scopes can push
+// to any repository. Only domains of namespaces named in the scopes are
+// expanded, since namespaces are not indexed by name.
+//
+// ARGS:
+// pushKeyID: The push key address
+//
+// RETURNS object <map>:
+// - repos <[]string>: The names of the repositories the key can push to
+// - namespaces <[]string>: The namespace paths (namespace/domain) the key can push to
+func (m *PushKeyModule) GetPushKeyReach(pushKeyID string) util.Map {
+	pushKeyID = m.aliases.Resolve(pushKeyID)
+
+	if pushKeyID == "" {
+		panic(errors.ReqErr(400, StatusCodeInvalidParam, "pkID", "push key id is required"))
+	}
+
+	pushKey := m.logic.PushKeyKeeper().Get(pushKeyID)
+	if pushKey.IsNil() {
+		panic(errors.ReqErr(404, StatusCodePushKeyNotFound, "pkID", types.ErrPushKeyUnknown.Error()))
+	}
+
+	var repos = []string{}
+	m.logic.RepoKeeper().Iterate(func(name string, _ *state.Repository) bool {
+		detail := &remotetypes.TxDetail{RepoName: name}
+		if len(pushKey.Scopes) == 0 || !validation.IsBlockedByScope(pushKey.Scopes, detail, state.BareNamespace()) {
+			repos = append(repos, name)
+		}
+		return false
+	})
+
+	// Collect the namespaces referenced by the scopes
+	var namespaces = map[string]struct{}{}
+	for _, scope := range pushKey.Scopes {
+		if identifier.IsNamespaceURI(scope) || (identifier.IsScopePattern(scope) && strings.Contains(scope, "/")) {
+			if ns, _, _ := util.SplitNamespaceDomain(scope); ns != remotetypes.DefaultNS {
+				namespaces[ns] = struct{}{}
+			}
+		}
+	}
+
+	var paths = []string{}
+	for name := range namespaces {
+		ns := m.logic.NamespaceKeeper().Get(crypto.MakeNamespaceHash(name))
+		if ns.IsNil() {
+			continue
+		}
+		for domain, target := range ns.Domains {
+			if !identifier.IsWholeNativeRepoURI(target) {
+				continue
+			}
+			detail := &remotetypes.TxDetail{RepoName: domain, RepoNamespace: name}
+			if !validation.IsBlockedByScope(pushKey.Scopes, detail, ns) {
+				paths = append(paths, name+"/"+domain)
+			}
+		}
+	}
+
+	sort.Strings(repos)
+	sort.Strings(paths)
+
+	return util.Map{"repos": repos, "namespaces": paths}
+}
+
+// SignMessage signs an arbitrary message using the local key of a push key.
+//
+// The passphrase argument is used to unlock the key.
+// If passphrase is not set, an interactive prompt will be started
+// to collect the passphrase without revealing it in the terminal.
+//
+// ARGS:
+// message: The message to sign
+// pushKeyID: The push key address of the local key
+// [passphrase]: The passphrase of the local key
+//
+// RETURNS: The base58 encoded signature
+func (m *PushKeyModule) SignMessage(message, pushKeyID string, passphrase ...string) string {
+	pushKeyID = m.aliases.Resolve(pushKeyID)
+
+	if pushKeyID == "" {
+		panic(errors.ReqErr(400, StatusCodeInvalidParam, "pkID", "push key id is required"))
+	} else if ed25519.IsValidPushAddr(pushKeyID) != nil {
+		panic(errors.ReqErr(400, StatusCodeInvalidParam, "pkID", "push key id is not valid"))
+	}
+
+	key, err := m.keystore.GetByAddress(pushKeyID)
+	if err != nil {
+		if err != types.ErrKeyUnknown {
+			panic(errors.ReqErr(500, StatusCodeServerErr, "pkID", err.Error()))
+		}
+		panic(errors.ReqErr(404, StatusCodePushKeyNotFound, "pkID", types.ErrPushKeyUnknown.Error()))
+	}
+
+	// If passphrase is not set and the key is protected, start interactive mode
+	var pass = keystore.DefaultPassphrase
+	if len(passphrase) > 0 {
+		pass = passphrase[0]
+	} else if !key.IsUnprotected() {
+		pass, _ = m.keystore.AskForPasswordOnce()
+	}
+
+	if err := key.Unlock(pass); err != nil {
+		if err == types.ErrInvalidPassphrase {
+			panic(errors.ReqErr(401, StatusCodeInvalidPass, "passphrase", err.Error()))
+		}
+		panic(errors.ReqErr(500, StatusCodeServerErr, "passphrase", err.Error()))
+	}
+
+	sig, err := key.GetKey().PrivKey().Sign([]byte(message))
+	if err != nil {
+		panic(errors.ReqErr(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return base58.Encode(sig)
+}
+
+// MakePushToken creates a push token signed by the local key of a push key.
+// The token can be embedded in the URL of a git remote to push with a
+// plain git client.
+//
+// If opts.Passphrase is not set and the key is protected, an interactive
+// prompt will be started to collect the passphrase.
+//
+// ARGS:
+// repo: The name of the target repository
+// pushKeyID: The push key address of the local key
+// [opts]: Token options
+//  - namespace <string>: The namespace of the target repository
+//  - reference <string>: The target reference
+//  - fee <string>: The network fee to pay (default: 0)
+//  - value <string>: Additional value (merge request references only)
+//  - nonce <number>: The nonce of the push key owner (default: next account nonce)
+//  - expiresAt <number>: The unix time after which the token is rejected (default: never)
+//  - passphrase <string>: The passphrase of the local key
+//
+// RETURNS: The push token
+func (m *PushKeyModule) MakePushToken(repo, pushKeyID string, opts ...modulestypes.MakePushTokenOptions) string {
+	var opt modulestypes.MakePushTokenOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	if repo == "" {
+		panic(errors.ReqErr(400, StatusCodeInvalidParam, "repo", "repo name is required"))
+	}
+
+	pushKeyID = m.aliases.Resolve(pushKeyID)
+	if pushKeyID == "" {
+		panic(errors.ReqErr(400, StatusCodeInvalidParam, "pkID", "push key id is required"))
+	} else if ed25519.IsValidPushAddr(pushKeyID) != nil {
+		panic(errors.ReqErr(400, StatusCodeInvalidParam, "pkID", "push key id is not valid"))
+	}
+
+	if opt.ExpiresAt < 0 {
+		panic(errors.ReqErr(400, StatusCodeInvalidParam, "expiresAt", "expiry time must not be negative"))
+	}
+
+	key, err := m.keystore.GetByAddress(pushKeyID)
+	if err != nil {
+		if err != types.ErrKeyUnknown {
+			panic(errors.ReqErr(500, StatusCodeServerErr, "pkID", err.Error()))
+		}
+		panic(errors.ReqErr(400, StatusCodeInvalidPrivateKey, "pkID", "local key of the push key is not available"))
+	}
+
+	// If passphrase is not set and the key is protected, start interactive mode
+	var pass = opt.Passphrase
+	if pass == "" {
+		pass = keystore.DefaultPassphrase
+		if !key.IsUnprotected() {
+			pass, _ = m.keystore.AskForPasswordOnce()
+		}
+	}
+
+	if err := key.Unlock(pass); err != nil {
+		if err == types.ErrInvalidPassphrase {
+			panic(errors.ReqErr(401, StatusCodeInvalidPass, "passphrase", err.Error()))
+		}
+		panic(errors.ReqErr(500, StatusCodeServerErr, "passphrase", err.Error()))
+	}
+
+	// Use the next nonce of the push key owner if nonce is not set
+	nonce := opt.Nonce
+	if nonce == 0 {
+		owner := m.GetAccountOfOwner(pushKeyID)
+		nonce = cast.ToUint64(fmt.Sprint(owner["nonce"])) + 1
+	}
+
+	fee := opt.Fee
+	if fee == "" {
+		fee = "0"
+	}
+
+	txDetail := &remotetypes.TxDetail{
+		RepoName:      repo,
+		RepoNamespace: opt.Namespace,
+		Reference:     opt.Reference,
+		Fee:           util.String(fee),
+		Value:         util.String(opt.Value),
+		Nonce:         nonce,
+		PushKeyID:     pushKeyID,
+		ExpiresAt:     opt.ExpiresAt,
+	}
+
+	token := pushtoken.MakeFromKey(key.GetKey(), txDetail)
+	if err := validation.CheckTxDetailSanity(txDetail, -1); err != nil {
+		if fe, ok := err.(*errors.BadFieldError); ok {
+			panic(errors.ReqErr(400, StatusCodeInvalidParam, fe.Field, fe.Msg))
+		}
+		panic(errors.ReqErr(400, StatusCodeInvalidParam, "", err.Error()))
+	}
+
+	return token
+}
+
+// VerifyMessage checks whether a message signature was created by a push key.
+// The signature is verified using the public key of the push key registered
+// on the network.
+//
+// ARGS:
+// message: The signed message
+// signature: The base58 encoded signature
+// pushKeyID: The push key address
+//
+// RETURNS: true if the signature is valid
+func (m *PushKeyModule) VerifyMessage(message, signature, pushKeyID string) bool {
+	pushKeyID = m.aliases.Resolve(pushKeyID)
+
+	if pushKeyID == "" {
+		panic(errors.ReqErr(400, StatusCodeInvalidParam, "pkID", "push key id is required"))
+	}
+
+	sig, err := base58.Decode(signature)
+	if err != nil || len(sig) == 0 {
+		panic(errors.ReqErr(400, StatusCodeInvalidParam, "signature", "signature is not valid"))
+	}
+
+	pushKey := m.logic.PushKeyKeeper().Get(pushKeyID)
+	if pushKey.IsNil() {
+		panic(errors.ReqErr(404, StatusCodePushKeyNotFound, "pkID", types.ErrPushKeyUnknown.Error()))
+	}
+
+	pubKey, err := ed25519.PubKeyFromBytes(pushKey.PubKey.Bytes())
+	if err != nil {
+		panic(errors.ReqErr(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	ok, _ := pubKey.Verify([]byte(message), sig)
+	return ok
+}
@@ -7,16 +7,22 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/make-os/kit/config"
 	crypto2 "github.com/make-os/kit/crypto/ed25519"
+	"github.com/make-os/kit/keystore"
 	"github.com/make-os/kit/mocks"
 	mocksrpc "github.com/make-os/kit/mocks/rpc"
 	"github.com/make-os/kit/modules"
+	modtypes "github.com/make-os/kit/modules/types"
 	"github.com/make-os/kit/testutil"
+	"github.com/make-os/kit/types"
 	"github.com/make-os/kit/types/api"
 	"github.com/make-os/kit/types/constants"
 	"github.com/make-os/kit/types/state"
 	"github.com/make-os/kit/types/txns"
 	"github.com/make-os/kit/util"
+	"github.com/make-os/kit/util/crypto"
 	"github.com/make-os/kit/util/errors"
+	"github.com/make-os/kit/util/pushtoken"
+	"github.com/mr-tron/base58"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/robertkrimen/otto"
@@ -36,6 +42,7 @@ var _ = Describe("PushKeyModule", func() {
 	var pk = crypto2.NewKeyFromIntSeed(1)
 	var mockClient *mocksrpc.MockClient
 	var mockPushKeyClient *mocksrpc.MockPushKey
+	var mockKeystore *mocks.MockKeystore
 
 	BeforeEach(func() {
 		cfg, err = testutil.SetTestCfg()
@@ -54,7 +61,8 @@ var _ = Describe("PushKeyModule", func() {
 		mockLogic.EXPECT().GetMempoolReactor().Return(mockMempoolReactor).AnyTimes()
 		mockLogic.EXPECT().PushKeyKeeper().Return(mockPushKeyKeeper).AnyTimes()
 		mockLogic.EXPECT().AccountKeeper().Return(mockAccountKeeper).AnyTimes()
-		m = modules.NewPushKeyModule(cfg, mockService, mockLogic)
+		mockKeystore = mocks.NewMockKeystore(ctrl)
+		m = modules.NewPushKeyModule(cfg, mockKeystore, mockService, mockLogic)
 	})
 
 	AfterEach(func() {
@@ -286,6 +294,201 @@ var _ = Describe("PushKeyModule", func() {
 		})
 	})
 
+	Describe(".GetPushKeysByAddress", func() {
+		It("should panic when address is not valid", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "address is not valid", Field: "address"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetPushKeysByAddress("invalid_addr")
+			})
+		})
+
+		It("should return empty result when address has no push keys", func() {
+			key := crypto2.NewKeyFromIntSeed(1)
+			mockPushKeyKeeper.EXPECT().GetByAddress(key.Addr().String()).Return(nil)
+			res := m.GetPushKeysByAddress(key.Addr().String())
+			Expect(res).To(BeEmpty())
+		})
+
+		It("should return push keys owned by the address", func() {
+			key := crypto2.NewKeyFromIntSeed(1)
+			pk1, pk2 := crypto2.NewKeyFromIntSeed(2), crypto2.NewKeyFromIntSeed(3)
+			mockPushKeyKeeper.EXPECT().GetByAddress(key.Addr().String()).Return([]string{
+				pk1.PushAddr().String(), pk2.PushAddr().String(),
+			})
+			mockPushKeyKeeper.EXPECT().Get(pk1.PushAddr().String()).Return(&state.PushKey{PubKey: pk1.PubKey().ToPublicKey(), Address: key.Addr(), FeeCap: "10"})
+			mockPushKeyKeeper.EXPECT().Get(pk2.PushAddr().String()).Return(&state.PushKey{PubKey: pk2.PubKey().ToPublicKey(), Address: key.Addr()})
+			res := m.GetPushKeysByAddress(key.Addr().String())
+			Expect(res).To(HaveLen(2))
+			Expect(res[0]["id"]).To(Equal(pk1.PushAddr().String()))
+			Expect(res[0]["address"]).To(Equal(key.Addr()))
+			Expect(res[0]["feeCap"]).To(Equal(util.String("10")))
+			Expect(res[1]["id"]).To(Equal(pk2.PushAddr().String()))
+		})
+	})
+
+	Describe(".CheckScope", func() {
+		var mockNSKeeper *mocks.MockNamespaceKeeper
+		id := pk.PushAddr().String()
+		pushKey := func(scopes ...string) *state.PushKey {
+			return &state.PushKey{PubKey: pk.PubKey().ToPublicKey(), Address: pk.Addr(), Scopes: scopes}
+		}
+
+		BeforeEach(func() {
+			mockNSKeeper = mocks.NewMockNamespaceKeeper(ctrl)
+			mockLogic.EXPECT().NamespaceKeeper().Return(mockNSKeeper).AnyTimes()
+		})
+
+		It("should panic when push key id is not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "push key id is required", Field: "pkID"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CheckScope("", "repo1", "")
+			})
+		})
+
+		It("should panic when repo name is not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "repo"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CheckScope(id, "", "")
+			})
+		})
+
+		It("should panic when push key does not exist", func() {
+			mockPushKeyKeeper.EXPECT().Get(id).Return(state.BarePushKey())
+			err := &errors.ReqError{Code: "push_key_not_found", HttpCode: 404, Msg: "push key not found", Field: "pkID"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CheckScope(id, "repo1", "")
+			})
+		})
+
+		It("should panic when namespace does not exist", func() {
+			mockPushKeyKeeper.EXPECT().Get(id).Return(pushKey("ns1/"))
+			mockNSKeeper.EXPECT().Get(crypto.MakeNamespaceHash("ns1")).Return(state.BareNamespace())
+			err := &errors.ReqError{Code: "namespace_not_found", HttpCode: 404, Msg: "namespace (ns1) is unknown", Field: "namespace"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CheckScope(id, "repo1", "ns1")
+			})
+		})
+
+		It("should return true when push key has no scopes", func() {
+			mockPushKeyKeeper.EXPECT().Get(id).Return(pushKey())
+			Expect(m.CheckScope(id, "repo1", "")).To(BeTrue())
+		})
+
+		for _, c := range []struct {
+			scope, repo, namespace, domainTarget string
+			permitted                            bool
+		}{
+			{"r/repo1", "repo2", "", "", false},
+			{"r/repo1", "repo1", "", "", true},
+			{"ns1/repo1", "repo1", "ns2", "", false},
+			{"ns1/repo1", "repo1", "ns1", "", true},
+			{"ns1/", "repo1", "ns2", "", false},
+			{"ns1/", "repo1", "ns1", "", true},
+			{"repo1", "repo1", "", "", true},
+			{"repo1", "repo2", "", "", false},
+			{"repo1", "repo2", "ns1", "r/repo100", false},
+			{"repo1", "repo2", "ns1", "r/repo1", true},
+			{"team-*", "team-a", "", "", true},
+			{"team-*", "repo1", "", "", false},
+			{"r/team-?", "team-a", "", "", true},
+			{"r/team-?", "team-ab", "", "", false},
+			{"ns1/team-*", "team-a", "ns1", "", true},
+			{"ns1/team-*", "team-a", "ns2", "", false},
+			{"team-*", "repo2", "ns1", "r/team-a", true},
+		} {
+			c := c
+			It(fmt.Sprintf("should return %v when scopes has %s and repo=%s and namespace='%s'", c.permitted, c.scope, c.repo, c.namespace), func() {
+				mockPushKeyKeeper.EXPECT().Get(id).Return(pushKey(c.scope))
+				if c.namespace != "" {
+					ns := state.BareNamespace()
+					ns.Owner = "owner"
+					if c.domainTarget != "" {
+						ns.Domains[c.repo] = c.domainTarget
+					}
+					mockNSKeeper.EXPECT().Get(crypto.MakeNamespaceHash(c.namespace)).Return(ns)
+				}
+				Expect(m.CheckScope(id, c.repo, c.namespace)).To(Equal(c.permitted))
+			})
+		}
+	})
+
+	Describe(".GetPushKeyReach", func() {
+		var mockNSKeeper *mocks.MockNamespaceKeeper
+		var mockRepoKeeper *mocks.MockRepoKeeper
+		id := pk.PushAddr().String()
+		pushKey := func(scopes ...string) *state.PushKey {
+			return &state.PushKey{PubKey: pk.PubKey().ToPublicKey(), Address: pk.Addr(), Scopes: scopes}
+		}
+
+		BeforeEach(func() {
+			mockNSKeeper = mocks.NewMockNamespaceKeeper(ctrl)
+			mockLogic.EXPECT().NamespaceKeeper().Return(mockNSKeeper).AnyTimes()
+			mockRepoKeeper = mocks.NewMockRepoKeeper(ctrl)
+			mockLogic.EXPECT().RepoKeeper().Return(mockRepoKeeper).AnyTimes()
+			mockRepoKeeper.EXPECT().Iterate(gomock.Any()).Do(func(fn func(string, *state.Repository) bool) {
+				for _, name := range []string{"repo1", "team-a", "team-b", "repo2"} {
+					if fn(name, state.BareRepository()) {
+						return
+					}
+				}
+			}).AnyTimes()
+		})
+
+		It("should panic when push key id is not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "push key id is required", Field: "pkID"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetPushKeyReach("")
+			})
+		})
+
+		It("should panic when push key does not exist", func() {
+			mockPushKeyKeeper.EXPECT().Get(id).Return(state.BarePushKey())
+			err := &errors.ReqError{Code: "push_key_not_found", HttpCode: 404, Msg: "push key not found", Field: "pkID"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetPushKeyReach(id)
+			})
+		})
+
+		It("should return all repositories when push key has no scopes", func() {
+			mockPushKeyKeeper.EXPECT().Get(id).Return(pushKey())
+			res := m.GetPushKeyReach(id)
+			Expect(res["repos"]).To(Equal([]string{"repo1", "repo2", "team-a", "team-b"}))
+			Expect(res["namespaces"]).To(BeEmpty())
+		})
+
+		It("should expand wildcard repo scopes against existing repositories", func() {
+			mockPushKeyKeeper.EXPECT().Get(id).Return(pushKey("team-*", "r/repo2"))
+			res := m.GetPushKeyReach(id)
+			Expect(res["repos"]).To(Equal([]string{"repo2", "team-a", "team-b"}))
+			Expect(res["namespaces"]).To(BeEmpty())
+		})
+
+		It("should expand namespace scopes against the domains of the namespace", func() {
+			ns := state.BareNamespace()
+			ns.Owner = "owner"
+			ns.Domains["web"] = "r/repo1"
+			ns.Domains["team-x"] = "r/team-a"
+			ns.Domains["team-y"] = "a/os1abc"
+			mockNSKeeper.EXPECT().Get(crypto.MakeNamespaceHash("ns1")).Return(ns).Times(2)
+			mockPushKeyKeeper.EXPECT().Get(id).Return(pushKey("ns1/"))
+			res := m.GetPushKeyReach(id)
+			Expect(res["repos"]).To(BeEmpty())
+			Expect(res["namespaces"]).To(Equal([]string{"ns1/team-x", "ns1/web"}))
+
+			mockPushKeyKeeper.EXPECT().Get(id).Return(pushKey("ns1/team-*"))
+			res = m.GetPushKeyReach(id)
+			Expect(res["namespaces"]).To(Equal([]string{"ns1/team-x"}))
+		})
+
+		It("should skip namespaces that do not exist", func() {
+			mockNSKeeper.EXPECT().Get(crypto.MakeNamespaceHash("ns2")).Return(state.BareNamespace())
+			mockPushKeyKeeper.EXPECT().Get(id).Return(pushKey("ns2/", "repo1"))
+			res := m.GetPushKeyReach(id)
+			Expect(res["repos"]).To(Equal([]string{"repo1"}))
+			Expect(res["namespaces"]).To(BeEmpty())
+		})
+	})
+
 	Describe(".GetAccountOfOwner", func() {
 		key := crypto2.NewKeyFromIntSeed(1)
 		id := key.PushAddr().String()
@@ -332,4 +535,162 @@ var _ = Describe("PushKeyModule", func() {
 			Expect(res["stakes"]).To(Equal(map[string]interface{}{}))
 		})
 	})
+
+	Describe(".SignMessage", func() {
+		id := pk.PushAddr().String()
+
+		It("should panic when push key id is not valid", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "push key id is not valid", Field: "pkID"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.SignMessage("hello", pk.Addr().String())
+			})
+		})
+
+		It("should panic when push key is not found on the keystore", func() {
+			mockKeystore.EXPECT().GetByAddress(id).Return(nil, types.ErrKeyUnknown)
+			err := &errors.ReqError{Code: "push_key_not_found", HttpCode: 404, Msg: "push key not found", Field: "pkID"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.SignMessage("hello", id)
+			})
+		})
+
+		It("should panic when passphrase is not valid", func() {
+			mockKey := mocks.NewMockStoredKey(ctrl)
+			mockKey.EXPECT().Unlock("bad").Return(types.ErrInvalidPassphrase)
+			mockKeystore.EXPECT().GetByAddress(id).Return(mockKey, nil)
+			err := &errors.ReqError{Code: "invalid_passphrase", HttpCode: 401, Msg: "invalid passphrase", Field: "passphrase"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.SignMessage("hello", id, "bad")
+			})
+		})
+
+		It("should return a signature of the message created by the push key", func() {
+			mockKey := mocks.NewMockStoredKey(ctrl)
+			mockKey.EXPECT().IsUnprotected().Return(true)
+			mockKey.EXPECT().Unlock(keystore.DefaultPassphrase).Return(nil)
+			mockKey.EXPECT().GetKey().Return(pk)
+			mockKeystore.EXPECT().GetByAddress(id).Return(mockKey, nil)
+			sig := m.SignMessage("hello", id)
+			Expect(sig).To(Equal(base58.Encode(pk.PrivKey().MustSign([]byte("hello")))))
+		})
+	})
+
+	Describe(".MakePushToken", func() {
+		id := pk.PushAddr().String()
+
+		It("should panic when repo name is not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "repo"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.MakePushToken("", id)
+			})
+		})
+
+		It("should panic when push key id is not valid", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "push key id is not valid", Field: "pkID"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.MakePushToken("repo1", pk.Addr().String())
+			})
+		})
+
+		It("should panic when the local key of the push key is not available", func() {
+			mockKeystore.EXPECT().GetByAddress(id).Return(nil, types.ErrKeyUnknown)
+			err := &errors.ReqError{Code: "invalid_private_key", HttpCode: 400, Msg: "local key of the push key is not available", Field: "pkID"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.MakePushToken("repo1", id)
+			})
+		})
+
+		It("should panic when passphrase is not valid", func() {
+			mockKey := mocks.NewMockStoredKey(ctrl)
+			mockKey.EXPECT().Unlock("bad").Return(types.ErrInvalidPassphrase)
+			mockKeystore.EXPECT().GetByAddress(id).Return(mockKey, nil)
+			err := &errors.ReqError{Code: "invalid_passphrase", HttpCode: 401, Msg: "invalid passphrase", Field: "passphrase"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.MakePushToken("repo1", id, modtypes.MakePushTokenOptions{Passphrase: "bad"})
+			})
+		})
+
+		It("should return a token that round-trips through the decoder", func() {
+			mockKey := mocks.NewMockStoredKey(ctrl)
+			mockKey.EXPECT().IsUnprotected().Return(true)
+			mockKey.EXPECT().Unlock(keystore.DefaultPassphrase).Return(nil)
+			mockKey.EXPECT().GetKey().Return(pk)
+			mockKeystore.EXPECT().GetByAddress(id).Return(mockKey, nil)
+			token := m.MakePushToken("repo1", id, modtypes.MakePushTokenOptions{
+				Reference: "refs/heads/master",
+				Fee:       "1.2",
+				Nonce:     3,
+				ExpiresAt: 1600000000,
+			})
+
+			txDetail, err := pushtoken.Decode(token)
+			Expect(err).To(BeNil())
+			Expect(txDetail.RepoName).To(Equal("repo1"))
+			Expect(txDetail.Reference).To(Equal("refs/heads/master"))
+			Expect(txDetail.Fee).To(Equal(util.String("1.2")))
+			Expect(txDetail.Nonce).To(Equal(uint64(3)))
+			Expect(txDetail.ExpiresAt).To(Equal(int64(1600000000)))
+			Expect(txDetail.PushKeyID).To(Equal(id))
+			ok, err := pk.PubKey().Verify(txDetail.BytesNoSig(), txDetail.SignatureToByte())
+			Expect(err).To(BeNil())
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should use the next nonce of the push key owner when nonce is not set", func() {
+			mockKey := mocks.NewMockStoredKey(ctrl)
+			mockKey.EXPECT().IsUnprotected().Return(true)
+			mockKey.EXPECT().Unlock(keystore.DefaultPassphrase).Return(nil)
+			mockKey.EXPECT().GetKey().Return(pk)
+			mockKeystore.EXPECT().GetByAddress(id).Return(mockKey, nil)
+
+			pushKey := state.BarePushKey()
+			pushKey.PubKey = pk.PubKey().ToPublicKey()
+			pushKey.Address = pk.Addr()
+			mockPushKeyKeeper.EXPECT().Get(id, uint64(0)).Return(pushKey)
+			acct := state.NewBareAccount()
+			acct.Nonce = 5
+			mockAccountKeeper.EXPECT().Get(pk.Addr(), uint64(0)).Return(acct)
+
+			txDetail, err := pushtoken.Decode(m.MakePushToken("repo1", id))
+			Expect(err).To(BeNil())
+			Expect(txDetail.Nonce).To(Equal(uint64(6)))
+			Expect(txDetail.Fee).To(Equal(util.String("0")))
+		})
+	})
+
+	Describe(".VerifyMessage", func() {
+		id := pk.PushAddr().String()
+		sig := base58.Encode(pk.PrivKey().MustSign([]byte("hello")))
+
+		It("should panic when signature is not valid base58", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "signature is not valid", Field: "signature"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.VerifyMessage("hello", "0OIl", id)
+			})
+		})
+
+		It("should panic when push key is unknown", func() {
+			mockPushKeyKeeper.EXPECT().Get(id).Return(state.BarePushKey())
+			err := &errors.ReqError{Code: "push_key_not_found", HttpCode: 404, Msg: "push key not found", Field: "pkID"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.VerifyMessage("hello", sig, id)
+			})
+		})
+
+		It("should return true when signature is valid", func() {
+			mockPushKeyKeeper.EXPECT().Get(id).Return(&state.PushKey{PubKey: pk.PubKey().ToPublicKey(), Address: pk.Addr()})
+			Expect(m.VerifyMessage("hello", sig, id)).To(BeTrue())
+		})
+
+		It("should return false when signature was created for another message", func() {
+			mockPushKeyKeeper.EXPECT().Get(id).Return(&state.PushKey{PubKey: pk.PubKey().ToPublicKey(), Address: pk.Addr()})
+			Expect(m.VerifyMessage("hello world", sig, id)).To(BeFalse())
+		})
+
+		It("should return false when signature was created by another key", func() {
+			otherSig := base58.Encode(crypto2.NewKeyFromIntSeed(2).PrivKey().MustSign([]byte("hello")))
+			mockPushKeyKeeper.EXPECT().Get(id).Return(&state.PushKey{PubKey: pk.PubKey().ToPublicKey(), Address: pk.Addr()})
+			Expect(m.VerifyMessage("hello", otherSig, id)).To(BeFalse())
+		})
+	})
 })
@@ -1,9 +1,19 @@
 package modules
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/AlekSi/pointer"
 	"github.com/acarl005/stripansi"
@@ -11,29 +21,47 @@ import (
 	"github.com/go-git/go-git/v5"
 	gogitcfg "github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
 	"github.com/make-os/kit/cmd/issuecmd"
 	"github.com/make-os/kit/cmd/mergecmd"
 	"github.com/make-os/kit/config"
 	"github.com/make-os/kit/crypto/ed25519"
+	"github.com/make-os/kit/logic/contracts/voteproposal"
+	"github.com/make-os/kit/logic/keepers"
 	modtypes "github.com/make-os/kit/modules/types"
+	"github.com/make-os/kit/net/dht"
 	"github.com/make-os/kit/node/services"
+	"github.com/make-os/kit/params"
 	pl "github.com/make-os/kit/remote/plumbing"
+	"github.com/make-os/kit/remote/policy"
+	"github.com/make-os/kit/remote/push"
+	pushtypes "github.com/make-os/kit/remote/push/types"
 	"github.com/make-os/kit/remote/repo"
+	"github.com/make-os/kit/remote/temprepomgr"
 	remotetypes "github.com/make-os/kit/remote/types"
+	"github.com/make-os/kit/remote/validation"
 	rpctypes "github.com/make-os/kit/rpc/types"
 	"github.com/make-os/kit/types"
 	"github.com/make-os/kit/types/api"
 	"github.com/make-os/kit/types/constants"
 	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/state"
 	"github.com/make-os/kit/types/txns"
 	"github.com/make-os/kit/util"
 	"github.com/make-os/kit/util/crypto"
+	errors2 "github.com/make-os/kit/util/errors"
 	"github.com/make-os/kit/util/identifier"
+	"github.com/make-os/kit/util/io"
 	"github.com/make-os/kit/util/pushtoken"
+	validators "github.com/make-os/kit/validation"
+	"github.com/olebedev/emitter"
 	"github.com/pkg/errors"
 	"github.com/robertkrimen/otto"
+	"github.com/shopspring/decimal"
 	"github.com/spf13/cast"
 	"github.com/stretchr/objx"
+	"github.com/thoas/go-funk"
 )
 
 // RepoModule provides repository functionalities to JS environment
@@ -54,21 +82,140 @@ type RepoModule struct {
 	MergeRequestList   mergecmd.MergeRequestListCmdFunc
 	IssueRead          issuecmd.IssueReadCmdFunc
 	MergeRequestRead   mergecmd.MergeRequestReadCmdFunc
+	GetSizeOfObjects   push.GetSizeOfObjectsFunc
+	PackToRepoUnpacker pl.PackToRepoUnpacker
+	Now                func() time.Time
+	pushLocks          *repoLocks
+	statsCache         *repoStatsCache
+	nonces             *NonceManager
+	repoUpdates        <-chan emitter.Event
+	checkReads         bool
+	readToken          string
+}
+
+// repoLocks provides a mutex per repository path, allowing operations on
+// the same repository to be serialized while operations on different
+// repositories proceed in parallel.
+type repoLocks struct {
+	lck   sync.Mutex
+	locks map[string]*repoLock
+}
+
+// repoLock is a mutex shared by the callers holding or waiting for it
+type repoLock struct {
+	sync.Mutex
+	refs int
+}
+
+// newRepoLocks creates an instance of repoLocks
+func newRepoLocks() *repoLocks {
+	return &repoLocks{locks: make(map[string]*repoLock)}
+}
+
+// Lock acquires the mutex of the repository at the given path.
+// It returns a function that must be called to release the mutex.
+func (l *repoLocks) Lock(path string) (unlock func()) {
+	path = filepath.Clean(path)
+
+	l.lck.Lock()
+	rl, ok := l.locks[path]
+	if !ok {
+		rl = &repoLock{}
+		l.locks[path] = rl
+	}
+	rl.refs++
+	l.lck.Unlock()
+
+	rl.Lock()
+	return func() {
+		rl.Unlock()
+		l.lck.Lock()
+		if rl.refs--; rl.refs == 0 {
+			delete(l.locks, path)
+		}
+		l.lck.Unlock()
+	}
+}
+
+// repoStatsCache stores the aggregated statistics of repositories.
+// Entries are invalidated when a repository is updated.
+type repoStatsCache struct {
+	lck   sync.RWMutex
+	stats map[string]util.Map
+}
+
+// newRepoStatsCache creates an instance of repoStatsCache
+func newRepoStatsCache() *repoStatsCache {
+	return &repoStatsCache{stats: make(map[string]util.Map)}
+}
+
+// Get returns a copy of the cached statistics of a repository
+func (c *repoStatsCache) Get(name string) (util.Map, bool) {
+	c.lck.RLock()
+	defer c.lck.RUnlock()
+	stats, ok := c.stats[name]
+	if !ok {
+		return nil, false
+	}
+	return util.CloneMap(stats), true
+}
+
+// Set caches the statistics of a repository
+func (c *repoStatsCache) Set(name string, stats util.Map) {
+	c.lck.Lock()
+	defer c.lck.Unlock()
+	c.stats[name] = util.CloneMap(stats)
+}
+
+// Invalidate removes the cached statistics of a repository
+func (c *repoStatsCache) Invalidate(name string) {
+	c.lck.Lock()
+	defer c.lck.Unlock()
+	delete(c.stats, name)
 }
 
 // NewAttachableRepoModule creates an instance of RepoModule suitable in attach mode
 func NewAttachableRepoModule(client rpctypes.Client) *RepoModule {
-	return &RepoModule{ModuleCommon: modtypes.ModuleCommon{Client: client}}
+	return &RepoModule{
+		ModuleCommon: modtypes.ModuleCommon{Client: client},
+		pushLocks:    newRepoLocks(),
+		statsCache:   newRepoStatsCache(),
+	}
 }
 
 // NewRepoModule creates an instance of RepoModule
 func NewRepoModule(service services.Service, repoSrv core.RemoteServer, logic core.Logic) *RepoModule {
+	cfg := logic.Config()
+	repoCache := repo.NewCache(cfg.Repo.CacheSize)
+	repoCache.CommitGraphSize = cfg.Repo.CommitGraphCacheSize
+	repoCache.Root = cfg.GetRepoRoot()
+	statsCache := newRepoStatsCache()
+
+	// Invalidate cached data of repositories that have been modified.
+	// Stops when the module is closed or the program is interrupted.
+	repoUpdates := cfg.G().Bus.On(core.EvtRepoUpdated)
+	go func() {
+		for {
+			select {
+			case evt, ok := <-repoUpdates:
+				if !ok {
+					return
+				}
+				repoCache.Invalidate(evt.Args[1].(string))
+				statsCache.Invalidate(evt.Args[0].(string))
+			case <-*config.GetInterrupt():
+				cfg.G().Bus.Off(core.EvtRepoUpdated, repoUpdates)
+				return
+			}
+		}
+	}()
+
 	return &RepoModule{
 		service:            service,
 		logic:              logic,
 		repoSrv:            repoSrv,
 		PostIDFinder:       pl.GetFreePostID,
-		GetLocalRepo:       repo.GetWithGitModule,
+		GetLocalRepo:       repoCache.Get,
 		IssueCreate:        issuecmd.IssueCreateCmd,
 		IssueClose:         issuecmd.IssueCloseCmd,
 		IssueReopen:        issuecmd.IssueReopenCmd,
@@ -79,6 +226,57 @@ func NewRepoModule(service services.Service, repoSrv core.RemoteServer, logic co
 		MergeRequestList:   mergecmd.MergeRequestListCmd,
 		IssueRead:          issuecmd.IssueReadCmd,
 		MergeRequestRead:   mergecmd.MergeRequestReadCmd,
+		GetSizeOfObjects:   push.GetSizeOfObjects,
+		PackToRepoUnpacker: pl.UnpackPackfileToRepo,
+		Now:                time.Now,
+		pushLocks:          newRepoLocks(),
+		statsCache:         statsCache,
+		repoUpdates:        repoUpdates,
+	}
+}
+
+// Close stops the module from listening for repository updates
+func (m *RepoModule) Close() {
+	if m.repoUpdates != nil {
+		m.logic.Config().G().Bus.Off(core.EvtRepoUpdated, m.repoUpdates)
+	}
+}
+
+// WithReadToken returns a copy of the module that serves the content of a
+// private repository only if the given push token was signed by an owner of
+// the repository or a requester in the repository's access allow-list.
+// It is used to serve requesters that are not the node operator.
+//  - token: The push token of the requester
+func (m *RepoModule) WithReadToken(token string) modtypes.RepoModule {
+	cp := *m
+	cp.checkReads = true
+	cp.readToken = token
+	return &cp
+}
+
+// checkReadAccess panics if the module was created with a read token and the
+// token does not permit reading the given repository
+func (m *RepoModule) checkReadAccess(name string) {
+	if !m.checkReads || m.IsAttached() {
+		return
+	}
+
+	repoState := m.logic.RepoKeeper().Get(name)
+	if repoState.IsEmpty() || repoState.Config == nil || !repoState.Config.Access.IsPrivate() {
+		return
+	}
+
+	if m.readToken == "" {
+		panic(se(403, StatusCodeReadAccessDenied, "token", "push token is required to read a private repository"))
+	}
+
+	txDetail, err := pushtoken.Decode(m.readToken)
+	if err != nil {
+		panic(se(400, StatusCodeInvalidParam, "token", err.Error()))
+	}
+
+	if err = validation.CheckReadAccess(txDetail, repoState, m.logic, validation.CheckTxDetail); err != nil {
+		panic(se(403, StatusCodeReadAccessDenied, "token", err.Error()))
 	}
 }
 
@@ -87,38 +285,101 @@ func (m *RepoModule) methods() []*modtypes.VMMember {
 	return []*modtypes.VMMember{
 		{Name: "create", Value: m.Create, Description: "Create a git repository on the network"},
 		{Name: "get", Value: m.Get, Description: "Get and return a repository"},
+		{Name: "getNamespaces", Value: m.GetRepoNamespaces, Description: "Get the namespace domains that point to a repository"},
+		{Name: "getGovernance", Value: m.GetGovernance, Description: "Get the governance config of a repository with named settings"},
+		{Name: "getReferenceNonce", Value: m.GetReferenceNonce, Description: "Get the current nonce of a repository reference"},
+		{Name: "listRefs", Value: m.ListRefs, Description: "List the references of a repository with their kind and target hash"},
+		{Name: "reconcile", Value: m.ReconcileRepo, Description: "Compare the local references of a repository with its on-chain state"},
+		{Name: "getEffectivePolicies", Value: m.GetEffectivePolicies, Description: "Get the policies that apply to a push by a push key"},
 		{Name: "update", Value: m.Update, Description: "Update a repository"},
+		{Name: "validateConfig", Value: m.ValidateRepoConfig, Description: "Validate a repository config without creating a proposal"},
 		{Name: "upsertOwner", Value: m.UpsertOwner, Description: "Create a proposal to add or update a repository owner"},
 		{Name: "vote", Value: m.Vote, Description: "Vote for or against a proposal"},
+		{Name: "getVotingPower", Value: m.GetVotingPower, Description: "Get the voting power of a voter on a proposal"},
 		{Name: "depositPropFee", Value: m.DepositProposalFee, Description: "Deposit fees into a proposal"},
+		{Name: "getClosedProposals", Value: m.GetClosedProposals, Description: "Get the finalized proposals of a repository and their outcome"},
+		{Name: "listProposals", Value: m.ListProposals, Description: "List the proposals of a repository"},
+		{Name: "getProposalConfigDiff", Value: m.GetProposalConfigDiff, Description: "Get the config changes an update proposal would apply"},
+		{Name: "getConfigHistory", Value: m.GetRepoConfigHistory, Description: "Get the config changes of a repository between two heights"},
 		{Name: "addContributor", Value: m.AddContributor, Description: "Register one or more push keys as contributors"},
 		{Name: "track", Value: m.Track, Description: "Track one or more repositories"},
 		{Name: "untrack", Value: m.UnTrack, Description: "Untrack one or more repositories"},
 		{Name: "tracked", Value: m.GetTracked, Description: "Get a list of tracked repositories"},
+		{Name: "trackedStatus", Value: m.GetTrackedStatus, Description: "Get the sync status of tracked repositories"},
 		{Name: "listByCreator", Value: m.GetReposCreatedByAddress, Description: "List repositories created by an address"},
+		{Name: "resolveURI", Value: m.ResolveURI, Description: "Resolve a repository URI to its canonical form and local path"},
+		{Name: "normalizeName", Value: m.NormalizeRepoName, Description: "Validate a repository name and get its canonical form"},
 
 		// Repository read and write methods.
 		{Name: "ls", Value: m.ListPath, Description: "List files and directories of a repository"},
+		{Name: "getCodeOwnership", Value: m.GetCodeOwnership, Description: "Get the share of lines each author owns under a path"},
+		{Name: "getFileHistory", Value: m.GetFileHistory, Description: "Get the commits that changed a file"},
 		{Name: "readFileLines", Value: m.ReadFileLines, Description: "Get the lines of a file in a repository"},
 		{Name: "readFile", Value: m.ReadFile, Description: "Get the string content of a file in a repository"},
+		{Name: "getReadme", Value: m.GetReadme, Description: "Get the README file of a repository at a branch"},
+		{Name: "readFileChunk", Value: m.ReadFileChunk, Description: "Get a byte range of a file in a repository"},
+		{Name: "archive", Value: m.Archive, Description: "Get a tar.gz archive of the files of a repository"},
+		{Name: "getContentHash", Value: m.GetRepoContentHash, Description: "Get a hash of the files of a repository at a reference"},
+		{Name: "getMeta", Value: m.GetRepoMeta, Description: "Get the files stored in the meta references of a repository"},
 		{Name: "getBranches", Value: m.GetBranches, Description: "Get a list of branches in a repository"},
+		{Name: "getBranchesWithMeta", Value: m.GetBranchesWithMeta, Description: "Get a list of branches in a repository along with their tip and last commit time"},
+		{Name: "getDefaultBranch", Value: m.GetDefaultBranch, Description: "Get the default branch of a repository"},
+		{Name: "getStaleBranches", Value: m.GetStaleBranches, Description: "Get branches with no recent commits"},
 		{Name: "getLatestCommit", Value: m.GetLatestBranchCommit, Description: "Get the latest commit of a branch in a repository"},
 		{Name: "getCommits", Value: m.GetCommits, Description: "Get a list of commits in a branch of a repository"},
+		{Name: "getCommitGraphDOT", Value: m.GetCommitGraphDOT, Description: "Get the commit graph of a branch in Graphviz DOT format"},
 		{Name: "getCommit", Value: m.GetCommit, Description: "Get a commit"},
+		{Name: "verifyBranchSignatures", Value: m.VerifyBranchSignatures, Description: "Verify the signatures of the commits of a branch"},
+		{Name: "getTagSignatureInfo", Value: m.GetTagSignatureInfo, Description: "Get the signature information of an annotated tag"},
+		{Name: "auditSignatures", Value: m.AuditRepoSignatures, Description: "Report reference tips with invalid signatures or signed by non-contributors"},
+		{Name: "getProvenanceChain", Value: m.GetProvenanceChain, Description: "Get the signer and signature validity of a commit and its ancestors"},
+		{Name: "getCommitNotes", Value: m.GetCommitNotes, Description: "Get the note attached to a commit"},
+		{Name: "getMissingObjects", Value: m.GetMissingObjects, Description: "Get objects reachable from a reference that are missing locally"},
+		{Name: "verifyIntegrity", Value: m.VerifyRepoIntegrity, Description: "Check that all objects reachable from the repository's references exist and are intact"},
+		{Name: "estimateCloneSize", Value: m.EstimateCloneSize, Description: "Estimate the number and size of objects a clone would transfer"},
+		{Name: "getObjectStats", Value: m.GetObjectStats, Description: "Get the number of objects of each type in a repository"},
+		{Name: "analyzeRepack", Value: m.AnalyzeRepack, Description: "Estimate the disk space a repack of a repository would reclaim"},
+		{Name: "repack", Value: m.DoRepack, Description: "Repack the objects of a repository"},
+		{Name: "getStats", Value: m.GetRepoStats, Description: "Get the aggregated statistics of a repository"},
 		{Name: "getAncestors", Value: m.GetCommitAncestors, Description: "Get ancestors of a commit in a repository"},
+		{Name: "getMergeBase", Value: m.GetMergeBase, Description: "Get the best common ancestor of two commits"},
+		{Name: "compareRepos", Value: m.CompareRepos, Description: "Get the commits a branch of a fork is ahead and behind the same branch of its base"},
+		{Name: "getBranchDivergence", Value: m.GetBranchDivergence, Description: "Get the number of commits a branch is ahead and behind the default branch"},
+		{Name: "getRefsContaining", Value: m.GetRefsContaining, Description: "Get the branches and tags whose history includes a commit"},
 		{Name: "countCommits", Value: m.CountCommits, Description: "Get a branch/reference commit count"},
 		{Name: "getDiffOfCommitAndParents", Value: m.GetParentsAndCommitDiff, Description: "Get the diff output of a commit and its parent(s)"},
+		{Name: "getCommitTree", Value: m.GetCommitTree, Description: "Get the entries of the full file tree of a commit"},
+		{Name: "exportPatchSeries", Value: m.ExportPatchSeries, Description: "Get a patch for each commit in a range of commits"},
+		{Name: "compareTags", Value: m.CompareTags, Description: "Get the commits and changed files between two tags"},
 		{Name: "createIssue", Value: m.CreateIssue, Description: "Create, add comment or edit an issue"},
 		{Name: "closeIssue", Value: m.CloseIssue, Description: "Close an issue"},
 		{Name: "reopenIssue", Value: m.ReopenIssue, Description: "Reopen an issue"},
 		{Name: "listIssues", Value: m.ListIssues, Description: "List all issues"},
 		{Name: "readIssue", Value: m.ReadIssue, Description: "Read an issue"},
+		{Name: "readIssueThread", Value: m.ReadIssueThread, Description: "Read an issue's comments as reply threads"},
 		{Name: "createMergeRequest", Value: m.CreateMergeRequest, Description: "Create, add comment or edit a merge request"},
 		{Name: "closeMergeRequest", Value: m.CloseMergeRequest, Description: "Close a merge request"},
 		{Name: "reopenMergeRequest", Value: m.ReopenMergeRequest, Description: "Reopen a merge request"},
 		{Name: "listMergeRequests", Value: m.ListMergeRequests, Description: "List all merge requests"},
+		{Name: "applyPostRetention", Value: m.ApplyPostRetention, Description: "Archive or prune issues and merge requests closed longer than the retention period"},
+		{Name: "exportPosts", Value: m.ExportPosts, Description: "Export the issues or merge requests of a repository as a portable bundle"},
+		{Name: "importPosts", Value: m.ImportPosts, Description: "Re-create the issues or merge requests of a bundle in a temporary worktree"},
+		{Name: "getTimeline", Value: m.GetRepoTimeline, Description: "Get the push, proposal, issue and merge request activity of a repository"},
 		{Name: "readMergeRequest", Value: m.ReadMergeRequest, Description: "Read a merge request"},
+		{Name: "readMergeRequestThread", Value: m.ReadMergeRequestThread, Description: "Read a merge request's comments as reply threads"},
 		{Name: "push", Value: m.Push, Description: "Sign and push a commit, tag or note in a temporary worktree"},
+		{Name: "estimatePushSize", Value: m.EstimatePushSize, Description: "Estimate the size of objects to be pushed from a temporary worktree"},
+		{Name: "computePushID", Value: m.ComputePushID, Description: "Compute a deterministic ID for the change set of a temporary worktree"},
+		{Name: "previewPostChange", Value: m.PreviewPostChange, Description: "Get the patch a staged issue or merge request change would introduce"},
+		{Name: "snapshotTempRepo", Value: m.SnapshotTempRepo, Description: "Get a serialized snapshot of a temporary worktree"},
+		{Name: "restoreTempRepo", Value: m.RestoreTempRepo, Description: "Restore a temporary worktree from a snapshot"},
+		{Name: "getPushedRefs", Value: m.GetPushedRefs, Description: "Get the references modified by a push transaction"},
+		{Name: "getPushEndorsements", Value: m.GetPushEndorsements, Description: "Get the endorsements of a push transaction"},
+		{Name: "getPendingPushes", Value: m.GetPendingPushes, Description: "Get push notes in the push pool awaiting an endorsement quorum"},
+		{Name: "resignRefs", Value: m.ResignRefs, Description: "Sign the branches and tags of a temporary worktree with a new push key"},
+		{Name: "cloneRepos", Value: m.CloneRepos, Description: "Clone several repositories from the DHT concurrently"},
+		{Name: "syncFromUpstream", Value: m.SyncFromUpstream, Description: "Fetch and stage new references of a fork's upstream repository"},
+		{Name: "decodePushToken", Value: m.DecodePushToken, Description: "Decode and verify a push token"},
 	}
 }
 
@@ -174,7 +435,7 @@ func (m *RepoModule) Create(params map[string]interface{}, options ...interface{
 	if err := tx.FromMap(params); err != nil {
 		panic(se(400, StatusCodeInvalidParam, "params", err.Error()))
 	}
-	retPayload, signingKey := finalizeTx(tx, m.logic, m.Client, options...)
+	retPayload, signingKey := finalizeTx(tx, m.logic, m.Client, m.nonces, options...)
 	if retPayload {
 		return tx.ToMap()
 	}
@@ -191,6 +452,7 @@ func (m *RepoModule) Create(params map[string]interface{}, options ...interface{
 		if err != nil {
 			panic(err)
 		}
+		m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
 		return util.ToMap(resp)
 	}
 
@@ -198,6 +460,7 @@ func (m *RepoModule) Create(params map[string]interface{}, options ...interface{
 	if err != nil {
 		panic(se(400, StatusCodeMempoolAddFail, "", err.Error()))
 	}
+	m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
 
 	return map[string]interface{}{
 		"hash":    hash,
@@ -228,7 +491,7 @@ func (m *RepoModule) UpsertOwner(params map[string]interface{}, options ...inter
 		panic(se(400, StatusCodeInvalidParam, "params", err.Error()))
 	}
 
-	if retPayload, _ := finalizeTx(tx, m.logic, nil, options...); retPayload {
+	if retPayload, _ := finalizeTx(tx, m.logic, nil, m.nonces, options...); retPayload {
 		return tx.ToMap()
 	}
 
@@ -236,6 +499,7 @@ func (m *RepoModule) UpsertOwner(params map[string]interface{}, options ...inter
 	if err != nil {
 		panic(se(400, StatusCodeMempoolAddFail, "", err.Error()))
 	}
+	m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
 
 	return map[string]interface{}{
 		"hash": hash,
@@ -266,7 +530,7 @@ func (m *RepoModule) Vote(params map[string]interface{}, options ...interface{})
 		panic(se(400, StatusCodeInvalidParam, "params", err.Error()))
 	}
 
-	retPayload, signingKey := finalizeTx(tx, m.logic, m.Client, options...)
+	retPayload, signingKey := finalizeTx(tx, m.logic, m.Client, m.nonces, options...)
 	if retPayload {
 		return tx.ToMap()
 	}
@@ -283,6 +547,7 @@ func (m *RepoModule) Vote(params map[string]interface{}, options ...interface{})
 		if err != nil {
 			panic(err)
 		}
+		m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
 		return util.ToMap(resp)
 	}
 
@@ -290,12 +555,160 @@ func (m *RepoModule) Vote(params map[string]interface{}, options ...interface{})
 	if err != nil {
 		panic(se(400, StatusCodeMempoolAddFail, "", err.Error()))
 	}
+	m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
 
 	return map[string]interface{}{
 		"hash": hash,
 	}
 }
 
+// GetVotingPower returns the voting power a voter would have on a proposal
+// based on the proposal's tally method and the current state.
+//  - name: The name of the repository.
+//  - id: The proposal ID.
+//  - address: The voter's public key or user address. The public key is
+//    required when the proposal uses a net-stake tally method.
+//
+// RETURN object <map>
+//  - power <number>: The voting power of the voter
+//  - tallyMethod <number>: The tally method of the proposal
+//  - endAt <number>: The last block height at which votes are accepted
+func (m *RepoModule) GetVotingPower(name, id, address string) util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+	if id == "" {
+		panic(se(400, StatusCodeInvalidParam, "id", "proposal id is required"))
+	}
+	if address == "" {
+		panic(se(400, StatusCodeInvalidParam, "address", "address is required"))
+	}
+
+	r := m.logic.RepoKeeper().Get(name)
+	if r.IsEmpty() {
+		panic(se(404, StatusCodeRepoNotFound, "name", types.ErrRepoNotFound.Error()))
+	}
+
+	proposal := r.Proposals.Get(id)
+	if proposal == nil {
+		panic(se(404, StatusCodeProposalNotFound, "id", "proposal not found"))
+	}
+
+	var voterAddr identifier.Address
+	var voterPubKey util.Bytes32
+	if pk, err := ed25519.PubKeyFromBase58(address); err == nil {
+		voterAddr, voterPubKey = pk.Addr(), pk.MustBytes32()
+	} else if identifier.IsValidUserAddr(address) == nil {
+		voterAddr = identifier.Address(address)
+	} else {
+		panic(se(400, StatusCodeInvalidParam, "address", "address is not a valid user address or public key"))
+	}
+
+	tallyMethod := *proposal.Config.PropTallyMethod
+	res := util.Map{"power": float64(0), "tallyMethod": tallyMethod, "endAt": proposal.EndAt.UInt64()}
+
+	switch state.ProposalTallyMethod(tallyMethod) {
+	case state.ProposalTallyMethodNetStake,
+		state.ProposalTallyMethodNetStakeNonDelegated,
+		state.ProposalTallyMethodNetStakeOfDelegators:
+		if voterPubKey.IsEmpty() {
+			panic(se(400, StatusCodeInvalidParam, "address", "public key is required for net-stake tally methods"))
+		}
+	}
+
+	// Only owners can vote on proposals restricted to owners
+	if *proposal.Config.Voter == *state.VoterOwner.Ptr() && !r.Owners.Has(voterAddr.String()) {
+		return res
+	}
+
+	bi, err := m.logic.SysKeeper().GetLastBlockInfo()
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	power, err := voteproposal.GetVotingPower(m.logic, name, id, proposal, voterAddr, voterPubKey, uint64(bi.Height), nil)
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+	res["power"] = power
+
+	return res
+}
+
+// resolveRepoName resolves a full namespace URI (r/repo or ns/domain) to
+// the name of the repository it points to. It panics if the namespace or
+// domain is unknown or the domain does not target a repository.
+//  - uri: The full namespace URI.
+//  - field: The name of the parameter the URI was passed in.
+func (m *RepoModule) resolveRepoName(uri, field string) string {
+	nsName := identifier.GetNamespace(uri)
+	if nsName == identifier.NativeNamespaceRepoChar {
+		return identifier.GetDomain(uri)
+	}
+
+	ns := m.logic.NamespaceKeeper().Get(crypto.MakeNamespaceHash(nsName))
+	if ns.IsNil() {
+		panic(se(404, StatusCodeInvalidParam, field, "namespace not found"))
+	}
+	target := ns.Domains.Get(identifier.GetDomain(uri))
+	if target == "" {
+		panic(se(404, StatusCodeInvalidParam, field, "namespace domain not found"))
+	}
+	if !strings.HasPrefix(target, identifier.NativeNamespaceRepo) {
+		panic(se(404, StatusCodeInvalidParam, field, "namespace domain target is not a repository"))
+	}
+
+	return identifier.GetDomain(target)
+}
+
+// ResolveURI resolves a repository URI to its canonical
+// form and the path of the repository on disk.
+//  - uri: The repository name or a full namespace URI (r/repo or ns/domain).
+//
+// RETURN <map>
+//  - name <string>: The name of the repository
+//  - uri <string>: The canonical URI of the repository (r/repo)
+//  - path <string>: The path of the repository on disk
+func (m *RepoModule) ResolveURI(uri string) util.Map {
+	if uri == "" {
+		panic(se(400, StatusCodeInvalidParam, "uri", "uri is required"))
+	}
+
+	name := uri
+	if identifier.IsFullNamespaceURI(uri) {
+		name = m.resolveRepoName(uri, "uri")
+	}
+
+	if err := identifier.IsValidResourceName(name); err != nil {
+		panic(se(400, StatusCodeInvalidParam, "uri", "uri is not valid: "+err.Error()))
+	}
+
+	return util.Map{
+		"name": name,
+		"uri":  identifier.NativeNamespaceRepo + name,
+		"path": m.logic.Config().GetRepoPath(name),
+	}
+}
+
+// NormalizeRepoName validates a repository name against the rules enforced
+// when a repository is created and returns its canonical form. The native
+// repo namespace prefix (r/) and surrounding spaces are removed.
+// The network is not contacted and the repository does not have to exist.
+//  - name: The name of the repository (e.g repo1 or r/repo1)
+func (m *RepoModule) NormalizeRepoName(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "name is required"))
+	}
+
+	name = strings.TrimPrefix(name, identifier.NativeNamespaceRepo)
+	if err := identifier.IsValidResourceName(name); err != nil {
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	return name
+}
+
 // Get finds and returns a repository.
 //
 // name: The name of the repository
@@ -332,23 +745,7 @@ func (m *RepoModule) Get(name string, opts ...modtypes.GetOptions) util.Map {
 	}
 
 	if identifier.IsFullNamespaceURI(name) {
-		nsName := identifier.GetNamespace(name)
-		if nsName == identifier.NativeNamespaceRepoChar {
-			name = identifier.GetDomain(name)
-		} else {
-			ns := m.logic.NamespaceKeeper().Get(crypto.MakeNamespaceHash(nsName))
-			if ns.IsNil() {
-				panic(se(404, StatusCodeInvalidParam, "name", "namespace not found"))
-			}
-			name = ns.Domains.Get(identifier.GetDomain(name))
-			if name == "" {
-				panic(se(404, StatusCodeInvalidParam, "name", "namespace domain not found"))
-			}
-			if !strings.HasPrefix(name, identifier.NativeNamespaceRepo) {
-				panic(se(404, StatusCodeInvalidParam, "name", "namespace domain target is not a repository"))
-			}
-			name = identifier.GetDomain(name)
-		}
+		name = m.resolveRepoName(name, "name")
 	}
 
 	r := m.logic.RepoKeeper().Get(name, blockHeight)
@@ -365,7 +762,281 @@ func (m *RepoModule) Get(name string, opts ...modtypes.GetOptions) util.Map {
 		return selected
 	}
 
-	return util.ToMap(r)
+	res := util.ToMap(r)
+	if r.Config != nil && r.Config.Upstream != "" {
+		res["upstream"] = r.Config.Upstream
+	}
+
+	return res
+}
+
+// GetRepoNamespaces returns the namespace domains that point to a repository.
+//
+// name: The name of the repository
+//
+// RETURN []<map>
+//  - namespace <string>: The hashed name of the namespace
+//  - domain <string>: The namespace domain that targets the repository
+func (m *RepoModule) GetRepoNamespaces(name string) []util.Map {
+	if m.logic.RepoKeeper().Get(name).IsEmpty() {
+		panic(se(404, StatusCodeRepoNotFound, "name", types.ErrRepoNotFound.Error()))
+	}
+	return util.StructSliceToMap(m.logic.NamespaceKeeper().GetRepoNamespaces(name))
+}
+
+// GetReferenceNonce returns the current nonce of a repository reference.
+// The next push to the reference must use the returned nonce + 1.
+// Returns 0 if the reference does not exist yet.
+//
+// name: The name of the repository
+// ref: The full reference name (e.g refs/heads/master)
+func (m *RepoModule) GetReferenceNonce(name, ref string) uint64 {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+	if ref == "" {
+		panic(se(400, StatusCodeInvalidParam, "ref", "reference name is required"))
+	}
+
+	repoState := m.logic.RepoKeeper().Get(name)
+	if repoState.IsEmpty() {
+		panic(se(404, StatusCodeRepoNotFound, "name", types.ErrRepoNotFound.Error()))
+	}
+
+	return repoState.References.Get(ref).Nonce.UInt64()
+}
+
+// ReconcileRepo compares the references of the local copy of a repository
+// with the references recorded in the repository's on-chain state.
+//
+// name: The name of the repository
+//
+// RETURN <map>
+//  - consistent <bool>: Indicates whether the local references match the on-chain state
+//  - missingOnChain <[]string>: References that exist locally but not on-chain
+//  - missingLocally <[]string>: References that exist on-chain but not locally
+//  - staleRefs <[]map>: References whose local hash differs from the on-chain hash
+//    - name <string>: The reference name
+//    - localHash <string>: The hash of the reference in the local repository
+//    - chainHash <string>: The hash of the reference in the on-chain state
+func (m *RepoModule) ReconcileRepo(name string) util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	repoState := m.logic.RepoKeeper().Get(name)
+	if repoState.IsEmpty() {
+		panic(se(404, StatusCodeRepoNotFound, "name", types.ErrRepoNotFound.Error()))
+	}
+
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeRepoNotFound, "name", types.ErrRepoNotFound.Error()))
+		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	refs, err := r.GetReferences()
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", errors.Wrap(err, "failed to get local references").Error()))
+	}
+
+	var missingOnChain, missingLocally = []string{}, []string{}
+	var staleRefs = []util.Map{}
+	var localNames []string
+	var localRefs = map[string]struct{}{}
+	for _, ref := range refs {
+		if !strings.HasPrefix(ref.String(), "refs/") {
+			continue
+		}
+		localRefs[ref.String()] = struct{}{}
+		localNames = append(localNames, ref.String())
+	}
+
+	sort.Strings(localNames)
+	for _, ref := range localNames {
+		chainRef := repoState.References.Get(ref)
+		if chainRef.IsNil() {
+			missingOnChain = append(missingOnChain, ref)
+			continue
+		}
+
+		localHash, err := r.RefGet(ref)
+		if err != nil {
+			panic(se(500, StatusCodeServerErr, "", errors.Wrapf(err, "failed to get hash of %s", ref).Error()))
+		}
+
+		chainHash := chainRef.Hash.HexStr(true)
+		if localHash != chainHash {
+			staleRefs = append(staleRefs, util.Map{
+				"name":      ref,
+				"localHash": localHash,
+				"chainHash": chainHash,
+			})
+		}
+	}
+
+	var chainNames []string
+	for ref := range repoState.References {
+		chainNames = append(chainNames, ref)
+	}
+
+	sort.Strings(chainNames)
+	for _, ref := range chainNames {
+		if _, ok := localRefs[ref]; !ok {
+			missingLocally = append(missingLocally, ref)
+		}
+	}
+
+	return util.Map{
+		"consistent":     len(missingOnChain) == 0 && len(missingLocally) == 0 && len(staleRefs) == 0,
+		"missingOnChain": missingOnChain,
+		"missingLocally": missingLocally,
+		"staleRefs":      staleRefs,
+	}
+}
+
+// GetGovernance returns the governance config of a repository with its
+// enumerated settings resolved to their names. Settings not set in the
+// repository config are not included.
+//
+// name: The name of the repository
+//
+// RETURN <map>
+//  - voter <string>: The type of voters (e.g Owner, NetStakers)
+//  - proposalCreator <string>: Who can create proposals (e.g Any, Owner)
+//  - tallyMethod <string>: The vote tally method (e.g Identity, CoinWeighted)
+//  - quorumMode <string>: How the total voting power of the quorum is determined
+//  - closeTrigger <string>: What closes a finalized proposal
+//  - feeRefundType <string>: When proposal fees are refunded
+//  - duration <uint64>: The number of blocks a proposal is open for voting
+//  - quorum <float64>: The percentage of voting power that must vote
+//  - threshold <float64>: The percentage of votes required to accept a proposal
+//  - vetoQuorum <float64>: The percentage of veto votes required to reject a proposal
+//  - vetoOwnersQuorum <float64>: The percentage of owner veto votes required to reject a proposal
+//  - proposalFee <string>: The proposal fee
+//  - feeDepositDuration <uint64>: The number of blocks during which fees can be deposited
+//  - feeDepositCap <string>: The maximum total fee deposit
+func (m *RepoModule) GetGovernance(name string) util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	repoState := m.logic.RepoKeeper().Get(name)
+	if repoState.IsEmpty() {
+		panic(se(404, StatusCodeRepoNotFound, "name", types.ErrRepoNotFound.Error()))
+	}
+
+	res := util.Map{}
+	if repoState.Config == nil || repoState.Config.Gov == nil {
+		return res
+	}
+
+	gov := repoState.Config.Gov
+	if gov.Voter != nil {
+		res["voter"] = state.VoterType(*gov.Voter).String()
+	}
+	if gov.PropCreator != nil {
+		res["proposalCreator"] = state.ProposalCreatorType(*gov.PropCreator).String()
+	}
+	if gov.PropTallyMethod != nil {
+		res["tallyMethod"] = state.ProposalTallyMethod(*gov.PropTallyMethod).String()
+	}
+	if gov.PropQuorumMode != nil {
+		res["quorumMode"] = state.ProposalQuorumMode(*gov.PropQuorumMode).String()
+	}
+	if gov.PropCloseTrigger != nil {
+		res["closeTrigger"] = state.ProposalCloseTrigger(*gov.PropCloseTrigger).String()
+	}
+	if gov.PropFeeRefundType != nil {
+		res["feeRefundType"] = state.PropFeeRefundType(*gov.PropFeeRefundType).String()
+	}
+	if gov.PropDuration != nil {
+		res["duration"] = cast.ToUint64(*gov.PropDuration)
+	}
+	if gov.PropQuorum != nil {
+		res["quorum"] = util.PtrStrToFloat(gov.PropQuorum)
+	}
+	if gov.PropThreshold != nil {
+		res["threshold"] = util.PtrStrToFloat(gov.PropThreshold)
+	}
+	if gov.PropVetoQuorum != nil {
+		res["vetoQuorum"] = util.PtrStrToFloat(gov.PropVetoQuorum)
+	}
+	if gov.PropVetoOwnersQuorum != nil {
+		res["vetoOwnersQuorum"] = util.PtrStrToFloat(gov.PropVetoOwnersQuorum)
+	}
+	if gov.PropFee != nil {
+		res["proposalFee"] = *gov.PropFee
+	}
+	if gov.PropFeeDepositDur != nil {
+		res["feeDepositDuration"] = cast.ToUint64(*gov.PropFeeDepositDur)
+	}
+	if gov.PropFeeDepositCap != nil {
+		res["feeDepositCap"] = *gov.PropFeeDepositCap
+	}
+	if gov.MaxOpenProposals != nil {
+		res["maxOpenProposals"] = cast.ToUint64(*gov.MaxOpenProposals)
+	}
+
+	return res
+}
+
+// GetEffectivePolicies returns the repo config policies and contributor
+// policies that apply to a push by the given push key, ordered by
+// precedence. A policy that also exists at a higher precedence level
+// is not repeated. Policies whose subject is "contrib" are only included
+// when the push key is a contributor of the repository.
+//
+// name: The name of the repository
+// pushKeyID: The push key ID
+//
+// RETURN []<map>
+//  - sub <string>: The policy subject
+//  - obj <string>: The policy object
+//  - act <string>: The policy action
+//  - level <int>: The precedence level of the policy (lower takes precedence)
+//  - source <string>: Where the policy was defined (contributor or repo)
+func (m *RepoModule) GetEffectivePolicies(name, pushKeyID string) []util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+	if pushKeyID == "" {
+		panic(se(400, StatusCodeInvalidParam, "pkID", "push key id is required"))
+	}
+
+	repoState := m.logic.RepoKeeper().Get(name)
+	if repoState.IsEmpty() {
+		panic(se(404, StatusCodeRepoNotFound, "name", types.ErrRepoNotFound.Error()))
+	}
+
+	if m.logic.PushKeyKeeper().Get(pushKeyID).IsNil() {
+		panic(se(404, StatusCodePushKeyNotFound, "pkID", types.ErrPushKeyUnknown.Error()))
+	}
+
+	isContributor := repoState.Contributors.Has(pushKeyID)
+	groups := policy.MakePusherPolicyGroups(pushKeyID, repoState, nil)
+
+	var res = []util.Map{}
+	for _, item := range policy.NewPolicyEnforcer(groups).GetPolicies() {
+		if item.Policy.Subject == "contrib" && !isContributor {
+			continue
+		}
+		source := "repo"
+		if item.Level == 0 {
+			source = "contributor"
+		}
+		res = append(res, util.Map{
+			"sub":    item.Policy.Subject,
+			"obj":    item.Policy.Object,
+			"act":    item.Policy.Action,
+			"level":  item.Level,
+			"source": source,
+		})
+	}
+
+	return res
 }
 
 // Update creates a proposal to update a repository
@@ -393,7 +1064,7 @@ func (m *RepoModule) Update(params map[string]interface{}, options ...interface{
 		panic(se(400, StatusCodeInvalidParam, "params", err.Error()))
 	}
 
-	if retPayload, _ := finalizeTx(tx, m.logic, nil, options...); retPayload {
+	if retPayload, _ := finalizeTx(tx, m.logic, nil, m.nonces, options...); retPayload {
 		return tx.ToMap()
 	}
 
@@ -401,49 +1072,401 @@ func (m *RepoModule) Update(params map[string]interface{}, options ...interface{
 	if err != nil {
 		panic(se(400, StatusCodeMempoolAddFail, "", err.Error()))
 	}
+	m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
 
 	return map[string]interface{}{
 		"hash": hash,
 	}
 }
 
-// DepositProposalFee creates a transaction to deposit a fee to a proposal
-//
-// params <map>
-//  - params.name <string>: The name of the repository
-//  - params.id <string>: A unique proposal ID
-//  - params.value <string|number>: The amount to add
-//  - params.nonce <number|string>: The senders next account nonce
-//  - params.fee <number|string>: The transaction fee to pay
-//  - params.timestamp <number>: The unix timestamp
-//
-// options <[]interface{}>
-//  - [0] key <string>: The signer's private key
-//  - [1] payloadOnly <bool>: When true, returns the payload only, without sending the tx.
+// ValidateRepoConfig checks whether a repository config is valid
+// without building or submitting an update proposal.
+//  - config: The repository config to validate
 //
 // RETURN object <map>
-//  - hash <string>: The transaction hash
-func (m *RepoModule) DepositProposalFee(params map[string]interface{}, options ...interface{}) util.Map {
-	var err error
-
-	var tx = txns.NewBareRepoProposalFeeSend()
-	if err = tx.FromMap(params); err != nil {
-		panic(se(400, StatusCodeInvalidParam, "params", err.Error()))
+//  - valid <bool>: Indicates whether the config is valid
+//  - errors <[]map>: The validation errors
+//    - field <string>: The invalid field
+//    - msg <string>: The error message
+func (m *RepoModule) ValidateRepoConfig(config map[string]interface{}) util.Map {
+	var errs = []util.Map{}
+
+	cfg := state.BareRepoConfig()
+	if err := util.DecodeMap(config, cfg); err != nil {
+		errs = append(errs, util.Map{"field": "config", "msg": err.Error()})
+	} else if err = validators.CheckRepoConfig(cfg, -1); err != nil {
+		if fe, ok := err.(*errors2.BadFieldError); ok {
+			errs = append(errs, util.Map{"field": fe.Field, "msg": fe.Msg})
+		} else {
+			errs = append(errs, util.Map{"field": "config", "msg": err.Error()})
+		}
 	}
 
-	if retPayload, _ := finalizeTx(tx, m.logic, nil, options...); retPayload {
-		return tx.ToMap()
+	return util.Map{
+		"valid":  len(errs) == 0,
+		"errors": errs,
 	}
+}
 
-	hash, err := m.logic.GetMempoolReactor().AddTx(tx)
-	if err != nil {
-		panic(se(400, StatusCodeMempoolAddFail, "", err.Error()))
+// GetProposalConfigDiff returns the difference between the current config
+// of a repository and the config an update proposal would apply.
+//  - name: The name of the repository.
+//  - id: The ID of the update proposal.
+//
+// RETURN object <map>: Maps the path of each changed config field to its values.
+//  - old <any>: The current value of the field
+//  - new <any>: The value the proposal would set on the field
+func (m *RepoModule) GetProposalConfigDiff(name, id string) util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
 	}
-
-	return map[string]interface{}{
-		"hash": hash,
+	if id == "" {
+		panic(se(400, StatusCodeInvalidParam, "id", "proposal id is required"))
 	}
-}
+
+	r := m.logic.RepoKeeper().Get(name)
+	if r.IsEmpty() {
+		panic(se(404, StatusCodeRepoNotFound, "name", types.ErrRepoNotFound.Error()))
+	}
+
+	proposal := r.Proposals.Get(id)
+	if proposal == nil {
+		panic(se(404, StatusCodeProposalNotFound, "id", "proposal not found"))
+	}
+	if proposal.Action != txns.TxTypeRepoProposalUpdate {
+		panic(se(400, StatusCodeInvalidParam, "id", "proposal is not an update proposal"))
+	}
+
+	proposed := r.Config.Clone()
+	if cfgData := proposal.ActionData[constants.ActionDataKeyCFG]; len(cfgData) > 0 {
+		var cfgUpd state.RepoConfig
+		if err := util.ToObject(cfgData, &cfgUpd); err != nil {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+		if err := proposed.Merge(cfgUpd.ToMap()); err != nil {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+	}
+
+	var diff = util.Map{}
+	diffConfigMaps("", util.ToJSONMap(r.Config), util.ToJSONMap(proposed), diff)
+	return diff
+}
+
+// GetRepoConfigHistory returns the difference between the config of a
+// repository at two block heights.
+//  - name: The name of the repository.
+//  - fromHeight: The height of the older config.
+//  - toHeight: The height of the newer config.
+//
+// RETURN object <map>: Maps the path of each changed config field to its values.
+//  - old <any>: The value of the field at fromHeight
+//  - new <any>: The value of the field at toHeight
+func (m *RepoModule) GetRepoConfigHistory(name string, fromHeight, toHeight uint64) util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+	if fromHeight == 0 {
+		panic(se(400, StatusCodeInvalidParam, "fromHeight", "from height is required"))
+	}
+	if toHeight == 0 {
+		panic(se(400, StatusCodeInvalidParam, "toHeight", "to height is required"))
+	}
+	if fromHeight > toHeight {
+		panic(se(400, StatusCodeInvalidParam, "fromHeight", "from height cannot be greater than to height"))
+	}
+
+	from := m.logic.RepoKeeper().Get(name, fromHeight)
+	if from.IsEmpty() {
+		panic(se(404, StatusCodeRepoNotFound, "name", types.ErrRepoNotFound.Error()))
+	}
+
+	to := m.logic.RepoKeeper().Get(name, toHeight)
+	if to.IsEmpty() {
+		panic(se(404, StatusCodeRepoNotFound, "name", types.ErrRepoNotFound.Error()))
+	}
+
+	var diff = util.Map{}
+	diffConfigMaps("", util.ToJSONMap(from.Config), util.ToJSONMap(to.Config), diff)
+	return diff
+}
+
+// GetClosedProposals returns the finalized proposals of a repository.
+// A proposal is considered closed if it has an outcome or has been
+// marked as closed. Proposals are sorted by their closing height.
+//  - name: The name of the repository.
+//  - opts <map>: list options
+//  - opts.offset: The number of closed proposals to skip.
+//  - opts.limit: The maximum number of closed proposals to return. 0 means all.
+//
+// RETURN <[]map>
+//  - id <string>: The proposal ID
+//  - action <int>: The proposal action type
+//  - creator <string>: The address of the proposal creator
+//  - height <uint64>: The height of the block the proposal was added
+//  - closedAt <uint64>: The height at which the proposal was closed
+//  - outcome <int>: The outcome of the proposal vote
+//  - yes, no, noWithVeto, noWithVetoByOwners, abstain <float64>: The vote tallies
+func (m *RepoModule) GetClosedProposals(name string, opts ...modtypes.ClosedProposalsOptions) []util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	var opt modtypes.ClosedProposalsOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Offset < 0 {
+		panic(se(400, StatusCodeInvalidParam, "opts.offset", "offset must be a non-negative number"))
+	}
+	if opt.Limit < 0 {
+		panic(se(400, StatusCodeInvalidParam, "opts.limit", "limit must be a non-negative number"))
+	}
+
+	r := m.logic.RepoKeeper().Get(name)
+	if r.IsEmpty() {
+		panic(se(404, StatusCodeRepoNotFound, "name", types.ErrRepoNotFound.Error()))
+	}
+
+	var ids []string
+	for id, prop := range r.Proposals {
+		closed := prop.IsFinalized()
+		if !closed {
+			var err error
+			if closed, err = m.logic.RepoKeeper().IsProposalClosed(name, id); err != nil {
+				panic(se(500, StatusCodeServerErr, "", err.Error()))
+			}
+		}
+		if closed {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := r.Proposals[ids[i]], r.Proposals[ids[j]]
+		if a.EndAt != b.EndAt {
+			return a.EndAt < b.EndAt
+		}
+		return ids[i] < ids[j]
+	})
+
+	if opt.Offset >= len(ids) {
+		return []util.Map{}
+	}
+	ids = ids[opt.Offset:]
+	if opt.Limit > 0 && opt.Limit < len(ids) {
+		ids = ids[:opt.Limit]
+	}
+
+	var res = []util.Map{}
+	for _, id := range ids {
+		prop := r.Proposals[id]
+		res = append(res, util.Map{
+			"id":                 id,
+			"action":             prop.Action,
+			"creator":            prop.Creator,
+			"height":             prop.Height.UInt64(),
+			"closedAt":           prop.EndAt.UInt64(),
+			"outcome":            prop.Outcome,
+			"yes":                prop.Yes,
+			"no":                 prop.No,
+			"noWithVeto":         prop.NoWithVeto,
+			"noWithVetoByOwners": prop.NoWithVetoByOwners,
+			"abstain":            prop.Abstain,
+		})
+	}
+
+	return res
+}
+
+// proposalTypes maps proposal type names to their action
+var proposalTypes = map[string]types.TxCode{
+	"upsert-owner": txns.TxTypeRepoProposalUpsertOwner,
+	"update":       txns.TxTypeRepoProposalUpdate,
+	"register-key": txns.TxTypeRepoProposalRegisterPushKey,
+	"merge":        txns.TxTypeMergeRequestProposalAction,
+}
+
+// ListProposals returns the proposals of a repository.
+// Proposals are sorted by the height at which they were created.
+//  - name: The name of the repository.
+//  - opts <map>: list options
+//  - opts.status: Filter by status (open or closed).
+//  - opts.type: Filter by type (upsert-owner, update, register-key or merge).
+//  - opts.creator: Filter by the address of the proposal creator.
+//  - opts.offset: The number of matching proposals to skip.
+//  - opts.limit: The maximum number of proposals to return. 0 means all.
+//
+// RETURN <[]map>
+//  - id <string>: The proposal ID
+//  - action <int>: The proposal action type
+//  - creator <string>: The address of the proposal creator
+//  - height <uint64>: The height of the block the proposal was added
+//  - endAt <uint64>: The height at which the proposal closes; votes are not accepted after it
+//  - closed <bool>: Whether the proposal is closed
+//  - outcome <int>: The outcome of the proposal vote
+//  - yes, no, noWithVeto, noWithVetoByOwners, abstain <float64>: The vote tallies
+func (m *RepoModule) ListProposals(name string, opts ...modtypes.ListProposalsOptions) []util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	var opt modtypes.ListProposalsOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Status != "" && opt.Status != "open" && opt.Status != "closed" {
+		panic(se(400, StatusCodeInvalidParam, "opts.status", "status must be 'open' or 'closed'"))
+	}
+	action, ok := proposalTypes[opt.Type]
+	if opt.Type != "" && !ok {
+		panic(se(400, StatusCodeInvalidParam, "opts.type", "unknown proposal type"))
+	}
+	if opt.Offset < 0 {
+		panic(se(400, StatusCodeInvalidParam, "opts.offset", "offset must be a non-negative number"))
+	}
+	if opt.Limit < 0 {
+		panic(se(400, StatusCodeInvalidParam, "opts.limit", "limit must be a non-negative number"))
+	}
+
+	r := m.logic.RepoKeeper().Get(name)
+	if r.IsEmpty() {
+		panic(se(404, StatusCodeRepoNotFound, "name", types.ErrRepoNotFound.Error()))
+	}
+
+	var ids []string
+	var closedIdx = map[string]bool{}
+	for id, prop := range r.Proposals {
+		if opt.Type != "" && prop.Action != action {
+			continue
+		}
+		if opt.Creator != "" && prop.Creator != opt.Creator {
+			continue
+		}
+
+		closed := prop.IsFinalized()
+		if !closed {
+			var err error
+			if closed, err = m.logic.RepoKeeper().IsProposalClosed(name, id); err != nil {
+				panic(se(500, StatusCodeServerErr, "", err.Error()))
+			}
+		}
+		if (opt.Status == "open" && closed) || (opt.Status == "closed" && !closed) {
+			continue
+		}
+
+		ids = append(ids, id)
+		closedIdx[id] = closed
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := r.Proposals[ids[i]], r.Proposals[ids[j]]
+		if a.Height != b.Height {
+			return a.Height < b.Height
+		}
+		return ids[i] < ids[j]
+	})
+
+	if opt.Offset >= len(ids) {
+		return []util.Map{}
+	}
+	ids = ids[opt.Offset:]
+	if opt.Limit > 0 && opt.Limit < len(ids) {
+		ids = ids[:opt.Limit]
+	}
+
+	var res = []util.Map{}
+	for _, id := range ids {
+		prop := r.Proposals[id]
+		res = append(res, util.Map{
+			"id":                 id,
+			"action":             prop.Action,
+			"creator":            prop.Creator,
+			"height":             prop.Height.UInt64(),
+			"endAt":              prop.EndAt.UInt64(),
+			"closed":             closedIdx[id],
+			"outcome":            prop.Outcome,
+			"yes":                prop.Yes,
+			"no":                 prop.No,
+			"noWithVeto":         prop.NoWithVeto,
+			"noWithVetoByOwners": prop.NoWithVetoByOwners,
+			"abstain":            prop.Abstain,
+		})
+	}
+
+	return res
+}
+
+// diffConfigMaps compares the fields of two config maps and adds each
+// field whose value differs to diff, keyed by its dot-separated path.
+// Nested maps are compared field by field; other values are compared whole.
+func diffConfigMaps(prefix string, cur, upd map[string]interface{}, diff util.Map) {
+	keys := map[string]struct{}{}
+	for k := range cur {
+		keys[k] = struct{}{}
+	}
+	for k := range upd {
+		keys[k] = struct{}{}
+	}
+
+	for k := range keys {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+
+		curVal, updVal := cur[k], upd[k]
+		curMap, curIsMap := curVal.(map[string]interface{})
+		updMap, updIsMap := updVal.(map[string]interface{})
+		if curIsMap && updIsMap {
+			diffConfigMaps(path, curMap, updMap, diff)
+			continue
+		}
+
+		if !reflect.DeepEqual(curVal, updVal) {
+			diff[path] = util.Map{"old": curVal, "new": updVal}
+		}
+	}
+}
+
+// DepositProposalFee creates a transaction to deposit a fee to a proposal
+//
+// params <map>
+//  - params.name <string>: The name of the repository
+//  - params.id <string>: A unique proposal ID
+//  - params.value <string|number>: The amount to add
+//  - params.nonce <number|string>: The senders next account nonce
+//  - params.fee <number|string>: The transaction fee to pay
+//  - params.timestamp <number>: The unix timestamp
+//
+// options <[]interface{}>
+//  - [0] key <string>: The signer's private key
+//  - [1] payloadOnly <bool>: When true, returns the payload only, without sending the tx.
+//
+// RETURN object <map>
+//  - hash <string>: The transaction hash
+func (m *RepoModule) DepositProposalFee(params map[string]interface{}, options ...interface{}) util.Map {
+	var err error
+
+	var tx = txns.NewBareRepoProposalFeeSend()
+	if err = tx.FromMap(params); err != nil {
+		panic(se(400, StatusCodeInvalidParam, "params", err.Error()))
+	}
+
+	if retPayload, _ := finalizeTx(tx, m.logic, nil, m.nonces, options...); retPayload {
+		return tx.ToMap()
+	}
+
+	hash, err := m.logic.GetMempoolReactor().AddTx(tx)
+	if err != nil {
+		panic(se(400, StatusCodeMempoolAddFail, "", err.Error()))
+	}
+	m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
+
+	return map[string]interface{}{
+		"hash": hash,
+	}
+}
 
 // AddContributor creates a proposal to register one or more push keys
 //
@@ -476,7 +1499,7 @@ func (m *RepoModule) AddContributor(params map[string]interface{}, options ...in
 		panic(se(400, StatusCodeInvalidParam, "params", err.Error()))
 	}
 
-	retPayload, signingKey := finalizeTx(tx, m.logic, m.Client, options...)
+	retPayload, signingKey := finalizeTx(tx, m.logic, m.Client, m.nonces, options...)
 	if retPayload {
 		return tx.ToMap()
 	}
@@ -499,6 +1522,7 @@ func (m *RepoModule) AddContributor(params map[string]interface{}, options ...in
 		if err != nil {
 			panic(err)
 		}
+		m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
 		return util.ToMap(resp)
 	}
 
@@ -506,6 +1530,7 @@ func (m *RepoModule) AddContributor(params map[string]interface{}, options ...in
 	if err != nil {
 		panic(se(400, StatusCodeMempoolAddFail, "", err.Error()))
 	}
+	m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
 
 	return map[string]interface{}{
 		"hash": hash,
@@ -533,6 +1558,48 @@ func (m *RepoModule) GetTracked() util.Map {
 	return util.ToJSONMap(m.logic.RepoSyncInfoKeeper().Tracked())
 }
 
+// GetTrackedStatus returns the sync status of each tracked repository,
+// sorted by repository name.
+//
+// RETURNS:
+//  - name <string>: The name of the repository
+//  - lastSyncHeight <uint64>: The block height the repository was last synced to
+//  - chainHeight <uint64>: The current block height
+//  - blocksBehind <uint64>: The number of blocks the repository is behind the chain
+//  - behind <bool>: Whether the repository is behind the chain
+func (m *RepoModule) GetTrackedStatus() (res []util.Map) {
+	bi, err := m.logic.SysKeeper().GetLastBlockInfo()
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+	chainHeight := uint64(bi.Height)
+
+	tracked := m.logic.RepoSyncInfoKeeper().Tracked()
+	var names []string
+	for name := range tracked {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	res = []util.Map{}
+	for _, name := range names {
+		lastSyncHeight := tracked[name].UpdatedAt.UInt64()
+		var blocksBehind uint64
+		if chainHeight > lastSyncHeight {
+			blocksBehind = chainHeight - lastSyncHeight
+		}
+		res = append(res, util.Map{
+			"name":           name,
+			"lastSyncHeight": lastSyncHeight,
+			"chainHeight":    chainHeight,
+			"blocksBehind":   blocksBehind,
+			"behind":         blocksBehind > 0,
+		})
+	}
+
+	return res
+}
+
 // GetReposCreatedByAddress returns names of repos created by an address
 func (m *RepoModule) GetReposCreatedByAddress(address string) []string {
 	bz, err := ed25519.DecodeAddr(address)
@@ -556,6 +1623,8 @@ func (m *RepoModule) ListPath(name, path string, revision ...string) []util.Map
 		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
 	}
 
+	m.checkReadAccess(name)
+
 	repoPath := m.logic.Config().GetRepoPath(name)
 	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
 	if err != nil {
@@ -588,6 +1657,94 @@ func (m *RepoModule) ListPath(name, path string, revision ...string) []util.Map
 	return util.StructSliceToMap(items)
 }
 
+// GetCodeOwnership returns the fraction of lines each author last modified
+// across the files under a path, sorted by ownership in descending order.
+//  - name: The name of the target repository.
+//  - path: The directory or file path
+//  - revision: The revision that will be queried (default: HEAD).
+func (m *RepoModule) GetCodeOwnership(name, path string, revision ...string) []util.Map {
+
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	m.checkReadAccess(name)
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	var rev = "HEAD"
+	if len(revision) > 0 {
+		rev = revision[0]
+	}
+
+	owners, err := r.GetCodeOwnership(rev, path)
+	if err != nil {
+		if err == plumbing.ErrReferenceNotFound {
+			return []util.Map{}
+		}
+		if err == repo.ErrPathNotFound {
+			panic(se(404, StatusCodePathNotFound, "path", err.Error()))
+		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return util.StructSliceToMap(owners)
+}
+
+// GetFileHistory returns the commits of HEAD that changed a file, newest first.
+//  - name: The name of the target repository.
+//  - filePath: The file path.
+//  - limit: The number of commits to return. 0 means all.
+//
+// RETURN object <[]map>
+//  - commit <map>: The commit that changed the file
+//  - change <string>: The type of change (added, modified or deleted)
+func (m *RepoModule) GetFileHistory(name, filePath string, limit int) []util.Map {
+
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	m.checkReadAccess(name)
+
+	if filePath == "" {
+		panic(se(400, StatusCodeInvalidParam, "file", "file path is required"))
+	}
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	if strings.HasPrefix(filePath, "."+string(os.PathSeparator)) {
+		filePath = filePath[2:]
+	}
+
+	history, err := r.GetFileHistory("HEAD", filePath, limit)
+	if err != nil {
+		if err == plumbing.ErrReferenceNotFound {
+			return []util.Map{}
+		}
+		if err == repo.ErrPathNotFound {
+			panic(se(404, StatusCodePathNotFound, "file", err.Error()))
+		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return util.StructSliceToMap(history)
+}
+
 // ReadFileLines returns the lines of a file in a repository.
 //  - name: The name of the target repository.
 //  - filePath: The file path.
@@ -598,6 +1755,8 @@ func (m *RepoModule) ReadFileLines(name, filePath string, revision ...string) []
 		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
 	}
 
+	m.checkReadAccess(name)
+
 	if filePath == "" {
 		panic(se(400, StatusCodeInvalidParam, "file", "file path is required"))
 	}
@@ -644,6 +1803,8 @@ func (m *RepoModule) ReadFile(name, filePath string, revision ...string) string
 		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
 	}
 
+	m.checkReadAccess(name)
+
 	if filePath == "" {
 		panic(se(400, StatusCodeInvalidParam, "file", "file path is required"))
 	}
@@ -680,13 +1841,34 @@ func (m *RepoModule) ReadFile(name, filePath string, revision ...string) string
 	return str
 }
 
-// GetBranches returns the list of branches
+// defaultReadmeNames are the README file names looked up, in order, when
+// no preferred name is given.
+var defaultReadmeNames = []string{
+	"README.md",
+	"README",
+	"README.markdown",
+	"README.txt",
+	"README.rst",
+	"readme.md",
+	"Readme.md",
+}
+
+// GetReadme returns the README file at the tip of a branch.
 //  - name: The name of the target repository.
-func (m *RepoModule) GetBranches(name string) []string {
+//  - branch: The name of the branch (default: HEAD).
+//  - preferredNames: The file names to look up, in order (default: README.md, README, etc).
+//
+// RETURNS object <map>
+//  - name <string>: The name of the README file
+//  - content <string>: The content of the README file
+func (m *RepoModule) GetReadme(name, branch string, preferredNames ...string) util.Map {
+
 	if name == "" {
 		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
 	}
 
+	m.checkReadAccess(name)
+
 	repoPath := m.logic.Config().GetRepoPath(name)
 	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
 	if err != nil {
@@ -696,28 +1878,62 @@ func (m *RepoModule) GetBranches(name string) []string {
 		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
 	}
 
-	branches, err := r.GetBranches()
-	if err != nil {
-		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	var rev = "HEAD"
+	if branch != "" {
+		rev = plumbing.NewBranchReferenceName(branch).String()
 	}
 
-	for i, branch := range branches {
-		branches[i] = "refs/heads/" + branch
+	if len(preferredNames) == 0 {
+		preferredNames = defaultReadmeNames
 	}
 
-	return branches
+	for _, fileName := range preferredNames {
+		content, err := r.GetFile(rev, fileName)
+		if err != nil {
+			if err == repo.ErrPathNotFound || err == repo.ErrPathNotAFile {
+				continue
+			}
+			if err == plumbing.ErrReferenceNotFound {
+				panic(se(404, StatusCodeBranchNotFound, "branch", "branch does not exist"))
+			}
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+		return util.Map{"name": fileName, "content": content}
+	}
+
+	panic(se(404, StatusCodeReadmeNotFound, "", "readme not found"))
 }
 
-// GetLatestBranchCommit returns the latest commit of a branch in a repository.
+// ReadFileChunk returns a byte range of a file in a repository.
 //  - name: The name of the target repository.
-//  - branch: The name of the branch.
-func (m *RepoModule) GetLatestBranchCommit(name, branch string) util.Map {
+//  - filePath: The file path.
+//  - offset: The position of the first byte to read.
+//  - length: The maximum number of bytes to read.
+//  - revision: The revision that will be queried (default: HEAD).
+//
+// RETURNS object <map>
+//  - content <string>: The bytes read (truncated if range extends beyond the end of the file)
+//  - offset <int>: The position of the first byte read
+//  - length <int>: The number of bytes read
+//  - size <int>: The size of the file
+func (m *RepoModule) ReadFileChunk(name, filePath string, offset, length int64, revision ...string) util.Map {
+
 	if name == "" {
 		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
 	}
 
-	if branch == "" {
-		panic(se(400, StatusCodeInvalidParam, "branch", "branch name is required"))
+	m.checkReadAccess(name)
+
+	if filePath == "" {
+		panic(se(400, StatusCodeInvalidParam, "file", "file path is required"))
+	}
+
+	if offset < 0 {
+		panic(se(400, StatusCodeInvalidParam, "offset", "offset must be a non-negative number"))
+	}
+
+	if length <= 0 {
+		panic(se(400, StatusCodeInvalidParam, "length", "length must be greater than zero"))
 	}
 
 	repoPath := m.logic.Config().GetRepoPath(name)
@@ -729,29 +1945,51 @@ func (m *RepoModule) GetLatestBranchCommit(name, branch string) util.Map {
 		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
 	}
 
-	c, err := r.GetLatestCommit(branch)
+	if strings.HasPrefix(filePath, "."+string(os.PathSeparator)) {
+		filePath = filePath[2:]
+	}
+
+	var rev = "HEAD"
+	if len(revision) > 0 {
+		rev = revision[0]
+	}
+
+	bz, size, err := r.GetFileChunk(rev, filePath, offset, length)
 	if err != nil {
-		if err == plumbing.ErrReferenceNotFound {
-			panic(se(404, StatusCodeBranchNotFound, "branch", "branch does not exist"))
+		if err == repo.ErrPathNotFound {
+			panic(se(404, StatusCodePathNotFound, "file", err.Error()))
 		}
-		panic(se(500, StatusCodeServerErr, "", err.Error()))
+		if err == repo.ErrPathNotAFile {
+			panic(se(400, StatusCodePathNotAFile, "file", err.Error()))
+		}
+		if err == repo.ErrOffsetOutOfRange {
+			panic(se(400, StatusCodeInvalidParam, "offset", err.Error()))
+		}
+		panic(se(500, StatusCodeServerErr, "file", err.Error()))
 	}
 
-	return util.ToMap(c)
+	return util.Map{
+		"content": string(bz),
+		"offset":  offset,
+		"length":  int64(len(bz)),
+		"size":    size,
+	}
 }
 
-// GetCommits returns commits in a branch.
-//  - name: The name of the repository.
-//  - branch: The target branch.
-//  - limit: The number of commit to return. 0 means all.
-func (m *RepoModule) GetCommits(name, branch string, limit ...int) []util.Map {
+// Archive returns a gzip compressed tar archive of the files of a repository at a given revision.
+// The archive must not exceed the max archive size; large archives should be
+// downloaded from the streaming archive endpoint of the remote server instead.
+//  - name: The name of the target repository.
+//  - revision: A commit hash, full reference name or branch name (default: HEAD).
+//
+// RETURNS <base64 string>: The archive
+func (m *RepoModule) Archive(name string, revision ...string) string {
+
 	if name == "" {
 		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
 	}
 
-	if branch == "" {
-		panic(se(400, StatusCodeInvalidParam, "branch", "branch name is required"))
-	}
+	m.checkReadAccess(name)
 
 	repoPath := m.logic.Config().GetRepoPath(name)
 	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
@@ -762,31 +2000,50 @@ func (m *RepoModule) GetCommits(name, branch string, limit ...int) []util.Map {
 		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
 	}
 
-	limit_ := 0
-	if len(limit) > 0 {
-		limit_ = limit[0]
+	var rev = "HEAD"
+	if len(revision) > 0 && revision[0] != "" {
+		rev = revision[0]
 	}
 
-	commits, err := r.GetCommits(branch, limit_)
+	var buf = bytes.NewBuffer(nil)
+	if maxSize := m.logic.Config().Repo.MaxArchiveSize; maxSize > 0 {
+		err = r.WriteArchive(rev, util.NewLimitedWriter(buf, maxSize))
+	} else {
+		err = r.WriteArchive(rev, buf)
+	}
 	if err != nil {
+		if err == util.ErrWriteLimitExceeded {
+			panic(se(413, StatusCodeInvalidParam, "revision", "archive exceeds the max archive size"))
+		}
 		if err == plumbing.ErrReferenceNotFound {
-			panic(se(404, StatusCodeBranchNotFound, "branch", "branch does not exist"))
+			panic(se(404, StatusCodeBranchNotFound, "revision", "branch does not exist"))
+		}
+		if err == plumbing.ErrObjectNotFound {
+			panic(se(404, StatusCodeCommitNotFound, "revision", "commit does not exist"))
 		}
 		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	return util.StructSliceToMap(commits)
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
 }
 
-// GetCommit gets a commit.
-//  - name: The name of the repository
-//  - hash: The commit hash.
-func (m *RepoModule) GetCommit(name, hash string) util.Map {
+// GetRepoContentHash returns a deterministic hash of the files of a repository at a reference.
+// The hash is independent of commit metadata, so repositories with identical
+// file contents share the same hash.
+//  - name: The name of the target repository.
+//  - ref: A commit hash, full reference name or branch name.
+//
+// RETURNS <string>: The hex-encoded content hash
+func (m *RepoModule) GetRepoContentHash(name, ref string) string {
+
 	if name == "" {
 		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
 	}
-	if hash == "" {
-		panic(se(400, StatusCodeInvalidParam, "hash", "commit hash is required"))
+
+	m.checkReadAccess(name)
+
+	if ref == "" {
+		panic(se(400, StatusCodeInvalidParam, "ref", "reference is required"))
 	}
 
 	repoPath := m.logic.Config().GetRepoPath(name)
@@ -798,27 +2055,39 @@ func (m *RepoModule) GetCommit(name, hash string) util.Map {
 		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
 	}
 
-	commit, err := r.GetCommit(hash)
+	hash, err := r.GetContentHash(ref)
 	if err != nil {
+		if err == plumbing.ErrReferenceNotFound {
+			panic(se(404, StatusCodeBranchNotFound, "ref", "branch does not exist"))
+		}
 		if err == plumbing.ErrObjectNotFound {
-			panic(se(404, StatusCodeCommitNotFound, "hash", "commit does not exist"))
+			panic(se(404, StatusCodeCommitNotFound, "ref", "commit does not exist"))
 		}
 		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	return util.ToMap(commit)
+	return hash
 }
 
-// CountCommits returns the number commits in a branch/reference.
+// ListRefs returns the references of a repository with their kind and target hash.
 //  - name: The name of the target repository.
-//  - ref: The target branch or reference.
-func (m *RepoModule) CountCommits(name, ref string) int {
+//  - [pattern]: Glob patterns (e.g refs/tags/*) a reference must match one of to be included.
+//
+// RETURN <[]map>
+//  - name <string>: The full name of the reference
+//  - kind <string>: The kind of reference (branch, tag, note, issue, merge-request, meta, upstream or other)
+//  - hash <string>: The hash of the object the reference points to
+func (m *RepoModule) ListRefs(name string, pattern ...string) []util.Map {
 	if name == "" {
 		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
 	}
 
-	if ref == "" {
-		panic(se(400, StatusCodeInvalidParam, "branch", "branch name is required"))
+	m.checkReadAccess(name)
+
+	for _, p := range pattern {
+		if _, err := path.Match(p, ""); err != nil {
+			panic(se(400, StatusCodeInvalidParam, "pattern", "pattern is not valid"))
+		}
 	}
 
 	repoPath := m.logic.Config().GetRepoPath(name)
@@ -830,28 +2099,108 @@ func (m *RepoModule) CountCommits(name, ref string) int {
 		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
 	}
 
-	count, err := r.NumCommits(ref, false)
+	itr, err := r.References()
 	if err != nil {
-		if err == plumbing.ErrReferenceNotFound {
-			panic(se(404, StatusCodeBranchNotFound, "branch", "branch does not exist"))
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	var res = []util.Map{}
+	err = itr.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference || !strings.HasPrefix(ref.Name().String(), "refs/") {
+			return nil
+		}
+
+		refName := ref.Name().String()
+		if len(pattern) > 0 {
+			matched := false
+			for _, p := range pattern {
+				if matched, _ = path.Match(p, refName); matched {
+					break
+				}
+			}
+			if !matched {
+				return nil
+			}
 		}
+
+		res = append(res, util.Map{
+			"name": refName,
+			"kind": getReferenceKind(refName),
+			"hash": ref.Hash().String(),
+		})
+		return nil
+	})
+	if err != nil {
 		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	return count
+	sort.Slice(res, func(i, j int) bool {
+		return res[i]["name"].(string) < res[j]["name"].(string)
+	})
+
+	return res
 }
 
-// GetCommitAncestors returns ancestors of a commit with the given hash.
-//  - commitHash: The hash of the commit.
-//  - limit: The number of commit to return. 0 means all.
-func (m *RepoModule) GetCommitAncestors(name, commitHash string, limit ...int) []util.Map {
+// getReferenceKind classifies a reference by its name
+func getReferenceKind(name string) string {
+	switch {
+	case pl.IsIssueReference(name):
+		return "issue"
+	case pl.IsMergeRequestReference(name):
+		return "merge-request"
+	case pl.IsBranch(name):
+		return "branch"
+	case pl.IsTag(name):
+		return "tag"
+	case pl.IsNote(name):
+		return "note"
+	case pl.IsMetaReference(name):
+		return "meta"
+	case strings.HasPrefix(name, pl.UpstreamReferencePrefix):
+		return "upstream"
+	default:
+		return "other"
+	}
+}
+
+// GetBranches returns the list of branches
+//  - name: The name of the target repository.
+func (m *RepoModule) GetBranches(name string) []string {
+	_, branches := m.getBranches(name)
+	return branches
+}
+
+// GetBranchesWithMeta returns the list of branches along with the tip hash
+// and last commit time of each branch.
+//  - name: The name of the target repository.
+//
+// RETURN <[]map>
+//  - name <string>: The full name of the branch
+//  - hash <string>: The hash of the branch tip
+//  - lastCommitTime <number>: The unix time of the branch tip commit
+func (m *RepoModule) GetBranchesWithMeta(name string) []util.Map {
+	r, branches := m.getBranches(name)
+	activities := m.getBranchActivities(name, r, branches)
+	var res = []util.Map{}
+	for _, branch := range branches {
+		res = append(res, util.Map{
+			"name":           branch,
+			"hash":           activities[branch].Hash,
+			"lastCommitTime": activities[branch].LastCommitTime,
+		})
+	}
+
+	return res
+}
+
+// getBranches opens the local repository and returns it along with the full
+// names of its branches.
+func (m *RepoModule) getBranches(name string) (pl.LocalRepo, []string) {
 	if name == "" {
 		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
 	}
 
-	if commitHash == "" {
-		panic(se(400, StatusCodeInvalidParam, "commitHash", "commit hash is required"))
-	}
+	m.checkReadAccess(name)
 
 	repoPath := m.logic.Config().GetRepoPath(name)
 	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
@@ -862,32 +2211,28 @@ func (m *RepoModule) GetCommitAncestors(name, commitHash string, limit ...int) [
 		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
 	}
 
-	limit_ := 0
-	if len(limit) > 0 {
-		limit_ = limit[0]
-	}
-
-	commits, err := r.GetCommitAncestors(commitHash, limit_)
+	branches, err := r.GetBranches()
 	if err != nil {
-		if err == plumbing.ErrObjectNotFound {
-			panic(se(404, StatusCodeCommitNotFound, "commitHash", "commit does not exist"))
-		}
 		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	return util.StructSliceToMap(commits)
+	for i, branch := range branches {
+		branches[i] = "refs/heads/" + branch
+	}
+
+	return r, branches
 }
 
-// GetParentsAndCommitDiff gets the diff output between a commit and its parent(s).
-//  - name: The name of the target repository.
-//  - commitHash: The hash of the commit.
-func (m *RepoModule) GetParentsAndCommitDiff(name string, commitHash string) util.Map {
+// GetDefaultBranch returns the full name of the default branch of a repository.
+// The default branch is the branch HEAD points to. If HEAD is detached, the
+// sole branch of the repository is considered the default branch.
+//  - name: The name of the repository
+func (m *RepoModule) GetDefaultBranch(name string) string {
 	if name == "" {
 		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
 	}
-	if commitHash == "" {
-		panic(se(400, StatusCodeInvalidParam, "commitHash", "commit hash is required"))
-	}
+
+	m.checkReadAccess(name)
 
 	repoPath := m.logic.Config().GetRepoPath(name)
 	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
@@ -898,112 +2243,123 @@ func (m *RepoModule) GetParentsAndCommitDiff(name string, commitHash string) uti
 		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
 	}
 
-	res, err := r.GetParentAndChildCommitDiff(commitHash)
+	branch, err := getDefaultBranch(r)
 	if err != nil {
-		if err == plumbing.ErrObjectNotFound {
-			panic(se(404, StatusCodeCommitNotFound, "commitHash", "commit not found"))
-		}
 		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	return util.ToMap(res)
+	if branch == "" {
+		panic(se(404, StatusCodeBranchNotFound, "name", "default branch could not be determined"))
+	}
+
+	return branch
 }
 
-// CreateIssue creates an issue or adds a comment to an issue.
-//  - name: The name of the repository.
-//  - params: Issue parameters.
-//    - id: The new issue ID or ID of an existing issue to reply to.
-//    - title: The title of the issue.
-//    - body: The body of the issue.
-//    - replyHash: The commit hash of a comment being replied to.
-//    - reactions: An array of unicode emojis.
-//    - labels: A list of labels.
-//    - assignees: A list of assignees.
-//    - close: Closes the issue status.
-func (m *RepoModule) CreateIssue(name string, params map[string]interface{}) util.Map {
-	if name == "" {
-		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+// getDefaultBranch returns the full name of the default branch of a repository.
+// It returns an empty string if the default branch could not be determined.
+func getDefaultBranch(r pl.LocalRepo) (string, error) {
+	head, err := r.Reference(plumbing.HEAD, false)
+	if err != nil && err != plumbing.ErrReferenceNotFound {
+		return "", err
+	} else if head != nil && head.Type() == plumbing.SymbolicReference && head.Target().IsBranch() {
+		return head.Target().String(), nil
 	}
 
-	repoPath := m.logic.Config().GetRepoPath(name)
-	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	branches, err := r.GetBranches()
 	if err != nil {
-		if err == git.ErrRepositoryNotExists {
-			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
-		}
-		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+		return "", err
 	}
 
-	// Generate a new issue ID
-	o := objx.New(params)
-	id := cast.ToInt(o.Get("id").Inter())
-	if id == 0 {
-		id, err = m.PostIDFinder(r, 1, pl.IssueBranchPrefix)
-		if err != nil {
-			panic(se(500, StatusCodeServerErr, "", err.Error()))
-		}
+	if len(branches) != 1 {
+		return "", nil
 	}
 
-	// Clone the repository and the issue reference.
-	// Determine the full issue reference name and check
-	// if it exists. If it does not reset to empty string.
-	cloneOpts := pl.CloneOptions{Depth: 1, ReferenceName: pl.MakeIssueReference(id)}
-	_, err = r.RefGet(cloneOpts.ReferenceName)
-	if err != nil {
-		cloneOpts.ReferenceName = ""
-	}
-	cloned, _, err := r.Clone(cloneOpts)
+	return plumbing.NewBranchReferenceName(branches[0]).String(), nil
+}
+
+// getBranchActivities returns the activity info of the given branches.
+// Index entries whose hash matches the current branch tip are used as is;
+// missing or stale entries are computed from the tip commit. The index is
+// not modified; it is maintained by the ref syncer and the reindexer.
+func (m *RepoModule) getBranchActivities(name string, r pl.LocalRepo, branches []string) map[string]*core.BranchActivity {
+	indexed, err := m.logic.RepoSyncInfoKeeper().GetBranchActivities(name)
 	if err != nil {
-		panic(se(500, StatusCodeServerErr, "", errors.Wrap(err, "failed to clone repo").Error()))
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	// Create the issue
-	args := &issuecmd.IssueCreateArgs{
-		ID:                 id,
-		Title:              o.Get("title").Str(),
-		Body:               o.Get("body").Str(),
-		ReplyHash:          o.Get("replyHash").Str(),
-		Reactions:          cast.ToStringSlice(o.Get("reactions").Inter()),
-		Labels:             cast.ToStringSlice(o.Get("labels").Inter()),
-		Assignees:          cast.ToStringSlice(o.Get("assignees").Inter()),
-		PostCommentCreator: pl.CreatePostCommit,
+	var activities = make(map[string]*core.BranchActivity, len(branches))
+	for _, branch := range branches {
+		hash, err := r.RefGet(branch)
+		if err != nil {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+
+		if activity, ok := indexed[branch]; ok && activity.Hash == hash {
+			activities[branch] = activity
+			continue
+		}
+
+		commit, err := r.CommitObject(plumbing.NewHash(hash))
+		if err != nil {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+
+		activities[branch] = &core.BranchActivity{Hash: hash, LastCommitTime: commit.Committer.When.Unix()}
 	}
-	closeIssue := o.Get("close")
-	if !closeIssue.IsNil() {
-		args.Close = pointer.ToBool(closeIssue.Bool())
+
+	return activities
+}
+
+// GetStaleBranches returns the branches whose last commit is older than a given age.
+//  - name: The name of the target repository.
+//  - age: The minimum age of the last commit of a stale branch (e.g 720h).
+//
+// RETURN <[]map>: The stale branches, sorted from least recently active.
+//  - name <string>: The full name of the branch
+//  - hash <string>: The hash of the branch tip
+//  - lastCommitTime <number>: The unix time of the branch tip commit
+func (m *RepoModule) GetStaleBranches(name, age string) []util.Map {
+	if age == "" {
+		panic(se(400, StatusCodeInvalidParam, "age", "age is required"))
 	}
 
-	res, err := m.IssueCreate(cloned, args)
-	if err != nil {
-		_ = cloned.Delete()
-		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	dur, err := time.ParseDuration(age)
+	if err != nil || dur < 0 {
+		panic(se(400, StatusCodeInvalidParam, "age", "age is not a valid duration"))
 	}
 
-	refHash, err := cloned.RefGet(res.Reference)
-	if err != nil {
-		_ = cloned.Delete()
-		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	cutoff := time.Now().Add(-dur).Unix()
+	var res = []util.Map{}
+	for _, branch := range m.GetBranchesWithMeta(name) {
+		if branch["lastCommitTime"].(int64) < cutoff {
+			res = append(res, branch)
+		}
 	}
 
-	// Add cloned repo path to temp repo manager.
-	tempRepoID := m.repoSrv.GetTempRepoManager().Add(cloned.GetPath())
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i]["lastCommitTime"].(int64) < res[j]["lastCommitTime"].(int64)
+	})
 
-	return map[string]interface{}{
-		"hash":      refHash,
-		"reference": res.Reference,
-		"repoID":    tempRepoID,
-	}
+	return res
 }
 
-// ReadIssue gets an issue.
-//  - name: The name of the repository.
-//  - reference: The full issue reference name.
-func (m *RepoModule) ReadIssue(name, reference string) []util.Map {
+// GetRepoMeta returns the files stored in the meta references (refs/meta/*)
+// of a repository. Meta references hold version-controlled repo-level
+// configuration such as CI and policy files.
+//  - name: The name of the target repository.
+//
+// RETURN object <map>: Maps each meta reference short name (e.g "ci" for refs/meta/ci) to:
+//  - hash <string>: The hash of the commit the meta reference points to
+//  - files <map>: Maps the path of each file in the commit tree to its content
+func (m *RepoModule) GetRepoMeta(name string) util.Map {
 	if name == "" {
 		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
 	}
 
-	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
+	m.checkReadAccess(name)
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
 	if err != nil {
 		if err == git.ErrRepositoryNotExists {
 			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
@@ -1011,85 +2367,104 @@ func (m *RepoModule) ReadIssue(name, reference string) []util.Map {
 		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
 	}
 
-	comments, err := m.IssueRead(r, &issuecmd.IssueReadArgs{
-		Reference:  reference,
-		PostGetter: pl.GetPosts,
-	})
+	refs, err := r.GetReferences()
 	if err != nil {
 		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	return util.StructSliceToMap(comments)
-}
+	var res = util.Map{}
+	for _, ref := range refs {
+		if !pl.IsMetaReference(ref.String()) {
+			continue
+		}
 
-// CloseIssue closes an issue.
-//  - name: The name of the repository.
-//  - reference: The full issue reference name.
-func (m *RepoModule) CloseIssue(name, reference string) util.Map {
+		hash, err := r.RefGet(ref.String())
+		if err != nil {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
 
-	if name == "" {
-		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
-	}
+		commit, err := r.CommitObject(plumbing.NewHash(hash))
+		if err != nil {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
 
-	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
-	if err != nil {
-		if err == git.ErrRepositoryNotExists {
-			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		fileIter, err := commit.Files()
+		if err != nil {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
 		}
-		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
-	}
 
-	curRefHash, err := r.RefGet(reference)
-	if err != nil && err == pl.ErrRefNotFound {
-		panic(se(404, StatusCodeIssueNotFound, "reference", "issue not found"))
+		var files = util.Map{}
+		if err = fileIter.ForEach(func(f *object.File) error {
+			content, err := f.Contents()
+			if err != nil {
+				return err
+			}
+			files[f.Name] = content
+			return nil
+		}); err != nil {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+
+		res[strings.TrimPrefix(ref.String(), pl.MetaReferencePrefix)] = util.Map{"hash": hash, "files": files}
 	}
 
-	// Clone the repository and the issue reference.
-	cloneOpts := pl.CloneOptions{Depth: 1, ReferenceName: reference}
-	if curRefHash == "" {
-		cloneOpts.ReferenceName = ""
+	return res
+}
+
+// GetLatestBranchCommit returns the latest commit of a branch in a repository.
+//  - name: The name of the target repository.
+//  - branch: The name of the branch.
+func (m *RepoModule) GetLatestBranchCommit(name, branch string) util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
 	}
-	cloned, _, err := r.Clone(cloneOpts)
-	if err != nil {
-		panic(se(500, StatusCodeServerErr, "", errors.Wrap(err, "failed to clone repo").Error()))
+
+	m.checkReadAccess(name)
+
+	if branch == "" {
+		panic(se(400, StatusCodeInvalidParam, "branch", "branch name is required"))
 	}
 
-	res, err := m.IssueClose(cloned, &issuecmd.IssueCloseArgs{
-		Reference:          reference,
-		PostCommentCreator: pl.CreatePostCommit,
-		ReadPostBody:       pl.ReadPostBody,
-	})
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
 	if err != nil {
-		_ = cloned.Delete()
-		panic(se(500, StatusCodeServerErr, "", err.Error()))
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
 	}
 
-	refHash, err := cloned.RefGet(res.Reference)
+	c, err := r.GetLatestCommit(branch)
 	if err != nil {
-		_ = cloned.Delete()
+		if err == plumbing.ErrReferenceNotFound {
+			panic(se(404, StatusCodeBranchNotFound, "branch", "branch does not exist"))
+		}
 		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	// Add cloned repo path to temp repo manager.
-	tempRepoID := m.repoSrv.GetTempRepoManager().Add(cloned.GetPath())
-
-	return map[string]interface{}{
-		"hash":      refHash,
-		"reference": res.Reference,
-		"repoID":    tempRepoID,
-	}
+	return util.ToMap(c)
 }
 
-// ReopenIssue reopens a closed issue.
+// GetCommits returns commits in a branch.
 //  - name: The name of the repository.
-//  - reference: The full issue reference name.
-func (m *RepoModule) ReopenIssue(name, reference string) util.Map {
-
+//  - branch: The target branch.
+//  - opts <map>: options
+//  - opts.limit: The number of commit to return. 0 means all.
+//  - opts.order: The order of the commits (topological, author-date,
+//    committer-date or reverse). Default: committer-date.
+func (m *RepoModule) GetCommits(name, branch string, opts ...modtypes.GetCommitsOptions) []util.Map {
 	if name == "" {
 		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
 	}
 
-	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
+	m.checkReadAccess(name)
+
+	if branch == "" {
+		panic(se(400, StatusCodeInvalidParam, "branch", "branch name is required"))
+	}
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
 	if err != nil {
 		if err == git.ErrRepositoryNotExists {
 			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
@@ -1097,55 +2472,48 @@ func (m *RepoModule) ReopenIssue(name, reference string) util.Map {
 		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
 	}
 
-	curRefHash, err := r.RefGet(reference)
-	if err != nil && err == pl.ErrRefNotFound {
-		panic(se(404, StatusCodeIssueNotFound, "reference", "issue not found"))
-	}
-
-	// Clone the repository and the issue reference.
-	cloneOpts := pl.CloneOptions{Depth: 1, ReferenceName: reference}
-	if curRefHash == "" {
-		cloneOpts.ReferenceName = ""
-	}
-	cloned, _, err := r.Clone(cloneOpts)
-	if err != nil {
-		panic(se(500, StatusCodeServerErr, "", errors.Wrap(err, "failed to clone repo").Error()))
+	var opt modtypes.GetCommitsOptions
+	if len(opts) > 0 {
+		opt = opts[0]
 	}
-
-	res, err := m.IssueReopen(cloned, &issuecmd.IssueReopenArgs{
-		Reference:          reference,
-		PostCommentCreator: pl.CreatePostCommit,
-		ReadPostBody:       pl.ReadPostBody,
-	})
-	if err != nil {
-		_ = cloned.Delete()
-		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	order := pl.CommitOrder(opt.Order)
+	if !funk.Contains(pl.CommitOrders, order) {
+		panic(se(400, StatusCodeInvalidParam, "opts.order", "unknown commit order"))
 	}
 
-	refHash, err := cloned.RefGet(res.Reference)
+	commits, err := r.GetCommits(branch, opt.Limit, order)
 	if err != nil {
-		_ = cloned.Delete()
+		if err == plumbing.ErrReferenceNotFound {
+			panic(se(404, StatusCodeBranchNotFound, "branch", "branch does not exist"))
+		}
 		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	// Add cloned repo path to temp repo manager.
-	tempRepoID := m.repoSrv.GetTempRepoManager().Add(cloned.GetPath())
-
-	return map[string]interface{}{
-		"hash":      refHash,
-		"reference": res.Reference,
-		"repoID":    tempRepoID,
-	}
+	return util.StructSliceToMap(commits)
 }
 
-// ListIssues returns a list of issues.
+// GetCommitGraphDOT returns the commit graph of a branch in Graphviz DOT format.
+// Each commit is a node labelled with its short hash and subject, and each
+// parent link is an edge from the commit to its parent. Parent links to
+// commits outside the limit are not included.
 //  - name: The name of the repository.
-func (m *RepoModule) ListIssues(name string) []util.Map {
-
+//  - branch: The target branch.
+//  - limit: The number of commits to include. 0 means all.
+func (m *RepoModule) GetCommitGraphDOT(name, branch string, limit int) string {
 	if name == "" {
 		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
 	}
 
+	m.checkReadAccess(name)
+
+	if branch == "" {
+		panic(se(400, StatusCodeInvalidParam, "branch", "branch name is required"))
+	}
+
+	if limit < 0 {
+		panic(se(400, StatusCodeInvalidParam, "limit", "limit must be a non-negative number"))
+	}
+
 	repoPath := m.logic.Config().GetRepoPath(name)
 	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
 	if err != nil {
@@ -1155,34 +2523,59 @@ func (m *RepoModule) ListIssues(name string) []util.Map {
 		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
 	}
 
-	issues, err := m.IssueList(r, &issuecmd.IssueListArgs{
-		PostGetter: pl.GetPosts,
-	})
+	commits, err := r.GetCommits(branch, limit, pl.CommitOrderTopological)
 	if err != nil {
+		if err == plumbing.ErrReferenceNotFound {
+			panic(se(404, StatusCodeBranchNotFound, "branch", "branch does not exist"))
+		}
 		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	return util.StructSliceToMap(issues)
+	var included = make(map[string]struct{}, len(commits))
+	for _, c := range commits {
+		included[c.Hash] = struct{}{}
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "digraph %q {\n", name+"/"+strings.TrimPrefix(branch, "refs/heads/"))
+	for _, c := range commits {
+		subject := strings.SplitN(strings.TrimSpace(c.Message), "\n", 2)[0]
+		fmt.Fprintf(&buf, "  %q [label=%q];\n", c.Hash, c.Hash[:7]+" "+subject)
+	}
+	for _, c := range commits {
+		for _, parent := range c.ParentHashes {
+			if _, ok := included[parent]; ok {
+				fmt.Fprintf(&buf, "  %q -> %q;\n", c.Hash, parent)
+			}
+		}
+	}
+	buf.WriteString("}\n")
+
+	return buf.String()
 }
 
-// CreateMergeRequest creates a merge request or adds a comment to an existing merge request.
+// VerifyBranchSignatures verifies the signature of the commits of a branch,
+// starting from its tip. Each commit must be signed by the push key it
+// names in its signature. Verification stops at the first commit that is
+// unsigned or has an invalid signature.
 //  - name: The name of the repository.
-//  - params: Issue parameters.
-//    - id: The new issue ID or ID of an existing issue to reply to.
-//    - base: The base branch name.
-//    - baseHash: The base branch hash.
-//    - target: The target branch name.
-//    - targetHash: The target branch hash.
-//    - title: The title of the issue.
-//    - body: The body of the issue.
-//    - replyHash: The commit hash of a comment being replied to.
-//    - reactions: An array of unicode emojis.
-//    - close: Closes the issue status.
-func (m *RepoModule) CreateMergeRequest(name string, params map[string]interface{}) util.Map {
+//  - branch: The target branch.
+//  - limit: The number of commits to verify. 0 means all.
+//
+// RETURN object <map>
+//  - valid <bool>: Whether all verified commits are correctly signed
+//  - checked <int>: The number of commits verified
+//  - hash <string>: The hash of the first unsigned or invalid commit
+//  - reason <string>: The reason the commit failed verification
+func (m *RepoModule) VerifyBranchSignatures(name, branch string, limit ...int) util.Map {
 	if name == "" {
 		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
 	}
 
+	if branch == "" {
+		panic(se(400, StatusCodeInvalidParam, "branch", "branch name is required"))
+	}
+
 	repoPath := m.logic.Config().GetRepoPath(name)
 	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
 	if err != nil {
@@ -1192,78 +2585,130 @@ func (m *RepoModule) CreateMergeRequest(name string, params map[string]interface
 		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
 	}
 
-	// Generate a new issue ID
-	o := objx.New(params)
-	id := cast.ToInt(o.Get("id").Inter())
-	if id == 0 {
-		id, err = m.PostIDFinder(r, 1, pl.MergeRequestBranchPrefix)
-		if err != nil {
-			panic(se(500, StatusCodeServerErr, "", err.Error()))
+	limit_ := 0
+	if len(limit) > 0 {
+		limit_ = limit[0]
+	}
+
+	refname := plumbing.NewBranchReferenceName(branch)
+	if strings.HasPrefix(branch, "refs/heads/") {
+		refname = plumbing.ReferenceName(branch)
+	}
+	ref, err := r.Reference(refname, true)
+	if err != nil {
+		if err == plumbing.ErrReferenceNotFound {
+			panic(se(404, StatusCodeBranchNotFound, "branch", "branch does not exist"))
 		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	// Clone the repository and the merge request reference.
-	// Determine the full issue reference name and check
-	// if it exists. If it does not reset to empty string.
-	cloneOpts := pl.CloneOptions{Depth: 1, ReferenceName: pl.MakeMergeRequestReference(id)}
-	_, err = r.RefGet(cloneOpts.ReferenceName)
+	tip, err := r.CommitObject(ref.Hash())
 	if err != nil {
-		cloneOpts.ReferenceName = ""
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
-	cloned, _, err := r.Clone(cloneOpts)
+
+	var res = util.Map{"valid": true, "checked": 0}
+	getPushKey := m.repoSrv.GetPushKeyGetter()
+	err = object.NewCommitPreorderIter(tip, nil, nil).ForEach(func(commit *object.Commit) error {
+		if limit_ > 0 && res["checked"].(int) >= limit_ {
+			return storer.ErrStop
+		}
+		res["checked"] = res["checked"].(int) + 1
+		if err := validation.CheckCommitSignature(commit, getPushKey); err != nil {
+			res["valid"] = false
+			res["hash"] = commit.Hash.String()
+			res["reason"] = err.Error()
+			return storer.ErrStop
+		}
+		return nil
+	})
 	if err != nil {
-		panic(se(500, StatusCodeServerErr, "", errors.Wrap(err, "failed to clone repo").Error()))
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	args := &mergecmd.MergeRequestCreateArgs{
-		ID:                 id,
-		Title:              o.Get("title").Str(),
-		Body:               o.Get("body").Str(),
-		ReplyHash:          o.Get("replyHash").Str(),
-		Reactions:          cast.ToStringSlice(o.Get("reactions").Inter()),
-		Base:               o.Get("base").Str(),
-		BaseHash:           o.Get("baseHash").Str(),
-		Target:             o.Get("target").Str(),
-		TargetHash:         o.Get("targetHash").Str(),
-		PostCommentCreator: pl.CreatePostCommit,
+	return res
+}
+
+// GetTagSignatureInfo gets the signature information of an annotated tag.
+//  - name: The name of the repository.
+//  - tag: The name of the tag.
+//
+// RETURN object <map>
+//  - signed <bool>: Whether the tag is signed
+//  - verified <bool>: Whether the signature is valid for the push key that signed it
+//  - txDetail <map>: The transaction detail decoded from the signature header
+//  - reason <string>: The reason the signature failed verification
+func (m *RepoModule) GetTagSignatureInfo(name, tag string) util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
 	}
 
-	closeIssue := o.Get("close")
-	if !closeIssue.IsNil() {
-		args.Close = pointer.ToBool(closeIssue.Bool())
+	if tag == "" {
+		panic(se(400, StatusCodeInvalidParam, "tag", "tag name is required"))
 	}
 
-	res, err := m.MergeRequestCreate(cloned, args)
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
 	if err != nil {
-		_ = cloned.Delete()
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	tagRef, err := r.Tag(strings.TrimPrefix(tag, "refs/tags/"))
+	if err != nil {
+		if err == git.ErrTagNotFound {
+			panic(se(404, StatusCodeTagNotFound, "tag", "tag does not exist"))
+		}
 		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	refHash, err := cloned.RefGet(res.Reference)
+	tagObj, err := r.TagObject(tagRef.Hash())
 	if err != nil {
-		_ = cloned.Delete()
+		if err == plumbing.ErrObjectNotFound {
+			panic(se(400, StatusCodeInvalidParam, "tag", "tag is not an annotated tag"))
+		}
 		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	// Add cloned repo path to temp repo manager.
-	tempRepoID := m.repoSrv.GetTempRepoManager().Add(cloned.GetPath())
+	var res = util.Map{"signed": false, "verified": false}
+	txDetail, err := validation.CheckTagSignature(tagObj, m.repoSrv.GetPushKeyGetter())
+	if err == validation.ErrTagNotSigned {
+		return res
+	}
 
-	return map[string]interface{}{
-		"hash":      refHash,
-		"reference": res.Reference,
-		"repoID":    tempRepoID,
+	res["signed"] = true
+	res["verified"] = err == nil
+	if txDetail != nil {
+		res["txDetail"] = txDetail.ToMap()
+	}
+	if err != nil {
+		res["reason"] = err.Error()
 	}
+
+	return res
 }
 
-// ReadMergeRequest gets an issue.
+// AuditRepoSignatures verifies the signature of the commit or annotated tag
+// at the tip of every reference of a repository and reports the objects
+// whose signature is invalid or was created by a push key that is not a
+// contributor of the repository. Unsigned objects are not reported.
 //  - name: The name of the repository.
-//  - reference: The full merge request reference name.
-func (m *RepoModule) ReadMergeRequest(name, reference string) []util.Map {
+//
+// RETURN <[]map>
+//  - ref <string>: The name of the reference
+//  - hash <string>: The hash of the object at the tip of the reference
+//  - type <string>: The type of the object (commit or tag)
+//  - pushKeyID <string>: The ID of the push key that signed the object
+//  - reason <string>: The reason the object was reported
+func (m *RepoModule) AuditRepoSignatures(name string) []util.Map {
 	if name == "" {
 		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
 	}
 
-	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
 	if err != nil {
 		if err == git.ErrRepositoryNotExists {
 			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
@@ -1271,27 +2716,90 @@ func (m *RepoModule) ReadMergeRequest(name, reference string) []util.Map {
 		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
 	}
 
-	comments, err := m.MergeRequestRead(r, &mergecmd.MergeRequestReadArgs{
-		Reference:  reference,
-		PostGetter: pl.GetPosts,
-	})
+	repoState := m.logic.RepoKeeper().Get(name)
+
+	refs, err := r.GetReferences()
 	if err != nil {
 		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
+	sort.Slice(refs, func(i, j int) bool { return refs[i] < refs[j] })
 
-	return util.StructSliceToMap(comments)
+	var res = []util.Map{}
+	for _, ref := range refs {
+		if !strings.HasPrefix(ref.String(), "refs/") {
+			continue
+		}
+
+		tip, err := r.Reference(ref, true)
+		if err != nil {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+
+		// Capture the push key ID the signature was created with, so that a
+		// valid signature can be checked against the repo contributors.
+		var pushKeyID string
+		getPushKey := func(pkID string) (ed25519.PublicKey, error) {
+			pushKeyID = pkID
+			return m.repoSrv.GetPushKeyGetter()(pkID)
+		}
+
+		var objType string
+		var sigErr error
+		if tag, err := r.TagObject(tip.Hash()); err == nil {
+			objType = "tag"
+			if _, sigErr = validation.CheckTagSignature(tag, getPushKey); sigErr == validation.ErrTagNotSigned {
+				continue
+			}
+		} else if err != plumbing.ErrObjectNotFound {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		} else if commit, err := r.CommitObject(tip.Hash()); err == nil {
+			objType = "commit"
+			if sigErr = validation.CheckCommitSignature(commit, getPushKey); sigErr == validation.ErrCommitNotSigned {
+				continue
+			}
+		} else {
+			continue
+		}
+
+		entry := util.Map{"ref": ref.String(), "hash": tip.Hash().String(), "type": objType, "pushKeyID": pushKeyID}
+		if sigErr != nil {
+			entry["reason"] = sigErr.Error()
+		} else if !repoState.Contributors.Has(pushKeyID) {
+			entry["reason"] = "push key is not a repo contributor"
+		} else {
+			continue
+		}
+		res = append(res, entry)
+	}
+
+	return res
 }
 
-// CloseMergeRequest closes a merge request.
+// GetProvenanceChain walks from a commit to its ancestors and returns,
+// for each commit, the push key that signed it and whether the signature
+// is valid. Unlike VerifyBranchSignatures, the walk does not stop at the
+// first unsigned or invalid commit.
 //  - name: The name of the repository.
-//  - reference: The full merge request reference name.
-func (m *RepoModule) CloseMergeRequest(name, reference string) util.Map {
-
+//  - commitHash: The hash of the commit to start from.
+//  - limit: The number of commits to return. 0 means all.
+//
+// RETURN <[]map>
+//  - hash <string>: The hash of the commit
+//  - pushKeyID <string>: The ID of the push key that signed the commit
+//  - signed <bool>: Whether the commit is signed
+//  - verified <bool>: Whether the signature is valid for the push key that signed it
+//  - reason <string>: The reason the signature failed verification
+func (m *RepoModule) GetProvenanceChain(name, commitHash string, limit int) []util.Map {
 	if name == "" {
 		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
 	}
 
-	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
+	if commitHash == "" {
+		panic(se(400, StatusCodeInvalidParam, "commitHash", "commit hash is required"))
+	}
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
 	if err != nil {
 		if err == git.ErrRepositoryNotExists {
 			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
@@ -1299,57 +2807,106 @@ func (m *RepoModule) CloseMergeRequest(name, reference string) util.Map {
 		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
 	}
 
-	curRefHash, err := r.RefGet(reference)
-	if err != nil && err == pl.ErrRefNotFound {
-		panic(se(404, StatusCodeMergeRequestNotFound, "reference", "merge request not found"))
-	}
-
-	// Clone the repository and the issue reference.
-	cloneOpts := pl.CloneOptions{Depth: 1, ReferenceName: reference}
-	if curRefHash == "" {
-		cloneOpts.ReferenceName = ""
-	}
-	cloned, _, err := r.Clone(cloneOpts)
+	start, err := r.CommitObject(plumbing.NewHash(commitHash))
 	if err != nil {
-		panic(se(500, StatusCodeServerErr, "", errors.Wrap(err, "failed to clone repo").Error()))
+		if err == plumbing.ErrObjectNotFound {
+			panic(se(404, StatusCodeCommitNotFound, "commitHash", "commit does not exist"))
+		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	res, err := m.MergeRequestClose(cloned, &mergecmd.MergeReqCloseArgs{
-		Reference:          reference,
-		PostCommentCreator: pl.CreatePostCommit,
-		ReadPostBody:       pl.ReadPostBody,
+	var res = []util.Map{}
+	err = object.NewCommitPreorderIter(start, nil, nil).ForEach(func(commit *object.Commit) error {
+		if limit > 0 && len(res) >= limit {
+			return storer.ErrStop
+		}
+
+		// Capture the push key ID the signature was created with
+		var pushKeyID string
+		getPushKey := func(pkID string) (ed25519.PublicKey, error) {
+			pushKeyID = pkID
+			return m.repoSrv.GetPushKeyGetter()(pkID)
+		}
+
+		entry := util.Map{"hash": commit.Hash.String(), "signed": true, "verified": false}
+		sigErr := validation.CheckCommitSignature(commit, getPushKey)
+		if sigErr == validation.ErrCommitNotSigned {
+			entry["signed"] = false
+		} else if sigErr != nil {
+			entry["reason"] = sigErr.Error()
+		} else {
+			entry["verified"] = true
+		}
+		entry["pushKeyID"] = pushKeyID
+		res = append(res, entry)
+		return nil
 	})
 	if err != nil {
-		_ = cloned.Delete()
 		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	refHash, err := cloned.RefGet(res.Reference)
-	if err != nil {
-		_ = cloned.Delete()
-		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	return res
+}
+
+// GetCommit gets a commit.
+//  - name: The name of the repository
+//  - hash: The commit hash.
+func (m *RepoModule) GetCommit(name, hash string) util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
 	}
 
-	// Add cloned repo path to temp repo manager.
-	tempRepoID := m.repoSrv.GetTempRepoManager().Add(cloned.GetPath())
+	m.checkReadAccess(name)
 
-	return map[string]interface{}{
-		"hash":      refHash,
-		"reference": res.Reference,
-		"repoID":    tempRepoID,
+	if hash == "" {
+		panic(se(400, StatusCodeInvalidParam, "hash", "commit hash is required"))
 	}
-}
 
-// ReopenMergeRequest reopens a merge request.
-//  - name: The name of the repository.
-//  - reference: The full merge request reference name.
-func (m *RepoModule) ReopenMergeRequest(name, reference string) util.Map {
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	commit, err := r.GetCommit(hash)
+	if err != nil {
+		if err == plumbing.ErrObjectNotFound {
+			panic(se(404, StatusCodeCommitNotFound, "hash", "commit does not exist"))
+		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return util.ToMap(commit)
+}
 
+// GetCommitNotes returns the note attached to a commit under a notes reference.
+//  - name: The name of the target repository.
+//  - commitHash: The commit hash.
+//  - notesRef: The notes reference (e.g refs/notes/commits). Defaults to refs/notes/commits.
+//
+// RETURN <string>: The content of the note
+func (m *RepoModule) GetCommitNotes(name, commitHash, notesRef string) string {
 	if name == "" {
 		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
 	}
 
-	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
+	m.checkReadAccess(name)
+
+	if commitHash == "" {
+		panic(se(400, StatusCodeInvalidParam, "hash", "commit hash is required"))
+	}
+	if notesRef == "" {
+		notesRef = "refs/notes/commits"
+	}
+	if !pl.IsNote(notesRef) {
+		panic(se(400, StatusCodeInvalidParam, "notesRef", "not a notes reference"))
+	}
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
 	if err != nil {
 		if err == git.ErrRepositoryNotExists {
 			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
@@ -1357,54 +2914,71 @@ func (m *RepoModule) ReopenMergeRequest(name, reference string) util.Map {
 		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
 	}
 
-	curRefHash, err := r.RefGet(reference)
-	if err != nil && err == pl.ErrRefNotFound {
-		panic(se(404, StatusCodeMergeRequestNotFound, "reference", "merge request not found"))
+	if _, err = r.CommitObject(plumbing.NewHash(commitHash)); err != nil {
+		if err == plumbing.ErrObjectNotFound {
+			panic(se(404, StatusCodeCommitNotFound, "hash", "commit does not exist"))
+		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	// Clone the repository and the merge request reference.
-	cloneOpts := pl.CloneOptions{Depth: 1, ReferenceName: reference}
-	if curRefHash == "" {
-		cloneOpts.ReferenceName = ""
-	}
-	cloned, _, err := r.Clone(cloneOpts)
+	notesHash, err := r.RefGet(notesRef)
 	if err != nil {
-		panic(se(500, StatusCodeServerErr, "", errors.Wrap(err, "failed to clone repo").Error()))
+		if err == pl.ErrRefNotFound {
+			panic(se(404, StatusCodeNoteNotFound, "notesRef", "notes reference does not exist"))
+		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	res, err := m.MergeRequestReopen(cloned, &mergecmd.MergeReqReopenArgs{
-		Reference:          reference,
-		PostCommentCreator: pl.CreatePostCommit,
-		ReadPostBody:       pl.ReadPostBody,
-	})
+	notesCommit, err := r.CommitObject(plumbing.NewHash(notesHash))
 	if err != nil {
-		_ = cloned.Delete()
 		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	refHash, err := cloned.RefGet(res.Reference)
+	fileIter, err := notesCommit.Files()
 	if err != nil {
-		_ = cloned.Delete()
 		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	// Add cloned repo path to temp repo manager.
-	tempRepoID := m.repoSrv.GetTempRepoManager().Add(cloned.GetPath())
+	// Note entries are named after the annotated object's hash,
+	// optionally split across fanout directories (e.g ab/cdef...).
+	var note *object.File
+	if err = fileIter.ForEach(func(f *object.File) error {
+		if strings.ReplaceAll(f.Name, "/", "") == commitHash {
+			note = f
+			return storer.ErrStop
+		}
+		return nil
+	}); err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+	if note == nil {
+		panic(se(404, StatusCodeNoteNotFound, "hash", "note does not exist"))
+	}
 
-	return map[string]interface{}{
-		"hash":      refHash,
-		"reference": res.Reference,
-		"repoID":    tempRepoID,
+	content, err := note.Contents()
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
-}
 
-// ListMergeRequests returns a list of merge requests.
-//  - name: The name of the repository.
-func (m *RepoModule) ListMergeRequests(name string) []util.Map {
+	return content
+}
 
+// GetMissingObjects returns the hashes of objects reachable from a
+// reference that do not exist in the local object store.
+//  - name: The name of the target repository.
+//  - ref: The target branch or reference.
+//
+// RETURN <[]string>: The hashes of the missing objects
+func (m *RepoModule) GetMissingObjects(name, ref string) []string {
 	if name == "" {
 		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
 	}
+	if ref == "" {
+		panic(se(400, StatusCodeInvalidParam, "ref", "reference is required"))
+	}
+	if !strings.HasPrefix(ref, "refs/") {
+		ref = "refs/heads/" + ref
+	}
 
 	repoPath := m.logic.Config().GetRepoPath(name)
 	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
@@ -1415,128 +2989,3026 @@ func (m *RepoModule) ListMergeRequests(name string) []util.Map {
 		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
 	}
 
-	issues, err := m.MergeRequestList(r, &mergecmd.MergeRequestListArgs{
-		PostGetter: pl.GetPosts,
-	})
+	hash, err := r.RefGet(ref)
 	if err != nil {
+		if err == pl.ErrRefNotFound {
+			panic(se(404, StatusCodeBranchNotFound, "ref", "reference does not exist"))
+		}
 		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	return util.StructSliceToMap(issues)
-}
-
-// Push signs and pushes a reference in a temporary repository identified by ID.
-//   params <map>
-//     - id: The unique temporary manager ID of the target repository.
-//     - reference: The full reference name of the commit, tag or note to be pushed.
-//     - hash: The latest hash of the reference.
-//     - value: Set transaction value (if applicable)
-//     - fee: Set the transaction fee
-//     - nonce: Set the next transaction nonce of the push key owner (optional).
-// 	 privateKeyOrPushToken: The private key or push token for signing the transaction
-// Blocks until push succeeds.
-// Returns the transaction hash on success.
-func (m *RepoModule) Push(params map[string]interface{}, privateKeyOrPushToken string) string {
-
-	o := objx.New(params)
-	tempRepoMgr := m.repoSrv.GetTempRepoManager()
-	path := tempRepoMgr.GetPath(o.Get("id").Str())
-	if path == "" {
-		panic(se(404, StatusCodeInvalidTempRepoID, "id", "id is expired or invalid"))
+	missing, err := pl.GetMissingObjects(r, hash)
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	// Get the reference to be pushed and ensure it is valid.
-	reference := plumbing.ReferenceName(o.Get("reference").Str())
-	if !reference.IsBranch() && !reference.IsNote() && !reference.IsTag() {
-		panic(se(400, StatusCodeInvalidReferenceName, "reference", "reference name is not valid"))
+	if missing == nil {
+		missing = []string{}
 	}
 
-	// Private key or push token is required
-	if privateKeyOrPushToken == "" {
-		panic(se(400, StatusCodeInvalidPrivateKey, "privateKeyOrPushToken",
-			"private key is required"))
-	}
+	return missing
+}
 
-	// Attempt to decode the privateKeyOrPushToken as though it is a push token.
-	// If we succeed, extract the push key from the token
-	var pushKeyID string
-	if txDetail, _ := pushtoken.Decode(privateKeyOrPushToken); txDetail != nil {
-		pushKeyID = txDetail.PushKeyID
+// VerifyRepoIntegrity checks that the objects reachable from every reference
+// of a repository exist locally and that their content hashes to their ID.
+// It is useful for detecting incomplete or tampered clones.
+//  - name: The name of the target repository.
+//
+// RETURN object <map>
+//  - complete <bool>: Indicates whether no object is missing or corrupt
+//  - missing <[]string>: The hashes of reachable objects that do not exist
+//  - corrupt <[]string>: The hashes of objects whose content fails verification
+func (m *RepoModule) VerifyRepoIntegrity(name string) util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
 	}
 
-	// If the push key is not already known, we assume privateKeyOrPushToken is
-	// a base58 encoded private key and as such we attempt to decode it.
-	var privKey *ed25519.PrivKey
-	var err error
-	if pushKeyID == "" {
-		privKey, err = ed25519.PrivKeyFromBase58(privateKeyOrPushToken)
-		if err != nil {
-			panic(se(400, StatusCodeInvalidPrivateKey, "privateKeyOrPushToken",
-				"private key or push token is not a valid"))
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
 		}
-		pushKeyID = privKey.Wrap().PushAddr().String()
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
 	}
 
-	// Get the working repository
-	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, path)
+	refs, err := r.GetReferences()
 	if err != nil {
-		if err == git.ErrRepositoryNotExists {
-			panic(se(404, StatusCodeRepoNotFound, "name", err.Error()))
-		}
-		panic(se(500, StatusCodeServerErr, "name", err.Error()))
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
+	sort.Slice(refs, func(i, j int) bool { return refs[i] < refs[j] })
 
-	// Create push token if a private key was provided
-	token := privateKeyOrPushToken
-	if privKey != nil {
-		txDetail := &remotetypes.TxDetail{
-			RepoName:  r.GetName(),
-			Fee:       util.String(o.Get("fee").Str()),
-			Value:     util.String(o.Get("value").Str()),
-			Nonce:     cast.ToUint64(o.Get("nonce").Str()),
-			PushKeyID: pushKeyID,
-			Reference: reference.String(),
-			Head:      o.Get("hash").Str(),
+	var missing, corrupt = []string{}, []string{}
+	var visited = map[string]struct{}{}
+	for _, ref := range refs {
+		if !strings.HasPrefix(ref.String(), "refs/") {
+			continue
 		}
 
-		// Get the next nonce, if not set
-		if txDetail.Nonce == 0 {
-			senderAcct := m.logic.AccountKeeper().Get(privKey.Wrap().Addr())
-			txDetail.Nonce = senderAcct.Nonce.UInt64() + 1
+		tip, err := r.Reference(ref, true)
+		if err != nil {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
 		}
 
-		// Create a push token
-		token = pushtoken.MakeFromKey(privKey.Wrap(), txDetail)
+		refMissing, refCorrupt := pl.VerifyReachableObjects(r, tip.Hash().String(), visited)
+		missing = append(missing, refMissing...)
+		corrupt = append(corrupt, refCorrupt...)
 	}
 
-	// Construct and set the remote address.
-	// The remote (origin) must point to a remote server.
-	remoteAddr := config.DefaultRemoteServerAddress
-	if remoteAddr[:1] == ":" {
-		remoteAddr = "127.0.0.1" + remoteAddr
+	return map[string]interface{}{
+		"complete": len(missing) == 0 && len(corrupt) == 0,
+		"missing":  missing,
+		"corrupt":  corrupt,
 	}
-	url := fmt.Sprintf("http://%s/r/%s", remoteAddr, r.GetName())
-	curConfig, err := r.Config()
+}
+
+// EstimateCloneSize estimates the number and size of objects that a clone
+// of a repository would transfer, without performing the clone.
+//  - name: The name of the target repository.
+//  - [opts] <map>
+//  - [opts.reference] <string>: The branch or reference to clone (default: HEAD).
+//  - [opts.depth] <number>: The number of commits to clone. 0 means all.
+//  - [opts.since] <number>: Exclude commits older than this unix time.
+//
+// RETURNS object <map>
+//  - objects <number>: The number of objects
+//  - size <number>: The total size of the objects
+func (m *RepoModule) EstimateCloneSize(name string, opts ...modtypes.CloneOptions) util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	var opt modtypes.CloneOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Depth < 0 {
+		panic(se(400, StatusCodeInvalidParam, "opts.depth", "depth must not be negative"))
+	}
+	if opt.Reference == "" {
+		opt.Reference = plumbing.HEAD.String()
+	}
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	var since time.Time
+	if opt.Since > 0 {
+		since = time.Unix(opt.Since, 0)
+	}
+
+	estimate, err := r.EstimateCloneSize(opt.Reference, opt.Depth, since)
 	if err != nil {
+		if err == plumbing.ErrReferenceNotFound || err == plumbing.ErrObjectNotFound {
+			panic(se(404, StatusCodeBranchNotFound, "opts.reference", "reference does not exist"))
+		}
 		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
-	curConfig.Remotes["origin"] = &gogitcfg.RemoteConfig{Name: "origin", URLs: []string{url}}
-	if err = r.SetConfig(curConfig); err != nil {
+
+	return util.ToMap(estimate)
+}
+
+// GetObjectStats returns the number of objects of each type stored
+// in the object database of a repository.
+//  - name: The name of the target repository.
+//
+// RETURNS object <map>
+//  - commits <number>: The number of commit objects
+//  - trees <number>: The number of tree objects
+//  - blobs <number>: The number of blob objects
+//  - tags <number>: The number of annotated tag objects
+func (m *RepoModule) GetObjectStats(name string) util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	stats, err := r.GetObjectStats()
+	if err != nil {
 		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	// Push to remote
-	progress, err := r.Push(pl.PushOptions{
-		RefSpec: fmt.Sprintf("+%s:%s", reference, reference),
-		Token:   token,
+	return util.ToMap(stats)
+}
+
+// AnalyzeRepack estimates the disk space a repack of a repository would
+// reclaim by comparing the current size of its loose and packed objects to
+// the size of a single pack of its reachable objects. The repository is not
+// modified.
+//  - name: The name of the target repository.
+//
+// RETURNS object <map>
+//  - looseObjects <number>: The number of loose objects
+//  - looseSize <number>: The disk usage of loose objects (in bytes)
+//  - packedObjects <number>: The number of objects in packs
+//  - packs <number>: The number of packs
+//  - packedSize <number>: The disk usage of packs (in bytes)
+//  - garbageSize <number>: The disk usage of garbage files (in bytes)
+//  - currentSize <number>: The current disk usage of all objects (in bytes)
+//  - projectedSize <number>: The projected disk usage after a repack (in bytes)
+//  - reclaimable <number>: The estimated disk space a repack would reclaim (in bytes)
+func (m *RepoModule) AnalyzeRepack(name string) util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	count, err := r.CountObjects()
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	projected, err := r.ProjectedPackSize()
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	current := count.LooseSize + count.PackedSize + count.GarbageSize
+	reclaimable := current - projected
+	if reclaimable < 0 {
+		reclaimable = 0
+	}
+
+	res := util.ToMap(count)
+	res["currentSize"] = current
+	res["projectedSize"] = projected
+	res["reclaimable"] = reclaimable
+	return res
+}
+
+// DoRepack packs the loose objects of a repository and consolidates its
+// packs by running garbage collection. Unreachable objects are pruned
+// according to git's default grace period.
+//  - name: The name of the target repository.
+//
+// RETURNS object <map>
+//  - sizeBefore <number>: The disk usage of all objects before the repack (in bytes)
+//  - sizeAfter <number>: The disk usage of all objects after the repack (in bytes)
+//  - reclaimed <number>: The disk space reclaimed (in bytes)
+func (m *RepoModule) DoRepack(name string) util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	before, err := r.CountObjects()
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	if err := r.GC(); err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	// The packs have changed; Cached handles of the repository must be reloaded.
+	m.logic.Config().G().Bus.Emit(core.EvtRepoUpdated, name, repoPath)
+
+	after, err := r.CountObjects()
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	sizeBefore := before.LooseSize + before.PackedSize + before.GarbageSize
+	sizeAfter := after.LooseSize + after.PackedSize + after.GarbageSize
+	return map[string]interface{}{
+		"sizeBefore": sizeBefore,
+		"sizeAfter":  sizeAfter,
+		"reclaimed":  sizeBefore - sizeAfter,
+	}
+}
+
+// GetRepoStats returns the aggregated statistics of a repository.
+// The result is cached until the repository is updated.
+//  - name: The name of the target repository.
+//
+// RETURNS object <map>
+//  - defaultBranch <string>: The full name of the default branch ("" if unknown)
+//  - commits <number>: The number of commits in the default branch
+//  - branches <number>: The number of branches (excluding issue and merge request branches)
+//  - tags <number>: The number of tags
+//  - openIssues <number>: The number of open issues
+//  - openMergeRequests <number>: The number of open merge requests
+//  - contributors <number>: The number of repository contributors
+//  - lastActivity <number>: The unix time of the most recent branch commit or post (0 if none)
+func (m *RepoModule) GetRepoStats(name string) util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	m.checkReadAccess(name)
+
+	if stats, ok := m.statsCache.Get(name); ok {
+		return stats
+	}
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	branches, err := r.GetBranches()
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+	var codeBranches []string
+	for _, branch := range branches {
+		ref := plumbing.NewBranchReferenceName(branch).String()
+		if pl.IsIssueReference(ref) || pl.IsMergeRequestReference(ref) {
+			continue
+		}
+		codeBranches = append(codeBranches, ref)
+	}
+	branches = codeBranches
+
+	var lastActivity int64
+	for _, activity := range m.getBranchActivities(name, r, branches) {
+		if activity.LastCommitTime > lastActivity {
+			lastActivity = activity.LastCommitTime
+		}
+	}
+
+	defaultBranch, err := getDefaultBranch(r)
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	var numCommits int
+	if defaultBranch != "" {
+		numCommits, err = r.NumCommits(defaultBranch, false)
+		if err != nil && err != plumbing.ErrReferenceNotFound {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+	}
+
+	tagIter, err := r.Tags()
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+	var numTags int
+	_ = tagIter.ForEach(func(*plumbing.Reference) error {
+		numTags++
+		return nil
+	})
+
+	posts, err := pl.GetPosts(r, func(ref plumbing.ReferenceName) bool {
+		return pl.IsIssueReference(ref.String()) || pl.IsMergeRequestReference(ref.String())
 	})
 	if err != nil {
-		panic(se(500, StatusCodePushFailure, "", err.Error()))
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
 	}
 
-	_ = tempRepoMgr.Remove(o.Get("id").Str())
+	var openIssues, openMergeRequests int
+	for _, post := range posts {
+		if createdAt := post.GetComment().CreatedAt.Unix(); createdAt > lastActivity {
+			lastActivity = createdAt
+		}
+		closed, err := post.IsClosed()
+		if err != nil {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		} else if closed {
+			continue
+		}
+		if pl.IsMergeRequestReference(post.GetName()) {
+			openMergeRequests++
+		} else {
+			openIssues++
+		}
+	}
 
-	hash := strings.Split(progress.String(), "hash: ")[1]
-	hash = strings.TrimSpace(stripansi.Strip(hash))
-	return hash
+	var stats = util.Map{
+		"defaultBranch":     defaultBranch,
+		"commits":           numCommits,
+		"branches":          len(branches),
+		"tags":              numTags,
+		"openIssues":        openIssues,
+		"openMergeRequests": openMergeRequests,
+		"contributors":      len(m.logic.RepoKeeper().Get(name).Contributors),
+		"lastActivity":      lastActivity,
+	}
+	m.statsCache.Set(name, stats)
+
+	return stats
+}
+
+// CountCommits returns the number commits in a branch/reference.
+//  - name: The name of the target repository.
+//  - ref: The target branch or reference.
+func (m *RepoModule) CountCommits(name, ref string) int {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	m.checkReadAccess(name)
+
+	if ref == "" {
+		panic(se(400, StatusCodeInvalidParam, "branch", "branch name is required"))
+	}
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	count, err := r.NumCommits(ref, false)
+	if err != nil {
+		if err == plumbing.ErrReferenceNotFound {
+			panic(se(404, StatusCodeBranchNotFound, "branch", "branch does not exist"))
+		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return count
+}
+
+// GetCommitAncestors returns ancestors of a commit with the given hash.
+//  - commitHash: The hash of the commit.
+//  - limit: The number of commit to return. 0 means all.
+func (m *RepoModule) GetCommitAncestors(name, commitHash string, limit ...int) []util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	m.checkReadAccess(name)
+
+	if commitHash == "" {
+		panic(se(400, StatusCodeInvalidParam, "commitHash", "commit hash is required"))
+	}
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	limit_ := 0
+	if len(limit) > 0 {
+		limit_ = limit[0]
+	}
+
+	commits, err := r.GetCommitAncestors(commitHash, limit_)
+	if err != nil {
+		if err == plumbing.ErrObjectNotFound {
+			panic(se(404, StatusCodeCommitNotFound, "commitHash", "commit does not exist"))
+		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return util.StructSliceToMap(commits)
+}
+
+// GetMergeBase returns the hash of the best common ancestor of two commits.
+//  - name: The name of the target repository.
+//  - commitA: The hash of the first commit.
+//  - commitB: The hash of the second commit.
+func (m *RepoModule) GetMergeBase(name, commitA, commitB string) string {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	m.checkReadAccess(name)
+
+	if commitA == "" {
+		panic(se(400, StatusCodeInvalidParam, "commitA", "commit hash is required"))
+	}
+
+	if commitB == "" {
+		panic(se(400, StatusCodeInvalidParam, "commitB", "commit hash is required"))
+	}
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	base, err := r.GetMergeBase(commitA, commitB)
+	if err != nil {
+		if err == plumbing.ErrObjectNotFound {
+			panic(se(404, StatusCodeCommitNotFound, "", "commit does not exist"))
+		}
+		if err == repo.ErrNoMergeBase {
+			panic(se(404, StatusCodeInvalidParam, "", err.Error()))
+		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return base
+}
+
+// GetBranchDivergence returns the number of commits a branch is ahead
+// and behind the default branch of a repository, and the diverging commits.
+//  - name: The name of the target repository.
+//  - branch: The name of the branch.
+//
+// RETURNS object <map>
+//  - base <string>: The full name of the default branch
+//  - mergeBase <string>: The hash of the best common ancestor of the branches.
+//    It is empty if the branches have no common history.
+//  - ahead <number>: The number of commits in the branch that are not in the default branch
+//  - behind <number>: The number of commits in the default branch that are not in the branch
+//  - aheadCommits <[]map>: The commits in the branch that are not in the default branch
+//  - behindCommits <[]map>: The commits in the default branch that are not in the branch
+func (m *RepoModule) GetBranchDivergence(name, branch string) util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	m.checkReadAccess(name)
+
+	if branch == "" {
+		panic(se(400, StatusCodeInvalidParam, "branch", "branch name is required"))
+	}
+
+	defaultBranch := m.GetDefaultBranch(name)
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	branchCommit, err := r.GetLatestCommit(branch)
+	if err != nil {
+		if err == plumbing.ErrReferenceNotFound {
+			panic(se(404, StatusCodeBranchNotFound, "branch", "branch does not exist"))
+		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	baseCommit, err := r.GetLatestCommit(defaultBranch)
+	if err != nil {
+		if err == plumbing.ErrReferenceNotFound {
+			panic(se(404, StatusCodeBranchNotFound, "name", "default branch does not exist"))
+		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	mergeBase, err := r.GetMergeBase(baseCommit.Hash, branchCommit.Hash)
+	if err != nil && err != repo.ErrNoMergeBase {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	ahead, err := r.GetCommitsBetween(baseCommit.Hash, branchCommit.Hash)
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	behind, err := r.GetCommitsBetween(branchCommit.Hash, baseCommit.Hash)
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return util.Map{
+		"base":          defaultBranch,
+		"mergeBase":     mergeBase,
+		"ahead":         len(ahead),
+		"behind":        len(behind),
+		"aheadCommits":  util.StructSliceToMap(ahead),
+		"behindCommits": util.StructSliceToMap(behind),
+	}
+}
+
+// CompareRepos returns the number of commits a branch of a head repository
+// (e.g. a fork) is ahead and behind the same branch of a base repository,
+// and the diverging commits. Objects of the head branch missing in the base
+// repository are fetched from the DHT before the branches are compared.
+//  - baseRepo: The name of the base repository.
+//  - headRepo: The name of the head repository.
+//  - branch: The name of the branch.
+//
+// RETURNS object <map>
+//  - mergeBase <string>: The hash of the best common ancestor of the branches.
+//    It is empty if the branches have no common history.
+//  - ahead <number>: The number of commits in the head branch that are not in the base branch
+//  - behind <number>: The number of commits in the base branch that are not in the head branch
+//  - aheadCommits <[]map>: The commits in the head branch that are not in the base branch
+//  - behindCommits <[]map>: The commits in the base branch that are not in the head branch
+func (m *RepoModule) CompareRepos(baseRepo, headRepo, branch string) util.Map {
+	if baseRepo == "" {
+		panic(se(400, StatusCodeInvalidParam, "baseRepo", "base repo name is required"))
+	}
+
+	if headRepo == "" {
+		panic(se(400, StatusCodeInvalidParam, "headRepo", "head repo name is required"))
+	}
+
+	if branch == "" {
+		panic(se(400, StatusCodeInvalidParam, "branch", "branch name is required"))
+	}
+
+	m.checkReadAccess(baseRepo)
+	m.checkReadAccess(headRepo)
+
+	getRepo := func(name, field string) pl.LocalRepo {
+		r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
+		if err != nil {
+			if err == git.ErrRepositoryNotExists {
+				panic(se(404, StatusCodeRepoNotFound, field, err.Error()))
+			}
+			panic(se(500, StatusCodeServerErr, field, err.Error()))
+		}
+		return r
+	}
+	base, head := getRepo(baseRepo, "baseRepo"), getRepo(headRepo, "headRepo")
+
+	getTip := func(r pl.LocalRepo, field string) string {
+		c, err := r.GetLatestCommit(branch)
+		if err != nil {
+			if err == plumbing.ErrReferenceNotFound {
+				panic(se(404, StatusCodeBranchNotFound, field, "branch does not exist"))
+			}
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+		return c.Hash
+	}
+	baseTip, headTip := getTip(base, "baseRepo"), getTip(head, "headRepo")
+
+	// Fetch the head branch objects missing in the base repository
+	if !base.ObjectExist(headTip) {
+		args := dht.GetAncestorArgs{
+			RepoName:      headRepo,
+			LocalRepoName: baseRepo,
+			StartHash:     pl.HashToBytes(headTip),
+			ResultCB: func(packfile io.ReadSeekerCloser, _ string) error {
+				defer packfile.Close()
+				return m.PackToRepoUnpacker(base, packfile)
+			},
+		}
+		ctx, cn := context.WithTimeout(context.Background(), 60*time.Second)
+		_, err := m.repoSrv.GetDHT().ObjectStreamer().GetCommitWithAncestors(ctx, args)
+		cn()
+		if err != nil {
+			panic(se(500, StatusCodeServerErr, "headRepo", errors.Wrap(err, "failed to fetch head objects").Error()))
+		}
+	}
+
+	mergeBase, err := base.GetMergeBase(baseTip, headTip)
+	if err != nil && err != repo.ErrNoMergeBase {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	ahead, err := base.GetCommitsBetween(baseTip, headTip)
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	behind, err := base.GetCommitsBetween(headTip, baseTip)
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return util.Map{
+		"mergeBase":     mergeBase,
+		"ahead":         len(ahead),
+		"behind":        len(behind),
+		"aheadCommits":  util.StructSliceToMap(ahead),
+		"behindCommits": util.StructSliceToMap(behind),
+	}
+}
+
+// GetRefsContaining returns the branches and tags whose history includes a commit.
+//  - name: The name of the target repository.
+//  - commitHash: The hash of the commit.
+//
+// RETURNS object <map>
+//  - branches <[]string>: The names of the branches
+//  - tags <[]string>: The names of the tags
+func (m *RepoModule) GetRefsContaining(name, commitHash string) util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	m.checkReadAccess(name)
+
+	if commitHash == "" {
+		panic(se(400, StatusCodeInvalidParam, "commitHash", "commit hash is required"))
+	}
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	res, err := r.GetRefsContaining(commitHash)
+	if err != nil {
+		if err == plumbing.ErrObjectNotFound {
+			panic(se(404, StatusCodeCommitNotFound, "commitHash", "commit not found"))
+		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return util.Map{
+		"branches": res.Branches,
+		"tags":     res.Tags,
+	}
+}
+
+// GetParentsAndCommitDiff gets the diff output between a commit and its parent(s).
+//  - name: The name of the target repository.
+//  - commitHash: The hash of the commit.
+func (m *RepoModule) GetParentsAndCommitDiff(name string, commitHash string) util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	m.checkReadAccess(name)
+
+	if commitHash == "" {
+		panic(se(400, StatusCodeInvalidParam, "commitHash", "commit hash is required"))
+	}
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	res, err := r.GetParentAndChildCommitDiff(commitHash)
+	if err != nil {
+		if err == plumbing.ErrObjectNotFound {
+			panic(se(404, StatusCodeCommitNotFound, "commitHash", "commit not found"))
+		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return util.ToMap(res)
+}
+
+// StreamParentsAndCommitDiff is like GetParentsAndCommitDiff but passes the
+// diff of each changed file to cb as it is produced instead of building
+// the entire diff output in memory.
+//  - name: The name of the target repository.
+//  - commitHash: The hash of the commit.
+//  - cb: Called with the parent commit hash and the diff of a file.
+func (m *RepoModule) StreamParentsAndCommitDiff(name string, commitHash string, cb func(parent, fileDiff string) error) {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	m.checkReadAccess(name)
+
+	if commitHash == "" {
+		panic(se(400, StatusCodeInvalidParam, "commitHash", "commit hash is required"))
+	}
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	if err = r.StreamParentAndChildCommitDiff(commitHash, cb); err != nil {
+		if err == plumbing.ErrObjectNotFound {
+			panic(se(404, StatusCodeCommitNotFound, "commitHash", "commit not found"))
+		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+}
+
+// GetCommitTree returns the path, mode, type, hash and size of every
+// entry in the file tree of a commit, including the entries of sub-trees.
+//  - name: The name of the target repository.
+//  - commitHash: The hash of the commit.
+func (m *RepoModule) GetCommitTree(name string, commitHash string) []util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	m.checkReadAccess(name)
+
+	if commitHash == "" {
+		panic(se(400, StatusCodeInvalidParam, "commitHash", "commit hash is required"))
+	}
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	entries, err := r.GetCommitTree(commitHash)
+	if err != nil {
+		if err == plumbing.ErrObjectNotFound {
+			panic(se(404, StatusCodeCommitNotFound, "commitHash", "commit not found"))
+		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return util.StructSliceToMap(entries)
+}
+
+// ExportPatchSeries returns a git-format-patch style patch for each commit
+// reachable from a commit but not from another, ordered from the oldest
+// commit to the newest.
+//  - name: The name of the target repository.
+//  - from: The hash of the commit whose history is excluded.
+//  - to: The hash of the commit whose history is included.
+//
+// RETURNS <[]string>: The patch of each commit
+func (m *RepoModule) ExportPatchSeries(name, from, to string) []string {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	m.checkReadAccess(name)
+
+	if from == "" {
+		panic(se(400, StatusCodeInvalidParam, "from", "commit hash is required"))
+	}
+	if to == "" {
+		panic(se(400, StatusCodeInvalidParam, "to", "commit hash is required"))
+	}
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	patches, err := r.ExportPatchSeries(from, to)
+	if err != nil {
+		if err == plumbing.ErrObjectNotFound {
+			panic(se(404, StatusCodeCommitNotFound, "", "commit not found"))
+		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return patches
+}
+
+// CompareTags returns the commits and changed files between two tags.
+//  - name: The name of the target repository.
+//  - fromTag: The name of the older tag.
+//  - toTag: The name of the newer tag.
+func (m *RepoModule) CompareTags(name, fromTag, toTag string) util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	m.checkReadAccess(name)
+
+	if fromTag == "" {
+		panic(se(400, StatusCodeInvalidParam, "fromTag", "tag name is required"))
+	}
+	if toTag == "" {
+		panic(se(400, StatusCodeInvalidParam, "toTag", "tag name is required"))
+	}
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	res, err := r.CompareTags(fromTag, toTag)
+	if err != nil {
+		if err == plumbing.ErrReferenceNotFound {
+			panic(se(404, StatusCodeTagNotFound, "", "tag does not exist"))
+		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return util.ToMap(res)
+}
+
+// cloneRepo clones a repository into a temporary directory. The clone is
+// aborted if it takes longer than the configured clone timeout.
+//
+// PANICS: With a clone_timeout error when the clone times out.
+func (m *RepoModule) cloneRepo(r pl.LocalRepo, opts pl.CloneOptions) (pl.LocalRepo, string, error) {
+	timeout := m.logic.Config().Repo.CloneTimeout
+	if timeout <= 0 {
+		return r.Clone(opts)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	opts.Context = ctx
+
+	type cloneResult struct {
+		repo pl.LocalRepo
+		dir  string
+		err  error
+	}
+
+	resCh := make(chan *cloneResult, 1)
+	go func() {
+		cloned, dir, err := r.Clone(opts)
+		resCh <- &cloneResult{repo: cloned, dir: dir, err: err}
+	}()
+
+	select {
+	case res := <-resCh:
+		if res.err == nil || ctx.Err() == nil {
+			return res.repo, res.dir, res.err
+		}
+		if res.dir != "" {
+			os.RemoveAll(res.dir)
+		}
+	case <-ctx.Done():
+		// Remove the partially cloned repository once the clone returns
+		go func() {
+			if res := <-resCh; res.dir != "" {
+				os.RemoveAll(res.dir)
+			}
+		}()
+	}
+
+	panic(se(504, StatusCodeCloneTimeout, "", fmt.Sprintf("repository clone timed out after %s", timeout)))
+}
+
+// CreateIssue creates an issue or adds a comment to an issue.
+//  - name: The name of the repository.
+//  - params: Issue parameters.
+//    - id: The new issue ID or ID of an existing issue to reply to.
+//    - title: The title of the issue.
+//    - body: The body of the issue.
+//    - replyHash: The commit hash of a comment being replied to.
+//    - reactions: An array of unicode emojis.
+//    - labels: A list of labels.
+//    - assignees: A list of assignees.
+//    - close: Closes the issue status.
+func (m *RepoModule) CreateIssue(name string, params map[string]interface{}) util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	// Generate a new issue ID
+	o := objx.New(params)
+	id := cast.ToInt(o.Get("id").Inter())
+	if id == 0 {
+		id, err = m.PostIDFinder(r, 1, pl.IssueBranchPrefix)
+		if err != nil {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+	}
+
+	// Clone the repository and the issue reference.
+	// Determine the full issue reference name and check
+	// if it exists. If it does not reset to empty string.
+	cloneOpts := pl.CloneOptions{Depth: 1, ReferenceName: pl.MakeIssueReference(id)}
+	_, err = r.RefGet(cloneOpts.ReferenceName)
+	if err != nil {
+		cloneOpts.ReferenceName = ""
+	}
+	cloned, _, err := m.cloneRepo(r, cloneOpts)
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", errors.Wrap(err, "failed to clone repo").Error()))
+	}
+
+	// Create the issue
+	args := &issuecmd.IssueCreateArgs{
+		ID:                 id,
+		Title:              o.Get("title").Str(),
+		Body:               o.Get("body").Str(),
+		ReplyHash:          o.Get("replyHash").Str(),
+		Reactions:          cast.ToStringSlice(o.Get("reactions").Inter()),
+		Labels:             cast.ToStringSlice(o.Get("labels").Inter()),
+		Assignees:          cast.ToStringSlice(o.Get("assignees").Inter()),
+		PostCommentCreator: pl.CreatePostCommit,
+	}
+	closeIssue := o.Get("close")
+	if !closeIssue.IsNil() {
+		args.Close = pointer.ToBool(closeIssue.Bool())
+	}
+
+	res, err := m.IssueCreate(cloned, args)
+	if err != nil {
+		_ = cloned.Delete()
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	refHash, err := cloned.RefGet(res.Reference)
+	if err != nil {
+		_ = cloned.Delete()
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	// Add cloned repo path to temp repo manager.
+	tempRepoID := m.repoSrv.GetTempRepoManager().Add(cloned.GetPath())
+
+	return map[string]interface{}{
+		"hash":      refHash,
+		"reference": res.Reference,
+		"repoID":    tempRepoID,
+	}
+}
+
+// ReadIssue gets an issue.
+//  - name: The name of the repository.
+//  - reference: The full issue reference name.
+func (m *RepoModule) ReadIssue(name, reference string) []util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	m.checkReadAccess(name)
+
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	comments, err := m.IssueRead(r, &issuecmd.IssueReadArgs{
+		Reference:  reference,
+		PostGetter: pl.GetPosts,
+	})
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return util.StructSliceToMap(comments)
+}
+
+// ReadIssueThread gets the comments of an issue arranged as reply threads.
+// Each comment includes a 'replies' field containing the comments that replied to it.
+//  - name: The name of the repository.
+//  - reference: The full issue reference name.
+func (m *RepoModule) ReadIssueThread(name, reference string) []util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	m.checkReadAccess(name)
+
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	if _, err = r.RefGet(reference); err != nil && err == pl.ErrRefNotFound {
+		panic(se(404, StatusCodeIssueNotFound, "reference", "issue not found"))
+	}
+
+	comments, err := m.IssueRead(r, &issuecmd.IssueReadArgs{
+		Reference:  reference,
+		PostGetter: pl.GetPosts,
+	})
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return commentThreadsToMap(comments.Threads())
+}
+
+// commentThreadsToMap converts comment threads to a slice of maps
+func commentThreadsToMap(threads []*pl.CommentThread) []util.Map {
+	var res = []util.Map{}
+	for _, thread := range threads {
+		m := util.ToMap(thread.Comment)
+		m["replies"] = commentThreadsToMap(thread.Replies)
+		res = append(res, m)
+	}
+	return res
+}
+
+// CloseIssue closes an issue.
+//  - name: The name of the repository.
+//  - reference: The full issue reference name.
+func (m *RepoModule) CloseIssue(name, reference string) util.Map {
+
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	curRefHash, err := r.RefGet(reference)
+	if err != nil && err == pl.ErrRefNotFound {
+		panic(se(404, StatusCodeIssueNotFound, "reference", "issue not found"))
+	}
+
+	// Clone the repository and the issue reference.
+	cloneOpts := pl.CloneOptions{Depth: 1, ReferenceName: reference}
+	if curRefHash == "" {
+		cloneOpts.ReferenceName = ""
+	}
+	cloned, _, err := m.cloneRepo(r, cloneOpts)
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", errors.Wrap(err, "failed to clone repo").Error()))
+	}
+
+	res, err := m.IssueClose(cloned, &issuecmd.IssueCloseArgs{
+		Reference:          reference,
+		PostCommentCreator: pl.CreatePostCommit,
+		ReadPostBody:       pl.ReadPostBody,
+	})
+	if err != nil {
+		_ = cloned.Delete()
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	refHash, err := cloned.RefGet(res.Reference)
+	if err != nil {
+		_ = cloned.Delete()
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	// Add cloned repo path to temp repo manager.
+	tempRepoID := m.repoSrv.GetTempRepoManager().Add(cloned.GetPath())
+
+	return map[string]interface{}{
+		"hash":      refHash,
+		"reference": res.Reference,
+		"repoID":    tempRepoID,
+	}
+}
+
+// ReopenIssue reopens a closed issue.
+//  - name: The name of the repository.
+//  - reference: The full issue reference name.
+func (m *RepoModule) ReopenIssue(name, reference string) util.Map {
+
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	curRefHash, err := r.RefGet(reference)
+	if err != nil && err == pl.ErrRefNotFound {
+		panic(se(404, StatusCodeIssueNotFound, "reference", "issue not found"))
+	}
+
+	// Clone the repository and the issue reference.
+	cloneOpts := pl.CloneOptions{Depth: 1, ReferenceName: reference}
+	if curRefHash == "" {
+		cloneOpts.ReferenceName = ""
+	}
+	cloned, _, err := m.cloneRepo(r, cloneOpts)
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", errors.Wrap(err, "failed to clone repo").Error()))
+	}
+
+	res, err := m.IssueReopen(cloned, &issuecmd.IssueReopenArgs{
+		Reference:          reference,
+		PostCommentCreator: pl.CreatePostCommit,
+		ReadPostBody:       pl.ReadPostBody,
+	})
+	if err != nil {
+		_ = cloned.Delete()
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	refHash, err := cloned.RefGet(res.Reference)
+	if err != nil {
+		_ = cloned.Delete()
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	// Add cloned repo path to temp repo manager.
+	tempRepoID := m.repoSrv.GetTempRepoManager().Add(cloned.GetPath())
+
+	return map[string]interface{}{
+		"hash":      refHash,
+		"reference": res.Reference,
+		"repoID":    tempRepoID,
+	}
+}
+
+// ListIssues returns a list of issues.
+// Issues archived by the repository's post retention policy are not included.
+//  - name: The name of the repository.
+//  - opts <map>: list options
+//  - opts.includeArchived: Include archived issues.
+func (m *RepoModule) ListIssues(name string, opts ...modtypes.ListPostsOptions) []util.Map {
+
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	m.checkReadAccess(name)
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	issues, err := m.IssueList(r, &issuecmd.IssueListArgs{
+		PostGetter: pl.GetPosts,
+	})
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	if len(opts) == 0 || !opts[0].IncludeArchived {
+		issues = m.removeArchivedPosts(name, issues)
+	}
+
+	return util.StructSliceToMap(issues)
+}
+
+// CreateMergeRequest creates a merge request or adds a comment to an existing merge request.
+//  - name: The name of the repository.
+//  - params: Issue parameters.
+//    - id: The new issue ID or ID of an existing issue to reply to.
+//    - base: The base branch name.
+//    - baseHash: The base branch hash.
+//    - target: The target branch name.
+//    - targetHash: The target branch hash.
+//    - title: The title of the issue.
+//    - body: The body of the issue.
+//    - replyHash: The commit hash of a comment being replied to.
+//    - reactions: An array of unicode emojis.
+//    - reviewers: Push keys of reviewers. Defaults to the repository's default reviewers.
+//    - close: Closes the issue status.
+func (m *RepoModule) CreateMergeRequest(name string, params map[string]interface{}) util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	// Generate a new issue ID
+	o := objx.New(params)
+	id := cast.ToInt(o.Get("id").Inter())
+	if id == 0 {
+		id, err = m.PostIDFinder(r, 1, pl.MergeRequestBranchPrefix)
+		if err != nil {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+	}
+
+	// Clone the repository and the merge request reference.
+	// Determine the full issue reference name and check
+	// if it exists. If it does not reset to empty string.
+	cloneOpts := pl.CloneOptions{Depth: 1, ReferenceName: pl.MakeMergeRequestReference(id)}
+	_, err = r.RefGet(cloneOpts.ReferenceName)
+	if err != nil {
+		cloneOpts.ReferenceName = ""
+	}
+	cloned, _, err := m.cloneRepo(r, cloneOpts)
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", errors.Wrap(err, "failed to clone repo").Error()))
+	}
+
+	args := &mergecmd.MergeRequestCreateArgs{
+		ID:                 id,
+		Title:              o.Get("title").Str(),
+		Body:               o.Get("body").Str(),
+		ReplyHash:          o.Get("replyHash").Str(),
+		Reactions:          cast.ToStringSlice(o.Get("reactions").Inter()),
+		Base:               o.Get("base").Str(),
+		BaseHash:           o.Get("baseHash").Str(),
+		Target:             o.Get("target").Str(),
+		TargetHash:         o.Get("targetHash").Str(),
+		PostCommentCreator: pl.CreatePostCommit,
+	}
+
+	closeIssue := o.Get("close")
+	if !closeIssue.IsNil() {
+		args.Close = pointer.ToBool(closeIssue.Bool())
+	}
+
+	if reviewers := o.Get("reviewers"); !reviewers.IsNil() {
+		args.Reviewers = cast.ToStringSlice(reviewers.Inter())
+	}
+
+	// Use the repository's default reviewers if none was specified
+	// and pass along its preferred merge strategy
+	if repoState := m.logic.RepoKeeper().Get(name); repoState.Config != nil {
+		args.DefaultReviewers = repoState.Config.DefaultReviewers
+		args.MergeStrategy = repoState.Config.MergeStrategy
+	}
+
+	res, err := m.MergeRequestCreate(cloned, args)
+	if err != nil {
+		_ = cloned.Delete()
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	refHash, err := cloned.RefGet(res.Reference)
+	if err != nil {
+		_ = cloned.Delete()
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	// Add cloned repo path to temp repo manager.
+	tempRepoID := m.repoSrv.GetTempRepoManager().Add(cloned.GetPath())
+
+	result := map[string]interface{}{
+		"hash":      refHash,
+		"reference": res.Reference,
+		"repoID":    tempRepoID,
+	}
+	if res.MergeStrategy != "" {
+		result["mergeStrategy"] = res.MergeStrategy
+	}
+
+	return result
+}
+
+// ReadMergeRequest gets an issue.
+//  - name: The name of the repository.
+//  - reference: The full merge request reference name.
+func (m *RepoModule) ReadMergeRequest(name, reference string) []util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	m.checkReadAccess(name)
+
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	comments, err := m.MergeRequestRead(r, &mergecmd.MergeRequestReadArgs{
+		Reference:  reference,
+		PostGetter: pl.GetPosts,
+	})
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return util.StructSliceToMap(comments)
+}
+
+// ReadMergeRequestThread gets the comments of a merge request arranged as reply threads.
+// Each comment includes a 'replies' field containing the comments that replied to it.
+//  - name: The name of the repository.
+//  - reference: The full merge request reference name.
+func (m *RepoModule) ReadMergeRequestThread(name, reference string) []util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	m.checkReadAccess(name)
+
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	if _, err = r.RefGet(reference); err != nil && err == pl.ErrRefNotFound {
+		panic(se(404, StatusCodeMergeRequestNotFound, "reference", "merge request not found"))
+	}
+
+	comments, err := m.MergeRequestRead(r, &mergecmd.MergeRequestReadArgs{
+		Reference:  reference,
+		PostGetter: pl.GetPosts,
+	})
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return commentThreadsToMap(comments.Threads())
+}
+
+// ExportPosts serializes the issues or merge requests of a repository,
+// including their comments, metadata and reply relationships, into a
+// portable bundle that can be loaded into a repository using ImportPosts.
+//  - name: The name of the repository.
+//  - kind: The kind of posts to export ('issue' or 'mergeRequest').
+//
+// RETURN <string>: The JSON encoded bundle.
+func (m *RepoModule) ExportPosts(name, kind string) string {
+
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	m.checkReadAccess(name)
+
+	if kind != pl.PostBundleKindIssue && kind != pl.PostBundleKindMergeRequest {
+		panic(se(400, StatusCodeInvalidParam, "kind", "expected 'issue' or 'mergeRequest'"))
+	}
+
+	if m.logic.RepoKeeper().Get(name).IsEmpty() {
+		panic(se(404, StatusCodeRepoNotFound, "name", types.ErrRepoNotFound.Error()))
+	}
+
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	// Get the posts, oldest first, so that they keep their
+	// relative order when they are imported.
+	var posts pl.Posts
+	if kind == pl.PostBundleKindIssue {
+		posts, err = m.IssueList(r, &issuecmd.IssueListArgs{Reverse: true, PostGetter: pl.GetPosts})
+	} else {
+		posts, err = m.MergeRequestList(r, &mergecmd.MergeRequestListArgs{Reverse: true, PostGetter: pl.GetPosts})
+	}
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	bundle := &pl.PostBundle{Version: pl.PostBundleVersion, Kind: kind, Posts: []*pl.BundledPost{}}
+	for _, post := range posts {
+		var comments pl.Comments
+		if kind == pl.PostBundleKindIssue {
+			comments, err = m.IssueRead(r, &issuecmd.IssueReadArgs{
+				Reference:  post.GetName(),
+				Reverse:    true,
+				PostGetter: pl.GetPosts,
+			})
+		} else {
+			comments, err = m.MergeRequestRead(r, &mergecmd.MergeRequestReadArgs{
+				Reference:  post.GetName(),
+				Reverse:    true,
+				PostGetter: pl.GetPosts,
+			})
+		}
+		if err != nil {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+
+		closed, err := post.IsClosed()
+		if err != nil {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+
+		bundle.Posts = append(bundle.Posts, &pl.BundledPost{
+			Reference: post.GetName(),
+			Title:     post.GetTitle(),
+			Closed:    closed,
+			Comments:  comments,
+		})
+	}
+
+	bz, err := json.Marshal(bundle)
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return string(bz)
+}
+
+// ImportPosts re-creates the posts of a bundle produced by ExportPosts in a
+// temporary worktree of a repository. Each post is given a new ID and its
+// comments are replayed in order, preserving their metadata and reply
+// relationships. The original authors and dates of the comments are not
+// preserved. Each new post reference must be pushed using the returned
+// temporary repository ID.
+//  - name: The name of the repository.
+//  - bundle: The JSON encoded bundle.
+//
+// RETURN object <map>
+//  - repoID <string>: The ID of the temporary repository.
+//  - posts <[]map>: The imported posts.
+//    - source <string>: The reference of the post in the bundle.
+//    - reference <string>: The new reference of the post.
+//    - hash <string>: The hash of the new reference.
+func (m *RepoModule) ImportPosts(name, bundle string) util.Map {
+
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	var b pl.PostBundle
+	if err := json.Unmarshal([]byte(bundle), &b); err != nil {
+		panic(se(400, StatusCodeInvalidParam, "bundle", "bundle is not valid"))
+	}
+	if b.Version > pl.PostBundleVersion {
+		panic(se(400, StatusCodeInvalidParam, "bundle", "bundle version is not supported"))
+	}
+
+	var prefix string
+	switch b.Kind {
+	case pl.PostBundleKindIssue:
+		prefix = pl.IssueBranchPrefix
+	case pl.PostBundleKindMergeRequest:
+		prefix = pl.MergeRequestBranchPrefix
+	default:
+		panic(se(400, StatusCodeInvalidParam, "bundle", "bundle kind is not supported"))
+	}
+
+	if m.logic.RepoKeeper().Get(name).IsEmpty() {
+		panic(se(404, StatusCodeRepoNotFound, "name", types.ErrRepoNotFound.Error()))
+	}
+
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	cloned, _, err := m.cloneRepo(r, pl.CloneOptions{Depth: 1})
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", errors.Wrap(err, "failed to clone repo").Error()))
+	}
+
+	var imported = []util.Map{}
+	var nextID = 1
+	for _, post := range b.Posts {
+
+		// Find an ID that is not used by the repository's posts. Since IDs are
+		// allocated in increasing order, they cannot collide with posts
+		// already imported into the temporary repository.
+		id, err := m.PostIDFinder(r, nextID, prefix)
+		if err != nil {
+			_ = cloned.Delete()
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+		nextID = id + 1
+
+		reference, err := m.replayPost(cloned, b.Kind, id, post)
+		if err != nil {
+			_ = cloned.Delete()
+			panic(se(500, StatusCodeServerErr, "", errors.Wrapf(err, "failed to import post (%s)", post.Reference).Error()))
+		}
+
+		refHash, err := cloned.RefGet(reference)
+		if err != nil {
+			_ = cloned.Delete()
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+
+		imported = append(imported, util.Map{
+			"source":    post.Reference,
+			"reference": reference,
+			"hash":      refHash,
+		})
+	}
+
+	// Add cloned repo path to temp repo manager.
+	tempRepoID := m.repoSrv.GetTempRepoManager().Add(cloned.GetPath())
+
+	return map[string]interface{}{
+		"repoID": tempRepoID,
+		"posts":  imported,
+	}
+}
+
+// replayPost creates the comments of a bundled post as a new post with the
+// given ID. Reply hashes are mapped to the hashes of the re-created comments.
+// It returns the reference of the new post.
+func (m *RepoModule) replayPost(r pl.LocalRepo, kind string, id int, post *pl.BundledPost) (string, error) {
+	if len(post.Comments) == 0 {
+		return "", fmt.Errorf("post has no comments")
+	}
+
+	var reference string
+	var hashes = make(map[string]string)
+	for i, comment := range post.Comments {
+		body := comment.Body
+		if body == nil {
+			body = &pl.PostBody{}
+		}
+
+		// Only the first comment of a post carries the title
+		var title string
+		if i == 0 {
+			title = post.Title
+		}
+
+		var replyHash string
+		if body.ReplyTo != "" {
+			if replyHash = hashes[body.ReplyTo]; replyHash == "" {
+				return "", fmt.Errorf("comment (%s) replies to an unknown comment", comment.Hash)
+			}
+		}
+
+		if kind == pl.PostBundleKindIssue {
+			args := &issuecmd.IssueCreateArgs{
+				ID:                 id,
+				Title:              title,
+				Body:               string(body.Content),
+				ReplyHash:          replyHash,
+				Reactions:          body.Reactions,
+				Close:              body.Close,
+				PostCommentCreator: pl.CreatePostCommit,
+			}
+			if body.IssueFields != nil {
+				args.Labels = body.IssueFields.Labels
+				args.Assignees = body.IssueFields.Assignees
+			}
+			res, err := m.IssueCreate(r, args)
+			if err != nil {
+				return "", err
+			}
+			reference = res.Reference
+		} else {
+			args := &mergecmd.MergeRequestCreateArgs{
+				ID:                 id,
+				Title:              title,
+				Body:               string(body.Content),
+				ReplyHash:          replyHash,
+				Reactions:          body.Reactions,
+				Close:              body.Close,
+				PostCommentCreator: pl.CreatePostCommit,
+			}
+			if body.MergeRequestFields != nil {
+				args.Base = body.BaseBranch
+				args.BaseHash = body.BaseBranchHash
+				args.Target = body.TargetBranch
+				args.TargetHash = body.TargetBranchHash
+				args.Reviewers = body.Reviewers
+			}
+			res, err := m.MergeRequestCreate(r, args)
+			if err != nil {
+				return "", err
+			}
+			reference = res.Reference
+		}
+
+		hash, err := r.RefGet(reference)
+		if err != nil {
+			return "", err
+		}
+		hashes[comment.Hash] = hash
+	}
+
+	return reference, nil
+}
+
+// CloseMergeRequest closes a merge request.
+//  - name: The name of the repository.
+//  - reference: The full merge request reference name.
+func (m *RepoModule) CloseMergeRequest(name, reference string) util.Map {
+
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	curRefHash, err := r.RefGet(reference)
+	if err != nil && err == pl.ErrRefNotFound {
+		panic(se(404, StatusCodeMergeRequestNotFound, "reference", "merge request not found"))
+	}
+
+	// Clone the repository and the issue reference.
+	cloneOpts := pl.CloneOptions{Depth: 1, ReferenceName: reference}
+	if curRefHash == "" {
+		cloneOpts.ReferenceName = ""
+	}
+	cloned, _, err := m.cloneRepo(r, cloneOpts)
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", errors.Wrap(err, "failed to clone repo").Error()))
+	}
+
+	res, err := m.MergeRequestClose(cloned, &mergecmd.MergeReqCloseArgs{
+		Reference:          reference,
+		PostCommentCreator: pl.CreatePostCommit,
+		ReadPostBody:       pl.ReadPostBody,
+	})
+	if err != nil {
+		_ = cloned.Delete()
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	refHash, err := cloned.RefGet(res.Reference)
+	if err != nil {
+		_ = cloned.Delete()
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	// Add cloned repo path to temp repo manager.
+	tempRepoID := m.repoSrv.GetTempRepoManager().Add(cloned.GetPath())
+
+	return map[string]interface{}{
+		"hash":      refHash,
+		"reference": res.Reference,
+		"repoID":    tempRepoID,
+	}
+}
+
+// ReopenMergeRequest reopens a merge request.
+//  - name: The name of the repository.
+//  - reference: The full merge request reference name.
+func (m *RepoModule) ReopenMergeRequest(name, reference string) util.Map {
+
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	curRefHash, err := r.RefGet(reference)
+	if err != nil && err == pl.ErrRefNotFound {
+		panic(se(404, StatusCodeMergeRequestNotFound, "reference", "merge request not found"))
+	}
+
+	// Clone the repository and the merge request reference.
+	cloneOpts := pl.CloneOptions{Depth: 1, ReferenceName: reference}
+	if curRefHash == "" {
+		cloneOpts.ReferenceName = ""
+	}
+	cloned, _, err := m.cloneRepo(r, cloneOpts)
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", errors.Wrap(err, "failed to clone repo").Error()))
+	}
+
+	res, err := m.MergeRequestReopen(cloned, &mergecmd.MergeReqReopenArgs{
+		Reference:          reference,
+		PostCommentCreator: pl.CreatePostCommit,
+		ReadPostBody:       pl.ReadPostBody,
+	})
+	if err != nil {
+		_ = cloned.Delete()
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	refHash, err := cloned.RefGet(res.Reference)
+	if err != nil {
+		_ = cloned.Delete()
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	// Add cloned repo path to temp repo manager.
+	tempRepoID := m.repoSrv.GetTempRepoManager().Add(cloned.GetPath())
+
+	return map[string]interface{}{
+		"hash":      refHash,
+		"reference": res.Reference,
+		"repoID":    tempRepoID,
+	}
+}
+
+// ListMergeRequests returns a list of merge requests.
+// Merge requests archived by the repository's post retention policy are not included.
+//  - name: The name of the repository.
+//  - opts <map>: list options
+//  - opts.includeArchived: Include archived merge requests.
+func (m *RepoModule) ListMergeRequests(name string, opts ...modtypes.ListPostsOptions) []util.Map {
+
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	m.checkReadAccess(name)
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	issues, err := m.MergeRequestList(r, &mergecmd.MergeRequestListArgs{
+		PostGetter: pl.GetPosts,
+	})
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	if len(opts) == 0 || !opts[0].IncludeArchived {
+		issues = m.removeArchivedPosts(name, issues)
+	}
+
+	return util.StructSliceToMap(issues)
+}
+
+// removeArchivedPosts returns the posts that have not expired
+// according to the post retention policy of a repository.
+func (m *RepoModule) removeArchivedPosts(name string, posts pl.Posts) pl.Posts {
+	repoState := m.logic.RepoKeeper().Get(name)
+	policy := repoState.Config.PostRetention
+	if policy.IsEmpty() {
+		return posts
+	}
+
+	now := m.Now()
+	var res = pl.Posts{}
+	for _, post := range posts {
+		if p, ok := post.(*pl.Post); ok && policy.IsExpired(p.ClosedAt, now) {
+			continue
+		}
+		res = append(res, post)
+	}
+	return res
+}
+
+// ApplyPostRetention applies the post retention policy of a repository to
+// its issues and merge requests. Posts that have been closed for longer than
+// the retention period are archived or, if the policy's action is 'prune',
+// their references are deleted from the local repository.
+//  - name: The name of the repository.
+//
+// RETURN object <map>
+//  - archived <[]string>: The references of posts that are archived
+//  - pruned <[]string>: The references of posts that were deleted
+func (m *RepoModule) ApplyPostRetention(name string) util.Map {
+
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	repoState := m.logic.RepoKeeper().Get(name)
+	if repoState.IsEmpty() {
+		panic(se(404, StatusCodeRepoNotFound, "name", types.ErrRepoNotFound.Error()))
+	}
+
+	repoPath := m.logic.Config().GetRepoPath(name)
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeInvalidParam, "name", err.Error()))
+		}
+		panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+	}
+
+	var res = util.Map{"archived": []string{}, "pruned": []string{}}
+	policy := repoState.Config.PostRetention
+	if policy.IsEmpty() {
+		return res
+	}
+
+	// Prevent pushes from updating posts while they are being pruned
+	unlock := m.pushLocks.Lock(repoPath)
+	defer unlock()
+
+	posts, err := pl.GetPosts(r, func(ref plumbing.ReferenceName) bool {
+		return pl.IsPostReference(ref.String())
+	})
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	var expired []string
+	now := m.Now()
+	for _, post := range posts {
+		if policy.IsExpired(post.(*pl.Post).ClosedAt, now) {
+			expired = append(expired, post.GetName())
+		}
+	}
+	sort.Strings(expired)
+
+	if policy.Action != state.PostRetentionPrune {
+		res["archived"] = append(res["archived"].([]string), expired...)
+		return res
+	}
+
+	for _, ref := range expired {
+		if err := r.RefDelete(ref); err != nil {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+		res["pruned"] = append(res["pruned"].([]string), ref)
+	}
+	if len(expired) > 0 {
+		m.logic.Config().G().Bus.Emit(core.EvtRepoUpdated, name, repoPath)
+	}
+
+	return res
+}
+
+// Timeline event types
+const (
+	TimelineEventPush         = "push"
+	TimelineEventProposal     = "proposal"
+	TimelineEventIssue        = "issue"
+	TimelineEventMergeRequest = "mergeRequest"
+)
+
+// GetRepoTimeline returns the activity of a repository as a single feed of
+// events sorted from the most recent. Push events are read from the branch
+// activity index, proposal events from the repository state and issue and
+// merge request events from the local repository, if it exists.
+//  - name: The name of the repository.
+//  - opts <map>: timeline options
+//  - opts.types: Filter by event types (push, proposal, issue or mergeRequest).
+//  - opts.offset: The number of matching events to skip.
+//  - opts.limit: The maximum number of events to return. 0 means all.
+//
+// RETURN <[]map>
+//  - type <string>: The event type
+//  - time <int64>: The unix time of the event (0 if unknown)
+//  - reference <string>: The branch or post reference (push, issue and mergeRequest)
+//  - hash <string>: The hash of the branch tip (push) or first post commit (issue and mergeRequest)
+//  - title, author <string>, closed <bool>: The post details (issue and mergeRequest)
+//  - id <string>, action <int>, creator <string>, height <uint64>: The proposal details (proposal)
+func (m *RepoModule) GetRepoTimeline(name string, opts ...modtypes.RepoTimelineOptions) []util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	m.checkReadAccess(name)
+
+	var opt modtypes.RepoTimelineOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	var include = map[string]bool{}
+	for _, t := range opt.Types {
+		switch t {
+		case TimelineEventPush, TimelineEventProposal, TimelineEventIssue, TimelineEventMergeRequest:
+			include[t] = true
+		default:
+			panic(se(400, StatusCodeInvalidParam, "opts.types", fmt.Sprintf("unknown event type (%s)", t)))
+		}
+	}
+	if opt.Offset < 0 {
+		panic(se(400, StatusCodeInvalidParam, "opts.offset", "offset must be a non-negative number"))
+	}
+	if opt.Limit < 0 {
+		panic(se(400, StatusCodeInvalidParam, "opts.limit", "limit must be a non-negative number"))
+	}
+	wants := func(t string) bool { return len(include) == 0 || include[t] }
+
+	r := m.logic.RepoKeeper().Get(name)
+	if r.IsEmpty() {
+		panic(se(404, StatusCodeRepoNotFound, "name", types.ErrRepoNotFound.Error()))
+	}
+
+	var events = []util.Map{}
+
+	if wants(TimelineEventPush) {
+		activities, err := m.logic.RepoSyncInfoKeeper().GetBranchActivities(name)
+		if err != nil {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+		for branch, activity := range activities {
+			events = append(events, util.Map{
+				"type":      TimelineEventPush,
+				"time":      activity.LastCommitTime,
+				"reference": branch,
+				"hash":      activity.Hash,
+			})
+		}
+	}
+
+	if wants(TimelineEventProposal) {
+		for id, prop := range r.Proposals {
+			var t int64
+			bi, err := m.logic.SysKeeper().GetBlockInfo(int64(prop.Height.UInt64()))
+			if err != nil && err != keepers.ErrBlockInfoNotFound {
+				panic(se(500, StatusCodeServerErr, "", err.Error()))
+			} else if bi != nil {
+				t = bi.Time.Int64()
+			}
+			events = append(events, util.Map{
+				"type":    TimelineEventProposal,
+				"time":    t,
+				"id":      id,
+				"action":  prop.Action,
+				"creator": prop.Creator,
+				"height":  prop.Height.UInt64(),
+			})
+		}
+	}
+
+	if wants(TimelineEventIssue) || wants(TimelineEventMergeRequest) {
+		repoPath := m.logic.Config().GetRepoPath(name)
+		localRepo, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, repoPath)
+		if err != nil && err != git.ErrRepositoryNotExists {
+			panic(se(400, StatusCodeInvalidParam, "name", err.Error()))
+		} else if err == nil {
+			posts, err := pl.GetPosts(localRepo, func(ref plumbing.ReferenceName) bool {
+				return (wants(TimelineEventIssue) && pl.IsIssueReference(ref.String())) ||
+					(wants(TimelineEventMergeRequest) && pl.IsMergeRequestReference(ref.String()))
+			})
+			if err != nil {
+				panic(se(500, StatusCodeServerErr, "", err.Error()))
+			}
+			for _, post := range posts {
+				eventType := TimelineEventIssue
+				if pl.IsMergeRequestReference(post.GetName()) {
+					eventType = TimelineEventMergeRequest
+				}
+				closed, err := post.IsClosed()
+				if err != nil {
+					panic(se(500, StatusCodeServerErr, "", err.Error()))
+				}
+				comment := post.GetComment()
+				events = append(events, util.Map{
+					"type":      eventType,
+					"time":      comment.CreatedAt.Unix(),
+					"reference": post.GetName(),
+					"hash":      comment.Hash,
+					"title":     post.GetTitle(),
+					"author":    comment.Author,
+					"closed":    closed,
+				})
+			}
+		}
+	}
+
+	// Sort by time (most recent first); break ties by type, reference and ID
+	sort.Slice(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if a["time"].(int64) != b["time"].(int64) {
+			return a["time"].(int64) > b["time"].(int64)
+		}
+		if a["type"] != b["type"] {
+			return a["type"].(string) < b["type"].(string)
+		}
+		return fmt.Sprint(a["reference"], a["id"]) < fmt.Sprint(b["reference"], b["id"])
+	})
+
+	if opt.Offset >= len(events) {
+		return []util.Map{}
+	}
+	events = events[opt.Offset:]
+	if opt.Limit > 0 && opt.Limit < len(events) {
+		events = events[:opt.Limit]
+	}
+
+	return events
+}
+
+// Push signs and pushes a reference in a temporary repository identified by ID.
+//   params <map>
+//     - id: The unique temporary manager ID of the target repository.
+//     - reference: The full reference name of the commit, tag or note to be pushed.
+//     - hash: The latest hash of the reference.
+//     - value: Set transaction value (if applicable)
+//     - fee: Set the transaction fee
+//     - nonce: Set the next transaction nonce of the push key owner (optional).
+//     - priority: When true, raises the fee to the priority fee rate (optional).
+// 	 privateKeyOrPushToken: The private key or push token for signing the transaction
+// Blocks until push succeeds.
+// Returns the transaction hash on success.
+func (m *RepoModule) Push(params map[string]interface{}, privateKeyOrPushToken string) string {
+
+	o := objx.New(params)
+	tempRepoMgr := m.repoSrv.GetTempRepoManager()
+	path := tempRepoMgr.GetPath(o.Get("id").Str())
+	if path == "" {
+		panic(se(404, StatusCodeInvalidTempRepoID, "id", "id is expired or invalid"))
+	}
+
+	// Get the reference to be pushed and ensure it is valid.
+	reference := plumbing.ReferenceName(o.Get("reference").Str())
+	if !reference.IsBranch() && !reference.IsNote() && !reference.IsTag() && !pl.IsMetaReference(reference.String()) {
+		panic(se(400, StatusCodeInvalidReferenceName, "reference", "reference name is not valid"))
+	}
+
+	// Private key or push token is required
+	if privateKeyOrPushToken == "" {
+		panic(se(400, StatusCodeInvalidPrivateKey, "privateKeyOrPushToken",
+			"private key is required"))
+	}
+
+	// Attempt to decode the privateKeyOrPushToken as though it is a push token.
+	// If we succeed, extract the push key from the token
+	var pushKeyID string
+	if txDetail, _ := pushtoken.Decode(privateKeyOrPushToken); txDetail != nil {
+		pushKeyID = txDetail.PushKeyID
+	}
+
+	// If the push key is not already known, we assume privateKeyOrPushToken is
+	// a base58 encoded private key and as such we attempt to decode it.
+	var privKey *ed25519.PrivKey
+	var err error
+	if pushKeyID == "" {
+		privKey, err = ed25519.PrivKeyFromBase58(privateKeyOrPushToken)
+		if err != nil {
+			panic(se(400, StatusCodeInvalidPrivateKey, "privateKeyOrPushToken",
+				"private key or push token is not a valid"))
+		}
+		pushKeyID = privKey.Wrap().PushAddr().String()
+	}
+
+	// The fee of a push token cannot be changed
+	if privKey == nil && o.Get("priority").Bool() {
+		panic(se(400, StatusCodeInvalidParam, "priority", "priority push requires a private key"))
+	}
+
+	// Serialize pushes of the same repository to prevent concurrent
+	// calls from racing on the repository's config.
+	unlock := m.pushLocks.Lock(path)
+	defer unlock()
+
+	// Get the working repository
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, path)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeRepoNotFound, "name", err.Error()))
+		}
+		panic(se(500, StatusCodeServerErr, "name", err.Error()))
+	}
+
+	// Create push token if a private key was provided
+	token := privateKeyOrPushToken
+	if privKey != nil {
+		txDetail := &remotetypes.TxDetail{
+			RepoName:  r.GetName(),
+			Fee:       util.String(o.Get("fee").Str()),
+			Value:     util.String(o.Get("value").Str()),
+			Nonce:     cast.ToUint64(o.Get("nonce").Str()),
+			PushKeyID: pushKeyID,
+			Reference: reference.String(),
+			Head:      o.Get("hash").Str(),
+		}
+
+		// For a priority push, ensure the fee is not below the priority fee
+		if o.Get("priority").Bool() {
+			priorityFee := m.getPriorityPushFee(r, reference, o.Get("hash").Str())
+			if txDetail.Fee.Empty() || txDetail.Fee.Decimal().LessThan(priorityFee) {
+				txDetail.Fee = util.String(priorityFee.String())
+			}
+		}
+
+		// Get the next nonce, if not set
+		if txDetail.Nonce == 0 {
+			senderAcct := m.logic.AccountKeeper().Get(privKey.Wrap().Addr())
+			txDetail.Nonce = senderAcct.Nonce.UInt64() + 1
+		}
+
+		// Create a push token
+		token = pushtoken.MakeFromKey(privKey.Wrap(), txDetail)
+	}
+
+	// Construct and set the remote address.
+	// The remote (origin) must point to a remote server.
+	remoteAddr := config.DefaultRemoteServerAddress
+	if remoteAddr[:1] == ":" {
+		remoteAddr = "127.0.0.1" + remoteAddr
+	}
+	url := fmt.Sprintf("http://%s/r/%s", remoteAddr, r.GetName())
+	curConfig, err := r.Config()
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+	curConfig.Remotes["origin"] = &gogitcfg.RemoteConfig{Name: "origin", URLs: []string{url}}
+	if err = r.SetConfig(curConfig); err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	// Push to remote
+	progress, err := r.Push(pl.PushOptions{
+		RefSpec: fmt.Sprintf("+%s:%s", reference, reference),
+		Token:   token,
+	})
+	if err != nil {
+		panic(se(500, StatusCodePushFailure, "", err.Error()))
+	}
+
+	_ = tempRepoMgr.Remove(o.Get("id").Str())
+
+	hash := strings.Split(progress.String(), "hash: ")[1]
+	hash = strings.TrimSpace(stripansi.Strip(hash))
+	return hash
+}
+
+// getPriorityPushFee returns the fee a push of a reference must pay to meet
+// the priority fee rate. The size of the push is estimated from the size of
+// the pushed objects and the push note.
+func (m *RepoModule) getPriorityPushFee(r pl.LocalRepo, reference plumbing.ReferenceName, newHash string) decimal.Decimal {
+	var err error
+	if newHash == "" {
+		if newHash, err = r.RefGet(reference.String()); err != nil {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+	}
+
+	oldHash := plumbing.ZeroHash.String()
+	if reference.IsBranch() {
+		trackingRef := plumbing.NewRemoteReferenceName("origin", reference.Short())
+		if hash, err := r.RefGet(trackingRef.String()); err == nil {
+			oldHash = hash
+		} else if err != pl.ErrRefNotFound {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+	}
+
+	note := &pushtypes.Note{TargetRepo: r, References: []*pushtypes.PushedReference{
+		{Name: reference.String(), OldHash: oldHash, NewHash: newHash},
+	}}
+	objSize, err := m.GetSizeOfObjects(note)
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	size := decimal.New(int64(objSize+note.GetEcoSize()), 0)
+	return params.PriorityFeePerByte.Mul(size)
+}
+
+// EstimatePushSize estimates the total size of objects that will be transferred
+// when the branches of a temporary repository identified by ID are pushed.
+// A branch is only considered if it differs from its remote-tracking branch.
+//  - id: The unique temporary manager ID of the target repository.
+//
+// RETURNS object <map>
+//  - size <uint64>: The total size of the objects
+//  - references <[]string>: The references that will be pushed
+func (m *RepoModule) EstimatePushSize(id string) util.Map {
+
+	path := m.repoSrv.GetTempRepoManager().GetPath(id)
+	if path == "" {
+		panic(se(404, StatusCodeInvalidTempRepoID, "id", "id is expired or invalid"))
+	}
+
+	// Get the working repository
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, path)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeRepoNotFound, "name", err.Error()))
+		}
+		panic(se(500, StatusCodeServerErr, "name", err.Error()))
+	}
+
+	note := &pushtypes.Note{TargetRepo: r, References: m.getChangedBranches(r)}
+	var pushed = []string{}
+	for _, ref := range note.References {
+		pushed = append(pushed, ref.Name)
+	}
+
+	size, err := m.GetSizeOfObjects(note)
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return util.Map{
+		"size":       size,
+		"references": pushed,
+	}
+}
+
+// getChangedBranches returns the branches of a temporary repository that
+// have changed since the repository was cloned. The remote-tracking branch
+// is used as the old hash of the branch.
+func (m *RepoModule) getChangedBranches(r pl.LocalRepo) (changed pushtypes.PushedReferences) {
+	refs, err := r.GetReferences()
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	for _, ref := range refs {
+		if !ref.IsBranch() {
+			continue
+		}
+
+		newHash, err := r.RefGet(ref.String())
+		if err != nil {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+
+		oldHash := plumbing.ZeroHash.String()
+		trackingRef := plumbing.NewRemoteReferenceName("origin", ref.Short())
+		if hash, err := r.RefGet(trackingRef.String()); err == nil {
+			oldHash = hash
+		} else if err != pl.ErrRefNotFound {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+
+		if newHash == oldHash {
+			continue
+		}
+
+		changed = append(changed, &pushtypes.PushedReference{
+			Name:    ref.String(),
+			OldHash: oldHash,
+			NewHash: newHash,
+		})
+	}
+
+	return
+}
+
+// ComputePushID computes a deterministic ID for the change set that will be
+// pushed from the branches of a temporary repository identified by ID.
+// The ID is a hash of the changed references, their new hashes and the
+// objects each reference will transfer; identical staged content always
+// yields the same ID, independent of the order references were updated.
+//  - id: The unique temporary manager ID of the target repository.
+//
+// RETURNS object <map>
+//  - id <string>: The push ID
+//  - references <[]string>: The references included in the ID
+func (m *RepoModule) ComputePushID(id string) util.Map {
+
+	path := m.repoSrv.GetTempRepoManager().GetPath(id)
+	if path == "" {
+		panic(se(404, StatusCodeInvalidTempRepoID, "id", "id is expired or invalid"))
+	}
+
+	// Get the working repository
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, path)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeRepoNotFound, "name", err.Error()))
+		}
+		panic(se(500, StatusCodeServerErr, "name", err.Error()))
+	}
+
+	changed := m.getChangedBranches(r)
+	sort.Slice(changed, func(i, j int) bool { return changed[i].Name < changed[j].Name })
+
+	var buf bytes.Buffer
+	var pushed = []string{}
+	for _, ref := range changed {
+		var objects []string
+		err := pl.WalkBack(r, ref.NewHash, ref.OldHash, func(hash string) error {
+			objects = append(objects, hash)
+			return nil
+		})
+		if err != nil {
+			panic(se(500, StatusCodeServerErr, "", err.Error()))
+		}
+		sort.Strings(objects)
+
+		buf.WriteString(ref.Name + "\n" + ref.NewHash + "\n")
+		for i, hash := range objects {
+			if i > 0 && objects[i-1] == hash {
+				continue
+			}
+			buf.WriteString(hash + "\n")
+		}
+		pushed = append(pushed, ref.Name)
+	}
+
+	return util.Map{
+		"id":         util.ToHex(crypto.Blake2b256(buf.Bytes())),
+		"references": pushed,
+	}
+}
+
+// emptyTreeHash is the hash of a git tree with no entries
+const emptyTreeHash = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+// PreviewPostChange returns the patch a staged post (issue or merge request)
+// reference of a temporary repository identified by ID would introduce when
+// pushed. The staged reference is diffed against its remote-tracking
+// reference or, for a new post, against an empty tree.
+//  - id: The unique temporary manager ID of the target repository.
+//  - reference: The full reference name of the staged post.
+//
+// RETURNS <string>: The unified diff of the change
+func (m *RepoModule) PreviewPostChange(id, reference string) string {
+
+	path := m.repoSrv.GetTempRepoManager().GetPath(id)
+	if path == "" {
+		panic(se(404, StatusCodeInvalidTempRepoID, "id", "id is expired or invalid"))
+	}
+
+	ref := plumbing.ReferenceName(reference)
+	if !ref.IsBranch() {
+		panic(se(400, StatusCodeInvalidReferenceName, "reference", "reference name is not valid"))
+	}
+
+	// Get the working repository
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, path)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeRepoNotFound, "name", err.Error()))
+		}
+		panic(se(500, StatusCodeServerErr, "name", err.Error()))
+	}
+
+	newHash, err := r.RefGet(ref.String())
+	if err != nil {
+		if err == pl.ErrRefNotFound {
+			panic(se(404, StatusCodeReferenceNotFound, "reference", "reference not found"))
+		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	// Use the remote-tracking reference as the previous state of the post.
+	// A new post has no previous state, so it is compared to an empty tree.
+	oldHash := emptyTreeHash
+	trackingRef := plumbing.NewRemoteReferenceName("origin", ref.Short())
+	if hash, err := r.RefGet(trackingRef.String()); err == nil {
+		oldHash = hash
+	} else if err != pl.ErrRefNotFound {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	if oldHash == newHash {
+		return ""
+	}
+
+	patch, err := r.DiffCommits(oldHash, newHash)
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return patch
+}
+
+// SnapshotTempRepo returns a serialized form of a temporary repository
+// identified by ID, including its staged references and working tree.
+// The snapshot can be restored with RestoreTempRepo.
+//  - id: The unique temporary manager ID of the target repository.
+//
+// RETURNS <base64 string>: The snapshot
+func (m *RepoModule) SnapshotTempRepo(id string) string {
+
+	path := m.repoSrv.GetTempRepoManager().GetPath(id)
+	if path == "" {
+		panic(se(404, StatusCodeInvalidTempRepoID, "id", "id is expired or invalid"))
+	}
+
+	var buf = bytes.NewBuffer(nil)
+	if err := temprepomgr.WriteSnapshot(path, buf); err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+// RestoreTempRepo restores a temporary repository from a snapshot
+// created by SnapshotTempRepo and registers it with the temporary
+// repository manager.
+//  - data: The base64 encoded snapshot.
+//
+// RETURNS <string>: The new temporary manager ID of the repository
+func (m *RepoModule) RestoreTempRepo(data string) string {
+
+	if data == "" {
+		panic(se(400, StatusCodeInvalidParam, "data", "snapshot is required"))
+	}
+
+	bz, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		panic(se(400, StatusCodeInvalidParam, "data", "snapshot is not valid base64"))
+	}
+
+	path, err := temprepomgr.ReadSnapshot(bytes.NewReader(bz))
+	if err != nil {
+		if err == temprepomgr.ErrInvalidSnapshot {
+			panic(se(400, StatusCodeInvalidParam, "data", err.Error()))
+		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	return m.repoSrv.GetTempRepoManager().Add(path)
+}
+
+// GetPushedRefs returns the references modified by a push transaction.
+// The push note is read from the chain or, if the transaction is not yet
+// in a block, from the push pool.
+//  - hash: The push transaction hash or push note ID
+//
+// RETURNS <[]map>
+//  - name <string>: The full reference name
+//  - oldHash <string>: The hash of the reference before the push
+//  - newHash <string>: The hash of the reference after the push
+func (m *RepoModule) GetPushedRefs(hash string) []util.Map {
+
+	bz, err := util.FromHex(hash)
+	if err != nil {
+		panic(se(400, StatusCodeInvalidParam, "hash", "invalid transaction hash"))
+	}
+
+	var note pushtypes.PushNote
+	tx, _, err := m.service.GetTx(context.Background(), bz, m.logic.Config().IsLightNode())
+	if err != nil && err != types.ErrTxNotFound {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	} else if tx != nil {
+		pushTx, ok := tx.(*txns.TxPush)
+		if !ok {
+			panic(se(400, StatusCodeInvalidParam, "hash", "transaction is not a push transaction"))
+		}
+		note = pushTx.Note
+	} else if pooled := m.repoSrv.GetPushPool().Get(hash); pooled != nil {
+		note = pooled
+	}
+
+	if note == nil {
+		panic(se(404, StatusCodeTxNotFound, "hash", types.ErrTxNotFound.Error()))
+	}
+
+	var refs = []util.Map{}
+	for _, ref := range note.GetPushedReferences() {
+		refs = append(refs, util.Map{
+			"name":    ref.Name,
+			"oldHash": ref.OldHash,
+			"newHash": ref.NewHash,
+		})
+	}
+
+	return refs
+}
+
+// GetPushEndorsements returns the endorsements of a push transaction.
+// The endorsements are read from the push transaction stored on the chain.
+// Endorsers' BLS signatures are usually replaced by the transaction's
+// aggregated signature, in which case the signature field is empty.
+//  - hash: The push transaction hash
+//
+// RETURNS <[]map>
+//  - pubKey <string>: The public key of the endorser
+//  - refs <[]string>: The endorsed hashes of the pushed references
+//  - sig <string>: The BLS signature of the endorser
+func (m *RepoModule) GetPushEndorsements(hash string) []util.Map {
+
+	bz, err := util.FromHex(hash)
+	if err != nil {
+		panic(se(400, StatusCodeInvalidParam, "hash", "invalid transaction hash"))
+	}
+
+	tx, _, err := m.service.GetTx(context.Background(), bz, m.logic.Config().IsLightNode())
+	if err != nil {
+		if err == types.ErrTxNotFound {
+			panic(se(404, StatusCodeTxNotFound, "hash", err.Error()))
+		}
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	pushTx, ok := tx.(*txns.TxPush)
+	if !ok {
+		panic(se(400, StatusCodeInvalidParam, "hash", "transaction is not a push transaction"))
+	}
+
+	// Only the first endorsement carries the endorsed references
+	// since all endorsements endorse the same references.
+	var refs = []string{}
+	if len(pushTx.Endorsements) > 0 {
+		for _, ref := range pushTx.Endorsements[0].References {
+			refs = append(refs, util.ToHex(ref.Hash, true))
+		}
+	}
+
+	var endorsements = []util.Map{}
+	for _, end := range pushTx.Endorsements {
+		var sig string
+		if len(end.SigBLS) > 0 {
+			sig = util.ToHex(end.SigBLS)
+		}
+		endorsements = append(endorsements, util.Map{
+			"pubKey": ed25519.MustPubKeyFromBytes(end.EndorserPubKey.Bytes()).Base58(),
+			"refs":   refs,
+			"sig":    sig,
+		})
+	}
+
+	return endorsements
+}
+
+// GetPendingPushes returns the push notes in the push pool that have not yet
+// received a quorum of endorsements. Notes are returned oldest first.
+//
+// RETURNS <[]map>
+//  - id <string>: The ID of the push note
+//  - repo <string>: The name of the target repository
+//  - namespace <string>: The namespace of the target repository
+//  - pusher <string>: The address of the pusher
+//  - refs <[]string>: The names of the pushed references
+//  - age <number>: The number of seconds the note has been in the pool
+//  - endorsements <number>: The number of endorsements received
+//  - quorum <number>: The number of endorsements required
+func (m *RepoModule) GetPendingPushes() []util.Map {
+	var res = []util.Map{}
+	now := time.Now()
+	for _, pending := range m.repoSrv.GetPushPool().GetUnendorsed() {
+		noteID := pending.Note.ID().String()
+		var refs = []string{}
+		for _, ref := range pending.Note.References {
+			refs = append(refs, ref.Name)
+		}
+		res = append(res, util.Map{
+			"id":           noteID,
+			"repo":         pending.Note.RepoName,
+			"namespace":    pending.Note.Namespace,
+			"pusher":       pending.Note.PusherAddress.String(),
+			"refs":         refs,
+			"age":          int64(now.Sub(pending.TimeAdded).Seconds()),
+			"endorsements": len(m.repoSrv.GetNoteEndorsements(noteID)),
+			"quorum":       params.PushEndorseQuorumSize,
+		})
+	}
+	return res
+}
+
+// ResignRefs creates push tokens signed by a new push key for the tip of every
+// branch and tag of a temporary repository identified by ID. Each token is
+// checked against the object it points to and can be passed to Push to submit
+// the reference. Nonces are assigned in sequence, one per signed reference.
+//   params <map>
+//     - id: The unique temporary manager ID of the target repository.
+//     - value: Set transaction value (if applicable)
+//     - fee: Set the transaction fee
+//     - nonce: Set the next transaction nonce of the push key owner (optional).
+//   privateKey: The new push key for signing the push tokens
+//
+// RETURNS <[]map>
+//  - reference <string>: The reference name
+//  - hash <string>: The hash of the reference
+//  - success <bool>: Indicates whether the reference was signed
+//  - token <string>: The push token (only if success is true)
+//  - nonce <uint64>: The nonce of the push token (only if success is true)
+//  - error <string>: The reason signing failed (only if success is false)
+func (m *RepoModule) ResignRefs(params map[string]interface{}, privateKey string) []util.Map {
+
+	o := objx.New(params)
+	path := m.repoSrv.GetTempRepoManager().GetPath(o.Get("id").Str())
+	if path == "" {
+		panic(se(404, StatusCodeInvalidTempRepoID, "id", "id is expired or invalid"))
+	}
+
+	privKey, err := ed25519.PrivKeyFromBase58(privateKey)
+	if err != nil {
+		panic(se(400, StatusCodeInvalidPrivateKey, "privateKey", "private key is not valid"))
+	}
+	key := privKey.Wrap()
+
+	// Get the working repository
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, path)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeRepoNotFound, "name", err.Error()))
+		}
+		panic(se(500, StatusCodeServerErr, "name", err.Error()))
+	}
+
+	refs, err := r.GetReferences()
+	if err != nil {
+		panic(se(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	// Get the next nonce, if not set
+	nonce := cast.ToUint64(o.Get("nonce").Str())
+	if nonce == 0 {
+		senderAcct := m.logic.AccountKeeper().Get(key.Addr())
+		nonce = senderAcct.Nonce.UInt64() + 1
+	}
+
+	var res = []util.Map{}
+	for _, ref := range refs {
+		if !ref.IsBranch() && !ref.IsTag() {
+			continue
+		}
+
+		result := util.Map{"reference": ref.String(), "success": false}
+		res = append(res, result)
+
+		hash, err := r.RefGet(ref.String())
+		if err != nil {
+			result["error"] = err.Error()
+			continue
+		}
+		result["hash"] = hash
+
+		txDetail := &remotetypes.TxDetail{
+			RepoName:  r.GetName(),
+			Fee:       util.String(o.Get("fee").Str()),
+			Value:     util.String(o.Get("value").Str()),
+			Nonce:     nonce,
+			PushKeyID: key.PushAddr().String(),
+			Reference: ref.String(),
+			Head:      hash,
+		}
+
+		// Ensure the token is acceptable for the object the reference points to.
+		// Lightweight tags point directly to a commit.
+		if err = checkSignedRef(r, ref, txDetail); err != nil {
+			result["error"] = err.Error()
+			continue
+		}
+
+		result["token"] = pushtoken.MakeFromKey(key, txDetail)
+		result["nonce"] = nonce
+		result["success"] = true
+		nonce++
+	}
+
+	return res
+}
+
+// SyncFromUpstream fetches objects of the branches and tags of a fork's
+// upstream repository from the DHT. Each reference is staged in the fork
+// under refs/upstream/ (e.g refs/heads/master -> refs/upstream/heads/master).
+// References already staged at the upstream's current hash are skipped.
+//  - name: The name of the fork
+//
+// RETURNS object <map>
+//  - upstream <string>: The name of the upstream repository
+//  - references <[]map>: The synced references
+//     - reference <string>: The upstream reference name
+//     - staged <string>: The reference the upstream reference is staged as
+//     - hash <string>: The current hash of the upstream reference
+//     - error <string>: The reason fetching failed (only if it failed)
+func (m *RepoModule) SyncFromUpstream(name string) util.Map {
+	if name == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repo name is required"))
+	}
+
+	fork := m.logic.RepoKeeper().Get(name)
+	if fork.IsEmpty() {
+		panic(se(404, StatusCodeRepoNotFound, "name", types.ErrRepoNotFound.Error()))
+	}
+
+	if fork.Config == nil || fork.Config.Upstream == "" {
+		panic(se(400, StatusCodeInvalidParam, "name", "repository is not a fork"))
+	}
+
+	upstream := m.logic.RepoKeeper().Get(fork.Config.Upstream)
+	if upstream.IsEmpty() {
+		panic(se(404, StatusCodeRepoNotFound, "name", "upstream repository not found"))
+	}
+
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			panic(se(404, StatusCodeRepoNotFound, "name", err.Error()))
+		}
+		panic(se(500, StatusCodeServerErr, "name", err.Error()))
+	}
+
+	// Sort the upstream references for a deterministic sync order
+	var refNames []string
+	for ref := range upstream.References {
+		if pl.IsBranch(ref) || pl.IsTag(ref) {
+			refNames = append(refNames, ref)
+		}
+	}
+	sort.Strings(refNames)
+
+	var synced = []util.Map{}
+	for _, ref := range refNames {
+		hash := upstream.References.Get(ref).Hash.HexStr(true)
+		staged := pl.MakeUpstreamReference(ref)
+		result := util.Map{"reference": ref, "staged": staged, "hash": hash}
+
+		// Skip references already staged at the current upstream hash
+		if curHash, err := r.RefGet(staged); err == nil && curHash == hash {
+			continue
+		}
+
+		if err = m.fetchRefObjects(r, fork.Config.Upstream, name, ref, hash); err != nil {
+			result["error"] = err.Error()
+			synced = append(synced, result)
+			continue
+		}
+
+		if err = r.RefUpdate(staged, hash); err != nil {
+			result["error"] = err.Error()
+		}
+		synced = append(synced, result)
+	}
+
+	return util.Map{
+		"upstream":   fork.Config.Upstream,
+		"references": synced,
+	}
+}
+
+// fetchRefObjects fetches the objects of a reference of a repository from
+// the DHT and unpacks them into a local repository.
+//  - r: The local repository to unpack the objects into
+//  - repoName: The name of the repository to fetch from
+//  - localName: The name of the local repository
+//  - ref: The name of the reference
+//  - hash: The hash of the reference tip
+func (m *RepoModule) fetchRefObjects(r pl.LocalRepo, repoName, localName, ref, hash string) error {
+	args := dht.GetAncestorArgs{
+		RepoName:         repoName,
+		LocalRepoName:    localName,
+		StartHash:        pl.HashToBytes(hash),
+		ExcludeEndCommit: true,
+		ResultCB: func(packfile io.ReadSeekerCloser, _ string) error {
+			defer packfile.Close()
+			return m.PackToRepoUnpacker(r, packfile)
+		},
+	}
+
+	ctx, cn := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cn()
+	streamer := m.repoSrv.GetDHT().ObjectStreamer()
+	if pl.IsTag(ref) {
+		_, err := streamer.GetTaggedCommitWithAncestors(ctx, args)
+		return err
+	}
+	_, err := streamer.GetCommitWithAncestors(ctx, args)
+	return err
+}
+
+// cloneFromDHT creates a local copy of a repository by fetching the objects
+// of its branches and tags from the DHT. The local repository is created if
+// it does not exist. References already at their current hash are skipped.
+//  - name: The name of the repository
+func (m *RepoModule) cloneFromDHT(name string) error {
+	repoState := m.logic.RepoKeeper().Get(name)
+	if repoState.IsEmpty() {
+		return types.ErrRepoNotFound
+	}
+
+	if !m.repoSrv.HasRepository(name) {
+		if err := m.repoSrv.InitRepository(name); err != nil {
+			return errors.Wrap(err, "failed to create local repository")
+		}
+	}
+
+	r, err := m.GetLocalRepo(m.logic.Config().Node.GitBinPath, m.logic.Config().GetRepoPath(name))
+	if err != nil {
+		return err
+	}
+
+	var refNames []string
+	for ref := range repoState.References {
+		if pl.IsBranch(ref) || pl.IsTag(ref) {
+			refNames = append(refNames, ref)
+		}
+	}
+	sort.Strings(refNames)
+
+	// The objects and references of the repository have changed;
+	// Cached handles and stats of the repository must be reloaded.
+	var updated bool
+	defer func() {
+		if updated {
+			m.logic.Config().G().Bus.Emit(core.EvtRepoUpdated, name, r.GetPath())
+		}
+	}()
+
+	for _, ref := range refNames {
+		hash := repoState.References.Get(ref).Hash.HexStr(true)
+		if curHash, err := r.RefGet(ref); err == nil && curHash == hash {
+			continue
+		}
+		updated = true
+		if err = m.fetchRefObjects(r, name, name, ref, hash); err != nil {
+			return errors.Wrapf(err, "failed to fetch %s", ref)
+		}
+		if err = r.RefUpdate(ref, hash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CloneRepos clones several repositories from the DHT concurrently.
+// All clones share the node's object streamer and its provider tracker.
+//  - names: The names of the repositories
+//  - [opts] <map>
+//  - [opts.parallelism] <number>: The max number of repositories to clone
+//    at the same time (default: repo.cloneParallelism config).
+//
+// RETURNS []<map>
+//  - name <string>: The name of the repository
+//  - success <bool>: Whether the repository was cloned
+//  - error <string>: The reason the clone failed (only if it failed)
+func (m *RepoModule) CloneRepos(names []string, opts ...modtypes.CloneReposOptions) []util.Map {
+	if len(names) == 0 {
+		panic(se(400, StatusCodeInvalidParam, "names", "at least one repo name is required"))
+	}
+	for i, name := range names {
+		if name == "" {
+			panic(se(400, StatusCodeInvalidParam, fmt.Sprintf("names[%d]", i), "repo name is required"))
+		}
+	}
+
+	parallelism := m.logic.Config().Repo.CloneParallelism
+	if len(opts) > 0 && opts[0].Parallelism != 0 {
+		parallelism = opts[0].Parallelism
+	}
+	if parallelism < 0 {
+		panic(se(400, StatusCodeInvalidParam, "opts.parallelism", "parallelism must not be negative"))
+	}
+	if parallelism == 0 {
+		parallelism = 1
+	}
+
+	res := make([]util.Map, len(names))
+	sem := make(chan struct{}, parallelism)
+	wg := sync.WaitGroup{}
+	for i, name := range names {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, name string) {
+			defer func() { <-sem; wg.Done() }()
+			res[i] = util.Map{"name": name, "success": true}
+
+			// A failed clone must not crash the node or the other clones
+			defer func() {
+				if rcv := recover(); rcv != nil {
+					res[i]["success"] = false
+					res[i]["error"] = fmt.Sprintf("clone failed: %v", rcv)
+				}
+			}()
+
+			if err := m.cloneFromDHT(name); err != nil {
+				res[i]["success"] = false
+				res[i]["error"] = err.Error()
+			}
+		}(i, name)
+	}
+	wg.Wait()
+
+	return res
+}
+
+// checkSignedRef checks a reference's tip object against a push transaction detail
+func checkSignedRef(r pl.LocalRepo, ref plumbing.ReferenceName, txDetail *remotetypes.TxDetail) error {
+	hash := plumbing.NewHash(txDetail.Head)
+	if ref.IsTag() {
+		tag, err := r.TagObject(hash)
+		if err == nil {
+			return validation.CheckAnnotatedTag(tag, txDetail, nil)
+		} else if err != plumbing.ErrObjectNotFound {
+			return err
+		}
+	}
+
+	commit, err := r.CommitObject(hash)
+	if err != nil {
+		return err
+	}
+
+	return validation.CheckCommit(commit, txDetail, nil)
+}
+
+// DecodePushToken decodes a push token, verifies its signature and returns
+// the transaction detail embedded in it.
+//  - token: The push token
+//  - [pushKeyPubKey]: The public key of the push key that signed the token.
+//    When not provided, the public key of the push key is fetched from the
+//    network state (required in attach mode).
+func (m *RepoModule) DecodePushToken(token string, pushKeyPubKey ...string) util.Map {
+	if token == "" {
+		panic(se(400, StatusCodeInvalidParam, "token", "token is required"))
+	}
+
+	txDetail, err := pushtoken.Decode(token)
+	if err != nil {
+		panic(se(400, StatusCodeInvalidParam, "token", err.Error()))
+	}
+
+	if err = validation.CheckTxDetailSanity(txDetail, -1); err != nil {
+		if fe, ok := err.(*errors2.BadFieldError); ok {
+			panic(se(400, StatusCodeInvalidParam, fe.Field, fe.Msg))
+		}
+		panic(se(400, StatusCodeInvalidParam, "token", err.Error()))
+	}
+
+	var pk *ed25519.PubKey
+	if len(pushKeyPubKey) > 0 && pushKeyPubKey[0] != "" {
+		pk, err = ed25519.PubKeyFromBase58(pushKeyPubKey[0])
+		if err != nil {
+			panic(se(400, StatusCodeInvalidParam, "pushKeyPubKey", "public key is not valid"))
+		}
+		if pk.PushAddr().String() != txDetail.PushKeyID {
+			panic(se(400, StatusCodeInvalidParam, "pushKeyPubKey", "public key does not match the token push key"))
+		}
+	} else if m.IsAttached() {
+		panic(se(400, StatusCodeInvalidParam, "pushKeyPubKey", "public key is required in attach mode"))
+	} else {
+		pushKey := m.logic.PushKeyKeeper().Get(txDetail.PushKeyID)
+		if pushKey.IsNil() {
+			panic(se(404, StatusCodePushKeyNotFound, "pkID", types.ErrPushKeyUnknown.Error()))
+		}
+		pk = ed25519.MustPubKeyFromBytes(pushKey.PubKey.Bytes())
+	}
+
+	if ok, err := pk.Verify(txDetail.BytesNoSig(), txDetail.SignatureToByte()); err != nil || !ok {
+		panic(se(400, StatusCodeInvalidParam, "sig", "signature is not valid"))
+	}
+
+	res := util.ToMap(txDetail)
+	res["verified"] = true
+	return res
 }
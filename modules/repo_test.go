@@ -2,23 +2,47 @@ package modules_test
 
 import (
 	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
-
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/AlekSi/pointer"
 	"github.com/go-git/go-git/v5"
 	config2 "github.com/go-git/go-git/v5/config"
 	plumbing2 "github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/golang/mock/gomock"
 	"github.com/make-os/kit/cmd/issuecmd"
 	"github.com/make-os/kit/cmd/mergecmd"
 	"github.com/make-os/kit/config"
 	"github.com/make-os/kit/crypto/ed25519"
+	"github.com/make-os/kit/logic/keepers"
 	"github.com/make-os/kit/mocks"
 	mocks2 "github.com/make-os/kit/mocks/rpc"
 	"github.com/make-os/kit/modules"
 	"github.com/make-os/kit/modules/types"
+	"github.com/make-os/kit/net/dht"
+	"github.com/make-os/kit/params"
+	"github.com/make-os/kit/pkgs/tree"
 	"github.com/make-os/kit/remote/plumbing"
+	pushtypes "github.com/make-os/kit/remote/push/types"
+	"github.com/make-os/kit/remote/repo"
+	"github.com/make-os/kit/remote/temprepomgr"
+	testutil2 "github.com/make-os/kit/remote/testutil"
 	remotetypes "github.com/make-os/kit/remote/types"
+	"github.com/make-os/kit/remote/validation"
 	"github.com/make-os/kit/testutil"
+	tickettypes "github.com/make-os/kit/ticket/types"
+	types2 "github.com/make-os/kit/types"
 	"github.com/make-os/kit/types/api"
 	"github.com/make-os/kit/types/constants"
 	"github.com/make-os/kit/types/core"
@@ -27,13 +51,39 @@ import (
 	"github.com/make-os/kit/util"
 	"github.com/make-os/kit/util/crypto"
 	"github.com/make-os/kit/util/errors"
+	io2 "github.com/make-os/kit/util/io"
 	"github.com/make-os/kit/util/pushtoken"
+	"github.com/mr-tron/base58"
+	"github.com/olebedev/emitter"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/robertkrimen/otto"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
+// cloneOptionsMatcher matches clone options, ignoring their context
+type cloneOptionsMatcher struct {
+	opts plumbing.CloneOptions
+}
+
+func (c cloneOptionsMatcher) Matches(x interface{}) bool {
+	opts, ok := x.(plumbing.CloneOptions)
+	if !ok {
+		return false
+	}
+	opts.Context = nil
+	return opts == c.opts
+}
+
+func (c cloneOptionsMatcher) String() string {
+	return fmt.Sprintf("is equal to %+v, ignoring context", c.opts)
+}
+
+func cloneOptionsEq(opts plumbing.CloneOptions) gomock.Matcher {
+	return cloneOptionsMatcher{opts: opts}
+}
+
 var _ = Describe("RepoModule", func() {
 	var m *modules.RepoModule
 	var ctrl *gomock.Controller
@@ -72,6 +122,7 @@ var _ = Describe("RepoModule", func() {
 	})
 
 	AfterEach(func() {
+		m.Close()
 		ctrl.Finish()
 	})
 
@@ -300,6 +351,15 @@ var _ = Describe("RepoModule", func() {
 			Expect(res["balance"]).To(Equal(util.String("100")))
 		})
 
+		It("should include upstream when repo is a fork", func() {
+			repo := state.BareRepository()
+			repo.Balance = "100"
+			repo.Config.Upstream = "upstream1"
+			mockRepoKeeper.EXPECT().Get("repo1", uint64(0)).Return(repo)
+			res := m.Get("repo1", types.GetOptions{Height: 0})
+			Expect(res["upstream"]).To(Equal("upstream1"))
+		})
+
 		It("should panic when repo does not exist", func() {
 			repo := state.BareRepository()
 			mockRepoKeeper.EXPECT().Get("repo1", uint64(0)).Return(repo)
@@ -391,1707 +451,6399 @@ var _ = Describe("RepoModule", func() {
 		})
 	})
 
-	Describe(".Update", func() {
-		It("should panic when unable to decode params", func() {
-			params := map[string]interface{}{"config": 123}
-			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "1 error(s) decoding:\n\n* 'config' expected a map, got 'int'", Field: "params"}
+	Describe(".GetGovernance", func() {
+		It("should panic when repo does not exist", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(state.BareRepository())
+			err := &errors.ReqError{Code: modules.StatusCodeRepoNotFound, HttpCode: 404, Msg: "repo not found", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.Update(params)
+				m.GetGovernance("repo1")
 			})
 		})
 
-		It("should return tx map equivalent if payloadOnly=true", func() {
-			key := ""
-			params := map[string]interface{}{"id": 1}
-			res := m.Update(params, key, true)
-			Expect(res["id"]).To(Equal("1"))
-			Expect(res).ToNot(HaveKey("hash"))
-			Expect(res["type"]).To(Equal(float64(txns.TxTypeRepoProposalUpdate)))
-			Expect(res).To(And(
-				HaveKey("timestamp"),
-				HaveKey("nonce"),
-				HaveKey("config"),
-				HaveKey("id"),
-				HaveKey("type"),
-				HaveKey("senderPubKey"),
-				HaveKey("fee"),
-				HaveKey("sig"),
-			))
+		It("should return the governance config with enum values resolved to names", func() {
+			repo := state.BareRepository()
+			repo.Balance = "10"
+			repo.Config = state.MakeDefaultRepoConfig()
+			repo.Config.Gov.Voter = state.VoterNetStakersAndVetoOwner.Ptr()
+			repo.Config.Gov.PropCreator = state.ProposalCreatorOwner.Ptr()
+			repo.Config.Gov.PropTallyMethod = state.ProposalTallyMethodNetStakeOfDelegators.Ptr()
+			repo.Config.Gov.PropQuorumMode = state.ProposalQuorumModeSnapshot.Ptr()
+			repo.Config.Gov.PropCloseTrigger = state.ProposalCloseTriggerDeadline.Ptr()
+			repo.Config.Gov.PropFeeRefundType = state.ProposalFeeRefundOnBelowThresholdAccept.Ptr()
+			repo.Config.Gov.PropDuration = pointer.ToString("100")
+			repo.Config.Gov.PropQuorum = pointer.ToString("40")
+			repo.Config.Gov.PropThreshold = pointer.ToString("51.5")
+			repo.Config.Gov.PropVetoQuorum = pointer.ToString("33")
+			repo.Config.Gov.PropVetoOwnersQuorum = pointer.ToString("10")
+			repo.Config.Gov.PropFee = pointer.ToString("2.5")
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			res := m.GetGovernance("repo1")
+			Expect(res).To(Equal(util.Map{
+				"voter":              "NetStakersAndVetoOwner",
+				"proposalCreator":    "Owner",
+				"tallyMethod":        "NetStakeOfDelegators",
+				"quorumMode":         "Snapshot",
+				"closeTrigger":       "Deadline",
+				"feeRefundType":      "OnBelowThresholdAccept",
+				"duration":           uint64(100),
+				"quorum":             float64(40),
+				"threshold":          51.5,
+				"vetoQuorum":         float64(33),
+				"vetoOwnersQuorum":   float64(10),
+				"proposalFee":        "2.5",
+				"feeDepositDuration": uint64(0),
+				"feeDepositCap":      "0",
+				"maxOpenProposals":   uint64(0),
+			}))
+		})
+
+		It("should return unset settings as omitted and unknown enum values as Unknown", func() {
+			repo := state.BareRepository()
+			repo.Balance = "10"
+			repo.Config = &state.RepoConfig{Gov: &state.RepoConfigGovernance{Voter: pointer.ToInt(10)}}
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			Expect(m.GetGovernance("repo1")).To(Equal(util.Map{"voter": "Unknown"}))
 		})
+	})
 
-		It("should panic if unable to add tx to mempool", func() {
-			params := map[string]interface{}{"id": 1}
-			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(nil, fmt.Errorf("error"))
-			err := &errors.ReqError{Code: "err_mempool", HttpCode: 400, Msg: "error", Field: ""}
+	Describe(".GetReferenceNonce", func() {
+		It("should panic when repo does not exist", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(state.BareRepository())
+			err := &errors.ReqError{Code: modules.StatusCodeRepoNotFound, HttpCode: 404, Msg: "repo not found", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.Update(params, "", false)
+				m.GetReferenceNonce("repo1", "refs/heads/master")
 			})
 		})
 
-		It("should return tx hash on success", func() {
-			params := map[string]interface{}{"id": 1}
-			hash := util.StrToHexBytes("tx_hash")
-			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(hash, nil)
-			res := m.Update(params, "", false)
-			Expect(res).To(HaveKey("hash"))
-			Expect(res["hash"]).To(Equal(hash))
-		})
-	})
-
-	Describe(".DepositProposalFee", func() {
-		It("should panic when unable to decode params", func() {
-			params := map[string]interface{}{"id": struct{}{}}
-			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "1 error(s) decoding:\n\n* 'id' expected type 'string', got unconvertible type 'struct {}', value: '{}'", Field: "params"}
+		It("should panic when reference name is not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "reference name is required", Field: "ref"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.DepositProposalFee(params)
+				m.GetReferenceNonce("repo1", "")
 			})
 		})
 
-		It("should return tx map equivalent if payloadOnly=true", func() {
-			key := ""
-			params := map[string]interface{}{"id": 1}
-			res := m.DepositProposalFee(params, key, true)
-			Expect(res["id"]).To(Equal("1"))
-			Expect(res).ToNot(HaveKey("hash"))
-			Expect(res["type"]).To(Equal(float64(txns.TxTypeRepoProposalSendFee)))
-			Expect(res).To(And(
-				HaveKey("timestamp"),
-				HaveKey("nonce"),
-				HaveKey("id"),
-				HaveKey("type"),
-				HaveKey("senderPubKey"),
-				HaveKey("fee"),
-				HaveKey("sig"),
-			))
-		})
-
-		It("should panic if unable to add tx to mempool", func() {
-			params := map[string]interface{}{"id": 1}
-			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(nil, fmt.Errorf("error"))
-			err := &errors.ReqError{Code: "err_mempool", HttpCode: 400, Msg: "error", Field: ""}
-			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.DepositProposalFee(params, "", false)
-			})
+		It("should return the nonce of an existing reference", func() {
+			repo := state.BareRepository()
+			repo.References["refs/heads/master"] = &state.Reference{Nonce: 5, Hash: []byte("hash")}
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			Expect(m.GetReferenceNonce("repo1", "refs/heads/master")).To(Equal(uint64(5)))
 		})
 
-		It("should return tx hash on success", func() {
-			params := map[string]interface{}{"id": 1}
-			hash := util.StrToHexBytes("tx_hash")
-			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(hash, nil)
-			res := m.DepositProposalFee(params, "", false)
-			Expect(res).To(HaveKey("hash"))
-			Expect(res["hash"]).To(Equal(hash))
+		It("should return 0 for a reference that does not exist yet", func() {
+			repo := state.BareRepository()
+			repo.References["refs/heads/master"] = &state.Reference{Nonce: 5, Hash: []byte("hash")}
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			Expect(m.GetReferenceNonce("repo1", "refs/heads/dev")).To(Equal(uint64(0)))
 		})
 	})
 
-	Describe(".AddContributor", func() {
-		It("should panic when unable to decode params", func() {
-			params := map[string]interface{}{"id": struct{}{}}
-			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "1 error(s) decoding:\n\n* 'id' expected type 'string', got unconvertible type 'struct {}', value: '{}'", Field: "params"}
+	Describe(".ReconcileRepo", func() {
+		It("should panic when repo does not exist", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(state.BareRepository())
+			err := &errors.ReqError{Code: modules.StatusCodeRepoNotFound, HttpCode: 404, Msg: "repo not found", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.AddContributor(params)
+				m.ReconcileRepo("repo1")
 			})
 		})
 
-		It("should return tx map equivalent if payloadOnly=true", func() {
-			key := ""
-			params := map[string]interface{}{"id": 1}
-			res := m.AddContributor(params, key, true)
-			Expect(res["id"]).To(Equal("1"))
-			Expect(res).ToNot(HaveKey("hash"))
-			Expect(res["type"]).To(Equal(float64(txns.TxTypeRepoProposalRegisterPushKey)))
-			Expect(res).To(And(
-				HaveKey("timestamp"),
-				HaveKey("nonce"),
-				HaveKey("policies"),
-				HaveKey("namespace"),
-				HaveKey("namespaceOnly"),
-				HaveKey("keys"),
-				HaveKey("id"),
-				HaveKey("type"),
-				HaveKey("senderPubKey"),
-				HaveKey("fee"),
-				HaveKey("sig"),
-			))
-		})
-
-		It("should panic if in attach mode and RPC client method returns error", func() {
-			mockClient := mocks2.NewMockClient(ctrl)
-			mockRepoClient := mocks2.NewMockRepo(ctrl)
-			mockClient.EXPECT().Repo().Return(mockRepoClient)
-			m.Client = mockClient
-
-			mockRepoClient.EXPECT().AddContributors(gomock.Any()).Return(&api.ResultHash{}, fmt.Errorf("error"))
-			params := map[string]interface{}{"id": 1}
-			err := fmt.Errorf("error")
+		It("should panic when the local repo does not exist", func() {
+			repo := state.BareRepository()
+			repo.Balance = "100"
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return nil, git.ErrRepositoryNotExists }
+			err := &errors.ReqError{Code: modules.StatusCodeRepoNotFound, HttpCode: 404, Msg: "repo not found", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.AddContributor(params)
-			})
-		})
-
-		It("should not panic if in attach mode and RPC client method returns no error", func() {
-			mockClient := mocks2.NewMockClient(ctrl)
-			mockRepoClient := mocks2.NewMockRepo(ctrl)
-			mockClient.EXPECT().Repo().Return(mockRepoClient)
-			m.Client = mockClient
-
-			mockRepoClient.EXPECT().AddContributors(gomock.Any()).Return(&api.ResultHash{}, nil)
-			params := map[string]interface{}{"id": 1}
-			assert.NotPanics(GinkgoT(), func() {
-				m.AddContributor(params)
+				m.ReconcileRepo("repo1")
 			})
 		})
 
-		It("should panic if unable to add tx to mempool", func() {
-			params := map[string]interface{}{"id": 1}
-			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(nil, fmt.Errorf("error"))
-			err := &errors.ReqError{Code: "err_mempool", HttpCode: 400, Msg: "error", Field: ""}
-			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.AddContributor(params, "", false)
-			})
+		It("should report no discrepancy when local references match the on-chain state", func() {
+			repo := state.BareRepository()
+			repo.References["refs/heads/master"] = &state.Reference{Nonce: 1, Hash: util.MustFromHex("6fe5e981f7defdfb907c1237e2e8427696adafa7")}
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			mockRepo := mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			mockRepo.EXPECT().GetReferences().Return([]plumbing2.ReferenceName{"HEAD", "refs/heads/master"}, nil)
+			mockRepo.EXPECT().RefGet("refs/heads/master").Return("6fe5e981f7defdfb907c1237e2e8427696adafa7", nil)
+			res := m.ReconcileRepo("repo1")
+			Expect(res["consistent"]).To(BeTrue())
+			Expect(res["missingOnChain"]).To(BeEmpty())
+			Expect(res["missingLocally"]).To(BeEmpty())
+			Expect(res["staleRefs"]).To(BeEmpty())
 		})
 
-		It("should return tx hash on success", func() {
-			params := map[string]interface{}{"id": 1}
-			hash := util.StrToHexBytes("tx_hash")
-			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(hash, nil)
-			res := m.AddContributor(params, "", false)
-			Expect(res).To(HaveKey("hash"))
-			Expect(res["hash"]).To(Equal(hash))
+		It("should report drifted, local-only and chain-only references", func() {
+			repo := state.BareRepository()
+			repo.References["refs/heads/master"] = &state.Reference{Nonce: 1, Hash: util.MustFromHex("6fe5e981f7defdfb907c1237e2e8427696adafa7")}
+			repo.References["refs/tags/v1"] = &state.Reference{Nonce: 1, Hash: util.MustFromHex("7a561e23f4e81c61df1b0dc63a89ae9c8d5680cd")}
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			mockRepo := mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			mockRepo.EXPECT().GetReferences().Return([]plumbing2.ReferenceName{"refs/heads/master", "refs/heads/dev"}, nil)
+			mockRepo.EXPECT().RefGet("refs/heads/master").Return("0000000000000000000000000000000000000001", nil)
+			res := m.ReconcileRepo("repo1")
+			Expect(res["consistent"]).To(BeFalse())
+			Expect(res["missingOnChain"]).To(Equal([]string{"refs/heads/dev"}))
+			Expect(res["missingLocally"]).To(Equal([]string{"refs/tags/v1"}))
+			Expect(res["staleRefs"]).To(Equal([]util.Map{{
+				"name":      "refs/heads/master",
+				"localHash": "0000000000000000000000000000000000000001",
+				"chainHash": "6fe5e981f7defdfb907c1237e2e8427696adafa7",
+			}}))
 		})
 	})
 
-	Describe(".Track", func() {
-		It("should panic if unable to add repo", func() {
-			mockRepoSyncInfoKeeper.EXPECT().Track("repo1", []uint64{100}).Return(fmt.Errorf("error"))
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error", Field: ""}
+	Describe(".GetRepoNamespaces", func() {
+		It("should panic when repo does not exist", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(state.BareRepository())
+			err := &errors.ReqError{Code: modules.StatusCodeRepoNotFound, HttpCode: 404, Msg: "repo not found", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.Track("repo1", 100)
-			})
-		})
-
-		It("should not panic if able to add repo", func() {
-			mockRepoSyncInfoKeeper.EXPECT().Track("repo1", []uint64{100}).Return(nil)
-			assert.NotPanics(GinkgoT(), func() {
-				m.Track("repo1", 100)
+				m.GetRepoNamespaces("repo1")
 			})
 		})
-	})
 
-	Describe(".UnTrack", func() {
-		It("should panic if unable to untrack repo", func() {
-			mockRepoSyncInfoKeeper.EXPECT().UnTrack("repo1").Return(fmt.Errorf("error"))
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error", Field: ""}
-			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.UnTrack("repo1")
-			})
+		It("should return empty result when no namespace points to the repo", func() {
+			repo := state.BareRepository()
+			repo.Balance = "100"
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			mockNSKeeper.EXPECT().GetRepoNamespaces("repo1").Return(nil)
+			Expect(m.GetRepoNamespaces("repo1")).To(BeEmpty())
 		})
 
-		It("should not panic if able to untrack repo", func() {
-			mockRepoSyncInfoKeeper.EXPECT().UnTrack("repo1").Return(nil)
-			assert.NotPanics(GinkgoT(), func() {
-				m.UnTrack("repo1")
-			})
+		It("should return all namespace domains pointing to the repo", func() {
+			repo := state.BareRepository()
+			repo.Balance = "100"
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			mockNSKeeper.EXPECT().GetRepoNamespaces("repo1").Return([]*state.RepoNamespace{
+				{Namespace: "ns1", Domain: "repo1"},
+				{Namespace: "ns1", Domain: "main"},
+				{Namespace: "ns2", Domain: "dev"},
+			})
+			res := m.GetRepoNamespaces("repo1")
+			Expect(res).To(Equal([]util.Map{
+				{"namespace": "ns1", "domain": "repo1"},
+				{"namespace": "ns1", "domain": "main"},
+				{"namespace": "ns2", "domain": "dev"},
+			}))
 		})
 	})
 
-	Describe(".Get", func() {
-		It("should panic if unable to untrack repo", func() {
-			tracked := map[string]*core.TrackedRepo{
-				"repo1": {UpdatedAt: 10},
+	Describe(".GetEffectivePolicies", func() {
+		var mockPushKeyKeeper *mocks.MockPushKeyKeeper
+		var repo *state.Repository
+
+		BeforeEach(func() {
+			mockPushKeyKeeper = mocks.NewMockPushKeyKeeper(ctrl)
+			mockLogic.EXPECT().PushKeyKeeper().Return(mockPushKeyKeeper).AnyTimes()
+			repo = state.BareRepository()
+			repo.Balance = "100"
+			repo.Config.Policies = []*state.Policy{
+				{Subject: "all", Object: "refs/heads", Action: "write"},
+				{Subject: "contrib", Object: "refs/heads/dev", Action: "delete"},
+				{Subject: "pk1", Object: "refs/heads/master", Action: "write"},
+				{Subject: "pk2", Object: "refs/heads/master", Action: "write"},
+				{Subject: "all", Object: "invalid", Action: "write"},
 			}
-			mockRepoSyncInfoKeeper.EXPECT().Tracked().Return(tracked)
-			res := m.GetTracked()
-			Expect(res).To(Equal(util.Map(util.ToJSONMap(tracked))))
 		})
-	})
-
-	Describe(".ListPath", func() {
 
-		It("should panic if repo name was not provided", func() {
+		It("should panic when repo name is not provided", func() {
 			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ListPath("", "")
+				m.GetEffectivePolicies("", "pk1")
 			})
 		})
 
-		It("should panic if repo does not exist", func() {
-			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+		It("should panic when push key id is not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "push key id is required", Field: "pkID"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ListPath("unknown", "")
+				m.GetEffectivePolicies("repo1", "")
 			})
 		})
 
-		It("should return entries when successful", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			res := m.ListPath("repo1", "")
-			Expect(res).To(HaveLen(4))
-		})
-
-		It("should return error when path is unknown", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			err := &errors.ReqError{Code: modules.StatusCodePathNotFound, HttpCode: 404, Msg: "path not found", Field: "path"}
+		It("should panic when repo does not exist", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(state.BareRepository())
+			err := &errors.ReqError{Code: modules.StatusCodeRepoNotFound, HttpCode: 404, Msg: "repo not found", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ListPath("repo1", "unknown")
+				m.GetEffectivePolicies("repo1", "pk1")
 			})
 		})
 
-		It("should return empty list when revision is invalid", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			res := m.ListPath("repo1", ".", "something")
-			Expect(res).To(BeEmpty())
+		It("should panic when push key does not exist", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			mockPushKeyKeeper.EXPECT().Get("pk1").Return(state.BarePushKey())
+			err := &errors.ReqError{Code: modules.StatusCodePushKeyNotFound, HttpCode: 404, Msg: "push key not found", Field: "pkID"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetEffectivePolicies("repo1", "pk1")
+			})
+		})
+
+		It("should return only the repo policies that apply to a non-contributor", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			mockPushKeyKeeper.EXPECT().Get("pk1").Return(&state.PushKey{Address: "addr1"})
+			res := m.GetEffectivePolicies("repo1", "pk1")
+			Expect(res).To(Equal([]util.Map{
+				{"sub": "all", "obj": "refs/heads", "act": "write", "level": 2, "source": "repo"},
+				{"sub": "pk1", "obj": "refs/heads/master", "act": "write", "level": 2, "source": "repo"},
+			}))
+		})
+
+		It("should merge contributor policies with repo policies, contributor policies first", func() {
+			repo.Contributors["pk1"] = &state.RepoContributor{Policies: []*state.ContributorPolicy{
+				{Object: "refs/heads/master", Action: "write"},
+				{Object: "refs/tags", Action: "deny-write"},
+			}}
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			mockPushKeyKeeper.EXPECT().Get("pk1").Return(&state.PushKey{Address: "addr1"})
+			res := m.GetEffectivePolicies("repo1", "pk1")
+			Expect(res).To(Equal([]util.Map{
+				{"sub": "pk1", "obj": "refs/heads/master", "act": "write", "level": 0, "source": "contributor"},
+				{"sub": "pk1", "obj": "refs/tags", "act": "deny-write", "level": 0, "source": "contributor"},
+				{"sub": "all", "obj": "refs/heads", "act": "write", "level": 2, "source": "repo"},
+				{"sub": "contrib", "obj": "refs/heads/dev", "act": "delete", "level": 2, "source": "repo"},
+			}))
 		})
 	})
 
-	Describe(".ReadFileLines", func() {
-		It("should panic if repo name was not provided", func() {
-			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+	Describe(".NormalizeRepoName", func() {
+		It("should panic when name is not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "name is required", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReadFileLines("", "")
+				m.NormalizeRepoName("  ")
 			})
 		})
 
-		It("should panic if file path was not provided", func() {
-			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "file path is required", Field: "file"}
+		It("should panic when name contains invalid characters", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "invalid identifier; only alphanumeric, _, and - characters are allowed", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReadFileLines("repo1", "")
+				m.NormalizeRepoName("repo.1")
+			})
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.NormalizeRepoName("ns1/repo1")
 			})
 		})
 
-		It("should panic if repo does not exist", func() {
-			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+		It("should panic when name starts with _ or -", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "invalid identifier; identifier cannot start with _ or - character", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReadFileLines("unknown", "a/b")
+				m.NormalizeRepoName("_repo1")
 			})
 		})
 
-		It("should panic if file path was not provided", func() {
-			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "file path is required", Field: "file"}
+		It("should panic when name is too short", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "name is too short. Must be at least 3 characters long", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReadFileLines("repo1", "")
+				m.NormalizeRepoName("r/")
 			})
 		})
 
-		It("should panic if file path does not exist", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			err := &errors.ReqError{Code: "path_not_found", HttpCode: 404, Msg: "path not found", Field: "file"}
+		It("should return a valid name unchanged", func() {
+			Expect(m.NormalizeRepoName("my-repo_1")).To(Equal("my-repo_1"))
+		})
+
+		It("should remove the native repo namespace prefix and surrounding spaces", func() {
+			Expect(m.NormalizeRepoName("r/repo1")).To(Equal("repo1"))
+			Expect(m.NormalizeRepoName(" repo1 ")).To(Equal("repo1"))
+		})
+	})
+
+	Describe(".ResolveURI", func() {
+		It("should panic when uri is not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "uri is required", Field: "uri"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReadFileLines("repo1", "unknown")
+				m.ResolveURI("")
 			})
 		})
 
-		It("should panic if file path was not a file", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			err := &errors.ReqError{Code: "path_not_file", HttpCode: 400, Msg: "path is not a file", Field: "file"}
+		It("should panic when uri is not a valid repository name or namespace URI", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "uri is not valid: invalid identifier; only alphanumeric, _, and - characters are allowed", Field: "uri"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReadFileLines("repo1", "a")
+				m.ResolveURI("ns1/repo1/path")
+			})
+			err = &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "uri is not valid: name is too short. Must be at least 3 characters long", Field: "uri"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ResolveURI("r/")
 			})
 		})
 
-		It("should return lines of a file", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			lines := m.ReadFileLines("repo1", "file.txt")
-			Expect(lines).To(Equal([]string{"Hello World", "Hello Friend", "Hello Degens"}))
+		It("should resolve a repository name", func() {
+			res := m.ResolveURI("repo1")
+			Expect(res["name"]).To(Equal("repo1"))
+			Expect(res["uri"]).To(Equal("r/repo1"))
+			Expect(res["path"]).To(Equal(filepath.Join(cfg.GetRepoRoot(), "repo1")))
 		})
-	})
 
-	Describe(".GetBranches", func() {
-		It("should panic if repo name was not provided", func() {
-			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
-			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.GetBranches("")
-			})
+		It("should resolve uri=r/repo1 without looking up the repository state", func() {
+			res := m.ResolveURI("r/repo1")
+			Expect(res["name"]).To(Equal("repo1"))
+			Expect(res["uri"]).To(Equal("r/repo1"))
+			Expect(res["path"]).To(Equal(filepath.Join(cfg.GetRepoRoot(), "repo1")))
 		})
 
-		It("should panic if repo does not exist", func() {
-			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
-			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.GetBranches("unknown")
+		When("uri=ns1/domain1", func() {
+			It("should panic if namespace=ns1 is unknown", func() {
+				mockNSKeeper.EXPECT().Get(crypto.MakeNamespaceHash("ns1")).Return(state.BareNamespace())
+				err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "namespace not found", Field: "uri"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.ResolveURI("ns1/domain1")
+				})
 			})
-		})
 
-		It("should return expected branch(es)", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			lines := m.GetBranches("repo1")
-			Expect(lines).To(Equal([]string{"refs/heads/dev", "refs/heads/master"}))
+			It("should panic if domain=domain1 does not exist in the namespace", func() {
+				ns := state.BareNamespace()
+				ns.Domains["something"] = "r/target"
+				mockNSKeeper.EXPECT().Get(crypto.MakeNamespaceHash("ns1")).Return(ns)
+				err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "namespace domain not found", Field: "uri"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.ResolveURI("ns1/domain1")
+				})
+			})
+
+			It("should panic if domain=domain1 does not point to a native repo URI", func() {
+				ns := state.BareNamespace()
+				ns.Domains["domain1"] = "a/target"
+				mockNSKeeper.EXPECT().Get(crypto.MakeNamespaceHash("ns1")).Return(ns)
+				err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "namespace domain target is not a repository", Field: "uri"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.ResolveURI("ns1/domain1")
+				})
+			})
+
+			It("should resolve to the target repository", func() {
+				ns := state.BareNamespace()
+				ns.Domains["domain1"] = "r/repo1"
+				mockNSKeeper.EXPECT().Get(crypto.MakeNamespaceHash("ns1")).Return(ns)
+				res := m.ResolveURI("ns1/domain1")
+				Expect(res["name"]).To(Equal("repo1"))
+				Expect(res["uri"]).To(Equal("r/repo1"))
+				Expect(res["path"]).To(Equal(filepath.Join(cfg.GetRepoRoot(), "repo1")))
+			})
 		})
 	})
 
-	Describe(".GetLatestBranchCommit", func() {
-		It("should panic if repo name was not provided", func() {
-			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+	Describe(".Update", func() {
+		It("should panic when unable to decode params", func() {
+			params := map[string]interface{}{"config": 123}
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "1 error(s) decoding:\n\n* 'config' expected a map, got 'int'", Field: "params"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.GetLatestBranchCommit("", "")
+				m.Update(params)
 			})
 		})
 
-		It("should panic if branch name was not provided", func() {
-			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "branch name is required", Field: "branch"}
+		It("should return tx map equivalent if payloadOnly=true", func() {
+			key := ""
+			params := map[string]interface{}{"id": 1}
+			res := m.Update(params, key, true)
+			Expect(res["id"]).To(Equal("1"))
+			Expect(res).ToNot(HaveKey("hash"))
+			Expect(res["type"]).To(Equal(float64(txns.TxTypeRepoProposalUpdate)))
+			Expect(res).To(And(
+				HaveKey("timestamp"),
+				HaveKey("nonce"),
+				HaveKey("config"),
+				HaveKey("id"),
+				HaveKey("type"),
+				HaveKey("senderPubKey"),
+				HaveKey("fee"),
+				HaveKey("sig"),
+			))
+		})
+
+		It("should panic if unable to add tx to mempool", func() {
+			params := map[string]interface{}{"id": 1}
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(nil, fmt.Errorf("error"))
+			err := &errors.ReqError{Code: "err_mempool", HttpCode: 400, Msg: "error", Field: ""}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.GetLatestBranchCommit("repo", "")
+				m.Update(params, "", false)
 			})
 		})
 
-		It("should panic if repo does not exist", func() {
-			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+		It("should return tx hash on success", func() {
+			params := map[string]interface{}{"id": 1}
+			hash := util.StrToHexBytes("tx_hash")
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(hash, nil)
+			res := m.Update(params, "", false)
+			Expect(res).To(HaveKey("hash"))
+			Expect(res["hash"]).To(Equal(hash))
+		})
+	})
+
+	Describe(".GetClosedProposals", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.GetLatestBranchCommit("unknown", "branch")
+				m.GetClosedProposals("")
 			})
 		})
 
-		It("should panic if branch does not exist", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			err := &errors.ReqError{Code: "branch_not_found", HttpCode: 404, Msg: "branch does not exist", Field: "branch"}
+		It("should panic when repo does not exist", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(state.BareRepository())
+			err := &errors.ReqError{Code: "repo_not_found", HttpCode: 404, Msg: "repo not found", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.GetLatestBranchCommit("repo1", "unknown")
+				m.GetClosedProposals("repo1")
 			})
 		})
 
-		It("should be successful if branch is known", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			bc := m.GetLatestBranchCommit("repo1", "master")
-			Expect(bc).ToNot(BeEmpty())
+		When("repo has open and closed proposals", func() {
+			BeforeEach(func() {
+				repo := state.BareRepository()
+				repo.Balance = "100"
+				repo.Proposals.Add("1", &state.RepoProposal{EndAt: 30, Yes: 2, Outcome: state.ProposalOutcomeAccepted})
+				repo.Proposals.Add("2", &state.RepoProposal{EndAt: 100})
+				repo.Proposals.Add("3", &state.RepoProposal{EndAt: 10, No: 1, Outcome: state.ProposalOutcomeRejected})
+				repo.Proposals.Add("4", &state.RepoProposal{EndAt: 20})
+				mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+				mockRepoKeeper.EXPECT().IsProposalClosed("repo1", "2").Return(false, nil)
+				mockRepoKeeper.EXPECT().IsProposalClosed("repo1", "4").Return(true, nil)
+			})
+
+			It("should return only closed proposals sorted by closing height", func() {
+				res := m.GetClosedProposals("repo1")
+				Expect(res).To(HaveLen(3))
+				Expect(res[0]["id"]).To(Equal("3"))
+				Expect(res[0]["outcome"]).To(Equal(state.ProposalOutcomeRejected))
+				Expect(res[0]["no"]).To(Equal(float64(1)))
+				Expect(res[0]["closedAt"]).To(Equal(uint64(10)))
+				Expect(res[1]["id"]).To(Equal("4"))
+				Expect(res[2]["id"]).To(Equal("1"))
+				Expect(res[2]["outcome"]).To(Equal(state.ProposalOutcomeAccepted))
+				Expect(res[2]["yes"]).To(Equal(float64(2)))
+			})
+
+			It("should paginate results using offset and limit", func() {
+				res := m.GetClosedProposals("repo1", types.ClosedProposalsOptions{Offset: 1, Limit: 1})
+				Expect(res).To(HaveLen(1))
+				Expect(res[0]["id"]).To(Equal("4"))
+			})
+
+			It("should return empty result when offset exceeds the number of closed proposals", func() {
+				res := m.GetClosedProposals("repo1", types.ClosedProposalsOptions{Offset: 3})
+				Expect(res).To(BeEmpty())
+			})
 		})
 	})
 
-	Describe(".GetCommits", func() {
-		It("should panic if repo name was not provided", func() {
-			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+	Describe(".ListProposals", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.GetCommits("", "")
+				m.ListProposals("")
 			})
 		})
 
-		It("should panic if branch name was not provided", func() {
-			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "branch name is required", Field: "branch"}
+		It("should panic when status is unknown", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "status must be 'open' or 'closed'", Field: "opts.status"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.GetCommits("repo", "")
+				m.ListProposals("repo1", types.ListProposalsOptions{Status: "pending"})
 			})
 		})
 
-		It("should panic if repo does not exist", func() {
-			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+		It("should panic when type is unknown", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "unknown proposal type", Field: "opts.type"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.GetCommits("unknown", "branch")
+				m.ListProposals("repo1", types.ListProposalsOptions{Type: "transfer"})
 			})
 		})
 
-		It("should panic if branch does not exist", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			err := &errors.ReqError{Code: "branch_not_found", HttpCode: 404, Msg: "branch does not exist", Field: "branch"}
+		It("should panic when offset is negative", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "offset must be a non-negative number", Field: "opts.offset"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.GetCommits("repo1", "unknown")
+				m.ListProposals("repo1", types.ListProposalsOptions{Offset: -1})
 			})
 		})
 
-		It("should return commits on success", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			bc := m.GetCommits("repo1", "master", 0)
-			Expect(bc).ToNot(BeEmpty())
-			Expect(bc).To(HaveLen(7))
+		It("should panic when repo does not exist", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(state.BareRepository())
+			err := &errors.ReqError{Code: "repo_not_found", HttpCode: 404, Msg: "repo not found", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ListProposals("repo1")
+			})
 		})
 
-		It("should return limited commits when limit is > 0", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			bc := m.GetCommits("repo1", "master", 2)
-			Expect(bc).ToNot(BeEmpty())
-			Expect(bc).To(HaveLen(2))
+		When("repo has proposals of different types and statuses", func() {
+			BeforeEach(func() {
+				repo := state.BareRepository()
+				repo.Balance = "100"
+				repo.Proposals.Add("1", &state.RepoProposal{Action: txns.TxTypeRepoProposalUpsertOwner, Creator: "addr1", Height: 4, Outcome: state.ProposalOutcomeAccepted})
+				repo.Proposals.Add("2", &state.RepoProposal{Action: txns.TxTypeRepoProposalUpdate, Creator: "addr2", Height: 1})
+				repo.Proposals.Add("3", &state.RepoProposal{Action: txns.TxTypeMergeRequestProposalAction, Creator: "addr1", Height: 3})
+				repo.Proposals.Add("4", &state.RepoProposal{Action: txns.TxTypeRepoProposalRegisterPushKey, Creator: "addr2", Height: 2, Outcome: state.ProposalOutcomeRejected})
+				mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+				mockRepoKeeper.EXPECT().IsProposalClosed("repo1", "2").Return(false, nil).MaxTimes(1)
+				mockRepoKeeper.EXPECT().IsProposalClosed("repo1", "3").Return(true, nil).MaxTimes(1)
+			})
+
+			It("should return all proposals sorted by height", func() {
+				res := m.ListProposals("repo1")
+				Expect(res).To(HaveLen(4))
+				Expect(res[0]["id"]).To(Equal("2"))
+				Expect(res[0]["closed"]).To(BeFalse())
+				Expect(res[1]["id"]).To(Equal("4"))
+				Expect(res[1]["closed"]).To(BeTrue())
+				Expect(res[1]["outcome"]).To(Equal(state.ProposalOutcomeRejected))
+				Expect(res[2]["id"]).To(Equal("3"))
+				Expect(res[2]["closed"]).To(BeTrue())
+				Expect(res[3]["id"]).To(Equal("1"))
+				Expect(res[3]["height"]).To(Equal(uint64(4)))
+			})
+
+			It("should return only open proposals when status is 'open'", func() {
+				res := m.ListProposals("repo1", types.ListProposalsOptions{Status: "open"})
+				Expect(res).To(HaveLen(1))
+				Expect(res[0]["id"]).To(Equal("2"))
+			})
+
+			It("should return only closed proposals when status is 'closed'", func() {
+				res := m.ListProposals("repo1", types.ListProposalsOptions{Status: "closed"})
+				Expect(res).To(HaveLen(3))
+				Expect(res[0]["id"]).To(Equal("4"))
+				Expect(res[1]["id"]).To(Equal("3"))
+				Expect(res[2]["id"]).To(Equal("1"))
+			})
+
+			It("should return only proposals of the given type", func() {
+				res := m.ListProposals("repo1", types.ListProposalsOptions{Type: "merge"})
+				Expect(res).To(HaveLen(1))
+				Expect(res[0]["id"]).To(Equal("3"))
+				Expect(res[0]["action"]).To(Equal(txns.TxTypeMergeRequestProposalAction))
+			})
+
+			It("should return only proposals of the given creator", func() {
+				res := m.ListProposals("repo1", types.ListProposalsOptions{Creator: "addr2"})
+				Expect(res).To(HaveLen(2))
+				Expect(res[0]["id"]).To(Equal("2"))
+				Expect(res[1]["id"]).To(Equal("4"))
+			})
+
+			It("should combine filters", func() {
+				res := m.ListProposals("repo1", types.ListProposalsOptions{Creator: "addr1", Status: "closed", Type: "upsert-owner"})
+				Expect(res).To(HaveLen(1))
+				Expect(res[0]["id"]).To(Equal("1"))
+			})
+
+			It("should paginate results using offset and limit", func() {
+				res := m.ListProposals("repo1", types.ListProposalsOptions{Offset: 1, Limit: 2})
+				Expect(res).To(HaveLen(2))
+				Expect(res[0]["id"]).To(Equal("4"))
+				Expect(res[1]["id"]).To(Equal("3"))
+			})
+
+			It("should return empty result when offset exceeds the number of matching proposals", func() {
+				res := m.ListProposals("repo1", types.ListProposalsOptions{Offset: 4})
+				Expect(res).To(BeEmpty())
+			})
 		})
 	})
 
-	Describe(".GetCommit", func() {
-		It("should panic if repo name was not provided", func() {
-			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
-			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.GetCommit("", "")
+	Describe(".ValidateRepoConfig", func() {
+		It("should return valid=true and no errors when config is valid", func() {
+			res := m.ValidateRepoConfig(map[string]interface{}{
+				"governance": map[string]interface{}{"propQuorum": "10", "propVoter": 1},
 			})
+			Expect(res["valid"]).To(BeTrue())
+			Expect(res["errors"]).To(BeEmpty())
 		})
 
-		It("should panic if commit hash was not provided", func() {
-			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "commit hash is required", Field: "hash"}
-			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.GetCommit("repo", "")
+		It("should return field error when governance.propVoter is unknown", func() {
+			res := m.ValidateRepoConfig(map[string]interface{}{
+				"governance": map[string]interface{}{"propVoter": 1000},
 			})
+			Expect(res["valid"]).To(BeFalse())
+			Expect(res["errors"]).To(Equal([]util.Map{{"field": "governance.propVoter", "msg": "unknown value"}}))
 		})
 
-		It("should panic if repo was not found", func() {
-			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
-			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.GetCommit("unknown", "hash")
+		It("should return field error when governance.propQuorum is negative", func() {
+			res := m.ValidateRepoConfig(map[string]interface{}{
+				"governance": map[string]interface{}{"propQuorum": "-1"},
 			})
+			Expect(res["valid"]).To(BeFalse())
+			Expect(res["errors"]).To(Equal([]util.Map{{"field": "governance.propQuorum", "msg": "must be a non-negative number"}}))
 		})
 
-		It("should panic if commit was not found", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			err := &errors.ReqError{Code: "commit_not_found", HttpCode: 404, Msg: "commit does not exist", Field: "hash"}
-			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.GetCommit("repo1", "f23482ae207b19498049ec7b35c8274c34ba6093")
+		It("should return field error when governance.propFeeDepCap is below governance.propFee", func() {
+			res := m.ValidateRepoConfig(map[string]interface{}{
+				"governance": map[string]interface{}{"propFee": "100", "propFeeDepCap": "50"},
 			})
+			Expect(res["valid"]).To(BeFalse())
+			Expect(res["errors"]).To(Equal([]util.Map{{"field": "governance.propFeeDepCap", "msg": "cannot be lower than the proposal fee"}}))
 		})
 
-		It("should not panic if commit was found", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			assert.NotPanics(GinkgoT(), func() {
-				hash := "932401fb0bf48f602c501334b773fbc3422ceb31"
-				res := m.GetCommit("repo1", hash)
-				Expect(res).ToNot(BeNil())
-				Expect(res["hash"]).To(Equal(hash))
+		It("should return config field error when config could not be decoded", func() {
+			res := m.ValidateRepoConfig(map[string]interface{}{
+				"governance": "invalid",
 			})
+			Expect(res["valid"]).To(BeFalse())
+			errs := res["errors"].([]util.Map)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0]["field"]).To(Equal("config"))
 		})
 	})
 
-	Describe(".CountCommits", func() {
-		It("should return correct commit count", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			count := m.CountCommits("repo1", "master")
-			Expect(count).To(Equal(7))
-			count = m.CountCommits("repo1", "cbc329e7e912227d58edea6d6a74d550cd664adf")
-			Expect(count).To(Equal(2))
+	Describe(".GetVotingPower", func() {
+		var key = ed25519.NewKeyFromIntSeed(1)
+		var mockSysKeeper *mocks.MockSystemKeeper
+		var mockTicketMgr *mocks.MockTicketManager
+
+		BeforeEach(func() {
+			mockSysKeeper = mocks.NewMockSystemKeeper(ctrl)
+			mockTicketMgr = mocks.NewMockTicketManager(ctrl)
+			mockLogic.EXPECT().SysKeeper().Return(mockSysKeeper).AnyTimes()
+			mockLogic.EXPECT().GetTicketManager().Return(mockTicketMgr).AnyTimes()
 		})
-	})
 
-	Describe(".GetCommitAncestors", func() {
-		It("should panic if repo name was not provided", func() {
-			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+		makeRepo := func(voter state.VoterType, tallyMethod state.ProposalTallyMethod) *state.Repository {
+			repo := state.BareRepository()
+			repo.AddOwner(key.Addr().String(), &state.RepoOwner{})
+			repo.Proposals.Add("1", &state.RepoProposal{
+				PowerAge: 10,
+				Config:   &state.RepoConfigGovernance{Voter: voter.Ptr(), PropTallyMethod: tallyMethod.Ptr()},
+			})
+			return repo
+		}
+
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.GetCommitAncestors("", "")
+				m.GetVotingPower("", "1", key.Addr().String())
 			})
 		})
 
-		It("should panic if commit hash was not provided", func() {
-			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "commit hash is required", Field: "commitHash"}
+		It("should panic when proposal id was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "proposal id is required", Field: "id"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.GetCommitAncestors("repo", "")
+				m.GetVotingPower("repo1", "", key.Addr().String())
 			})
 		})
 
-		It("should panic if repo does not exist", func() {
-			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+		It("should panic when address was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "address is required", Field: "address"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.GetCommitAncestors("unknown", "hash")
+				m.GetVotingPower("repo1", "1", "")
 			})
 		})
 
-		It("should panic if commit does not exist", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			err := &errors.ReqError{Code: "commit_not_found", HttpCode: 404, Msg: "commit does not exist", Field: "commitHash"}
+		It("should panic when repo does not exist", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(state.BareRepository())
+			err := &errors.ReqError{Code: "repo_not_found", HttpCode: 404, Msg: "repo not found", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.GetCommitAncestors("repo1", "unknown")
+				m.GetVotingPower("repo1", "1", key.Addr().String())
 			})
 		})
 
-		It("should return commits on success", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			commits := m.GetCommitAncestors("repo1", "aef606780a3f857fdd7fe8270efa547f118bef5f")
-			Expect(commits).ToNot(BeEmpty())
-			Expect(commits).To(HaveLen(5))
+		It("should panic when proposal does not exist", func() {
+			repo := state.BareRepository()
+			repo.Balance = "100"
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			err := &errors.ReqError{Code: "proposal_not_found", HttpCode: 404, Msg: "proposal not found", Field: "id"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetVotingPower("repo1", "1", key.Addr().String())
+			})
 		})
 
-		It("should return limited commits when limit is > 0", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			commits := m.GetCommitAncestors("repo1", "aef606780a3f857fdd7fe8270efa547f118bef5f", 1)
-			Expect(commits).ToNot(BeEmpty())
-			Expect(commits).To(HaveLen(1))
+		It("should panic when address is not a user address or public key", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(makeRepo(state.VoterOwner, state.ProposalTallyMethodIdentity))
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "address is not a valid user address or public key", Field: "address"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetVotingPower("repo1", "1", "invalid")
+			})
+		})
+
+		It("should return 1 for an owner when tally method is identity", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(makeRepo(state.VoterOwner, state.ProposalTallyMethodIdentity))
+			mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 100}, nil)
+			res := m.GetVotingPower("repo1", "1", key.Addr().String())
+			Expect(res["power"]).To(Equal(float64(1)))
+			Expect(res["tallyMethod"]).To(Equal(int(state.ProposalTallyMethodIdentity)))
+		})
+
+		It("should return 0 for a non-owner when only owners can vote", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(makeRepo(state.VoterOwner, state.ProposalTallyMethodIdentity))
+			res := m.GetVotingPower("repo1", "1", ed25519.NewKeyFromIntSeed(2).Addr().String())
+			Expect(res["power"]).To(Equal(float64(0)))
+		})
+
+		It("should return the height at which voting ends", func() {
+			repo := makeRepo(state.VoterOwner, state.ProposalTallyMethodIdentity)
+			repo.Proposals.Get("1").EndAt = 120
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 100}, nil)
+			res := m.GetVotingPower("repo1", "1", key.Addr().String())
+			Expect(res["endAt"]).To(Equal(uint64(120)))
+		})
+
+		It("should return the owner's spendable balance when tally method is coin-weighted", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(makeRepo(state.VoterOwner, state.ProposalTallyMethodCoinWeighted))
+			mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 100}, nil)
+			acct := state.NewBareAccount()
+			acct.Balance = "250.5"
+			mockAccountKeeper.EXPECT().Get(key.Addr()).Return(acct)
+			res := m.GetVotingPower("repo1", "1", key.Addr().String())
+			Expect(res["power"]).To(Equal(250.5))
+		})
+
+		It("should return value of non-delegated tickets when tally method is net-stake (non-delegated)", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(makeRepo(state.VoterNetStakers, state.ProposalTallyMethodNetStakeNonDelegated))
+			mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 100}, nil)
+			mockTicketMgr.EXPECT().ValueOfNonDelegatedTickets(key.PubKey().MustBytes32(), uint64(10)).Return(float64(30), nil)
+			res := m.GetVotingPower("repo1", "1", key.PubKey().Base58())
+			Expect(res["power"]).To(Equal(float64(30)))
+		})
+
+		It("should return value of delegated tickets when tally method is net-stake (delegators)", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(makeRepo(state.VoterNetStakers, state.ProposalTallyMethodNetStakeOfDelegators))
+			mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 100}, nil)
+			mockTicketMgr.EXPECT().ValueOfDelegatedTickets(key.PubKey().MustBytes32(), uint64(10)).Return(float64(20), nil)
+			res := m.GetVotingPower("repo1", "1", key.PubKey().Base58())
+			Expect(res["power"]).To(Equal(float64(20)))
+		})
+
+		It("should return value of unexpired tickets when tally method is net-stake", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(makeRepo(state.VoterNetStakers, state.ProposalTallyMethodNetStake))
+			mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 100}, nil)
+			mockTicketMgr.EXPECT().GetUnExpiredTickets(key.PubKey().MustBytes32(), uint64(10)).Return([]*tickettypes.Ticket{
+				{Value: "10"}, {Value: "5"},
+			}, nil)
+			res := m.GetVotingPower("repo1", "1", key.PubKey().Base58())
+			Expect(res["power"]).To(Equal(float64(15)))
+		})
+
+		It("should panic when tally method is net-stake and a public key was not provided", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(makeRepo(state.VoterNetStakers, state.ProposalTallyMethodNetStake))
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "public key is required for net-stake tally methods", Field: "address"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetVotingPower("repo1", "1", key.Addr().String())
+			})
 		})
 	})
 
-	Describe(".GetParentsAndCommitDiff", func() {
+	Describe(".GetProposalConfigDiff", func() {
 		It("should panic when repo name was not provided", func() {
 			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.GetParentsAndCommitDiff("", "")
+				m.GetProposalConfigDiff("", "1")
 			})
 		})
 
-		It("should panic when commit hash was not provided", func() {
-			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "commit hash is required", Field: "commitHash"}
+		It("should panic when proposal id was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "proposal id is required", Field: "id"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.GetParentsAndCommitDiff("repo", "")
+				m.GetProposalConfigDiff("repo1", "")
 			})
 		})
 
 		It("should panic when repo does not exist", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			mockRepoKeeper.EXPECT().Get("repo1").Return(state.BareRepository())
+			err := &errors.ReqError{Code: "repo_not_found", HttpCode: 404, Msg: "repo not found", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.GetParentsAndCommitDiff("unknown", "abc")
+				m.GetProposalConfigDiff("repo1", "1")
 			})
 		})
 
-		It("should panic when unable to get diff", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			err := &errors.ReqError{Code: "commit_not_found", HttpCode: 404, Msg: "commit not found", Field: "commitHash"}
+		It("should panic when proposal does not exist", func() {
+			repo := state.BareRepository()
+			repo.Balance = "100"
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			err := &errors.ReqError{Code: "proposal_not_found", HttpCode: 404, Msg: "proposal not found", Field: "id"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.GetParentsAndCommitDiff("repo3", "abc")
+				m.GetProposalConfigDiff("repo1", "1")
 			})
 		})
 
-		It("should not panic when successful", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			assert.NotPanics(GinkgoT(), func() {
-				res := m.GetParentsAndCommitDiff("repo3", "8c427dcc0d582cd7387b4c529185b7c1ab28f20c")
-				Expect(res).To(HaveKey("patches"))
-				Expect(res["patches"]).To(HaveLen(1))
-				Expect(res["patches"].([]map[string]string)[0]).To(HaveKey("a77021f4aead5f5ab8934a94154b0b4da6a551b5"))
-				Expect(res["patches"].([]map[string]string)[0]["a77021f4aead5f5ab8934a94154b0b4da6a551b5"]).To(Equal(`diff --git a/file_b.txt b/file_b.txt
-new file mode 100644
-index 0000000..3b0c2f1
---- /dev/null
-+++ b/file_b.txt
-@@ -0,0 +1 @@
-+We made games
-\ No newline at end of file`))
+		It("should panic when proposal is not an update proposal", func() {
+			repo := state.BareRepository()
+			repo.Proposals.Add("1", &state.RepoProposal{Action: txns.TxTypeRepoProposalVote})
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "proposal is not an update proposal", Field: "id"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetProposalConfigDiff("repo1", "1")
 			})
 		})
+
+		It("should return only the governance fields the proposal would change", func() {
+			repo := state.BareRepository()
+			repo.Config = state.MakeDefaultRepoConfig()
+			cfgUpd := &state.RepoConfig{Gov: &state.RepoConfigGovernance{
+				Voter:      state.VoterNetStakers.Ptr(),
+				PropQuorum: pointer.ToString("50"),
+				PropFee:    pointer.ToString("10"),
+			}}
+			repo.Proposals.Add("1", &state.RepoProposal{
+				Action:     txns.TxTypeRepoProposalUpdate,
+				ActionData: map[string]util.Bytes{constants.ActionDataKeyCFG: util.ToBytes(cfgUpd)},
+			})
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			res := m.GetProposalConfigDiff("repo1", "1")
+			Expect(res).To(HaveLen(3))
+			Expect(res["governance.propVoter"]).To(Equal(util.Map{"old": "0", "new": "1"}))
+			Expect(res["governance.propQuorum"]).To(Equal(util.Map{"old": *repo.Config.Gov.PropQuorum, "new": "50"}))
+			Expect(res["governance.propFee"]).To(Equal(util.Map{"old": *repo.Config.Gov.PropFee, "new": "10"}))
+		})
+
+		It("should return empty diff when proposal does not change the config", func() {
+			repo := state.BareRepository()
+			repo.Config = state.MakeDefaultRepoConfig()
+			repo.Proposals.Add("1", &state.RepoProposal{Action: txns.TxTypeRepoProposalUpdate, ActionData: map[string]util.Bytes{}})
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			res := m.GetProposalConfigDiff("repo1", "1")
+			Expect(res).To(BeEmpty())
+		})
 	})
 
-	Describe(".CreateIssue", func() {
+	Describe(".GetRepoConfigHistory", func() {
 		It("should panic when repo name was not provided", func() {
 			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CreateIssue("", nil)
+				m.GetRepoConfigHistory("", 1, 2)
 			})
 		})
 
-		It("should panic when repo was not found", func() {
-			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+		It("should panic when from height is not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "from height is required", Field: "fromHeight"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CreateIssue("unknown", nil)
+				m.GetRepoConfigHistory("repo1", 0, 2)
 			})
 		})
 
-		It("should panic when unable to find a free post ID", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			m.PostIDFinder = func(_ plumbing.LocalRepo, _ int, _ string) (int, error) {
-				return 0, fmt.Errorf("error here")
-			}
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+		It("should panic when to height is not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "to height is required", Field: "toHeight"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CreateIssue("repo3", nil)
+				m.GetRepoConfigHistory("repo1", 1, 0)
 			})
 		})
 
-		It("should panic when unable to clone repository", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(plumbing.MakeIssueReference("1")).Return("", plumbing2.ErrReferenceNotFound)
-			mockRepo.EXPECT().Clone(plumbing.CloneOptions{
-				Bare:          false,
-				ReferenceName: "",
-				Depth:         1,
-			}).Return(nil, "", fmt.Errorf("error here"))
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
-				return mockRepo, nil
-			}
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "failed to clone repo: error here", Field: ""}
+		It("should panic when from height is greater than to height", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "from height cannot be greater than to height", Field: "fromHeight"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CreateIssue("repo3", map[string]interface{}{
-					"id": 1,
-				})
+				m.GetRepoConfigHistory("repo1", 2, 1)
 			})
 		})
 
-		It("should panic when unable to create issue", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(plumbing.MakeIssueReference("1")).Return("", plumbing2.ErrReferenceNotFound)
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
-
-			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
-			mockCloneRepo.EXPECT().Delete()
-
-			m.IssueCreate = func(_ plumbing.LocalRepo, _ *issuecmd.IssueCreateArgs) (*issuecmd.IssueCreateResult, error) {
-				return nil, fmt.Errorf("error here")
-			}
-
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+		It("should panic when repo does not exist at from height", func() {
+			mockRepoKeeper.EXPECT().Get("repo1", uint64(1)).Return(state.BareRepository())
+			err := &errors.ReqError{Code: "repo_not_found", HttpCode: 404, Msg: "repo not found", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CreateIssue("repo3", map[string]interface{}{
-					"id": 1,
-				})
+				m.GetRepoConfigHistory("repo1", 1, 2)
 			})
 		})
 
-		It("should panic when unable to get create issue reference", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-
-			issueRef := plumbing.MakeIssueReference("1")
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(issueRef).Return("", plumbing2.ErrReferenceNotFound)
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
-
-			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
-			mockCloneRepo.EXPECT().Delete()
-
-			m.IssueCreate = func(_ plumbing.LocalRepo, _ *issuecmd.IssueCreateArgs) (*issuecmd.IssueCreateResult, error) {
-				return &issuecmd.IssueCreateResult{Reference: issueRef}, nil
-			}
-
-			mockCloneRepo.EXPECT().RefGet(issueRef).Return("", fmt.Errorf("error here"))
-
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+		It("should panic when repo does not exist at to height", func() {
+			repo := state.BareRepository()
+			repo.Config = state.MakeDefaultRepoConfig()
+			mockRepoKeeper.EXPECT().Get("repo1", uint64(1)).Return(repo)
+			mockRepoKeeper.EXPECT().Get("repo1", uint64(2)).Return(state.BareRepository())
+			err := &errors.ReqError{Code: "repo_not_found", HttpCode: 404, Msg: "repo not found", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CreateIssue("repo3", map[string]interface{}{
-					"id": 1,
-				})
+				m.GetRepoConfigHistory("repo1", 1, 2)
 			})
 		})
 
-		It("should panic when unable to get create issue reference", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-
-			issueRef := plumbing.MakeIssueReference("1")
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(issueRef).Return("", plumbing2.ErrReferenceNotFound)
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+		It("should return empty diff when config did not change", func() {
+			repo := state.BareRepository()
+			repo.Config = state.MakeDefaultRepoConfig()
+			mockRepoKeeper.EXPECT().Get("repo1", uint64(1)).Return(repo)
+			mockRepoKeeper.EXPECT().Get("repo1", uint64(2)).Return(repo)
+			res := m.GetRepoConfigHistory("repo1", 1, 2)
+			Expect(res).To(BeEmpty())
+		})
 
-			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
-			mockCloneRepo.EXPECT().GetPath().Return("/repo/path")
-			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
+		It("should return the governance changes between versions of the repo", func() {
+			appDB, stateTreeDB := testutil.GetDB()
+			defer appDB.Close()
+			stateTree, err := tree.NewSafeTree(stateTreeDB, 128)
+			Expect(err).To(BeNil())
+			repoKeeper := keepers.NewRepoKeeper(stateTree, appDB)
 
-			m.IssueCreate = func(_ plumbing.LocalRepo, _ *issuecmd.IssueCreateArgs) (*issuecmd.IssueCreateResult, error) {
-				return &issuecmd.IssueCreateResult{Reference: issueRef}, nil
-			}
+			repo := state.BareRepository()
+			repo.Config = state.MakeDefaultRepoConfig()
+			repoKeeper.Update("repo1", repo)
+			_, _, err = stateTree.SaveVersion()
+			Expect(err).To(BeNil())
+			oldQuorum := *repo.Config.Gov.PropQuorum
 
-			mockCloneRepo.EXPECT().RefGet(issueRef).Return("hash123", nil)
+			repo.Config.Gov.PropQuorum = pointer.ToString("50")
+			repo.Config.Gov.Voter = state.VoterNetStakers.Ptr()
+			repoKeeper.Update("repo1", repo)
+			_, _, err = stateTree.SaveVersion()
+			Expect(err).To(BeNil())
 
-			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
-			mockTempRepoMgr.EXPECT().Add("/repo/path").Return("repoId_123")
-			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+			mockLogic := mocks.NewMockLogic(ctrl)
+			mockLogic.EXPECT().Config().Return(cfg).AnyTimes()
+			mockLogic.EXPECT().RepoKeeper().Return(repoKeeper).AnyTimes()
+			m.Close()
+			m = modules.NewRepoModule(mockService, mockRepoSrv, mockLogic)
 
-			assert.NotPanics(GinkgoT(), func() {
-				res := m.CreateIssue("repo3", map[string]interface{}{
-					"id": 1,
-				})
-				Expect(res).To(Equal(util.Map(map[string]interface{}{
-					"hash":      "hash123",
-					"reference": issueRef,
-					"repoID":    "repoId_123",
-				})))
-			})
+			res := m.GetRepoConfigHistory("repo1", 1, 2)
+			Expect(res).To(HaveLen(2))
+			Expect(res["governance.propVoter"]).To(Equal(util.Map{"old": "0", "new": "1"}))
+			Expect(res["governance.propQuorum"]).To(Equal(util.Map{"old": oldQuorum, "new": "50"}))
 		})
 	})
 
-	Describe(".CreateMergeRequest()", func() {
-		It("should panic when repo name was not provided", func() {
-			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+	Describe(".DepositProposalFee", func() {
+		It("should panic when unable to decode params", func() {
+			params := map[string]interface{}{"id": struct{}{}}
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "1 error(s) decoding:\n\n* 'id' expected type 'string', got unconvertible type 'struct {}', value: '{}'", Field: "params"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CreateMergeRequest("", nil)
+				m.DepositProposalFee(params)
 			})
 		})
 
-		It("should panic when repo was not found", func() {
-			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
-			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CreateMergeRequest("unknown", nil)
-			})
+		It("should return tx map equivalent if payloadOnly=true", func() {
+			key := ""
+			params := map[string]interface{}{"id": 1}
+			res := m.DepositProposalFee(params, key, true)
+			Expect(res["id"]).To(Equal("1"))
+			Expect(res).ToNot(HaveKey("hash"))
+			Expect(res["type"]).To(Equal(float64(txns.TxTypeRepoProposalSendFee)))
+			Expect(res).To(And(
+				HaveKey("timestamp"),
+				HaveKey("nonce"),
+				HaveKey("id"),
+				HaveKey("type"),
+				HaveKey("senderPubKey"),
+				HaveKey("fee"),
+				HaveKey("sig"),
+			))
 		})
 
-		It("should panic when unable to find a free post ID", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			m.PostIDFinder = func(_ plumbing.LocalRepo, _ int, _ string) (int, error) {
-				return 0, fmt.Errorf("error here")
-			}
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+		It("should panic if unable to add tx to mempool", func() {
+			params := map[string]interface{}{"id": 1}
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(nil, fmt.Errorf("error"))
+			err := &errors.ReqError{Code: "err_mempool", HttpCode: 400, Msg: "error", Field: ""}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CreateMergeRequest("repo3", nil)
+				m.DepositProposalFee(params, "", false)
 			})
 		})
 
-		It("should panic when unable to clone repository", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(plumbing.MakeMergeRequestReference("1")).Return("", plumbing2.ErrReferenceNotFound)
-			mockRepo.EXPECT().Clone(plumbing.CloneOptions{
-				Bare:          false,
-				ReferenceName: "",
-				Depth:         1,
-			}).Return(nil, "", fmt.Errorf("error here"))
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
-				return mockRepo, nil
-			}
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "failed to clone repo: error here", Field: ""}
+		It("should return tx hash on success", func() {
+			params := map[string]interface{}{"id": 1}
+			hash := util.StrToHexBytes("tx_hash")
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(hash, nil)
+			res := m.DepositProposalFee(params, "", false)
+			Expect(res).To(HaveKey("hash"))
+			Expect(res["hash"]).To(Equal(hash))
+		})
+	})
+
+	Describe(".AddContributor", func() {
+		It("should panic when unable to decode params", func() {
+			params := map[string]interface{}{"id": struct{}{}}
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "1 error(s) decoding:\n\n* 'id' expected type 'string', got unconvertible type 'struct {}', value: '{}'", Field: "params"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CreateMergeRequest("repo3", map[string]interface{}{
-					"id": 1,
-				})
+				m.AddContributor(params)
 			})
 		})
 
-		It("should panic when unable to create merge request", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(plumbing.MakeMergeRequestReference("1")).Return("", plumbing2.ErrReferenceNotFound)
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
-
-			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
-			mockCloneRepo.EXPECT().Delete()
+		It("should return tx map equivalent if payloadOnly=true", func() {
+			key := ""
+			params := map[string]interface{}{"id": 1}
+			res := m.AddContributor(params, key, true)
+			Expect(res["id"]).To(Equal("1"))
+			Expect(res).ToNot(HaveKey("hash"))
+			Expect(res["type"]).To(Equal(float64(txns.TxTypeRepoProposalRegisterPushKey)))
+			Expect(res).To(And(
+				HaveKey("timestamp"),
+				HaveKey("nonce"),
+				HaveKey("policies"),
+				HaveKey("namespace"),
+				HaveKey("namespaceOnly"),
+				HaveKey("keys"),
+				HaveKey("id"),
+				HaveKey("type"),
+				HaveKey("senderPubKey"),
+				HaveKey("fee"),
+				HaveKey("sig"),
+			))
+		})
 
-			m.MergeRequestCreate = func(r plumbing.LocalRepo, args *mergecmd.MergeRequestCreateArgs) (*mergecmd.MergeRequestCreateResult, error) {
-				return nil, fmt.Errorf("error here")
-			}
+		It("should panic if in attach mode and RPC client method returns error", func() {
+			mockClient := mocks2.NewMockClient(ctrl)
+			mockRepoClient := mocks2.NewMockRepo(ctrl)
+			mockClient.EXPECT().Repo().Return(mockRepoClient)
+			m.Client = mockClient
 
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+			mockRepoClient.EXPECT().AddContributors(gomock.Any()).Return(&api.ResultHash{}, fmt.Errorf("error"))
+			params := map[string]interface{}{"id": 1}
+			err := fmt.Errorf("error")
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CreateMergeRequest("repo3", map[string]interface{}{
-					"id": 1,
-				})
+				m.AddContributor(params)
 			})
 		})
 
-		It("should panic when unable to get create merge request reference", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-
-			mrRef := plumbing.MakeMergeRequestReference("1")
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(mrRef).Return("", plumbing2.ErrReferenceNotFound)
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+		It("should not panic if in attach mode and RPC client method returns no error", func() {
+			mockClient := mocks2.NewMockClient(ctrl)
+			mockRepoClient := mocks2.NewMockRepo(ctrl)
+			mockClient.EXPECT().Repo().Return(mockRepoClient)
+			m.Client = mockClient
 
-			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
-			mockCloneRepo.EXPECT().Delete()
+			mockRepoClient.EXPECT().AddContributors(gomock.Any()).Return(&api.ResultHash{}, nil)
+			params := map[string]interface{}{"id": 1}
+			assert.NotPanics(GinkgoT(), func() {
+				m.AddContributor(params)
+			})
+		})
 
-			m.MergeRequestCreate = func(r plumbing.LocalRepo, args *mergecmd.MergeRequestCreateArgs) (*mergecmd.MergeRequestCreateResult, error) {
-				return &mergecmd.MergeRequestCreateResult{Reference: mrRef}, nil
-			}
+		It("should panic if unable to add tx to mempool", func() {
+			params := map[string]interface{}{"id": 1}
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(nil, fmt.Errorf("error"))
+			err := &errors.ReqError{Code: "err_mempool", HttpCode: 400, Msg: "error", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.AddContributor(params, "", false)
+			})
+		})
 
-			mockCloneRepo.EXPECT().RefGet(mrRef).Return("", fmt.Errorf("error here"))
+		It("should return tx hash on success", func() {
+			params := map[string]interface{}{"id": 1}
+			hash := util.StrToHexBytes("tx_hash")
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(hash, nil)
+			res := m.AddContributor(params, "", false)
+			Expect(res).To(HaveKey("hash"))
+			Expect(res["hash"]).To(Equal(hash))
+		})
+	})
 
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+	Describe(".Track", func() {
+		It("should panic if unable to add repo", func() {
+			mockRepoSyncInfoKeeper.EXPECT().Track("repo1", []uint64{100}).Return(fmt.Errorf("error"))
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error", Field: ""}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CreateMergeRequest("repo3", map[string]interface{}{
-					"id": 1,
-				})
+				m.Track("repo1", 100)
 			})
 		})
 
-		It("should panic when unable to get create issue reference", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
+		It("should not panic if able to add repo", func() {
+			mockRepoSyncInfoKeeper.EXPECT().Track("repo1", []uint64{100}).Return(nil)
+			assert.NotPanics(GinkgoT(), func() {
+				m.Track("repo1", 100)
+			})
+		})
+	})
 
-			mrRef := plumbing.MakeMergeRequestReference("1")
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(mrRef).Return("", plumbing2.ErrReferenceNotFound)
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+	Describe(".UnTrack", func() {
+		It("should panic if unable to untrack repo", func() {
+			mockRepoSyncInfoKeeper.EXPECT().UnTrack("repo1").Return(fmt.Errorf("error"))
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.UnTrack("repo1")
+			})
+		})
 
-			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
-			mockCloneRepo.EXPECT().GetPath().Return("/repo/path")
-			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
+		It("should not panic if able to untrack repo", func() {
+			mockRepoSyncInfoKeeper.EXPECT().UnTrack("repo1").Return(nil)
+			assert.NotPanics(GinkgoT(), func() {
+				m.UnTrack("repo1")
+			})
+		})
+	})
 
-			m.MergeRequestCreate = func(r plumbing.LocalRepo, args *mergecmd.MergeRequestCreateArgs) (*mergecmd.MergeRequestCreateResult, error) {
-				return &mergecmd.MergeRequestCreateResult{Reference: mrRef}, nil
+	Describe(".Get", func() {
+		It("should panic if unable to untrack repo", func() {
+			tracked := map[string]*core.TrackedRepo{
+				"repo1": {UpdatedAt: 10},
 			}
+			mockRepoSyncInfoKeeper.EXPECT().Tracked().Return(tracked)
+			res := m.GetTracked()
+			Expect(res).To(Equal(util.Map(util.ToJSONMap(tracked))))
+		})
+	})
 
-			mockCloneRepo.EXPECT().RefGet(mrRef).Return("hash123", nil)
+	Describe(".GetTrackedStatus", func() {
+		var mockSysKeeper *mocks.MockSystemKeeper
 
-			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
-			mockTempRepoMgr.EXPECT().Add("/repo/path").Return("repoId_123")
-			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+		BeforeEach(func() {
+			mockSysKeeper = mocks.NewMockSystemKeeper(ctrl)
+			mockLogic.EXPECT().SysKeeper().Return(mockSysKeeper).AnyTimes()
+		})
 
-			assert.NotPanics(GinkgoT(), func() {
-				res := m.CreateMergeRequest("repo3", map[string]interface{}{
-					"id": 1,
-				})
-				Expect(res).To(Equal(util.Map(map[string]interface{}{
-					"hash":      "hash123",
-					"reference": mrRef,
-					"repoID":    "repoId_123",
-				})))
+		It("should panic if unable to get last block info", func() {
+			mockSysKeeper.EXPECT().GetLastBlockInfo().Return(nil, fmt.Errorf("error"))
+			err := &errors.ReqError{Code: modules.StatusCodeServerErr, HttpCode: 500, Msg: "error", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetTrackedStatus()
 			})
 		})
+
+		It("should return empty result if no repo is tracked", func() {
+			mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 100}, nil)
+			mockRepoSyncInfoKeeper.EXPECT().Tracked().Return(map[string]*core.TrackedRepo{})
+			Expect(m.GetTrackedStatus()).To(BeEmpty())
+		})
+
+		It("should report repos that are behind and up-to-date", func() {
+			mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 100}, nil)
+			mockRepoSyncInfoKeeper.EXPECT().Tracked().Return(map[string]*core.TrackedRepo{
+				"repo2": {UpdatedAt: 100},
+				"repo1": {UpdatedAt: 90},
+			})
+			res := m.GetTrackedStatus()
+			Expect(res).To(HaveLen(2))
+			Expect(res[0]).To(Equal(util.Map{"name": "repo1", "lastSyncHeight": uint64(90),
+				"chainHeight": uint64(100), "blocksBehind": uint64(10), "behind": true}))
+			Expect(res[1]).To(Equal(util.Map{"name": "repo2", "lastSyncHeight": uint64(100),
+				"chainHeight": uint64(100), "blocksBehind": uint64(0), "behind": false}))
+		})
 	})
 
-	Describe(".CloseIssue", func() {
-		It("should panic when repo name was not provided", func() {
-			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+	Describe(".ListPath", func() {
+
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CloseIssue("", plumbing.MakeIssueReference(1))
+				m.ListPath("", "")
 			})
 		})
 
-		It("should panic when repo was not found", func() {
-			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CloseIssue("unknown", plumbing.MakeIssueReference(1))
+				m.ListPath("unknown", "")
 			})
 		})
 
-		It("should panic when issue reference was not found", func() {
+		It("should return entries when successful", func() {
 			cfg.SetRepoRoot("../remote/repo/testdata")
-			err := &errors.ReqError{Code: "issue_not_found", HttpCode: 404, Msg: "issue not found", Field: "reference"}
-			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CloseIssue("repo3", plumbing.MakeIssueReference(1))
-			})
+			res := m.ListPath("repo1", "")
+			Expect(res).To(HaveLen(4))
 		})
 
-		It("should panic when unable to clone repository", func() {
+		It("should return error when path is unknown", func() {
 			cfg.SetRepoRoot("../remote/repo/testdata")
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(plumbing.MakeIssueReference("1")).Return("", nil)
-			mockRepo.EXPECT().Clone(plumbing.CloneOptions{
-				Bare:          false,
-				ReferenceName: "",
-				Depth:         1,
-			}).Return(nil, "", fmt.Errorf("error here"))
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
-				return mockRepo, nil
-			}
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "failed to clone repo: error here", Field: ""}
+			err := &errors.ReqError{Code: modules.StatusCodePathNotFound, HttpCode: 404, Msg: "path not found", Field: "path"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CloseIssue("repo3", plumbing.MakeIssueReference(1))
+				m.ListPath("repo1", "unknown")
 			})
 		})
 
-		It("should panic when unable to close issue", func() {
+		It("should return empty list when revision is invalid", func() {
 			cfg.SetRepoRoot("../remote/repo/testdata")
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(plumbing.MakeIssueReference("1")).Return("", nil)
-			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
-			mockCloneRepo.EXPECT().Delete()
-			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
-			m.IssueClose = func(r plumbing.LocalRepo, args *issuecmd.IssueCloseArgs) (*issuecmd.IssueCloseResult, error) {
-				return nil, fmt.Errorf("error here; cant close")
-			}
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
-				return mockRepo, nil
-			}
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here; cant close", Field: ""}
+			res := m.ListPath("repo1", ".", "something")
+			Expect(res).To(BeEmpty())
+		})
+	})
+
+	Describe(".GetCodeOwnership", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CloseIssue("repo3", plumbing.MakeIssueReference(1))
+				m.GetCodeOwnership("", "")
 			})
 		})
 
-		It("should panic when unable to get hash of updated reference", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			ref := plumbing.MakeIssueReference("1")
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(ref).Return("", nil)
-			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
-			m.IssueClose = func(r plumbing.LocalRepo, args *issuecmd.IssueCloseArgs) (*issuecmd.IssueCloseResult, error) {
-				return &issuecmd.IssueCloseResult{Reference: ref}, nil
-			}
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
-				return mockRepo, nil
-			}
-			mockCloneRepo.EXPECT().RefGet(ref).Return("", fmt.Errorf("error here"))
-			mockCloneRepo.EXPECT().Delete()
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CloseIssue("repo3", plumbing.MakeIssueReference(1))
+				m.GetCodeOwnership("unknown", "")
 			})
 		})
 
-		It("should add repo to temporary repo manager; "+
-			"return ID from temporary repo manager;"+
-			"return name and hash of new issue reference;", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			ref := plumbing.MakeIssueReference("1")
-			hash := "hash_123"
-
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(ref).Return("", nil)
-			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
-			m.IssueClose = func(r plumbing.LocalRepo, args *issuecmd.IssueCloseArgs) (*issuecmd.IssueCloseResult, error) {
-				return &issuecmd.IssueCloseResult{Reference: ref}, nil
-			}
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
-				return mockRepo, nil
-			}
+		When("repository exists", func() {
+			BeforeEach(func() {
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+				path := cfg.GetRepoPath("repo1")
+				testutil2.AppendToFile(path, "dir/a.txt", "line 1\nline 2\nline 3\n")
+				testutil2.ExecGit(path, "add", ".")
+				testutil2.ExecGit(path, "commit", "--author", "Alice <alice@example.com>", "-m", "commit 1")
+				testutil2.AppendToFile(path, "dir/b.txt", "line 1\n")
+				testutil2.ExecGit(path, "add", ".")
+				testutil2.ExecGit(path, "commit", "--author", "Bob <bob@example.com>", "-m", "commit 2")
+			})
 
-			mockCloneRepo.EXPECT().RefGet(ref).Return(hash, nil)
+			It("should return authors sorted by ownership", func() {
+				res := m.GetCodeOwnership("repo1", "dir")
+				Expect(res).To(HaveLen(2))
+				Expect(res[0]["author"]).To(Equal("alice@example.com"))
+				Expect(res[0]["lines"]).To(Equal(3))
+				Expect(res[0]["ownership"]).To(Equal(0.75))
+				Expect(res[1]["author"]).To(Equal("bob@example.com"))
+				Expect(res[1]["ownership"]).To(Equal(0.25))
+			})
 
-			tempRepoId := "repoId_123"
-			mockCloneRepo.EXPECT().GetPath().Return("/repo/path")
-			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
-			mockTempRepoMgr.EXPECT().Add("/repo/path").Return(tempRepoId)
-			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+			It("should panic if path is unknown", func() {
+				err := &errors.ReqError{Code: modules.StatusCodePathNotFound, HttpCode: 404, Msg: "path not found", Field: "path"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.GetCodeOwnership("repo1", "unknown")
+				})
+			})
 
-			assert.NotPanics(GinkgoT(), func() {
-				res := m.CloseIssue("repo3", plumbing.MakeIssueReference(1))
-				Expect(res["reference"]).To(Equal(ref))
-				Expect(res["hash"]).To(Equal(hash))
-				Expect(res["repoID"]).To(Equal(tempRepoId))
+			It("should return empty list when revision is invalid", func() {
+				res := m.GetCodeOwnership("repo1", "dir", "something")
+				Expect(res).To(BeEmpty())
 			})
 		})
 	})
 
-	Describe(".ReadIssue", func() {
-		It("should panic when repo name was not provided", func() {
-			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+	Describe(".ListRefs", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReadIssue("", plumbing.MakeIssueReference(1))
+				m.ListRefs("")
 			})
 		})
 
-		It("should panic when repo was not found", func() {
-			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReadIssue("unknown", plumbing.MakeIssueReference(1))
+				m.ListRefs("unknown")
 			})
 		})
 
-		It("should panic when unable to get the issue", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			m.IssueRead = func(_ plumbing.LocalRepo, _ *issuecmd.IssueReadArgs) (plumbing.Comments, error) {
-				return nil, fmt.Errorf("error here")
-			}
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+		It("should panic if a pattern is not valid", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "pattern is not valid", Field: "pattern"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReadIssue("repo3", plumbing.MakeIssueReference(1))
+				m.ListRefs("repo1", "refs/[")
+			})
+		})
+
+		When("repository exists", func() {
+			var hash string
+
+			BeforeEach(func() {
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+				path := cfg.GetRepoPath("repo1")
+				testutil2.AppendToFile(path, "a.txt", "line 1\n")
+				testutil2.ExecGit(path, "add", ".")
+				testutil2.ExecGit(path, "commit", "-m", "commit 1")
+				testutil2.ExecGit(path, "branch", "-M", "master")
+				testutil2.ExecGit(path, "branch", "dev")
+				testutil2.ExecGit(path, "branch", "issues/1")
+				testutil2.ExecGit(path, "branch", "merges/1")
+				testutil2.ExecGit(path, "tag", "v1")
+				testutil2.ExecGit(path, "notes", "add", "-m", "a note")
+				testutil2.ExecGit(path, "update-ref", "refs/meta/ci", "HEAD")
+				testutil2.ExecGit(path, "update-ref", "refs/custom/x", "HEAD")
+				hash = strings.TrimSpace(string(testutil2.ExecGit(path, "rev-parse", "HEAD")))
+			})
+
+			It("should return all references with their kind", func() {
+				res := m.ListRefs("repo1")
+				kinds := map[string]string{}
+				for _, ref := range res {
+					kinds[ref["name"].(string)] = ref["kind"].(string)
+				}
+				Expect(kinds).To(Equal(map[string]string{
+					"refs/custom/x":       "other",
+					"refs/heads/dev":      "branch",
+					"refs/heads/issues/1": "issue",
+					"refs/heads/master":   "branch",
+					"refs/heads/merges/1": "merge-request",
+					"refs/meta/ci":        "meta",
+					"refs/notes/commits":  "note",
+					"refs/tags/v1":        "tag",
+				}))
+			})
+
+			It("should return only references matching a pattern", func() {
+				res := m.ListRefs("repo1", "refs/tags/*", "refs/meta/*")
+				Expect(res).To(Equal([]util.Map{
+					{"name": "refs/meta/ci", "kind": "meta", "hash": hash},
+					{"name": "refs/tags/v1", "kind": "tag", "hash": hash},
+				}))
 			})
 		})
+	})
 
-		It("should not panic on success", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			m.IssueRead = func(_ plumbing.LocalRepo, _ *issuecmd.IssueReadArgs) (plumbing.Comments, error) {
-				return []*plumbing.Comment{
-					{Author: "a"},
-				}, nil
-			}
-			assert.NotPanics(GinkgoT(), func() {
-				res := m.ReadIssue("repo3", plumbing.MakeIssueReference(1))
-				Expect(res).To(HaveLen(1))
-				Expect(res[0]).To(HaveKey("author"))
-				Expect(res[0]["author"]).To(Equal("a"))
+	Describe(".GetFileHistory", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetFileHistory("", "file.txt", 0)
 			})
 		})
-	})
 
-	Describe(".ReopenIssue", func() {
-		It("should panic when repo name was not provided", func() {
-			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+		It("should panic if file path was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "file path is required", Field: "file"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReopenIssue("", plumbing.MakeIssueReference(1))
+				m.GetFileHistory("repo1", "", 0)
 			})
 		})
 
-		It("should panic when repo was not found", func() {
-			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReopenIssue("unknown", plumbing.MakeIssueReference(1))
+				m.GetFileHistory("unknown", "file.txt", 0)
 			})
 		})
 
-		It("should panic when issue reference was not found", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			err := &errors.ReqError{Code: "issue_not_found", HttpCode: 404, Msg: "issue not found", Field: "reference"}
+		When("repository exists", func() {
+			var path string
+
+			BeforeEach(func() {
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+				path = cfg.GetRepoPath("repo1")
+			})
+
+			It("should return empty list when the repository has no commits", func() {
+				res := m.GetFileHistory("repo1", "file.txt", 0)
+				Expect(res).To(BeEmpty())
+			})
+
+			When("the file has been changed", func() {
+				BeforeEach(func() {
+					testutil2.AppendCommit(path, "file.txt", "line 1\n", "commit 1")
+					testutil2.AppendCommit(path, "other.txt", "line 1\n", "commit 2")
+					testutil2.AppendCommit(path, "file.txt", "line 2\n", "commit 3")
+				})
+
+				It("should return the commits that changed the file, newest first", func() {
+					res := m.GetFileHistory("repo1", "file.txt", 0)
+					Expect(res).To(HaveLen(2))
+					Expect(res[0]["change"]).To(Equal("modified"))
+					Expect(res[0]["commit"]).To(HaveKeyWithValue("hash", testutil2.GetRecentCommitHash(path, "HEAD")))
+					Expect(res[1]["change"]).To(Equal("added"))
+					Expect(res[1]["commit"]).To(HaveKeyWithValue("hash", testutil2.GetRecentCommitHash(path, "HEAD~2")))
+				})
+
+				It("should accept a path prefixed with ./", func() {
+					res := m.GetFileHistory("repo1", "./file.txt", 0)
+					Expect(res).To(HaveLen(2))
+				})
+
+				It("should return limited commits when limit is > 0", func() {
+					res := m.GetFileHistory("repo1", "file.txt", 1)
+					Expect(res).To(HaveLen(1))
+					Expect(res[0]["change"]).To(Equal("modified"))
+				})
+
+				It("should panic if file is unknown", func() {
+					err := &errors.ReqError{Code: modules.StatusCodePathNotFound, HttpCode: 404, Msg: "path not found", Field: "file"}
+					assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+						m.GetFileHistory("repo1", "unknown.txt", 0)
+					})
+				})
+			})
+		})
+	})
+
+	Describe(".ReadFileLines", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReopenIssue("repo3", plumbing.MakeIssueReference(1))
+				m.ReadFileLines("", "")
 			})
 		})
-		//
-		It("should panic when unable to clone repository", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(plumbing.MakeIssueReference("1")).Return("", nil)
-			mockRepo.EXPECT().Clone(plumbing.CloneOptions{
-				Bare:          false,
-				ReferenceName: "",
-				Depth:         1,
-			}).Return(nil, "", fmt.Errorf("error here"))
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
-				return mockRepo, nil
-			}
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "failed to clone repo: error here", Field: ""}
+
+		It("should panic if file path was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "file path is required", Field: "file"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReopenIssue("repo3", plumbing.MakeIssueReference(1))
+				m.ReadFileLines("repo1", "")
 			})
 		})
 
-		It("should panic when unable to reopen issue", func() {
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReadFileLines("unknown", "a/b")
+			})
+		})
+
+		It("should panic if file path was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "file path is required", Field: "file"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReadFileLines("repo1", "")
+			})
+		})
+
+		It("should panic if file path does not exist", func() {
 			cfg.SetRepoRoot("../remote/repo/testdata")
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(plumbing.MakeIssueReference("1")).Return("", nil)
-			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
-			mockCloneRepo.EXPECT().Delete()
-			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
-			m.IssueReopen = func(r plumbing.LocalRepo, args *issuecmd.IssueReopenArgs) (*issuecmd.IssueReopenResult, error) {
-				return nil, fmt.Errorf("error here; cant reopen")
-			}
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
-				return mockRepo, nil
-			}
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here; cant reopen", Field: ""}
+			err := &errors.ReqError{Code: "path_not_found", HttpCode: 404, Msg: "path not found", Field: "file"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReopenIssue("repo3", plumbing.MakeIssueReference(1))
+				m.ReadFileLines("repo1", "unknown")
 			})
 		})
 
-		It("should panic when unable to get hash of updated reference", func() {
+		It("should panic if file path was not a file", func() {
 			cfg.SetRepoRoot("../remote/repo/testdata")
-			ref := plumbing.MakeIssueReference("1")
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(ref).Return("", nil)
-			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
-			m.IssueReopen = func(r plumbing.LocalRepo, args *issuecmd.IssueReopenArgs) (*issuecmd.IssueReopenResult, error) {
-				return &issuecmd.IssueReopenResult{Reference: ref}, nil
-			}
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
-				return mockRepo, nil
-			}
-			mockCloneRepo.EXPECT().RefGet(ref).Return("", fmt.Errorf("error here"))
-			mockCloneRepo.EXPECT().Delete()
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+			err := &errors.ReqError{Code: "path_not_file", HttpCode: 400, Msg: "path is not a file", Field: "file"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReopenIssue("repo3", plumbing.MakeIssueReference(1))
+				m.ReadFileLines("repo1", "a")
 			})
 		})
 
-		It("should add repo to temporary repo manager; "+
-			"return ID from temporary repo manager;"+
-			"return name and hash of new merge request reference;", func() {
+		It("should return lines of a file", func() {
 			cfg.SetRepoRoot("../remote/repo/testdata")
-			ref := plumbing.MakeIssueReference("1")
-			hash := "hash_123"
+			lines := m.ReadFileLines("repo1", "file.txt")
+			Expect(lines).To(Equal([]string{"Hello World", "Hello Friend", "Hello Degens"}))
+		})
+	})
 
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(ref).Return("", nil)
-			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
-			m.IssueReopen = func(r plumbing.LocalRepo, args *issuecmd.IssueReopenArgs) (*issuecmd.IssueReopenResult, error) {
-				return &issuecmd.IssueReopenResult{Reference: ref}, nil
-			}
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
-				return mockRepo, nil
-			}
+	Describe(".GetReadme", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetReadme("", "")
+			})
+		})
 
-			mockCloneRepo.EXPECT().RefGet(ref).Return(hash, nil)
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetReadme("unknown", "master")
+			})
+		})
 
-			tempRepoId := "repoId_123"
-			mockCloneRepo.EXPECT().GetPath().Return("/repo/path")
-			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
-			mockTempRepoMgr.EXPECT().Add("/repo/path").Return(tempRepoId)
-			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+		When("repo exists", func() {
+			var mockRepo *mocks.MockLocalRepo
 
-			assert.NotPanics(GinkgoT(), func() {
-				res := m.ReopenIssue("repo3", plumbing.MakeIssueReference(1))
-				Expect(res["reference"]).To(Equal(ref))
-				Expect(res["hash"]).To(Equal(hash))
-				Expect(res["repoID"]).To(Equal(tempRepoId))
+			BeforeEach(func() {
+				mockRepo = mocks.NewMockLocalRepo(ctrl)
+				m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			})
+
+			It("should panic if branch does not exist", func() {
+				mockRepo.EXPECT().GetFile("refs/heads/dev", "README.md").Return("", plumbing2.ErrReferenceNotFound)
+				err := &errors.ReqError{Code: modules.StatusCodeBranchNotFound, HttpCode: 404, Msg: "branch does not exist", Field: "branch"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.GetReadme("repo1", "dev")
+				})
+			})
+
+			It("should panic if repo has no README", func() {
+				mockRepo.EXPECT().GetFile("HEAD", gomock.Any()).Return("", repo.ErrPathNotFound).AnyTimes()
+				err := &errors.ReqError{Code: modules.StatusCodeReadmeNotFound, HttpCode: 404, Msg: "readme not found", Field: ""}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.GetReadme("repo1", "")
+				})
+			})
+
+			It("should return the first README found", func() {
+				mockRepo.EXPECT().GetFile("refs/heads/master", "README.md").Return("", repo.ErrPathNotFound)
+				mockRepo.EXPECT().GetFile("refs/heads/master", "README").Return("hello", nil)
+				res := m.GetReadme("repo1", "master")
+				Expect(res).To(Equal(util.Map{"name": "README", "content": "hello"}))
+			})
+
+			It("should look up preferred names in order", func() {
+				mockRepo.EXPECT().GetFile("HEAD", "docs").Return("", repo.ErrPathNotAFile)
+				mockRepo.EXPECT().GetFile("HEAD", "INTRO.md").Return("intro", nil)
+				res := m.GetReadme("repo1", "", "docs", "INTRO.md", "README.md")
+				Expect(res).To(Equal(util.Map{"name": "INTRO.md", "content": "intro"}))
 			})
 		})
 	})
 
-	Describe(".ListIssues", func() {
-		It("should panic when repo name was not provided", func() {
-			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+	Describe(".ReadFileChunk", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ListIssues("")
+				m.ReadFileChunk("", "", 0, 1)
 			})
 		})
 
-		It("should panic when repo was not found", func() {
-			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+		It("should panic if file path was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "file path is required", Field: "file"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ListIssues("unknown")
+				m.ReadFileChunk("repo1", "", 0, 1)
 			})
 		})
 
-		It("should panic when unable to list issues", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			m.IssueList = func(_ plumbing.LocalRepo, _ *issuecmd.IssueListArgs) (plumbing.Posts, error) {
-				return nil, fmt.Errorf("error here")
-			}
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+		It("should panic if offset is negative", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "offset must be a non-negative number", Field: "offset"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ListIssues("repo3")
+				m.ReadFileChunk("repo1", "file.txt", -1, 1)
 			})
 		})
 
-		It("should not panic on success", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			m.IssueList = func(_ plumbing.LocalRepo, _ *issuecmd.IssueListArgs) (plumbing.Posts, error) {
-				return []plumbing.PostEntry{
-					&plumbing.Post{Title: "title"},
-				}, nil
-			}
-			assert.NotPanics(GinkgoT(), func() {
-				res := m.ListIssues("repo3")
-				Expect(res).To(HaveLen(1))
-				Expect(res[0]).To(HaveKey("title"))
-				Expect(res[0]["title"]).To(Equal("title"))
+		It("should panic if length is not greater than zero", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "length must be greater than zero", Field: "length"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReadFileChunk("repo1", "file.txt", 0, 0)
 			})
 		})
-	})
 
-	Describe(".CloseMergeRequest()", func() {
-		It("should panic when repo name was not provided", func() {
-			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CloseMergeRequest("", plumbing.MakeMergeRequestReference(1))
+				m.ReadFileChunk("unknown", "file.txt", 0, 1)
 			})
 		})
 
-		It("should panic when repo was not found", func() {
-			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
-			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CloseMergeRequest("unknown", plumbing.MakeMergeRequestReference(1))
+		When("repo exists", func() {
+			var mockRepo *mocks.MockLocalRepo
+
+			BeforeEach(func() {
+				mockRepo = mocks.NewMockLocalRepo(ctrl)
+				m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
 			})
-		})
 
-		It("should panic when merge request reference was not found", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			err := &errors.ReqError{Code: "merge_request_not_found", HttpCode: 404, Msg: "merge request not found", Field: "reference"}
-			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CloseMergeRequest("repo3", plumbing.MakeMergeRequestReference(1))
+			It("should panic if path does not exist", func() {
+				mockRepo.EXPECT().GetFileChunk("HEAD", "unknown", int64(0), int64(1)).Return(nil, int64(0), repo.ErrPathNotFound)
+				err := &errors.ReqError{Code: modules.StatusCodePathNotFound, HttpCode: 404, Msg: "path not found", Field: "file"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.ReadFileChunk("repo1", "unknown", 0, 1)
+				})
+			})
+
+			It("should panic if path is not a file", func() {
+				mockRepo.EXPECT().GetFileChunk("HEAD", "a", int64(0), int64(1)).Return(nil, int64(0), repo.ErrPathNotAFile)
+				err := &errors.ReqError{Code: modules.StatusCodePathNotAFile, HttpCode: 400, Msg: "path is not a file", Field: "file"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.ReadFileChunk("repo1", "a", 0, 1)
+				})
+			})
+
+			It("should panic if offset is beyond the end of the file", func() {
+				mockRepo.EXPECT().GetFileChunk("HEAD", "file.txt", int64(100), int64(1)).Return(nil, int64(38), repo.ErrOffsetOutOfRange)
+				err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "offset is out of range", Field: "offset"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.ReadFileChunk("repo1", "file.txt", 100, 1)
+				})
+			})
+
+			It("should return chunk of the file at the given revision", func() {
+				mockRepo.EXPECT().GetFileChunk("refs/heads/dev", "file.txt", int64(6), int64(12)).Return([]byte("World\nHello "), int64(38), nil)
+				res := m.ReadFileChunk("repo1", "./file.txt", 6, 12, "refs/heads/dev")
+				Expect(res).To(Equal(util.Map{"content": "World\nHello ", "offset": int64(6), "length": int64(12), "size": int64(38)}))
 			})
 		})
+	})
 
-		It("should panic when unable to clone repository", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(plumbing.MakeMergeRequestReference("1")).Return("", nil)
-			mockRepo.EXPECT().Clone(plumbing.CloneOptions{
-				Bare:          false,
-				ReferenceName: "",
-				Depth:         1,
-			}).Return(nil, "", fmt.Errorf("error here"))
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
-				return mockRepo, nil
-			}
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "failed to clone repo: error here", Field: ""}
+	Describe(".Archive", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CloseMergeRequest("repo3", plumbing.MakeMergeRequestReference(1))
+				m.Archive("")
 			})
 		})
 
-		It("should panic when unable to close merge request", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(plumbing.MakeMergeRequestReference("1")).Return("", nil)
-			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
-			mockCloneRepo.EXPECT().Delete()
-			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
-			m.MergeRequestClose = func(r plumbing.LocalRepo, args *mergecmd.MergeReqCloseArgs) (*mergecmd.MergeReqCloseResult, error) {
-				return nil, fmt.Errorf("error here; cant close")
-			}
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
-				return mockRepo, nil
-			}
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here; cant close", Field: ""}
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CloseMergeRequest("repo3", plumbing.MakeMergeRequestReference(1))
+				m.Archive("unknown")
 			})
 		})
 
-		It("should panic when unable to get reference of created merge request", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			ref := plumbing.MakeMergeRequestReference("1")
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(ref).Return("", nil)
-			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
-			m.MergeRequestClose = func(r plumbing.LocalRepo, args *mergecmd.MergeReqCloseArgs) (*mergecmd.MergeReqCloseResult, error) {
-				return &mergecmd.MergeReqCloseResult{Reference: ref}, nil
-			}
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
-				return mockRepo, nil
+		When("repo exists", func() {
+			var mockRepo *mocks.MockLocalRepo
+
+			BeforeEach(func() {
+				mockRepo = mocks.NewMockLocalRepo(ctrl)
+				m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			})
+
+			It("should panic if branch does not exist", func() {
+				mockRepo.EXPECT().WriteArchive("dev", gomock.Any()).Return(plumbing2.ErrReferenceNotFound)
+				err := &errors.ReqError{Code: modules.StatusCodeBranchNotFound, HttpCode: 404, Msg: "branch does not exist", Field: "revision"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.Archive("repo1", "dev")
+				})
+			})
+
+			It("should panic if commit does not exist", func() {
+				mockRepo.EXPECT().WriteArchive("HEAD", gomock.Any()).Return(plumbing2.ErrObjectNotFound)
+				err := &errors.ReqError{Code: modules.StatusCodeCommitNotFound, HttpCode: 404, Msg: "commit does not exist", Field: "revision"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.Archive("repo1")
+				})
+			})
+
+			It("should return base64 encoded archive on success", func() {
+				mockRepo.EXPECT().WriteArchive("HEAD", gomock.Any()).DoAndReturn(func(_ string, w io.Writer) error {
+					_, err := w.Write([]byte("archive"))
+					return err
+				})
+				res := m.Archive("repo1")
+				Expect(res).To(Equal(base64.StdEncoding.EncodeToString([]byte("archive"))))
+			})
+
+			It("should panic if archive exceeds the max archive size", func() {
+				cfg.Repo.MaxArchiveSize = 3
+				mockRepo.EXPECT().WriteArchive("HEAD", gomock.Any()).DoAndReturn(func(_ string, w io.Writer) error {
+					_, err := w.Write([]byte("archive"))
+					return err
+				})
+				err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 413, Msg: "archive exceeds the max archive size", Field: "revision"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.Archive("repo1")
+				})
+			})
+		})
+	})
+
+	Describe(".GetRepoContentHash", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetRepoContentHash("", "master")
+			})
+		})
+
+		It("should panic if reference was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "reference is required", Field: "ref"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetRepoContentHash("repo1", "")
+			})
+		})
+
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetRepoContentHash("unknown", "master")
+			})
+		})
+
+		When("repo exists", func() {
+			var mockRepo *mocks.MockLocalRepo
+
+			BeforeEach(func() {
+				mockRepo = mocks.NewMockLocalRepo(ctrl)
+				m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			})
+
+			It("should panic if branch does not exist", func() {
+				mockRepo.EXPECT().GetContentHash("dev").Return("", plumbing2.ErrReferenceNotFound)
+				err := &errors.ReqError{Code: modules.StatusCodeBranchNotFound, HttpCode: 404, Msg: "branch does not exist", Field: "ref"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.GetRepoContentHash("repo1", "dev")
+				})
+			})
+
+			It("should panic if commit does not exist", func() {
+				mockRepo.EXPECT().GetContentHash("abc").Return("", plumbing2.ErrObjectNotFound)
+				err := &errors.ReqError{Code: modules.StatusCodeCommitNotFound, HttpCode: 404, Msg: "commit does not exist", Field: "ref"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.GetRepoContentHash("repo1", "abc")
+				})
+			})
+
+			It("should return content hash on success", func() {
+				mockRepo.EXPECT().GetContentHash("master").Return("hash", nil)
+				Expect(m.GetRepoContentHash("repo1", "master")).To(Equal("hash"))
+			})
+		})
+	})
+
+	Describe(".GetBranches", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetBranches("")
+			})
+		})
+
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetBranches("unknown")
+			})
+		})
+
+		It("should return expected branch(es)", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			lines := m.GetBranches("repo1")
+			Expect(lines).To(Equal([]string{"refs/heads/dev", "refs/heads/master"}))
+		})
+	})
+
+	Describe(".GetBranchesWithMeta", func() {
+		var mockRepo *mocks.MockLocalRepo
+		var hash = "8d998c7de21bbe561f7992bb983cef4b1554993b"
+		var hash2 = "b5a4a1b1c2d5e1b9a0a5c4e7f6b2a8d3c9e0f1a2"
+
+		BeforeEach(func() {
+			mockRepo = mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			mockRepo.EXPECT().GetBranches().Return([]string{"dev", "master"}, nil)
+		})
+
+		It("should return branch activity from the index when it matches the branch tip", func() {
+			mockRepoSyncInfoKeeper.EXPECT().GetBranchActivities("repo1").Return(map[string]*core.BranchActivity{
+				"refs/heads/dev":    {Hash: hash, LastCommitTime: 100},
+				"refs/heads/master": {Hash: hash2, LastCommitTime: 200},
+			}, nil)
+			mockRepo.EXPECT().RefGet("refs/heads/dev").Return(hash, nil)
+			mockRepo.EXPECT().RefGet("refs/heads/master").Return(hash2, nil)
+			res := m.GetBranchesWithMeta("repo1")
+			Expect(res).To(Equal([]util.Map{
+				{"name": "refs/heads/dev", "hash": hash, "lastCommitTime": int64(100)},
+				{"name": "refs/heads/master", "hash": hash2, "lastCommitTime": int64(200)},
+			}))
+		})
+
+		It("should compute activity of branches missing from the index without updating the index", func() {
+			mockRepoSyncInfoKeeper.EXPECT().GetBranchActivities("repo1").Return(map[string]*core.BranchActivity{
+				"refs/heads/dev": {Hash: hash, LastCommitTime: 100},
+			}, nil)
+			mockRepo.EXPECT().RefGet("refs/heads/dev").Return(hash, nil)
+			mockRepo.EXPECT().RefGet("refs/heads/master").Return(hash2, nil)
+			mockRepo.EXPECT().CommitObject(plumbing2.NewHash(hash2)).Return(&object.Commit{Committer: object.Signature{When: time.Unix(300, 0)}}, nil)
+			mockRepoSyncInfoKeeper.EXPECT().UpdateBranchActivity(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			res := m.GetBranchesWithMeta("repo1")
+			Expect(res).To(Equal([]util.Map{
+				{"name": "refs/heads/dev", "hash": hash, "lastCommitTime": int64(100)},
+				{"name": "refs/heads/master", "hash": hash2, "lastCommitTime": int64(300)},
+			}))
+		})
+
+		It("should recompute activity of branches whose indexed hash is not the current tip", func() {
+			mockRepoSyncInfoKeeper.EXPECT().GetBranchActivities("repo1").Return(map[string]*core.BranchActivity{
+				"refs/heads/dev":    {Hash: hash, LastCommitTime: 100},
+				"refs/heads/master": {Hash: hash, LastCommitTime: 200},
+			}, nil)
+			mockRepo.EXPECT().RefGet("refs/heads/dev").Return(hash, nil)
+			mockRepo.EXPECT().RefGet("refs/heads/master").Return(hash2, nil)
+			mockRepo.EXPECT().CommitObject(plumbing2.NewHash(hash2)).Return(&object.Commit{Committer: object.Signature{When: time.Unix(300, 0)}}, nil)
+			res := m.GetBranchesWithMeta("repo1")
+			Expect(res[1]).To(Equal(util.Map{"name": "refs/heads/master", "hash": hash2, "lastCommitTime": int64(300)}))
+		})
+
+		It("should panic if unable to read the branch activity index", func() {
+			mockRepoSyncInfoKeeper.EXPECT().GetBranchActivities("repo1").Return(nil, fmt.Errorf("error"))
+			err := &errors.ReqError{Code: modules.StatusCodeServerErr, HttpCode: 500, Msg: "error", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetBranchesWithMeta("repo1")
+			})
+		})
+	})
+
+	Describe(".GetStaleBranches", func() {
+		It("should panic if age was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "age is required", Field: "age"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetStaleBranches("repo1", "")
+			})
+		})
+
+		It("should panic if age is not a valid duration", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "age is not a valid duration", Field: "age"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetStaleBranches("repo1", "1 month")
+			})
+		})
+
+		It("should return branches whose last commit is older than age, least recently active first", func() {
+			mockRepo := mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			mockRepo.EXPECT().GetBranches().Return([]string{"dev", "feature", "master"}, nil)
+			mockRepo.EXPECT().RefGet(gomock.Any()).DoAndReturn(func(ref string) (string, error) {
+				return map[string]string{"refs/heads/dev": "hash1", "refs/heads/feature": "hash2", "refs/heads/master": "hash3"}[ref], nil
+			}).Times(3)
+			now := time.Now()
+			mockRepoSyncInfoKeeper.EXPECT().GetBranchActivities("repo1").Return(map[string]*core.BranchActivity{
+				"refs/heads/dev":     {Hash: "hash1", LastCommitTime: now.Add(-48 * time.Hour).Unix()},
+				"refs/heads/feature": {Hash: "hash2", LastCommitTime: now.Add(-72 * time.Hour).Unix()},
+				"refs/heads/master":  {Hash: "hash3", LastCommitTime: now.Add(-1 * time.Hour).Unix()},
+			}, nil)
+			res := m.GetStaleBranches("repo1", "24h")
+			Expect(res).To(HaveLen(2))
+			Expect(res[0]["name"]).To(Equal("refs/heads/feature"))
+			Expect(res[1]["name"]).To(Equal("refs/heads/dev"))
+		})
+	})
+
+	Describe(".GetRepoMeta", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetRepoMeta("")
+			})
+		})
+
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetRepoMeta("unknown")
+			})
+		})
+
+		When("repo exists", func() {
+			var path string
+
+			BeforeEach(func() {
+				path = cfg.GetRepoPath("repo1")
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+				testutil2.AppendCommit(path, "file.txt", "hello", "c1")
+			})
+
+			It("should return empty result if repo has no meta reference", func() {
+				Expect(m.GetRepoMeta("repo1")).To(BeEmpty())
+			})
+
+			It("should return files of meta references", func() {
+				testutil2.AppendCommit(path, "pipeline.yml", "steps: []", "c2")
+				hash := testutil2.GetRecentCommitHash(path, "refs/heads/master")
+				testutil2.ExecGit(path, "update-ref", "refs/meta/ci", hash)
+				res := m.GetRepoMeta("repo1")
+				Expect(res).To(HaveLen(1))
+				Expect(res).To(HaveKey("ci"))
+				Expect(res["ci"].(util.Map)["hash"]).To(Equal(hash))
+				Expect(res["ci"].(util.Map)["files"]).To(Equal(util.Map{"file.txt": "hello", "pipeline.yml": "steps: []"}))
+			})
+		})
+	})
+
+	Describe(".GetDefaultBranch", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetDefaultBranch("")
+			})
+		})
+
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetDefaultBranch("unknown")
+			})
+		})
+
+		When("repo exists", func() {
+			var path string
+
+			BeforeEach(func() {
+				path = cfg.GetRepoPath("repo1")
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+			})
+
+			It("should return the branch HEAD points to", func() {
+				testutil2.AppendCommit(path, "file.txt", "hello", "c1")
+				Expect(m.GetDefaultBranch("repo1")).To(Equal("refs/heads/master"))
+			})
+
+			It("should return a non-master branch HEAD points to", func() {
+				testutil2.ExecGit(path, "symbolic-ref", "HEAD", "refs/heads/main")
+				testutil2.AppendCommit(path, "file.txt", "hello", "c1")
+				testutil2.ExecGit(path, "branch", "dev")
+				Expect(m.GetDefaultBranch("repo1")).To(Equal("refs/heads/main"))
+			})
+
+			It("should return the sole branch when HEAD is detached", func() {
+				testutil2.ExecGit(path, "symbolic-ref", "HEAD", "refs/heads/main")
+				testutil2.AppendCommit(path, "file.txt", "hello", "c1")
+				testutil2.ExecGit(path, "checkout", "--detach")
+				Expect(m.GetDefaultBranch("repo1")).To(Equal("refs/heads/main"))
+			})
+
+			It("should panic when HEAD is detached and repo has multiple branches", func() {
+				testutil2.AppendCommit(path, "file.txt", "hello", "c1")
+				testutil2.ExecGit(path, "branch", "dev")
+				testutil2.ExecGit(path, "checkout", "--detach")
+				err := &errors.ReqError{Code: modules.StatusCodeBranchNotFound, HttpCode: 404, Msg: "default branch could not be determined", Field: "name"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.GetDefaultBranch("repo1")
+				})
+			})
+		})
+	})
+
+	Describe(".GetLatestBranchCommit", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetLatestBranchCommit("", "")
+			})
+		})
+
+		It("should panic if branch name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "branch name is required", Field: "branch"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetLatestBranchCommit("repo", "")
+			})
+		})
+
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetLatestBranchCommit("unknown", "branch")
+			})
+		})
+
+		It("should panic if branch does not exist", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			err := &errors.ReqError{Code: "branch_not_found", HttpCode: 404, Msg: "branch does not exist", Field: "branch"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetLatestBranchCommit("repo1", "unknown")
+			})
+		})
+
+		It("should be successful if branch is known", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			bc := m.GetLatestBranchCommit("repo1", "master")
+			Expect(bc).ToNot(BeEmpty())
+		})
+	})
+
+	Describe(".GetCommits", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetCommits("", "")
+			})
+		})
+
+		It("should panic if branch name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "branch name is required", Field: "branch"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetCommits("repo", "")
+			})
+		})
+
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetCommits("unknown", "branch")
+			})
+		})
+
+		It("should panic if branch does not exist", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			err := &errors.ReqError{Code: "branch_not_found", HttpCode: 404, Msg: "branch does not exist", Field: "branch"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetCommits("repo1", "unknown")
+			})
+		})
+
+		It("should return commits on success", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			bc := m.GetCommits("repo1", "master", types.GetCommitsOptions{})
+			Expect(bc).ToNot(BeEmpty())
+			Expect(bc).To(HaveLen(7))
+		})
+
+		It("should return limited commits when limit is > 0", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			bc := m.GetCommits("repo1", "master", types.GetCommitsOptions{Limit: 2})
+			Expect(bc).ToNot(BeEmpty())
+			Expect(bc).To(HaveLen(2))
+		})
+
+		It("should panic if order is unknown", func() {
+			testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+			testutil2.AppendCommit(cfg.GetRepoPath("repo1"), "file.txt", "line 1", "commit 1")
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "unknown commit order", Field: "opts.order"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetCommits("repo1", "master", types.GetCommitsOptions{Order: "unknown"})
+			})
+		})
+
+		It("should return commits in the requested order", func() {
+			testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+			testutil2.AppendCommit(cfg.GetRepoPath("repo1"), "file.txt", "line 1", "commit 1")
+			testutil2.AppendCommit(cfg.GetRepoPath("repo1"), "file.txt", "line 2", "commit 2")
+			bc := m.GetCommits("repo1", "master", types.GetCommitsOptions{Order: "reverse"})
+			Expect(bc).To(HaveLen(2))
+			Expect(bc[0]["message"]).To(Equal("commit 1\n"))
+			Expect(bc[1]["message"]).To(Equal("commit 2\n"))
+		})
+	})
+
+	Describe(".GetCommitGraphDOT", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetCommitGraphDOT("", "master", 0)
+			})
+		})
+
+		It("should panic if branch name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "branch name is required", Field: "branch"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetCommitGraphDOT("repo1", "", 0)
+			})
+		})
+
+		It("should panic if limit is negative", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "limit must be a non-negative number", Field: "limit"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetCommitGraphDOT("repo1", "master", -1)
+			})
+		})
+
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetCommitGraphDOT("unknown", "master", 0)
+			})
+		})
+
+		When("repo exists", func() {
+			var path string
+
+			BeforeEach(func() {
+				path = cfg.GetRepoPath("repo1")
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+				testutil2.AppendCommit(path, "file.txt", "line 1", "commit 1")
+				testutil2.CreateCheckoutBranch(path, "dev")
+				testutil2.AppendCommit(path, "dev.txt", "line 1", "commit 2")
+				testutil2.CheckoutBranch(path, "master")
+				testutil2.AppendCommit(path, "file.txt", "line 2", "commit 3")
+				testutil2.ExecGit(path, "merge", "--no-ff", "-m", "merge dev", "dev")
+			})
+
+			It("should panic if branch does not exist", func() {
+				err := &errors.ReqError{Code: modules.StatusCodeBranchNotFound, HttpCode: 404, Msg: "branch does not exist", Field: "branch"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.GetCommitGraphDOT("repo1", "unknown", 0)
+				})
+			})
+
+			It("should return all commits as nodes and parent links as edges", func() {
+				dot := m.GetCommitGraphDOT("repo1", "master", 0)
+				Expect(dot).To(HavePrefix(`digraph "repo1/master" {`))
+				Expect(dot).To(HaveSuffix("}\n"))
+				Expect(strings.Count(dot, "[label=")).To(Equal(4))
+				Expect(strings.Count(dot, " -> ")).To(Equal(4))
+
+				head := testutil2.GetRecentCommitHash(path, "refs/heads/master")
+				Expect(dot).To(ContainSubstring(fmt.Sprintf(`%q [label="%s merge dev"];`, head, head[:7])))
+			})
+
+			It("should include only edges between commits within the limit", func() {
+				dot := m.GetCommitGraphDOT("repo1", "master", 2)
+				Expect(strings.Count(dot, "[label=")).To(Equal(2))
+				Expect(strings.Count(dot, " -> ")).To(Equal(1))
+			})
+		})
+	})
+
+	Describe(".VerifyBranchSignatures", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.VerifyBranchSignatures("", "")
+			})
+		})
+
+		It("should panic if branch name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "branch name is required", Field: "branch"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.VerifyBranchSignatures("repo", "")
+			})
+		})
+
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.VerifyBranchSignatures("unknown", "master")
+			})
+		})
+
+		When("repo exists", func() {
+			var path string
+			var key = ed25519.NewKeyFromIntSeed(1)
+
+			BeforeEach(func() {
+				path = cfg.GetRepoPath("repo1")
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+				mockRepoSrv.EXPECT().GetPushKeyGetter().Return(func(pushKeyID string) (ed25519.PublicKey, error) {
+					if pushKeyID != key.PushAddr().String() {
+						return ed25519.EmptyPublicKey, fmt.Errorf("push key does not exist")
+					}
+					return key.PubKey().ToPublicKey(), nil
+				}).AnyTimes()
+			})
+
+			It("should panic if branch does not exist", func() {
+				testutil2.AppendSignedCommit(path, "file.txt", "line 1", "c1", key)
+				err := &errors.ReqError{Code: "branch_not_found", HttpCode: 404, Msg: "branch does not exist", Field: "branch"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.VerifyBranchSignatures("repo1", "unknown")
+				})
+			})
+
+			It("should return valid=true when all commits are signed", func() {
+				testutil2.AppendSignedCommit(path, "file.txt", "line 1", "c1", key)
+				testutil2.AppendSignedCommit(path, "file.txt", "line 2", "c2", key)
+				testutil2.AppendSignedCommit(path, "file.txt", "line 3", "c3", key)
+				res := m.VerifyBranchSignatures("repo1", "master")
+				Expect(res["valid"]).To(BeTrue())
+				Expect(res["checked"]).To(Equal(3))
+				Expect(res).ToNot(HaveKey("hash"))
+			})
+
+			It("should return the first unsigned commit", func() {
+				testutil2.AppendSignedCommit(path, "file.txt", "line 1", "c1", key)
+				testutil2.AppendCommit(path, "file.txt", "line 2", "c2")
+				unsigned := testutil2.GetRecentCommitHash(path, "HEAD")
+				testutil2.AppendSignedCommit(path, "file.txt", "line 3", "c3", key)
+				res := m.VerifyBranchSignatures("repo1", "refs/heads/master")
+				Expect(res["valid"]).To(BeFalse())
+				Expect(res["checked"]).To(Equal(2))
+				Expect(res["hash"]).To(Equal(unsigned))
+				Expect(res["reason"]).To(Equal("commit is not signed"))
+			})
+
+			It("should only verify up to limit commits", func() {
+				testutil2.AppendCommit(path, "file.txt", "line 1", "c1")
+				testutil2.AppendSignedCommit(path, "file.txt", "line 2", "c2", key)
+				res := m.VerifyBranchSignatures("repo1", "master", 1)
+				Expect(res["valid"]).To(BeTrue())
+				Expect(res["checked"]).To(Equal(1))
+			})
+		})
+	})
+
+	Describe(".GetProvenanceChain", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetProvenanceChain("", "", 0)
+			})
+		})
+
+		It("should panic if commit hash was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "commit hash is required", Field: "commitHash"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetProvenanceChain("repo", "", 0)
+			})
+		})
+
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetProvenanceChain("unknown", "a2d8d6f0b4f3a1c7e4b9c0d1e2f3a4b5c6d7e8f9", 0)
+			})
+		})
+
+		When("repo exists", func() {
+			var path string
+			var key = ed25519.NewKeyFromIntSeed(1)
+			var key2 = ed25519.NewKeyFromIntSeed(2)
+
+			BeforeEach(func() {
+				path = cfg.GetRepoPath("repo1")
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+				mockRepoSrv.EXPECT().GetPushKeyGetter().Return(func(pushKeyID string) (ed25519.PublicKey, error) {
+					if pushKeyID != key.PushAddr().String() {
+						return ed25519.EmptyPublicKey, fmt.Errorf("push key does not exist")
+					}
+					return key.PubKey().ToPublicKey(), nil
+				}).AnyTimes()
+			})
+
+			It("should panic if commit does not exist", func() {
+				testutil2.AppendSignedCommit(path, "file.txt", "line 1", "c1", key)
+				err := &errors.ReqError{Code: "commit_not_found", HttpCode: 404, Msg: "commit does not exist", Field: "commitHash"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.GetProvenanceChain("repo1", "a2d8d6f0b4f3a1c7e4b9c0d1e2f3a4b5c6d7e8f9", 0)
+				})
+			})
+
+			It("should return the signer and signature validity of the commit and its ancestors", func() {
+				testutil2.AppendSignedCommit(path, "file.txt", "line 1", "c1", key)
+				c1 := testutil2.GetRecentCommitHash(path, "HEAD")
+				testutil2.AppendCommit(path, "file.txt", "line 2", "c2")
+				c2 := testutil2.GetRecentCommitHash(path, "HEAD")
+				testutil2.AppendSignedCommit(path, "file.txt", "line 3", "c3", key2)
+				c3 := testutil2.GetRecentCommitHash(path, "HEAD")
+				testutil2.AppendSignedCommit(path, "file.txt", "line 4", "c4", key)
+				c4 := testutil2.GetRecentCommitHash(path, "HEAD")
+
+				res := m.GetProvenanceChain("repo1", c4, 0)
+				Expect(res).To(HaveLen(4))
+				Expect(res[0]).To(Equal(util.Map{"hash": c4, "pushKeyID": key.PushAddr().String(), "signed": true, "verified": true}))
+				Expect(res[1]["hash"]).To(Equal(c3))
+				Expect(res[1]["pushKeyID"]).To(Equal(key2.PushAddr().String()))
+				Expect(res[1]["signed"]).To(BeTrue())
+				Expect(res[1]["verified"]).To(BeFalse())
+				Expect(res[1]["reason"]).ToNot(BeEmpty())
+				Expect(res[2]).To(Equal(util.Map{"hash": c2, "pushKeyID": "", "signed": false, "verified": false}))
+				Expect(res[3]).To(Equal(util.Map{"hash": c1, "pushKeyID": key.PushAddr().String(), "signed": true, "verified": true}))
+			})
+
+			It("should return at most limit commits", func() {
+				testutil2.AppendSignedCommit(path, "file.txt", "line 1", "c1", key)
+				testutil2.AppendSignedCommit(path, "file.txt", "line 2", "c2", key)
+				c2 := testutil2.GetRecentCommitHash(path, "HEAD")
+				res := m.GetProvenanceChain("repo1", c2, 1)
+				Expect(res).To(HaveLen(1))
+				Expect(res[0]["hash"]).To(Equal(c2))
+			})
+		})
+	})
+
+	Describe(".GetTagSignatureInfo", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetTagSignatureInfo("", "")
+			})
+		})
+
+		It("should panic if tag name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "tag name is required", Field: "tag"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetTagSignatureInfo("repo", "")
+			})
+		})
+
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetTagSignatureInfo("unknown", "v1")
+			})
+		})
+
+		When("repo exists", func() {
+			var path string
+			var key = ed25519.NewKeyFromIntSeed(1)
+
+			BeforeEach(func() {
+				path = cfg.GetRepoPath("repo1")
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+				testutil2.AppendCommit(path, "file.txt", "line 1", "c1")
+				mockRepoSrv.EXPECT().GetPushKeyGetter().Return(func(pushKeyID string) (ed25519.PublicKey, error) {
+					if pushKeyID != key.PushAddr().String() {
+						return ed25519.EmptyPublicKey, fmt.Errorf("push key does not exist")
+					}
+					return key.PubKey().ToPublicKey(), nil
+				}).AnyTimes()
+			})
+
+			It("should panic if tag does not exist", func() {
+				err := &errors.ReqError{Code: "tag_not_found", HttpCode: 404, Msg: "tag does not exist", Field: "tag"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.GetTagSignatureInfo("repo1", "unknown")
+				})
+			})
+
+			It("should panic if tag is not an annotated tag", func() {
+				testutil2.ExecGit(path, "tag", "v1")
+				err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "tag is not an annotated tag", Field: "tag"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.GetTagSignatureInfo("repo1", "v1")
+				})
+			})
+
+			It("should return signed=false when tag is not signed", func() {
+				testutil2.ExecGit(path, "tag", "-a", "v1", "-m", "v1")
+				res := m.GetTagSignatureInfo("repo1", "refs/tags/v1")
+				Expect(res["signed"]).To(BeFalse())
+				Expect(res["verified"]).To(BeFalse())
+				Expect(res).ToNot(HaveKey("txDetail"))
+			})
+
+			It("should return verified=true and the tx detail when tag is signed by the push key", func() {
+				testutil2.CreateSignedAnnotatedTag(path, "v1", "v1", key, map[string]string{"reference": "refs/tags/v1", "nonce": "1"})
+				res := m.GetTagSignatureInfo("repo1", "v1")
+				Expect(res["signed"]).To(BeTrue())
+				Expect(res["verified"]).To(BeTrue())
+				Expect(res).ToNot(HaveKey("reason"))
+				Expect(res["txDetail"]).To(HaveKeyWithValue("pkID", key.PushAddr().String()))
+				Expect(res["txDetail"]).To(HaveKeyWithValue("reference", "refs/tags/v1"))
+				Expect(res["txDetail"]).To(HaveKeyWithValue("nonce", uint64(1)))
+			})
+
+			It("should return verified=false when tag was tampered with", func() {
+				testutil2.CreateSignedAnnotatedTag(path, "v1", "v1", key, nil)
+				tagHash := strings.TrimSpace(string(testutil2.ExecGit(path, "rev-parse", "v1")))
+				content := string(testutil2.ExecGit(path, "cat-file", "tag", tagHash))
+				content = strings.Replace(content, "\nv1\n", "\nv2\n", 1)
+				tmp := filepath.Join(path, "tag.txt")
+				Expect(ioutil.WriteFile(tmp, []byte(content), 0644)).To(BeNil())
+				newHash := strings.TrimSpace(string(testutil2.ExecGit(path, "hash-object", "-t", "tag", "-w", tmp)))
+				testutil2.ExecGit(path, "update-ref", "refs/tags/v1", newHash)
+
+				res := m.GetTagSignatureInfo("repo1", "v1")
+				Expect(res["signed"]).To(BeTrue())
+				Expect(res["verified"]).To(BeFalse())
+				Expect(res["reason"]).To(Equal("tag signature is not valid"))
+				Expect(res["txDetail"]).To(HaveKeyWithValue("pkID", key.PushAddr().String()))
+			})
+		})
+	})
+
+	Describe(".AuditRepoSignatures", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.AuditRepoSignatures("")
+			})
+		})
+
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.AuditRepoSignatures("unknown")
+			})
+		})
+
+		When("repo exists", func() {
+			var path string
+			var contribKey = ed25519.NewKeyFromIntSeed(1)
+			var otherKey = ed25519.NewKeyFromIntSeed(2)
+			var unknownKey = ed25519.NewKeyFromIntSeed(3)
+
+			BeforeEach(func() {
+				path = cfg.GetRepoPath("repo1")
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+				mockRepoSrv.EXPECT().GetPushKeyGetter().Return(func(pushKeyID string) (ed25519.PublicKey, error) {
+					switch pushKeyID {
+					case contribKey.PushAddr().String():
+						return contribKey.PubKey().ToPublicKey(), nil
+					case otherKey.PushAddr().String():
+						return otherKey.PubKey().ToPublicKey(), nil
+					}
+					return ed25519.EmptyPublicKey, fmt.Errorf("push key does not exist")
+				}).AnyTimes()
+				repo := state.BareRepository()
+				repo.Contributors[contribKey.PushAddr().String()] = &state.RepoContributor{}
+				mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			})
+
+			It("should return empty result when all tips are unsigned or signed by contributors", func() {
+				testutil2.AppendSignedCommit(path, "file.txt", "line 1", "c1", contribKey)
+				testutil2.CreateCheckoutBranch(path, "dev")
+				testutil2.AppendCommit(path, "file.txt", "line 2", "c2")
+				Expect(m.AuditRepoSignatures("repo1")).To(BeEmpty())
+			})
+
+			It("should report commits signed by a non-contributor push key", func() {
+				testutil2.AppendSignedCommit(path, "file.txt", "line 1", "c1", contribKey)
+				testutil2.CreateCheckoutBranch(path, "dev")
+				testutil2.AppendSignedCommit(path, "file.txt", "line 2", "c2", otherKey)
+				hash := testutil2.GetRecentCommitHash(path, "refs/heads/dev")
+				res := m.AuditRepoSignatures("repo1")
+				Expect(res).To(HaveLen(1))
+				Expect(res[0]["ref"]).To(Equal("refs/heads/dev"))
+				Expect(res[0]["hash"]).To(Equal(hash))
+				Expect(res[0]["type"]).To(Equal("commit"))
+				Expect(res[0]["pushKeyID"]).To(Equal(otherKey.PushAddr().String()))
+				Expect(res[0]["reason"]).To(Equal("push key is not a repo contributor"))
+			})
+
+			It("should report tags whose signature could not be verified", func() {
+				testutil2.AppendSignedCommit(path, "file.txt", "line 1", "c1", contribKey)
+				testutil2.CreateSignedAnnotatedTag(path, "v1", "v1", unknownKey, nil)
+				res := m.AuditRepoSignatures("repo1")
+				Expect(res).To(HaveLen(1))
+				Expect(res[0]["ref"]).To(Equal("refs/tags/v1"))
+				Expect(res[0]["type"]).To(Equal("tag"))
+				Expect(res[0]["pushKeyID"]).To(Equal(unknownKey.PushAddr().String()))
+				Expect(res[0]["reason"]).To(ContainSubstring("failed to get push key"))
+			})
+		})
+	})
+
+	Describe(".GetCommit", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetCommit("", "")
+			})
+		})
+
+		It("should panic if commit hash was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "commit hash is required", Field: "hash"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetCommit("repo", "")
+			})
+		})
+
+		It("should panic if repo was not found", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetCommit("unknown", "hash")
+			})
+		})
+
+		It("should panic if commit was not found", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			err := &errors.ReqError{Code: "commit_not_found", HttpCode: 404, Msg: "commit does not exist", Field: "hash"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetCommit("repo1", "f23482ae207b19498049ec7b35c8274c34ba6093")
+			})
+		})
+
+		It("should not panic if commit was found", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			assert.NotPanics(GinkgoT(), func() {
+				hash := "932401fb0bf48f602c501334b773fbc3422ceb31"
+				res := m.GetCommit("repo1", hash)
+				Expect(res).ToNot(BeNil())
+				Expect(res["hash"]).To(Equal(hash))
+			})
+		})
+	})
+
+	Describe(".GetCommitNotes", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetCommitNotes("", "", "")
+			})
+		})
+
+		It("should panic if commit hash was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "commit hash is required", Field: "hash"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetCommitNotes("repo1", "", "")
+			})
+		})
+
+		It("should panic if notes reference is not a note reference", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "not a notes reference", Field: "notesRef"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetCommitNotes("repo1", "hash", "refs/heads/master")
+			})
+		})
+
+		When("repo exists", func() {
+			var path, commitHash string
+
+			BeforeEach(func() {
+				path = cfg.GetRepoPath("repo1")
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+				testutil2.AppendCommit(path, "file.txt", "hello", "c1")
+				commitHash = testutil2.GetRecentCommitHash(path, "refs/heads/master")
+			})
+
+			It("should panic if commit does not exist", func() {
+				err := &errors.ReqError{Code: modules.StatusCodeCommitNotFound, HttpCode: 404, Msg: "commit does not exist", Field: "hash"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.GetCommitNotes("repo1", "8d998c7de21bbe561f7992bb983cef4b1554993b", "")
+				})
+			})
+
+			It("should panic if notes reference does not exist", func() {
+				err := &errors.ReqError{Code: modules.StatusCodeNoteNotFound, HttpCode: 404, Msg: "notes reference does not exist", Field: "notesRef"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.GetCommitNotes("repo1", commitHash, "refs/notes/review")
+				})
+			})
+
+			It("should panic if commit has no note", func() {
+				testutil2.CreateNote(path, "a note", "review")
+				testutil2.AppendCommit(path, "file.txt", "world", "c2")
+				commitHash = testutil2.GetRecentCommitHash(path, "refs/heads/master")
+				err := &errors.ReqError{Code: modules.StatusCodeNoteNotFound, HttpCode: 404, Msg: "note does not exist", Field: "hash"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.GetCommitNotes("repo1", commitHash, "refs/notes/review")
+				})
+			})
+
+			It("should return the note of the commit", func() {
+				testutil2.CreateNote(path, "reviewed by bob", "review")
+				Expect(m.GetCommitNotes("repo1", commitHash, "refs/notes/review")).To(Equal("reviewed by bob\n"))
+			})
+
+			It("should use refs/notes/commits when notes reference is not provided", func() {
+				testutil2.CreateNote(path, "default note", "commits")
+				Expect(m.GetCommitNotes("repo1", commitHash, "")).To(Equal("default note\n"))
+			})
+		})
+	})
+
+	Describe(".GetMissingObjects", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetMissingObjects("", "")
+			})
+		})
+
+		It("should panic if reference was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "reference is required", Field: "ref"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetMissingObjects("repo1", "")
+			})
+		})
+
+		When("repo exists", func() {
+			var path string
+
+			BeforeEach(func() {
+				path = cfg.GetRepoPath("repo1")
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+				testutil2.AppendCommit(path, "file.txt", "hello", "c1")
+			})
+
+			It("should panic if branch does not exist", func() {
+				err := &errors.ReqError{Code: modules.StatusCodeBranchNotFound, HttpCode: 404, Msg: "reference does not exist", Field: "ref"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.GetMissingObjects("repo1", "dev")
+				})
+			})
+
+			It("should return empty result if no object is missing", func() {
+				Expect(m.GetMissingObjects("repo1", "master")).To(BeEmpty())
+			})
+
+			It("should return hashes of missing objects", func() {
+				blobHash := strings.TrimSpace(string(testutil2.ExecGit(path, "rev-parse", "HEAD:file.txt")))
+				Expect(os.Remove(filepath.Join(path, ".git", "objects", blobHash[:2], blobHash[2:]))).To(BeNil())
+				Expect(m.GetMissingObjects("repo1", "refs/heads/master")).To(Equal([]string{blobHash}))
+			})
+		})
+	})
+
+	Describe(".VerifyRepoIntegrity", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.VerifyRepoIntegrity("")
+			})
+		})
+
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.VerifyRepoIntegrity("unknown")
+			})
+		})
+
+		When("repo exists", func() {
+			var path string
+
+			objPath := func(hash string) string {
+				return filepath.Join(path, ".git", "objects", hash[:2], hash[2:])
+			}
+
+			BeforeEach(func() {
+				path = cfg.GetRepoPath("repo1")
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+				testutil2.AppendCommit(path, "file.txt", "hello", "c1")
+				testutil2.CreateCommitAndLightWeightTag(path, "file.txt", "hello world", "c2", "v1")
+				testutil2.CreateCheckoutBranch(path, "dev")
+				testutil2.AppendCommit(path, "dev.txt", "dev", "c3")
+			})
+
+			It("should report a complete repository", func() {
+				res := m.VerifyRepoIntegrity("repo1")
+				Expect(res["complete"]).To(BeTrue())
+				Expect(res["missing"]).To(BeEmpty())
+				Expect(res["corrupt"]).To(BeEmpty())
+			})
+
+			It("should report a missing object", func() {
+				blobHash := strings.TrimSpace(string(testutil2.ExecGit(path, "rev-parse", "dev:dev.txt")))
+				Expect(os.Remove(objPath(blobHash))).To(BeNil())
+				res := m.VerifyRepoIntegrity("repo1")
+				Expect(res["complete"]).To(BeFalse())
+				Expect(res["missing"]).To(Equal([]string{blobHash}))
+				Expect(res["corrupt"]).To(BeEmpty())
+			})
+
+			It("should report an object whose content does not match its hash", func() {
+				blobHash := strings.TrimSpace(string(testutil2.ExecGit(path, "rev-parse", "dev:dev.txt")))
+				otherHash := strings.TrimSpace(string(testutil2.ExecGit(path, "rev-parse", "master:file.txt")))
+				data, err := ioutil.ReadFile(objPath(otherHash))
+				Expect(err).To(BeNil())
+				Expect(os.Remove(objPath(blobHash))).To(BeNil())
+				Expect(ioutil.WriteFile(objPath(blobHash), data, 0644)).To(BeNil())
+				res := m.VerifyRepoIntegrity("repo1")
+				Expect(res["complete"]).To(BeFalse())
+				Expect(res["missing"]).To(BeEmpty())
+				Expect(res["corrupt"]).To(Equal([]string{blobHash}))
+			})
+		})
+	})
+
+	Describe(".GetObjectStats", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetObjectStats("")
+			})
+		})
+
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetObjectStats("unknown")
+			})
+		})
+
+		It("should return object counts by type", func() {
+			path := cfg.GetRepoPath("repo1")
+			testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+			testutil2.AppendCommit(path, "file.txt", "hello", "c1")
+			testutil2.AppendCommit(path, "file.txt", " world", "c2")
+			res := m.GetObjectStats("repo1")
+			Expect(res).To(Equal(util.Map{"commits": 2, "trees": 2, "blobs": 2, "tags": 0}))
+		})
+	})
+
+	Describe(".AnalyzeRepack", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.AnalyzeRepack("")
+			})
+		})
+
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.AnalyzeRepack("unknown")
+			})
+		})
+
+		It("should panic if unable to project the packed size", func() {
+			mockRepo := mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().CountObjects().Return(&plumbing.ObjectCount{}, nil)
+			mockRepo.EXPECT().ProjectedPackSize().Return(int64(0), fmt.Errorf("error"))
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			err := &errors.ReqError{Code: modules.StatusCodeServerErr, HttpCode: 500, Msg: "error", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.AnalyzeRepack("repo1")
+			})
+		})
+
+		It("should not report negative reclaimable space", func() {
+			mockRepo := mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().CountObjects().Return(&plumbing.ObjectCount{PackedSize: 100}, nil)
+			mockRepo.EXPECT().ProjectedPackSize().Return(int64(120), nil)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			res := m.AnalyzeRepack("repo1")
+			Expect(res["currentSize"]).To(Equal(int64(100)))
+			Expect(res["projectedSize"]).To(Equal(int64(120)))
+			Expect(res["reclaimable"]).To(Equal(int64(0)))
+		})
+
+		When("repo has loose objects", func() {
+			var path string
+
+			BeforeEach(func() {
+				path = cfg.GetRepoPath("repo1")
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+				for i := 0; i < 5; i++ {
+					testutil2.AppendCommit(path, "file.txt", fmt.Sprintf("line %d", i), fmt.Sprintf("c%d", i))
+				}
+			})
+
+			It("should return the current and projected sizes without packing objects", func() {
+				res := m.AnalyzeRepack("repo1")
+				Expect(res["looseObjects"]).To(Equal(15))
+				Expect(res["packs"]).To(Equal(0))
+				current, projected := res["currentSize"].(int64), res["projectedSize"].(int64)
+				Expect(current).To(Equal(res["looseSize"]))
+				Expect(projected).To(BeNumerically(">", 0))
+				Expect(projected).To(BeNumerically("<", current))
+				Expect(res["reclaimable"]).To(Equal(current - projected))
+
+				entries, err := ioutil.ReadDir(filepath.Join(path, ".git", "objects", "pack"))
+				Expect(err).To(BeNil())
+				Expect(entries).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe(".DoRepack", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.DoRepack("")
+			})
+		})
+
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.DoRepack("unknown")
+			})
+		})
+
+		It("should panic if garbage collection failed", func() {
+			mockRepo := mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().CountObjects().Return(&plumbing.ObjectCount{}, nil)
+			mockRepo.EXPECT().GC().Return(fmt.Errorf("error"))
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			err := &errors.ReqError{Code: modules.StatusCodeServerErr, HttpCode: 500, Msg: "error", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.DoRepack("repo1")
+			})
+		})
+
+		When("repo has loose objects", func() {
+			BeforeEach(func() {
+				path := cfg.GetRepoPath("repo1")
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+				for i := 0; i < 5; i++ {
+					testutil2.AppendCommit(path, "file.txt", fmt.Sprintf("line %d", i), fmt.Sprintf("c%d", i))
+				}
+			})
+
+			It("should pack the loose objects and report the reclaimed space", func() {
+				res := m.DoRepack("repo1")
+				before, after := res["sizeBefore"].(int64), res["sizeAfter"].(int64)
+				Expect(after).To(BeNumerically("<", before))
+				Expect(res["reclaimed"]).To(Equal(before - after))
+
+				analysis := m.AnalyzeRepack("repo1")
+				Expect(analysis["looseObjects"]).To(Equal(0))
+				Expect(analysis["packs"]).To(Equal(1))
+			})
+		})
+	})
+
+	Describe(".GetRepoStats", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetRepoStats("")
+			})
+		})
+
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetRepoStats("unknown")
+			})
+		})
+
+		When("repo exists", func() {
+			var path string
+
+			BeforeEach(func() {
+				repo := state.BareRepository()
+				repo.Contributors["pk1"] = &state.RepoContributor{}
+				repo.Contributors["pk2"] = &state.RepoContributor{}
+				mockRepoKeeper.EXPECT().Get("repo1").Return(repo).AnyTimes()
+
+				path = cfg.GetRepoPath("repo1")
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+				testutil2.AppendCommit(path, "file.txt", "hello", "c1")
+				testutil2.CreateCommitAndLightWeightTag(path, "file.txt", " world", "c2", "v1")
+				testutil2.CreateCheckoutBranch(path, "dev")
+				testutil2.AppendCommit(path, "file.txt", " again", "c3")
+				testutil2.CheckoutBranch(path, "master")
+
+				mockRepoSyncInfoKeeper.EXPECT().GetBranchActivities("repo1").Return(map[string]*core.BranchActivity{
+					"refs/heads/master": {Hash: testutil2.GetRecentCommitHash(path, "refs/heads/master"), LastCommitTime: 3000},
+					"refs/heads/dev":    {Hash: testutil2.GetRecentCommitHash(path, "refs/heads/dev"), LastCommitTime: 2000},
+				}, nil).AnyTimes()
+			})
+
+			It("should return the aggregated statistics", func() {
+				testutil2.CreateCheckoutOrphanBranch(path, "issues/1")
+				testutil2.AppendCommit(path, "body", "---\ntitle: Bug\n---\nSomething broke", "issue 1")
+				testutil2.CheckoutBranch(path, "master")
+				testutil2.CreateCheckoutOrphanBranch(path, "issues/2")
+				testutil2.AppendCommit(path, "body", "---\ntitle: Bug 2\n---\nSomething else broke", "issue 2")
+				testutil2.CheckoutBranch(path, "master")
+				testutil2.CreateCheckoutOrphanBranch(path, "merges/1")
+				testutil2.AppendCommit(path, "body", "---\ntitle: Fix\nclose: true\n---\nFixes the bug", "merge request 1")
+				testutil2.CheckoutBranch(path, "master")
+
+				res := m.GetRepoStats("repo1")
+				Expect(res["defaultBranch"]).To(Equal("refs/heads/master"))
+				Expect(res["commits"]).To(Equal(2))
+				Expect(res["branches"]).To(Equal(2))
+				Expect(res["tags"]).To(Equal(1))
+				Expect(res["openIssues"]).To(Equal(2))
+				Expect(res["openMergeRequests"]).To(Equal(0))
+				Expect(res["contributors"]).To(Equal(2))
+				Expect(res["lastActivity"]).To(BeNumerically(">", 3000))
+			})
+
+			It("should use the most recent branch commit as last activity when there are no posts", func() {
+				res := m.GetRepoStats("repo1")
+				Expect(res["openIssues"]).To(Equal(0))
+				Expect(res["lastActivity"]).To(Equal(int64(3000)))
+			})
+
+			It("should return cached statistics until the repo is updated", func() {
+				res := m.GetRepoStats("repo1")
+				Expect(res["commits"]).To(Equal(2))
+
+				testutil2.AppendCommit(path, "file.txt", " more", "c4")
+				Expect(m.GetRepoStats("repo1")["commits"]).To(Equal(2))
+
+				cfg.G().Bus.Emit(core.EvtRepoUpdated, "repo1", path)
+				Eventually(func() interface{} {
+					return m.GetRepoStats("repo1")["commits"]
+				}).Should(Equal(3))
+			})
+
+			It("should not invalidate cached statistics after the module is closed", func() {
+				Expect(m.GetRepoStats("repo1")["commits"]).To(Equal(2))
+				m.Close()
+
+				testutil2.AppendCommit(path, "file.txt", " more", "c4")
+				cfg.G().Bus.Emit(core.EvtRepoUpdated, "repo1", path)
+				Consistently(func() interface{} {
+					return m.GetRepoStats("repo1")["commits"]
+				}, 200*time.Millisecond).Should(Equal(2))
+			})
+		})
+	})
+
+	Describe(".EstimateCloneSize", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.EstimateCloneSize("")
+			})
+		})
+
+		It("should panic if depth is negative", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "depth must not be negative", Field: "opts.depth"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.EstimateCloneSize("repo1", types.CloneOptions{Depth: -1})
+			})
+		})
+
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.EstimateCloneSize("unknown")
+			})
+		})
+
+		When("repo exists", func() {
+			BeforeEach(func() {
+				path := cfg.GetRepoPath("repo1")
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+				testutil2.AppendCommit(path, "file.txt", "hello", "c1")
+				testutil2.AppendCommit(path, "file.txt", " world", "c2")
+			})
+
+			It("should panic if reference does not exist", func() {
+				err := &errors.ReqError{Code: modules.StatusCodeBranchNotFound, HttpCode: 404, Msg: "reference does not exist", Field: "opts.reference"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.EstimateCloneSize("repo1", types.CloneOptions{Reference: "dev"})
+				})
+			})
+
+			It("should return a smaller estimate for a depth 1 clone than a full clone", func() {
+				full := m.EstimateCloneSize("repo1")
+				Expect(full["objects"]).To(Equal(6))
+				shallow := m.EstimateCloneSize("repo1", types.CloneOptions{Reference: "master", Depth: 1})
+				Expect(shallow["objects"]).To(Equal(3))
+				Expect(shallow["size"]).To(BeNumerically("<", full["size"]))
+			})
+		})
+	})
+
+	Describe(".CountCommits", func() {
+		It("should return correct commit count", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			count := m.CountCommits("repo1", "master")
+			Expect(count).To(Equal(7))
+			count = m.CountCommits("repo1", "cbc329e7e912227d58edea6d6a74d550cd664adf")
+			Expect(count).To(Equal(2))
+		})
+	})
+
+	Describe(".GetCommitAncestors", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetCommitAncestors("", "")
+			})
+		})
+
+		It("should panic if commit hash was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "commit hash is required", Field: "commitHash"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetCommitAncestors("repo", "")
+			})
+		})
+
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetCommitAncestors("unknown", "hash")
+			})
+		})
+
+		It("should panic if commit does not exist", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			err := &errors.ReqError{Code: "commit_not_found", HttpCode: 404, Msg: "commit does not exist", Field: "commitHash"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetCommitAncestors("repo1", "unknown")
+			})
+		})
+
+		It("should return commits on success", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			commits := m.GetCommitAncestors("repo1", "aef606780a3f857fdd7fe8270efa547f118bef5f")
+			Expect(commits).ToNot(BeEmpty())
+			Expect(commits).To(HaveLen(5))
+		})
+
+		It("should return limited commits when limit is > 0", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			commits := m.GetCommitAncestors("repo1", "aef606780a3f857fdd7fe8270efa547f118bef5f", 1)
+			Expect(commits).ToNot(BeEmpty())
+			Expect(commits).To(HaveLen(1))
+		})
+	})
+
+	Describe(".GetMergeBase", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetMergeBase("", "", "")
+			})
+		})
+
+		It("should panic if first commit hash was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "commit hash is required", Field: "commitA"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetMergeBase("repo", "", "")
+			})
+		})
+
+		It("should panic if second commit hash was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "commit hash is required", Field: "commitB"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetMergeBase("repo", "hash1", "")
+			})
+		})
+
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetMergeBase("unknown", "hash1", "hash2")
+			})
+		})
+
+		When("repo exists", func() {
+			var mockRepo *mocks.MockLocalRepo
+
+			BeforeEach(func() {
+				mockRepo = mocks.NewMockLocalRepo(ctrl)
+				m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			})
+
+			It("should panic if a commit does not exist", func() {
+				mockRepo.EXPECT().GetMergeBase("hash1", "hash2").Return("", plumbing2.ErrObjectNotFound)
+				err := &errors.ReqError{Code: modules.StatusCodeCommitNotFound, HttpCode: 404, Msg: "commit does not exist", Field: ""}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.GetMergeBase("repo", "hash1", "hash2")
+				})
+			})
+
+			It("should panic if commits have no common ancestor", func() {
+				mockRepo.EXPECT().GetMergeBase("hash1", "hash2").Return("", repo.ErrNoMergeBase)
+				err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "no merge base found", Field: ""}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.GetMergeBase("repo", "hash1", "hash2")
+				})
+			})
+
+			It("should return merge base on success", func() {
+				mockRepo.EXPECT().GetMergeBase("hash1", "hash2").Return("hash3", nil)
+				Expect(m.GetMergeBase("repo", "hash1", "hash2")).To(Equal("hash3"))
+			})
+		})
+	})
+
+	Describe(".CompareRepos", func() {
+		var baseRepo, headRepo *mocks.MockLocalRepo
+		var baseHash = "5f7dd3b4ca4e23ae3ff1a5b9e3ef3ac39e8a2f0e"
+		var headHash = "a1ab3f5a52f2ad0a1f44bc44ba3d1bb55d13e8a9"
+
+		It("should panic if base repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeRepoNotFound, HttpCode: 404, Msg: "repository does not exist", Field: "baseRepo"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CompareRepos("unknown", "fork1", "master")
+			})
+		})
+
+		When("base repo exists", func() {
+			BeforeEach(func() {
+				baseRepo = mocks.NewMockLocalRepo(ctrl)
+				headRepo = mocks.NewMockLocalRepo(ctrl)
+				m.GetLocalRepo = func(_, path string) (plumbing.LocalRepo, error) {
+					switch path {
+					case cfg.GetRepoPath("base1"):
+						return baseRepo, nil
+					case cfg.GetRepoPath("fork1"):
+						return headRepo, nil
+					}
+					return nil, git.ErrRepositoryNotExists
+				}
+			})
+
+			It("should panic if head repo does not exist", func() {
+				err := &errors.ReqError{Code: modules.StatusCodeRepoNotFound, HttpCode: 404, Msg: "repository does not exist", Field: "headRepo"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.CompareRepos("base1", "unknown", "master")
+				})
+			})
+
+			It("should return the commits the fork is ahead by", func() {
+				baseRepo.EXPECT().GetLatestCommit("master").Return(&plumbing.CommitResult{Hash: baseHash}, nil)
+				headRepo.EXPECT().GetLatestCommit("master").Return(&plumbing.CommitResult{Hash: headHash}, nil)
+				baseRepo.EXPECT().ObjectExist(headHash).Return(true)
+				baseRepo.EXPECT().GetMergeBase(baseHash, headHash).Return(baseHash, nil)
+				baseRepo.EXPECT().GetCommitsBetween(baseHash, headHash).Return([]*plumbing.CommitResult{{Hash: headHash}}, nil)
+				baseRepo.EXPECT().GetCommitsBetween(headHash, baseHash).Return(nil, nil)
+				res := m.CompareRepos("base1", "fork1", "master")
+				Expect(res["mergeBase"]).To(Equal(baseHash))
+				Expect(res["ahead"]).To(Equal(1))
+				Expect(res["behind"]).To(Equal(0))
+				Expect(res["aheadCommits"]).To(HaveLen(1))
+			})
+
+			It("should fetch head objects missing in the base repo from the DHT", func() {
+				mockDHT := mocks.NewMockDHT(ctrl)
+				mockStreamer := mocks.NewMockStreamer(ctrl)
+				mockRepoSrv.EXPECT().GetDHT().Return(mockDHT)
+				mockDHT.EXPECT().ObjectStreamer().Return(mockStreamer)
+
+				baseRepo.EXPECT().GetLatestCommit("master").Return(&plumbing.CommitResult{Hash: baseHash}, nil)
+				headRepo.EXPECT().GetLatestCommit("master").Return(&plumbing.CommitResult{Hash: headHash}, nil)
+				baseRepo.EXPECT().ObjectExist(headHash).Return(false)
+
+				unpacked := 0
+				m.PackToRepoUnpacker = func(repo plumbing.LocalRepo, pack io2.ReadSeekerCloser) error {
+					Expect(repo).To(Equal(baseRepo))
+					unpacked++
+					return nil
+				}
+				mockStreamer.EXPECT().GetCommitWithAncestors(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, args dht.GetAncestorArgs) ([]io2.ReadSeekerCloser, error) {
+						Expect(args.RepoName).To(Equal("fork1"))
+						Expect(args.LocalRepoName).To(Equal("base1"))
+						Expect(args.StartHash).To(Equal(plumbing.HashToBytes(headHash)))
+						return nil, args.ResultCB(testutil.WrapReadSeekerCloser{Rdr: bytes.NewBuffer(nil)}, headHash)
+					})
+
+				baseRepo.EXPECT().GetMergeBase(baseHash, headHash).Return(baseHash, nil)
+				baseRepo.EXPECT().GetCommitsBetween(baseHash, headHash).Return([]*plumbing.CommitResult{{Hash: headHash}}, nil)
+				baseRepo.EXPECT().GetCommitsBetween(headHash, baseHash).Return(nil, nil)
+				res := m.CompareRepos("base1", "fork1", "master")
+				Expect(unpacked).To(Equal(1))
+				Expect(res["ahead"]).To(Equal(1))
+			})
+		})
+	})
+
+	Describe(".GetBranchDivergence", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetBranchDivergence("", "dev")
+			})
+		})
+
+		It("should panic if branch name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "branch name is required", Field: "branch"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetBranchDivergence("repo1", "")
+			})
+		})
+
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetBranchDivergence("unknown", "dev")
+			})
+		})
+
+		When("repo exists", func() {
+			var path, master, dev string
+
+			BeforeEach(func() {
+				path = cfg.GetRepoPath("repo1")
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+				testutil2.AppendCommit(path, "file.txt", "line 1", "commit 1")
+				testutil2.CreateCheckoutBranch(path, "dev")
+				testutil2.AppendCommit(path, "file.txt", "line 2", "commit 2")
+				testutil2.AppendCommit(path, "file.txt", "line 3", "commit 3")
+				testutil2.AppendCommit(path, "file.txt", "line 4", "commit 4")
+				testutil2.CheckoutBranch(path, "master")
+				testutil2.AppendCommit(path, "file2.txt", "line 1", "commit 5")
+				master = testutil2.GetRecentCommitHash(path, "refs/heads/master")
+				dev = testutil2.GetRecentCommitHash(path, "refs/heads/dev")
+			})
+
+			It("should panic if branch does not exist", func() {
+				err := &errors.ReqError{Code: modules.StatusCodeBranchNotFound, HttpCode: 404, Msg: "branch does not exist", Field: "branch"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.GetBranchDivergence("repo1", "unknown")
+				})
+			})
+
+			It("should return the commits the branch is ahead and behind the default branch", func() {
+				res := m.GetBranchDivergence("repo1", "dev")
+				Expect(res["base"]).To(Equal("refs/heads/master"))
+				Expect(res["mergeBase"]).To(Equal(testutil2.GetRecentCommitHash(path, "refs/heads/dev~3")))
+				Expect(res["ahead"]).To(Equal(3))
+				Expect(res["behind"]).To(Equal(1))
+				Expect(res["aheadCommits"]).To(HaveLen(3))
+				Expect(res["aheadCommits"].([]util.Map)[0]["hash"]).To(Equal(dev))
+				Expect(res["behindCommits"]).To(HaveLen(1))
+				Expect(res["behindCommits"].([]util.Map)[0]["hash"]).To(Equal(master))
+			})
+
+			It("should return no divergence for the default branch", func() {
+				res := m.GetBranchDivergence("repo1", "refs/heads/master")
+				Expect(res["mergeBase"]).To(Equal(master))
+				Expect(res["ahead"]).To(Equal(0))
+				Expect(res["behind"]).To(Equal(0))
+				Expect(res["aheadCommits"]).To(BeEmpty())
+				Expect(res["behindCommits"]).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe(".GetRefsContaining", func() {
+		It("should panic if repo name was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetRefsContaining("", "")
+			})
+		})
+
+		It("should panic if commit hash was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "commit hash is required", Field: "commitHash"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetRefsContaining("repo", "")
+			})
+		})
+
+		It("should panic if repo does not exist", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetRefsContaining("unknown", "hash1")
+			})
+		})
+
+		When("repo exists", func() {
+			var mockRepo *mocks.MockLocalRepo
+
+			BeforeEach(func() {
+				mockRepo = mocks.NewMockLocalRepo(ctrl)
+				m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			})
+
+			It("should panic if commit does not exist", func() {
+				mockRepo.EXPECT().GetRefsContaining("hash1").Return(nil, plumbing2.ErrObjectNotFound)
+				err := &errors.ReqError{Code: modules.StatusCodeCommitNotFound, HttpCode: 404, Msg: "commit not found", Field: "commitHash"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.GetRefsContaining("repo", "hash1")
+				})
+			})
+
+			It("should panic if unable to get references", func() {
+				mockRepo.EXPECT().GetRefsContaining("hash1").Return(nil, fmt.Errorf("error"))
+				err := &errors.ReqError{Code: modules.StatusCodeServerErr, HttpCode: 500, Msg: "error", Field: ""}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.GetRefsContaining("repo", "hash1")
+				})
+			})
+
+			It("should return branches and tags on success", func() {
+				mockRepo.EXPECT().GetRefsContaining("hash1").Return(&plumbing.RefsContainingResult{
+					Branches: []string{"master"},
+					Tags:     []string{"v1", "v2"},
+				}, nil)
+				res := m.GetRefsContaining("repo", "hash1")
+				Expect(res).To(Equal(util.Map{"branches": []string{"master"}, "tags": []string{"v1", "v2"}}))
+			})
+		})
+	})
+
+	Describe(".GetParentsAndCommitDiff", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetParentsAndCommitDiff("", "")
+			})
+		})
+
+		It("should panic when commit hash was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "commit hash is required", Field: "commitHash"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetParentsAndCommitDiff("repo", "")
+			})
+		})
+
+		It("should panic when repo does not exist", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetParentsAndCommitDiff("unknown", "abc")
+			})
+		})
+
+		It("should panic when unable to get diff", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			err := &errors.ReqError{Code: "commit_not_found", HttpCode: 404, Msg: "commit not found", Field: "commitHash"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetParentsAndCommitDiff("repo3", "abc")
+			})
+		})
+
+		It("should not panic when successful", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			assert.NotPanics(GinkgoT(), func() {
+				res := m.GetParentsAndCommitDiff("repo3", "8c427dcc0d582cd7387b4c529185b7c1ab28f20c")
+				Expect(res).To(HaveKey("patches"))
+				Expect(res["patches"]).To(HaveLen(1))
+				Expect(res["patches"].([]map[string]string)[0]).To(HaveKey("a77021f4aead5f5ab8934a94154b0b4da6a551b5"))
+				Expect(res["patches"].([]map[string]string)[0]["a77021f4aead5f5ab8934a94154b0b4da6a551b5"]).To(Equal(`diff --git a/file_b.txt b/file_b.txt
+new file mode 100644
+index 0000000..3b0c2f1
+--- /dev/null
++++ b/file_b.txt
+@@ -0,0 +1 @@
++We made games
+\ No newline at end of file`))
+			})
+		})
+	})
+
+	Describe(".StreamParentsAndCommitDiff", func() {
+		noop := func(string, string) error { return nil }
+
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.StreamParentsAndCommitDiff("", "", noop)
+			})
+		})
+
+		It("should panic when commit hash was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "commit hash is required", Field: "commitHash"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.StreamParentsAndCommitDiff("repo", "", noop)
+			})
+		})
+
+		It("should panic when repo does not exist", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.StreamParentsAndCommitDiff("unknown", "abc", noop)
+			})
+		})
+	})
+
+	Describe(".GetCommitTree", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetCommitTree("", "")
+			})
+		})
+
+		It("should panic when commit hash was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "commit hash is required", Field: "commitHash"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetCommitTree("repo", "")
+			})
+		})
+
+		It("should panic when repo does not exist", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetCommitTree("unknown", "abc")
+			})
+		})
+
+		When("repo exists", func() {
+			var mockRepo *mocks.MockLocalRepo
+
+			BeforeEach(func() {
+				mockRepo = mocks.NewMockLocalRepo(ctrl)
+				m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			})
+
+			It("should panic when commit does not exist", func() {
+				mockRepo.EXPECT().GetCommitTree("abc").Return(nil, plumbing2.ErrObjectNotFound)
+				err := &errors.ReqError{Code: "commit_not_found", HttpCode: 404, Msg: "commit not found", Field: "commitHash"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.GetCommitTree("repo1", "abc")
+				})
+			})
+
+			It("should return the tree entries on success", func() {
+				mockRepo.EXPECT().GetCommitTree("abc").Return([]*plumbing.CommitTreeEntry{
+					{Path: "dir", Mode: "040000", Type: "tree", Hash: "h1"},
+					{Path: "dir/file.txt", Mode: "100644", Type: "blob", Hash: "h2", Size: 10},
+				}, nil)
+				res := m.GetCommitTree("repo1", "abc")
+				Expect(res).To(HaveLen(2))
+				Expect(res[0]["path"]).To(Equal("dir"))
+				Expect(res[0]["type"]).To(Equal("tree"))
+				Expect(res[1]["path"]).To(Equal("dir/file.txt"))
+				Expect(res[1]["mode"]).To(Equal("100644"))
+				Expect(res[1]["hash"]).To(Equal("h2"))
+				Expect(res[1]["size"]).To(Equal(int64(10)))
+			})
+		})
+	})
+
+	Describe(".ExportPatchSeries", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ExportPatchSeries("", "", "")
+			})
+		})
+
+		It("should panic when from commit hash was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "commit hash is required", Field: "from"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ExportPatchSeries("repo", "", "")
+			})
+		})
+
+		It("should panic when to commit hash was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "commit hash is required", Field: "to"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ExportPatchSeries("repo", "abc", "")
+			})
+		})
+
+		It("should panic when repo does not exist", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ExportPatchSeries("unknown", "abc", "def")
+			})
+		})
+
+		When("repo exists", func() {
+			var path string
+
+			BeforeEach(func() {
+				path = cfg.GetRepoPath("repo1")
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+			})
+
+			It("should panic when a commit does not exist", func() {
+				testutil2.AppendCommit(path, "file.txt", "line 1", "c1")
+				from := testutil2.GetRecentCommitHash(path, "refs/heads/master")
+				err := &errors.ReqError{Code: "commit_not_found", HttpCode: 404, Msg: "commit not found", Field: ""}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.ExportPatchSeries("repo1", from, "a1f8a4b6a39c2f3b7e1b5cbd2e7f6e8a9d3c4b5a")
+				})
+			})
+
+			It("should return a patch for each commit in the range", func() {
+				testutil2.AppendCommit(path, "file.txt", "line 1\n", "c1")
+				from := testutil2.GetRecentCommitHash(path, "refs/heads/master")
+				testutil2.AppendCommit(path, "file.txt", "line 2\n", "c2")
+				testutil2.AppendCommit(path, "file.txt", "line 3\n", "c3")
+				testutil2.AppendCommit(path, "file.txt", "line 4\n", "c4")
+				to := testutil2.GetRecentCommitHash(path, "refs/heads/master")
+				res := m.ExportPatchSeries("repo1", from, to)
+				Expect(res).To(HaveLen(3))
+				Expect(res[0]).To(ContainSubstring("Subject: [PATCH 1/3] c2"))
+				Expect(res[0]).To(ContainSubstring("+line 2"))
+				Expect(res[1]).To(ContainSubstring("Subject: [PATCH 2/3] c3"))
+				Expect(res[2]).To(ContainSubstring("Subject: [PATCH 3/3] c4"))
+				Expect(res[2]).To(HavePrefix("From " + to))
+			})
+		})
+	})
+
+	Describe(".CompareTags", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CompareTags("", "", "")
+			})
+		})
+
+		It("should panic when from tag was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "tag name is required", Field: "fromTag"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CompareTags("repo", "", "v2")
+			})
+		})
+
+		It("should panic when to tag was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "tag name is required", Field: "toTag"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CompareTags("repo", "v1", "")
+			})
+		})
+
+		It("should panic when repo does not exist", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CompareTags("unknown", "v1", "v2")
+			})
+		})
+
+		It("should panic when a tag does not exist", func() {
+			mockRepo := mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			mockRepo.EXPECT().CompareTags("v1", "v2").Return(nil, plumbing2.ErrReferenceNotFound)
+			err := &errors.ReqError{Code: "tag_not_found", HttpCode: 404, Msg: "tag does not exist", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CompareTags("repo1", "v1", "v2")
+			})
+		})
+
+		It("should panic when unable to compare tags", func() {
+			mockRepo := mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			mockRepo.EXPECT().CompareTags("v1", "v2").Return(nil, fmt.Errorf("error"))
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CompareTags("repo1", "v1", "v2")
+			})
+		})
+
+		It("should return commits and files on success", func() {
+			mockRepo := mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			mockRepo.EXPECT().CompareTags("v1", "v2").Return(&plumbing.CompareTagsResult{
+				Commits: []*plumbing.CommitResult{{Hash: "abc"}},
+				Files:   []string{"file.txt"},
+			}, nil)
+			res := m.CompareTags("repo1", "v1", "v2")
+			Expect(res).To(HaveKey("commits"))
+			Expect(res).To(HaveKey("files"))
+			Expect(res["commits"]).To(HaveLen(1))
+			Expect(res["files"]).To(Equal([]string{"file.txt"}))
+		})
+	})
+
+	Describe(".CreateIssue", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CreateIssue("", nil)
+			})
+		})
+
+		It("should panic when repo was not found", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CreateIssue("unknown", nil)
+			})
+		})
+
+		It("should panic when unable to find a free post ID", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			m.PostIDFinder = func(_ plumbing.LocalRepo, _ int, _ string) (int, error) {
+				return 0, fmt.Errorf("error here")
+			}
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CreateIssue("repo3", nil)
+			})
+		})
+
+		It("should panic when unable to clone repository", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(plumbing.MakeIssueReference("1")).Return("", plumbing2.ErrReferenceNotFound)
+			mockRepo.EXPECT().Clone(cloneOptionsEq(plumbing.CloneOptions{
+				Bare:          false,
+				ReferenceName: "",
+				Depth:         1,
+			})).Return(nil, "", fmt.Errorf("error here"))
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
+				return mockRepo, nil
+			}
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "failed to clone repo: error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CreateIssue("repo3", map[string]interface{}{
+					"id": 1,
+				})
+			})
+		})
+
+		When("clone takes longer than the clone timeout", func() {
+			var mockRepo *mocks.MockLocalRepo
+			var tmpDir string
+
+			BeforeEach(func() {
+				var err error
+				tmpDir, err = os.MkdirTemp("", "")
+				Expect(err).To(BeNil())
+				cfg.SetRepoRoot("../remote/repo/testdata")
+				cfg.Repo.CloneTimeout = 50 * time.Millisecond
+				mockRepo = mocks.NewMockLocalRepo(ctrl)
+				mockRepo.EXPECT().RefGet(plumbing.MakeIssueReference("1")).Return("", plumbing2.ErrReferenceNotFound)
+				m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			})
+
+			isRemoved := func() bool {
+				_, err := os.Stat(tmpDir)
+				return os.IsNotExist(err)
+			}
+
+			It("should cancel the clone, remove the partial repo and panic with clone_timeout", func() {
+				mockRepo.EXPECT().Clone(gomock.Any()).DoAndReturn(func(opts plumbing.CloneOptions) (plumbing.LocalRepo, string, error) {
+					<-opts.Context.Done()
+					return nil, tmpDir, opts.Context.Err()
+				})
+				err := &errors.ReqError{Code: "clone_timeout", HttpCode: 504, Msg: "repository clone timed out after 50ms", Field: ""}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.CreateIssue("repo3", map[string]interface{}{"id": 1})
+				})
+				Eventually(isRemoved).Should(BeTrue())
+			})
+
+			It("should not wait for a clone that ignores cancellation", func() {
+				release := make(chan struct{})
+				mockRepo.EXPECT().Clone(gomock.Any()).DoAndReturn(func(opts plumbing.CloneOptions) (plumbing.LocalRepo, string, error) {
+					<-release
+					return nil, tmpDir, nil
+				})
+				err := &errors.ReqError{Code: "clone_timeout", HttpCode: 504, Msg: "repository clone timed out after 50ms", Field: ""}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.CreateIssue("repo3", map[string]interface{}{"id": 1})
+				})
+				Expect(isRemoved()).To(BeFalse())
+				close(release)
+				Eventually(isRemoved).Should(BeTrue())
+			})
+		})
+
+		It("should panic when unable to create issue", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(plumbing.MakeIssueReference("1")).Return("", plumbing2.ErrReferenceNotFound)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+
+			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
+			mockCloneRepo.EXPECT().Delete()
+
+			m.IssueCreate = func(_ plumbing.LocalRepo, _ *issuecmd.IssueCreateArgs) (*issuecmd.IssueCreateResult, error) {
+				return nil, fmt.Errorf("error here")
+			}
+
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CreateIssue("repo3", map[string]interface{}{
+					"id": 1,
+				})
+			})
+		})
+
+		It("should panic when unable to get create issue reference", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+
+			issueRef := plumbing.MakeIssueReference("1")
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(issueRef).Return("", plumbing2.ErrReferenceNotFound)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+
+			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
+			mockCloneRepo.EXPECT().Delete()
+
+			m.IssueCreate = func(_ plumbing.LocalRepo, _ *issuecmd.IssueCreateArgs) (*issuecmd.IssueCreateResult, error) {
+				return &issuecmd.IssueCreateResult{Reference: issueRef}, nil
+			}
+
+			mockCloneRepo.EXPECT().RefGet(issueRef).Return("", fmt.Errorf("error here"))
+
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CreateIssue("repo3", map[string]interface{}{
+					"id": 1,
+				})
+			})
+		})
+
+		It("should panic when unable to get create issue reference", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+
+			issueRef := plumbing.MakeIssueReference("1")
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(issueRef).Return("", plumbing2.ErrReferenceNotFound)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+
+			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
+			mockCloneRepo.EXPECT().GetPath().Return("/repo/path")
+			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
+
+			m.IssueCreate = func(_ plumbing.LocalRepo, _ *issuecmd.IssueCreateArgs) (*issuecmd.IssueCreateResult, error) {
+				return &issuecmd.IssueCreateResult{Reference: issueRef}, nil
+			}
+
+			mockCloneRepo.EXPECT().RefGet(issueRef).Return("hash123", nil)
+
+			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
+			mockTempRepoMgr.EXPECT().Add("/repo/path").Return("repoId_123")
+			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+
+			assert.NotPanics(GinkgoT(), func() {
+				res := m.CreateIssue("repo3", map[string]interface{}{
+					"id": 1,
+				})
+				Expect(res).To(Equal(util.Map(map[string]interface{}{
+					"hash":      "hash123",
+					"reference": issueRef,
+					"repoID":    "repoId_123",
+				})))
+			})
+		})
+	})
+
+	Describe(".CreateMergeRequest()", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CreateMergeRequest("", nil)
+			})
+		})
+
+		It("should panic when repo was not found", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CreateMergeRequest("unknown", nil)
+			})
+		})
+
+		It("should panic when unable to find a free post ID", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			m.PostIDFinder = func(_ plumbing.LocalRepo, _ int, _ string) (int, error) {
+				return 0, fmt.Errorf("error here")
+			}
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CreateMergeRequest("repo3", nil)
+			})
+		})
+
+		It("should panic when unable to clone repository", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(plumbing.MakeMergeRequestReference("1")).Return("", plumbing2.ErrReferenceNotFound)
+			mockRepo.EXPECT().Clone(cloneOptionsEq(plumbing.CloneOptions{
+				Bare:          false,
+				ReferenceName: "",
+				Depth:         1,
+			})).Return(nil, "", fmt.Errorf("error here"))
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
+				return mockRepo, nil
+			}
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "failed to clone repo: error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CreateMergeRequest("repo3", map[string]interface{}{
+					"id": 1,
+				})
+			})
+		})
+
+		It("should panic when unable to create merge request", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(plumbing.MakeMergeRequestReference("1")).Return("", plumbing2.ErrReferenceNotFound)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+
+			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
+			mockCloneRepo.EXPECT().Delete()
+			mockRepoKeeper.EXPECT().Get("repo3").Return(state.BareRepository())
+
+			m.MergeRequestCreate = func(r plumbing.LocalRepo, args *mergecmd.MergeRequestCreateArgs) (*mergecmd.MergeRequestCreateResult, error) {
+				return nil, fmt.Errorf("error here")
+			}
+
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CreateMergeRequest("repo3", map[string]interface{}{
+					"id": 1,
+				})
+			})
+		})
+
+		It("should panic when unable to get create merge request reference", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+
+			mrRef := plumbing.MakeMergeRequestReference("1")
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(mrRef).Return("", plumbing2.ErrReferenceNotFound)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+
+			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
+			mockCloneRepo.EXPECT().Delete()
+			mockRepoKeeper.EXPECT().Get("repo3").Return(state.BareRepository())
+
+			m.MergeRequestCreate = func(r plumbing.LocalRepo, args *mergecmd.MergeRequestCreateArgs) (*mergecmd.MergeRequestCreateResult, error) {
+				return &mergecmd.MergeRequestCreateResult{Reference: mrRef}, nil
+			}
+
+			mockCloneRepo.EXPECT().RefGet(mrRef).Return("", fmt.Errorf("error here"))
+
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CreateMergeRequest("repo3", map[string]interface{}{
+					"id": 1,
+				})
+			})
+		})
+
+		It("should panic when unable to get create issue reference", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+
+			mrRef := plumbing.MakeMergeRequestReference("1")
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(mrRef).Return("", plumbing2.ErrReferenceNotFound)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+
+			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
+			mockCloneRepo.EXPECT().GetPath().Return("/repo/path")
+			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
+
+			repoState := state.BareRepository()
+			repoState.Config.DefaultReviewers = []string{"pk1"}
+			mockRepoKeeper.EXPECT().Get("repo3").Return(repoState)
+
+			m.MergeRequestCreate = func(r plumbing.LocalRepo, args *mergecmd.MergeRequestCreateArgs) (*mergecmd.MergeRequestCreateResult, error) {
+				Expect(args.DefaultReviewers).To(Equal([]string{"pk1"}))
+				Expect(args.Reviewers).To(Equal([]string{"pk2"}))
+				return &mergecmd.MergeRequestCreateResult{Reference: mrRef}, nil
+			}
+
+			mockCloneRepo.EXPECT().RefGet(mrRef).Return("hash123", nil)
+
+			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
+			mockTempRepoMgr.EXPECT().Add("/repo/path").Return("repoId_123")
+			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+
+			assert.NotPanics(GinkgoT(), func() {
+				res := m.CreateMergeRequest("repo3", map[string]interface{}{
+					"id":        1,
+					"reviewers": []interface{}{"pk2"},
+				})
+				Expect(res).To(Equal(util.Map(map[string]interface{}{
+					"hash":      "hash123",
+					"reference": mrRef,
+					"repoID":    "repoId_123",
+				})))
+			})
+		})
+
+		It("should surface the repository's preferred merge strategy", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+
+			mrRef := plumbing.MakeMergeRequestReference("1")
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(mrRef).Return("", plumbing2.ErrReferenceNotFound)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+
+			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
+			mockCloneRepo.EXPECT().GetPath().Return("/repo/path")
+			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
+
+			repoState := state.BareRepository()
+			repoState.Config.MergeStrategy = state.MergeStrategyRebase
+			mockRepoKeeper.EXPECT().Get("repo3").Return(repoState)
+
+			m.MergeRequestCreate = func(r plumbing.LocalRepo, args *mergecmd.MergeRequestCreateArgs) (*mergecmd.MergeRequestCreateResult, error) {
+				Expect(args.MergeStrategy).To(Equal(state.MergeStrategyRebase))
+				return &mergecmd.MergeRequestCreateResult{Reference: mrRef, MergeStrategy: args.MergeStrategy}, nil
+			}
+
+			mockCloneRepo.EXPECT().RefGet(mrRef).Return("hash123", nil)
+
+			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
+			mockTempRepoMgr.EXPECT().Add("/repo/path").Return("repoId_123")
+			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+
+			res := m.CreateMergeRequest("repo3", map[string]interface{}{"id": 1})
+			Expect(res["mergeStrategy"]).To(Equal("rebase"))
+		})
+	})
+
+	Describe(".CloseIssue", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CloseIssue("", plumbing.MakeIssueReference(1))
+			})
+		})
+
+		It("should panic when repo was not found", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CloseIssue("unknown", plumbing.MakeIssueReference(1))
+			})
+		})
+
+		It("should panic when issue reference was not found", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			err := &errors.ReqError{Code: "issue_not_found", HttpCode: 404, Msg: "issue not found", Field: "reference"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CloseIssue("repo3", plumbing.MakeIssueReference(1))
+			})
+		})
+
+		It("should panic when unable to clone repository", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(plumbing.MakeIssueReference("1")).Return("", nil)
+			mockRepo.EXPECT().Clone(cloneOptionsEq(plumbing.CloneOptions{
+				Bare:          false,
+				ReferenceName: "",
+				Depth:         1,
+			})).Return(nil, "", fmt.Errorf("error here"))
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
+				return mockRepo, nil
+			}
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "failed to clone repo: error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CloseIssue("repo3", plumbing.MakeIssueReference(1))
+			})
+		})
+
+		It("should panic when unable to close issue", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(plumbing.MakeIssueReference("1")).Return("", nil)
+			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
+			mockCloneRepo.EXPECT().Delete()
+			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
+			m.IssueClose = func(r plumbing.LocalRepo, args *issuecmd.IssueCloseArgs) (*issuecmd.IssueCloseResult, error) {
+				return nil, fmt.Errorf("error here; cant close")
+			}
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
+				return mockRepo, nil
+			}
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here; cant close", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CloseIssue("repo3", plumbing.MakeIssueReference(1))
+			})
+		})
+
+		It("should panic when unable to get hash of updated reference", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			ref := plumbing.MakeIssueReference("1")
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(ref).Return("", nil)
+			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
+			m.IssueClose = func(r plumbing.LocalRepo, args *issuecmd.IssueCloseArgs) (*issuecmd.IssueCloseResult, error) {
+				return &issuecmd.IssueCloseResult{Reference: ref}, nil
+			}
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
+				return mockRepo, nil
+			}
+			mockCloneRepo.EXPECT().RefGet(ref).Return("", fmt.Errorf("error here"))
+			mockCloneRepo.EXPECT().Delete()
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CloseIssue("repo3", plumbing.MakeIssueReference(1))
+			})
+		})
+
+		It("should add repo to temporary repo manager; "+
+			"return ID from temporary repo manager;"+
+			"return name and hash of new issue reference;", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			ref := plumbing.MakeIssueReference("1")
+			hash := "hash_123"
+
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(ref).Return("", nil)
+			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
+			m.IssueClose = func(r plumbing.LocalRepo, args *issuecmd.IssueCloseArgs) (*issuecmd.IssueCloseResult, error) {
+				return &issuecmd.IssueCloseResult{Reference: ref}, nil
+			}
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
+				return mockRepo, nil
+			}
+
+			mockCloneRepo.EXPECT().RefGet(ref).Return(hash, nil)
+
+			tempRepoId := "repoId_123"
+			mockCloneRepo.EXPECT().GetPath().Return("/repo/path")
+			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
+			mockTempRepoMgr.EXPECT().Add("/repo/path").Return(tempRepoId)
+			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+
+			assert.NotPanics(GinkgoT(), func() {
+				res := m.CloseIssue("repo3", plumbing.MakeIssueReference(1))
+				Expect(res["reference"]).To(Equal(ref))
+				Expect(res["hash"]).To(Equal(hash))
+				Expect(res["repoID"]).To(Equal(tempRepoId))
+			})
+		})
+	})
+
+	Describe(".ReadIssue", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReadIssue("", plumbing.MakeIssueReference(1))
+			})
+		})
+
+		It("should panic when repo was not found", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReadIssue("unknown", plumbing.MakeIssueReference(1))
+			})
+		})
+
+		It("should panic when unable to get the issue", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			m.IssueRead = func(_ plumbing.LocalRepo, _ *issuecmd.IssueReadArgs) (plumbing.Comments, error) {
+				return nil, fmt.Errorf("error here")
+			}
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReadIssue("repo3", plumbing.MakeIssueReference(1))
+			})
+		})
+
+		It("should not panic on success", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			m.IssueRead = func(_ plumbing.LocalRepo, _ *issuecmd.IssueReadArgs) (plumbing.Comments, error) {
+				return []*plumbing.Comment{
+					{Author: "a"},
+				}, nil
+			}
+			assert.NotPanics(GinkgoT(), func() {
+				res := m.ReadIssue("repo3", plumbing.MakeIssueReference(1))
+				Expect(res).To(HaveLen(1))
+				Expect(res[0]).To(HaveKey("author"))
+				Expect(res[0]["author"]).To(Equal("a"))
+			})
+		})
+	})
+
+	Describe(".ReadIssueThread", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReadIssueThread("", plumbing.MakeIssueReference(1))
+			})
+		})
+
+		It("should panic when repo was not found", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReadIssueThread("unknown", plumbing.MakeIssueReference(1))
+			})
+		})
+
+		It("should panic when issue reference was not found", func() {
+			mockRepo := mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			mockRepo.EXPECT().RefGet(plumbing.MakeIssueReference(1)).Return("", plumbing.ErrRefNotFound)
+			err := &errors.ReqError{Code: "issue_not_found", HttpCode: 404, Msg: "issue not found", Field: "reference"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReadIssueThread("repo1", plumbing.MakeIssueReference(1))
+			})
+		})
+
+		It("should panic when unable to read the issue", func() {
+			mockRepo := mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			mockRepo.EXPECT().RefGet(plumbing.MakeIssueReference(1)).Return("hash", nil)
+			m.IssueRead = func(_ plumbing.LocalRepo, _ *issuecmd.IssueReadArgs) (plumbing.Comments, error) {
+				return nil, fmt.Errorf("error here")
+			}
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReadIssueThread("repo1", plumbing.MakeIssueReference(1))
+			})
+		})
+
+		It("should return comments nested under the comments they replied to", func() {
+			mockRepo := mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			mockRepo.EXPECT().RefGet(plumbing.MakeIssueReference(1)).Return("hash", nil)
+			m.IssueRead = func(_ plumbing.LocalRepo, _ *issuecmd.IssueReadArgs) (plumbing.Comments, error) {
+				return []*plumbing.Comment{
+					{Hash: "h1", Author: "a", Body: &plumbing.PostBody{}},
+					{Hash: "h2", Author: "b", Body: &plumbing.PostBody{ReplyTo: "h1"}},
+					{Hash: "h3", Author: "c", Body: &plumbing.PostBody{ReplyTo: "h2"}},
+				}, nil
+			}
+			res := m.ReadIssueThread("repo1", plumbing.MakeIssueReference(1))
+			Expect(res).To(HaveLen(1))
+			Expect(res[0]["author"]).To(Equal("a"))
+			replies := res[0]["replies"].([]util.Map)
+			Expect(replies).To(HaveLen(1))
+			Expect(replies[0]["author"]).To(Equal("b"))
+			Expect(replies[0]["replies"].([]util.Map)).To(HaveLen(1))
+			Expect(replies[0]["replies"].([]util.Map)[0]["author"]).To(Equal("c"))
+		})
+	})
+
+	Describe(".ReopenIssue", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReopenIssue("", plumbing.MakeIssueReference(1))
+			})
+		})
+
+		It("should panic when repo was not found", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReopenIssue("unknown", plumbing.MakeIssueReference(1))
+			})
+		})
+
+		It("should panic when issue reference was not found", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			err := &errors.ReqError{Code: "issue_not_found", HttpCode: 404, Msg: "issue not found", Field: "reference"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReopenIssue("repo3", plumbing.MakeIssueReference(1))
+			})
+		})
+		//
+		It("should panic when unable to clone repository", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(plumbing.MakeIssueReference("1")).Return("", nil)
+			mockRepo.EXPECT().Clone(cloneOptionsEq(plumbing.CloneOptions{
+				Bare:          false,
+				ReferenceName: "",
+				Depth:         1,
+			})).Return(nil, "", fmt.Errorf("error here"))
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
+				return mockRepo, nil
+			}
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "failed to clone repo: error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReopenIssue("repo3", plumbing.MakeIssueReference(1))
+			})
+		})
+
+		It("should panic when unable to reopen issue", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(plumbing.MakeIssueReference("1")).Return("", nil)
+			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
+			mockCloneRepo.EXPECT().Delete()
+			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
+			m.IssueReopen = func(r plumbing.LocalRepo, args *issuecmd.IssueReopenArgs) (*issuecmd.IssueReopenResult, error) {
+				return nil, fmt.Errorf("error here; cant reopen")
+			}
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
+				return mockRepo, nil
+			}
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here; cant reopen", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReopenIssue("repo3", plumbing.MakeIssueReference(1))
+			})
+		})
+
+		It("should panic when unable to get hash of updated reference", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			ref := plumbing.MakeIssueReference("1")
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(ref).Return("", nil)
+			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
+			m.IssueReopen = func(r plumbing.LocalRepo, args *issuecmd.IssueReopenArgs) (*issuecmd.IssueReopenResult, error) {
+				return &issuecmd.IssueReopenResult{Reference: ref}, nil
+			}
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
+				return mockRepo, nil
+			}
+			mockCloneRepo.EXPECT().RefGet(ref).Return("", fmt.Errorf("error here"))
+			mockCloneRepo.EXPECT().Delete()
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReopenIssue("repo3", plumbing.MakeIssueReference(1))
+			})
+		})
+
+		It("should add repo to temporary repo manager; "+
+			"return ID from temporary repo manager;"+
+			"return name and hash of new merge request reference;", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			ref := plumbing.MakeIssueReference("1")
+			hash := "hash_123"
+
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(ref).Return("", nil)
+			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
+			m.IssueReopen = func(r plumbing.LocalRepo, args *issuecmd.IssueReopenArgs) (*issuecmd.IssueReopenResult, error) {
+				return &issuecmd.IssueReopenResult{Reference: ref}, nil
+			}
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
+				return mockRepo, nil
+			}
+
+			mockCloneRepo.EXPECT().RefGet(ref).Return(hash, nil)
+
+			tempRepoId := "repoId_123"
+			mockCloneRepo.EXPECT().GetPath().Return("/repo/path")
+			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
+			mockTempRepoMgr.EXPECT().Add("/repo/path").Return(tempRepoId)
+			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+
+			assert.NotPanics(GinkgoT(), func() {
+				res := m.ReopenIssue("repo3", plumbing.MakeIssueReference(1))
+				Expect(res["reference"]).To(Equal(ref))
+				Expect(res["hash"]).To(Equal(hash))
+				Expect(res["repoID"]).To(Equal(tempRepoId))
+			})
+		})
+	})
+
+	Describe(".ListIssues", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ListIssues("")
+			})
+		})
+
+		It("should panic when repo was not found", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ListIssues("unknown")
+			})
+		})
+
+		It("should panic when unable to list issues", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			m.IssueList = func(_ plumbing.LocalRepo, _ *issuecmd.IssueListArgs) (plumbing.Posts, error) {
+				return nil, fmt.Errorf("error here")
+			}
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ListIssues("repo3")
+			})
+		})
+
+		It("should not panic on success", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			m.IssueList = func(_ plumbing.LocalRepo, _ *issuecmd.IssueListArgs) (plumbing.Posts, error) {
+				return []plumbing.PostEntry{
+					&plumbing.Post{Title: "title"},
+				}, nil
+			}
+			mockRepoKeeper.EXPECT().Get("repo3").Return(state.BareRepository())
+			assert.NotPanics(GinkgoT(), func() {
+				res := m.ListIssues("repo3")
+				Expect(res).To(HaveLen(1))
+				Expect(res[0]).To(HaveKey("title"))
+				Expect(res[0]["title"]).To(Equal("title"))
+			})
+		})
+
+		When("repository has a post retention policy", func() {
+			BeforeEach(func() {
+				path := cfg.GetRepoPath("repo1")
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+				testutil2.AppendCommit(path, "file.txt", "hello", "c1")
+				testutil2.CreateCheckoutOrphanBranch(path, "issues/1")
+				testutil2.AppendCommit(path, "body", "---\ntitle: Open\n---\nbody", "post 1")
+				testutil2.CheckoutBranch(path, "master")
+				testutil2.CreateCheckoutOrphanBranch(path, "issues/2")
+				testutil2.AppendCommit(path, "body", "---\ntitle: Closed\nclose: true\n---\nbody", "post 2")
+
+				repo := state.BareRepository()
+				repo.Config.PostRetention = &state.PostRetentionPolicy{ClosedTTL: 3600, Action: state.PostRetentionArchive}
+				mockRepoKeeper.EXPECT().Get("repo1").Return(repo).MaxTimes(1)
+			})
+
+			It("should include closed issues that have not expired", func() {
+				m.Now = func() time.Time { return time.Now().Add(30 * time.Minute) }
+				res := m.ListIssues("repo1")
+				Expect(res).To(HaveLen(2))
+			})
+
+			It("should not include expired issues", func() {
+				m.Now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+				res := m.ListIssues("repo1")
+				Expect(res).To(HaveLen(1))
+				Expect(res[0]["title"]).To(Equal("Open"))
+			})
+
+			It("should include expired issues when includeArchived is set", func() {
+				m.Now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+				res := m.ListIssues("repo1", types.ListPostsOptions{IncludeArchived: true})
+				Expect(res).To(HaveLen(2))
+			})
+		})
+	})
+
+	Describe(".ReadMergeRequestThread()", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReadMergeRequestThread("", plumbing.MakeMergeRequestReference(1))
+			})
+		})
+
+		It("should panic when merge request reference was not found", func() {
+			mockRepo := mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			mockRepo.EXPECT().RefGet(plumbing.MakeMergeRequestReference(1)).Return("", plumbing.ErrRefNotFound)
+			err := &errors.ReqError{Code: "merge_request_not_found", HttpCode: 404, Msg: "merge request not found", Field: "reference"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReadMergeRequestThread("repo1", plumbing.MakeMergeRequestReference(1))
+			})
+		})
+
+		It("should panic when unable to read the merge request", func() {
+			mockRepo := mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			mockRepo.EXPECT().RefGet(plumbing.MakeMergeRequestReference(1)).Return("hash", nil)
+			m.MergeRequestRead = func(_ plumbing.LocalRepo, _ *mergecmd.MergeRequestReadArgs) (plumbing.Comments, error) {
+				return nil, fmt.Errorf("error here")
+			}
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReadMergeRequestThread("repo1", plumbing.MakeMergeRequestReference(1))
+			})
+		})
+
+		It("should return comments nested under the comments they replied to", func() {
+			mockRepo := mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+			mockRepo.EXPECT().RefGet(plumbing.MakeMergeRequestReference(1)).Return("hash", nil)
+			m.MergeRequestRead = func(_ plumbing.LocalRepo, _ *mergecmd.MergeRequestReadArgs) (plumbing.Comments, error) {
+				return []*plumbing.Comment{
+					{Hash: "h1", Author: "a", Body: &plumbing.PostBody{}},
+					{Hash: "h2", Author: "b", Body: &plumbing.PostBody{ReplyTo: "h1"}},
+					{Hash: "h3", Author: "c", Body: &plumbing.PostBody{}},
+				}, nil
+			}
+			res := m.ReadMergeRequestThread("repo1", plumbing.MakeMergeRequestReference(1))
+			Expect(res).To(HaveLen(2))
+			Expect(res[0]["author"]).To(Equal("a"))
+			Expect(res[0]["replies"].([]util.Map)).To(HaveLen(1))
+			Expect(res[0]["replies"].([]util.Map)[0]["author"]).To(Equal("b"))
+			Expect(res[1]["author"]).To(Equal("c"))
+			Expect(res[1]["replies"].([]util.Map)).To(BeEmpty())
+		})
+	})
+
+	Describe(".CloseMergeRequest()", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CloseMergeRequest("", plumbing.MakeMergeRequestReference(1))
+			})
+		})
+
+		It("should panic when repo was not found", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CloseMergeRequest("unknown", plumbing.MakeMergeRequestReference(1))
+			})
+		})
+
+		It("should panic when merge request reference was not found", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			err := &errors.ReqError{Code: "merge_request_not_found", HttpCode: 404, Msg: "merge request not found", Field: "reference"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CloseMergeRequest("repo3", plumbing.MakeMergeRequestReference(1))
+			})
+		})
+
+		It("should panic with clone_timeout when clone takes longer than the clone timeout", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			cfg.Repo.CloneTimeout = 50 * time.Millisecond
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(plumbing.MakeMergeRequestReference("1")).Return("", nil)
+			hasDeadline := make(chan bool, 1)
+			mockRepo.EXPECT().Clone(gomock.Any()).DoAndReturn(func(opts plumbing.CloneOptions) (plumbing.LocalRepo, string, error) {
+				_, ok := opts.Context.Deadline()
+				hasDeadline <- ok
+				<-opts.Context.Done()
+				return nil, "", opts.Context.Err()
+			})
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
+				return mockRepo, nil
+			}
+			err := &errors.ReqError{Code: "clone_timeout", HttpCode: 504, Msg: "repository clone timed out after 50ms", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CloseMergeRequest("repo3", plumbing.MakeMergeRequestReference(1))
+			})
+			Expect(<-hasDeadline).To(BeTrue())
+		})
+
+		It("should panic when unable to clone repository", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(plumbing.MakeMergeRequestReference("1")).Return("", nil)
+			mockRepo.EXPECT().Clone(cloneOptionsEq(plumbing.CloneOptions{
+				Bare:          false,
+				ReferenceName: "",
+				Depth:         1,
+			})).Return(nil, "", fmt.Errorf("error here"))
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
+				return mockRepo, nil
+			}
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "failed to clone repo: error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CloseMergeRequest("repo3", plumbing.MakeMergeRequestReference(1))
+			})
+		})
+
+		It("should panic when unable to close merge request", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(plumbing.MakeMergeRequestReference("1")).Return("", nil)
+			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
+			mockCloneRepo.EXPECT().Delete()
+			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
+			m.MergeRequestClose = func(r plumbing.LocalRepo, args *mergecmd.MergeReqCloseArgs) (*mergecmd.MergeReqCloseResult, error) {
+				return nil, fmt.Errorf("error here; cant close")
+			}
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
+				return mockRepo, nil
+			}
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here; cant close", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CloseMergeRequest("repo3", plumbing.MakeMergeRequestReference(1))
+			})
+		})
+
+		It("should panic when unable to get reference of created merge request", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			ref := plumbing.MakeMergeRequestReference("1")
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(ref).Return("", nil)
+			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
+			m.MergeRequestClose = func(r plumbing.LocalRepo, args *mergecmd.MergeReqCloseArgs) (*mergecmd.MergeReqCloseResult, error) {
+				return &mergecmd.MergeReqCloseResult{Reference: ref}, nil
+			}
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
+				return mockRepo, nil
+			}
+			mockCloneRepo.EXPECT().RefGet(ref).Return("", fmt.Errorf("error here"))
+			mockCloneRepo.EXPECT().Delete()
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.CloseMergeRequest("repo3", ref)
+			})
+		})
+
+		It("should add repo to temporary repo manager; "+
+			"return ID from temporary repo manager;"+
+			"return name and hash of new merge request reference;", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			ref := plumbing.MakeMergeRequestReference("1")
+			hash := "hash_123"
+
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(ref).Return("", nil)
+			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
+			m.MergeRequestClose = func(r plumbing.LocalRepo, args *mergecmd.MergeReqCloseArgs) (*mergecmd.MergeReqCloseResult, error) {
+				return &mergecmd.MergeReqCloseResult{Reference: ref}, nil
+			}
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
+				return mockRepo, nil
+			}
+
+			mockCloneRepo.EXPECT().RefGet(ref).Return(hash, nil)
+
+			tempRepoId := "repoId_123"
+			mockCloneRepo.EXPECT().GetPath().Return("/repo/path")
+			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
+			mockTempRepoMgr.EXPECT().Add("/repo/path").Return(tempRepoId)
+			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+
+			assert.NotPanics(GinkgoT(), func() {
+				res := m.CloseMergeRequest("repo3", ref)
+				Expect(res["reference"]).To(Equal(ref))
+				Expect(res["hash"]).To(Equal(hash))
+				Expect(res["repoID"]).To(Equal(tempRepoId))
+			})
+		})
+	})
+
+	Describe(".ReopenMergeRequest()", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReopenMergeRequest("", plumbing.MakeMergeRequestReference(1))
+			})
+		})
+
+		It("should panic when repo was not found", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReopenMergeRequest("unknown", plumbing.MakeMergeRequestReference(1))
+			})
+		})
+
+		It("should panic when issue reference was not found", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			err := &errors.ReqError{Code: "merge_request_not_found", HttpCode: 404, Msg: "merge request not found", Field: "reference"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReopenMergeRequest("repo3", plumbing.MakeMergeRequestReference(1))
+			})
+		})
+
+		It("should panic when unable to clone repository", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(plumbing.MakeMergeRequestReference("1")).Return("", nil)
+			mockRepo.EXPECT().Clone(cloneOptionsEq(plumbing.CloneOptions{
+				Bare:          false,
+				ReferenceName: "",
+				Depth:         1,
+			})).Return(nil, "", fmt.Errorf("error here"))
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
+				return mockRepo, nil
+			}
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "failed to clone repo: error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReopenMergeRequest("repo3", plumbing.MakeMergeRequestReference(1))
+			})
+		})
+
+		It("should panic when unable to reopen merge request", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(plumbing.MakeMergeRequestReference("1")).Return("", nil)
+			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
+			mockCloneRepo.EXPECT().Delete()
+			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
+			m.MergeRequestReopen = func(r plumbing.LocalRepo, args *mergecmd.MergeReqReopenArgs) (*mergecmd.MergeReqReopenResult, error) {
+				return nil, fmt.Errorf("error here; cant reopen")
+			}
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
+				return mockRepo, nil
+			}
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here; cant reopen", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReopenMergeRequest("repo3", plumbing.MakeMergeRequestReference(1))
+			})
+		})
+
+		It("should panic when unable to get hash of updated reference", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			ref := plumbing.MakeMergeRequestReference("1")
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(ref).Return("", nil)
+			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
+			m.MergeRequestReopen = func(r plumbing.LocalRepo, args *mergecmd.MergeReqReopenArgs) (*mergecmd.MergeReqReopenResult, error) {
+				return &mergecmd.MergeReqReopenResult{Reference: ref}, nil
+			}
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
+				return mockRepo, nil
+			}
+			mockCloneRepo.EXPECT().RefGet(ref).Return("", fmt.Errorf("error here"))
+			mockCloneRepo.EXPECT().Delete()
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReopenMergeRequest("repo3", plumbing.MakeMergeRequestReference(1))
+			})
+		})
+
+		It("should add repo to temporary repo manager; "+
+			"return ID from temporary repo manager;"+
+			"return name and hash of new merge request reference;", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			ref := plumbing.MakeMergeRequestReference("1")
+			hash := "hash_123"
+
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().RefGet(ref).Return("", nil)
+			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
+			m.MergeRequestReopen = func(r plumbing.LocalRepo, args *mergecmd.MergeReqReopenArgs) (*mergecmd.MergeReqReopenResult, error) {
+				return &mergecmd.MergeReqReopenResult{Reference: ref}, nil
+			}
+			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
+				return mockRepo, nil
+			}
+
+			mockCloneRepo.EXPECT().RefGet(ref).Return(hash, nil)
+
+			tempRepoId := "repoId_123"
+			mockCloneRepo.EXPECT().GetPath().Return("/repo/path")
+			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
+			mockTempRepoMgr.EXPECT().Add("/repo/path").Return(tempRepoId)
+			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+
+			assert.NotPanics(GinkgoT(), func() {
+				res := m.ReopenMergeRequest("repo3", plumbing.MakeMergeRequestReference(1))
+				Expect(res["reference"]).To(Equal(ref))
+				Expect(res["hash"]).To(Equal(hash))
+				Expect(res["repoID"]).To(Equal(tempRepoId))
+			})
+		})
+	})
+
+	Describe(".ListMergeRequests", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ListMergeRequests("")
+			})
+		})
+
+		It("should panic when repo was not found", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ListMergeRequests("unknown")
+			})
+		})
+
+		It("should panic when unable to list issues", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			m.MergeRequestList = func(_ plumbing.LocalRepo, _ *mergecmd.MergeRequestListArgs) (plumbing.Posts, error) {
+				return nil, fmt.Errorf("error here")
+			}
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ListMergeRequests("repo3")
+			})
+		})
+
+		It("should not panic on success", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			m.MergeRequestList = func(_ plumbing.LocalRepo, _ *mergecmd.MergeRequestListArgs) (plumbing.Posts, error) {
+				return []plumbing.PostEntry{
+					&plumbing.Post{Title: "title"},
+				}, nil
+			}
+			mockRepoKeeper.EXPECT().Get("repo3").Return(state.BareRepository())
+			assert.NotPanics(GinkgoT(), func() {
+				res := m.ListMergeRequests("repo3")
+				Expect(res).To(HaveLen(1))
+				Expect(res[0]).To(HaveKey("title"))
+				Expect(res[0]["title"]).To(Equal("title"))
+			})
+		})
+
+		When("repository has a post retention policy", func() {
+			BeforeEach(func() {
+				path := cfg.GetRepoPath("repo1")
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+				testutil2.AppendCommit(path, "file.txt", "hello", "c1")
+				testutil2.CreateCheckoutOrphanBranch(path, "merges/1")
+				testutil2.AppendCommit(path, "body", "---\ntitle: Open\n---\nbody", "post 1")
+				testutil2.CheckoutBranch(path, "master")
+				testutil2.CreateCheckoutOrphanBranch(path, "merges/2")
+				testutil2.AppendCommit(path, "body", "---\ntitle: Closed\nclose: true\n---\nbody", "post 2")
+
+				repo := state.BareRepository()
+				repo.Config.PostRetention = &state.PostRetentionPolicy{ClosedTTL: 3600, Action: state.PostRetentionArchive}
+				mockRepoKeeper.EXPECT().Get("repo1").Return(repo).MaxTimes(1)
+			})
+
+			It("should include closed merge requests that have not expired", func() {
+				m.Now = func() time.Time { return time.Now().Add(30 * time.Minute) }
+				res := m.ListMergeRequests("repo1")
+				Expect(res).To(HaveLen(2))
+			})
+
+			It("should not include expired merge requests", func() {
+				m.Now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+				res := m.ListMergeRequests("repo1")
+				Expect(res).To(HaveLen(1))
+				Expect(res[0]["title"]).To(Equal("Open"))
+			})
+
+			It("should include expired merge requests when includeArchived is set", func() {
+				m.Now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+				res := m.ListMergeRequests("repo1", types.ListPostsOptions{IncludeArchived: true})
+				Expect(res).To(HaveLen(2))
+			})
+		})
+	})
+
+	Describe(".ApplyPostRetention", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ApplyPostRetention("")
+			})
+		})
+
+		It("should panic when repo does not exist", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(state.BareRepository())
+			err := &errors.ReqError{Code: modules.StatusCodeRepoNotFound, HttpCode: 404, Msg: "repo not found", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ApplyPostRetention("repo1")
+			})
+		})
+
+		When("repository exists", func() {
+			var path string
+			var repo *state.Repository
+			var closedAt time.Time
+
+			BeforeEach(func() {
+				path = cfg.GetRepoPath("repo1")
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+				testutil2.AppendCommit(path, "file.txt", "hello", "c1")
+				testutil2.CreateCheckoutOrphanBranch(path, "issues/1")
+				testutil2.AppendCommit(path, "body", "---\ntitle: Open\n---\nbody", "issue 1")
+				testutil2.CheckoutBranch(path, "master")
+				testutil2.CreateCheckoutOrphanBranch(path, "issues/2")
+				testutil2.AppendCommit(path, "body", "---\ntitle: Closed\nclose: true\n---\nbody", "issue 2")
+				testutil2.CheckoutBranch(path, "master")
+				testutil2.CreateCheckoutOrphanBranch(path, "merges/1")
+				testutil2.AppendCommit(path, "body", "---\ntitle: Closed\nclose: true\n---\nbody", "merge request 1")
+				testutil2.CheckoutBranch(path, "master")
+				closedAt = time.Now()
+
+				repo = state.BareRepository()
+				repo.Balance = "10"
+				mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+			})
+
+			It("should return empty lists when repository has no post retention policy", func() {
+				res := m.ApplyPostRetention("repo1")
+				Expect(res).To(Equal(util.Map{"archived": []string{}, "pruned": []string{}}))
+			})
+
+			It("should not return posts that have not expired", func() {
+				repo.Config.PostRetention = &state.PostRetentionPolicy{ClosedTTL: 3600, Action: state.PostRetentionPrune}
+				m.Now = func() time.Time { return closedAt.Add(30 * time.Minute) }
+				res := m.ApplyPostRetention("repo1")
+				Expect(res).To(Equal(util.Map{"archived": []string{}, "pruned": []string{}}))
+			})
+
+			It("should return expired posts as archived when action is 'archive'", func() {
+				repo.Config.PostRetention = &state.PostRetentionPolicy{ClosedTTL: 3600, Action: state.PostRetentionArchive}
+				m.Now = func() time.Time { return closedAt.Add(2 * time.Hour) }
+				res := m.ApplyPostRetention("repo1")
+				Expect(res["archived"]).To(Equal([]string{"refs/heads/issues/2", "refs/heads/merges/1"}))
+				Expect(res["pruned"]).To(BeEmpty())
+				Expect(testutil2.GetRecentCommitHash(path, "refs/heads/issues/2")).ToNot(BeEmpty())
+			})
+
+			It("should delete the references of expired posts when action is 'prune'", func() {
+				repo.Config.PostRetention = &state.PostRetentionPolicy{ClosedTTL: 3600, Action: state.PostRetentionPrune}
+				m.Now = func() time.Time { return closedAt.Add(2 * time.Hour) }
+				res := m.ApplyPostRetention("repo1")
+				Expect(res["archived"]).To(BeEmpty())
+				Expect(res["pruned"]).To(Equal([]string{"refs/heads/issues/2", "refs/heads/merges/1"}))
+				refs := string(testutil2.ExecGit(path, "for-each-ref", "--format=%(refname)"))
+				Expect(strings.Fields(refs)).To(Equal([]string{"refs/heads/issues/1", "refs/heads/master"}))
+			})
+		})
+	})
+
+	Describe(".ExportPosts", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ExportPosts("", plumbing.PostBundleKindIssue)
+			})
+		})
+
+		It("should panic when kind is unknown", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "expected 'issue' or 'mergeRequest'", Field: "kind"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ExportPosts("repo1", "proposal")
+			})
+		})
+
+		It("should panic when repo does not exist", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(state.BareRepository())
+			err := &errors.ReqError{Code: modules.StatusCodeRepoNotFound, HttpCode: 404, Msg: "repo not found", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ExportPosts("repo1", plumbing.PostBundleKindIssue)
+			})
+		})
+	})
+
+	Describe(".ImportPosts", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ImportPosts("", "{}")
+			})
+		})
+
+		It("should panic when bundle is not valid", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "bundle is not valid", Field: "bundle"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ImportPosts("repo1", "not json")
+			})
+		})
+
+		It("should panic when bundle kind is not supported", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "bundle kind is not supported", Field: "bundle"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ImportPosts("repo1", `{"version":1,"kind":"proposal"}`)
+			})
+		})
+
+		It("should panic when bundle version is not supported", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "bundle version is not supported", Field: "bundle"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ImportPosts("repo1", `{"version":100,"kind":"issue"}`)
+			})
+		})
+
+		It("should panic when repo does not exist", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(state.BareRepository())
+			err := &errors.ReqError{Code: modules.StatusCodeRepoNotFound, HttpCode: 404, Msg: "repo not found", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ImportPosts("repo1", `{"version":1,"kind":"issue"}`)
+			})
+		})
+	})
+
+	Describe(".ExportPosts and .ImportPosts", func() {
+		var env []string
+		var srcPath, dstPath string
+		var tempRepoMgr *temprepomgr.BasicTempRepoManager
+
+		// commitAt sets the author and committer dates of subsequent test commits
+		commitAt := func(t int64) {
+			testutil2.GitEnv = append(append([]string{}, env...),
+				fmt.Sprintf("GIT_AUTHOR_DATE=@%d +0000", t),
+				fmt.Sprintf("GIT_COMMITTER_DATE=@%d +0000", t))
+		}
+
+		BeforeEach(func() {
+			env = testutil2.GitEnv
+			repo := state.BareRepository()
+			repo.Balance = "10"
+			mockRepoKeeper.EXPECT().Get(gomock.Any()).Return(repo).AnyTimes()
+			tempRepoMgr = temprepomgr.New()
+			mockRepoSrv.EXPECT().GetTempRepoManager().Return(tempRepoMgr).AnyTimes()
+
+			srcPath = cfg.GetRepoPath("repo1")
+			testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+			commitAt(1600000000)
+			testutil2.AppendCommit(srcPath, "file.txt", "hello", "c1")
+			testutil2.CreateCheckoutOrphanBranch(srcPath, "issues/1")
+			testutil2.AppendCommit(srcPath, "body", "---\ntitle: Bug\nlabels: [bug]\n---\nSomething broke", "issue 1")
+			firstComment := testutil2.GetRecentCommitHash(srcPath, "refs/heads/issues/1")
+			commitAt(1600000002)
+			Expect(ioutil.WriteFile(filepath.Join(srcPath, "body"), []byte("---\nreplyTo: "+firstComment+"\n---\nSeen it"), 0644)).To(Succeed())
+			testutil2.ExecGitAdd(srcPath, "body")
+			testutil2.ExecGitCommit(srcPath, "comment 1")
+			testutil2.CheckoutBranch(srcPath, "master")
+			commitAt(1600000001)
+			testutil2.CreateCheckoutOrphanBranch(srcPath, "issues/2")
+			testutil2.AppendCommit(srcPath, "body", "---\ntitle: Typo\nclose: true\n---\nFix the typo", "issue 2")
+			testutil2.CheckoutBranch(srcPath, "master")
+			testutil2.CreateCheckoutOrphanBranch(srcPath, "merges/1")
+			testutil2.AppendCommit(srcPath, "body", "---\ntitle: Fix\nbase: master\nbaseHash: abc\ntarget: dev\ntargetHash: xyz\n---\nFixes the bug", "merge request 1")
+			testutil2.CheckoutBranch(srcPath, "master")
+
+			dstPath = cfg.GetRepoPath("repo2")
+			testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo2")
+			testutil2.AppendCommit(dstPath, "file.txt", "hello", "c1")
+			testutil2.CreateCheckoutOrphanBranch(dstPath, "issues/1")
+			testutil2.AppendCommit(dstPath, "body", "---\ntitle: Existing\n---\nbody", "issue 1")
+			testutil2.CheckoutBranch(dstPath, "master")
+		})
+
+		AfterEach(func() {
+			testutil2.GitEnv = env
+		})
+
+		readComments := func(repoID, reference string) plumbing.Comments {
+			r, err := repo.GetWithGitModule(cfg.Node.GitBinPath, tempRepoMgr.GetPath(repoID))
+			Expect(err).To(BeNil())
+			comments, err := issuecmd.IssueReadCmd(r, &issuecmd.IssueReadArgs{Reference: reference, Reverse: true, PostGetter: plumbing.GetPosts})
+			Expect(err).To(BeNil())
+			return comments
+		}
+
+		It("should export issues oldest first with their comments", func() {
+			var bundle plumbing.PostBundle
+			Expect(json.Unmarshal([]byte(m.ExportPosts("repo1", plumbing.PostBundleKindIssue)), &bundle)).To(Succeed())
+			Expect(bundle.Version).To(Equal(plumbing.PostBundleVersion))
+			Expect(bundle.Kind).To(Equal(plumbing.PostBundleKindIssue))
+			Expect(bundle.Posts).To(HaveLen(2))
+			Expect(bundle.Posts[0].Reference).To(Equal("refs/heads/issues/1"))
+			Expect(bundle.Posts[0].Title).To(Equal("Bug"))
+			Expect(bundle.Posts[0].Closed).To(BeFalse())
+			Expect(bundle.Posts[0].Comments).To(HaveLen(2))
+			Expect(bundle.Posts[0].Comments[0].Body.Labels).To(Equal([]string{"bug"}))
+			Expect(string(bundle.Posts[0].Comments[1].Body.Content)).To(Equal("Seen it"))
+			Expect(bundle.Posts[0].Comments[1].Body.ReplyTo).To(Equal(bundle.Posts[0].Comments[0].Hash))
+			Expect(bundle.Posts[1].Reference).To(Equal("refs/heads/issues/2"))
+			Expect(bundle.Posts[1].Closed).To(BeTrue())
+		})
+
+		It("should re-create exported issues with new IDs, preserving metadata and replies", func() {
+			res := m.ImportPosts("repo2", m.ExportPosts("repo1", plumbing.PostBundleKindIssue))
+			repoID := res["repoID"].(string)
+			posts := res["posts"].([]util.Map)
+			Expect(posts).To(HaveLen(2))
+			Expect(posts[0]["source"]).To(Equal("refs/heads/issues/1"))
+			Expect(posts[0]["reference"]).To(Equal("refs/heads/issues/2"))
+			Expect(posts[1]["source"]).To(Equal("refs/heads/issues/2"))
+			Expect(posts[1]["reference"]).To(Equal("refs/heads/issues/3"))
+
+			comments := readComments(repoID, "refs/heads/issues/2")
+			Expect(comments).To(HaveLen(2))
+			Expect(comments[0].Body.Title).To(Equal("Bug"))
+			Expect(comments[0].Body.Labels).To(Equal([]string{"bug"}))
+			Expect(string(comments[0].Body.Content)).To(Equal("Something broke"))
+			Expect(string(comments[1].Body.Content)).To(Equal("Seen it"))
+			Expect(comments[1].Body.ReplyTo).To(Equal(comments[0].Hash))
+			Expect(posts[0]["hash"]).To(Equal(comments[1].Hash))
+
+			comments = readComments(repoID, "refs/heads/issues/3")
+			Expect(comments).To(HaveLen(1))
+			Expect(comments[0].Body.Title).To(Equal("Typo"))
+			Expect(*comments[0].Body.Close).To(BeTrue())
+		})
+
+		It("should re-create exported merge requests", func() {
+			res := m.ImportPosts("repo2", m.ExportPosts("repo1", plumbing.PostBundleKindMergeRequest))
+			posts := res["posts"].([]util.Map)
+			Expect(posts).To(HaveLen(1))
+			Expect(posts[0]["reference"]).To(Equal("refs/heads/merges/1"))
+
+			r, err := repo.GetWithGitModule(cfg.Node.GitBinPath, tempRepoMgr.GetPath(res["repoID"].(string)))
+			Expect(err).To(BeNil())
+			comments, err := mergecmd.MergeRequestReadCmd(r, &mergecmd.MergeRequestReadArgs{Reference: "refs/heads/merges/1", PostGetter: plumbing.GetPosts})
+			Expect(err).To(BeNil())
+			Expect(comments).To(HaveLen(1))
+			Expect(comments[0].Body.Title).To(Equal("Fix"))
+			Expect(comments[0].Body.BaseBranch).To(Equal("master"))
+			Expect(comments[0].Body.TargetBranchHash).To(Equal("xyz"))
+		})
+
+		It("should panic when a comment replies to a comment that is not in the bundle", func() {
+			var bundle plumbing.PostBundle
+			Expect(json.Unmarshal([]byte(m.ExportPosts("repo1", plumbing.PostBundleKindIssue)), &bundle)).To(Succeed())
+			bundle.Posts[0].Comments = bundle.Posts[0].Comments[1:]
+			bz, _ := json.Marshal(bundle)
+			msg := fmt.Sprintf("failed to import post (refs/heads/issues/1): comment (%s) replies to an unknown comment", bundle.Posts[0].Comments[0].Hash)
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: msg, Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ImportPosts("repo2", string(bz))
+			})
+		})
+	})
+
+	Describe(".GetRepoTimeline", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetRepoTimeline("")
+			})
+		})
+
+		It("should panic when an event type is unknown", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "unknown event type (star)", Field: "opts.types"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetRepoTimeline("repo1", types.RepoTimelineOptions{Types: []string{"push", "star"}})
+			})
+		})
+
+		It("should panic when limit is negative", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "limit must be a non-negative number", Field: "opts.limit"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetRepoTimeline("repo1", types.RepoTimelineOptions{Limit: -1})
+			})
+		})
+
+		It("should panic when repo does not exist", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(state.BareRepository())
+			err := &errors.ReqError{Code: "repo_not_found", HttpCode: 404, Msg: "repo not found", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetRepoTimeline("repo1")
+			})
+		})
+
+		When("repo has pushes, proposals and posts", func() {
+			var mockSysKeeper *mocks.MockSystemKeeper
+
+			BeforeEach(func() {
+				repo := state.BareRepository()
+				repo.Balance = "100"
+				repo.Proposals.Add("1", &state.RepoProposal{Action: txns.TxTypeRepoProposalUpdate, Creator: "addr1", Height: 10})
+				repo.Proposals.Add("2", &state.RepoProposal{Action: txns.TxTypeRepoProposalUpsertOwner, Creator: "addr2", Height: 20})
+				mockRepoKeeper.EXPECT().Get("repo1").Return(repo)
+
+				mockSysKeeper = mocks.NewMockSystemKeeper(ctrl)
+				mockLogic.EXPECT().SysKeeper().Return(mockSysKeeper).AnyTimes()
+				mockSysKeeper.EXPECT().GetBlockInfo(int64(10)).Return(&state.BlockInfo{Height: 10, Time: 1000}, nil).MaxTimes(1)
+				mockSysKeeper.EXPECT().GetBlockInfo(int64(20)).Return(nil, keepers.ErrBlockInfoNotFound).MaxTimes(1)
+
+				mockRepoSyncInfoKeeper.EXPECT().GetBranchActivities("repo1").Return(map[string]*core.BranchActivity{
+					"refs/heads/master": {Hash: "hash1", LastCommitTime: 3000},
+					"refs/heads/dev":    {Hash: "hash2", LastCommitTime: 2000},
+				}, nil).MaxTimes(1)
+
+				path := cfg.GetRepoPath("repo1")
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+				testutil2.AppendCommit(path, "file.txt", "hello", "c1")
+				testutil2.CreateCheckoutOrphanBranch(path, "issues/1")
+				testutil2.AppendCommit(path, "body", "---\ntitle: Bug\n---\nSomething broke", "issue 1")
+				testutil2.CheckoutBranch(path, "master")
+				testutil2.CreateCheckoutOrphanBranch(path, "merges/1")
+				testutil2.AppendCommit(path, "body", "---\ntitle: Fix\nclose: true\n---\nFixes the bug", "merge request 1")
+			})
+
+			It("should merge all events, most recent first", func() {
+				res := m.GetRepoTimeline("repo1")
+				Expect(res).To(HaveLen(6))
+				Expect(res[0]["type"]).To(Equal(modules.TimelineEventIssue))
+				Expect(res[0]["reference"]).To(Equal("refs/heads/issues/1"))
+				Expect(res[0]["title"]).To(Equal("Bug"))
+				Expect(res[0]["closed"]).To(BeFalse())
+				Expect(res[1]["type"]).To(Equal(modules.TimelineEventMergeRequest))
+				Expect(res[1]["reference"]).To(Equal("refs/heads/merges/1"))
+				Expect(res[1]["closed"]).To(BeTrue())
+				Expect(res[2]["type"]).To(Equal(modules.TimelineEventPush))
+				Expect(res[2]["reference"]).To(Equal("refs/heads/master"))
+				Expect(res[2]["time"]).To(Equal(int64(3000)))
+				Expect(res[3]["reference"]).To(Equal("refs/heads/dev"))
+				Expect(res[4]["type"]).To(Equal(modules.TimelineEventProposal))
+				Expect(res[4]["id"]).To(Equal("1"))
+				Expect(res[4]["time"]).To(Equal(int64(1000)))
+				Expect(res[5]["id"]).To(Equal("2"))
+				Expect(res[5]["time"]).To(Equal(int64(0)))
+			})
+
+			It("should return only events of the given types", func() {
+				res := m.GetRepoTimeline("repo1", types.RepoTimelineOptions{Types: []string{"push", "proposal"}})
+				Expect(res).To(HaveLen(4))
+				Expect(res[0]["reference"]).To(Equal("refs/heads/master"))
+				Expect(res[3]["id"]).To(Equal("2"))
+			})
+
+			It("should return a page of events when offset and limit are set", func() {
+				res := m.GetRepoTimeline("repo1", types.RepoTimelineOptions{Offset: 2, Limit: 3})
+				Expect(res).To(HaveLen(3))
+				Expect(res[0]["reference"]).To(Equal("refs/heads/master"))
+				Expect(res[2]["id"]).To(Equal("1"))
+			})
+
+			It("should return empty result when offset is beyond the number of events", func() {
+				Expect(m.GetRepoTimeline("repo1", types.RepoTimelineOptions{Offset: 6})).To(BeEmpty())
+			})
+		})
+
+		It("should skip post events when the repo does not exist locally", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(&state.Repository{Balance: "10"})
+			mockRepoSyncInfoKeeper.EXPECT().GetBranchActivities("repo1").Return(map[string]*core.BranchActivity{
+				"refs/heads/master": {Hash: "hash1", LastCommitTime: 3000},
+			}, nil)
+			res := m.GetRepoTimeline("repo1")
+			Expect(res).To(HaveLen(1))
+			Expect(res[0]["type"]).To(Equal(modules.TimelineEventPush))
+		})
+	})
+
+	Describe(".ReadMergeRequest()", func() {
+		It("should panic when repo name was not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReadMergeRequest("", plumbing.MakeMergeRequestReference(1))
+			})
+		})
+
+		It("should panic when repo was not found", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReadMergeRequest("unknown", plumbing.MakeMergeRequestReference(1))
+			})
+		})
+
+		It("should panic when unable to get the merge request", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			m.MergeRequestRead = func(_ plumbing.LocalRepo, _ *mergecmd.MergeRequestReadArgs) (plumbing.Comments, error) {
+				return nil, fmt.Errorf("error here")
+			}
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReadMergeRequest("repo3", plumbing.MakeMergeRequestReference(1))
+			})
+		})
+
+		It("should not panic on success", func() {
+			cfg.SetRepoRoot("../remote/repo/testdata")
+			m.MergeRequestRead = func(_ plumbing.LocalRepo, _ *mergecmd.MergeRequestReadArgs) (plumbing.Comments, error) {
+				return []*plumbing.Comment{
+					{Author: "a"},
+				}, nil
+			}
+			assert.NotPanics(GinkgoT(), func() {
+				res := m.ReadMergeRequest("repo3", plumbing.MakeMergeRequestReference(1))
+				Expect(res).To(HaveLen(1))
+				Expect(res[0]).To(HaveKey("author"))
+				Expect(res[0]["author"]).To(Equal("a"))
+			})
+		})
+	})
+
+	Describe(".Push", func() {
+		It("should panic if id is not associated with a temporary repo", func() {
+			param := map[string]interface{}{"id": "repo_123"}
+			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
+			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+			mockTempRepoMgr.EXPECT().GetPath(param["id"]).Return("")
+			err := &errors.ReqError{Code: "invalid_temp_repo_id", HttpCode: 404, Msg: "id is expired or invalid", Field: "id"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.Push(param, "privKey")
+			})
+		})
+
+		It("should panic if reference is not a branch, note or tag reference", func() {
+			param := map[string]interface{}{"id": "repo_123", "reference": "some/type/of/reference"}
+			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
+			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+			mockTempRepoMgr.EXPECT().GetPath(param["id"]).Return("/path/repo")
+			err := &errors.ReqError{Code: "invalid_reference_name", HttpCode: 400, Msg: "reference name is not valid", Field: "reference"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.Push(param, "privKey")
+			})
+		})
+
+		It("should panic if private key was not set or is invalid", func() {
+			param := map[string]interface{}{"id": "repo_123", "reference": "refs/heads/master"}
+			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
+			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr).Times(2)
+			mockTempRepoMgr.EXPECT().GetPath(param["id"]).Return("/path/repo").Times(2)
+			err := &errors.ReqError{Code: "invalid_private_key", HttpCode: 400, Msg: "private key is required", Field: "privateKeyOrPushToken"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.Push(param, "")
+			})
+
+			err = &errors.ReqError{Code: "invalid_private_key", HttpCode: 400, Msg: "private key or push token is not a valid", Field: "privateKeyOrPushToken"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.Push(param, "invalid_pk")
+			})
+		})
+
+		It("should panic if repo was not found", func() {
+			key := ed25519.NewKeyFromIntSeed(1)
+			param := map[string]interface{}{"id": "repo_123", "reference": "refs/heads/master"}
+			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
+			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr).Times(2)
+			mockTempRepoMgr.EXPECT().GetPath(param["id"]).Return("/path/repo").Times(2)
+			m.GetLocalRepo = func(_, path string) (plumbing.LocalRepo, error) {
+				Expect(path).To(Equal("/path/repo"))
+				return nil, fmt.Errorf("error here")
+			}
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.Push(param, key.PrivKey().Base58())
+			})
+
+			m.GetLocalRepo = func(_, path string) (plumbing.LocalRepo, error) {
+				Expect(path).To(Equal("/path/repo"))
+				return nil, git.ErrRepositoryNotExists
+			}
+			err = &errors.ReqError{Code: "repo_not_found", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.Push(param, key.PrivKey().Base58())
+			})
+		})
+
+		It("should panic if unable to get config", func() {
+			key := ed25519.NewKeyFromIntSeed(1)
+			param := map[string]interface{}{
+				"id":        "repo_123",
+				"reference": "refs/heads/master",
+				"nonce":     "0",
+			}
+			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
+			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+			mockTempRepoMgr.EXPECT().GetPath(param["id"]).Return("/path/repo")
+
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, path string) (plumbing.LocalRepo, error) {
+				Expect(path).To(Equal("/path/repo"))
+				return mockRepo, nil
+			}
+
+			// expected to be called if nonce is unset or zero
+			mockAccountKeeper.EXPECT().Get(key.PubKey().Addr()).Return(state.NewBareAccount())
+
+			// expect origin remote to be set with correct url
+			mockRepo.EXPECT().GetName().Return("repo1").Times(2)
+			mockRepo.EXPECT().Config().Return(nil, fmt.Errorf("error here"))
+
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.Push(param, key.PrivKey().Base58())
+			})
+		})
+
+		It("should panic if unable to set config", func() {
+			key := ed25519.NewKeyFromIntSeed(1)
+			param := map[string]interface{}{
+				"id":        "repo_123",
+				"reference": "refs/heads/master",
+				"nonce":     "0",
+			}
+			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
+			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+			mockTempRepoMgr.EXPECT().GetPath(param["id"]).Return("/path/repo")
+
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, path string) (plumbing.LocalRepo, error) {
+				Expect(path).To(Equal("/path/repo"))
+				return mockRepo, nil
+			}
+
+			// expected to be called if nonce is unset or zero
+			mockAccountKeeper.EXPECT().Get(key.PubKey().Addr()).Return(state.NewBareAccount())
+
+			// expect origin remote to be set with correct url
+			mockRepo.EXPECT().GetName().Return("repo1").Times(2)
+			mockRepo.EXPECT().Config().Return(&config2.Config{Remotes: map[string]*config2.RemoteConfig{}}, nil)
+			mockRepo.EXPECT().SetConfig(gomock.Any()).Return(fmt.Errorf("error here"))
+
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.Push(param, key.PrivKey().Base58())
+			})
+		})
+
+		It("should attempt to push; "+
+			"it should set correct nonce when one was not provided; "+
+			"it should add correct remote to repo;"+
+			"it should nil on successful push", func() {
+			key := ed25519.NewKeyFromIntSeed(1)
+			param := map[string]interface{}{
+				"id":        "repo_123",
+				"reference": "refs/heads/master",
+				"nonce":     "0",
+			}
+			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
+			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+			mockTempRepoMgr.EXPECT().GetPath(param["id"]).Return("/path/repo")
+
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, path string) (plumbing.LocalRepo, error) {
+				Expect(path).To(Equal("/path/repo"))
+				return mockRepo, nil
+			}
+
+			// expected to be called if nonce is unset or zero
+			mockAccountKeeper.EXPECT().Get(key.PubKey().Addr()).Return(state.NewBareAccount())
+
+			// expect origin remote to be set with correct url
+			mockRepo.EXPECT().GetName().Return("repo1").Times(2)
+			mockRepo.EXPECT().Config().Return(&config2.Config{Remotes: map[string]*config2.RemoteConfig{}}, nil)
+			mockRepo.EXPECT().SetConfig(gomock.Any()).Do(func(cfg *config2.Config) {
+				Expect(cfg.Remotes).To(HaveLen(1))
+				Expect(cfg.Remotes).To(HaveKey("origin"))
+				Expect(cfg.Remotes["origin"].URLs).To(HaveLen(1))
+				Expect(cfg.Remotes["origin"].URLs[0]).To(Equal("http://127.0.0.1:9002/r/repo1"))
+			})
+
+			mockRepo.EXPECT().Push(gomock.Any()).DoAndReturn(func(opts plumbing.PushOptions) (bytes.Buffer, error) {
+				Expect(opts.RemoteName).To(BeEmpty())
+				Expect(opts.Token).ToNot(BeEmpty())
+				Expect(opts.RefSpec).To(Equal(fmt.Sprintf("+%s:%s", param["reference"], param["reference"])))
+				return *bytes.NewBuffer([]byte("hash: tx_hash_123")), nil
+			})
+
+			// it should remove repo from temp. repo manager cache
+			mockTempRepoMgr.EXPECT().Remove(param["id"])
+
+			assert.NotPanics(GinkgoT(), func() {
+				txHash := m.Push(param, key.PrivKey().Base58())
+				Expect(txHash).To(Equal("tx_hash_123"))
+			})
+		})
+
+		It("should panic if push failed", func() {
+			key := ed25519.NewKeyFromIntSeed(1)
+			param := map[string]interface{}{
+				"id":        "repo_123",
+				"reference": "refs/heads/master",
+				"nonce":     "0",
+			}
+			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
+			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+			mockTempRepoMgr.EXPECT().GetPath(param["id"]).Return("/path/repo")
+
+			var mockRepo = mocks.NewMockLocalRepo(ctrl)
+			m.GetLocalRepo = func(_, path string) (plumbing.LocalRepo, error) {
+				Expect(path).To(Equal("/path/repo"))
+				return mockRepo, nil
+			}
+
+			// expected to be called if nonce is unset or zero
+			mockAccountKeeper.EXPECT().Get(key.PubKey().Addr()).Return(state.NewBareAccount())
+
+			// expect origin remote to be set with correct url
+			mockRepo.EXPECT().GetName().Return("repo1").Times(2)
+			mockRepo.EXPECT().Config().Return(&config2.Config{Remotes: map[string]*config2.RemoteConfig{}}, nil)
+			mockRepo.EXPECT().SetConfig(gomock.Any())
+
+			mockRepo.EXPECT().Push(gomock.Any()).DoAndReturn(func(opts plumbing.PushOptions) (bytes.Buffer, error) {
+				return bytes.Buffer{}, fmt.Errorf("error here")
+			})
+
+			err := &errors.ReqError{Code: "push_failure", HttpCode: 500, Msg: "error here", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.Push(param, key.PrivKey().Base58())
+			})
+		})
+
+		When("pushes are made concurrently", func() {
+			var key = ed25519.NewKeyFromIntSeed(1)
+			var mockTempRepoMgr *mocks.MockTempRepoManager
+
+			BeforeEach(func() {
+				mockTempRepoMgr = mocks.NewMockTempRepoManager(ctrl)
+				mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr).AnyTimes()
+				mockTempRepoMgr.EXPECT().Remove(gomock.Any()).AnyTimes()
+			})
+
+			It("should serialize config update and push of the same repo", func() {
+				mockTempRepoMgr.EXPECT().GetPath("repo_123").Return("/path/repo").AnyTimes()
+
+				var cfgLck sync.Mutex
+				var repoCfg = &config2.Config{Remotes: map[string]*config2.RemoteConfig{}}
+				var active, maxActive int32
+				var mockRepo = mocks.NewMockLocalRepo(ctrl)
+				mockRepo.EXPECT().GetName().Return("repo1").AnyTimes()
+				mockRepo.EXPECT().Config().DoAndReturn(func() (*config2.Config, error) {
+					if n := atomic.AddInt32(&active, 1); n > atomic.LoadInt32(&maxActive) {
+						atomic.StoreInt32(&maxActive, n)
+					}
+					cfgLck.Lock()
+					defer cfgLck.Unlock()
+					cp := &config2.Config{Remotes: map[string]*config2.RemoteConfig{}}
+					for k, v := range repoCfg.Remotes {
+						cp.Remotes[k] = v
+					}
+					return cp, nil
+				}).AnyTimes()
+				mockRepo.EXPECT().SetConfig(gomock.Any()).DoAndReturn(func(c *config2.Config) error {
+					time.Sleep(time.Millisecond)
+					cfgLck.Lock()
+					defer cfgLck.Unlock()
+					repoCfg = c
+					return nil
+				}).AnyTimes()
+				mockRepo.EXPECT().Push(gomock.Any()).DoAndReturn(func(opts plumbing.PushOptions) (bytes.Buffer, error) {
+					time.Sleep(time.Millisecond)
+					atomic.AddInt32(&active, -1)
+					return *bytes.NewBuffer([]byte("hash: tx_hash_123")), nil
+				}).AnyTimes()
+				m.GetLocalRepo = func(_, path string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+
+				wg := sync.WaitGroup{}
+				for i := 0; i < 10; i++ {
+					wg.Add(1)
+					go func() {
+						defer GinkgoRecover()
+						defer wg.Done()
+						param := map[string]interface{}{"id": "repo_123", "reference": "refs/heads/master", "nonce": "1"}
+						Expect(m.Push(param, key.PrivKey().Base58())).To(Equal("tx_hash_123"))
+					}()
+				}
+				wg.Wait()
+
+				Expect(maxActive).To(Equal(int32(1)))
+				Expect(repoCfg.Remotes).To(HaveLen(1))
+				Expect(repoCfg.Remotes["origin"].URLs).To(Equal([]string{"http://127.0.0.1:9002/r/repo1"}))
+			})
+
+			It("should not serialize pushes of different repos", func() {
+				mockTempRepoMgr.EXPECT().GetPath("repo_1").Return("/path/repo1").AnyTimes()
+				mockTempRepoMgr.EXPECT().GetPath("repo_2").Return("/path/repo2").AnyTimes()
+
+				started := make(chan struct{})
+				newMockRepo := func(name string, push func() error) *mocks.MockLocalRepo {
+					mockRepo := mocks.NewMockLocalRepo(ctrl)
+					mockRepo.EXPECT().GetName().Return(name).AnyTimes()
+					mockRepo.EXPECT().Config().Return(&config2.Config{Remotes: map[string]*config2.RemoteConfig{}}, nil)
+					mockRepo.EXPECT().SetConfig(gomock.Any()).Return(nil)
+					mockRepo.EXPECT().Push(gomock.Any()).DoAndReturn(func(opts plumbing.PushOptions) (bytes.Buffer, error) {
+						if err := push(); err != nil {
+							return bytes.Buffer{}, err
+						}
+						return *bytes.NewBuffer([]byte("hash: tx_hash_123")), nil
+					})
+					return mockRepo
+				}
+
+				// The push of repo1 blocks until the push of repo2 has started
+				repo1 := newMockRepo("repo1", func() error {
+					select {
+					case <-started:
+						return nil
+					case <-time.After(5 * time.Second):
+						return fmt.Errorf("timed out")
+					}
+				})
+				repo2 := newMockRepo("repo2", func() error {
+					close(started)
+					return nil
+				})
+				m.GetLocalRepo = func(_, path string) (plumbing.LocalRepo, error) {
+					if path == "/path/repo1" {
+						return repo1, nil
+					}
+					return repo2, nil
+				}
+
+				wg := sync.WaitGroup{}
+				wg.Add(2)
+				for _, id := range []string{"repo_1", "repo_2"} {
+					go func(id string) {
+						defer GinkgoRecover()
+						defer wg.Done()
+						param := map[string]interface{}{"id": id, "reference": "refs/heads/master", "nonce": "1"}
+						Expect(m.Push(param, key.PrivKey().Base58())).To(Equal("tx_hash_123"))
+					}(id)
+				}
+				wg.Wait()
+			})
+		})
+
+		When("push token is provided as key", func() {
+			It("should use push token directly", func() {
+				key := ed25519.NewKeyFromIntSeed(1)
+				token := pushtoken.MakeFromKey(key, &remotetypes.TxDetail{
+					RepoName:  "repo1",
+					PushKeyID: key.PushAddr().String(),
+				})
+
+				param := map[string]interface{}{
+					"id":        "repo_123",
+					"reference": "refs/heads/master",
+					"nonce":     "0",
+				}
+				mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
+				mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+				mockTempRepoMgr.EXPECT().GetPath(param["id"]).Return("/path/repo")
+
+				var mockRepo = mocks.NewMockLocalRepo(ctrl)
+				m.GetLocalRepo = func(_, path string) (plumbing.LocalRepo, error) {
+					Expect(path).To(Equal("/path/repo"))
+					return mockRepo, nil
+				}
+
+				// expect origin remote to be set with correct url
+				mockRepo.EXPECT().GetName().Return("repo1")
+				mockRepo.EXPECT().Config().Return(&config2.Config{Remotes: map[string]*config2.RemoteConfig{}}, nil)
+				mockRepo.EXPECT().SetConfig(gomock.Any()).Do(func(cfg *config2.Config) {
+					Expect(cfg.Remotes).To(HaveLen(1))
+					Expect(cfg.Remotes).To(HaveKey("origin"))
+					Expect(cfg.Remotes["origin"].URLs).To(HaveLen(1))
+					Expect(cfg.Remotes["origin"].URLs[0]).To(Equal("http://127.0.0.1:9002/r/repo1"))
+				})
+
+				mockRepo.EXPECT().Push(gomock.Any()).DoAndReturn(func(opts plumbing.PushOptions) (bytes.Buffer, error) {
+					Expect(opts.RemoteName).To(BeEmpty())
+					Expect(opts.Token).To(Equal(token))
+					Expect(opts.RefSpec).To(Equal(fmt.Sprintf("+%s:%s", param["reference"], param["reference"])))
+					return *bytes.NewBuffer([]byte("hash: tx_hash_123")), nil
+				})
+
+				// it should remove repo from temp. repo manager cache
+				mockTempRepoMgr.EXPECT().Remove(param["id"])
+
+				assert.NotPanics(GinkgoT(), func() {
+					txHash := m.Push(param, token)
+					Expect(txHash).To(Equal("tx_hash_123"))
+				})
+			})
+
+			It("should panic if priority is requested", func() {
+				key := ed25519.NewKeyFromIntSeed(1)
+				token := pushtoken.MakeFromKey(key, &remotetypes.TxDetail{RepoName: "repo1", PushKeyID: key.PushAddr().String()})
+				param := map[string]interface{}{"id": "repo_123", "reference": "refs/heads/master", "priority": true}
+				mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
+				mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+				mockTempRepoMgr.EXPECT().GetPath(param["id"]).Return("/path/repo")
+				err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "priority push requires a private key", Field: "priority"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.Push(param, token)
+				})
+			})
+		})
+
+		When("priority is requested", func() {
+			var key = ed25519.NewKeyFromIntSeed(1)
+			var mockRepo *mocks.MockLocalRepo
+			var mockTempRepoMgr *mocks.MockTempRepoManager
+			var pushedFee util.String
+			var hash = "8d998c7de21bbe561f7992bb983cef4b1554993b"
+			var priorityFee decimal.Decimal
+
+			BeforeEach(func() {
+				mockTempRepoMgr = mocks.NewMockTempRepoManager(ctrl)
+				mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+				mockTempRepoMgr.EXPECT().GetPath("repo_123").Return("/path/repo")
+				mockTempRepoMgr.EXPECT().Remove("repo_123")
+
+				mockRepo = mocks.NewMockLocalRepo(ctrl)
+				m.GetLocalRepo = func(_, path string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+				mockRepo.EXPECT().GetName().Return("repo1").Times(2)
+				mockRepo.EXPECT().Config().Return(&config2.Config{Remotes: map[string]*config2.RemoteConfig{}}, nil)
+				mockRepo.EXPECT().SetConfig(gomock.Any()).Return(nil)
+				mockRepo.EXPECT().RefGet("refs/remotes/origin/master").Return("", plumbing.ErrRefNotFound)
+				mockRepo.EXPECT().Push(gomock.Any()).DoAndReturn(func(opts plumbing.PushOptions) (bytes.Buffer, error) {
+					txDetail, err := pushtoken.Decode(opts.Token)
+					Expect(err).To(BeNil())
+					pushedFee = txDetail.Fee
+					return *bytes.NewBuffer([]byte("hash: tx_hash_123")), nil
+				})
+
+				m.GetSizeOfObjects = func(note pushtypes.PushNote) (uint64, error) {
+					Expect(note.GetPushedReferences()[0].NewHash).To(Equal(hash))
+					return 1000, nil
+				}
+
+				note := &pushtypes.Note{References: []*pushtypes.PushedReference{
+					{Name: "refs/heads/master", OldHash: plumbing2.ZeroHash.String(), NewHash: hash},
+				}}
+				priorityFee = params.PriorityFeePerByte.Mul(decimal.New(int64(1000+note.GetEcoSize()), 0))
+			})
+
+			It("should raise the fee to the priority fee", func() {
+				param := map[string]interface{}{"id": "repo_123", "reference": "refs/heads/master", "hash": hash, "fee": "0.00001", "nonce": "1", "priority": true}
+				Expect(m.Push(param, key.PrivKey().Base58())).To(Equal("tx_hash_123"))
+				Expect(pushedFee.Decimal().Equal(priorityFee)).To(BeTrue())
+			})
+
+			It("should keep the fee if it is higher than the priority fee", func() {
+				param := map[string]interface{}{"id": "repo_123", "reference": "refs/heads/master", "hash": hash, "fee": "10", "nonce": "1", "priority": true}
+				Expect(m.Push(param, key.PrivKey().Base58())).To(Equal("tx_hash_123"))
+				Expect(pushedFee).To(Equal(util.String("10")))
+			})
+		})
+	})
+
+	Describe(".EstimatePushSize", func() {
+		var mockTempRepoMgr *mocks.MockTempRepoManager
+		var mockRepo *mocks.MockLocalRepo
+
+		BeforeEach(func() {
+			mockTempRepoMgr = mocks.NewMockTempRepoManager(ctrl)
+			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+			mockRepo = mocks.NewMockLocalRepo(ctrl)
+		})
+
+		It("should panic if id is not associated with a temporary repo", func() {
+			mockTempRepoMgr.EXPECT().GetPath("repo_123").Return("")
+			err := &errors.ReqError{Code: "invalid_temp_repo_id", HttpCode: 404, Msg: "id is expired or invalid", Field: "id"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.EstimatePushSize("repo_123")
+			})
+		})
+
+		It("should panic if repo was not found", func() {
+			mockTempRepoMgr.EXPECT().GetPath("repo_123").Return("/path/repo")
+			m.GetLocalRepo = func(_, path string) (plumbing.LocalRepo, error) {
+				Expect(path).To(Equal("/path/repo"))
+				return nil, git.ErrRepositoryNotExists
+			}
+			err := &errors.ReqError{Code: "repo_not_found", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.EstimatePushSize("repo_123")
+			})
+		})
+
+		When("repo exists", func() {
+			BeforeEach(func() {
+				mockTempRepoMgr.EXPECT().GetPath("repo_123").Return("/path/repo")
+				m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+				mockRepo.EXPECT().GetReferences().Return([]plumbing2.ReferenceName{
+					"HEAD",
+					"refs/heads/master",
+					"refs/heads/issues/1",
+					"refs/remotes/origin/master",
+				}, nil)
+				mockRepo.EXPECT().RefGet("refs/heads/master").Return("hash1", nil)
+				mockRepo.EXPECT().RefGet("refs/remotes/origin/master").Return("hash1", nil)
+				mockRepo.EXPECT().RefGet("refs/heads/issues/1").Return("hash2", nil)
+				mockRepo.EXPECT().RefGet("refs/remotes/origin/issues/1").Return("", plumbing.ErrRefNotFound)
+			})
+
+			It("should panic if unable to get size of objects", func() {
+				m.GetSizeOfObjects = func(note pushtypes.PushNote) (uint64, error) {
+					return 0, fmt.Errorf("error")
+				}
+				err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error", Field: ""}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.EstimatePushSize("repo_123")
+				})
+			})
+
+			It("should return size of objects of changed branches", func() {
+				m.GetSizeOfObjects = func(note pushtypes.PushNote) (uint64, error) {
+					Expect(note.GetTargetRepo()).To(Equal(mockRepo))
+					Expect(note.GetPushedReferences()).To(HaveLen(1))
+					Expect(note.GetPushedReferences()[0].Name).To(Equal("refs/heads/issues/1"))
+					Expect(note.GetPushedReferences()[0].OldHash).To(Equal(plumbing2.ZeroHash.String()))
+					Expect(note.GetPushedReferences()[0].NewHash).To(Equal("hash2"))
+					return 1024, nil
+				}
+				res := m.EstimatePushSize("repo_123")
+				Expect(res["size"]).To(Equal(uint64(1024)))
+				Expect(res["references"]).To(Equal([]string{"refs/heads/issues/1"}))
+			})
+		})
+	})
+
+	Describe(".ComputePushID", func() {
+		var mockTempRepoMgr *mocks.MockTempRepoManager
+
+		BeforeEach(func() {
+			mockTempRepoMgr = mocks.NewMockTempRepoManager(ctrl)
+			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr).AnyTimes()
+		})
+
+		It("should panic if id is not associated with a temporary repo", func() {
+			mockTempRepoMgr.EXPECT().GetPath("repo_123").Return("")
+			err := &errors.ReqError{Code: "invalid_temp_repo_id", HttpCode: 404, Msg: "id is expired or invalid", Field: "id"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ComputePushID("repo_123")
+			})
+		})
+
+		It("should panic if repo was not found", func() {
+			mockTempRepoMgr.EXPECT().GetPath("repo_123").Return("/path/repo")
+			m.GetLocalRepo = func(_, path string) (plumbing.LocalRepo, error) {
+				return nil, git.ErrRepositoryNotExists
 			}
-			mockCloneRepo.EXPECT().RefGet(ref).Return("", fmt.Errorf("error here"))
-			mockCloneRepo.EXPECT().Delete()
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+			err := &errors.ReqError{Code: "repo_not_found", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.CloseMergeRequest("repo3", ref)
+				m.ComputePushID("repo_123")
 			})
 		})
 
-		It("should add repo to temporary repo manager; "+
-			"return ID from temporary repo manager;"+
-			"return name and hash of new merge request reference;", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			ref := plumbing.MakeMergeRequestReference("1")
-			hash := "hash_123"
+		When("temporary repos are staged", func() {
+			var cloneA, cloneB string
 
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(ref).Return("", nil)
-			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
-			m.MergeRequestClose = func(r plumbing.LocalRepo, args *mergecmd.MergeReqCloseArgs) (*mergecmd.MergeReqCloseResult, error) {
-				return &mergecmd.MergeReqCloseResult{Reference: ref}, nil
-			}
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
-				return mockRepo, nil
-			}
+			BeforeEach(func() {
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+				path := cfg.GetRepoPath("repo1")
+				testutil2.AppendCommit(path, "file.txt", "line 1", "c1")
+				testutil2.ExecGit(path, "branch", "-M", "master")
+				testutil2.ExecGit(path, "branch", "dev")
+				testutil2.AppendCommit(path, "file.txt", "line 2", "c2")
 
-			mockCloneRepo.EXPECT().RefGet(ref).Return(hash, nil)
+				cloneA = filepath.Join(cfg.GetRepoRoot(), "cloneA")
+				cloneB = filepath.Join(cfg.GetRepoRoot(), "cloneB")
+				testutil2.ExecGit(cfg.GetRepoRoot(), "clone", path, cloneA)
+				testutil2.ExecGit(cfg.GetRepoRoot(), "clone", path, cloneB)
+				mockTempRepoMgr.EXPECT().GetPath("repoA").Return(cloneA).AnyTimes()
+				mockTempRepoMgr.EXPECT().GetPath("repoB").Return(cloneB).AnyTimes()
+			})
 
-			tempRepoId := "repoId_123"
-			mockCloneRepo.EXPECT().GetPath().Return("/repo/path")
+			It("should return identical ids for identical staged content", func() {
+				testutil2.ExecGit(cloneA, "branch", "feature-1", "origin/dev")
+				testutil2.ExecGit(cloneA, "branch", "feature-2", "origin/master")
+				testutil2.ExecGit(cloneB, "branch", "feature-2", "origin/master")
+				testutil2.ExecGit(cloneB, "branch", "feature-1", "origin/dev")
+				resA := m.ComputePushID("repoA")
+				resB := m.ComputePushID("repoB")
+				Expect(resA["id"]).ToNot(BeEmpty())
+				Expect(resA["id"]).To(Equal(resB["id"]))
+				Expect(resA["references"]).To(Equal([]string{"refs/heads/feature-1", "refs/heads/feature-2"}))
+				Expect(m.ComputePushID("repoA")).To(Equal(resA))
+			})
+
+			It("should return different ids for different staged content", func() {
+				testutil2.ExecGit(cloneA, "branch", "feature-1", "origin/dev")
+				testutil2.ExecGit(cloneB, "branch", "feature-1", "origin/master")
+				resA := m.ComputePushID("repoA")
+				resB := m.ComputePushID("repoB")
+				Expect(resA["id"]).ToNot(Equal(resB["id"]))
+			})
+
+			It("should not include branches that have not changed", func() {
+				res := m.ComputePushID("repoA")
+				Expect(res["references"]).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe(".PreviewPostChange", func() {
+		It("should panic if id is not associated with a temporary repo", func() {
 			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
-			mockTempRepoMgr.EXPECT().Add("/repo/path").Return(tempRepoId)
 			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+			mockTempRepoMgr.EXPECT().GetPath("repo_123").Return("")
+			err := &errors.ReqError{Code: "invalid_temp_repo_id", HttpCode: 404, Msg: "id is expired or invalid", Field: "id"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.PreviewPostChange("repo_123", "refs/heads/issues/1")
+			})
+		})
 
-			assert.NotPanics(GinkgoT(), func() {
-				res := m.CloseMergeRequest("repo3", ref)
-				Expect(res["reference"]).To(Equal(ref))
-				Expect(res["hash"]).To(Equal(hash))
-				Expect(res["repoID"]).To(Equal(tempRepoId))
+		When("temp repo exists", func() {
+			var tempRepoMgr *temprepomgr.BasicTempRepoManager
+
+			BeforeEach(func() {
+				tempRepoMgr = temprepomgr.New()
+				mockRepoSrv.EXPECT().GetTempRepoManager().Return(tempRepoMgr).AnyTimes()
+				testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+			})
+
+			It("should panic if reference is not a branch", func() {
+				id := tempRepoMgr.Add(cfg.GetRepoPath("repo1"))
+				err := &errors.ReqError{Code: "invalid_reference_name", HttpCode: 400, Msg: "reference name is not valid", Field: "reference"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.PreviewPostChange(id, "refs/tags/v1")
+				})
+			})
+
+			It("should panic if reference does not exist", func() {
+				id := tempRepoMgr.Add(cfg.GetRepoPath("repo1"))
+				err := &errors.ReqError{Code: "reference_not_found", HttpCode: 404, Msg: "reference not found", Field: "reference"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.PreviewPostChange(id, "refs/heads/issues/1")
+				})
+			})
+
+			It("should return the patch of a new post against an empty tree", func() {
+				path := cfg.GetRepoPath("repo1")
+				testutil2.CreateCheckoutBranch(path, "issues/1")
+				testutil2.AppendCommit(path, "body", "issue body", "new issue")
+				id := tempRepoMgr.Add(path)
+
+				patch := m.PreviewPostChange(id, "refs/heads/issues/1")
+				Expect(patch).To(ContainSubstring("diff --git a/body b/body"))
+				Expect(patch).To(ContainSubstring("new file mode"))
+				Expect(patch).To(ContainSubstring("+issue body"))
+			})
+
+			It("should return the patch of a staged change against the remote-tracking reference", func() {
+				path := cfg.GetRepoPath("repo1")
+				testutil2.CreateCheckoutBranch(path, "issues/1")
+				testutil2.AppendCommit(path, "body", "issue body\n", "new issue")
+				testutil2.ExecGit(cfg.GetRepoRoot(), "clone", path, "staged")
+				staged := filepath.Join(cfg.GetRepoRoot(), "staged")
+				testutil2.AppendCommit(staged, "body", "a comment\n", "comment")
+				id := tempRepoMgr.Add(staged)
+
+				expected := strings.TrimSpace(string(testutil2.ExecGit(staged, "diff", "origin/issues/1..issues/1")))
+				patch := m.PreviewPostChange(id, "refs/heads/issues/1")
+				Expect(patch).To(Equal(expected))
+				Expect(patch).To(ContainSubstring("+a comment"))
+				Expect(patch).ToNot(ContainSubstring("+issue body"))
+			})
+
+			It("should return empty string if the post has no staged change", func() {
+				path := cfg.GetRepoPath("repo1")
+				testutil2.CreateCheckoutBranch(path, "issues/1")
+				testutil2.AppendCommit(path, "body", "issue body", "new issue")
+				testutil2.ExecGit(cfg.GetRepoRoot(), "clone", path, "staged")
+				id := tempRepoMgr.Add(filepath.Join(cfg.GetRepoRoot(), "staged"))
+				Expect(m.PreviewPostChange(id, "refs/heads/issues/1")).To(BeEmpty())
 			})
 		})
 	})
 
-	Describe(".ReopenMergeRequest()", func() {
-		It("should panic when repo name was not provided", func() {
-			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+	Describe(".SnapshotTempRepo", func() {
+		It("should panic if id is not associated with a temporary repo", func() {
+			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
+			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+			mockTempRepoMgr.EXPECT().GetPath("repo_123").Return("")
+			err := &errors.ReqError{Code: "invalid_temp_repo_id", HttpCode: 404, Msg: "id is expired or invalid", Field: "id"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReopenMergeRequest("", plumbing.MakeMergeRequestReference(1))
+				m.SnapshotTempRepo("repo_123")
 			})
 		})
+	})
 
-		It("should panic when repo was not found", func() {
-			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+	Describe(".RestoreTempRepo", func() {
+		It("should panic if snapshot was not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "snapshot is required", Field: "data"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReopenMergeRequest("unknown", plumbing.MakeMergeRequestReference(1))
+				m.RestoreTempRepo("")
 			})
 		})
 
-		It("should panic when issue reference was not found", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			err := &errors.ReqError{Code: "merge_request_not_found", HttpCode: 404, Msg: "merge request not found", Field: "reference"}
+		It("should panic if snapshot is not valid", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "invalid snapshot", Field: "data"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReopenMergeRequest("repo3", plumbing.MakeMergeRequestReference(1))
+				m.RestoreTempRepo(base64.StdEncoding.EncodeToString([]byte("invalid")))
 			})
 		})
 
-		It("should panic when unable to clone repository", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(plumbing.MakeMergeRequestReference("1")).Return("", nil)
-			mockRepo.EXPECT().Clone(plumbing.CloneOptions{
-				Bare:          false,
-				ReferenceName: "",
-				Depth:         1,
-			}).Return(nil, "", fmt.Errorf("error here"))
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
-				return mockRepo, nil
-			}
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "failed to clone repo: error here", Field: ""}
+		It("should restore a snapshot of a staged repo under a new id", func() {
+			tempRepoMgr := temprepomgr.New()
+			mockRepoSrv.EXPECT().GetTempRepoManager().Return(tempRepoMgr).AnyTimes()
+
+			path := cfg.GetRepoPath("repo1")
+			testutil2.ExecGit(cfg.GetRepoRoot(), "init", "repo1")
+			testutil2.AppendCommit(path, "file.txt", "line 1", "commit 1")
+			testutil2.CreateCheckoutBranch(path, "issues/1")
+			testutil2.AppendCommit(path, "body", "issue body", "staged issue")
+			stagedHash := testutil2.GetRecentCommitHash(path, "refs/heads/issues/1")
+			id := tempRepoMgr.Add(path)
+
+			snapshot := m.SnapshotTempRepo(id)
+			Expect(tempRepoMgr.Remove(id)).To(BeNil())
+			Expect(tempRepoMgr.GetPath(id)).To(BeEmpty())
+
+			newID := m.RestoreTempRepo(snapshot)
+			Expect(newID).ToNot(Equal(id))
+			restored := tempRepoMgr.GetPath(newID)
+			defer os.RemoveAll(filepath.Dir(restored))
+			Expect(filepath.Base(restored)).To(Equal("repo1"))
+			Expect(testutil2.GetRecentCommitHash(restored, "refs/heads/issues/1")).To(Equal(stagedHash))
+			bz, err := ioutil.ReadFile(filepath.Join(restored, "body"))
+			Expect(err).To(BeNil())
+			Expect(string(bz)).To(Equal("issue body"))
+		})
+	})
+
+	Describe(".GetPushedRefs", func() {
+		var note *pushtypes.Note
+		var hash util.Bytes32
+
+		BeforeEach(func() {
+			note = &pushtypes.Note{RepoName: "repo1", References: []*pushtypes.PushedReference{
+				{Name: "refs/heads/master", OldHash: "hash1", NewHash: "hash2"},
+				{Name: "refs/tags/v1", OldHash: plumbing2.ZeroHash.String(), NewHash: "hash3"},
+			}}
+			hash = note.ID()
+		})
+
+		It("should panic if hash is not valid", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "invalid transaction hash", Field: "hash"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReopenMergeRequest("repo3", plumbing.MakeMergeRequestReference(1))
+				m.GetPushedRefs("xyz")
 			})
 		})
 
-		It("should panic when unable to reopen merge request", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(plumbing.MakeMergeRequestReference("1")).Return("", nil)
-			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
-			mockCloneRepo.EXPECT().Delete()
-			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
-			m.MergeRequestReopen = func(r plumbing.LocalRepo, args *mergecmd.MergeReqReopenArgs) (*mergecmd.MergeReqReopenResult, error) {
-				return nil, fmt.Errorf("error here; cant reopen")
-			}
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
-				return mockRepo, nil
-			}
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here; cant reopen", Field: ""}
+		It("should panic if unable to get transaction", func() {
+			mockService.EXPECT().GetTx(gomock.Any(), hash.Bytes(), cfg.IsLightNode()).Return(nil, nil, fmt.Errorf("error"))
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error", Field: ""}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReopenMergeRequest("repo3", plumbing.MakeMergeRequestReference(1))
+				m.GetPushedRefs(hash.HexStr())
 			})
 		})
 
-		It("should panic when unable to get hash of updated reference", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			ref := plumbing.MakeMergeRequestReference("1")
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(ref).Return("", nil)
-			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
-			m.MergeRequestReopen = func(r plumbing.LocalRepo, args *mergecmd.MergeReqReopenArgs) (*mergecmd.MergeReqReopenResult, error) {
-				return &mergecmd.MergeReqReopenResult{Reference: ref}, nil
-			}
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
-				return mockRepo, nil
-			}
-			mockCloneRepo.EXPECT().RefGet(ref).Return("", fmt.Errorf("error here"))
-			mockCloneRepo.EXPECT().Delete()
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+		It("should panic if transaction is not a push transaction", func() {
+			mockService.EXPECT().GetTx(gomock.Any(), hash.Bytes(), cfg.IsLightNode()).Return(txns.NewBareTxCoinTransfer(), nil, nil)
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "transaction is not a push transaction", Field: "hash"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReopenMergeRequest("repo3", plumbing.MakeMergeRequestReference(1))
+				m.GetPushedRefs(hash.HexStr())
 			})
 		})
 
-		It("should add repo to temporary repo manager; "+
-			"return ID from temporary repo manager;"+
-			"return name and hash of new merge request reference;", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			ref := plumbing.MakeMergeRequestReference("1")
-			hash := "hash_123"
+		It("should panic if transaction is unknown", func() {
+			mockService.EXPECT().GetTx(gomock.Any(), hash.Bytes(), cfg.IsLightNode()).Return(nil, nil, types2.ErrTxNotFound)
+			mockPushPool := mocks.NewMockPushPool(ctrl)
+			mockRepoSrv.EXPECT().GetPushPool().Return(mockPushPool)
+			mockPushPool.EXPECT().Get(hash.HexStr()).Return(nil)
+			err := &errors.ReqError{Code: "tx_not_found", HttpCode: 404, Msg: "transaction not found", Field: "hash"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetPushedRefs(hash.HexStr())
+			})
+		})
 
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().RefGet(ref).Return("", nil)
-			var mockCloneRepo = mocks.NewMockLocalRepo(ctrl)
-			mockRepo.EXPECT().Clone(gomock.Any()).Return(mockCloneRepo, "", nil)
-			m.MergeRequestReopen = func(r plumbing.LocalRepo, args *mergecmd.MergeReqReopenArgs) (*mergecmd.MergeReqReopenResult, error) {
-				return &mergecmd.MergeReqReopenResult{Reference: ref}, nil
-			}
-			m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) {
-				return mockRepo, nil
-			}
+		It("should return references of a push transaction in a block", func() {
+			tx := txns.NewBareTxPush()
+			tx.Note = note
+			mockService.EXPECT().GetTx(gomock.Any(), hash.Bytes(), cfg.IsLightNode()).Return(tx, nil, nil)
+			res := m.GetPushedRefs(hash.HexStr())
+			Expect(res).To(Equal([]util.Map{
+				{"name": "refs/heads/master", "oldHash": "hash1", "newHash": "hash2"},
+				{"name": "refs/tags/v1", "oldHash": plumbing2.ZeroHash.String(), "newHash": "hash3"},
+			}))
+		})
 
-			mockCloneRepo.EXPECT().RefGet(ref).Return(hash, nil)
+		It("should return references of a push note in the push pool", func() {
+			mockService.EXPECT().GetTx(gomock.Any(), hash.Bytes(), cfg.IsLightNode()).Return(nil, nil, types2.ErrTxNotFound)
+			mockPushPool := mocks.NewMockPushPool(ctrl)
+			mockRepoSrv.EXPECT().GetPushPool().Return(mockPushPool)
+			mockPushPool.EXPECT().Get(hash.HexStr()).Return(note)
+			res := m.GetPushedRefs(hash.HexStr())
+			Expect(res).To(HaveLen(2))
+			Expect(res[0]["name"]).To(Equal("refs/heads/master"))
+		})
+	})
 
-			tempRepoId := "repoId_123"
-			mockCloneRepo.EXPECT().GetPath().Return("/repo/path")
-			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
-			mockTempRepoMgr.EXPECT().Add("/repo/path").Return(tempRepoId)
-			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+	Describe(".GetPushEndorsements", func() {
+		var hash = util.StrToBytes32("hash")
+		var key1 = ed25519.NewKeyFromIntSeed(1)
+		var key2 = ed25519.NewKeyFromIntSeed(2)
 
-			assert.NotPanics(GinkgoT(), func() {
-				res := m.ReopenMergeRequest("repo3", plumbing.MakeMergeRequestReference(1))
-				Expect(res["reference"]).To(Equal(ref))
-				Expect(res["hash"]).To(Equal(hash))
-				Expect(res["repoID"]).To(Equal(tempRepoId))
+		It("should panic if hash is not valid", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "invalid transaction hash", Field: "hash"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetPushEndorsements("xyz")
 			})
 		})
-	})
 
-	Describe(".ListMergeRequests", func() {
-		It("should panic when repo name was not provided", func() {
-			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+		It("should panic if transaction is unknown", func() {
+			mockService.EXPECT().GetTx(gomock.Any(), hash.Bytes(), cfg.IsLightNode()).Return(nil, nil, types2.ErrTxNotFound)
+			err := &errors.ReqError{Code: "tx_not_found", HttpCode: 404, Msg: "transaction not found", Field: "hash"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ListMergeRequests("")
+				m.GetPushEndorsements(hash.HexStr())
 			})
 		})
 
-		It("should panic when repo was not found", func() {
-			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+		It("should panic if unable to get transaction", func() {
+			mockService.EXPECT().GetTx(gomock.Any(), hash.Bytes(), cfg.IsLightNode()).Return(nil, nil, fmt.Errorf("error"))
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error", Field: ""}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ListMergeRequests("unknown")
+				m.GetPushEndorsements(hash.HexStr())
 			})
 		})
 
-		It("should panic when unable to list issues", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			m.MergeRequestList = func(_ plumbing.LocalRepo, _ *mergecmd.MergeRequestListArgs) (plumbing.Posts, error) {
-				return nil, fmt.Errorf("error here")
-			}
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+		It("should panic if transaction is not a push transaction", func() {
+			mockService.EXPECT().GetTx(gomock.Any(), hash.Bytes(), cfg.IsLightNode()).Return(txns.NewBareTxCoinTransfer(), nil, nil)
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "transaction is not a push transaction", Field: "hash"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ListMergeRequests("repo3")
-			})
+				m.GetPushEndorsements(hash.HexStr())
+			})
+		})
+
+		It("should return the endorsements of the push transaction", func() {
+			refHash := util.MustFromHex("2f3ddf2e6d3a0e1a1b5f4c9e4f1d2c3b4a5f6e7d")
+			tx := txns.NewBareTxPush()
+			tx.Endorsements = append(tx.Endorsements,
+				&pushtypes.PushEndorsement{
+					EndorserPubKey: key1.PubKey().MustBytes32(),
+					References:     pushtypes.EndorsedReferences{{Hash: refHash}},
+				},
+				&pushtypes.PushEndorsement{
+					EndorserPubKey: key2.PubKey().MustBytes32(),
+					SigBLS:         []byte{1, 2},
+				},
+			)
+			mockService.EXPECT().GetTx(gomock.Any(), hash.Bytes(), cfg.IsLightNode()).Return(tx, nil, nil)
+			res := m.GetPushEndorsements(hash.HexStr())
+			Expect(res).To(Equal([]util.Map{
+				{"pubKey": key1.PubKey().Base58(), "refs": []string{"2f3ddf2e6d3a0e1a1b5f4c9e4f1d2c3b4a5f6e7d"}, "sig": ""},
+				{"pubKey": key2.PubKey().Base58(), "refs": []string{"2f3ddf2e6d3a0e1a1b5f4c9e4f1d2c3b4a5f6e7d"}, "sig": "0x0102"},
+			}))
 		})
+	})
 
-		It("should not panic on success", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			m.MergeRequestList = func(_ plumbing.LocalRepo, _ *mergecmd.MergeRequestListArgs) (plumbing.Posts, error) {
-				return []plumbing.PostEntry{
-					&plumbing.Post{Title: "title"},
-				}, nil
+	Describe(".GetPendingPushes", func() {
+		var mockPushPool *mocks.MockPushPool
+
+		BeforeEach(func() {
+			mockPushPool = mocks.NewMockPushPool(ctrl)
+			mockRepoSrv.EXPECT().GetPushPool().Return(mockPushPool)
+		})
+
+		It("should return empty result if no push note is pending", func() {
+			mockPushPool.EXPECT().GetUnendorsed().Return(nil)
+			Expect(m.GetPendingPushes()).To(BeEmpty())
+		})
+
+		It("should return pending push notes with their endorsement count", func() {
+			note := &pushtypes.Note{
+				RepoName:      "repo1",
+				PusherAddress: "addr1",
+				References:    pushtypes.PushedReferences{{Name: "refs/heads/master"}},
 			}
-			assert.NotPanics(GinkgoT(), func() {
-				res := m.ListMergeRequests("repo3")
-				Expect(res).To(HaveLen(1))
-				Expect(res[0]).To(HaveKey("title"))
-				Expect(res[0]["title"]).To(Equal("title"))
-			})
+			mockPushPool.EXPECT().GetUnendorsed().Return([]*pushtypes.PendingNote{
+				{Note: note, TimeAdded: time.Now().Add(-time.Minute)},
+			})
+			mockRepoSrv.EXPECT().GetNoteEndorsements(note.ID().String()).Return([]*pushtypes.PushEndorsement{{}})
+			res := m.GetPendingPushes()
+			Expect(res).To(HaveLen(1))
+			Expect(res[0]["id"]).To(Equal(note.ID().String()))
+			Expect(res[0]["repo"]).To(Equal("repo1"))
+			Expect(res[0]["pusher"]).To(Equal("addr1"))
+			Expect(res[0]["refs"]).To(Equal([]string{"refs/heads/master"}))
+			Expect(res[0]["age"]).To(BeNumerically(">=", 60))
+			Expect(res[0]["endorsements"]).To(Equal(1))
+			Expect(res[0]["quorum"]).To(Equal(params.PushEndorseQuorumSize))
 		})
 	})
 
-	Describe(".ReadMergeRequest()", func() {
-		It("should panic when repo name was not provided", func() {
-			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+	Describe(".ResignRefs", func() {
+		var mockTempRepoMgr *mocks.MockTempRepoManager
+		var mockRepo *mocks.MockLocalRepo
+		var key = ed25519.NewKeyFromIntSeed(1)
+		var params map[string]interface{}
+
+		BeforeEach(func() {
+			mockTempRepoMgr = mocks.NewMockTempRepoManager(ctrl)
+			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
+			mockRepo = mocks.NewMockLocalRepo(ctrl)
+			params = map[string]interface{}{"id": "repo_123", "fee": "1"}
+		})
+
+		It("should panic if id is not associated with a temporary repo", func() {
+			mockTempRepoMgr.EXPECT().GetPath("repo_123").Return("")
+			err := &errors.ReqError{Code: "invalid_temp_repo_id", HttpCode: 404, Msg: "id is expired or invalid", Field: "id"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReadMergeRequest("", plumbing.MakeMergeRequestReference(1))
+				m.ResignRefs(params, key.PrivKey().Base58())
 			})
 		})
 
-		It("should panic when repo was not found", func() {
-			err := &errors.ReqError{Code: "invalid_param", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+		It("should panic if private key is not valid", func() {
+			mockTempRepoMgr.EXPECT().GetPath("repo_123").Return("/path/repo")
+			err := &errors.ReqError{Code: "invalid_private_key", HttpCode: 400, Msg: "private key is not valid", Field: "privateKey"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReadMergeRequest("unknown", plumbing.MakeMergeRequestReference(1))
+				m.ResignRefs(params, "invalid_pk")
 			})
 		})
 
-		It("should panic when unable to get the merge request", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			m.MergeRequestRead = func(_ plumbing.LocalRepo, _ *mergecmd.MergeRequestReadArgs) (plumbing.Comments, error) {
-				return nil, fmt.Errorf("error here")
+		It("should panic if repo was not found", func() {
+			mockTempRepoMgr.EXPECT().GetPath("repo_123").Return("/path/repo")
+			m.GetLocalRepo = func(_, path string) (plumbing.LocalRepo, error) {
+				Expect(path).To(Equal("/path/repo"))
+				return nil, git.ErrRepositoryNotExists
 			}
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+			err := &errors.ReqError{Code: "repo_not_found", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.ReadMergeRequest("repo3", plumbing.MakeMergeRequestReference(1))
+				m.ResignRefs(params, key.PrivKey().Base58())
+			})
+		})
+
+		When("repo exists", func() {
+			var masterHash = "5f7dd3b4ca4e23ae3ff1a5b9e3ef3ac39e8a2f0e"
+			var annotatedTagHash = "0c8a7e1bf8bf0a4b9b92ff8b6bd39a42e5ab1b37"
+			var lightTagHash = "d9b6f9ef52d76a6b3fd0b0e1c7d7c3a5c9b7c6e1"
+			var devHash = "a1ab3f5a52f2ad0a1f44bc44ba3d1bb55d13e8a9"
+
+			BeforeEach(func() {
+				mockTempRepoMgr.EXPECT().GetPath("repo_123").Return("/path/repo")
+				m.GetLocalRepo = func(_, _ string) (plumbing.LocalRepo, error) { return mockRepo, nil }
+				mockRepo.EXPECT().GetName().Return("repo1").AnyTimes()
+				mockRepo.EXPECT().GetReferences().Return([]plumbing2.ReferenceName{
+					"HEAD",
+					"refs/heads/master",
+					"refs/heads/dev",
+					"refs/notes/note1",
+					"refs/tags/v1",
+					"refs/tags/v2",
+				}, nil)
+				mockRepo.EXPECT().RefGet("refs/heads/master").Return(masterHash, nil)
+				mockRepo.EXPECT().CommitObject(plumbing2.NewHash(masterHash)).Return(&object.Commit{Hash: plumbing2.NewHash(masterHash)}, nil)
+				mockRepo.EXPECT().RefGet("refs/heads/dev").Return(devHash, nil)
+				mockRepo.EXPECT().CommitObject(plumbing2.NewHash(devHash)).Return(nil, plumbing2.ErrObjectNotFound)
+				mockRepo.EXPECT().RefGet("refs/tags/v1").Return(annotatedTagHash, nil)
+				mockRepo.EXPECT().TagObject(plumbing2.NewHash(annotatedTagHash)).Return(&object.Tag{Hash: plumbing2.NewHash(annotatedTagHash)}, nil)
+				mockRepo.EXPECT().RefGet("refs/tags/v2").Return(lightTagHash, nil)
+				mockRepo.EXPECT().TagObject(plumbing2.NewHash(lightTagHash)).Return(nil, plumbing2.ErrObjectNotFound)
+				mockRepo.EXPECT().CommitObject(plumbing2.NewHash(lightTagHash)).Return(&object.Commit{Hash: plumbing2.NewHash(lightTagHash)}, nil)
+			})
+
+			It("should return signed tokens for branches and tags and report failures", func() {
+				params["nonce"] = "10"
+				res := m.ResignRefs(params, key.PrivKey().Base58())
+				Expect(res).To(HaveLen(4))
+
+				Expect(res[0]["reference"]).To(Equal("refs/heads/master"))
+				Expect(res[0]["success"]).To(BeTrue())
+				Expect(res[0]["nonce"]).To(Equal(uint64(10)))
+
+				Expect(res[1]["reference"]).To(Equal("refs/heads/dev"))
+				Expect(res[1]["success"]).To(BeFalse())
+				Expect(res[1]["error"]).To(Equal(plumbing2.ErrObjectNotFound.Error()))
+				Expect(res[1]).ToNot(HaveKey("token"))
+
+				Expect(res[2]["reference"]).To(Equal("refs/tags/v1"))
+				Expect(res[2]["success"]).To(BeTrue())
+				Expect(res[2]["nonce"]).To(Equal(uint64(11)))
+
+				Expect(res[3]["reference"]).To(Equal("refs/tags/v2"))
+				Expect(res[3]["success"]).To(BeTrue())
+				Expect(res[3]["nonce"]).To(Equal(uint64(12)))
+			})
+
+			It("should return tokens signed by the new key that pass commit and tag checks", func() {
+				params["nonce"] = "10"
+				res := m.ResignRefs(params, key.PrivKey().Base58())
+
+				for _, i := range []int{0, 2, 3} {
+					txDetail, err := pushtoken.Decode(res[i]["token"].(string))
+					Expect(err).To(BeNil())
+					Expect(txDetail.PushKeyID).To(Equal(key.PushAddr().String()))
+					Expect(txDetail.RepoName).To(Equal("repo1"))
+					Expect(txDetail.Reference).To(Equal(res[i]["reference"]))
+					Expect(txDetail.Head).To(Equal(res[i]["hash"]))
+
+					sig, err := base58.Decode(txDetail.Signature)
+					Expect(err).To(BeNil())
+					ok, err := key.PubKey().Verify(txDetail.BytesNoSig(), sig)
+					Expect(err).To(BeNil())
+					Expect(ok).To(BeTrue())
+
+					if i == 2 {
+						tag := &object.Tag{Hash: plumbing2.NewHash(txDetail.Head)}
+						Expect(validation.CheckAnnotatedTag(tag, txDetail, nil)).To(BeNil())
+						continue
+					}
+					commit := &object.Commit{Hash: plumbing2.NewHash(txDetail.Head)}
+					Expect(validation.CheckCommit(commit, txDetail, nil)).To(BeNil())
+				}
+			})
+
+			It("should use the account's next nonce when nonce is not provided", func() {
+				acct := state.NewBareAccount()
+				acct.Nonce = 4
+				mockAccountKeeper.EXPECT().Get(key.Addr()).Return(acct)
+				res := m.ResignRefs(params, key.PrivKey().Base58())
+				Expect(res[0]["nonce"]).To(Equal(uint64(5)))
+				Expect(res[2]["nonce"]).To(Equal(uint64(6)))
 			})
 		})
+	})
 
-		It("should not panic on success", func() {
-			cfg.SetRepoRoot("../remote/repo/testdata")
-			m.MergeRequestRead = func(_ plumbing.LocalRepo, _ *mergecmd.MergeRequestReadArgs) (plumbing.Comments, error) {
-				return []*plumbing.Comment{
-					{Author: "a"},
-				}, nil
-			}
-			assert.NotPanics(GinkgoT(), func() {
-				res := m.ReadMergeRequest("repo3", plumbing.MakeMergeRequestReference(1))
-				Expect(res).To(HaveLen(1))
-				Expect(res[0]).To(HaveKey("author"))
-				Expect(res[0]["author"]).To(Equal("a"))
+	Describe(".SyncFromUpstream", func() {
+		var mockRepo *mocks.MockLocalRepo
+		var fork, upstream *state.Repository
+
+		BeforeEach(func() {
+			mockRepo = mocks.NewMockLocalRepo(ctrl)
+			fork = state.BareRepository()
+			fork.Balance = "10"
+			fork.Config.Upstream = "upstream1"
+			upstream = state.BareRepository()
+			upstream.Balance = "10"
+		})
+
+		It("should panic if name is not provided", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repo name is required", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.SyncFromUpstream("")
 			})
 		})
-	})
 
-	Describe(".Push", func() {
-		It("should panic if id is not associated with a temporary repo", func() {
-			param := map[string]interface{}{"id": "repo_123"}
-			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
-			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
-			mockTempRepoMgr.EXPECT().GetPath(param["id"]).Return("")
-			err := &errors.ReqError{Code: "invalid_temp_repo_id", HttpCode: 404, Msg: "id is expired or invalid", Field: "id"}
+		It("should panic if repo does not exist", func() {
+			mockRepoKeeper.EXPECT().Get("fork1").Return(state.BareRepository())
+			err := &errors.ReqError{Code: "repo_not_found", HttpCode: 404, Msg: "repo not found", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.Push(param, "privKey")
+				m.SyncFromUpstream("fork1")
 			})
 		})
 
-		It("should panic if reference is not a branch, note or tag reference", func() {
-			param := map[string]interface{}{"id": "repo_123", "reference": "some/type/of/reference"}
-			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
-			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
-			mockTempRepoMgr.EXPECT().GetPath(param["id"]).Return("/path/repo")
-			err := &errors.ReqError{Code: "invalid_reference_name", HttpCode: 400, Msg: "reference name is not valid", Field: "reference"}
+		It("should panic if repo is not a fork", func() {
+			fork.Config.Upstream = ""
+			mockRepoKeeper.EXPECT().Get("fork1").Return(fork)
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "repository is not a fork", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.Push(param, "privKey")
+				m.SyncFromUpstream("fork1")
 			})
 		})
 
-		It("should panic if private key was not set or is invalid", func() {
-			param := map[string]interface{}{"id": "repo_123", "reference": "refs/heads/master"}
-			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
-			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr).Times(2)
-			mockTempRepoMgr.EXPECT().GetPath(param["id"]).Return("/path/repo").Times(2)
-			err := &errors.ReqError{Code: "invalid_private_key", HttpCode: 400, Msg: "private key is required", Field: "privateKeyOrPushToken"}
+		It("should panic if upstream repo does not exist", func() {
+			mockRepoKeeper.EXPECT().Get("fork1").Return(fork)
+			mockRepoKeeper.EXPECT().Get("upstream1").Return(state.BareRepository())
+			err := &errors.ReqError{Code: "repo_not_found", HttpCode: 404, Msg: "upstream repository not found", Field: "name"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.Push(param, "")
+				m.SyncFromUpstream("fork1")
 			})
+		})
 
-			err = &errors.ReqError{Code: "invalid_private_key", HttpCode: 400, Msg: "private key or push token is not a valid", Field: "privateKeyOrPushToken"}
-			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.Push(param, "invalid_pk")
+		When("fork and upstream exist", func() {
+			var mockDHT *mocks.MockDHT
+			var mockStreamer *mocks.MockStreamer
+			var masterHash = "5f7dd3b4ca4e23ae3ff1a5b9e3ef3ac39e8a2f0e"
+			var devHash = "a1ab3f5a52f2ad0a1f44bc44ba3d1bb55d13e8a9"
+			var tagHash = "0c8a7e1bf8bf0a4b9b92ff8b6bd39a42e5ab1b37"
+
+			BeforeEach(func() {
+				upstream.References = map[string]*state.Reference{
+					"refs/heads/master": {Hash: plumbing.HashToBytes(masterHash)},
+					"refs/heads/dev":    {Hash: plumbing.HashToBytes(devHash)},
+					"refs/tags/v1":      {Hash: plumbing.HashToBytes(tagHash)},
+					"refs/notes/note1":  {Hash: plumbing.HashToBytes(tagHash)},
+				}
+				mockRepoKeeper.EXPECT().Get("fork1").Return(fork)
+				mockRepoKeeper.EXPECT().Get("upstream1").Return(upstream)
+				m.GetLocalRepo = func(_, path string) (plumbing.LocalRepo, error) {
+					Expect(path).To(Equal(cfg.GetRepoPath("fork1")))
+					return mockRepo, nil
+				}
+				mockDHT = mocks.NewMockDHT(ctrl)
+				mockStreamer = mocks.NewMockStreamer(ctrl)
+				mockRepoSrv.EXPECT().GetDHT().Return(mockDHT).AnyTimes()
+				mockDHT.EXPECT().ObjectStreamer().Return(mockStreamer).AnyTimes()
+			})
+
+			It("should fetch new references from upstream and stage them", func() {
+				mockRepo.EXPECT().RefGet("refs/upstream/heads/dev").Return(devHash, nil)
+				mockRepo.EXPECT().RefGet("refs/upstream/heads/master").Return("", plumbing.ErrRefNotFound)
+				mockRepo.EXPECT().RefGet("refs/upstream/tags/v1").Return("", plumbing.ErrRefNotFound)
+
+				unpacked := 0
+				m.PackToRepoUnpacker = func(repo plumbing.LocalRepo, pack io2.ReadSeekerCloser) error {
+					Expect(repo).To(Equal(mockRepo))
+					unpacked++
+					return nil
+				}
+
+				mockStreamer.EXPECT().GetCommitWithAncestors(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, args dht.GetAncestorArgs) ([]io2.ReadSeekerCloser, error) {
+						Expect(args.RepoName).To(Equal("upstream1"))
+						Expect(args.LocalRepoName).To(Equal("fork1"))
+						Expect(args.StartHash).To(Equal(plumbing.HashToBytes(masterHash)))
+						return nil, args.ResultCB(testutil.WrapReadSeekerCloser{Rdr: bytes.NewBuffer(nil)}, masterHash)
+					})
+				mockRepo.EXPECT().RefUpdate("refs/upstream/heads/master", masterHash).Return(nil)
+
+				mockStreamer.EXPECT().GetTaggedCommitWithAncestors(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, args dht.GetAncestorArgs) ([]io2.ReadSeekerCloser, error) {
+						Expect(args.StartHash).To(Equal(plumbing.HashToBytes(tagHash)))
+						return nil, fmt.Errorf("no provider found")
+					})
+
+				res := m.SyncFromUpstream("fork1")
+				Expect(unpacked).To(Equal(1))
+				Expect(res["upstream"]).To(Equal("upstream1"))
+				refs := res["references"].([]util.Map)
+				Expect(refs).To(HaveLen(2))
+				Expect(refs[0]).To(Equal(util.Map{
+					"reference": "refs/heads/master",
+					"staged":    "refs/upstream/heads/master",
+					"hash":      masterHash,
+				}))
+				Expect(refs[1]["reference"]).To(Equal("refs/tags/v1"))
+				Expect(refs[1]["error"]).To(Equal("no provider found"))
 			})
 		})
+	})
 
-		It("should panic if repo was not found", func() {
-			key := ed25519.NewKeyFromIntSeed(1)
-			param := map[string]interface{}{"id": "repo_123", "reference": "refs/heads/master"}
-			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
-			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr).Times(2)
-			mockTempRepoMgr.EXPECT().GetPath(param["id"]).Return("/path/repo").Times(2)
-			m.GetLocalRepo = func(_, path string) (plumbing.LocalRepo, error) {
-				Expect(path).To(Equal("/path/repo"))
-				return nil, fmt.Errorf("error here")
-			}
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: "name"}
+	Describe(".CloneRepos", func() {
+		It("should panic if no repo name is provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "at least one repo name is required", Field: "names"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.Push(param, key.PrivKey().Base58())
+				m.CloneRepos(nil)
 			})
+		})
 
-			m.GetLocalRepo = func(_, path string) (plumbing.LocalRepo, error) {
-				Expect(path).To(Equal("/path/repo"))
-				return nil, git.ErrRepositoryNotExists
-			}
-			err = &errors.ReqError{Code: "repo_not_found", HttpCode: 404, Msg: "repository does not exist", Field: "name"}
+		It("should panic if a repo name is empty", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "repo name is required", Field: "names[1]"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.Push(param, key.PrivKey().Base58())
+				m.CloneRepos([]string{"repo1", ""})
 			})
 		})
 
-		It("should panic if unable to get config", func() {
-			key := ed25519.NewKeyFromIntSeed(1)
-			param := map[string]interface{}{
-				"id":        "repo_123",
-				"reference": "refs/heads/master",
-				"nonce":     "0",
-			}
-			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
-			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
-			mockTempRepoMgr.EXPECT().GetPath(param["id"]).Return("/path/repo")
-
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			m.GetLocalRepo = func(_, path string) (plumbing.LocalRepo, error) {
-				Expect(path).To(Equal("/path/repo"))
-				return mockRepo, nil
-			}
-
-			// expected to be called if nonce is unset or zero
-			mockAccountKeeper.EXPECT().Get(key.PubKey().Addr()).Return(state.NewBareAccount())
-
-			// expect origin remote to be set with correct url
-			mockRepo.EXPECT().GetName().Return("repo1").Times(2)
-			mockRepo.EXPECT().Config().Return(nil, fmt.Errorf("error here"))
-
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
+		It("should panic if parallelism is negative", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "parallelism must not be negative", Field: "opts.parallelism"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.Push(param, key.PrivKey().Base58())
+				m.CloneRepos([]string{"repo1"}, types.CloneReposOptions{Parallelism: -1})
 			})
 		})
 
-		It("should panic if unable to set config", func() {
-			key := ed25519.NewKeyFromIntSeed(1)
-			param := map[string]interface{}{
-				"id":        "repo_123",
-				"reference": "refs/heads/master",
-				"nonce":     "0",
-			}
-			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
-			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
-			mockTempRepoMgr.EXPECT().GetPath(param["id"]).Return("/path/repo")
+		It("should report a clone that panicked as failed without affecting other clones", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").DoAndReturn(func(name string, _ ...uint64) *state.Repository {
+				panic("boom")
+			})
+			mockRepoKeeper.EXPECT().Get("unknown").Return(state.BareRepository())
+			res := m.CloneRepos([]string{"repo1", "unknown"})
+			Expect(res[0]).To(Equal(util.Map{"name": "repo1", "success": false, "error": "clone failed: boom"}))
+			Expect(res[1]).To(Equal(util.Map{"name": "unknown", "success": false, "error": "repo not found"}))
+		})
 
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			m.GetLocalRepo = func(_, path string) (plumbing.LocalRepo, error) {
-				Expect(path).To(Equal("/path/repo"))
-				return mockRepo, nil
-			}
+		When("cloning a mix of repos that can and cannot be cloned", func() {
+			var repo1, repo2 *mocks.MockLocalRepo
+			var hash1 = "5f7dd3b4ca4e23ae3ff1a5b9e3ef3ac39e8a2f0e"
+			var hash2 = "a1ab3f5a52f2ad0a1f44bc44ba3d1bb55d13e8a9"
 
-			// expected to be called if nonce is unset or zero
-			mockAccountKeeper.EXPECT().Get(key.PubKey().Addr()).Return(state.NewBareAccount())
+			BeforeEach(func() {
+				repo1 = mocks.NewMockLocalRepo(ctrl)
+				repo2 = mocks.NewMockLocalRepo(ctrl)
+				m.GetLocalRepo = func(_, path string) (plumbing.LocalRepo, error) {
+					switch path {
+					case cfg.GetRepoPath("repo1"):
+						return repo1, nil
+					case cfg.GetRepoPath("repo2"):
+						return repo2, nil
+					}
+					return nil, git.ErrRepositoryNotExists
+				}
+				m.PackToRepoUnpacker = func(repo plumbing.LocalRepo, pack io2.ReadSeekerCloser) error {
+					return nil
+				}
 
-			// expect origin remote to be set with correct url
-			mockRepo.EXPECT().GetName().Return("repo1").Times(2)
-			mockRepo.EXPECT().Config().Return(&config2.Config{Remotes: map[string]*config2.RemoteConfig{}}, nil)
-			mockRepo.EXPECT().SetConfig(gomock.Any()).Return(fmt.Errorf("error here"))
+				r1 := state.BareRepository()
+				r1.References = map[string]*state.Reference{"refs/heads/master": {Hash: plumbing.HashToBytes(hash1)}}
+				r2 := state.BareRepository()
+				r2.References = map[string]*state.Reference{"refs/heads/master": {Hash: plumbing.HashToBytes(hash2)}}
+				mockRepoKeeper.EXPECT().Get("repo1").Return(r1)
+				mockRepoKeeper.EXPECT().Get("repo2").Return(r2)
+				mockRepoKeeper.EXPECT().Get("unknown").Return(state.BareRepository())
+
+				mockDHT := mocks.NewMockDHT(ctrl)
+				mockStreamer := mocks.NewMockStreamer(ctrl)
+				mockRepoSrv.EXPECT().GetDHT().Return(mockDHT).AnyTimes()
+				mockDHT.EXPECT().ObjectStreamer().Return(mockStreamer).AnyTimes()
+				mockRepoSrv.EXPECT().HasRepository("repo1").Return(true)
+				mockRepoSrv.EXPECT().HasRepository("repo2").Return(false)
+				mockRepoSrv.EXPECT().InitRepository("repo2").Return(nil)
+
+				repo1.EXPECT().RefGet("refs/heads/master").Return("", plumbing.ErrRefNotFound)
+				repo2.EXPECT().RefGet("refs/heads/master").Return("", plumbing.ErrRefNotFound)
+				mockStreamer.EXPECT().GetCommitWithAncestors(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, args dht.GetAncestorArgs) ([]io2.ReadSeekerCloser, error) {
+						if args.RepoName == "repo2" {
+							return nil, fmt.Errorf("no provider found")
+						}
+						Expect(args.StartHash).To(Equal(plumbing.HashToBytes(hash1)))
+						return nil, args.ResultCB(testutil.WrapReadSeekerCloser{Rdr: bytes.NewBuffer(nil)}, hash1)
+					}).Times(2)
+				repo1.EXPECT().RefUpdate("refs/heads/master", hash1).Return(nil)
+				repo1.EXPECT().GetPath().Return(cfg.GetRepoPath("repo1")).AnyTimes()
+				repo2.EXPECT().GetPath().Return(cfg.GetRepoPath("repo2")).AnyTimes()
+			})
+
+			It("should emit EvtRepoUpdated for repositories whose references were fetched", func() {
+				evts := cfg.G().Bus.On(core.EvtRepoUpdated)
+				defer cfg.G().Bus.Off(core.EvtRepoUpdated, evts)
+				m.CloneRepos([]string{"repo1", "repo2", "unknown"}, types.CloneReposOptions{Parallelism: 1})
+				var updated []string
+				for i := 0; i < 2; i++ {
+					var evt emitter.Event
+					Eventually(evts).Should(Receive(&evt))
+					updated = append(updated, evt.Args[0].(string))
+				}
+				Expect(updated).To(ConsistOf("repo1", "repo2"))
+			})
 
-			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error here", Field: ""}
-			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.Push(param, key.PrivKey().Base58())
+			It("should return the result of each clone in the order of the names", func() {
+				res := m.CloneRepos([]string{"repo1", "repo2", "unknown"}, types.CloneReposOptions{Parallelism: 2})
+				Expect(res).To(HaveLen(3))
+				Expect(res[0]).To(Equal(util.Map{"name": "repo1", "success": true}))
+				Expect(res[1]["name"]).To(Equal("repo2"))
+				Expect(res[1]["success"]).To(BeFalse())
+				Expect(res[1]["error"]).To(Equal("failed to fetch refs/heads/master: no provider found"))
+				Expect(res[2]["name"]).To(Equal("unknown"))
+				Expect(res[2]["success"]).To(BeFalse())
+				Expect(res[2]["error"]).To(Equal("repo not found"))
 			})
 		})
+	})
 
-		It("should attempt to push; "+
-			"it should set correct nonce when one was not provided; "+
-			"it should add correct remote to repo;"+
-			"it should nil on successful push", func() {
-			key := ed25519.NewKeyFromIntSeed(1)
-			param := map[string]interface{}{
-				"id":        "repo_123",
-				"reference": "refs/heads/master",
-				"nonce":     "0",
-			}
-			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
-			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
-			mockTempRepoMgr.EXPECT().GetPath(param["id"]).Return("/path/repo")
+	Describe(".WithReadToken", func() {
+		var key = ed25519.NewKeyFromIntSeed(1)
+		var mockPushKeyKeeper *mocks.MockPushKeyKeeper
+		var repoState *state.Repository
 
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			m.GetLocalRepo = func(_, path string) (plumbing.LocalRepo, error) {
-				Expect(path).To(Equal("/path/repo"))
-				return mockRepo, nil
-			}
+		BeforeEach(func() {
+			mockPushKeyKeeper = mocks.NewMockPushKeyKeeper(ctrl)
+			mockLogic.EXPECT().PushKeyKeeper().Return(mockPushKeyKeeper).AnyTimes()
+			repoState = state.BareRepository()
+			repoState.Balance = "10"
+			repoState.Config.Access = &state.RepoAccess{Private: true}
+		})
 
-			// expected to be called if nonce is unset or zero
-			mockAccountKeeper.EXPECT().Get(key.PubKey().Addr()).Return(state.NewBareAccount())
+		makeToken := func() string {
+			return pushtoken.MakeFromKey(key, &remotetypes.TxDetail{RepoName: "repo1", Fee: "1", Nonce: 1, PushKeyID: key.PushAddr().String()})
+		}
 
-			// expect origin remote to be set with correct url
-			mockRepo.EXPECT().GetName().Return("repo1").Times(2)
-			mockRepo.EXPECT().Config().Return(&config2.Config{Remotes: map[string]*config2.RemoteConfig{}}, nil)
-			mockRepo.EXPECT().SetConfig(gomock.Any()).Do(func(cfg *config2.Config) {
-				Expect(cfg.Remotes).To(HaveLen(1))
-				Expect(cfg.Remotes).To(HaveKey("origin"))
-				Expect(cfg.Remotes["origin"].URLs).To(HaveLen(1))
-				Expect(cfg.Remotes["origin"].URLs[0]).To(Equal("http://127.0.0.1:9002/r/repo1"))
+		It("should not check read access when the module has no read token", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "file path is required", Field: "file"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.ReadFile("repo1", "")
 			})
+		})
 
-			mockRepo.EXPECT().Push(gomock.Any()).DoAndReturn(func(opts plumbing.PushOptions) (bytes.Buffer, error) {
-				Expect(opts.RemoteName).To(BeEmpty())
-				Expect(opts.Token).ToNot(BeEmpty())
-				Expect(opts.RefSpec).To(Equal(fmt.Sprintf("+%s:%s", param["reference"], param["reference"])))
-				return *bytes.NewBuffer([]byte("hash: tx_hash_123")), nil
+		It("should serve a public repository without a token", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(&state.Repository{Balance: "10", Config: state.DefaultRepoConfig})
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "file path is required", Field: "file"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.WithReadToken("").ReadFile("repo1", "")
 			})
+		})
 
-			// it should remove repo from temp. repo manager cache
-			mockTempRepoMgr.EXPECT().Remove(param["id"])
+		It("should panic when a private repository is read without a token", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repoState)
+			err := &errors.ReqError{Code: modules.StatusCodeReadAccessDenied, HttpCode: 403, Msg: "push token is required to read a private repository", Field: "token"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.WithReadToken("").ReadFile("repo1", "file.txt")
+			})
+		})
 
-			assert.NotPanics(GinkgoT(), func() {
-				txHash := m.Push(param, key.PrivKey().Base58())
-				Expect(txHash).To(Equal("tx_hash_123"))
+		It("should panic when the token signer is not permitted to read the private repository", func() {
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repoState)
+			mockPushKeyKeeper.EXPECT().Get(key.PushAddr().String()).Return(&state.PushKey{PubKey: key.PubKey().ToPublicKey(), Address: key.Addr()}).AnyTimes()
+			mockAccountKeeper.EXPECT().Get(key.Addr()).Return(state.NewBareAccount())
+			err := &errors.ReqError{Code: modules.StatusCodeReadAccessDenied, HttpCode: 403, Msg: "not permitted to read the repository", Field: "token"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.WithReadToken(makeToken()).ReadFile("repo1", "file.txt")
 			})
 		})
 
-		It("should panic if push failed", func() {
-			key := ed25519.NewKeyFromIntSeed(1)
-			param := map[string]interface{}{
-				"id":        "repo_123",
-				"reference": "refs/heads/master",
-				"nonce":     "0",
-			}
-			mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
-			mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
-			mockTempRepoMgr.EXPECT().GetPath(param["id"]).Return("/path/repo")
+		It("should serve a private repository to a permitted token signer", func() {
+			repoState.Config.Access.Allowed = []string{key.PushAddr().String()}
+			mockRepoKeeper.EXPECT().Get("repo1").Return(repoState)
+			mockPushKeyKeeper.EXPECT().Get(key.PushAddr().String()).Return(&state.PushKey{PubKey: key.PubKey().ToPublicKey(), Address: key.Addr()}).AnyTimes()
+			mockAccountKeeper.EXPECT().Get(key.Addr()).Return(state.NewBareAccount())
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "file path is required", Field: "file"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.WithReadToken(makeToken()).ReadFile("repo1", "")
+			})
+		})
+	})
 
-			var mockRepo = mocks.NewMockLocalRepo(ctrl)
-			m.GetLocalRepo = func(_, path string) (plumbing.LocalRepo, error) {
-				Expect(path).To(Equal("/path/repo"))
-				return mockRepo, nil
+	Describe(".DecodePushToken", func() {
+		var key = ed25519.NewKeyFromIntSeed(1)
+		var txDetail *remotetypes.TxDetail
+		var mockPushKeyKeeper *mocks.MockPushKeyKeeper
+
+		BeforeEach(func() {
+			mockPushKeyKeeper = mocks.NewMockPushKeyKeeper(ctrl)
+			mockLogic.EXPECT().PushKeyKeeper().Return(mockPushKeyKeeper).AnyTimes()
+			txDetail = &remotetypes.TxDetail{
+				RepoName:  "repo1",
+				Reference: "refs/heads/master",
+				Fee:       "1.2",
+				Nonce:     3,
+				PushKeyID: key.PushAddr().String(),
 			}
+		})
 
-			// expected to be called if nonce is unset or zero
-			mockAccountKeeper.EXPECT().Get(key.PubKey().Addr()).Return(state.NewBareAccount())
-
-			// expect origin remote to be set with correct url
-			mockRepo.EXPECT().GetName().Return("repo1").Times(2)
-			mockRepo.EXPECT().Config().Return(&config2.Config{Remotes: map[string]*config2.RemoteConfig{}}, nil)
-			mockRepo.EXPECT().SetConfig(gomock.Any())
-
-			mockRepo.EXPECT().Push(gomock.Any()).DoAndReturn(func(opts plumbing.PushOptions) (bytes.Buffer, error) {
-				return bytes.Buffer{}, fmt.Errorf("error here")
+		It("should panic if token is not provided", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "token is required", Field: "token"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.DecodePushToken("")
 			})
+		})
 
-			err := &errors.ReqError{Code: "push_failure", HttpCode: 500, Msg: "error here", Field: ""}
+		It("should panic if token is malformed", func() {
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "malformed token", Field: "token"}
 			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
-				m.Push(param, key.PrivKey().Base58())
+				m.DecodePushToken("invalid_token")
 			})
 		})
 
-		When("push token is provided as key", func() {
-			It("should use push token directly", func() {
-				key := ed25519.NewKeyFromIntSeed(1)
-				token := pushtoken.MakeFromKey(key, &remotetypes.TxDetail{
-					RepoName:  "repo1",
-					PushKeyID: key.PushAddr().String(),
-				})
-
-				param := map[string]interface{}{
-					"id":        "repo_123",
-					"reference": "refs/heads/master",
-					"nonce":     "0",
-				}
-				mockTempRepoMgr := mocks.NewMockTempRepoManager(ctrl)
-				mockRepoSrv.EXPECT().GetTempRepoManager().Return(mockTempRepoMgr)
-				mockTempRepoMgr.EXPECT().GetPath(param["id"]).Return("/path/repo")
+		It("should panic if token failed sanity check", func() {
+			txDetail.Nonce = 0
+			token := pushtoken.MakeFromKey(key, txDetail)
+			err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "nonce is required", Field: "nonce"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.DecodePushToken(token)
+			})
+		})
 
-				var mockRepo = mocks.NewMockLocalRepo(ctrl)
-				m.GetLocalRepo = func(_, path string) (plumbing.LocalRepo, error) {
-					Expect(path).To(Equal("/path/repo"))
-					return mockRepo, nil
-				}
+		When("push key public key is not provided", func() {
+			It("should return the transaction detail of a valid token", func() {
+				mockPushKeyKeeper.EXPECT().Get(key.PushAddr().String()).Return(&state.PushKey{PubKey: key.PubKey().ToPublicKey()})
+				token := pushtoken.MakeFromKey(key, txDetail)
+				res := m.DecodePushToken(token)
+				Expect(res["repo"]).To(Equal("repo1"))
+				Expect(res["reference"]).To(Equal("refs/heads/master"))
+				Expect(res["fee"]).To(Equal(util.String("1.2")))
+				Expect(res["nonce"]).To(Equal(uint64(3)))
+				Expect(res["pkID"]).To(Equal(key.PushAddr().String()))
+				Expect(res["verified"]).To(BeTrue())
+			})
 
-				// expect origin remote to be set with correct url
-				mockRepo.EXPECT().GetName().Return("repo1")
-				mockRepo.EXPECT().Config().Return(&config2.Config{Remotes: map[string]*config2.RemoteConfig{}}, nil)
-				mockRepo.EXPECT().SetConfig(gomock.Any()).Do(func(cfg *config2.Config) {
-					Expect(cfg.Remotes).To(HaveLen(1))
-					Expect(cfg.Remotes).To(HaveKey("origin"))
-					Expect(cfg.Remotes["origin"].URLs).To(HaveLen(1))
-					Expect(cfg.Remotes["origin"].URLs[0]).To(Equal("http://127.0.0.1:9002/r/repo1"))
+			It("should panic if the push key is unknown", func() {
+				mockPushKeyKeeper.EXPECT().Get(key.PushAddr().String()).Return(state.BarePushKey())
+				token := pushtoken.MakeFromKey(key, txDetail)
+				err := &errors.ReqError{Code: modules.StatusCodePushKeyNotFound, HttpCode: 404, Msg: "push key not found", Field: "pkID"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.DecodePushToken(token)
 				})
+			})
 
-				mockRepo.EXPECT().Push(gomock.Any()).DoAndReturn(func(opts plumbing.PushOptions) (bytes.Buffer, error) {
-					Expect(opts.RemoteName).To(BeEmpty())
-					Expect(opts.Token).To(Equal(token))
-					Expect(opts.RefSpec).To(Equal(fmt.Sprintf("+%s:%s", param["reference"], param["reference"])))
-					return *bytes.NewBuffer([]byte("hash: tx_hash_123")), nil
+			It("should panic if signature does not match the on-chain push key", func() {
+				key2 := ed25519.NewKeyFromIntSeed(2)
+				mockPushKeyKeeper.EXPECT().Get(key.PushAddr().String()).Return(&state.PushKey{PubKey: key2.PubKey().ToPublicKey()})
+				token := pushtoken.MakeFromKey(key, txDetail)
+				err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "signature is not valid", Field: "sig"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.DecodePushToken(token)
 				})
+			})
+		})
 
-				// it should remove repo from temp. repo manager cache
-				mockTempRepoMgr.EXPECT().Remove(param["id"])
+		When("push key public key is provided", func() {
+			It("should return the transaction detail if signature is valid", func() {
+				token := pushtoken.MakeFromKey(key, txDetail)
+				res := m.DecodePushToken(token, key.PubKey().Base58())
+				Expect(res["pkID"]).To(Equal(key.PushAddr().String()))
+				Expect(res["verified"]).To(BeTrue())
+			})
 
-				assert.NotPanics(GinkgoT(), func() {
-					txHash := m.Push(param, token)
-					Expect(txHash).To(Equal("tx_hash_123"))
+			It("should panic if public key does not match the token push key", func() {
+				token := pushtoken.MakeFromKey(key, txDetail)
+				key2 := ed25519.NewKeyFromIntSeed(2)
+				err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "public key does not match the token push key", Field: "pushKeyPubKey"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.DecodePushToken(token, key2.PubKey().Base58())
+				})
+			})
+
+			It("should panic if signature is not valid", func() {
+				key2 := ed25519.NewKeyFromIntSeed(2)
+				token := pushtoken.MakeFromKey(key2, txDetail)
+				err := &errors.ReqError{Code: modules.StatusCodeInvalidParam, HttpCode: 400, Msg: "signature is not valid", Field: "sig"}
+				assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+					m.DecodePushToken(token, key.PubKey().Base58())
 				})
 			})
 		})
@@ -2,6 +2,7 @@ package modules
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/c-bata/go-prompt"
 	"github.com/make-os/kit/crypto/ed25519"
@@ -27,6 +28,7 @@ type TicketModule struct {
 	service   services.Service
 	logic     core.Logic
 	ticketmgr tickettypes.TicketManager
+	nonces    *NonceManager
 }
 
 // NewAttachableTicketModule creates an instance of TicketModule suitable in attach mode
@@ -102,6 +104,16 @@ func (m *TicketModule) hostFuncs() []*types.VMMember {
 			Value:       m.GetTopHosts,
 			Description: "Get a list of top host tickets",
 		},
+		{
+			Name:        "set",
+			Value:       m.GetHostSet,
+			Description: "Get the public key, BLS public key and stake of top hosts",
+		},
+		{
+			Name:        "getAll",
+			Value:       m.GetHosts,
+			Description: "Get hosts filtered by stake, sorted and paginated",
+		},
 	}
 }
 
@@ -159,7 +171,7 @@ func (m *TicketModule) BuyValidatorTicket(params map[string]interface{}, options
 		panic(errors.ReqErr(400, StatusCodeInvalidParam, "params", err.Error()))
 	}
 
-	retPayload, signingKey := finalizeTx(tx, m.logic, m.Client, options...)
+	retPayload, signingKey := finalizeTx(tx, m.logic, m.Client, m.nonces, options...)
 	if retPayload {
 		return tx.ToMap()
 	}
@@ -175,6 +187,7 @@ func (m *TicketModule) BuyValidatorTicket(params map[string]interface{}, options
 		if err != nil {
 			panic(err)
 		}
+		m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
 		return util.ToMap(resp)
 	}
 
@@ -183,6 +196,7 @@ func (m *TicketModule) BuyValidatorTicket(params map[string]interface{}, options
 	if err != nil {
 		panic(errors.ReqErr(400, StatusCodeMempoolAddFail, "", err.Error()))
 	}
+	m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
 
 	return map[string]interface{}{
 		"hash": hash,
@@ -218,7 +232,7 @@ func (m *TicketModule) BuyHostTicket(params map[string]interface{}, options ...i
 		tx.BLSPubKey = blsKey.Public().Bytes()
 	}
 
-	retPayload, signingKey := finalizeTx(tx, m.logic, m.Client, options...)
+	retPayload, signingKey := finalizeTx(tx, m.logic, m.Client, m.nonces, options...)
 	if retPayload {
 		return tx.ToMap()
 	}
@@ -235,6 +249,7 @@ func (m *TicketModule) BuyHostTicket(params map[string]interface{}, options ...i
 		if err != nil {
 			panic(err)
 		}
+		m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
 		return util.ToMap(resp)
 	}
 
@@ -242,6 +257,7 @@ func (m *TicketModule) BuyHostTicket(params map[string]interface{}, options ...i
 	if err != nil {
 		panic(errors.ReqErr(400, StatusCodeMempoolAddFail, "", err.Error()))
 	}
+	m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
 
 	return map[string]interface{}{
 		"hash": hash,
@@ -390,6 +406,116 @@ func (m *TicketModule) GetTopHosts(limit ...int) []util.Map {
 	return util.StructSliceToMap(tickets)
 }
 
+// GetHostSet returns the current top host set.
+// Each entry describes the host's keys and total stake.
+//
+// [limit] <int>: Set the number of result to return (default: 0 = no limit)
+//
+// RETURNS <[]map>
+//  - pubKey <string>: The public key of the host
+//  - blsPubKey <string>: The BLS public key of the host
+//  - stake <string>: The sum of the host's ticket value and delegated ticket value
+func (m *TicketModule) GetHostSet(limit ...int) []util.Map {
+	var n int
+	if len(limit) > 0 {
+		n = limit[0]
+	}
+
+	tickets, err := m.ticketmgr.GetTopHosts(n)
+	if err != nil {
+		panic(errors.ReqErr(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	var res = []util.Map{}
+	for _, t := range tickets {
+		res = append(res, util.Map{
+			"pubKey":    ed25519.ToBase58PubKey(t.Ticket.ProposerPubKey),
+			"blsPubKey": t.Ticket.BLSPubKey.String(),
+			"stake":     t.Power.String(),
+		})
+	}
+
+	return res
+}
+
+// GetHosts returns the host set filtered by a minimum stake, sorted and paginated.
+//
+// [opts] <map>
+//  - [minStake] <string>: Exclude hosts with a lower stake
+//  - [sortBy] <string>: Sort by "stake" (highest first) or "height" (earliest first). Default: stake
+//  - [offset] <int>: The number of hosts to skip
+//  - [limit] <int>: The max number of hosts to return (default: 0 = no limit)
+//
+// RETURNS <[]map>
+//  - pubKey <string>: The public key of the host
+//  - blsPubKey <string>: The BLS public key of the host
+//  - stake <string>: The sum of the host's ticket value and delegated ticket value
+//  - height <uint64>: The block height where the host ticket was registered
+//  - hash <string>: The hash of the host ticket
+func (m *TicketModule) GetHosts(opts ...types.GetHostsOptions) []util.Map {
+	var opt types.GetHostsOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	var minStake decimal.Decimal
+	if opt.MinStake != "" {
+		var err error
+		if minStake, err = decimal.NewFromString(opt.MinStake); err != nil {
+			panic(errors.ReqErr(400, StatusCodeInvalidParam, "minStake", "must be a number"))
+		}
+	}
+
+	if opt.SortBy != "" && opt.SortBy != "stake" && opt.SortBy != "height" {
+		panic(errors.ReqErr(400, StatusCodeInvalidParam, "sortBy", "expected 'stake' or 'height'"))
+	}
+
+	if opt.Offset < 0 || opt.Limit < 0 {
+		panic(errors.ReqErr(400, StatusCodeInvalidParam, "offset", "offset and limit must not be negative"))
+	}
+
+	tickets, err := m.ticketmgr.GetTopHosts(0)
+	if err != nil {
+		panic(errors.ReqErr(500, StatusCodeServerErr, "", err.Error()))
+	}
+
+	var hosts tickettypes.SelectedTickets
+	for _, t := range tickets {
+		if t.Power.Decimal().LessThan(minStake) {
+			continue
+		}
+		hosts = append(hosts, t)
+	}
+
+	sort.SliceStable(hosts, func(i, j int) bool {
+		if opt.SortBy == "height" {
+			return hosts[i].Ticket.Height < hosts[j].Ticket.Height
+		}
+		return hosts[i].Power.Decimal().GreaterThan(hosts[j].Power.Decimal())
+	})
+
+	if opt.Offset >= len(hosts) {
+		return []util.Map{}
+	}
+	hosts = hosts[opt.Offset:]
+	if opt.Limit > 0 && opt.Limit < len(hosts) {
+		hosts = hosts[:opt.Limit]
+	}
+
+	var res = []util.Map{}
+	for _, t := range hosts {
+		res = append(res, util.Map{
+			"pubKey":    ed25519.ToBase58PubKey(t.Ticket.ProposerPubKey),
+			"blsPubKey": t.Ticket.BLSPubKey.String(),
+			"stake":     t.Power.String(),
+			"height":    t.Ticket.Height,
+			"hash":      t.Ticket.Hash.String(),
+		})
+	}
+
+	return res
+}
+
 // TicketStats returns various statistics about tickets.
 // If proposerPubKey is provided, stats will be personalized
 // to the given proposer public key.
@@ -486,7 +612,7 @@ func (m *TicketModule) UnbondHostTicket(params map[string]interface{}, options .
 		panic(errors.ReqErr(400, StatusCodeInvalidParam, "params", err.Error()))
 	}
 
-	if retPayload, _ := finalizeTx(tx, m.logic, nil, options...); retPayload {
+	if retPayload, _ := finalizeTx(tx, m.logic, nil, m.nonces, options...); retPayload {
 		return tx.ToMap()
 	}
 
@@ -494,6 +620,7 @@ func (m *TicketModule) UnbondHostTicket(params map[string]interface{}, options .
 	if err != nil {
 		panic(errors.ReqErr(400, StatusCodeMempoolAddFail, "", err.Error()))
 	}
+	m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
 
 	return map[string]interface{}{
 		"hash": hash,
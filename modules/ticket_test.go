@@ -7,6 +7,7 @@ import (
 	crypto2 "github.com/make-os/kit/crypto/ed25519"
 	"github.com/make-os/kit/mocks"
 	"github.com/make-os/kit/modules"
+	modtypes "github.com/make-os/kit/modules/types"
 	"github.com/make-os/kit/ticket/types"
 	"github.com/make-os/kit/types/constants"
 	"github.com/make-os/kit/types/state"
@@ -318,6 +319,113 @@ var _ = Describe("TicketModule", func() {
 		})
 	})
 
+	Describe(".GetHostSet()", func() {
+		It("should panic when unable to get top hosts", func() {
+			mockTicketMgr.EXPECT().GetTopHosts(0).Return(nil, fmt.Errorf("error"))
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetHostSet()
+			})
+		})
+
+		It("should return empty result when no top hosts was returned", func() {
+			mockTicketMgr.EXPECT().GetTopHosts(0).Return([]*types.SelectedTicket{}, nil)
+			res := m.GetHostSet()
+			Expect(res).To(BeEmpty())
+		})
+
+		It("should return public key, BLS public key and stake of each host", func() {
+			key := crypto2.NewKeyFromIntSeed(1)
+			tickets := []*types.SelectedTicket{
+				{Ticket: &types.Ticket{ProposerPubKey: key.PubKey().MustBytes32(), BLSPubKey: []byte{1, 2}}, Power: "100"},
+			}
+			mockTicketMgr.EXPECT().GetTopHosts(1).Return(tickets, nil)
+			res := m.GetHostSet(1)
+			Expect(res).To(HaveLen(1))
+			Expect(res[0]["pubKey"]).To(Equal(key.PubKey().Base58()))
+			Expect(res[0]["blsPubKey"]).To(Equal("0x0102"))
+			Expect(res[0]["stake"]).To(Equal("100"))
+		})
+	})
+
+	Describe(".GetHosts()", func() {
+		var key1 = crypto2.NewKeyFromIntSeed(1)
+		var key2 = crypto2.NewKeyFromIntSeed(2)
+		var key3 = crypto2.NewKeyFromIntSeed(3)
+		var tickets []*types.SelectedTicket
+
+		BeforeEach(func() {
+			tickets = []*types.SelectedTicket{
+				{Ticket: &types.Ticket{ProposerPubKey: key1.PubKey().MustBytes32(), Height: 30, Hash: []byte{1}}, Power: "500"},
+				{Ticket: &types.Ticket{ProposerPubKey: key2.PubKey().MustBytes32(), Height: 10, Hash: []byte{2}}, Power: "50"},
+				{Ticket: &types.Ticket{ProposerPubKey: key3.PubKey().MustBytes32(), Height: 20, Hash: []byte{3}}, Power: "1000"},
+			}
+		})
+
+		It("should panic when unable to get hosts", func() {
+			mockTicketMgr.EXPECT().GetTopHosts(0).Return(nil, fmt.Errorf("error"))
+			err := &errors.ReqError{Code: "server_err", HttpCode: 500, Msg: "error", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetHosts()
+			})
+		})
+
+		It("should panic when minimum stake is not a number", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "must be a number", Field: "minStake"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetHosts(modtypes.GetHostsOptions{MinStake: "abc"})
+			})
+		})
+
+		It("should panic when sort field is unknown", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "expected 'stake' or 'height'", Field: "sortBy"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetHosts(modtypes.GetHostsOptions{SortBy: "name"})
+			})
+		})
+
+		It("should return all hosts sorted by stake by default", func() {
+			mockTicketMgr.EXPECT().GetTopHosts(0).Return(tickets, nil)
+			res := m.GetHosts()
+			Expect(res).To(HaveLen(3))
+			Expect(res[0]["pubKey"]).To(Equal(key3.PubKey().Base58()))
+			Expect(res[1]["pubKey"]).To(Equal(key1.PubKey().Base58()))
+			Expect(res[2]["pubKey"]).To(Equal(key2.PubKey().Base58()))
+			Expect(res[0]["stake"]).To(Equal("1000"))
+			Expect(res[0]["height"]).To(Equal(uint64(20)))
+			Expect(res[0]["hash"]).To(Equal("0x03"))
+		})
+
+		It("should exclude hosts with a stake lower than the minimum stake", func() {
+			mockTicketMgr.EXPECT().GetTopHosts(0).Return(tickets, nil)
+			res := m.GetHosts(modtypes.GetHostsOptions{MinStake: "100"})
+			Expect(res).To(HaveLen(2))
+			Expect(res[0]["stake"]).To(Equal("1000"))
+			Expect(res[1]["stake"]).To(Equal("500"))
+		})
+
+		It("should sort hosts by registration height", func() {
+			mockTicketMgr.EXPECT().GetTopHosts(0).Return(tickets, nil)
+			res := m.GetHosts(modtypes.GetHostsOptions{SortBy: "height"})
+			Expect(res).To(HaveLen(3))
+			Expect(res[0]["height"]).To(Equal(uint64(10)))
+			Expect(res[1]["height"]).To(Equal(uint64(20)))
+			Expect(res[2]["height"]).To(Equal(uint64(30)))
+		})
+
+		It("should paginate the result", func() {
+			mockTicketMgr.EXPECT().GetTopHosts(0).Return(tickets, nil)
+			res := m.GetHosts(modtypes.GetHostsOptions{Offset: 1, Limit: 1})
+			Expect(res).To(HaveLen(1))
+			Expect(res[0]["stake"]).To(Equal("500"))
+		})
+
+		It("should return empty result when offset is beyond the host set", func() {
+			mockTicketMgr.EXPECT().GetTopHosts(0).Return(tickets, nil)
+			Expect(m.GetHosts(modtypes.GetHostsOptions{Offset: 3})).To(BeEmpty())
+		})
+	})
+
 	Describe(".GetStats", func() {
 		It("should panic when unable to get all tickets value", func() {
 			mockTicketMgr.EXPECT().ValueOfAllTickets(uint64(0)).Return(float64(0), fmt.Errorf("error"))
@@ -2,6 +2,7 @@ package modules
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 
 	modulestypes "github.com/make-os/kit/modules/types"
@@ -12,6 +13,7 @@ import (
 	"github.com/make-os/kit/types/core"
 	"github.com/make-os/kit/types/txns"
 	"github.com/make-os/kit/util/errors"
+	"github.com/make-os/kit/validation"
 
 	"github.com/c-bata/go-prompt"
 	"github.com/make-os/kit/util"
@@ -40,6 +42,7 @@ func (m *TxModule) methods() []*modulestypes.VMMember {
 	return []*modulestypes.VMMember{
 		{Name: "get", Value: m.Get, Description: "Get a transactions by its hash"},
 		{Name: "send", Value: m.SendPayload, Description: "Send a signed transaction payload to the network"},
+		{Name: "sendRaw", Value: m.SubmitRawTx, Description: "Send a base64-encoded, signed raw transaction to the network"},
 	}
 }
 
@@ -138,6 +141,51 @@ func (m *TxModule) SendPayload(params map[string]interface{}) util.Map {
 		panic(errors.ReqErr(400, StatusCodeInvalidParam, "params", err.Error()))
 	}
 
+	return m.addTx(tx)
+}
+
+// SubmitRawTx sends an already signed, serialized transaction to the network.
+// It allows transactions signed offline by an external signer to be submitted.
+//
+// ARGS:
+//  - rawTx: The base64-encoded transaction bytes
+//
+// RETURNS object <map>
+//  - object.hash <string>: 				The transaction hash
+func (m *TxModule) SubmitRawTx(rawTx string) util.Map {
+
+	bz, err := base64.StdEncoding.DecodeString(rawTx)
+	if err != nil {
+		panic(errors.ReqErr(400, StatusCodeInvalidParam, "rawTx", "invalid base64 encoding"))
+	}
+
+	tx, err := txns.DecodeTx(bz)
+	if err != nil {
+		panic(errors.ReqErr(400, StatusCodeInvalidParam, "rawTx", err.Error()))
+	}
+
+	if m.IsAttached() {
+		res, err := m.Client.Tx().Send(tx.ToMap())
+		if err != nil {
+			panic(err)
+		}
+		return util.ToMap(res)
+	}
+
+	if err := validation.ValidateTxSanity(tx, -1); err != nil {
+		se := errors.ReqErr(400, StatusCodeInvalidParam, "", err.Error())
+		if bfe := errors.BadFieldErrorFromStr(err.Error()); bfe.Msg != "" && bfe.Field != "" {
+			se.Msg = bfe.Msg
+			se.Field = bfe.Field
+		}
+		panic(se)
+	}
+
+	return m.addTx(tx)
+}
+
+// addTx adds a transaction to the mempool and returns its hash
+func (m *TxModule) addTx(tx types.BaseTx) util.Map {
 	hash, err := m.logic.GetMempoolReactor().AddTx(tx)
 	if err != nil {
 		se := errors.ReqErr(400, StatusCodeMempoolAddFail, "", err.Error())
@@ -1,6 +1,7 @@
 package modules_test
 
 import (
+	"encoding/base64"
 	"fmt"
 	"time"
 
@@ -232,4 +233,60 @@ var _ = Describe("TxModule", func() {
 			Expect(res["hash"]).To(Equal(tx.GetHash()))
 		})
 	})
+	Describe(".SubmitRawTx", func() {
+		It("should panic if raw tx is not valid base64", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "invalid base64 encoding", Field: "rawTx"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.SubmitRawTx("not_base64!")
+			})
+		})
+
+		It("should panic if raw tx could not be decoded", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "unsupported tx type", Field: "rawTx"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.SubmitRawTx(base64.StdEncoding.EncodeToString([]byte("abc")))
+			})
+		})
+
+		It("should panic if signed tx was tampered with", func() {
+			tx := txns.NewCoinTransferTx(1, pk.Addr(), pk, "1", "1", time.Now().Unix())
+			tx.(*txns.TxCoinTransfer).Value = "100"
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "signature is not valid", Field: "sig"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.SubmitRawTx(base64.StdEncoding.EncodeToString(tx.Bytes()))
+			})
+		})
+
+		It("should panic when unable to add tx to mempool", func() {
+			tx := txns.NewCoinTransferTx(1, pk.Addr(), pk, "1", "1", time.Now().Unix())
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).Return(nil, fmt.Errorf("error"))
+			err := &errors.ReqError{Code: "err_mempool", HttpCode: 400, Msg: "error", Field: ""}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.SubmitRawTx(base64.StdEncoding.EncodeToString(tx.Bytes()))
+			})
+		})
+
+		It("should return hash of a valid signed tx on success", func() {
+			tx := txns.NewCoinTransferTx(1, pk.Addr(), pk, "1", "1", time.Now().Unix())
+			mockMempoolReactor.EXPECT().AddTx(gomock.Any()).DoAndReturn(func(added types.BaseTx) (util.HexBytes, error) {
+				Expect(added.GetHash()).To(Equal(tx.GetHash()))
+				return added.GetHash(), nil
+			})
+			res := m.SubmitRawTx(base64.StdEncoding.EncodeToString(tx.Bytes()))
+			Expect(res).To(HaveKey("hash"))
+			Expect(res["hash"]).To(Equal(tx.GetHash()))
+		})
+
+		It("should send decoded tx via RPC client in attach mode", func() {
+			mockClient := mocksrpc.NewMockClient(ctrl)
+			mockTxClient := mocksrpc.NewMockTx(ctrl)
+			mockClient.EXPECT().Tx().Return(mockTxClient)
+			m.Client = mockClient
+
+			tx := txns.NewCoinTransferTx(1, pk.Addr(), pk, "1", "1", time.Now().Unix())
+			mockTxClient.EXPECT().Send(tx.ToMap()).Return(&api.ResultHash{Hash: tx.GetHash().String()}, nil)
+			res := m.SubmitRawTx(base64.StdEncoding.EncodeToString(tx.Bytes()))
+			Expect(res["hash"]).To(Equal(tx.GetHash().String()))
+		})
+	})
 })
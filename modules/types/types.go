@@ -73,6 +73,8 @@ type NodeModule interface {
 	GetValidators(height string) (res []util.Map)
 	GetCurrentEpoch() string
 	GetEpoch(height int64) string
+	GetNetworkParams() util.Map
+	GetNetworkInfo() util.Map
 	IsSyncing() bool
 }
 
@@ -80,6 +82,7 @@ type TxModule interface {
 	Module
 	Get(hash string) util.Map
 	SendPayload(params map[string]interface{}) util.Map
+	SubmitRawTx(rawTx string) util.Map
 }
 
 type PoolModule interface {
@@ -101,6 +104,9 @@ type UserModule interface {
 	GetValidator(includePrivKey ...bool) util.Map
 	SetCommission(params map[string]interface{}, options ...interface{}) util.Map
 	SendCoin(params map[string]interface{}, options ...interface{}) util.Map
+	SetAlias(name, target string, overwrite ...bool)
+	GetAlias(name string) string
+	ListAliases() util.Map
 }
 
 type PushKeyModule interface {
@@ -110,7 +116,23 @@ type PushKeyModule interface {
 	Find(id string, blockHeight ...uint64) util.Map
 	Unregister(params map[string]interface{}, options ...interface{}) util.Map
 	GetByAddress(address string) []string
+	GetPushKeysByAddress(address string) []util.Map
 	GetAccountOfOwner(gpgID string, blockHeight ...uint64) util.Map
+	CheckScope(pushKeyID, repo, namespace string) bool
+	GetPushKeyReach(pushKeyID string) util.Map
+	SignMessage(message, pushKeyID string, passphrase ...string) string
+	MakePushToken(repo, pushKeyID string, opts ...MakePushTokenOptions) string
+	VerifyMessage(message, signature, pushKeyID string) bool
+}
+
+type MakePushTokenOptions struct {
+	Namespace  string `json:"namespace"`
+	Reference  string `json:"reference"`
+	Fee        string `json:"fee"`
+	Value      string `json:"value"`
+	Nonce      uint64 `json:"nonce"`
+	ExpiresAt  int64  `json:"expiresAt"`
+	Passphrase string `json:"passphrase"`
 }
 
 type ConsoleUtilModule interface {
@@ -134,50 +156,162 @@ type TicketModule interface {
 	GetHostTicketsByProposer(proposerPubKey string, queryOpts ...util.Map) []util.Map
 	GetTopValidators(limit ...int) []util.Map
 	GetTopHosts(limit ...int) []util.Map
+	GetHostSet(limit ...int) []util.Map
+	GetHosts(opts ...GetHostsOptions) []util.Map
 	GetStats(proposerPubKey ...string) (result util.Map)
 	GetAll(limit ...int) []util.Map
 	UnbondHostTicket(params map[string]interface{}, options ...interface{}) util.Map
 }
 
+type GetHostsOptions struct {
+	MinStake string `json:"minStake"`
+	SortBy   string `json:"sortBy"`
+	Offset   int    `json:"offset"`
+	Limit    int    `json:"limit"`
+}
+
 type GetOptions struct {
 	Height interface{} `json:"height"`
 	Select []string    `json:"select"`
 }
 
+type ClosedProposalsOptions struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+type ListProposalsOptions struct {
+	Status  string `json:"status"`
+	Type    string `json:"type"`
+	Creator string `json:"creator"`
+	Offset  int    `json:"offset"`
+	Limit   int    `json:"limit"`
+}
+
+type ListPostsOptions struct {
+	IncludeArchived bool `json:"includeArchived"`
+}
+
+type GetCommitsOptions struct {
+	Limit int    `json:"limit"`
+	Order string `json:"order"`
+}
+
+type CloneOptions struct {
+	Reference string `json:"reference"`
+	Depth     int    `json:"depth"`
+	Since     int64  `json:"since"`
+}
+
+type CloneReposOptions struct {
+	Parallelism int `json:"parallelism"`
+}
+
+type RepoTimelineOptions struct {
+	Types  []string `json:"types"`
+	Offset int      `json:"offset"`
+	Limit  int      `json:"limit"`
+}
+
 type RepoModule interface {
 	Module
 	Create(params map[string]interface{}, options ...interface{}) util.Map
 	UpsertOwner(params map[string]interface{}, options ...interface{}) util.Map
 	Vote(params map[string]interface{}, options ...interface{}) util.Map
+	GetVotingPower(name, id, address string) util.Map
 	Get(name string, opts ...GetOptions) util.Map
+	GetRepoNamespaces(name string) []util.Map
+	CloneRepos(names []string, opts ...CloneReposOptions) []util.Map
+	GetReferenceNonce(name, ref string) uint64
+	ReconcileRepo(name string) util.Map
+	ListRefs(name string, pattern ...string) []util.Map
+	GetGovernance(name string) util.Map
+	GetEffectivePolicies(name, pushKeyID string) []util.Map
+	ResolveURI(uri string) util.Map
+	NormalizeRepoName(name string) string
 	Update(params map[string]interface{}, options ...interface{}) util.Map
+	ValidateRepoConfig(config map[string]interface{}) util.Map
 	DepositProposalFee(params map[string]interface{}, options ...interface{}) util.Map
+	GetProposalConfigDiff(name, id string) util.Map
+	GetRepoConfigHistory(name string, fromHeight, toHeight uint64) util.Map
 	AddContributor(params map[string]interface{}, options ...interface{}) util.Map
 	Track(names string, height ...uint64)
 	UnTrack(names string)
 	GetTracked() util.Map
+	GetTrackedStatus() []util.Map
 	GetReposCreatedByAddress(address string) []string
+	GetClosedProposals(name string, opts ...ClosedProposalsOptions) []util.Map
+	ListProposals(name string, opts ...ListProposalsOptions) []util.Map
 	ListPath(name, path string, revision ...string) []util.Map
+	GetCodeOwnership(name, path string, revision ...string) []util.Map
+	GetFileHistory(name, filePath string, limit int) []util.Map
 	ReadFileLines(name, filePath string, revision ...string) []string
 	ReadFile(name, filePath string, revision ...string) string
+	GetReadme(name, branch string, preferredNames ...string) util.Map
+	ReadFileChunk(name, filePath string, offset, length int64, revision ...string) util.Map
+	Archive(name string, revision ...string) string
+	GetRepoContentHash(name, ref string) string
 	GetBranches(name string) []string
+	GetBranchesWithMeta(name string) []util.Map
+	GetDefaultBranch(name string) string
+	GetStaleBranches(name, age string) []util.Map
+	GetRepoMeta(name string) util.Map
 	GetLatestBranchCommit(name, branch string) util.Map
-	GetCommits(reference, branch string, limit ...int) []util.Map
+	GetCommits(name, branch string, opts ...GetCommitsOptions) []util.Map
+	GetCommitGraphDOT(name, branch string, limit int) string
+	VerifyBranchSignatures(name, branch string, limit ...int) util.Map
+	GetTagSignatureInfo(name, tag string) util.Map
+	AuditRepoSignatures(name string) []util.Map
+	GetProvenanceChain(name, commitHash string, limit int) []util.Map
 	GetCommit(name, hash string) util.Map
+	GetCommitNotes(name, commitHash, notesRef string) string
+	GetMissingObjects(name, ref string) []string
+	VerifyRepoIntegrity(name string) util.Map
+	EstimateCloneSize(name string, opts ...CloneOptions) util.Map
+	GetObjectStats(name string) util.Map
+	AnalyzeRepack(name string) util.Map
+	DoRepack(name string) util.Map
+	GetRepoStats(name string) util.Map
 	CountCommits(name, branch string) int
 	GetCommitAncestors(name, commitHash string, limit ...int) []util.Map
+	GetMergeBase(name, commitA, commitB string) string
+	GetBranchDivergence(name, branch string) util.Map
+	CompareRepos(baseRepo, headRepo, branch string) util.Map
+	GetRefsContaining(name, commitHash string) util.Map
 	GetParentsAndCommitDiff(name string, commitHash string) util.Map
+	StreamParentsAndCommitDiff(name string, commitHash string, cb func(parent, fileDiff string) error)
+	GetCommitTree(name string, commitHash string) []util.Map
+	ExportPatchSeries(name, from, to string) []string
+	CompareTags(name, fromTag, toTag string) util.Map
 	CreateIssue(name string, params map[string]interface{}) util.Map
 	ReadIssue(name, reference string) []util.Map
+	ReadIssueThread(name, reference string) []util.Map
 	CloseIssue(name, reference string) util.Map
 	ReopenIssue(name, reference string) util.Map
-	ListIssues(name string) []util.Map
+	ListIssues(name string, opts ...ListPostsOptions) []util.Map
 	CreateMergeRequest(name string, params map[string]interface{}) util.Map
 	ReadMergeRequest(name, reference string) []util.Map
+	ReadMergeRequestThread(name, reference string) []util.Map
 	CloseMergeRequest(name, reference string) util.Map
-	ListMergeRequests(name string) []util.Map
+	ListMergeRequests(name string, opts ...ListPostsOptions) []util.Map
+	ApplyPostRetention(name string) util.Map
+	ExportPosts(name, kind string) string
+	ImportPosts(name, bundle string) util.Map
+	GetRepoTimeline(name string, opts ...RepoTimelineOptions) []util.Map
 	ReopenMergeRequest(name, reference string) util.Map
 	Push(params map[string]interface{}, privateKeyOrPushToken string) string
+	EstimatePushSize(id string) util.Map
+	ComputePushID(id string) util.Map
+	PreviewPostChange(id, reference string) string
+	SnapshotTempRepo(id string) string
+	RestoreTempRepo(data string) string
+	GetPushedRefs(hash string) []util.Map
+	GetPushEndorsements(hash string) []util.Map
+	GetPendingPushes() []util.Map
+	ResignRefs(params map[string]interface{}, privateKey string) []util.Map
+	SyncFromUpstream(name string) util.Map
+	DecodePushToken(token string, pushKeyPubKey ...string) util.Map
+	WithReadToken(token string) RepoModule
 }
 type NamespaceModule interface {
 	Module
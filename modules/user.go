@@ -32,24 +32,32 @@ type UserModule struct {
 	keystore kstypes.Keystore
 	service  services.Service
 	logic    core.Logic
+	aliases  *keystore.AliasStore
+	nonces   *NonceManager
 }
 
 // NewAttachableUserModule creates an instance of UserModule suitable in attach mode
 func NewAttachableUserModule(cfg *config.AppConfig, client types2.Client, ks *keystore.Keystore) *UserModule {
-	return &UserModule{ModuleCommon: types.ModuleCommon{Client: client}, cfg: cfg, keystore: ks}
+	return &UserModule{
+		ModuleCommon: types.ModuleCommon{Client: client},
+		cfg:          cfg,
+		keystore:     ks,
+		aliases:      keystore.NewAliasStore(cfg.GetAliasesPath()),
+	}
 }
 
 // NewUserModule creates an instance of UserModule
 func NewUserModule(
 	cfg *config.AppConfig,
-	keystore kstypes.Keystore,
+	ks kstypes.Keystore,
 	service services.Service,
 	logic core.Logic) *UserModule {
 	return &UserModule{
 		cfg:      cfg,
-		keystore: keystore,
+		keystore: ks,
 		service:  service,
 		logic:    logic,
+		aliases:  keystore.NewAliasStore(cfg.GetAliasesPath()),
 	}
 }
 
@@ -66,6 +74,9 @@ func (m *UserModule) methods() []*types.VMMember {
 		{Name: "getValidator", Value: m.GetValidator, Description: "Get the validator information"},
 		{Name: "setCommission", Value: m.SetCommission, Description: "Set the percentage of reward to share with a delegator"},
 		{Name: "send", Value: m.SendCoin, Description: "Send coins to another user account or a repository"},
+		{Name: "setAlias", Value: m.SetAlias, Description: "Set a name that can be used in place of an address"},
+		{Name: "getAlias", Value: m.GetAlias, Description: "Get the address an alias points to"},
+		{Name: "listAliases", Value: m.ListAliases, Description: "List all aliases and the addresses they point to"},
 	}
 }
 
@@ -184,6 +195,7 @@ unlock:
 //  - address: The address corresponding the the local key
 //  - [passphrase]: The passphrase of the local key
 func (m *UserModule) GetPrivKey(address string, passphrase ...string) string {
+	address = m.aliases.Resolve(address)
 
 	// If passphrase is not set, start interactive mode
 	var pass string
@@ -213,6 +225,7 @@ func (m *UserModule) GetPrivKey(address string, passphrase ...string) string {
 //  - address: The address corresponding the the local key
 //  - [passphrase]: The passphrase of the local key
 func (m *UserModule) GetPublicKey(address string, passphrase ...string) string {
+	address = m.aliases.Resolve(address)
 
 	// If passphrase is not set, start interactive mode
 	var pass string
@@ -238,6 +251,7 @@ func (m *UserModule) GetPublicKey(address string, passphrase ...string) string {
 //  - [passphrase]: The target block height to query (default: latest)
 //  - [height]: The target block height to query (default: latest)
 func (m *UserModule) GetNonce(address string, height ...uint64) string {
+	address = m.aliases.Resolve(address)
 
 	if m.IsAttached() {
 		nonce, err := m.Client.User().GetNonce(address, height...)
@@ -259,6 +273,7 @@ func (m *UserModule) GetNonce(address string, height ...uint64) string {
 //  - address: The address corresponding the account
 //  - [height]: The target block height to query (default: latest)
 func (m *UserModule) GetAccount(address string, height ...uint64) util.Map {
+	address = m.aliases.Resolve(address)
 
 	if m.IsAttached() {
 		tx, err := m.Client.User().Get(address, height...)
@@ -284,6 +299,7 @@ func (m *UserModule) GetAccount(address string, height ...uint64) util.Map {
 //  - address: The address corresponding the account
 //  - [height]: The target block height to query (default: latest)
 func (m *UserModule) GetAvailableBalance(address string, height ...uint64) string {
+	address = m.aliases.Resolve(address)
 
 	if m.IsAttached() {
 		bal, err := m.Client.User().GetBalance(address, height...)
@@ -310,6 +326,7 @@ func (m *UserModule) GetAvailableBalance(address string, height ...uint64) strin
 //  - address: The address corresponding the account
 //  - [height]: The target block height to query (default: latest)
 func (m *UserModule) GetStakedBalance(address string, height ...uint64) string {
+	address = m.aliases.Resolve(address)
 
 	if m.IsAttached() {
 		bal, err := m.Client.User().GetStakedBalance(address, height...)
@@ -392,7 +409,7 @@ func (m *UserModule) SetCommission(params map[string]interface{}, options ...int
 		panic(errors.ReqErr(400, StatusCodeInvalidParam, "", err.Error()))
 	}
 
-	retPayload, signingKey := finalizeTx(tx, m.logic, m.Client, options...)
+	retPayload, signingKey := finalizeTx(tx, m.logic, m.Client, m.nonces, options...)
 	if retPayload {
 		return tx.ToMap()
 	}
@@ -407,6 +424,7 @@ func (m *UserModule) SetCommission(params map[string]interface{}, options ...int
 		if err != nil {
 			panic(err)
 		}
+		m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
 		return util.ToMap(resp)
 	}
 
@@ -414,6 +432,7 @@ func (m *UserModule) SetCommission(params map[string]interface{}, options ...int
 	if err != nil {
 		panic(errors.ReqErr(400, StatusCodeMempoolAddFail, "", err.Error()))
 	}
+	m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
 
 	return map[string]interface{}{
 		"hash": hash,
@@ -438,12 +457,16 @@ func (m *UserModule) SetCommission(params map[string]interface{}, options ...int
 func (m *UserModule) SendCoin(params map[string]interface{}, options ...interface{}) util.Map {
 	var err error
 
+	if to, ok := params["to"].(string); ok {
+		params["to"] = m.aliases.Resolve(to)
+	}
+
 	var tx = txns.NewBareTxCoinTransfer()
 	if err = tx.FromMap(params); err != nil {
 		panic(errors.ReqErr(400, StatusCodeInvalidParam, "params", err.Error()))
 	}
 
-	retPayload, signingKey := finalizeTx(tx, m.logic, m.Client, options...)
+	retPayload, signingKey := finalizeTx(tx, m.logic, m.Client, m.nonces, options...)
 	if retPayload {
 		return tx.ToMap()
 	}
@@ -459,6 +482,7 @@ func (m *UserModule) SendCoin(params map[string]interface{}, options ...interfac
 		if err != nil {
 			panic(err)
 		}
+		m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
 		return util.ToMap(resp)
 	}
 
@@ -466,8 +490,52 @@ func (m *UserModule) SendCoin(params map[string]interface{}, options ...interfac
 	if err != nil {
 		panic(errors.ReqErr(400, StatusCodeMempoolAddFail, "", err.Error()))
 	}
+	m.nonces.Commit(tx.GetFrom(), tx.GetNonce())
 
 	return map[string]interface{}{
 		"hash": hash,
 	}
 }
+
+// SetAlias sets a name that can be used in place of an address
+// in methods that accept a user or push key address.
+//  - name: The alias
+//  - target: The user or push key address the alias points to
+//  - [overwrite]: When true, replaces an existing alias with the same name
+func (m *UserModule) SetAlias(name, target string, overwrite ...bool) {
+	if err := m.aliases.Set(name, target, len(overwrite) > 0 && overwrite[0]); err != nil {
+		if err == keystore.ErrAliasExists {
+			panic(errors.ReqErr(409, StatusCodeAliasExists, "name", err.Error()))
+		}
+		if fe, ok := err.(*errors.BadFieldError); ok {
+			panic(errors.ReqErr(400, StatusCodeInvalidParam, fe.Field, fe.Msg))
+		}
+		panic(errors.ReqErr(500, StatusCodeServerErr, "", err.Error()))
+	}
+}
+
+// GetAlias returns the address an alias points to
+//  - name: The alias
+func (m *UserModule) GetAlias(name string) string {
+	target, err := m.aliases.Get(name)
+	if err != nil {
+		if err == keystore.ErrAliasUnknown {
+			panic(errors.ReqErr(404, StatusCodeAliasNotFound, "name", err.Error()))
+		}
+		panic(errors.ReqErr(500, StatusCodeServerErr, "", err.Error()))
+	}
+	return target
+}
+
+// ListAliases returns all aliases and the addresses they point to
+func (m *UserModule) ListAliases() util.Map {
+	aliases, err := m.aliases.List()
+	if err != nil {
+		panic(errors.ReqErr(500, StatusCodeServerErr, "", err.Error()))
+	}
+	var res = util.Map{}
+	for name, target := range aliases {
+		res[name] = target
+	}
+	return res
+}
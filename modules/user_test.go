@@ -528,4 +528,60 @@ var _ = Describe("UserModule", func() {
 			Expect(res["hash"]).To(Equal(hash))
 		})
 	})
+
+	Describe(".SetAlias", func() {
+		It("should panic when name is invalid", func() {
+			err := &errors.ReqError{Code: "invalid_param", HttpCode: 400, Msg: "alias must not be a number", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.SetAlias("1", pk.Addr().String())
+			})
+		})
+
+		It("should panic when alias already points to a different address", func() {
+			m.SetAlias("alice", pk.Addr().String())
+			err := &errors.ReqError{Code: "alias_exists", HttpCode: 409, Msg: "alias already exists", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.SetAlias("alice", crypto2.NewKeyFromIntSeed(2).Addr().String())
+			})
+		})
+
+		It("should replace alias when overwrite is true", func() {
+			addr2 := crypto2.NewKeyFromIntSeed(2).Addr().String()
+			m.SetAlias("alice", pk.Addr().String())
+			m.SetAlias("alice", addr2, true)
+			Expect(m.GetAlias("alice")).To(Equal(addr2))
+		})
+	})
+
+	Describe(".GetAlias", func() {
+		It("should panic when alias does not exist", func() {
+			err := &errors.ReqError{Code: "alias_not_found", HttpCode: 404, Msg: "alias not found", Field: "name"}
+			assert.PanicsWithError(GinkgoT(), err.Error(), func() {
+				m.GetAlias("alice")
+			})
+		})
+
+		It("should return the alias target", func() {
+			m.SetAlias("alice", pk.PushAddr().String())
+			Expect(m.GetAlias("alice")).To(Equal(pk.PushAddr().String()))
+		})
+	})
+
+	Describe(".ListAliases", func() {
+		It("should return all aliases", func() {
+			m.SetAlias("alice", pk.Addr().String())
+			m.SetAlias("bob", pk.PushAddr().String())
+			Expect(m.ListAliases()).To(Equal(util.Map{"alice": pk.Addr().String(), "bob": pk.PushAddr().String()}))
+		})
+	})
+
+	When("an alias is passed in place of an address", func() {
+		It("should resolve the alias to its address", func() {
+			m.SetAlias("alice", pk.Addr().String())
+			acct := state.NewBareAccount()
+			acct.Nonce = 100
+			mockAcctKeeper.EXPECT().Get(pk.Addr()).Return(acct)
+			Expect(m.GetNonce("alice")).To(Equal("100"))
+		})
+	})
 })
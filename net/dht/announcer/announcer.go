@@ -3,12 +3,12 @@ package announcer
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	cid2 "github.com/ipfs/go-cid"
-	kaddht "github.com/libp2p/go-libp2p-kad-dht"
 	"github.com/make-os/kit/config"
 	dht3 "github.com/make-os/kit/net/dht"
 	"github.com/make-os/kit/pkgs/logger"
@@ -27,11 +27,19 @@ const (
 var ErrDelisted = fmt.Errorf("key delisted")
 
 const (
-	// KeyReannounceDur is the duration that passes streamer/streamer_test.go:630:between key re-announcement.
+	// KeyReannounceDur is the default duration that passes between key re-announcement.
 	KeyReannounceDur = 5 * time.Hour
 
 	// ReannouncerInterval is the duration between each reannoucement process.
 	ReannouncerInterval = 30 * time.Second
+
+	// RepublishJitter is the maximum fraction of the republish interval
+	// that is randomly subtracted from a key's next announcement time.
+	RepublishJitter = 0.1
+
+	// RetryDrainInterval is the duration between each processing of the
+	// failed announcements that are due to be retried.
+	RetryDrainInterval = 10 * time.Second
 )
 
 // MaxRetry is the number of times to try to reannounce a key
@@ -83,39 +91,70 @@ type Task struct {
 	Key            util.Bytes      // The unique object key
 	CheckExistence bool            // Indicates that existence check should be performed on the key.
 	Done           func(err error) // Done is called on success or failure
+	attempts       int             // The number of retries of the task
 }
 
 func (t *Task) GetID() interface{} {
 	return t.Key.String()
 }
 
+// Provider describes a DHT on which a node can announce itself
+// as a provider of a key.
+type Provider interface {
+	Provide(ctx context.Context, key cid2.Cid, brdcst bool) error
+}
+
+// retryEntry is a failed announcement awaiting another attempt
+type retryEntry struct {
+	task     *Task
+	nextTime time.Time
+}
+
 // Announcer implements dht.Announcer.
 // It provides the mechanism for announcing keys on the DHT.
 // Announcement requests are queued up an concurrently executed by n workers.
-// When an announcement fails, it is retried several times.
+// When an announcement fails, it is retried several times. If it still
+// fails, it is added to a retry queue and attempted again after a delay
+// that doubles on each failure, until the retry limit is reached.
 type Announcer struct {
 	keepers     core.Keepers
 	log         logger.Logger
-	dht         *kaddht.IpfsDHT
+	dht         Provider
 	checkers    *sync.Map
 	lck         *sync.Mutex
 	queue       chan *Task
 	queued      map[string]struct{}
+	retries     map[string]*retryEntry
 	reannouncer *time.Ticker
+	retrier     *time.Ticker
 	started     bool
 	stopped     bool
+
+	republishInterval time.Duration    // Duration between re-announcement of a key
+	retryLimit        int              // Maximum number of retries of a failed announcement
+	retryBackoff      time.Duration    // Delay before the first retry of a failed announcement
+	now               func() time.Time // Returns the current time
 }
 
 // New creates an instance of Announcer
-func New(cfg *config.AppConfig, dht *kaddht.IpfsDHT, keepers core.Keepers) *Announcer {
+func New(cfg *config.AppConfig, dht Provider, keepers core.Keepers) *Announcer {
 	rs := &Announcer{
-		keepers:  keepers,
-		dht:      dht,
-		checkers: &sync.Map{},
-		lck:      &sync.Mutex{},
-		log:      cfg.G().Log.Module("announcer"),
-		queue:    make(chan *Task, 10000),
-		queued:   make(map[string]struct{}),
+		keepers:           keepers,
+		dht:               dht,
+		checkers:          &sync.Map{},
+		lck:               &sync.Mutex{},
+		log:               cfg.G().Log.Module("announcer"),
+		queue:             make(chan *Task, 10000),
+		queued:            make(map[string]struct{}),
+		retries:           make(map[string]*retryEntry),
+		republishInterval: cfg.DHT.RepublishInterval,
+		retryLimit:        cfg.DHT.AnnounceRetryLimit,
+		retryBackoff:      cfg.DHT.AnnounceRetryBackoff,
+		now:               time.Now,
+	}
+
+	if rs.republishInterval <= 0 {
+		rs.republishInterval = KeyReannounceDur
 	}
 
 	go func() {
@@ -139,6 +178,68 @@ func (a *Announcer) addTask(task *Task) bool {
 	return false
 }
 
+// scheduleRetry adds a failed task to the retry queue unless it has
+// reached the retry limit. The retry delay doubles on every attempt.
+func (a *Announcer) scheduleRetry(task *Task) {
+	if task.attempts >= a.retryLimit || a.retryBackoff <= 0 {
+		return
+	}
+
+	a.lck.Lock()
+	defer a.lck.Unlock()
+	a.retries[task.GetID().(string)] = &retryEntry{
+		task: &Task{
+			Type:           task.Type,
+			RepoName:       task.RepoName,
+			Key:            task.Key,
+			CheckExistence: task.CheckExistence,
+			attempts:       task.attempts + 1,
+		},
+		nextTime: a.now().Add(a.retryBackoff << uint(task.attempts)),
+	}
+}
+
+// RetryQueueSize returns the number of failed announcements awaiting retry
+func (a *Announcer) RetryQueueSize() int {
+	a.lck.Lock()
+	defer a.lck.Unlock()
+	return len(a.retries)
+}
+
+// ProcessRetries queues the failed announcements that are due to be retried
+func (a *Announcer) ProcessRetries() {
+	a.lck.Lock()
+	var due []*Task
+	for id, entry := range a.retries {
+		if now := a.now(); now.After(entry.nextTime) || now.Equal(entry.nextTime) {
+			due = append(due, entry.task)
+			delete(a.retries, id)
+		}
+	}
+	a.lck.Unlock()
+
+	for _, task := range due {
+		a.addTask(task)
+	}
+}
+
+// SetClock sets the function used to get the current time
+func (a *Announcer) SetClock(now func() time.Time) {
+	a.now = now
+}
+
+// nextAnnounceTime returns the time a key announced now should be
+// re-announced. A random jitter is subtracted from the republish interval
+// so that the record is refreshed before it expires and keys announced
+// together are not re-announced at the same time.
+func (a *Announcer) nextAnnounceTime() time.Time {
+	var jitter time.Duration
+	if max := int64(float64(a.republishInterval) * RepublishJitter); max > 0 {
+		jitter = time.Duration(rand.Int63n(max + 1))
+	}
+	return a.now().Add(a.republishInterval - jitter)
+}
+
 // GetQueued returns the index containing tasks awaiting processing.
 func (a *Announcer) GetQueued() map[string]struct{} {
 	return a.queued
@@ -196,6 +297,13 @@ func (a *Announcer) Start() {
 		}
 	}()
 
+	a.retrier = time.NewTicker(RetryDrainInterval)
+	go func() {
+		for range a.retrier.C {
+			a.ProcessRetries()
+		}
+	}()
+
 	go func() {
 		for task := range a.queue {
 			go a.Do(task)
@@ -232,6 +340,9 @@ func (a *Announcer) Stop() {
 	if a.reannouncer != nil {
 		a.reannouncer.Stop()
 	}
+	if a.retrier != nil {
+		a.retrier.Stop()
+	}
 	a.lck.Unlock()
 }
 
@@ -249,6 +360,8 @@ func (a *Announcer) keyExist(task *Task) bool {
 
 // Do announces the key in the given task.
 // After announcement, the key is (re)added to the announce list.
+// If the announcement fails, the task is scheduled to be retried; task.Done
+// is only called for the first attempt.
 func (a *Announcer) Do(task *Task) (err error) {
 
 	if task.Done == nil {
@@ -285,12 +398,13 @@ func (a *Announcer) Do(task *Task) (err error) {
 		return nil
 	}, backoff.WithMaxRetries(backoff.NewExponentialBackOff(), uint64(MaxRetry)))
 	if err != nil {
-		a.log.Error("Failed to announce key", "Err", err, "Key", plumbing.BytesToHex(key))
+		a.log.Error("Failed to announce key", "Err", err, "Key", plumbing.BytesToHex(key), "Attempts", task.attempts)
+		a.scheduleRetry(task)
 		return
 	}
 
 	// (Re)add the key to the announce list
-	a.keepers.DHTKeeper().AddToAnnounceList(key, task.RepoName, task.Type, time.Now().Add(KeyReannounceDur).Unix())
+	a.keepers.DHTKeeper().AddToAnnounceList(key, task.RepoName, task.Type, a.nextAnnounceTime().Unix())
 
 	a.log.Debug("Successfully announced a key", "Key", plumbing.BytesToHex(key))
 
@@ -301,7 +415,7 @@ func (a *Announcer) Do(task *Task) (err error) {
 func (a *Announcer) Reannounce() {
 	a.keepers.DHTKeeper().IterateAnnounceList(func(key []byte, entry *core.AnnounceListEntry) {
 		annTime := time.Unix(entry.NextTime, 0)
-		if time.Now().After(annTime) || time.Now().Equal(annTime) {
+		if now := a.now(); now.After(annTime) || now.Equal(annTime) {
 			a.addTask(&Task{Type: entry.Type, RepoName: entry.Repo, Key: key, CheckExistence: true})
 		}
 	})
@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p-core/peer"
 	record "github.com/libp2p/go-libp2p-record"
 	"github.com/make-os/kit/config"
@@ -142,6 +145,42 @@ var _ = Describe("Announcer", func() {
 				Expect(err).To(BeNil())
 				Expect(ann.GetQueued()).ToNot(HaveKey(task.GetID()))
 			})
+
+			It("should schedule the key for republish before the republish interval elapses", func() {
+				now := time.Now()
+				ann.SetClock(func() time.Time { return now })
+				interval := announcer.KeyReannounceDur
+				jitter := time.Duration(float64(interval) * announcer.RepublishJitter)
+				var nextTime int64
+				mockDHTKeeper.EXPECT().AddToAnnounceList(key, "repo1", 1, gomock.Any()).Do(func(_ []byte, _ string, _ int, t int64) {
+					nextTime = t
+				}).Times(2)
+				mockDHTKeeper.EXPECT().IterateAnnounceList(gomock.Any()).Do(func(it func(key []byte, entry *core.AnnounceListEntry)) {
+					it(key, &core.AnnounceListEntry{Type: 1, Repo: "repo1", NextTime: nextTime})
+				}).Times(3)
+				ann.RegisterChecker(1, func(repo string, k []byte) bool { return true })
+
+				Expect(ann.Do(task)).To(BeNil())
+				Expect(nextTime).To(BeNumerically(">=", now.Add(interval-jitter).Unix()))
+				Expect(nextTime).To(BeNumerically("<=", now.Add(interval).Unix()))
+
+				By("not republishing before the scheduled time")
+				ann.Reannounce()
+				Expect(ann.QueueSize()).To(Equal(0))
+
+				By("republishing once the clock reaches the scheduled time")
+				now = time.Unix(nextTime, 0)
+				ann.Reannounce()
+				Expect(ann.QueueSize()).To(Equal(1))
+				prevNextTime := nextTime
+				Expect(ann.Do(&announcer.Task{Key: key, RepoName: "repo1", Type: 1, CheckExistence: true})).To(BeNil())
+				Expect(nextTime).To(BeNumerically(">", prevNextTime))
+
+				By("republishing again after the next interval")
+				now = time.Unix(nextTime, 0)
+				ann.Reannounce()
+				Expect(ann.QueueSize()).To(Equal(2))
+			})
 		})
 
 		When("Not connected to a DHT peer", func() {
@@ -154,6 +193,71 @@ var _ = Describe("Announcer", func() {
 		})
 	})
 
+	Describe(".ProcessRetries", func() {
+		var provider *flakyProvider
+		var flakyAnn *announcer.Announcer
+		var now int64
+		key := []byte("key")
+
+		BeforeEach(func() {
+			announcer.MaxRetry = 0
+			cfg.DHT.AnnounceRetryLimit = 3
+			cfg.DHT.AnnounceRetryBackoff = time.Minute
+			provider = &flakyProvider{failures: 2}
+			flakyAnn = announcer.New(cfg, provider, mockObjects.Logic)
+			now = time.Now().Unix()
+			flakyAnn.SetClock(func() time.Time { return time.Unix(atomic.LoadInt64(&now), 0) })
+		})
+
+		AfterEach(func() {
+			flakyAnn.Stop()
+		})
+
+		It("should retry a failed announcement with backoff until it succeeds", func() {
+			mockDHTKeeper.EXPECT().AddToAnnounceList(key, "repo1", 1, gomock.Any())
+			err := flakyAnn.Do(&announcer.Task{Key: key, RepoName: "repo1", Type: 1})
+			Expect(err).To(MatchError("provide failed"))
+			Expect(flakyAnn.RetryQueueSize()).To(Equal(1))
+
+			By("not retrying before the backoff elapses")
+			flakyAnn.ProcessRetries()
+			Expect(flakyAnn.QueueSize()).To(Equal(0))
+			Expect(flakyAnn.RetryQueueSize()).To(Equal(1))
+
+			By("retrying once the backoff elapses")
+			flakyAnn.Start()
+			atomic.AddInt64(&now, int64(time.Minute.Seconds()))
+			flakyAnn.ProcessRetries()
+			Eventually(provider.Calls).Should(Equal(2))
+			Eventually(flakyAnn.RetryQueueSize).Should(Equal(1))
+
+			By("doubling the backoff after the retry fails")
+			atomic.AddInt64(&now, int64(time.Minute.Seconds()))
+			flakyAnn.ProcessRetries()
+			Consistently(provider.Calls, 200*time.Millisecond).Should(Equal(2))
+			atomic.AddInt64(&now, int64(time.Minute.Seconds()))
+			flakyAnn.ProcessRetries()
+			Eventually(provider.Calls).Should(Equal(3))
+			Eventually(func() int { return len(flakyAnn.GetQueued()) }).Should(Equal(0))
+			Expect(flakyAnn.RetryQueueSize()).To(Equal(0))
+		})
+
+		It("should stop retrying when the retry limit is reached", func() {
+			cfg.DHT.AnnounceRetryLimit = 1
+			flakyAnn = announcer.New(cfg, provider, mockObjects.Logic)
+			flakyAnn.SetClock(func() time.Time { return time.Unix(atomic.LoadInt64(&now), 0) })
+			Expect(flakyAnn.Do(&announcer.Task{Key: key, RepoName: "repo1", Type: 1})).ToNot(BeNil())
+			Expect(flakyAnn.RetryQueueSize()).To(Equal(1))
+			atomic.AddInt64(&now, int64(time.Minute.Seconds()))
+			flakyAnn.ProcessRetries()
+			Expect(flakyAnn.QueueSize()).To(Equal(1))
+			flakyAnn.Start()
+			Eventually(provider.Calls).Should(Equal(2))
+			Eventually(func() int { return len(flakyAnn.GetQueued()) }).Should(Equal(0))
+			Expect(flakyAnn.RetryQueueSize()).To(Equal(0))
+		})
+	})
+
 	Describe(".Reannounce", func() {
 		It("should re-add keys where NextTime equal to current time or is before current time", func() {
 			now := time.Now()
@@ -165,6 +269,17 @@ var _ = Describe("Announcer", func() {
 			ann.Reannounce()
 			Expect(ann.QueueSize()).To(Equal(2))
 		})
+
+		It("should use the announcer's clock to determine due keys", func() {
+			now := time.Now()
+			ann.SetClock(func() time.Time { return now.Add(2 * time.Minute) })
+			mockDHTKeeper.EXPECT().IterateAnnounceList(gomock.Any()).Do(func(it func(key []byte, entry *core.AnnounceListEntry)) {
+				it([]byte("key"), &core.AnnounceListEntry{Type: 1, Repo: "repo1", NextTime: now.Add(1 * time.Minute).Unix()})
+				it([]byte("key2"), &core.AnnounceListEntry{Type: 1, Repo: "repo1", NextTime: now.Add(3 * time.Minute).Unix()})
+			})
+			ann.Reannounce()
+			Expect(ann.QueueSize()).To(Equal(1))
+		})
 	})
 })
 
@@ -241,6 +356,29 @@ func makePeer(ctrl *gomock.Controller, cfg *config.AppConfig, keepers core.Keepe
 	return mockHost, svr
 }
 
+// flakyProvider is a DHT provider that fails a number of times before succeeding
+type flakyProvider struct {
+	lck      sync.Mutex
+	failures int
+	calls    int
+}
+
+func (p *flakyProvider) Provide(context.Context, cid.Cid, bool) error {
+	p.lck.Lock()
+	defer p.lck.Unlock()
+	p.calls++
+	if p.calls <= p.failures {
+		return fmt.Errorf("provide failed")
+	}
+	return nil
+}
+
+func (p *flakyProvider) Calls() int {
+	p.lck.Lock()
+	defer p.lck.Unlock()
+	return p.calls
+}
+
 type okValidator struct{ err error }
 
 func (v okValidator) Validate(key string, value []byte) error         { return nil }
@@ -6,6 +6,8 @@ import (
 	"io"
 
 	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/make-os/kit/crypto/ed25519"
 	"github.com/make-os/kit/util"
 	"github.com/make-os/kit/util/identifier"
 	"github.com/multiformats/go-multihash"
@@ -24,8 +26,20 @@ const (
 )
 
 var (
-	ErrObjNotFound = fmt.Errorf("object not found")
-	MsgTypeLen     = 4
+	ErrObjNotFound       = fmt.Errorf("object not found")
+	ErrInvalidRequestSig = fmt.Errorf("invalid request signature")
+	MsgTypeLen           = 4
+)
+
+const (
+	// RequestHashLen is the length of the object hash in a request
+	RequestHashLen = 20
+
+	// RequestPubKeyLen is the length of a requester's public key in a signed request
+	RequestPubKeyLen = 32
+
+	// RequestSigLen is the length of a requester's signature in a signed request
+	RequestSigLen = 64
 )
 
 // ParseObjectKeyToHex parses an object key to an hex-encoded version
@@ -66,6 +80,101 @@ func ReadWantOrSendMsg(r io.Reader) (typ string, repoName string, hash []byte, e
 	return ParseWantOrSendMsg(buf)
 }
 
+// Request describes a 'WANT' or 'SEND' request
+type Request struct {
+	Type     string
+	RepoName string
+	Hash     []byte
+
+	// PubKey and Sig are the public key and signature of the requester.
+	// They are only set when the request is signed.
+	PubKey []byte
+	Sig    []byte
+
+	// payload is the unsigned portion of the request
+	payload []byte
+}
+
+// IsSigned checks whether the request was signed by the requester
+func (r *Request) IsSigned() bool {
+	return len(r.PubKey) > 0
+}
+
+// Verify checks that the request signature was created by the requester's
+// key for the given provider. It returns the requester's public key.
+func (r *Request) Verify(provider peer.ID) (*ed25519.PubKey, error) {
+	if !r.IsSigned() {
+		return nil, ErrInvalidRequestSig
+	}
+	pubKey, err := ed25519.PubKeyFromBytes(r.PubKey)
+	if err != nil {
+		return nil, ErrInvalidRequestSig
+	}
+	if ok, err := pubKey.Verify(requestSigningData(r.payload, provider), r.Sig); err != nil || !ok {
+		return nil, ErrInvalidRequestSig
+	}
+	return pubKey, nil
+}
+
+// requestSigningData returns the data signed by a requester.
+// The provider's ID is included so a signed request cannot be replayed to other providers.
+func requestSigningData(msg []byte, provider peer.ID) []byte {
+	return append(append([]byte{}, msg...), []byte(provider)...)
+}
+
+// SignRequestMsg appends the requester's public key and a signature of a
+// 'WANT' or 'SEND' message to the message.
+//  - Format: <msg><32 bytes public key><64 bytes signature>
+//  - provider: The ID of the provider the message is sent to.
+func SignRequestMsg(msg []byte, key *ed25519.Key, provider peer.ID) ([]byte, error) {
+	sig, err := key.PrivKey().Sign(requestSigningData(msg, provider))
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := key.PubKey().Bytes()
+	if err != nil {
+		return nil, err
+	}
+	signed := append(append([]byte{}, msg...), pubKey...)
+	return append(signed, sig...), nil
+}
+
+// ParseRequest parses a signed or unsigned 'WANT/SEND' message
+func ParseRequest(msg []byte) (*Request, error) {
+	parts := bytes.SplitN(msg, []byte(" "), 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed message")
+	}
+
+	req := &Request{Type: string(parts[0]), RepoName: string(parts[1])}
+	rest := parts[2]
+	if len(rest) < RequestHashLen {
+		req.Hash = make([]byte, RequestHashLen)
+		copy(req.Hash, rest)
+		return req, nil
+	}
+
+	req.Hash = rest[:RequestHashLen]
+	req.payload = msg[:len(msg)-len(rest)+RequestHashLen]
+	if sigData := rest[RequestHashLen:]; len(sigData) >= RequestPubKeyLen+RequestSigLen {
+		req.PubKey = sigData[:RequestPubKeyLen]
+		req.Sig = sigData[RequestPubKeyLen : RequestPubKeyLen+RequestSigLen]
+	}
+
+	return req, nil
+}
+
+// ReadRequest reads a signed or unsigned 'WANT' or 'SEND' message from the reader
+func ReadRequest(r io.Reader) (*Request, error) {
+	var buf = make([]byte, MsgTypeLen+identifier.MaxResourceNameLength+2+
+		RequestHashLen+RequestPubKeyLen+RequestSigLen)
+	n, err := r.Read(buf)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return ParseRequest(buf[:n])
+}
+
 // MakeHaveMsg creates a 'HAVE' message
 func MakeHaveMsg() []byte {
 	return []byte(MsgTypeHave)
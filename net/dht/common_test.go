@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/make-os/kit/crypto/ed25519"
 	"github.com/make-os/kit/remote/plumbing"
 	. "github.com/onsi/ginkgo"
 
@@ -75,6 +77,78 @@ var _ = Describe("Common", func() {
 		})
 	})
 
+	Describe(".ParseRequest", func() {
+		var key = ed25519.NewKeyFromIntSeed(1)
+		var hashBz = plumbing.HashToBytes("d9dbe0e59248c7f0505dd5d80ed470fb43f82521")
+
+		It("should parse an unsigned request", func() {
+			req, err := ParseRequest(MakeWantMsg("repo1", hashBz))
+			Expect(err).To(BeNil())
+			Expect(req.Type).To(Equal(MsgTypeWant))
+			Expect(req.RepoName).To(Equal("repo1"))
+			Expect(req.Hash).To(Equal(hashBz))
+			Expect(req.IsSigned()).To(BeFalse())
+		})
+
+		It("should return error if message is malformed", func() {
+			_, err := ParseRequest([]byte("WANT"))
+			Expect(err).To(MatchError("malformed message"))
+		})
+
+		It("should parse a signed request", func() {
+			msg, err := SignRequestMsg(MakeSendMsg("repo1", hashBz), key, peer.ID("provider"))
+			Expect(err).To(BeNil())
+			req, err := ParseRequest(msg)
+			Expect(err).To(BeNil())
+			Expect(req.Type).To(Equal(MsgTypeSend))
+			Expect(req.RepoName).To(Equal("repo1"))
+			Expect(req.Hash).To(Equal(hashBz))
+			Expect(req.IsSigned()).To(BeTrue())
+			Expect(req.PubKey).To(Equal(key.PubKey().MustBytes()))
+		})
+	})
+
+	Describe(".Request.Verify", func() {
+		var key = ed25519.NewKeyFromIntSeed(1)
+		var hashBz = plumbing.HashToBytes("d9dbe0e59248c7f0505dd5d80ed470fb43f82521")
+
+		It("should return the requester's public key if the signature is valid", func() {
+			msg, err := SignRequestMsg(MakeWantMsg("repo1", hashBz), key, peer.ID("provider"))
+			Expect(err).To(BeNil())
+			req, err := ReadRequest(bytes.NewReader(msg))
+			Expect(err).To(BeNil())
+			pubKey, err := req.Verify(peer.ID("provider"))
+			Expect(err).To(BeNil())
+			Expect(pubKey.Addr()).To(Equal(key.Addr()))
+		})
+
+		It("should return error if the request was signed for another provider", func() {
+			msg, err := SignRequestMsg(MakeWantMsg("repo1", hashBz), key, peer.ID("provider"))
+			Expect(err).To(BeNil())
+			req, err := ParseRequest(msg)
+			Expect(err).To(BeNil())
+			_, err = req.Verify(peer.ID("other-provider"))
+			Expect(err).To(Equal(ErrInvalidRequestSig))
+		})
+
+		It("should return error if the signed message was altered", func() {
+			msg, err := SignRequestMsg(MakeWantMsg("repo1", hashBz), key, peer.ID("provider"))
+			Expect(err).To(BeNil())
+			msg = append([]byte("WANT repo2"), msg[len("WANT repo1"):]...)
+			req, err := ParseRequest(msg)
+			Expect(err).To(BeNil())
+			_, err = req.Verify(peer.ID("provider"))
+			Expect(err).To(Equal(ErrInvalidRequestSig))
+		})
+
+		It("should return error if the request is not signed", func() {
+			req, err := ParseRequest(MakeWantMsg("repo1", hashBz))
+			Expect(err).To(BeNil())
+			_, err = req.Verify(peer.ID("provider"))
+			Expect(err).To(Equal(ErrInvalidRequestSig))
+		})
+	})
+
 	Describe(".MakeSendMsg", func() {
 		It("should return expected format", func() {
 			msg := MakeSendMsg("repo1", plumbing.HashToBytes("d9dbe0e59248c7f0505dd5d80ed470fb43f82521"))
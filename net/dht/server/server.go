@@ -76,7 +76,11 @@ func New(ctx context.Context, host kitnet.Host, keepers core.Keepers, cfg *confi
 		announcer: announcer2.New(cfg, server, keepers),
 	}
 
-	node.streamer = streamer.NewStreamer(node, cfg)
+	objStreamer := streamer.NewStreamer(node, cfg)
+	if cfg.DHT.AuthorizeRequests {
+		objStreamer.AuthorizeRequest = streamer.NewRepoAccessAuthorizer(keepers)
+	}
+	node.streamer = objStreamer
 
 	go func() {
 		config.GetInterrupt().Wait()
@@ -252,6 +256,17 @@ func (dht *Server) Stop() (err error) {
 			dht.announcer.Stop()
 		}
 
+		if s, ok := dht.streamer.(*streamer.BasicObjectStreamer); ok {
+			s.StopPrefetch()
+
+			// Let in-flight object transfers finish before the host is closed
+			if timeout := dht.cfg.DHT.ShutdownDrainTimeout; timeout > 0 {
+				if err := s.Drain(timeout); err != nil {
+					dht.log.Warn("Closing host with in-flight object streams", "Err", err.Error())
+				}
+			}
+		}
+
 		if dht.host != nil {
 			err = dht.host.Close()
 		}
@@ -27,6 +27,10 @@ type GetAncestorArgs struct {
 	// RepoName is the target repository to query commits from.
 	RepoName string
 
+	// LocalRepoName is the local repository used to check for objects that
+	// already exist locally. If unset, RepoName is used.
+	LocalRepoName string
+
 	// StartHash is the hash of the object to start from
 	StartHash []byte
 
@@ -48,3 +52,11 @@ type GetAncestorArgs struct {
 	// hash is the object hash of the object that owns the packfile.
 	ResultCB func(packfile io.ReadSeekerCloser, hash string) error
 }
+
+// GetLocalRepoName returns the name of the local repository
+func (a *GetAncestorArgs) GetLocalRepoName() string {
+	if a.LocalRepoName != "" {
+		return a.LocalRepoName
+	}
+	return a.RepoName
+}
@@ -0,0 +1,57 @@
+package streamer
+
+import (
+	"github.com/make-os/kit/crypto/ed25519"
+	"github.com/make-os/kit/params"
+	"github.com/make-os/kit/types/core"
+)
+
+// NewRepoAccessAuthorizer returns a RequestAuthorizer that permits requests for
+// objects of a private repository only if the requester is an owner of the
+// repository or its address or push key is in the repository's access allow-list.
+// Current validators and top hosts are also permitted since they sign requests
+// with their validator key and need the objects to process pushes.
+// Requests for objects of public or unknown repositories are permitted.
+func NewRepoAccessAuthorizer(keepers core.Keepers) RequestAuthorizer {
+	return func(repoName string, requester *ed25519.PubKey) error {
+		repo := keepers.RepoKeeper().Get(repoName)
+		if repo.IsEmpty() || repo.Config == nil || !repo.Config.Access.IsPrivate() {
+			return nil
+		}
+
+		if requester == nil {
+			return ErrRequestUnauthorized
+		}
+
+		address := requester.Addr().String()
+		if repo.Owners.Has(address) || repo.Config.Access.IsAllowed(address, requester.PushAddr().String()) {
+			return nil
+		}
+
+		if isValidatorOrTopHost(keepers, requester) {
+			return nil
+		}
+
+		return ErrRequestUnauthorized
+	}
+}
+
+// isValidatorOrTopHost checks whether a public key belongs
+// to a current validator or one of the top hosts.
+func isValidatorOrTopHost(keepers core.Keepers, pubKey *ed25519.PubKey) bool {
+	pk := pubKey.MustBytes32()
+
+	validators, err := keepers.ValidatorKeeper().Get(0)
+	if err == nil {
+		if _, ok := validators[pk]; ok {
+			return true
+		}
+	}
+
+	hosts, err := keepers.GetTicketManager().GetTopHosts(params.NumTopHostsLimit)
+	if err == nil && hosts.Has(pk) {
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,124 @@
+package streamer
+
+import (
+	"math"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// Provider preference policies used to order providers before they are requested
+const (
+	// ProviderPreferenceNone keeps providers in the order they were found
+	ProviderPreferenceNone = "none"
+
+	// ProviderPreferenceLatency prefers providers with the lowest observed latency
+	ProviderPreferenceLatency = "latency"
+
+	// ProviderPreferenceSubnet prefers providers in the same subnet as the local host
+	ProviderPreferenceSubnet = "subnet"
+
+	// ProviderPreferenceTracker prefers providers with the best record in the provider tracker
+	ProviderPreferenceTracker = "tracker"
+)
+
+// IsValidProviderPreference checks whether a provider preference policy is known
+func IsValidProviderPreference(pref string) bool {
+	switch pref {
+	case ProviderPreferenceNone, ProviderPreferenceLatency, ProviderPreferenceSubnet, ProviderPreferenceTracker:
+		return true
+	default:
+		return false
+	}
+}
+
+// SetProviderPreference sets the policy used to order providers
+func (c *BasicObjectStreamer) SetProviderPreference(pref string) {
+	c.providerPreference = pref
+}
+
+// SortProviders orders providers according to the provider preference policy.
+// Providers that are equally preferred keep their relative order.
+func (c *BasicObjectStreamer) SortProviders(providers []peer.AddrInfo) []peer.AddrInfo {
+	switch c.providerPreference {
+	case ProviderPreferenceLatency:
+		ps := c.dht.Host().Peerstore()
+		latency := func(id peer.ID) time.Duration {
+			if l := ps.LatencyEWMA(id); l > 0 {
+				return l
+			}
+			return math.MaxInt64
+		}
+		sort.SliceStable(providers, func(i, j int) bool {
+			return latency(providers[i].ID) < latency(providers[j].ID)
+		})
+
+	case ProviderPreferenceSubnet:
+		var localIPs []net.IP
+		for _, addr := range c.dht.Host().Addrs() {
+			if ip := getIP(addr); ip != nil {
+				localIPs = append(localIPs, ip)
+			}
+		}
+		inSubnet := make(map[peer.ID]bool)
+		for _, p := range providers {
+			inSubnet[p.ID] = isInLocalSubnet(p, localIPs)
+		}
+		sort.SliceStable(providers, func(i, j int) bool {
+			return inSubnet[providers[i].ID] && !inSubnet[providers[j].ID]
+		})
+
+	case ProviderPreferenceTracker:
+		sort.SliceStable(providers, func(i, j int) bool {
+			a, b := c.tracker.Get(providers[i].ID, nil), c.tracker.Get(providers[j].ID, nil)
+			switch {
+			case a == nil || b == nil:
+				return a != nil && b == nil
+			case a.Failed != b.Failed:
+				return a.Failed < b.Failed
+			default:
+				return a.LastSeen.After(b.LastSeen)
+			}
+		})
+	}
+
+	return providers
+}
+
+// getIP returns the IP address of a multiaddress or nil if it has none
+func getIP(addr multiaddr.Multiaddr) net.IP {
+	if v, err := addr.ValueForProtocol(multiaddr.P_IP4); err == nil {
+		return net.ParseIP(v)
+	}
+	if v, err := addr.ValueForProtocol(multiaddr.P_IP6); err == nil {
+		return net.ParseIP(v)
+	}
+	return nil
+}
+
+// isInLocalSubnet checks whether any address of a provider shares a /24 (IPv4)
+// or /64 (IPv6) subnet with any of the local IP addresses.
+func isInLocalSubnet(p peer.AddrInfo, localIPs []net.IP) bool {
+	for _, addr := range p.Addrs {
+		ip := getIP(addr)
+		if ip == nil {
+			continue
+		}
+		mask := net.CIDRMask(64, 128)
+		if ip.To4() != nil {
+			mask = net.CIDRMask(24, 32)
+		}
+		for _, local := range localIPs {
+			if (ip.To4() == nil) != (local.To4() == nil) {
+				continue
+			}
+			if ip.Mask(mask).Equal(local.Mask(mask)) {
+				return true
+			}
+		}
+	}
+	return false
+}
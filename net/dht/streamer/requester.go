@@ -12,6 +12,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/peerstore"
 	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/make-os/kit/crypto/ed25519"
 	dht2 "github.com/make-os/kit/net/dht"
 	"github.com/make-os/kit/pkgs/logger"
 	"github.com/make-os/kit/remote/plumbing"
@@ -76,6 +77,18 @@ type RequestArgs struct {
 
 	// BasicProviderTracker for recording and tracking provider behaviour
 	ProviderTracker dht2.ProviderTracker
+
+	// DialAttempts is the maximum number of times a provider is dialed
+	// before it is abandoned. Values less than 1 mean a single attempt.
+	DialAttempts int
+
+	// DialBackoff is the initial wait time between dial attempts.
+	// It is doubled after every failed attempt.
+	DialBackoff time.Duration
+
+	// SigningKey is used to sign requests so providers can identify the
+	// requester. Requests are not signed when unset.
+	SigningKey *ed25519.Key
 }
 
 // BasicObjectRequester manages object download sessions between multiple providers
@@ -90,6 +103,9 @@ type BasicObjectRequester struct {
 	closed                bool
 	tracker               dht2.ProviderTracker
 	providerStreams       []network.Stream
+	dialAttempts          int
+	dialBackoff           time.Duration
+	signingKey            *ed25519.Key
 	OnWantResponseHandler func(network.Stream) error
 	OnSendResponseHandler func(network.Stream) (io.ReadSeekerCloser, error)
 }
@@ -97,14 +113,21 @@ type BasicObjectRequester struct {
 // NewBasicObjectRequester creates an instance of BasicObjectRequester
 func NewBasicObjectRequester(args RequestArgs) *BasicObjectRequester {
 	r := BasicObjectRequester{
-		lck:       &sync.Mutex{},
-		providers: args.Providers,
-		repoName:  args.RepoName,
-		key:       args.Key,
-		host:      args.Host,
-		log:       args.Log,
-		reposDir:  args.ReposDir,
-		tracker:   args.ProviderTracker,
+		lck:          &sync.Mutex{},
+		providers:    args.Providers,
+		repoName:     args.RepoName,
+		key:          args.Key,
+		host:         args.Host,
+		log:          args.Log,
+		reposDir:     args.ReposDir,
+		tracker:      args.ProviderTracker,
+		dialAttempts: args.DialAttempts,
+		dialBackoff:  args.DialBackoff,
+		signingKey:   args.SigningKey,
+	}
+
+	if r.dialAttempts < 1 {
+		r.dialAttempts = 1
 	}
 
 	r.OnWantResponseHandler = r.OnWantResponse
@@ -122,10 +145,38 @@ func (r *BasicObjectRequester) AddProviderStream(streams ...network.Stream) {
 	r.providerStreams = append(r.providerStreams, streams...)
 }
 
+// newStream opens a stream to a provider. If the provider cannot be dialed,
+// it is retried up to r.dialAttempts times, waiting for an exponentially
+// increasing backoff between attempts.
+func (r *BasicObjectRequester) newStream(ctx context.Context, id peer.ID, pid protocol.ID) (network.Stream, error) {
+	backoff := r.dialBackoff
+	for attempt := 1; ; attempt++ {
+		str, err := r.host.NewStream(ctx, id, pid)
+		if err == nil {
+			return str, nil
+		}
+
+		if attempt >= r.dialAttempts {
+			return nil, err
+		}
+
+		r.log.Debug("Failed to dial provider; retrying", "Peer", id.Pretty(),
+			"Attempt", attempt, "Backoff", backoff.String(), "Err", err.Error())
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
 // Write writes a message to a provider
 func (r *BasicObjectRequester) Write(ctx context.Context, prov peer.AddrInfo, pid protocol.ID, data []byte) (network.Stream, error) {
 	r.host.Peerstore().AddAddr(prov.ID, prov.Addrs[0], peerstore.ProviderAddrTTL)
-	str, err := r.host.NewStream(ctx, prov.ID, pid)
+	str, err := r.newStream(ctx, prov.ID, pid)
 	if err != nil {
 		return nil, err
 	}
@@ -164,7 +215,15 @@ func (r *BasicObjectRequester) DoWant(ctx context.Context) (err error) {
 
 		// Send 'WANT' message to provider
 		var s network.Stream
-		s, err = r.Write(ctx, prov, ObjectStreamerProtocolID, dht2.MakeWantMsg(r.repoName, r.key))
+		msg := dht2.MakeWantMsg(r.repoName, r.key)
+		if r.signingKey != nil {
+			if msg, err = dht2.SignRequestMsg(msg, r.signingKey, prov.ID); err != nil {
+				wg.Done()
+				r.log.Error("Unable to sign `WANT` message", "Peer", prov.ID.Pretty(), "Err", err)
+				continue
+			}
+		}
+		s, err = r.Write(ctx, prov, ObjectStreamerProtocolID, msg)
 		if err != nil {
 			wg.Done()
 			r.log.Error("Unable to write `WANT` message to peer", "Peer", prov.ID.Pretty(), "Err", err)
@@ -210,7 +269,15 @@ func (r *BasicObjectRequester) Do(ctx context.Context) (result *PackResult, err
 		}
 
 		// Send a 'SEND' message to the stream.
-		if err = r.WriteToStream(str, dht2.MakeSendMsg(r.repoName, r.key)); err != nil {
+		msg := dht2.MakeSendMsg(r.repoName, r.key)
+		if r.signingKey != nil {
+			if msg, err = dht2.SignRequestMsg(msg, r.signingKey, str.Conn().RemotePeer()); err != nil {
+				str.Reset()
+				r.log.Error("failed to sign 'SEND' message", "Err", err)
+				continue
+			}
+		}
+		if err = r.WriteToStream(str, msg); err != nil {
 			str.Reset()
 			r.log.Error("failed to write 'SEND' message to peer", "Err", err,
 				"Peer", str.Conn().RemotePeer().Pretty())
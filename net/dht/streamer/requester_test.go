@@ -6,6 +6,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	core "github.com/libp2p/go-libp2p-core"
@@ -99,6 +100,43 @@ var _ = Describe("BasicObjectRequester", func() {
 			Expect(err).To(BeNil())
 			Expect(mockStream).To(Equal(stream))
 		})
+
+		It("should retry dialing the provider when the first attempt fails", func() {
+			ctx := context.Background()
+			data := []byte("xyz")
+			prov := peer.AddrInfo{ID: "id", Addrs: []multiaddr.Multiaddr{multiaddr.StringCast("/ip4/127.0.0.1")}}
+
+			mockPeerstore := mocks.NewMockPeerstore(ctrl)
+			mockPeerstore.EXPECT().AddAddr(prov.ID, prov.Addrs[0], peerstore.ProviderAddrTTL)
+			mockHost.EXPECT().Peerstore().Return(mockPeerstore)
+			mockStream := mocks.NewMockStream(ctrl)
+			mockStream.EXPECT().SetDeadline(gomock.Any())
+			mockStream.EXPECT().Write(data).Return(0, nil)
+			gomock.InOrder(
+				mockHost.EXPECT().NewStream(ctx, prov.ID, core.ProtocolID("")).Return(nil, fmt.Errorf("dial error")),
+				mockHost.EXPECT().NewStream(ctx, prov.ID, core.ProtocolID("")).Return(mockStream, nil),
+			)
+
+			r := streamer.NewBasicObjectRequester(streamer.RequestArgs{Host: mockHost, DialAttempts: 2, DialBackoff: time.Millisecond})
+			stream, err := r.Write(context.Background(), prov, "", data)
+			Expect(err).To(BeNil())
+			Expect(mockStream).To(Equal(stream))
+		})
+
+		It("should return error when all dial attempts fail", func() {
+			ctx := context.Background()
+			prov := peer.AddrInfo{ID: "id", Addrs: []multiaddr.Multiaddr{multiaddr.StringCast("/ip4/127.0.0.1")}}
+
+			mockPeerstore := mocks.NewMockPeerstore(ctrl)
+			mockPeerstore.EXPECT().AddAddr(prov.ID, prov.Addrs[0], peerstore.ProviderAddrTTL)
+			mockHost.EXPECT().Peerstore().Return(mockPeerstore)
+			mockHost.EXPECT().NewStream(ctx, prov.ID, core.ProtocolID("")).Return(nil, fmt.Errorf("dial error")).Times(3)
+
+			r := streamer.NewBasicObjectRequester(streamer.RequestArgs{Host: mockHost, DialAttempts: 3, DialBackoff: time.Millisecond})
+			_, err := r.Write(context.Background(), prov, "", nil)
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError("dial error"))
+		})
 	})
 
 	Describe(".WriteToStream", func() {
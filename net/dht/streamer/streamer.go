@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"sync"
 	"time"
 
 	plumb "github.com/go-git/go-git/v5/plumbing"
@@ -14,13 +15,16 @@ import (
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/protocol"
 	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/crypto/ed25519"
 	dht3 "github.com/make-os/kit/net/dht"
 	"github.com/make-os/kit/net/dht/providertracker"
 	"github.com/make-os/kit/pkgs/logger"
 	"github.com/make-os/kit/remote/plumbing"
 	"github.com/make-os/kit/remote/repo"
 	types2 "github.com/make-os/kit/types"
+	"github.com/make-os/kit/types/core"
 	"github.com/make-os/kit/util/io"
+	"github.com/olebedev/emitter"
 	"github.com/pkg/errors"
 	"github.com/thoas/go-funk"
 )
@@ -28,39 +32,105 @@ import (
 var (
 	ErrNoProviderFound        = fmt.Errorf("no provider found")
 	ErrEndObjMustExistLocally = fmt.Errorf("end object must already exist in the local repo")
+	ErrTooManyStreams         = fmt.Errorf("too many concurrent streams from peer")
+	ErrRequestUnauthorized    = fmt.Errorf("requester is not authorized")
+	ErrDraining               = fmt.Errorf("streamer is draining; not accepting new streams")
+	ErrDrainTimeout           = fmt.Errorf("timed out waiting for in-flight streams to finish")
 )
 
 var (
 	ObjectStreamerProtocolID = protocol.ID("/object/1.0")
 )
 
+// MaxConcurrentPrefetches is the maximum number of prefetches that can run
+// at the same time. Prefetches are skipped while the limit is reached.
+const MaxConcurrentPrefetches = 2
+
+type noPrefetchKey struct{}
+
+// WithoutPrefetch returns a copy of ctx that disables prefetching for
+// commits fetched with it. It is used by callers that fetch the
+// ancestors of a commit themselves.
+func WithoutPrefetch(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noPrefetchKey{}, true)
+}
+
+// RequestAuthorizer checks whether a requester may request objects of a repository.
+// requester is nil when the request was not signed.
+type RequestAuthorizer func(repoName string, requester *ed25519.PubKey) error
+
 // BasicObjectStreamer implements Streamer. It provides a mechanism for
 // announcing or transferring repository objects to/from the DHT.
 type BasicObjectStreamer struct {
-	dht              dht3.DHT
-	log              logger.Logger
-	reposDir         string
-	gitBinPath       string
-	tracker          dht3.ProviderTracker
-	OnWantHandler    WantSendHandler
-	OnSendHandler    WantSendHandler
-	RepoGetter       repo.GetLocalRepoFunc
-	PackObject       plumbing.CommitPacker
-	MakeRequester    MakeObjectRequester
-	PackObjectGetter plumbing.PackObjectFinder
+	dht                dht3.DHT
+	log                logger.Logger
+	bus                *emitter.Emitter
+	reposDir           string
+	gitBinPath         string
+	tracker            dht3.ProviderTracker
+	dialAttempts       int
+	dialBackoff        time.Duration
+	streamLimiter      *peerStreamLimiter
+	providerPreference string
+	packDeltaWindow    uint
+	signingKey         *ed25519.Key
+	serveLimiter       *RateLimiter
+	streamServeRate    int64
+	prefetchDepth      int
+	prefetchCtx        context.Context
+	stopPrefetch       context.CancelFunc
+	prefetchSem        chan struct{}
+	inflight           sync.Map
+	drainLck           sync.Mutex
+	draining           bool
+	activeStreams      sync.WaitGroup
+	OnWantHandler      WantSendHandler
+	OnSendHandler      WantSendHandler
+	RepoGetter         repo.GetLocalRepoFunc
+	PackObject         plumbing.CommitPacker
+	MakeRequester      MakeObjectRequester
+	PackObjectGetter   plumbing.PackObjectFinder
+	AuthorizeRequest   RequestAuthorizer
 }
 
 // NewStreamer creates an instance of BasicObjectStreamer
 func NewStreamer(dht dht3.DHT, cfg *config.AppConfig) *BasicObjectStreamer {
 	ce := &BasicObjectStreamer{
-		dht:              dht,
-		reposDir:         cfg.GetRepoRoot(),
-		log:              cfg.G().Log.Module("object-streamer"),
-		gitBinPath:       cfg.Node.GitBinPath,
-		tracker:          providertracker.New(),
-		RepoGetter:       repo.GetWithGitModule,
-		PackObject:       plumbing.PackObject,
-		PackObjectGetter: plumbing.GetObjectFromPack,
+		dht:                dht,
+		reposDir:           cfg.GetRepoRoot(),
+		log:                cfg.G().Log.Module("object-streamer"),
+		bus:                cfg.G().Bus,
+		gitBinPath:         cfg.Node.GitBinPath,
+		tracker:            providertracker.New(),
+		dialAttempts:       cfg.DHT.DialAttempts,
+		dialBackoff:        cfg.DHT.DialBackoff,
+		streamLimiter:      newPeerStreamLimiter(cfg.DHT.MaxStreamsPerPeer),
+		providerPreference: cfg.DHT.ProviderPreference,
+		packDeltaWindow:    cfg.DHT.PackDeltaWindow,
+		serveLimiter:       NewRateLimiter(cfg.DHT.MaxServeRate),
+		streamServeRate:    cfg.DHT.MaxStreamServeRate,
+		prefetchDepth:      cfg.DHT.PrefetchDepth,
+		prefetchSem:        make(chan struct{}, MaxConcurrentPrefetches),
+		RepoGetter:         repo.GetWithGitModule,
+		PackObject:         plumbing.PackObject,
+		PackObjectGetter:   plumbing.GetObjectFromPack,
+	}
+
+	ce.prefetchCtx, ce.stopPrefetch = context.WithCancel(context.Background())
+
+	if !IsValidProviderPreference(ce.providerPreference) {
+		ce.log.Warn("Unknown provider preference; providers will not be ordered",
+			"Preference", ce.providerPreference)
+		ce.providerPreference = ProviderPreferenceNone
+	}
+
+	// Sign object requests using the node's validator key if enabled
+	if cfg.DHT.SignRequests && cfg.G().PrivVal != nil {
+		key, err := cfg.G().PrivVal.GetKey()
+		if err != nil {
+			ce.log.Error("Unable to get key for signing requests", "Err", err.Error())
+		}
+		ce.signingKey = key
 	}
 
 	// Hook concrete functions to function type fields
@@ -72,6 +142,73 @@ func NewStreamer(dht dht3.DHT, cfg *config.AppConfig) *BasicObjectStreamer {
 	return ce
 }
 
+// SetSigningKey sets the key used to sign object requests.
+// Requests are not signed when key is nil.
+func (c *BasicObjectStreamer) SetSigningKey(key *ed25519.Key) {
+	c.signingKey = key
+}
+
+// SetServeRate sets the maximum number of bytes per second written when
+// serving objects to all peers (nodeRate) and on a single stream (streamRate).
+// Zero means no limit.
+func (c *BasicObjectStreamer) SetServeRate(nodeRate, streamRate int64) {
+	c.serveLimiter = NewRateLimiter(nodeRate)
+	c.streamServeRate = streamRate
+}
+
+// SetPrefetchDepth sets the number of ancestor generations of a fetched
+// commit to prefetch in the background. Zero disables prefetching.
+func (c *BasicObjectStreamer) SetPrefetchDepth(depth int) {
+	c.prefetchDepth = depth
+}
+
+// StopPrefetch cancels in-flight prefetches. Prefetching is not
+// started for commits fetched after it is called.
+func (c *BasicObjectStreamer) StopPrefetch() {
+	c.stopPrefetch()
+}
+
+// Drain stops accepting new object streams and waits for the in-flight
+// streams to finish. It returns ErrDrainTimeout if the streams did not
+// finish before timeout elapsed. Zero timeout does not wait.
+func (c *BasicObjectStreamer) Drain(timeout time.Duration) error {
+	c.drainLck.Lock()
+	c.draining = true
+	c.drainLck.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		c.activeStreams.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+		return ErrDrainTimeout
+	}
+}
+
+// IsDraining checks whether the streamer has stopped accepting new streams
+func (c *BasicObjectStreamer) IsDraining() bool {
+	c.drainLck.Lock()
+	defer c.drainLck.Unlock()
+	return c.draining
+}
+
+// beginStream registers an in-flight stream.
+// It returns false if the streamer is draining.
+func (c *BasicObjectStreamer) beginStream() bool {
+	c.drainLck.Lock()
+	defer c.drainLck.Unlock()
+	if c.draining {
+		return false
+	}
+	c.activeStreams.Add(1)
+	return true
+}
+
 // SetProviderTracker overwrites the default provider tracker.
 func (c *BasicObjectStreamer) SetProviderTracker(t dht3.ProviderTracker) {
 	c.tracker = t
@@ -83,9 +220,6 @@ func (c *BasicObjectStreamer) SetProviderTracker(t dht3.ProviderTracker) {
 // It also finds providers that have announce their ability to provide a
 // repository - these providers are used as fallback in cases where an object
 // may exist in a repository but not announced.
-//
-// TODO: In the future, we should sort the providers by rank such that hosts,
-//  popular remotes and good-behaved providers are prioritized.
 func (c *BasicObjectStreamer) GetProviders(ctx context.Context, repoName string, objKey []byte) ([]peer.AddrInfo, error) {
 
 	// First, get providers that can provide the target object
@@ -120,11 +254,102 @@ func (c *BasicObjectStreamer) GetProviders(ctx context.Context, repoName string,
 
 // GetCommit gets a single commit by hash.
 // It returns the packfile, the commit object and error.
+//
+// If prefetching is enabled, ancestors of the commit that do not exist
+// locally are fetched in the background into the local repository,
+// unless ctx was created with WithoutPrefetch.
 func (c *BasicObjectStreamer) GetCommit(
 	ctx context.Context,
 	repoName string,
 	hash []byte) (io.ReadSeekerCloser, *object.Commit, error) {
 
+	pack, commit, err := c.getCommit(ctx, repoName, hash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if c.prefetchDepth > 0 && c.prefetchCtx.Err() == nil && ctx.Value(noPrefetchKey{}) == nil {
+		select {
+		case c.prefetchSem <- struct{}{}:
+			go func() {
+				defer func() { <-c.prefetchSem }()
+				c.prefetch(repoName, commit.ParentHashes, c.prefetchDepth)
+			}()
+		default:
+			c.log.Debug("Too many prefetches in progress; skipped prefetch", "Hash", commit.ID().String())
+		}
+	}
+
+	return pack, commit, nil
+}
+
+// prefetch fetches the given commits and their ancestors, up to depth
+// generations, and unpacks them into the local repository. Commits that
+// already exist locally or are currently being fetched are skipped. It stops when StopPrefetch is called.
+// EvtRepoUpdated is emitted if any commit was unpacked.
+func (c *BasicObjectStreamer) prefetch(repoName string, wantlist []plumb.Hash, depth int) {
+	repoPath := filepath.Join(c.reposDir, repoName)
+	r, err := c.RepoGetter(c.gitBinPath, repoPath)
+	if err != nil {
+		c.log.Debug("Unable to get repo for prefetching", "Repo", repoName, "Err", err.Error())
+		return
+	}
+
+	var unpacked bool
+	defer func() {
+		if unpacked && c.bus != nil {
+			c.bus.Emit(core.EvtRepoUpdated, repoName, repoPath)
+		}
+	}()
+
+	seen := map[plumb.Hash]struct{}{}
+	for ; depth > 0 && len(wantlist) > 0; depth-- {
+		var next []plumb.Hash
+		for _, hash := range wantlist {
+			if c.prefetchCtx.Err() != nil {
+				return
+			}
+
+			if _, ok := seen[hash]; ok || r.ObjectExist(hash.String()) {
+				continue
+			}
+			if _, ok := c.inflight.Load(hash.String()); ok {
+				continue
+			}
+			seen[hash] = struct{}{}
+
+			pack, commit, err := c.getCommit(c.prefetchCtx, repoName, hash[:])
+			if err != nil {
+				c.log.Debug("Failed to prefetch commit", "Hash", hash.String(), "Err", err.Error())
+				continue
+			}
+
+			err = plumbing.UnpackPackfileToRepo(r, pack)
+			pack.Close()
+			if err != nil {
+				c.log.Debug("Failed to unpack prefetched commit", "Hash", hash.String(), "Err", err.Error())
+				continue
+			}
+			unpacked = true
+
+			next = append(next, commit.ParentHashes...)
+		}
+		wantlist = next
+	}
+}
+
+// getCommit fetches a single commit by hash from the network.
+func (c *BasicObjectStreamer) getCommit(
+	ctx context.Context,
+	repoName string,
+	hash []byte) (io.ReadSeekerCloser, *object.Commit, error) {
+
+	// Track the commit as in-flight so prefetches do not fetch it again
+	key := plumbing.BytesToHex(hash)
+	if _, loaded := c.inflight.LoadOrStore(key, struct{}{}); !loaded {
+		defer c.inflight.Delete(key)
+	}
+
 	// Find providers of the object
 	providers, err := c.GetProviders(ctx, repoName, hash)
 	if err != nil {
@@ -142,6 +367,9 @@ func (c *BasicObjectStreamer) GetCommit(
 		return nil, nil, ErrNoProviderFound
 	}
 
+	// Order the providers according to the provider preference policy
+	providers = c.SortProviders(providers)
+
 	// Register the providers we can track its behaviour over time.
 	c.tracker.Register(providers...)
 
@@ -154,6 +382,9 @@ func (c *BasicObjectStreamer) GetCommit(
 		Log:             c.log,
 		ReposDir:        c.reposDir,
 		ProviderTracker: c.tracker,
+		DialAttempts:    c.dialAttempts,
+		DialBackoff:     c.dialBackoff,
+		SigningKey:      c.signingKey,
 	})
 
 	// Do the request
@@ -196,9 +427,12 @@ func GetCommitWithAncestors(
 	repoGetter repo.GetLocalRepoFunc,
 	args dht3.GetAncestorArgs) (packfiles []io.ReadSeekerCloser, err error) {
 
+	// Ancestors are fetched here; prefetching them would fetch them twice.
+	ctx = WithoutPrefetch(ctx)
+
 	// Get the target repo
 	var r plumbing.LocalRepo
-	r, err = repoGetter(args.GitBinPath, filepath.Join(args.ReposDir, args.RepoName))
+	r, err = repoGetter(args.GitBinPath, filepath.Join(args.ReposDir, args.GetLocalRepoName()))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get repo")
 	}
@@ -380,6 +614,9 @@ func (c *BasicObjectStreamer) GetTag(
 		Log:             c.log,
 		ReposDir:        c.reposDir,
 		ProviderTracker: c.tracker,
+		DialAttempts:    c.dialAttempts,
+		DialBackoff:     c.dialBackoff,
+		SigningKey:      c.signingKey,
 	})
 
 	// Do the request
@@ -428,7 +665,7 @@ func GetTaggedCommitWithAncestors(
 
 	// Get the target repo
 	var r plumbing.LocalRepo
-	r, err = repoGetter(args.GitBinPath, filepath.Join(args.ReposDir, args.RepoName))
+	r, err = repoGetter(args.GitBinPath, filepath.Join(args.ReposDir, args.GetLocalRepoName()))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get repo")
 	}
@@ -555,25 +792,74 @@ func (c *BasicObjectStreamer) Handler(s network.Stream) {
 	}
 }
 
+// NumRejectedStreams returns the number of streams from a peer that
+// were reset because the peer had too many streams in-flight.
+func (c *BasicObjectStreamer) NumRejectedStreams(id peer.ID) int {
+	return c.streamLimiter.numRejected(id)
+}
+
 // OnRequest handles incoming commit object requests
 func (c *BasicObjectStreamer) OnRequest(s network.Stream) (bool, error) {
 
+	// Reject the stream if the streamer is shutting down
+	if !c.beginStream() {
+		_ = s.Reset()
+		return false, ErrDraining
+	}
+	defer c.activeStreams.Done()
+
+	// Reject the stream if the remote peer has too many streams in-flight
+	remotePeer := s.Conn().RemotePeer()
+	if !c.streamLimiter.acquire(remotePeer) {
+		_ = s.Reset()
+		c.log.Debug("Rejected stream; too many concurrent streams", "Peer", remotePeer.Pretty())
+		return false, ErrTooManyStreams
+	}
+	defer c.streamLimiter.release(remotePeer)
+
 	// Get request message
-	msgType, repoName, hash, err := dht3.ReadWantOrSendMsg(s)
+	req, err := dht3.ReadRequest(s)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to read request")
 	}
 
-	switch msgType {
+	if req.Type != dht3.MsgTypeWant && req.Type != dht3.MsgTypeSend {
+		return false, ErrUnknownMsgType
+	}
+
+	// Verify the requester's signature if the request is signed
+	var requester *ed25519.PubKey
+	if req.IsSigned() {
+		if requester, err = req.Verify(s.Conn().LocalPeer()); err != nil {
+			_ = s.Reset()
+			c.log.Debug("Rejected request; bad signature", "Peer", remotePeer.Pretty())
+			return false, err
+		}
+	}
+
+	// Check whether the requester may request objects of the repository.
+	// Respond with 'NOPE' so unauthorized requesters cannot learn what the node hosts.
+	if c.AuthorizeRequest != nil {
+		if err := c.AuthorizeRequest(req.RepoName, requester); err != nil {
+			c.log.Debug("Rejected unauthorized request", "Peer", remotePeer.Pretty(),
+				"Repo", req.RepoName, "Err", err.Error())
+			if _, err := s.Write(dht3.MakeNopeMsg()); err != nil {
+				return false, errors.Wrap(err, "failed to write 'nope' message")
+			}
+			return false, ErrRequestUnauthorized
+		}
+	}
+
+	switch req.Type {
 
 	// Handle 'want' message
 	case dht3.MsgTypeWant:
-		err := c.OnWantHandler(repoName, hash, s)
+		err := c.OnWantHandler(req.RepoName, req.Hash, s)
 		return false, err
 
 	// Handle 'send' message
 	case dht3.MsgTypeSend:
-		err := c.OnSendHandler(repoName, hash, s)
+		err := c.OnSendHandler(req.RepoName, req.Hash, s)
 		return err == nil, err
 
 	default:
@@ -665,14 +951,14 @@ func (c *BasicObjectStreamer) OnSendRequest(repo string, hash []byte, s network.
 		"Peer", remotePeerID)
 
 	// Get the packfile representation of the object.
-	pack, objs, err := c.PackObject(r, &plumbing.PackObjectArgs{Obj: obj})
+	pack, objs, err := c.PackObject(r, &plumbing.PackObjectArgs{Obj: obj, DeltaWindow: c.packDeltaWindow})
 	if err != nil {
 		_ = s.Reset()
 		return errors.Wrap(err, "failed to generate commit packfile")
 	}
 
-	// Write the packfile to the requester
-	w := bufio.NewWriter(bufio.NewWriter(s))
+	// Write the packfile to the requester, throttled to the serving rate caps
+	w := bufio.NewWriter(NewThrottledWriter(s, c.serveLimiter, NewRateLimiter(c.streamServeRate)))
 	if _, err := w.ReadFrom(pack); err != nil {
 		_ = s.Reset()
 		c.log.Error("failed to Write commit pack", "Err", err)
@@ -686,3 +972,53 @@ func (c *BasicObjectStreamer) OnSendRequest(repo string, hash []byte, s network.
 
 	return nil
 }
+
+// peerStreamLimiter is a per-peer counting semaphore that limits
+// the number of streams a peer can have in-flight concurrently.
+type peerStreamLimiter struct {
+	lck      sync.Mutex
+	max      int
+	active   map[peer.ID]int
+	rejected map[peer.ID]int
+}
+
+// newPeerStreamLimiter creates an instance of peerStreamLimiter.
+// max is the maximum number of in-flight streams per peer; Zero means no limit.
+func newPeerStreamLimiter(max int) *peerStreamLimiter {
+	return &peerStreamLimiter{
+		max:      max,
+		active:   make(map[peer.ID]int),
+		rejected: make(map[peer.ID]int),
+	}
+}
+
+// acquire takes a stream slot for the given peer.
+// It returns false and records the rejection if the peer has no free slot.
+func (l *peerStreamLimiter) acquire(id peer.ID) bool {
+	l.lck.Lock()
+	defer l.lck.Unlock()
+	if l.max > 0 && l.active[id] >= l.max {
+		l.rejected[id]++
+		return false
+	}
+	l.active[id]++
+	return true
+}
+
+// release frees a stream slot previously taken by the given peer
+func (l *peerStreamLimiter) release(id peer.ID) {
+	l.lck.Lock()
+	defer l.lck.Unlock()
+	if l.active[id] <= 1 {
+		delete(l.active, id)
+		return
+	}
+	l.active[id]--
+}
+
+// numRejected returns the number of streams of a peer that were rejected
+func (l *peerStreamLimiter) numRejected(id peer.ID) int {
+	l.lck.Lock()
+	defer l.lck.Unlock()
+	return l.rejected[id]
+}
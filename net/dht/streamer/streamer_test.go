@@ -7,7 +7,9 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	plumb "github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
@@ -15,13 +17,19 @@ import (
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/crypto/ed25519"
 	"github.com/make-os/kit/mocks"
 	dht2 "github.com/make-os/kit/net/dht"
+	"github.com/make-os/kit/net/dht/providertracker"
 	"github.com/make-os/kit/net/dht/streamer"
+	"github.com/make-os/kit/params"
 	"github.com/make-os/kit/remote/plumbing"
 	"github.com/make-os/kit/remote/repo"
 	"github.com/make-os/kit/testutil"
+	tickettypes "github.com/make-os/kit/ticket/types"
 	types2 "github.com/make-os/kit/types"
+	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/state"
 	io2 "github.com/make-os/kit/util/io"
 	"github.com/multiformats/go-multiaddr"
 	. "github.com/onsi/ginkgo"
@@ -88,8 +96,16 @@ var _ = Describe("BasicObjectStreamer", func() {
 	})
 
 	Describe(".OnRequest", func() {
+		var mockConn *mocks.MockConn
+
+		BeforeEach(func() {
+			mockConn = mocks.NewMockConn(ctrl)
+			mockConn.EXPECT().RemotePeer().Return(peer.ID("peer-id")).AnyTimes()
+		})
+
 		It("should return error when unable to read stream", func() {
 			mockStream := mocks.NewMockStream(ctrl)
+			mockStream.EXPECT().Conn().Return(mockConn)
 			mockStream.EXPECT().Read(gomock.Any()).DoAndReturn(func(p []byte) (int, error) {
 				return 0, fmt.Errorf("read error")
 			})
@@ -100,6 +116,7 @@ var _ = Describe("BasicObjectStreamer", func() {
 
 		It("should return ErrUnknownMsgType when message type is unknown", func() {
 			mockStream := mocks.NewMockStream(ctrl)
+			mockStream.EXPECT().Conn().Return(mockConn)
 			mockStream.EXPECT().Read(gomock.Any()).DoAndReturn(func(p []byte) (int, error) {
 				msg := []byte("unknown repo hash")
 				copy(p, msg)
@@ -113,6 +130,7 @@ var _ = Describe("BasicObjectStreamer", func() {
 		It("should call 'Want' handler when message is MsgTypeWant", func() {
 			msg := []byte(dht2.MsgTypeWant + " repo hash")
 			mockStream := mocks.NewMockStream(ctrl)
+			mockStream.EXPECT().Conn().Return(mockConn)
 			mockStream.EXPECT().Read(gomock.Any()).DoAndReturn(func(p []byte) (int, error) {
 				copy(p, msg)
 				return len(msg), nil
@@ -128,6 +146,7 @@ var _ = Describe("BasicObjectStreamer", func() {
 		It("should call 'Send' handler when message is MsgTypeSend", func() {
 			msg := []byte(dht2.MsgTypeSend + " repo hash")
 			mockStream := mocks.NewMockStream(ctrl)
+			mockStream.EXPECT().Conn().Return(mockConn)
 			mockStream.EXPECT().Read(gomock.Any()).DoAndReturn(func(p []byte) (int, error) {
 				copy(p, msg)
 				return len(msg), nil
@@ -140,6 +159,258 @@ var _ = Describe("BasicObjectStreamer", func() {
 			Expect(err).To(BeNil())
 			Expect(success).To(BeTrue())
 		})
+
+		When("the number of concurrent streams per peer is capped", func() {
+			var release chan struct{}
+			var started chan struct{}
+
+			BeforeEach(func() {
+				cfg.DHT.MaxStreamsPerPeer = 2
+				mockHost.EXPECT().SetStreamHandler(gomock.Any(), gomock.Any())
+				mockDHT.EXPECT().Host().Return(mockHost)
+				cs = streamer.NewStreamer(mockDHT, cfg)
+
+				release, started = make(chan struct{}), make(chan struct{}, 10)
+				cs.OnWantHandler = func(repo string, hash []byte, s network.Stream) error {
+					started <- struct{}{}
+					<-release
+					return nil
+				}
+			})
+
+			makeStream := func(peerID string) *mocks.MockStream {
+				msg := []byte(dht2.MsgTypeWant + " repo hash")
+				conn := mocks.NewMockConn(ctrl)
+				conn.EXPECT().RemotePeer().Return(peer.ID(peerID)).AnyTimes()
+				mockStream := mocks.NewMockStream(ctrl)
+				mockStream.EXPECT().Conn().Return(conn).AnyTimes()
+				mockStream.EXPECT().Read(gomock.Any()).DoAndReturn(func(p []byte) (int, error) {
+					copy(p, msg)
+					return len(msg), nil
+				}).AnyTimes()
+				return mockStream
+			}
+
+			It("should reset surplus streams from a peer and not affect other peers", func() {
+				errs := make(chan error, 2)
+				for i := 0; i < 2; i++ {
+					s := makeStream("peer-1")
+					go func() {
+						_, err := cs.OnRequest(s)
+						errs <- err
+					}()
+					<-started
+				}
+
+				surplus := makeStream("peer-1")
+				surplus.EXPECT().Reset()
+				_, err := cs.OnRequest(surplus)
+				Expect(err).To(Equal(streamer.ErrTooManyStreams))
+				Expect(cs.NumRejectedStreams("peer-1")).To(Equal(1))
+
+				other := makeStream("peer-2")
+				go func() { _, _ = cs.OnRequest(other) }()
+				Eventually(started).Should(Receive())
+				Expect(cs.NumRejectedStreams("peer-2")).To(Equal(0))
+
+				close(release)
+				Expect(<-errs).To(BeNil())
+				Expect(<-errs).To(BeNil())
+			})
+
+			It("should accept new streams from a peer after its in-flight streams end", func() {
+				close(release)
+				for i := 0; i < 3; i++ {
+					_, err := cs.OnRequest(makeStream("peer-1"))
+					Expect(err).To(BeNil())
+				}
+				Expect(cs.NumRejectedStreams("peer-1")).To(Equal(0))
+			})
+		})
+
+		When("the streamer is draining", func() {
+			var release chan struct{}
+			var started chan struct{}
+
+			makeStream := func() *mocks.MockStream {
+				msg := []byte(dht2.MsgTypeSend + " repo hash")
+				mockStream := mocks.NewMockStream(ctrl)
+				mockStream.EXPECT().Conn().Return(mockConn).AnyTimes()
+				mockStream.EXPECT().Read(gomock.Any()).DoAndReturn(func(p []byte) (int, error) {
+					copy(p, msg)
+					return len(msg), nil
+				}).AnyTimes()
+				return mockStream
+			}
+
+			BeforeEach(func() {
+				release, started = make(chan struct{}), make(chan struct{}, 10)
+				cs.OnSendHandler = func(repo string, hash []byte, s network.Stream) error {
+					started <- struct{}{}
+					<-release
+					return nil
+				}
+			})
+
+			It("should let an in-flight transfer finish within the deadline and refuse new streams", func() {
+				transferred := make(chan error, 1)
+				go func() {
+					_, err := cs.OnRequest(makeStream())
+					transferred <- err
+				}()
+				<-started
+
+				drained := make(chan error, 1)
+				go func() { drained <- cs.Drain(5 * time.Second) }()
+
+				Eventually(cs.IsDraining).Should(BeTrue())
+				refused := makeStream()
+				refused.EXPECT().Reset()
+				_, err := cs.OnRequest(refused)
+				Expect(err).To(Equal(streamer.ErrDraining))
+				Consistently(drained, 100*time.Millisecond).ShouldNot(Receive())
+
+				close(release)
+				Eventually(transferred).Should(Receive(BeNil()))
+				Eventually(drained).Should(Receive(BeNil()))
+			})
+
+			It("should return ErrDrainTimeout when an in-flight transfer outlives the deadline", func() {
+				defer close(release)
+				go func() { _, _ = cs.OnRequest(makeStream()) }()
+				<-started
+				Expect(cs.Drain(50 * time.Millisecond)).To(Equal(streamer.ErrDrainTimeout))
+			})
+
+			It("should return immediately when there are no in-flight streams", func() {
+				Expect(cs.Drain(time.Second)).To(BeNil())
+			})
+		})
+
+		When("requests are signed", func() {
+			var key = ed25519.NewKeyFromIntSeed(1)
+			var hashBz = plumbing.HashToBytes("d9dbe0e59248c7f0505dd5d80ed470fb43f82521")
+
+			makeStream := func(msg []byte) *mocks.MockStream {
+				mockConn.EXPECT().LocalPeer().Return(peer.ID("provider")).AnyTimes()
+				mockStream := mocks.NewMockStream(ctrl)
+				mockStream.EXPECT().Conn().Return(mockConn).AnyTimes()
+				mockStream.EXPECT().Read(gomock.Any()).DoAndReturn(func(p []byte) (int, error) {
+					copy(p, msg)
+					return len(msg), nil
+				})
+				return mockStream
+			}
+
+			BeforeEach(func() {
+				cs.AuthorizeRequest = func(repoName string, requester *ed25519.PubKey) error {
+					if requester != nil && requester.Addr() == key.Addr() {
+						return nil
+					}
+					return streamer.ErrRequestUnauthorized
+				}
+			})
+
+			It("should call handler when an authorized requester's signature is valid", func() {
+				msg, err := dht2.SignRequestMsg(dht2.MakeWantMsg("repo1", hashBz), key, peer.ID("provider"))
+				Expect(err).To(BeNil())
+				var called bool
+				cs.OnWantHandler = func(repo string, hash []byte, s network.Stream) error {
+					called = true
+					Expect(repo).To(Equal("repo1"))
+					Expect(hash).To(Equal(hashBz))
+					return nil
+				}
+				_, err = cs.OnRequest(makeStream(msg))
+				Expect(err).To(BeNil())
+				Expect(called).To(BeTrue())
+			})
+
+			It("should respond with NOPE when the requester is not authorized", func() {
+				msg, err := dht2.SignRequestMsg(dht2.MakeSendMsg("repo1", hashBz), ed25519.NewKeyFromIntSeed(2), peer.ID("provider"))
+				Expect(err).To(BeNil())
+				cs.OnSendHandler = func(repo string, hash []byte, s network.Stream) error {
+					Fail("handler should not be called")
+					return nil
+				}
+				mockStream := makeStream(msg)
+				mockStream.EXPECT().Write(dht2.MakeNopeMsg()).Return(4, nil)
+				_, err = cs.OnRequest(mockStream)
+				Expect(err).To(Equal(streamer.ErrRequestUnauthorized))
+			})
+
+			It("should respond with NOPE when an unsigned request is not authorized", func() {
+				mockStream := makeStream(dht2.MakeWantMsg("repo1", hashBz))
+				mockStream.EXPECT().Write(dht2.MakeNopeMsg()).Return(4, nil)
+				_, err := cs.OnRequest(mockStream)
+				Expect(err).To(Equal(streamer.ErrRequestUnauthorized))
+			})
+
+			It("should reset the stream when the signature is invalid", func() {
+				msg, err := dht2.SignRequestMsg(dht2.MakeWantMsg("repo1", hashBz), key, peer.ID("other-provider"))
+				Expect(err).To(BeNil())
+				mockStream := makeStream(msg)
+				mockStream.EXPECT().Reset()
+				_, err = cs.OnRequest(mockStream)
+				Expect(err).To(Equal(dht2.ErrInvalidRequestSig))
+			})
+		})
+	})
+
+	Describe(".NewRepoAccessAuthorizer", func() {
+		var mockKeepers *mocks.MockKeepers
+		var mockRepoKeeper *mocks.MockRepoKeeper
+		var mockValidatorKeeper *mocks.MockValidatorKeeper
+		var mockTicketMgr *mocks.MockTicketManager
+		var authorize streamer.RequestAuthorizer
+		var owner, allowed, other = ed25519.NewKeyFromIntSeed(1), ed25519.NewKeyFromIntSeed(2), ed25519.NewKeyFromIntSeed(3)
+		var validator, host = ed25519.NewKeyFromIntSeed(4), ed25519.NewKeyFromIntSeed(5)
+
+		BeforeEach(func() {
+			mockKeepers = mocks.NewMockKeepers(ctrl)
+			mockRepoKeeper = mocks.NewMockRepoKeeper(ctrl)
+			mockValidatorKeeper = mocks.NewMockValidatorKeeper(ctrl)
+			mockTicketMgr = mocks.NewMockTicketManager(ctrl)
+			mockKeepers.EXPECT().RepoKeeper().Return(mockRepoKeeper).AnyTimes()
+			mockKeepers.EXPECT().ValidatorKeeper().Return(mockValidatorKeeper).AnyTimes()
+			mockKeepers.EXPECT().GetTicketManager().Return(mockTicketMgr).AnyTimes()
+			mockValidatorKeeper.EXPECT().Get(int64(0)).Return(core.BlockValidators{
+				validator.PubKey().MustBytes32(): &core.Validator{PubKey: validator.PubKey().MustBytes32()},
+			}, nil).AnyTimes()
+			mockTicketMgr.EXPECT().GetTopHosts(params.NumTopHostsLimit).Return(tickettypes.SelectedTickets{
+				{Ticket: &tickettypes.Ticket{ProposerPubKey: host.PubKey().MustBytes32()}},
+			}, nil).AnyTimes()
+			authorize = streamer.NewRepoAccessAuthorizer(mockKeepers)
+
+			repoState := state.BareRepository()
+			repoState.Owners[owner.Addr().String()] = &state.RepoOwner{}
+			repoState.Config.Access = &state.RepoAccess{Private: true, Allowed: []string{allowed.PushAddr().String()}}
+			mockRepoKeeper.EXPECT().Get("private").Return(repoState).AnyTimes()
+
+			publicRepo := state.BareRepository()
+			publicRepo.Balance = "10"
+			mockRepoKeeper.EXPECT().Get("public").Return(publicRepo).AnyTimes()
+		})
+
+		It("should permit any requester of a public repository", func() {
+			Expect(authorize("public", nil)).To(BeNil())
+			Expect(authorize("public", other.PubKey())).To(BeNil())
+		})
+
+		It("should permit owners and allowed requesters of a private repository", func() {
+			Expect(authorize("private", owner.PubKey())).To(BeNil())
+			Expect(authorize("private", allowed.PubKey())).To(BeNil())
+		})
+
+		It("should permit validators and top hosts to request objects of a private repository", func() {
+			Expect(authorize("private", validator.PubKey())).To(BeNil())
+			Expect(authorize("private", host.PubKey())).To(BeNil())
+		})
+
+		It("should reject unsigned and unauthorized requesters of a private repository", func() {
+			Expect(authorize("private", nil)).To(Equal(streamer.ErrRequestUnauthorized))
+			Expect(authorize("private", other.PubKey())).To(Equal(streamer.ErrRequestUnauthorized))
+		})
 	})
 
 	Describe(".OnWantRequest", func() {
@@ -316,6 +587,29 @@ var _ = Describe("BasicObjectStreamer", func() {
 				err := cs.OnSendRequest(repoName, key, mockStream)
 				Expect(err).To(BeNil())
 			})
+
+			It("should pack the object with the configured delta window", func() {
+				cfg.DHT.PackDeltaWindow = 10
+				mockHost.EXPECT().SetStreamHandler(gomock.Any(), gomock.Any())
+				mockDHT.EXPECT().Host().Return(mockHost)
+				cs = streamer.NewStreamer(mockDHT, cfg)
+				mockStream.EXPECT().Conn().Return(mockConn)
+				mockRepo := mocks.NewMockLocalRepo(ctrl)
+				mockRepo.EXPECT().GetObject(hash.String()).Return(nil, nil)
+				cs.RepoGetter = func(string, string) (plumbing.LocalRepo, error) {
+					return mockRepo, nil
+				}
+				var deltaWindow uint
+				cs.PackObject = func(repo plumbing.LocalRepo, args *plumbing.PackObjectArgs) (io.Reader, []plumb.Hash, error) {
+					deltaWindow = args.DeltaWindow
+					return bytes.NewReader(nil), nil, nil
+				}
+				mockStream.EXPECT().Close()
+
+				err := cs.OnSendRequest("repo1", hash[:], mockStream)
+				Expect(err).To(BeNil())
+				Expect(deltaWindow).To(Equal(uint(10)))
+			})
 		})
 	})
 
@@ -380,6 +674,56 @@ var _ = Describe("BasicObjectStreamer", func() {
 		})
 	})
 
+	Describe(".SortProviders", func() {
+		var prov1, prov2, prov3 peer.AddrInfo
+
+		BeforeEach(func() {
+			prov1 = peer.AddrInfo{ID: "id1", Addrs: []multiaddr.Multiaddr{multiaddr.StringCast("/ip4/10.0.0.1/tcp/9003")}}
+			prov2 = peer.AddrInfo{ID: "id2", Addrs: []multiaddr.Multiaddr{multiaddr.StringCast("/ip4/192.168.1.2/tcp/9003")}}
+			prov3 = peer.AddrInfo{ID: "id3", Addrs: []multiaddr.Multiaddr{multiaddr.StringCast("/ip4/172.16.0.3/tcp/9003")}}
+		})
+
+		It("should keep providers order when preference is none", func() {
+			cs.SetProviderPreference(streamer.ProviderPreferenceNone)
+			res := cs.SortProviders([]peer.AddrInfo{prov1, prov2, prov3})
+			Expect(res).To(Equal([]peer.AddrInfo{prov1, prov2, prov3}))
+		})
+
+		It("should order providers by latency when preference is latency; providers with unknown latency last", func() {
+			cs.SetProviderPreference(streamer.ProviderPreferenceLatency)
+			mockPeerstore := mocks.NewMockPeerstore(ctrl)
+			mockDHT.EXPECT().Host().Return(mockHost)
+			mockHost.EXPECT().Peerstore().Return(mockPeerstore)
+			mockPeerstore.EXPECT().LatencyEWMA(prov1.ID).Return(time.Duration(0)).AnyTimes()
+			mockPeerstore.EXPECT().LatencyEWMA(prov2.ID).Return(200 * time.Millisecond).AnyTimes()
+			mockPeerstore.EXPECT().LatencyEWMA(prov3.ID).Return(50 * time.Millisecond).AnyTimes()
+			res := cs.SortProviders([]peer.AddrInfo{prov1, prov2, prov3})
+			Expect(res).To(Equal([]peer.AddrInfo{prov3, prov2, prov1}))
+		})
+
+		It("should put providers in the local subnet first when preference is subnet", func() {
+			cs.SetProviderPreference(streamer.ProviderPreferenceSubnet)
+			mockDHT.EXPECT().Host().Return(mockHost)
+			mockHost.EXPECT().Addrs().Return([]multiaddr.Multiaddr{
+				multiaddr.StringCast("/ip4/127.0.0.1/tcp/9003"),
+				multiaddr.StringCast("/ip4/172.16.0.100/tcp/9003"),
+			})
+			res := cs.SortProviders([]peer.AddrInfo{prov1, prov2, prov3})
+			Expect(res).To(Equal([]peer.AddrInfo{prov3, prov1, prov2}))
+		})
+
+		It("should order providers by their tracker record when preference is tracker", func() {
+			cs.SetProviderPreference(streamer.ProviderPreferenceTracker)
+			tracker := providertracker.New()
+			cs.SetProviderTracker(tracker)
+			tracker.Register(prov2, prov3)
+			tracker.MarkFailure(prov2.ID)
+			tracker.MarkSeen(prov3.ID)
+			res := cs.SortProviders([]peer.AddrInfo{prov1, prov2, prov3})
+			Expect(res).To(Equal([]peer.AddrInfo{prov3, prov2, prov1}))
+		})
+	})
+
 	Describe(".GetCommit", func() {
 		var ctx = context.Background()
 		var repoName = "repo1"
@@ -428,6 +772,29 @@ var _ = Describe("BasicObjectStreamer", func() {
 			Expect(err).To(Equal(streamer.ErrNoProviderFound))
 		})
 
+		It("should request providers in the order of the provider preference", func() {
+			cs.SetProviderPreference(streamer.ProviderPreferenceLatency)
+			mockPeerstore := mocks.NewMockPeerstore(ctrl)
+			mockDHT.EXPECT().Host().Return(mockHost).Times(2)
+			mockHost.EXPECT().Peerstore().Return(mockPeerstore)
+
+			prov := peer.AddrInfo{ID: "id", Addrs: []multiaddr.Multiaddr{multiaddr.StringCast("/ip4/127.0.0.1")}}
+			prov2 := peer.AddrInfo{ID: "id2", Addrs: []multiaddr.Multiaddr{multiaddr.StringCast("/ip4/127.0.0.2")}}
+			mockPeerstore.EXPECT().LatencyEWMA(prov.ID).Return(100 * time.Millisecond).AnyTimes()
+			mockPeerstore.EXPECT().LatencyEWMA(prov2.ID).Return(10 * time.Millisecond).AnyTimes()
+			mockDHT.EXPECT().GetProviders(ctx, hash[:]).Return([]peer.AddrInfo{prov, prov2}, nil)
+			mockDHT.EXPECT().GetProviders(ctx, []byte(repoName)).Return(nil, nil)
+
+			mockReq := mocks.NewMockObjectRequester(ctrl)
+			mockReq.EXPECT().Do(ctx).Return(nil, fmt.Errorf("request error"))
+			cs.MakeRequester = func(args streamer.RequestArgs) streamer.ObjectRequester {
+				Expect(args.Providers).To(Equal([]peer.AddrInfo{prov2, prov}))
+				return mockReq
+			}
+			_, _, err := cs.GetCommit(ctx, repoName, hash[:])
+			Expect(err).To(MatchError("request failed: request error"))
+		})
+
 		It("should return error when request failed", func() {
 			mockDHT.EXPECT().Host().Return(mockHost)
 
@@ -508,6 +875,81 @@ var _ = Describe("BasicObjectStreamer", func() {
 			Expect(err).To(BeNil())
 			Expect(res).To(Equal(pack))
 		})
+
+		When("prefetching", func() {
+			var prov peer.AddrInfo
+			var pack *os.File
+			var requested chan []byte
+
+			BeforeEach(func() {
+				prov = peer.AddrInfo{ID: "id", Addrs: []multiaddr.Multiaddr{multiaddr.StringCast("/ip4/127.0.0.1")}}
+				mockDHT.EXPECT().Host().Return(mockHost).AnyTimes()
+				mockDHT.EXPECT().GetProviders(gomock.Any(), gomock.Any()).Return([]peer.AddrInfo{prov}, nil).AnyTimes()
+
+				pack, err = ioutil.TempFile(os.TempDir(), "")
+				Expect(err).To(BeNil())
+
+				requested = make(chan []byte, 10)
+				cs.MakeRequester = func(args streamer.RequestArgs) streamer.ObjectRequester {
+					requested <- args.Key
+					mockReq := mocks.NewMockObjectRequester(ctrl)
+					if bytes.Equal(args.Key, hash[:]) {
+						mockReq.EXPECT().Do(gomock.Any()).Return(&streamer.PackResult{Pack: pack}, nil)
+					} else {
+						mockReq.EXPECT().Do(gomock.Any()).Return(nil, fmt.Errorf("request error"))
+					}
+					return mockReq
+				}
+				commit := object.Commit{Hash: hash, ParentHashes: []plumb.Hash{parentHash}}
+				cs.PackObjectGetter = func(io.ReadSeeker, string) (res object.Object, err error) {
+					return &commit, nil
+				}
+
+				mockRepo := mocks.NewMockLocalRepo(ctrl)
+				mockRepo.EXPECT().ObjectExist(parentHash.String()).Return(false).AnyTimes()
+				cs.RepoGetter = func(string, string) (plumbing.LocalRepo, error) {
+					return mockRepo, nil
+				}
+			})
+
+			AfterEach(func() {
+				cs.StopPrefetch()
+				pack.Close()
+			})
+
+			It("should request the ancestors of the commit when prefetch depth is set", func() {
+				cs.SetPrefetchDepth(1)
+				_, _, err := cs.GetCommit(ctx, repoName, hash[:])
+				Expect(err).To(BeNil())
+				Expect(<-requested).To(Equal(hash[:]))
+				Eventually(requested).Should(Receive(Equal(parentHash[:])))
+			})
+
+			It("should not request the ancestors of the commit when prefetch is disabled", func() {
+				cs.SetPrefetchDepth(0)
+				_, _, err := cs.GetCommit(ctx, repoName, hash[:])
+				Expect(err).To(BeNil())
+				Expect(<-requested).To(Equal(hash[:]))
+				Consistently(requested, 200*time.Millisecond).ShouldNot(Receive())
+			})
+
+			It("should not request the ancestors of the commit when the caller disabled prefetch", func() {
+				cs.SetPrefetchDepth(1)
+				_, _, err := cs.GetCommit(streamer.WithoutPrefetch(ctx), repoName, hash[:])
+				Expect(err).To(BeNil())
+				Expect(<-requested).To(Equal(hash[:]))
+				Consistently(requested, 200*time.Millisecond).ShouldNot(Receive())
+			})
+
+			It("should not request the ancestors of the commit when prefetch was stopped", func() {
+				cs.SetPrefetchDepth(1)
+				cs.StopPrefetch()
+				_, _, err := cs.GetCommit(ctx, repoName, hash[:])
+				Expect(err).To(BeNil())
+				Expect(<-requested).To(Equal(hash[:]))
+				Consistently(requested, 200*time.Millisecond).ShouldNot(Receive())
+			})
+		})
 	})
 
 	Describe(".GetTag", func() {
@@ -825,6 +1267,16 @@ var _ = Describe("BasicObjectStreamer", func() {
 			Expect(err).To(MatchError("failed to get repo: error"))
 		})
 
+		It("should get the local repository by LocalRepoName when set", func() {
+			cs := mocks.NewMockStreamer(ctrl)
+			_, err := streamer.GetCommitWithAncestors(ctx, cs, func(gitBinPath, path string) (plumbing.LocalRepo, error) {
+				Expect(path).To(Equal(filepath.Join("/repos", "fork1")))
+				return nil, fmt.Errorf("error")
+			}, dht2.GetAncestorArgs{RepoName: repoName, LocalRepoName: "fork1", ReposDir: "/repos"})
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError("failed to get repo: error"))
+		})
+
 		When("end commit hash is provided", func() {
 			It("should return error if end commit object does not exist locally", func() {
 				cs := mocks.NewMockStreamer(ctrl)
@@ -879,7 +1331,7 @@ var _ = Describe("BasicObjectStreamer", func() {
 
 				// Mock expectations for parent
 				mockRepo.EXPECT().CommitObject(parentHash).Return(nil, plumb.ErrObjectNotFound)
-				cs.EXPECT().GetCommit(ctx, repoName, parentHash[:]).Return(&fakePackfile{}, &object.Commit{}, nil)
+				cs.EXPECT().GetCommit(gomock.Any(), repoName, parentHash[:]).Return(&fakePackfile{}, &object.Commit{}, nil)
 
 				packfiles, err := streamer.GetCommitWithAncestors(ctx, cs, func(gitBinPath, path string) (plumbing.LocalRepo, error) {
 					return mockRepo, nil
@@ -898,7 +1350,7 @@ var _ = Describe("BasicObjectStreamer", func() {
 			mockRepo := mocks.NewMockLocalRepo(ctrl)
 
 			mockRepo.EXPECT().CommitObject(hash).Return(nil, plumb.ErrObjectNotFound)
-			cs.EXPECT().GetCommit(ctx, repoName, hash[:]).Return(nil, nil, fmt.Errorf("error"))
+			cs.EXPECT().GetCommit(gomock.Any(), repoName, hash[:]).Return(nil, nil, fmt.Errorf("error"))
 
 			_, err := streamer.GetCommitWithAncestors(ctx, cs, func(gitBinPath, path string) (plumbing.LocalRepo, error) {
 				return mockRepo, nil
@@ -918,7 +1370,7 @@ var _ = Describe("BasicObjectStreamer", func() {
 				startCommitPackfile := &fakePackfile{"pack-1"}
 				mockRepo.EXPECT().ObjectExist(hash.String()).Return(true)
 				mockRepo.EXPECT().CommitObject(hash).Return(nil, plumb.ErrObjectNotFound)
-				cs.EXPECT().GetCommit(ctx, repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
+				cs.EXPECT().GetCommit(gomock.Any(), repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
 
 				packfiles, err := streamer.GetCommitWithAncestors(ctx, cs, func(gitBinPath, path string) (plumbing.LocalRepo, error) {
 					return mockRepo, nil
@@ -940,7 +1392,7 @@ var _ = Describe("BasicObjectStreamer", func() {
 				startCommitPackfile := &fakePackfile{"pack-1"}
 				mockRepo.EXPECT().ObjectExist(hash.String()).Return(true)
 				mockRepo.EXPECT().CommitObject(hash).Return(nil, plumb.ErrObjectNotFound)
-				cs.EXPECT().GetCommit(ctx, repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
+				cs.EXPECT().GetCommit(gomock.Any(), repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
 
 				packfiles, err := streamer.GetCommitWithAncestors(ctx, cs, func(gitBinPath, path string) (plumbing.LocalRepo, error) {
 					return mockRepo, nil
@@ -962,7 +1414,7 @@ var _ = Describe("BasicObjectStreamer", func() {
 				startCommit := &object.Commit{Hash: hash}
 				startCommitPackfile := &fakePackfile{"pack-1"}
 				mockRepo.EXPECT().CommitObject(hash).Return(nil, plumb.ErrObjectNotFound)
-				cs.EXPECT().GetCommit(ctx, repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
+				cs.EXPECT().GetCommit(gomock.Any(), repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
 
 				packfiles, err := streamer.GetCommitWithAncestors(ctx, cs, func(gitBinPath, path string) (plumbing.LocalRepo, error) {
 					return mockRepo, nil
@@ -986,7 +1438,7 @@ var _ = Describe("BasicObjectStreamer", func() {
 				startCommitPackfile := &fakePackfile{"pack-1"}
 
 				mockRepo.EXPECT().CommitObject(hash).Return(nil, plumb.ErrObjectNotFound)
-				cs.EXPECT().GetCommit(ctx, repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
+				cs.EXPECT().GetCommit(gomock.Any(), repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
 				mockRepo.EXPECT().CommitObject(hash).Return(nil, plumb.ErrObjectNotFound)
 
 				packfiles, err := streamer.GetCommitWithAncestors(ctx, cs, func(gitBinPath, path string) (plumbing.LocalRepo, error) {
@@ -1012,11 +1464,11 @@ var _ = Describe("BasicObjectStreamer", func() {
 				startCommitPackfile := &fakePackfile{"pack-1"}
 
 				mockRepo.EXPECT().CommitObject(hash).Return(nil, plumb.ErrObjectNotFound)
-				cs.EXPECT().GetCommit(ctx, repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
+				cs.EXPECT().GetCommit(gomock.Any(), repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
 
 				parentCommit := &object.Commit{Hash: parentHash}
 				parentCommitPackfile := &fakePackfile{"pack-2"}
-				cs.EXPECT().GetCommit(ctx, repoName, parentHash[:]).Return(parentCommitPackfile, parentCommit, nil)
+				cs.EXPECT().GetCommit(gomock.Any(), repoName, parentHash[:]).Return(parentCommitPackfile, parentCommit, nil)
 
 				mockRepo.EXPECT().CommitObject(parentHash).Return(nil, plumb.ErrObjectNotFound)
 
@@ -1041,7 +1493,7 @@ var _ = Describe("BasicObjectStreamer", func() {
 					startCommitPackfile := &fakePackfile{"pack-1"}
 
 					mockRepo.EXPECT().CommitObject(hash).Return(nil, plumb.ErrObjectNotFound)
-					cs.EXPECT().GetCommit(ctx, repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
+					cs.EXPECT().GetCommit(gomock.Any(), repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
 
 					mockRepo.EXPECT().CommitObject(parentHash).Return(nil, fmt.Errorf("error"))
 
@@ -1069,7 +1521,7 @@ var _ = Describe("BasicObjectStreamer", func() {
 					startCommit.ParentHashes = append(startCommit.ParentHashes, parentHash)
 					startCommitPackfile := &fakePackfile{"pack-1"}
 					mockRepo.EXPECT().CommitObject(hash).Return(nil, plumb.ErrObjectNotFound)
-					cs.EXPECT().GetCommit(ctx, repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
+					cs.EXPECT().GetCommit(gomock.Any(), repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
 
 					grandParentHash := plumb.NewHash("d9dbe0e59248c7f0505dd5d80ed470fb43f82521")
 					parentCommit := &object.Commit{Hash: parentHash, ParentHashes: []plumb.Hash{grandParentHash}}
@@ -1078,7 +1530,7 @@ var _ = Describe("BasicObjectStreamer", func() {
 
 					grandParentCommit := &object.Commit{Hash: grandParentHash}
 					grandParentCommitPackfile := &fakePackfile{"pack-2"}
-					cs.EXPECT().GetCommit(ctx, repoName, grandParentHash[:]).Return(grandParentCommitPackfile, grandParentCommit, nil)
+					cs.EXPECT().GetCommit(gomock.Any(), repoName, grandParentHash[:]).Return(grandParentCommitPackfile, grandParentCommit, nil)
 
 					packfiles, err := streamer.GetCommitWithAncestors(ctx, cs, func(gitBinPath, path string) (plumbing.LocalRepo, error) {
 						return mockRepo, nil
@@ -1100,7 +1552,7 @@ var _ = Describe("BasicObjectStreamer", func() {
 				startCommit.ParentHashes = append(startCommit.ParentHashes, parentHash)
 				startCommitPackfile := &fakePackfile{"pack-1"}
 				mockRepo.EXPECT().CommitObject(hash).Return(nil, plumb.ErrObjectNotFound)
-				cs.EXPECT().GetCommit(ctx, repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
+				cs.EXPECT().GetCommit(gomock.Any(), repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
 				mockRepo.EXPECT().ObjectExist(parentHash.String()).Return(true)
 
 				packfiles, err := streamer.GetCommitWithAncestors(ctx, cs, func(gitBinPath, path string) (plumbing.LocalRepo, error) {
@@ -1124,12 +1576,12 @@ var _ = Describe("BasicObjectStreamer", func() {
 					startCommit.ParentHashes = append(startCommit.ParentHashes, parentHash)
 					startCommitPackfile := &fakePackfile{"pack-1"}
 					mockRepo.EXPECT().CommitObject(hash).Return(nil, plumb.ErrObjectNotFound)
-					cs.EXPECT().GetCommit(ctx, repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
+					cs.EXPECT().GetCommit(gomock.Any(), repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
 					mockRepo.EXPECT().ObjectExist(parentHash.String()).Return(true)
 
 					parentCommit := &object.Commit{Hash: parentHash}
 					parentCommitPackfile := &fakePackfile{"pack-2"}
-					cs.EXPECT().GetCommit(ctx, repoName, parentHash[:]).Return(parentCommitPackfile, parentCommit, nil)
+					cs.EXPECT().GetCommit(gomock.Any(), repoName, parentHash[:]).Return(parentCommitPackfile, parentCommit, nil)
 
 					packfiles, err := streamer.GetCommitWithAncestors(ctx, cs, func(gitBinPath, path string) (plumbing.LocalRepo, error) {
 						return mockRepo, nil
@@ -1158,16 +1610,16 @@ var _ = Describe("BasicObjectStreamer", func() {
 				startCommit.ParentHashes = append(startCommit.ParentHashes, parentHash, parent2Hash)
 				startCommitPackfile := &fakePackfile{"pack-1"}
 				mockRepo.EXPECT().CommitObject(hash).Return(nil, plumb.ErrObjectNotFound)
-				cs.EXPECT().GetCommit(ctx, repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
+				cs.EXPECT().GetCommit(gomock.Any(), repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
 
 				parentCommit := &object.Commit{Hash: parentHash}
 				parentCommitPackfile := &fakePackfile{"pack-2"}
-				cs.EXPECT().GetCommit(ctx, repoName, parentHash[:]).Return(parentCommitPackfile, parentCommit, nil)
+				cs.EXPECT().GetCommit(gomock.Any(), repoName, parentHash[:]).Return(parentCommitPackfile, parentCommit, nil)
 				mockRepo.EXPECT().CommitObject(parentHash).Return(nil, plumb.ErrObjectNotFound)
 
 				parent2Commit := &object.Commit{Hash: parent2Hash}
 				parent2CommitPackfile := &fakePackfile{"pack-3"}
-				cs.EXPECT().GetCommit(ctx, repoName, parent2Hash[:]).Return(parent2CommitPackfile, parent2Commit, nil)
+				cs.EXPECT().GetCommit(gomock.Any(), repoName, parent2Hash[:]).Return(parent2CommitPackfile, parent2Commit, nil)
 				mockRepo.EXPECT().CommitObject(parent2Hash).Return(nil, plumb.ErrObjectNotFound)
 
 				packfiles, err := streamer.GetCommitWithAncestors(ctx, cs, func(gitBinPath, path string) (plumbing.LocalRepo, error) {
@@ -1196,18 +1648,18 @@ var _ = Describe("BasicObjectStreamer", func() {
 				startCommitPackfile := &fakePackfile{"pack-1"}
 
 				mockRepo.EXPECT().CommitObject(hash).Return(nil, plumb.ErrObjectNotFound)
-				cs.EXPECT().GetCommit(ctx, repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
+				cs.EXPECT().GetCommit(gomock.Any(), repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
 
 				mockRepo.EXPECT().ObjectExist(parentHash.String()).Return(true)
 				mockRepo.EXPECT().CommitObject(parent2Hash).Return(nil, plumb.ErrObjectNotFound)
 
 				parentCommit := &object.Commit{Hash: parentHash}
 				parentCommitPackfile := &fakePackfile{"pack-2"}
-				cs.EXPECT().GetCommit(ctx, repoName, parentHash[:]).Return(parentCommitPackfile, parentCommit, nil)
+				cs.EXPECT().GetCommit(gomock.Any(), repoName, parentHash[:]).Return(parentCommitPackfile, parentCommit, nil)
 
 				parent2Commit := &object.Commit{Hash: parent2Hash}
 				parent2CommitPackfile := &fakePackfile{"pack-3"}
-				cs.EXPECT().GetCommit(ctx, repoName, parent2Hash[:]).Return(parent2CommitPackfile, parent2Commit, nil)
+				cs.EXPECT().GetCommit(gomock.Any(), repoName, parent2Hash[:]).Return(parent2CommitPackfile, parent2Commit, nil)
 
 				mockRepo.EXPECT().IsAncestor(parent2Hash.String(), parentHash.String()).Return(plumb.ErrObjectNotFound)
 
@@ -1234,18 +1686,18 @@ var _ = Describe("BasicObjectStreamer", func() {
 				startCommitPackfile := &fakePackfile{"pack-1"}
 
 				mockRepo.EXPECT().CommitObject(hash).Return(nil, plumb.ErrObjectNotFound)
-				cs.EXPECT().GetCommit(ctx, repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
+				cs.EXPECT().GetCommit(gomock.Any(), repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
 
 				mockRepo.EXPECT().ObjectExist(parentHash.String()).Return(true)
 				mockRepo.EXPECT().CommitObject(parent2Hash).Return(nil, plumb.ErrObjectNotFound)
 
 				parentCommit := &object.Commit{Hash: parentHash}
 				parentCommitPackfile := &fakePackfile{"pack-2"}
-				cs.EXPECT().GetCommit(ctx, repoName, parentHash[:]).Return(parentCommitPackfile, parentCommit, nil)
+				cs.EXPECT().GetCommit(gomock.Any(), repoName, parentHash[:]).Return(parentCommitPackfile, parentCommit, nil)
 
 				parent2Commit := &object.Commit{Hash: parent2Hash}
 				parent2CommitPackfile := &fakePackfile{"pack-3"}
-				cs.EXPECT().GetCommit(ctx, repoName, parent2Hash[:]).Return(parent2CommitPackfile, parent2Commit, nil)
+				cs.EXPECT().GetCommit(gomock.Any(), repoName, parent2Hash[:]).Return(parent2CommitPackfile, parent2Commit, nil)
 
 				mockRepo.EXPECT().IsAncestor(parent2Hash.String(), parentHash.String()).Return(repo.ErrNotAnAncestor)
 
@@ -1272,7 +1724,7 @@ var _ = Describe("BasicObjectStreamer", func() {
 				startCommitPackfile := &fakePackfile{"pack-1"}
 
 				mockRepo.EXPECT().CommitObject(hash).Return(nil, plumb.ErrObjectNotFound)
-				cs.EXPECT().GetCommit(ctx, repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
+				cs.EXPECT().GetCommit(gomock.Any(), repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
 
 				mockRepo.EXPECT().ObjectExist(parentHash.String()).Return(true)
 				mockRepo.EXPECT().CommitObject(parent2Hash).Return(nil, plumb.ErrObjectNotFound)
@@ -1304,7 +1756,7 @@ var _ = Describe("BasicObjectStreamer", func() {
 					startCommitPackfile := &fakePackfile{"pack-1"}
 
 					mockRepo.EXPECT().CommitObject(hash).Return(nil, plumb.ErrObjectNotFound)
-					cs.EXPECT().GetCommit(ctx, repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
+					cs.EXPECT().GetCommit(gomock.Any(), repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
 
 					var cbPackfiles []io2.ReadSeekerCloser
 					packfiles, err := streamer.GetCommitWithAncestors(ctx, cs, func(gitBinPath, path string) (plumbing.LocalRepo, error) {
@@ -1333,7 +1785,7 @@ var _ = Describe("BasicObjectStreamer", func() {
 					startCommitPackfile := &fakePackfile{"pack-1"}
 
 					mockRepo.EXPECT().CommitObject(hash).Return(nil, plumb.ErrObjectNotFound)
-					cs.EXPECT().GetCommit(ctx, repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
+					cs.EXPECT().GetCommit(gomock.Any(), repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
 
 					var cbPackfiles []io2.ReadSeekerCloser
 					packfiles, err := streamer.GetCommitWithAncestors(ctx, cs, func(gitBinPath, path string) (plumbing.LocalRepo, error) {
@@ -1363,7 +1815,7 @@ var _ = Describe("BasicObjectStreamer", func() {
 					startCommitPackfile := &fakePackfile{"pack-1"}
 
 					mockRepo.EXPECT().CommitObject(hash).Return(nil, plumb.ErrObjectNotFound)
-					cs.EXPECT().GetCommit(ctx, repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
+					cs.EXPECT().GetCommit(gomock.Any(), repoName, hash[:]).Return(startCommitPackfile, startCommit, nil)
 
 					var cbPackfiles []io2.ReadSeekerCloser
 					packfiles, err := streamer.GetCommitWithAncestors(ctx, cs, func(gitBinPath, path string) (plumbing.LocalRepo, error) {
@@ -0,0 +1,98 @@
+package streamer
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// throttleChunkSize is the maximum number of bytes a throttled writer
+// writes before waiting on its rate limiters again.
+const throttleChunkSize = 16 * 1024
+
+// RateLimiter is a token bucket that limits the number of bytes
+// transferred per second. The bucket holds up to one second worth
+// of tokens, allowing short bursts up to the rate.
+type RateLimiter struct {
+	lck    sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates an instance of RateLimiter.
+// bytesPerSec is the maximum transfer rate; It returns nil if bytesPerSec is not positive.
+func NewRateLimiter(bytesPerSec int64) *RateLimiter {
+	if bytesPerSec <= 0 {
+		return nil
+	}
+	return &RateLimiter{
+		rate:   float64(bytesPerSec),
+		tokens: float64(bytesPerSec),
+		last:   time.Now(),
+	}
+}
+
+// WaitN takes n tokens from the bucket, blocking until they are available.
+// Concurrent callers reserve tokens in turn, so their combined rate is limited.
+func (l *RateLimiter) WaitN(n int) {
+	l.lck.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.rate {
+		l.tokens = l.rate
+	}
+	l.last = now
+	l.tokens -= float64(n)
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.lck.Unlock()
+
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+}
+
+// throttledWriter is an io.Writer that waits on rate limiters before writing
+type throttledWriter struct {
+	w        io.Writer
+	limiters []*RateLimiter
+}
+
+// NewThrottledWriter returns a writer that writes to w no faster than
+// the rate allowed by every limiter. Nil limiters are ignored and w is
+// returned as-is when there is no limiter.
+func NewThrottledWriter(w io.Writer, limiters ...*RateLimiter) io.Writer {
+	tw := &throttledWriter{w: w}
+	for _, l := range limiters {
+		if l != nil {
+			tw.limiters = append(tw.limiters, l)
+		}
+	}
+	if len(tw.limiters) == 0 {
+		return w
+	}
+	return tw
+}
+
+// Write implements io.Writer
+func (t *throttledWriter) Write(p []byte) (n int, err error) {
+	for len(p) > 0 {
+		chunk := p
+		if len(chunk) > throttleChunkSize {
+			chunk = chunk[:throttleChunkSize]
+		}
+		for _, l := range t.limiters {
+			l.WaitN(len(chunk))
+		}
+		written, err := t.w.Write(chunk)
+		n += written
+		if err != nil {
+			return n, err
+		}
+		p = p[written:]
+	}
+	return n, nil
+}
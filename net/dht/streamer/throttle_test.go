@@ -0,0 +1,54 @@
+package streamer_test
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/make-os/kit/net/dht/streamer"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Throttle", func() {
+	Describe(".NewRateLimiter", func() {
+		It("should return nil if rate is not positive", func() {
+			Expect(streamer.NewRateLimiter(0)).To(BeNil())
+			Expect(streamer.NewRateLimiter(-1)).To(BeNil())
+		})
+	})
+
+	Describe(".NewThrottledWriter", func() {
+		It("should return the writer as-is if there is no limiter", func() {
+			buf := bytes.NewBuffer(nil)
+			Expect(streamer.NewThrottledWriter(buf, nil)).To(BeIdenticalTo(buf))
+		})
+
+		It("should throttle a large transfer to approximately the configured rate", func() {
+			rate := int64(256 * 1024)
+			buf := bytes.NewBuffer(nil)
+			w := streamer.NewThrottledWriter(buf, streamer.NewRateLimiter(rate))
+
+			// The bucket starts with one second worth of tokens,
+			// so writing three seconds worth should take about two seconds.
+			data := make([]byte, 3*rate)
+			start := time.Now()
+			n, err := w.Write(data)
+			elapsed := time.Since(start)
+			Expect(err).To(BeNil())
+			Expect(n).To(Equal(len(data)))
+			Expect(buf.Len()).To(Equal(len(data)))
+			Expect(elapsed).To(BeNumerically(">=", 1900*time.Millisecond))
+			Expect(elapsed).To(BeNumerically("<", 2500*time.Millisecond))
+		})
+
+		It("should be limited by the slowest limiter", func() {
+			buf := bytes.NewBuffer(nil)
+			fast, slow := streamer.NewRateLimiter(1024*1024), streamer.NewRateLimiter(128*1024)
+			w := streamer.NewThrottledWriter(buf, fast, slow)
+			start := time.Now()
+			_, err := w.Write(make([]byte, 256*1024))
+			Expect(err).To(BeNil())
+			Expect(time.Since(start)).To(BeNumerically(">=", 900*time.Millisecond))
+		})
+	})
+})
@@ -11,6 +11,7 @@ import (
 	storagetypes "github.com/make-os/kit/storage/types"
 	tickettypes "github.com/make-os/kit/ticket/types"
 	"github.com/make-os/kit/types"
+	"github.com/make-os/kit/types/constants"
 	"github.com/make-os/kit/types/core"
 	"github.com/make-os/kit/types/state"
 	"github.com/make-os/kit/types/txns"
@@ -64,6 +65,8 @@ type App struct {
 	newRepos                  []newRepo
 	closedMergeProps          []*mergeProposalInfo
 	curEpoch                  int64
+	restore                   *snapshotRestore
+	snapshotting              int32
 }
 
 // NewApp creates an instance of App
@@ -347,6 +350,9 @@ func (a *App) Commit() abcitypes.ResponseCommit {
 		a.commitPanic(errors.Wrap(err, "failed to commit"))
 	}
 
+	a.broadcastBlock(bi)
+	a.takeSnapshot(bi.Height.Int64())
+
 	return abcitypes.ResponseCommit{
 		Data: bi.AppHash,
 	}
@@ -524,6 +530,16 @@ func (a *App) broadcastTx() {
 	}
 }
 
+// broadcastBlock broadcasts EvtBlockCommitted with the committed
+// block information and the transactions accepted in the block.
+func (a *App) broadcastBlock(bi *state.BlockInfo) {
+	var txs = make([]types.BaseTx, len(a.okTxs))
+	for i, btx := range a.okTxs {
+		txs[i] = btx.tx
+	}
+	a.cfg.G().Bus.Emit(constants.EvtBlockCommitted, bi, txs)
+}
+
 // trackAndBroadcastEpochChange tracks current epoch and will
 // broadcast EvtNewEpoch if there is a change in epoch
 func (a *App) trackAndBroadcastEpochChange() error {
@@ -563,19 +579,3 @@ func (a *App) updateDifficulty(block *state.BlockInfo) {
 	}
 	return
 }
-
-func (a *App) ListSnapshots(_ abcitypes.RequestListSnapshots) abcitypes.ResponseListSnapshots {
-	panic("implement me")
-}
-
-func (a *App) OfferSnapshot(_ abcitypes.RequestOfferSnapshot) abcitypes.ResponseOfferSnapshot {
-	panic("implement me")
-}
-
-func (a *App) LoadSnapshotChunk(_ abcitypes.RequestLoadSnapshotChunk) abcitypes.ResponseLoadSnapshotChunk {
-	panic("implement me")
-}
-
-func (a *App) ApplySnapshotChunk(_ abcitypes.RequestApplySnapshotChunk) abcitypes.ResponseApplySnapshotChunk {
-	panic("implement me")
-}
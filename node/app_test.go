@@ -11,6 +11,7 @@ import (
 	pushtypes "github.com/make-os/kit/remote/push/types"
 	storagetypes "github.com/make-os/kit/storage/types"
 	tickettypes "github.com/make-os/kit/ticket/types"
+	"github.com/make-os/kit/types/constants"
 	"github.com/make-os/kit/types/core"
 	"github.com/make-os/kit/types/state"
 	"github.com/make-os/kit/types/txns"
@@ -691,6 +692,21 @@ var _ = Describe("App", func() {
 		})
 	})
 
+	Describe(".broadcastBlock", func() {
+		It("should broadcast block information and accepted transactions", func() {
+			tx := txns.NewBareTxCoinTransfer()
+			tx2 := txns.NewBareTxPush()
+			app.okTxs = []blockTx{{tx, 0}, {tx2, 1}}
+			bi := &state.BlockInfo{Height: 10, Hash: []byte("hash")}
+			ch := cfg.G().Bus.Once(constants.EvtBlockCommitted)
+			go app.broadcastBlock(bi)
+			evt := <-ch
+			Expect(evt.Args).To(HaveLen(2))
+			Expect(evt.Args[0]).To(Equal(bi))
+			Expect(evt.Args[1]).To(Equal([]types.BaseTx{tx, tx2}))
+		})
+	})
+
 	Describe(".trackAndBroadcastEpochChange", func() {
 		It("should return error when unable to get current epoch", func() {
 			mockLogic.SysKeeper.EXPECT().GetCurrentEpoch().Return(int64(0), fmt.Errorf("error"))
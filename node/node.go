@@ -108,7 +108,9 @@ func (n *Node) OpenDB() (err error) {
 		return fmt.Errorf("db already open")
 	}
 
-	n.db, err = storage.NewBadger(n.cfg.GetAppDBDir())
+	n.db, err = storage.NewBadger(n.cfg.GetAppDBDir(), storage.Options{
+		Compression: n.cfg.Node.DBCompression,
+	})
 	if err != nil {
 		return err
 	}
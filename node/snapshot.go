@@ -0,0 +1,259 @@
+package node
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/make-os/kit/pkgs/tree"
+	"github.com/make-os/kit/types/state"
+	"github.com/make-os/kit/util"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// snapshotFormat is the format of the state snapshots produced and
+// restored by the app. Snapshots of other formats are rejected.
+const snapshotFormat = 1
+
+// snapshotRestore describes a state snapshot being restored
+type snapshotRestore struct {
+	dir      string
+	height   int64
+	appHash  []byte
+	manifest *tree.SnapshotManifest
+}
+
+// snapshotDir returns the directory of the state snapshot at the given height
+func (a *App) snapshotDir(height int64) string {
+	return filepath.Join(a.cfg.GetSnapshotDir(), strconv.FormatInt(height, 10))
+}
+
+// snapshotHeights returns the heights of the complete state
+// snapshots in the snapshot directory in ascending order.
+func (a *App) snapshotHeights() []int64 {
+	entries, err := ioutil.ReadDir(a.cfg.GetSnapshotDir())
+	if err != nil {
+		return nil
+	}
+
+	var heights []int64
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		height, err := strconv.ParseInt(entry.Name(), 10, 64)
+		if err != nil {
+			continue
+		}
+		heights = append(heights, height)
+	}
+
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	return heights
+}
+
+// takeSnapshot takes a snapshot of the state tree at the given height in
+// the background if the height is a multiple of the snapshot interval.
+// Older snapshots beyond the number of snapshots to keep are removed
+// once the snapshot has been written.
+func (a *App) takeSnapshot(height int64) {
+	interval := a.cfg.Node.SnapshotInterval
+	if interval <= 0 || height%interval != 0 {
+		return
+	}
+
+	// Skip the height if the previous snapshot is still being written
+	if !atomic.CompareAndSwapInt32(&a.snapshotting, 0, 1) {
+		a.log.Warn("Skipped state snapshot; previous snapshot is still being written", "Height", height)
+		return
+	}
+
+	go func() {
+		defer atomic.StoreInt32(&a.snapshotting, 0)
+		if err := a.writeSnapshot(height); err != nil {
+			a.log.Error("Failed to take state snapshot", "Height", height, "Err", err.Error())
+			return
+		}
+		a.log.Info("Took state snapshot", "Height", height)
+		a.pruneSnapshots()
+	}()
+}
+
+// writeSnapshot writes a snapshot of the state tree at the given height.
+// The snapshot is written to a temporary directory that is moved into
+// place when complete so that partial snapshots are never listed.
+func (a *App) writeSnapshot(height int64) error {
+	dir := a.snapshotDir(height)
+	tmpDir := dir + ".tmp"
+	_ = os.RemoveAll(tmpDir)
+	if _, err := a.logic.StateTree().WriteSnapshot(height, tmpDir, 0); err != nil {
+		_ = os.RemoveAll(tmpDir)
+		return err
+	}
+	return os.Rename(tmpDir, dir)
+}
+
+// pruneSnapshots removes all but the most recent state snapshots
+func (a *App) pruneSnapshots() {
+	keep := a.cfg.Node.SnapshotKeepRecent
+	if keep <= 0 {
+		return
+	}
+	heights := a.snapshotHeights()
+	for i := 0; i < len(heights)-keep; i++ {
+		if err := os.RemoveAll(a.snapshotDir(heights[i])); err != nil {
+			a.log.Error("Failed to remove state snapshot", "Height", heights[i], "Err", err.Error())
+		}
+	}
+}
+
+// ListSnapshots lists the state snapshots available to state syncing peers.
+// The snapshot metadata is the snapshot manifest. Snapshots without chunks
+// are not listed since they cannot be state synced.
+func (a *App) ListSnapshots(_ abcitypes.RequestListSnapshots) abcitypes.ResponseListSnapshots {
+	var resp abcitypes.ResponseListSnapshots
+	for _, height := range a.snapshotHeights() {
+		manifest, err := tree.ReadSnapshotManifest(a.snapshotDir(height))
+		if err != nil || len(manifest.Chunks) == 0 {
+			continue
+		}
+		metadata, _ := json.Marshal(manifest)
+		hash := sha256.Sum256(metadata)
+		resp.Snapshots = append(resp.Snapshots, &abcitypes.Snapshot{
+			Height:   uint64(height),
+			Format:   snapshotFormat,
+			Chunks:   uint32(len(manifest.Chunks)),
+			Hash:     hash[:],
+			Metadata: metadata,
+		})
+	}
+	return resp
+}
+
+// LoadSnapshotChunk returns a chunk of a state snapshot to a state syncing peer.
+// No chunk is returned if the snapshot or chunk does not exist.
+func (a *App) LoadSnapshotChunk(req abcitypes.RequestLoadSnapshotChunk) abcitypes.ResponseLoadSnapshotChunk {
+	if req.Format != snapshotFormat {
+		return abcitypes.ResponseLoadSnapshotChunk{}
+	}
+	path := filepath.Join(a.snapshotDir(int64(req.Height)), tree.SnapshotChunkFile(int(req.Chunk)))
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		a.log.Debug("Failed to load state snapshot chunk", "Height", req.Height, "Chunk", req.Chunk, "Err", err.Error())
+		return abcitypes.ResponseLoadSnapshotChunk{}
+	}
+	return abcitypes.ResponseLoadSnapshotChunk{Chunk: data}
+}
+
+// OfferSnapshot is called when state syncing to offer a state snapshot
+// discovered from a peer. The manifest carried in the snapshot metadata
+// is untrusted; the snapshot is only accepted if it is for the height
+// and app hash verified by the light client.
+func (a *App) OfferSnapshot(req abcitypes.RequestOfferSnapshot) abcitypes.ResponseOfferSnapshot {
+	reject := abcitypes.ResponseOfferSnapshot{Result: abcitypes.ResponseOfferSnapshot_REJECT}
+	abort := abcitypes.ResponseOfferSnapshot{Result: abcitypes.ResponseOfferSnapshot_ABORT}
+
+	if req.Snapshot == nil {
+		return reject
+	}
+	if req.Snapshot.Format != snapshotFormat {
+		return abcitypes.ResponseOfferSnapshot{Result: abcitypes.ResponseOfferSnapshot_REJECT_FORMAT}
+	}
+
+	var manifest tree.SnapshotManifest
+	if err := json.Unmarshal(req.Snapshot.Metadata, &manifest); err != nil {
+		return reject
+	}
+	if manifest.Version != int64(req.Snapshot.Height) ||
+		manifest.RootHash != hex.EncodeToString(req.AppHash) ||
+		len(manifest.Chunks) != int(req.Snapshot.Chunks) {
+		return reject
+	}
+
+	if a.logic.StateTree().Version() != 0 {
+		a.log.Error("Cannot restore state snapshot; state is not empty")
+		return abort
+	}
+
+	dir := filepath.Join(a.cfg.GetSnapshotDir(), "restore")
+	_ = os.RemoveAll(dir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		a.log.Error("Failed to create state snapshot restore directory", "Err", err.Error())
+		return abort
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, tree.SnapshotManifestFile), req.Snapshot.Metadata, 0600); err != nil {
+		a.log.Error("Failed to write state snapshot manifest", "Err", err.Error())
+		return abort
+	}
+
+	a.restore = &snapshotRestore{
+		dir:      dir,
+		height:   manifest.Version,
+		appHash:  req.AppHash,
+		manifest: &manifest,
+	}
+
+	return abcitypes.ResponseOfferSnapshot{Result: abcitypes.ResponseOfferSnapshot_ACCEPT}
+}
+
+// ApplySnapshotChunk stores a chunk of the accepted state snapshot.
+// Chunks that do not match the snapshot manifest are refetched from
+// another peer. Chunks are applied in order, so the snapshot is restored
+// once the last chunk is applied. Only the state tree and the information
+// of the snapshot block are restored.
+func (a *App) ApplySnapshotChunk(req abcitypes.RequestApplySnapshotChunk) abcitypes.ResponseApplySnapshotChunk {
+	abort := abcitypes.ResponseApplySnapshotChunk{Result: abcitypes.ResponseApplySnapshotChunk_ABORT}
+
+	r := a.restore
+	if r == nil {
+		return abort
+	}
+
+	if err := tree.VerifySnapshotChunk(r.manifest, int(req.Index), req.Chunk); err != nil {
+		return abcitypes.ResponseApplySnapshotChunk{
+			Result:        abcitypes.ResponseApplySnapshotChunk_RETRY,
+			RefetchChunks: []uint32{req.Index},
+			RejectSenders: []string{req.Sender},
+		}
+	}
+
+	path := filepath.Join(r.dir, tree.SnapshotChunkFile(int(req.Index)))
+	if err := ioutil.WriteFile(path, req.Chunk, 0600); err != nil {
+		a.log.Error("Failed to write state snapshot chunk", "Chunk", req.Index, "Err", err.Error())
+		return abort
+	}
+
+	if int(req.Index) < len(r.manifest.Chunks)-1 {
+		return abcitypes.ResponseApplySnapshotChunk{Result: abcitypes.ResponseApplySnapshotChunk_ACCEPT}
+	}
+
+	if _, err := a.logic.StateTree().RestoreSnapshot(r.dir, r.height, r.appHash); err != nil {
+		a.log.Error("Failed to restore state snapshot", "Height", r.height, "Err", err.Error())
+		return abort
+	}
+
+	// Save the snapshot block information; Tendermint checks the
+	// last block height and app hash of the app after state sync.
+	bi := &state.BlockInfo{Height: util.Int64(r.height), AppHash: r.appHash}
+	if err := a.logic.SysKeeper().SaveBlockInfo(bi); err != nil {
+		a.log.Error("Failed to save state snapshot block information", "Height", r.height, "Err", err.Error())
+		return abort
+	}
+	if err := a.logic.GetDBTx().Commit(); err != nil {
+		a.log.Error("Failed to commit state snapshot block information", "Height", r.height, "Err", err.Error())
+		return abort
+	}
+	a.logic.GetDBTx().RenewTx()
+
+	_ = os.RemoveAll(r.dir)
+	a.restore = nil
+	a.log.Info("Restored state snapshot", "Height", r.height)
+
+	return abcitypes.ResponseApplySnapshotChunk{Result: abcitypes.ResponseApplySnapshotChunk_ACCEPT}
+}
@@ -0,0 +1,272 @@
+package node
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/golang/mock/gomock"
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/mocks"
+	"github.com/make-os/kit/pkgs/tree"
+	storagetypes "github.com/make-os/kit/storage/types"
+	"github.com/make-os/kit/testutil"
+	"github.com/make-os/kit/types/state"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	db "github.com/tendermint/tm-db"
+)
+
+// makeSnapshotChunk returns a snapshot chunk entry describing data
+func makeSnapshotChunk(data []byte) *tree.SnapshotChunk {
+	hash := sha256.Sum256(data)
+	return &tree.SnapshotChunk{Hash: hex.EncodeToString(hash[:]), Size: int64(len(data))}
+}
+
+var _ = Describe("App Snapshots", func() {
+	var c storagetypes.Engine
+	var stateTreeDB db.DB
+	var err error
+	var cfg *config.AppConfig
+	var app *App
+	var ctrl *gomock.Controller
+	var mockLogic *testutil.MockObjects
+	var appHash = []byte("app_hash")
+	var chunks = [][]byte{[]byte("chunk0"), []byte("chunk1")}
+	var manifest *tree.SnapshotManifest
+
+	BeforeEach(func() {
+		cfg, err = testutil.SetTestCfg()
+		Expect(err).To(BeNil())
+		c, stateTreeDB = testutil.GetDB()
+		ctrl = gomock.NewController(GinkgoT())
+		mockLogic = testutil.Mocks(ctrl)
+		app = NewApp(cfg, c, mockLogic.AtomicLogic, nil)
+		manifest = &tree.SnapshotManifest{
+			Version:  10,
+			RootHash: hex.EncodeToString(appHash),
+			Nodes:    3,
+			Chunks:   []*tree.SnapshotChunk{makeSnapshotChunk(chunks[0]), makeSnapshotChunk(chunks[1])},
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		Expect(c.Close()).To(BeNil())
+		Expect(stateTreeDB.Close()).To(BeNil())
+		err = os.RemoveAll(cfg.DataDir())
+		Expect(err).To(BeNil())
+	})
+
+	// writeSnapshot writes a snapshot manifest and its chunks at the given height
+	writeSnapshot := func(height int64, m *tree.SnapshotManifest, data ...[]byte) {
+		dir := app.snapshotDir(height)
+		Expect(os.MkdirAll(dir, 0700)).To(Succeed())
+		bz, _ := json.Marshal(m)
+		Expect(ioutil.WriteFile(filepath.Join(dir, tree.SnapshotManifestFile), bz, 0600)).To(Succeed())
+		for i, chunk := range data {
+			Expect(ioutil.WriteFile(filepath.Join(dir, tree.SnapshotChunkFile(i)), chunk, 0600)).To(Succeed())
+		}
+	}
+
+	Describe(".takeSnapshot", func() {
+		It("should not take a snapshot when the snapshot interval is zero", func() {
+			cfg.Node.SnapshotInterval = 0
+			app.takeSnapshot(10)
+			Expect(app.snapshotHeights()).To(BeEmpty())
+		})
+
+		It("should not take a snapshot when the height is not a multiple of the snapshot interval", func() {
+			cfg.Node.SnapshotInterval = 3
+			app.takeSnapshot(10)
+			Expect(app.snapshotHeights()).To(BeEmpty())
+		})
+
+		It("should take a snapshot and remove snapshots beyond the number of snapshots to keep", func() {
+			cfg.Node.SnapshotInterval = 5
+			cfg.Node.SnapshotKeepRecent = 2
+			writeSnapshot(1, manifest)
+			writeSnapshot(5, manifest)
+			mockLogic.StateTree.EXPECT().WriteSnapshot(int64(10), app.snapshotDir(10)+".tmp", 0).
+				DoAndReturn(func(version int64, dir string, _ int) (*tree.SnapshotManifest, error) {
+					return &tree.SnapshotManifest{Version: version}, os.MkdirAll(dir, 0700)
+				})
+			app.takeSnapshot(10)
+			Eventually(app.snapshotHeights).Should(Equal([]int64{5, 10}))
+		})
+
+		It("should not keep a partially written snapshot", func() {
+			cfg.Node.SnapshotInterval = 5
+			mockLogic.StateTree.EXPECT().WriteSnapshot(int64(10), gomock.Any(), 0).
+				DoAndReturn(func(_ int64, dir string, _ int) (*tree.SnapshotManifest, error) {
+					Expect(os.MkdirAll(dir, 0700)).To(Succeed())
+					return nil, fmt.Errorf("error")
+				})
+			app.takeSnapshot(10)
+			Eventually(func() int32 { return atomic.LoadInt32(&app.snapshotting) }).Should(Equal(int32(0)))
+			Expect(app.snapshotDir(10) + ".tmp").ToNot(BeADirectory())
+			Expect(app.snapshotHeights()).To(BeEmpty())
+		})
+	})
+
+	Describe(".ListSnapshots", func() {
+		It("should list complete snapshots with chunks in ascending height order", func() {
+			writeSnapshot(20, manifest, chunks...)
+			writeSnapshot(10, manifest, chunks...)
+			writeSnapshot(15, &tree.SnapshotManifest{Version: 15})
+			Expect(os.MkdirAll(app.snapshotDir(30)+".tmp", 0700)).To(Succeed())
+
+			res := app.ListSnapshots(abcitypes.RequestListSnapshots{})
+			Expect(res.Snapshots).To(HaveLen(2))
+			Expect(res.Snapshots[0].Height).To(Equal(uint64(10)))
+			Expect(res.Snapshots[0].Format).To(Equal(uint32(snapshotFormat)))
+			Expect(res.Snapshots[0].Chunks).To(Equal(uint32(2)))
+			Expect(res.Snapshots[1].Height).To(Equal(uint64(20)))
+
+			var m tree.SnapshotManifest
+			Expect(json.Unmarshal(res.Snapshots[0].Metadata, &m)).To(Succeed())
+			Expect(&m).To(Equal(manifest))
+			hash := sha256.Sum256(res.Snapshots[0].Metadata)
+			Expect(res.Snapshots[0].Hash).To(Equal(hash[:]))
+		})
+	})
+
+	Describe(".LoadSnapshotChunk", func() {
+		BeforeEach(func() {
+			writeSnapshot(10, manifest, chunks...)
+		})
+
+		It("should return the chunk", func() {
+			res := app.LoadSnapshotChunk(abcitypes.RequestLoadSnapshotChunk{Height: 10, Format: snapshotFormat, Chunk: 1})
+			Expect(res.Chunk).To(Equal(chunks[1]))
+		})
+
+		It("should return no chunk if the chunk does not exist", func() {
+			res := app.LoadSnapshotChunk(abcitypes.RequestLoadSnapshotChunk{Height: 10, Format: snapshotFormat, Chunk: 2})
+			Expect(res.Chunk).To(BeNil())
+		})
+
+		It("should return no chunk if the format is unknown", func() {
+			res := app.LoadSnapshotChunk(abcitypes.RequestLoadSnapshotChunk{Height: 10, Format: 2, Chunk: 0})
+			Expect(res.Chunk).To(BeNil())
+		})
+	})
+
+	Describe(".OfferSnapshot", func() {
+		var snapshot *abcitypes.Snapshot
+
+		BeforeEach(func() {
+			metadata, _ := json.Marshal(manifest)
+			snapshot = &abcitypes.Snapshot{Height: 10, Format: snapshotFormat, Chunks: 2, Metadata: metadata}
+		})
+
+		It("should reject snapshot of unknown format", func() {
+			snapshot.Format = 2
+			res := app.OfferSnapshot(abcitypes.RequestOfferSnapshot{Snapshot: snapshot, AppHash: appHash})
+			Expect(res.Result).To(Equal(abcitypes.ResponseOfferSnapshot_REJECT_FORMAT))
+		})
+
+		It("should reject snapshot with invalid metadata", func() {
+			snapshot.Metadata = []byte("invalid")
+			res := app.OfferSnapshot(abcitypes.RequestOfferSnapshot{Snapshot: snapshot, AppHash: appHash})
+			Expect(res.Result).To(Equal(abcitypes.ResponseOfferSnapshot_REJECT))
+		})
+
+		It("should reject snapshot whose root hash is not the trusted app hash", func() {
+			res := app.OfferSnapshot(abcitypes.RequestOfferSnapshot{Snapshot: snapshot, AppHash: []byte("other_hash")})
+			Expect(res.Result).To(Equal(abcitypes.ResponseOfferSnapshot_REJECT))
+		})
+
+		It("should reject snapshot whose version is not the snapshot height", func() {
+			snapshot.Height = 11
+			res := app.OfferSnapshot(abcitypes.RequestOfferSnapshot{Snapshot: snapshot, AppHash: appHash})
+			Expect(res.Result).To(Equal(abcitypes.ResponseOfferSnapshot_REJECT))
+		})
+
+		It("should reject snapshot whose chunk count does not match the manifest", func() {
+			snapshot.Chunks = 3
+			res := app.OfferSnapshot(abcitypes.RequestOfferSnapshot{Snapshot: snapshot, AppHash: appHash})
+			Expect(res.Result).To(Equal(abcitypes.ResponseOfferSnapshot_REJECT))
+		})
+
+		It("should abort if the state is not empty", func() {
+			mockLogic.StateTree.EXPECT().Version().Return(int64(1))
+			res := app.OfferSnapshot(abcitypes.RequestOfferSnapshot{Snapshot: snapshot, AppHash: appHash})
+			Expect(res.Result).To(Equal(abcitypes.ResponseOfferSnapshot_ABORT))
+			Expect(app.restore).To(BeNil())
+		})
+
+		It("should accept snapshot and write its manifest", func() {
+			mockLogic.StateTree.EXPECT().Version().Return(int64(0))
+			res := app.OfferSnapshot(abcitypes.RequestOfferSnapshot{Snapshot: snapshot, AppHash: appHash})
+			Expect(res.Result).To(Equal(abcitypes.ResponseOfferSnapshot_ACCEPT))
+			Expect(app.restore).ToNot(BeNil())
+			Expect(app.restore.height).To(Equal(int64(10)))
+			Expect(app.restore.appHash).To(Equal(appHash))
+			m, err := tree.ReadSnapshotManifest(app.restore.dir)
+			Expect(err).To(BeNil())
+			Expect(m).To(Equal(manifest))
+		})
+	})
+
+	Describe(".ApplySnapshotChunk", func() {
+		It("should abort if no snapshot was accepted", func() {
+			res := app.ApplySnapshotChunk(abcitypes.RequestApplySnapshotChunk{Index: 0, Chunk: chunks[0]})
+			Expect(res.Result).To(Equal(abcitypes.ResponseApplySnapshotChunk_ABORT))
+		})
+
+		When("a snapshot was accepted", func() {
+			BeforeEach(func() {
+				metadata, _ := json.Marshal(manifest)
+				snapshot := &abcitypes.Snapshot{Height: 10, Format: snapshotFormat, Chunks: 2, Metadata: metadata}
+				mockLogic.StateTree.EXPECT().Version().Return(int64(0))
+				res := app.OfferSnapshot(abcitypes.RequestOfferSnapshot{Snapshot: snapshot, AppHash: appHash})
+				Expect(res.Result).To(Equal(abcitypes.ResponseOfferSnapshot_ACCEPT))
+			})
+
+			It("should refetch a chunk that does not match the manifest and reject its sender", func() {
+				res := app.ApplySnapshotChunk(abcitypes.RequestApplySnapshotChunk{Index: 0, Chunk: chunks[1], Sender: "peer1"})
+				Expect(res.Result).To(Equal(abcitypes.ResponseApplySnapshotChunk_RETRY))
+				Expect(res.RefetchChunks).To(Equal([]uint32{0}))
+				Expect(res.RejectSenders).To(Equal([]string{"peer1"}))
+			})
+
+			It("should store a chunk that is not the last chunk", func() {
+				res := app.ApplySnapshotChunk(abcitypes.RequestApplySnapshotChunk{Index: 0, Chunk: chunks[0]})
+				Expect(res.Result).To(Equal(abcitypes.ResponseApplySnapshotChunk_ACCEPT))
+				data, err := ioutil.ReadFile(filepath.Join(app.restore.dir, tree.SnapshotChunkFile(0)))
+				Expect(err).To(BeNil())
+				Expect(data).To(Equal(chunks[0]))
+			})
+
+			It("should abort if the snapshot could not be restored", func() {
+				dir := app.restore.dir
+				app.ApplySnapshotChunk(abcitypes.RequestApplySnapshotChunk{Index: 0, Chunk: chunks[0]})
+				mockLogic.StateTree.EXPECT().RestoreSnapshot(dir, int64(10), appHash).Return(nil, tree.ErrSnapshotRootHashMismatch)
+				res := app.ApplySnapshotChunk(abcitypes.RequestApplySnapshotChunk{Index: 1, Chunk: chunks[1]})
+				Expect(res.Result).To(Equal(abcitypes.ResponseApplySnapshotChunk_ABORT))
+			})
+
+			It("should restore the snapshot and save the snapshot block information after the last chunk", func() {
+				dir := app.restore.dir
+				app.ApplySnapshotChunk(abcitypes.RequestApplySnapshotChunk{Index: 0, Chunk: chunks[0]})
+				mockLogic.StateTree.EXPECT().RestoreSnapshot(dir, int64(10), appHash).Return(manifest, nil)
+				mockLogic.SysKeeper.EXPECT().SaveBlockInfo(&state.BlockInfo{Height: 10, AppHash: appHash}).Return(nil)
+				mockTx := mocks.NewMockTx(ctrl)
+				mockTx.EXPECT().Commit().Return(nil)
+				mockTx.EXPECT().RenewTx()
+				mockLogic.AtomicLogic.EXPECT().GetDBTx().Return(mockTx).Times(2)
+				res := app.ApplySnapshotChunk(abcitypes.RequestApplySnapshotChunk{Index: 1, Chunk: chunks[1]})
+				Expect(res.Result).To(Equal(abcitypes.ResponseApplySnapshotChunk_ACCEPT))
+				Expect(app.restore).To(BeNil())
+				Expect(dir).ToNot(BeADirectory())
+			})
+		})
+	})
+})
@@ -0,0 +1,30 @@
+package params
+
+import "github.com/shopspring/decimal"
+
+// NamespacePriceTier is the registration fee of namespaces
+// whose name has at most MaxLen characters.
+type NamespacePriceTier struct {
+	MaxLen int
+	Fee    decimal.Decimal
+}
+
+// GetNamespacePriceTier returns the price tier of a namespace name.
+// It returns nil if the name is longer than every tier.
+func GetNamespacePriceTier(name string) *NamespacePriceTier {
+	var tier *NamespacePriceTier
+	for i, t := range NamespacePriceTiers {
+		if len(name) <= t.MaxLen && (tier == nil || t.MaxLen < tier.MaxLen) {
+			tier = &NamespacePriceTiers[i]
+		}
+	}
+	return tier
+}
+
+// GetNamespaceRegFee returns the registration fee of a namespace name.
+func GetNamespaceRegFee(name string) decimal.Decimal {
+	if tier := GetNamespacePriceTier(name); tier != nil {
+		return tier.Fee
+	}
+	return NamespaceRegFee
+}
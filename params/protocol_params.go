@@ -30,6 +30,10 @@ var (
 	// FeePerByte is the cost per byte of a transaction
 	FeePerByte = decimal.NewFromFloat(0.00001)
 
+	// PriorityFeePerByte is the minimum cost per byte of a priority push.
+	// Paying above FeePerByte gives a push a higher priority in the mempool.
+	PriorityFeePerByte = decimal.NewFromFloat(0.00002)
+
 	// MinTicketMatDur is the number of blocks that must be created
 	// before a ticket is considered matured.
 	MinTicketMatDur = 3
@@ -78,6 +82,18 @@ var (
 
 	// TxRepoCreateMaxCharDesc is the maximum character for a repo description
 	TxRepoCreateMaxCharDesc = 140
+
+	// MaxTagGracePeriod is the maximum number of blocks a repo can allow
+	// for a tag to be re-pointed by its creator
+	MaxTagGracePeriod = uint64(8640)
+)
+
+// Repo config
+var (
+	// RepoCreateFee is the amount of native coin required to create a
+	// repository. When positive, it is paid to the treasury. Zero means
+	// repository creation has no price and the value is added to the repo balance.
+	RepoCreateFee = decimal.Zero
 )
 
 // Namespace config
@@ -86,11 +102,23 @@ var (
 	// repo namespace
 	NamespaceRegFee = decimal.NewFromFloat(1)
 
+	// NamespacePriceTiers are the registration fees of short namespaces.
+	// A name pays the fee of the tier with the smallest MaxLen it fits in.
+	// Names longer than every tier pay NamespaceRegFee.
+	NamespacePriceTiers = []NamespacePriceTier{
+		{MaxLen: 3, Fee: decimal.NewFromFloat(100)},
+		{MaxLen: 5, Fee: decimal.NewFromFloat(10)},
+	}
+
 	// NamespaceTTL is the number of blocks of a namespace life span
 	NamespaceTTL = 10
 
 	// NamespaceGraceDur is the number of blocks before a namespace expires
 	NamespaceGraceDur = 10
+
+	// MaxDomainsPerNamespace is the maximum number of domains a namespace
+	// domain update transaction can set
+	MaxDomainsPerNamespace = 100
 )
 
 // Remote config
@@ -104,6 +132,17 @@ var (
 	// PushPoolItemTTL is the maximum life time of an item in the push pool
 	PushPoolItemTTL = 1 * time.Hour
 
+	// PushNoteEndorsementCheckInt is the duration between each check for
+	// push notes that failed to receive enough endorsements in time
+	PushNoteEndorsementCheckInt = 5 * time.Second
+
+	// UploadSessionTTL is the duration an upload session remains
+	// valid after it was last written to
+	UploadSessionTTL = 1 * time.Hour
+
+	// UploadSessionCleanUpInt is the duration between each removal of expired upload sessions
+	UploadSessionCleanUpInt = 5 * time.Minute
+
 	// PushObjectsSendersCacheSize is the max size for push note senders cache
 	PushObjectsSendersCacheSize = 5000
 
@@ -116,9 +155,29 @@ var (
 	// NotesReceivedCacheSize is the max size of the cache that stores IDs of notes recently received
 	NotesReceivedCacheSize = 10000
 
+	// RecentPushesCacheSize is the max size of the cache that stores transaction hashes of recently accepted pushes
+	RecentPushesCacheSize = 1000
+
+	// PendingRecentPushTTL is how long the content key of a push whose transaction
+	// is in the mempool is kept while waiting for the transaction to be included in a block
+	PendingRecentPushTTL = 30 * time.Minute
+
 	// PushEndorseQuorumSize is the minimum number of PushEnds a push note requires for approval
 	PushEndorseQuorumSize = 2
 
+	// MaxEndorsementAge is the max age of the endorsements of a push transaction,
+	// measured from the endorsement timestamp to the time of the last block.
+	// Push transactions with older endorsements or endorsements dated before
+	// their push note are rejected. Disabled when zero (the default) so that
+	// push transactions already on chain remain valid when replayed.
+	MaxEndorsementAge time.Duration = 0
+
+	// PushEndorseAllowRefSubset allows the endorsements of a push transaction to cover
+	// a subset of the pushed references. Each endorsed reference must name the pushed
+	// reference it endorses. When false, the endorsed references must match the pushed
+	// references one-to-one and in order.
+	PushEndorseAllowRefSubset = false
+
 	// RepoProposalTTL is the number of blocks a repo proposal can remain active
 	RepoProposalTTL = uint64(10)
 
@@ -153,6 +212,18 @@ var (
 	// MaxPushFileSize is the maximum size of files in a push request
 	MaxPushFileSize = 1024 * 1024 * 50 // 50 MB
 
+	// MaxFetchedObjectSize is the maximum size of a single packfile
+	// fetched from the DHT during push note object fetching
+	MaxFetchedObjectSize = 1024 * 1024 * 50 // 50 MB
+
 	// MaxRepoSize is the maximum size of a repository
 	MaxRepoSize = 1024 * 1024 * 300 // 300 MB
+
+	// MaxPushNoteReferences is the maximum number of references in a push note
+	MaxPushNoteReferences = 100
+
+	// MergeHistoryDepth is the max number of ancestors of a pushed merge commit
+	// to walk when checking that the merge builds on the merge proposal's base
+	// hash. The check is disabled when zero.
+	MergeHistoryDepth = 50
 )
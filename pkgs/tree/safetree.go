@@ -108,3 +108,25 @@ func (s *SafeTree) Rollback() {
 	defer s.Unlock()
 	s.state.Rollback()
 }
+
+// IteratePrefix calls fn for each key in the working tree that begins with
+// the given prefix, in ascending order. Iteration stops if fn returns true.
+// Returns true if the iteration was stopped by fn.
+func (s *SafeTree) IteratePrefix(prefix []byte, fn func(key, value []byte) bool) bool {
+	s.RLock()
+	defer s.RUnlock()
+	return s.state.IterateRange(prefix, prefixEnd(prefix), true, fn)
+}
+
+// prefixEnd returns the smallest key greater than all keys that begin with
+// the given prefix or nil if there is no such key.
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xFF {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
@@ -243,4 +243,33 @@ var _ = Describe("TMDBAdapter", func() {
 			Expect(v).To(Equal([]byte("val2")))
 		})
 	})
+
+	Describe(".IteratePrefix", func() {
+		BeforeEach(func() {
+			tree.Set([]byte("a:1"), []byte("1"))
+			tree.Set([]byte("a:2"), []byte("2"))
+			tree.Set([]byte("ab:1"), []byte("3"))
+			tree.Set([]byte("b:1"), []byte("4"))
+		})
+
+		It("should iterate only keys with the prefix in ascending order", func() {
+			var keys []string
+			stopped := tree.IteratePrefix([]byte("a:"), func(key, value []byte) bool {
+				keys = append(keys, string(key))
+				return false
+			})
+			Expect(stopped).To(BeFalse())
+			Expect(keys).To(Equal([]string{"a:1", "a:2"}))
+		})
+
+		It("should stop iterating when callback returns true", func() {
+			var keys []string
+			stopped := tree.IteratePrefix([]byte("a"), func(key, value []byte) bool {
+				keys = append(keys, string(key))
+				return true
+			})
+			Expect(stopped).To(BeTrue())
+			Expect(keys).To(Equal([]string{"a:1"}))
+		})
+	})
 })
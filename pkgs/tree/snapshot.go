@@ -0,0 +1,268 @@
+package tree
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cosmos/iavl"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultSnapshotChunkSize is the default maximum size of a snapshot chunk
+	DefaultSnapshotChunkSize = 4 << 20
+
+	// SnapshotManifestFile is the name of the file that describes a snapshot
+	SnapshotManifestFile = "manifest.json"
+)
+
+var (
+	ErrSnapshotChunkHashMismatch = fmt.Errorf("snapshot chunk hash does not match")
+	ErrSnapshotRootHashMismatch  = fmt.Errorf("restored root hash does not match the snapshot root hash")
+	ErrSnapshotUntrusted         = fmt.Errorf("snapshot version or root hash does not match the trusted version or root hash")
+	ErrSnapshotTreeNotEmpty      = fmt.Errorf("tree is not empty")
+)
+
+// SnapshotChunk describes a file that contains a part of the nodes of a snapshot
+type SnapshotChunk struct {
+	Hash string `json:"hash"` // The hex-encoded SHA256 hash of the chunk file
+	Size int64  `json:"size"` // The size of the chunk file
+}
+
+// SnapshotManifest describes a snapshot of a tree at a version
+type SnapshotManifest struct {
+	Version  int64            `json:"version"`  // The version of the tree
+	RootHash string           `json:"rootHash"` // The hex-encoded root hash of the tree
+	Nodes    int              `json:"nodes"`    // The number of nodes in the snapshot
+	Chunks   []*SnapshotChunk `json:"chunks"`   // The chunks of the snapshot in import order
+}
+
+// SnapshotChunkFile returns the name of the file of the chunk at the given index
+func SnapshotChunkFile(index int) string {
+	return fmt.Sprintf("chunk-%06d", index)
+}
+
+// WriteSnapshot serializes the tree at the given version into chunk files
+// stored in dir and writes a manifest describing them. The nodes are
+// written in the order expected by RestoreSnapshot. A chunk is closed once
+// its size reaches chunkSize; nodes are never split across chunks.
+//  - version: The saved version of the tree to snapshot.
+//  - dir: The directory where the snapshot files are written.
+//  - chunkSize: The maximum size of a chunk. Zero uses DefaultSnapshotChunkSize.
+func (s *SafeTree) WriteSnapshot(version int64, dir string, chunkSize int) (*SnapshotManifest, error) {
+	if chunkSize <= 0 {
+		chunkSize = DefaultSnapshotChunkSize
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+
+	immutable, err := s.state.GetImmutable(version)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tree version")
+	}
+
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "failed to create snapshot directory")
+	}
+
+	manifest := &SnapshotManifest{Version: version, RootHash: hex.EncodeToString(immutable.Hash())}
+	buf := bytes.NewBuffer(nil)
+	flush := func() error {
+		if buf.Len() == 0 {
+			return nil
+		}
+		path := filepath.Join(dir, SnapshotChunkFile(len(manifest.Chunks)))
+		if err := ioutil.WriteFile(path, buf.Bytes(), 0600); err != nil {
+			return errors.Wrap(err, "failed to write chunk")
+		}
+		hash := sha256.Sum256(buf.Bytes())
+		manifest.Chunks = append(manifest.Chunks, &SnapshotChunk{Hash: hex.EncodeToString(hash[:]), Size: int64(buf.Len())})
+		buf.Reset()
+		return nil
+	}
+
+	// An empty tree has no nodes to export
+	if immutable.Size() > 0 {
+		exporter := immutable.Export()
+		defer exporter.Close()
+		for {
+			node, err := exporter.Next()
+			if err == iavl.ExportDone {
+				break
+			} else if err != nil {
+				return nil, errors.Wrap(err, "failed to export node")
+			}
+			encodeSnapshotNode(buf, node)
+			manifest.Nodes++
+			if buf.Len() >= chunkSize {
+				if err = flush(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if err = flush(); err != nil {
+			return nil, err
+		}
+	}
+
+	bz, _ := json.MarshalIndent(manifest, "", "  ")
+	if err = ioutil.WriteFile(filepath.Join(dir, SnapshotManifestFile), bz, 0600); err != nil {
+		return nil, errors.Wrap(err, "failed to write manifest")
+	}
+
+	return manifest, nil
+}
+
+// ReadSnapshotManifest reads the manifest of the snapshot stored in dir
+func ReadSnapshotManifest(dir string) (*SnapshotManifest, error) {
+	bz, err := ioutil.ReadFile(filepath.Join(dir, SnapshotManifestFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read manifest")
+	}
+	var manifest SnapshotManifest
+	if err = json.Unmarshal(bz, &manifest); err != nil {
+		return nil, errors.Wrap(err, "failed to decode manifest")
+	}
+	return &manifest, nil
+}
+
+// VerifySnapshotChunk checks whether data is the chunk at the given index of a snapshot
+func VerifySnapshotChunk(manifest *SnapshotManifest, index int, data []byte) error {
+	if index < 0 || index >= len(manifest.Chunks) {
+		return fmt.Errorf("chunk %d does not exist", index)
+	}
+	hash := sha256.Sum256(data)
+	if hex.EncodeToString(hash[:]) != manifest.Chunks[index].Hash {
+		return ErrSnapshotChunkHashMismatch
+	}
+	return nil
+}
+
+// RestoreSnapshot restores the snapshot stored in dir into the tree.
+// The manifest and chunks are untrusted, so the snapshot must be for the
+// given version and root hash, which the caller takes from a trusted source
+// such as a verified block header. Every chunk is verified against the
+// manifest before its nodes are imported and the root hash of the restored
+// tree must match rootHash. The tree must be empty.
+//  - dir: The directory where the snapshot files are stored.
+//  - version: The trusted version of the tree.
+//  - rootHash: The trusted root hash of the tree at the version.
+func (s *SafeTree) RestoreSnapshot(dir string, version int64, rootHash []byte) (*SnapshotManifest, error) {
+	manifest, err := ReadSnapshotManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest.Version != version || manifest.RootHash != hex.EncodeToString(rootHash) {
+		return nil, ErrSnapshotUntrusted
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	if s.state.Version() != 0 || !s.state.IsEmpty() {
+		return nil, ErrSnapshotTreeNotEmpty
+	}
+
+	importer, err := s.state.Import(manifest.Version)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start import")
+	}
+	defer importer.Close()
+
+	for i := range manifest.Chunks {
+		data, err := ioutil.ReadFile(filepath.Join(dir, SnapshotChunkFile(i)))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read chunk %d", i)
+		}
+		if err = VerifySnapshotChunk(manifest, i, data); err != nil {
+			return nil, errors.Wrapf(err, "bad chunk %d", i)
+		}
+		r := bytes.NewReader(data)
+		for {
+			node, err := decodeSnapshotNode(r)
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, errors.Wrapf(err, "failed to decode node in chunk %d", i)
+			}
+			if err = importer.Add(node); err != nil {
+				return nil, errors.Wrapf(err, "failed to import node in chunk %d", i)
+			}
+		}
+	}
+
+	if err = importer.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit import")
+	}
+
+	if !bytes.Equal(s.state.Hash(), rootHash) {
+		return nil, ErrSnapshotRootHashMismatch
+	}
+
+	return manifest, nil
+}
+
+// encodeSnapshotNode writes an exported node to w in the format:
+// height | version | key length | key | value length + 1 | value.
+// A value length of zero indicates a nil (inner node) value.
+func encodeSnapshotNode(w *bytes.Buffer, node *iavl.ExportNode) {
+	var tmp [binary.MaxVarintLen64]byte
+	w.WriteByte(byte(node.Height))
+	w.Write(tmp[:binary.PutVarint(tmp[:], node.Version)])
+	w.Write(tmp[:binary.PutUvarint(tmp[:], uint64(len(node.Key)))])
+	w.Write(node.Key)
+	if node.Value == nil {
+		w.Write(tmp[:binary.PutUvarint(tmp[:], 0)])
+		return
+	}
+	w.Write(tmp[:binary.PutUvarint(tmp[:], uint64(len(node.Value))+1)])
+	w.Write(node.Value)
+}
+
+// decodeSnapshotNode reads a node written by encodeSnapshotNode.
+// Key and value lengths larger than the unread part of r are rejected
+// before anything is allocated for them.
+// Returns io.EOF when r has no more nodes.
+func decodeSnapshotNode(r *bytes.Reader) (*iavl.ExportNode, error) {
+	height, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &iavl.ExportNode{Height: int8(height)}
+	if node.Version, err = binary.ReadVarint(r); err != nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	keyLen, err := binary.ReadUvarint(r)
+	if err != nil || keyLen > uint64(r.Len()) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	node.Key = make([]byte, keyLen)
+	if _, err = io.ReadFull(r, node.Key); err != nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	valLen, err := binary.ReadUvarint(r)
+	if err != nil || (valLen > 0 && valLen-1 > uint64(r.Len())) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if valLen > 0 {
+		node.Value = make([]byte, valLen-1)
+		if _, err = io.ReadFull(r, node.Value); err != nil {
+			return nil, io.ErrUnexpectedEOF
+		}
+	}
+
+	return node, nil
+}
@@ -0,0 +1,194 @@
+package tree_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/make-os/kit/pkgs/tree"
+	storagetypes "github.com/make-os/kit/storage/types"
+	tmdb "github.com/tendermint/tm-db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/testutil"
+)
+
+var _ = Describe("Snapshot", func() {
+	var appDB, appDB2 storagetypes.Engine
+	var stateDB, stateDB2 tmdb.DB
+	var err error
+	var cfg *config.AppConfig
+	var tree, tree2 *SafeTree
+	var dir string
+
+	BeforeEach(func() {
+		cfg, err = testutil.SetTestCfg()
+		Expect(err).To(BeNil())
+		appDB, stateDB = testutil.GetDB()
+		tree, err = NewSafeTree(stateDB, 128)
+		Expect(err).To(BeNil())
+		appDB2, stateDB2 = testutil.GetDB()
+		tree2, err = NewSafeTree(stateDB2, 128)
+		Expect(err).To(BeNil())
+		dir = filepath.Join(cfg.DataDir(), "snapshot")
+	})
+
+	AfterEach(func() {
+		Expect(appDB.Close()).To(BeNil())
+		Expect(appDB2.Close()).To(BeNil())
+		err = os.RemoveAll(cfg.DataDir())
+		Expect(err).To(BeNil())
+	})
+
+	When("the tree has a few saved versions", func() {
+		var v1Hash, v2Hash []byte
+
+		BeforeEach(func() {
+			for i := 0; i < 50; i++ {
+				tree.Set([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i)))
+			}
+			tree.Set([]byte("empty"), []byte{})
+			v1Hash, _, err = tree.SaveVersion()
+			Expect(err).To(BeNil())
+			for i := 0; i < 10; i++ {
+				tree.Set([]byte(fmt.Sprintf("key%d", i)), []byte("updated"))
+			}
+			tree.Remove([]byte("key49"))
+			v2Hash, _, err = tree.SaveVersion()
+			Expect(err).To(BeNil())
+		})
+
+		It("should write multiple chunks and restore the latest version with a matching root hash", func() {
+			manifest, err := tree.WriteSnapshot(2, dir, 256)
+			Expect(err).To(BeNil())
+			Expect(manifest.Version).To(Equal(int64(2)))
+			Expect(len(manifest.Chunks)).To(BeNumerically(">", 1))
+
+			restored, err := tree2.RestoreSnapshot(dir, 2, v2Hash)
+			Expect(err).To(BeNil())
+			Expect(restored).To(Equal(manifest))
+			Expect(tree2.Hash()).To(Equal(v2Hash))
+			Expect(tree2.Version()).To(Equal(int64(2)))
+
+			_, val := tree2.Get([]byte("key0"))
+			Expect(val).To(Equal([]byte("updated")))
+			_, val = tree2.Get([]byte("key49"))
+			Expect(val).To(BeNil())
+			_, val = tree2.Get([]byte("empty"))
+			Expect(val).To(Equal([]byte{}))
+		})
+
+		It("should restore an older version", func() {
+			_, err := tree.WriteSnapshot(1, dir, 0)
+			Expect(err).To(BeNil())
+			_, err = tree2.RestoreSnapshot(dir, 1, v1Hash)
+			Expect(err).To(BeNil())
+			Expect(tree2.Hash()).To(Equal(v1Hash))
+			_, val := tree2.Get([]byte("key49"))
+			Expect(val).To(Equal([]byte("value49")))
+		})
+
+		It("should allow the restored tree to save new versions", func() {
+			_, err := tree.WriteSnapshot(2, dir, 0)
+			Expect(err).To(BeNil())
+			_, err = tree2.RestoreSnapshot(dir, 2, v2Hash)
+			Expect(err).To(BeNil())
+
+			tree.Set([]byte("new"), []byte("value"))
+			expected, _, err := tree.SaveVersion()
+			Expect(err).To(BeNil())
+			tree2.Set([]byte("new"), []byte("value"))
+			hash, version, err := tree2.SaveVersion()
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal(int64(3)))
+			Expect(hash).To(Equal(expected))
+		})
+
+		It("should return error when version does not exist", func() {
+			_, err := tree.WriteSnapshot(10, dir, 0)
+			Expect(err).ToNot(BeNil())
+		})
+
+		It("should return error when a chunk was modified", func() {
+			_, err := tree.WriteSnapshot(2, dir, 256)
+			Expect(err).To(BeNil())
+			chunkPath := filepath.Join(dir, SnapshotChunkFile(1))
+			data, err := ioutil.ReadFile(chunkPath)
+			Expect(err).To(BeNil())
+			data[len(data)-1] ^= 0xFF
+			Expect(ioutil.WriteFile(chunkPath, data, 0600)).To(Succeed())
+
+			_, err = tree2.RestoreSnapshot(dir, 2, v2Hash)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("bad chunk 1: " + ErrSnapshotChunkHashMismatch.Error()))
+		})
+
+		It("should return error when the target tree is not empty", func() {
+			_, err := tree.WriteSnapshot(2, dir, 0)
+			Expect(err).To(BeNil())
+			_, err = tree.RestoreSnapshot(dir, 2, v2Hash)
+			Expect(err).To(Equal(ErrSnapshotTreeNotEmpty))
+		})
+
+		It("should return error when the target tree has unsaved changes", func() {
+			_, err := tree.WriteSnapshot(2, dir, 0)
+			Expect(err).To(BeNil())
+			tree2.Set([]byte("key"), []byte("value"))
+			_, err = tree2.RestoreSnapshot(dir, 2, v2Hash)
+			Expect(err).To(Equal(ErrSnapshotTreeNotEmpty))
+		})
+
+		It("should return error when the snapshot is not for the trusted version", func() {
+			_, err := tree.WriteSnapshot(2, dir, 0)
+			Expect(err).To(BeNil())
+			_, err = tree2.RestoreSnapshot(dir, 1, v2Hash)
+			Expect(err).To(Equal(ErrSnapshotUntrusted))
+			Expect(tree2.Version()).To(Equal(int64(0)))
+		})
+
+		It("should return error when the snapshot is not for the trusted root hash", func() {
+			_, err := tree.WriteSnapshot(2, dir, 0)
+			Expect(err).To(BeNil())
+			_, err = tree2.RestoreSnapshot(dir, 2, v1Hash)
+			Expect(err).To(Equal(ErrSnapshotUntrusted))
+			Expect(tree2.Version()).To(Equal(int64(0)))
+		})
+
+		It("should return error when a node key length exceeds the chunk size", func() {
+			manifest, err := tree.WriteSnapshot(2, dir, 0)
+			Expect(err).To(BeNil())
+
+			// height | version | key length (2^62) with no key bytes
+			data := []byte{0, 2, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x40}
+			Expect(ioutil.WriteFile(filepath.Join(dir, SnapshotChunkFile(0)), data, 0600)).To(Succeed())
+			hash := sha256.Sum256(data)
+			manifest.Chunks = []*SnapshotChunk{{Hash: hex.EncodeToString(hash[:]), Size: int64(len(data))}}
+			bz, _ := json.Marshal(manifest)
+			Expect(ioutil.WriteFile(filepath.Join(dir, SnapshotManifestFile), bz, 0600)).To(Succeed())
+
+			_, err = tree2.RestoreSnapshot(dir, 2, v2Hash)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("failed to decode node in chunk 0: unexpected EOF"))
+		})
+	})
+
+	When("the tree is empty", func() {
+		It("should write and restore a snapshot with no chunks", func() {
+			hash, _, err := tree.SaveVersion()
+			Expect(err).To(BeNil())
+			manifest, err := tree.WriteSnapshot(1, dir, 0)
+			Expect(err).To(BeNil())
+			Expect(manifest.Chunks).To(BeEmpty())
+			_, err = tree2.RestoreSnapshot(dir, 1, hash)
+			Expect(err).To(BeNil())
+			Expect(tree2.Version()).To(Equal(int64(1)))
+		})
+	})
+})
@@ -11,4 +11,6 @@ type Tree interface {
 	Load() (int64, error)
 	WorkingHash() []byte
 	Hash() []byte
+	WriteSnapshot(version int64, dir string, chunkSize int) (*SnapshotManifest, error)
+	RestoreSnapshot(dir string, version int64, rootHash []byte) (*SnapshotManifest, error)
 }
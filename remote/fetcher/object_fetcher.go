@@ -10,9 +10,11 @@ import (
 	plumbing2 "github.com/go-git/go-git/v5/plumbing"
 	"github.com/make-os/kit/config"
 	dht2 "github.com/make-os/kit/net/dht"
+	"github.com/make-os/kit/params"
 	"github.com/make-os/kit/pkgs/logger"
 	"github.com/make-os/kit/remote/plumbing"
 	"github.com/make-os/kit/remote/push/types"
+	"github.com/make-os/kit/util/errors"
 	io2 "github.com/make-os/kit/util/io"
 )
 
@@ -74,6 +76,10 @@ type BasicObjectFetcher struct {
 	queue              chan *Task
 	onObjFetchedCb     func(string, io.ReadSeeker)
 	PackToRepoUnpacker plumbing.PackToRepoUnpacker
+
+	// MaxObjectSize is the maximum size of a single fetched packfile.
+	// Packfiles larger than this are rejected before they are unpacked.
+	MaxObjectSize int64
 }
 
 // NewFetcher creates an instance of BasicObjectFetcher
@@ -85,6 +91,7 @@ func NewFetcher(dht dht2.DHT, cfg *config.AppConfig) *BasicObjectFetcher {
 		queue:              make(chan *Task, 10000),
 		cfg:                cfg,
 		PackToRepoUnpacker: plumbing.UnpackPackfileToRepo,
+		MaxObjectSize:      int64(params.MaxFetchedObjectSize),
 	}
 }
 
@@ -147,7 +154,7 @@ func (f *BasicObjectFetcher) Operation(task *Task) error {
 	streamer := f.dht.ObjectStreamer()
 
 	for _, ref := range task.note.GetPushedReferences() {
-		if plumbing.IsBranch(ref.Name) || plumbing.IsNote(ref.Name) {
+		if plumbing.IsBranch(ref.Name) || plumbing.IsNote(ref.Name) || plumbing.IsMetaReference(ref.Name) {
 
 			// Set end hash only if the pushed reference end hash is non-zero
 			var endHash []byte
@@ -164,6 +171,10 @@ func (f *BasicObjectFetcher) Operation(task *Task) error {
 				GitBinPath:       f.cfg.Node.GitBinPath,
 				ReposDir:         f.cfg.GetRepoRoot(),
 				ResultCB: func(packfile io2.ReadSeekerCloser, hash string) error {
+					if err := f.checkObjectSize(packfile); err != nil {
+						packfile.Close()
+						return err
+					}
 					err := f.PackToRepoUnpacker(task.note.GetTargetRepo(), packfile)
 					if err != nil {
 						packfile.Close()
@@ -224,6 +235,10 @@ func (f *BasicObjectFetcher) Operation(task *Task) error {
 				GitBinPath:       f.cfg.Node.GitBinPath,
 				ReposDir:         f.cfg.GetRepoRoot(),
 				ResultCB: func(packfile io2.ReadSeekerCloser, hash string) error {
+					if err := f.checkObjectSize(packfile); err != nil {
+						packfile.Close()
+						return err
+					}
 					err := f.PackToRepoUnpacker(task.note.GetTargetRepo(), packfile)
 					if err != nil {
 						packfile.Close()
@@ -249,6 +264,28 @@ func (f *BasicObjectFetcher) Operation(task *Task) error {
 	return nil
 }
 
+// checkObjectSize checks that the size of a fetched packfile does not exceed
+// the per-object limit. The packfile's read position is reset to the start.
+func (f *BasicObjectFetcher) checkObjectSize(packfile io.ReadSeeker) error {
+	if f.MaxObjectSize <= 0 {
+		return nil
+	}
+
+	size, err := packfile.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if _, err = packfile.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	if size > f.MaxObjectSize {
+		return errors.FieldError("size", "object exceeds per-object limit")
+	}
+
+	return nil
+}
+
 // do processes a task
 // Try the Operation multiple times using an exponential backoff function.
 // On error, call the task's callback function with the error.
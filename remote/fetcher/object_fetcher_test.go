@@ -129,6 +129,34 @@ var _ = Describe("ObjectFetcher", func() {
 				Expect(err).To(MatchError("bad packfile"))
 			})
 
+			It("should return error when the fetched packfile exceeds the per-object size limit", func() {
+				note := &types.Note{
+					RepoName:   "repo1",
+					References: []*types.PushedReference{{Name: "refs/heads/master", OldHash: oldHash, NewHash: newHash}},
+				}
+
+				unpackCalled := false
+				f.PackToRepoUnpacker = func(repo plumbing.LocalRepo, pack io.ReadSeekerCloser) error {
+					unpackCalled = true
+					return nil
+				}
+				f.MaxObjectSize = 5
+
+				mockDHT.EXPECT().ObjectStreamer().Return(mockObjStreamer)
+				mockF := mockObjStreamer.EXPECT().GetCommitWithAncestors(gomock.Any(), gomock.Any())
+				mockF.DoAndReturn(func(ctx context.Context, args dht2.GetAncestorArgs) (packfiles []io.ReadSeekerCloser, err error) {
+					pack, err := io.LimitedReadToTmpFile(bytes.NewBuffer([]byte("large packfile")), 100)
+					Expect(err).To(BeNil())
+					return nil, args.ResultCB(pack, "")
+				})
+
+				task := fetcher.NewTask(note, func(err error) {})
+				err := f.Operation(task)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal(`"field":"size","msg":"object exceeds per-object limit"`))
+				Expect(unpackCalled).To(BeFalse())
+			})
+
 			It("should call fetcher 'fetched' callback when streamer result callback successfully wrote packfile to repo", func() {
 				note := &types.Note{
 					RepoName:   "repo1",
@@ -2,6 +2,8 @@ package plumbing
 
 import (
 	"bytes"
+	"context"
+	"io"
 	"time"
 
 	"github.com/go-git/go-git/v5/config"
@@ -16,6 +18,9 @@ type CloneOptions struct {
 	Bare          bool
 	ReferenceName string
 	Depth         int
+
+	// Context allows the clone to be cancelled (optional)
+	Context context.Context
 }
 
 // LocalRepo represents a local git repository on disk
@@ -131,6 +136,10 @@ type LocalRepo interface {
 	// ObjectExist checks whether an object exist in the target repository
 	ObjectExist(objHash string) bool
 
+	// ObjectsExist checks whether the given objects exist in the target
+	// repository. Returns a map of each hash to its presence.
+	ObjectsExist(hashes []string) map[string]bool
+
 	// GetObjectSize returns the size of an object
 	GetObjectSize(objHash string) (int64, error)
 
@@ -141,6 +150,16 @@ type LocalRepo interface {
 	// GetObject returns an object
 	GetObject(objHash string) (object.Object, error)
 
+	// WriteObject writes an object to the repository's object store
+	// and returns the hash of the object
+	WriteObject(obj plumbing.EncodedObject) (string, error)
+
+	// GetObjectStore returns the object store backend of the repository
+	GetObjectStore() ObjectStore
+
+	// SetObjectStore sets the object store backend of the repository
+	SetObjectStore(store ObjectStore)
+
 	// GetStorer returns the storage engine of the repository
 	GetStorer() storage.Storer
 
@@ -170,6 +189,29 @@ type LocalRepo interface {
 	// GetFile returns the file as a string
 	GetFile(ref, path string) (res string, err error)
 
+	// GetFileChunk returns a byte range of a file and the size of the file
+	GetFileChunk(ref, path string, offset, length int64) (res []byte, size int64, err error)
+
+	// GetFileBlame returns the last modification of each line of a file
+	GetFileBlame(ref, path string) (res []*BlameLine, err error)
+
+	// GetCodeOwnership returns the share of lines each author last modified
+	// across the files under a path, sorted by ownership in descending order
+	GetCodeOwnership(ref, path string) (res []*CodeOwner, err error)
+
+	// WriteArchive writes a gzip compressed tar archive of the tree of a commit to w
+	WriteArchive(ref string, w io.Writer) error
+
+	// GetContentHash returns a hash of the files in the tree of a commit
+	GetContentHash(ref string) (string, error)
+
+	// EstimateCloneSize estimates the number and total size of objects a
+	// clone of a revision with the given depth and since time would transfer
+	EstimateCloneSize(ref string, depth int, since time.Time) (*CloneSizeEstimate, error)
+
+	// GetObjectStats returns the number of objects of each type in the repository
+	GetObjectStats() (*ObjectStats, error)
+
 	// GetBranches returns a list of branches
 	GetBranches() (branches []string, err error)
 
@@ -179,7 +221,8 @@ type LocalRepo interface {
 	// GetCommits returns commits of a branch or commit hash
 	//  - ref: The target reference name (branch or commit hash)
 	//  - limit: The number of commit to return. 0 means all.
-	GetCommits(ref string, limit int) (res []*CommitResult, err error)
+	//  - order: The order in which commits are walked.
+	GetCommits(ref string, limit int, order CommitOrder) (res []*CommitResult, err error)
 
 	// GetCommit gets a commit by hash
 	//  - hash: The commit hash
@@ -190,12 +233,56 @@ type LocalRepo interface {
 	//  - limit: The number of commit to return. 0 means all.
 	GetCommitAncestors(commitHash string, limit int) (res []*CommitResult, err error)
 
+	// GetFileHistory returns the commits that changed a file, newest first.
+	//  - ref: A commit hash, full reference name or branch name
+	//  - path: The case-sensitive file path
+	//  - limit: The number of commits to return. 0 means all.
+	GetFileHistory(ref, path string, limit int) (res []*FileHistoryEntry, err error)
+
+	// GetMergeBase returns the hash of the best common ancestor of two commits.
+	//  - commitA: The hash of the first commit.
+	//  - commitB: The hash of the second commit.
+	GetMergeBase(commitA, commitB string) (string, error)
+
+	// GetCommitsBetween returns the commits in the history of a commit that
+	// are not in the history of another commit, most recent first.
+	//  - fromHash: The hash of the commit whose history is excluded.
+	//  - toHash: The hash of the commit whose history is walked.
+	GetCommitsBetween(fromHash, toHash string) ([]*CommitResult, error)
+
+	// ExportPatchSeries returns a git-format-patch style patch for each commit
+	// in the history of a commit that is not in the history of another
+	// commit. The patches are ordered from the oldest commit to the newest.
+	//  - fromHash: The hash of the commit whose history is excluded.
+	//  - toHash: The hash of the commit whose history is walked.
+	ExportPatchSeries(fromHash, toHash string) ([]string, error)
+
+	// GetRefsContaining returns the branches and tags whose history includes a commit.
+	//  - commitHash: The hash of the commit.
+	GetRefsContaining(commitHash string) (*RefsContainingResult, error)
+
 	// GetParentAndChildCommitDiff returns the commit diff output between a
 	// child commit and its parent commit(s). If the commit has more than
 	// one parent, the diff will be run for all parents.
 	//  - commitHash: The child commit hash.
 	GetParentAndChildCommitDiff(commitHash string) (*GetCommitDiffResult, error)
 
+	// StreamParentAndChildCommitDiff is like GetParentAndChildCommitDiff but
+	// passes the diff of each changed file to cb as it is produced.
+	//  - commitHash: The child commit hash.
+	//  - cb: Called with the parent commit hash and the diff of a file.
+	StreamParentAndChildCommitDiff(commitHash string, cb func(parentHash, fileDiff string) error) error
+
+	// GetCommitTree returns the entries of the tree of a commit and all of
+	// its sub-trees, in the order they are found in a depth-first walk.
+	//  - commitHash: The commit hash.
+	GetCommitTree(commitHash string) ([]*CommitTreeEntry, error)
+
+	// CompareTags returns the commits and changed files between two tags.
+	//  - fromTag: The name of the older tag.
+	//  - toTag: The name of the newer tag.
+	CompareTags(fromTag, toTag string) (*CompareTagsResult, error)
+
 	// Push performs push to the repository
 	Push(options PushOptions) (progress bytes.Buffer, err error)
 
@@ -210,7 +297,51 @@ type PushOptions struct {
 }
 
 type GetCommitDiffResult struct {
-	Patches []map[string]string `json:"patches"`
+	Patches  []map[string]string            `json:"patches"`
+	Binaries []map[string][]*BinaryFileDiff `json:"binaries"`
+}
+
+// BinaryFileDiff describes a binary file that changed between two commits
+type BinaryFileDiff struct {
+	Path    string `json:"path"`
+	Binary  bool   `json:"binary"`
+	OldSize int64  `json:"oldSize"`
+	NewSize int64  `json:"newSize"`
+}
+
+// CloneSizeEstimate describes the objects a clone is expected to transfer
+type CloneSizeEstimate struct {
+	Objects int   `json:"objects"`
+	Size    int64 `json:"size"`
+}
+
+// ObjectStats describes the number of objects of each type in a repository
+type ObjectStats struct {
+	Commits int `json:"commits"`
+	Trees   int `json:"trees"`
+	Blobs   int `json:"blobs"`
+	Tags    int `json:"tags"`
+}
+
+// ObjectCount describes the number and disk usage of the loose and packed objects of a repository
+type ObjectCount struct {
+	LooseObjects  int   `json:"looseObjects"`
+	LooseSize     int64 `json:"looseSize"`
+	PackedObjects int   `json:"packedObjects"`
+	Packs         int   `json:"packs"`
+	PackedSize    int64 `json:"packedSize"`
+	GarbageSize   int64 `json:"garbageSize"`
+}
+
+// RefsContainingResult describes the references whose history includes a commit
+type RefsContainingResult struct {
+	Branches []string `json:"branches"`
+	Tags     []string `json:"tags"`
+}
+
+type CompareTagsResult struct {
+	Commits []*CommitResult `json:"commits"`
+	Files   []string        `json:"files"`
 }
 
 type CommitSignatory struct {
@@ -219,6 +350,36 @@ type CommitSignatory struct {
 	Timestamp int64  `json:"timestamp"`
 }
 
+// CommitOrder describes the order in which the commits of a history are walked
+type CommitOrder string
+
+const (
+	// CommitOrderDefault is the default order (committer date, newest first)
+	CommitOrderDefault CommitOrder = ""
+
+	// CommitOrderTopological shows no parent before all of its children;
+	// Commits that are not ordered by ancestry are shown by committer date.
+	CommitOrderTopological CommitOrder = "topological"
+
+	// CommitOrderAuthorDate orders commits by author date, newest first
+	CommitOrderAuthorDate CommitOrder = "author-date"
+
+	// CommitOrderCommitterDate orders commits by committer date, newest first
+	CommitOrderCommitterDate CommitOrder = "committer-date"
+
+	// CommitOrderReverse is the default order, oldest first
+	CommitOrderReverse CommitOrder = "reverse"
+)
+
+// CommitOrders is the list of known commit orders
+var CommitOrders = []CommitOrder{
+	CommitOrderDefault,
+	CommitOrderTopological,
+	CommitOrderAuthorDate,
+	CommitOrderCommitterDate,
+	CommitOrderReverse,
+}
+
 type CommitResult struct {
 	Committer    *CommitSignatory `json:"committer"`
 	Author       *CommitSignatory `json:"author"`
@@ -227,6 +388,28 @@ type CommitResult struct {
 	ParentHashes []string         `json:"parents"`
 }
 
+// File change types of a file history entry
+const (
+	FileChangeAdded    = "added"
+	FileChangeModified = "modified"
+	FileChangeDeleted  = "deleted"
+)
+
+// FileHistoryEntry describes a commit that changed a file
+type FileHistoryEntry struct {
+	Commit *CommitResult `json:"commit"`
+	Change string        `json:"change"`
+}
+
+// CommitTreeEntry describes an entry of the tree of a commit
+type CommitTreeEntry struct {
+	Path string `json:"path"`
+	Mode string `json:"mode"`
+	Type string `json:"type"`
+	Hash string `json:"hash"`
+	Size int64  `json:"size"`
+}
+
 type ListPathValue struct {
 	Name              string `json:"name"`
 	BlobHash          string `json:"blobHash"`
@@ -238,6 +421,21 @@ type ListPathValue struct {
 	UpdatedAt         int64  `json:"updatedAt"`
 }
 
+// BlameLine describes the last modification of a line of a file
+type BlameLine struct {
+	Author string `json:"author"`
+	Hash   string `json:"hash"`
+	Date   int64  `json:"date"`
+	Text   string `json:"text"`
+}
+
+// CodeOwner describes the share of lines an author last modified
+type CodeOwner struct {
+	Author    string  `json:"author"`
+	Lines     int     `json:"lines"`
+	Ownership float64 `json:"ownership"`
+}
+
 type LocalConfig struct {
 	Tokens map[string][]string `json:"tokens"`
 }
@@ -282,8 +480,11 @@ type GitModule interface {
 	RefFetch(args RefFetchArgs) error
 	GC(pruneExpire ...string) error
 	Size() (size float64, err error)
+	CountObjects() (*ObjectCount, error)
+	ProjectedPackSize() (int64, error)
 	GetPathLogInfo(path string, revision ...string) (*PathLogInfo, error)
 	DiffCommits(commitA, commitB string) (string, error)
+	DiffCommitsStream(commitA, commitB string, cb func(fileDiff string) error) error
 }
 
 type PathLogInfo struct {
@@ -0,0 +1,25 @@
+package plumbing
+
+import (
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// ObjectStore describes a backend for storing and retrieving git objects.
+// It allows a repository's objects to be kept somewhere other than the
+// local filesystem (e.g. a cloud object store or memory).
+type ObjectStore interface {
+
+	// Get returns an encoded object by its hash.
+	// Returns plumbing.ErrObjectNotFound if the object does not exist.
+	Get(hash plumbing.Hash) (plumbing.EncodedObject, error)
+
+	// Put stores an encoded object and returns its hash
+	Put(obj plumbing.EncodedObject) (plumbing.Hash, error)
+
+	// Exists checks whether an object exists
+	Exists(hash plumbing.Hash) bool
+
+	// Size returns the decompressed size of an object.
+	// Returns plumbing.ErrObjectNotFound if the object does not exist.
+	Size(hash plumbing.Hash) (int64, error)
+}
@@ -154,6 +154,12 @@ type PackObjectArgs struct {
 
 	// Filter selects objects that should be packed by returning true.
 	Filter func(hash plumbing.Hash) bool
+
+	// DeltaWindow is the number of objects each object is compared against
+	// when searching for a delta base. Larger windows produce smaller packs
+	// at the cost of CPU. Zero disables delta compression. The maximum
+	// delta chain depth is fixed by the packfile encoder.
+	DeltaWindow uint
 }
 
 // CommitPacker describes a function for packing an object into a packfile.
@@ -178,7 +184,7 @@ func PackObject(repo LocalRepo, args *PackObjectArgs) (pack io.Reader, objs []pl
 
 	var buf = bytes.NewBuffer(nil)
 	enc := packfile.NewEncoder(buf, repo.GetStorer(), true)
-	_, err = enc.Encode(objs, 0)
+	_, err = enc.Encode(objs, args.DeltaWindow)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to encoded objects to pack format")
 	}
@@ -302,7 +308,7 @@ func UnpackPackfileToRepo(repo LocalRepo, pack io2.ReadSeekerCloser) error {
 			return err
 		}
 
-		_, err = repo.GetStorer().SetEncodedObject(&memObj)
+		_, err = repo.WriteObject(&memObj)
 		if err != nil {
 			return errors.Wrap(err, "failed to write object to repo object database")
 		}
@@ -137,6 +137,31 @@ var _ = Describe("Packfile", func() {
 			})
 		})
 
+		When("delta window is set and the commit has similar files", func() {
+			var commit *object.Commit
+
+			BeforeEach(func() {
+				content := strings.Repeat("the quick brown fox jumps over the lazy dog\n", 200)
+				testutil2.AppendToFile(path, "a.txt", content)
+				testutil2.AppendCommit(path, "b.txt", content+"one more line\n", "commit msg")
+				commitHash := testutil2.GetRecentCommitHash(path, "refs/heads/master")
+				commit, _ = testRepo.CommitObject(plumbing.NewHash(commitHash))
+			})
+
+			It("should produce a smaller pack than with delta compression disabled", func() {
+				pack, objs, err := pl.PackObject(testRepo, &pl.PackObjectArgs{Obj: commit})
+				Expect(err).To(BeNil())
+				noDelta, _ := io.ReadAll(pack)
+
+				pack, deltaObjs, err := pl.PackObject(testRepo, &pl.PackObjectArgs{Obj: commit, DeltaWindow: 10})
+				Expect(err).To(BeNil())
+				delta, _ := io.ReadAll(pack)
+
+				Expect(deltaObjs).To(Equal(objs))
+				Expect(len(delta)).To(BeNumerically("<", len(noDelta)))
+			})
+		})
+
 		Context("object is a commit with files and a tree", func() {
 			var pack io.Reader
 			var err error
@@ -30,6 +30,36 @@ func (c *Comments) Reverse() {
 	}
 }
 
+// CommentThread is a comment and the comments that replied to it
+type CommentThread struct {
+	*Comment
+	Replies []*CommentThread `json:"replies"`
+}
+
+// Threads reconstructs the reply relationships between the comments.
+// Comments that do not reply to another comment in the collection are
+// returned as top-level threads. The order of the comments is preserved.
+func (c Comments) Threads() []*CommentThread {
+	index := make(map[string]*CommentThread)
+	for _, comment := range c {
+		index[comment.Hash] = &CommentThread{Comment: comment, Replies: []*CommentThread{}}
+	}
+
+	var threads = []*CommentThread{}
+	for _, comment := range c {
+		thread := index[comment.Hash]
+		if comment.Body != nil && comment.Body.ReplyTo != "" {
+			if parent, ok := index[comment.Body.ReplyTo]; ok && parent != thread {
+				parent.Replies = append(parent.Replies, thread)
+				continue
+			}
+		}
+		threads = append(threads, thread)
+	}
+
+	return threads
+}
+
 // Comment represent a reference post comment
 type Comment struct {
 	CreatedAt    time.Time             `json:"createdAt"`
@@ -53,6 +83,10 @@ type Post struct {
 	// Closed indicates whether the last post is closed.
 	Closed bool `json:"closed"`
 
+	// ClosedAt is the time of the comment that closed the post.
+	// It is zero if the post is open.
+	ClosedAt time.Time `json:"closedAt,omitempty"`
+
 	// Comment is the first comment of the post.
 	Comment *Comment `json:"comment"`
 }
@@ -284,15 +318,22 @@ func GetPosts(targetRepo LocalRepo, filter func(ref plumbing.ReferenceName) bool
 			return nil, err
 		}
 
-		recentPostBody, _, err := targetRepo.ReadPostBody(recentHash)
+		recentPostBody, recentCommit, err := targetRepo.ReadPostBody(recentHash)
 		if err != nil {
 			return nil, err
 		}
 
+		var closedAt time.Time
+		closed := pointer.GetBool(recentPostBody.Close)
+		if closed && recentCommit != nil {
+			closedAt = recentCommit.Committer.When
+		}
+
 		posts = append(posts, &Post{
-			Name:   ref.String(),
-			Title:  postBody.Title,
-			Closed: pointer.GetBool(recentPostBody.Close),
+			Name:     ref.String(),
+			Title:    postBody.Title,
+			Closed:   closed,
+			ClosedAt: closedAt,
 			Comment: &Comment{
 				Body:        postBody,
 				Hash:        commit.Hash.String(),
@@ -365,7 +406,7 @@ func (b *PostBody) IncludesAdminFields() bool {
 		return true
 	}
 	if b.MergeRequestFields != nil && (len(b.BaseBranch) > 0 || len(b.BaseBranchHash) > 0 ||
-		len(b.TargetBranch) > 0 || len(b.TargetBranchHash) > 0) {
+		len(b.TargetBranch) > 0 || len(b.TargetBranchHash) > 0 || len(b.Reviewers) > 0) {
 		return true
 	}
 	return false
@@ -402,6 +443,10 @@ func PostBodyFromContentFrontMatter(cfm *pageparser.ContentFrontMatter) *PostBod
 		b.Assignees = assignees
 	}
 
+	if ob.Has("reviewers") {
+		b.Reviewers = cast.ToStringSlice(ob.Get("reviewers").InterSlice())
+	}
+
 	return b
 }
 
@@ -85,6 +85,36 @@ var _ = Describe("Post", func() {
 		})
 	})
 
+	Describe("Comments.Threads", func() {
+		It("should nest replies under the comments they replied to", func() {
+			comments := plumbing.Comments{
+				{Hash: "h1", Body: &plumbing.PostBody{}},
+				{Hash: "h2", Body: &plumbing.PostBody{ReplyTo: "h1"}},
+				{Hash: "h3", Body: &plumbing.PostBody{}},
+				{Hash: "h4", Body: &plumbing.PostBody{ReplyTo: "h2"}},
+				{Hash: "h5", Body: &plumbing.PostBody{ReplyTo: "h1"}},
+			}
+			threads := comments.Threads()
+			Expect(threads).To(HaveLen(2))
+			Expect(threads[0].Hash).To(Equal("h1"))
+			Expect(threads[0].Replies).To(HaveLen(2))
+			Expect(threads[0].Replies[0].Hash).To(Equal("h2"))
+			Expect(threads[0].Replies[0].Replies).To(HaveLen(1))
+			Expect(threads[0].Replies[0].Replies[0].Hash).To(Equal("h4"))
+			Expect(threads[0].Replies[1].Hash).To(Equal("h5"))
+			Expect(threads[1].Hash).To(Equal("h3"))
+			Expect(threads[1].Replies).To(BeEmpty())
+		})
+
+		It("should return a comment replying to an unknown comment as a top-level thread", func() {
+			comments := plumbing.Comments{{Hash: "h1", Body: &plumbing.PostBody{ReplyTo: "unknown"}}, {Hash: "h2"}}
+			threads := comments.Threads()
+			Expect(threads).To(HaveLen(2))
+			Expect(threads[0].Hash).To(Equal("h1"))
+			Expect(threads[1].Hash).To(Equal("h2"))
+		})
+	})
+
 	Describe(".GetFreePostID", func() {
 		It("should return error when post reference type is unknown", func() {
 			_, err := plumbing.GetFreePostID(mockRepo, 1, "unknown")
@@ -188,6 +218,33 @@ var _ = Describe("Post", func() {
 			Expect(posts).To(HaveLen(1))
 		})
 
+		It("should set the close time of a post closed by its most recent comment", func() {
+			testutil2.AppendCommit(path, "file.txt", "some text 1", "commit 1")
+			testutil2.CreateCheckoutOrphanBranch(path, "issues/1")
+			testutil2.AppendCommit(path, "body", "some text 1", "commit 1")
+			isIssue := func(ref plumbing2.ReferenceName) bool {
+				return strings.Contains(ref.String(), plumbing.IssueBranchPrefix)
+			}
+			posts, err := plumbing.GetPosts(testRepo, isIssue)
+			Expect(err).To(BeNil())
+			Expect(posts).To(HaveLen(1))
+			issue := posts[0].(*plumbing.Post)
+			Expect(issue.Closed).To(BeFalse())
+			Expect(issue.ClosedAt.IsZero()).To(BeTrue())
+
+			testutil2.ExecGit(path, "rm", "-q", "body")
+			testutil2.AppendCommit(path, "body", "---\nclose: true\n---\n", "commit 2")
+			posts, err = plumbing.GetPosts(testRepo, isIssue)
+			Expect(err).To(BeNil())
+			Expect(posts).To(HaveLen(1))
+			issue = posts[0].(*plumbing.Post)
+			Expect(issue.Closed).To(BeTrue())
+			recentHash := testutil2.GetRecentCommitHash(path, "issues/1")
+			_, commit, err := testRepo.ReadPostBody(recentHash)
+			Expect(err).To(BeNil())
+			Expect(issue.ClosedAt).To(Equal(commit.Committer.When))
+		})
+
 		It("should return err when a post reference does not include body file", func() {
 			testutil2.AppendCommit(path, "file.txt", "some text 1", "commit 1")
 			testutil2.CreateCheckoutOrphanBranch(path, "issues/1")
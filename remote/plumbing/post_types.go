@@ -24,4 +24,46 @@ type MergeRequestFields struct {
 
 	// TargetBranchHash is the hash of the source branch
 	TargetBranchHash string `yaml:"targetHash,omitempty" msgpack:"targetHash,omitempty" json:"targetBranchHash,omitempty"`
+
+	// Reviewers are the push keys requested to review the merge request
+	Reviewers []string `yaml:"reviewers,flow,omitempty" msgpack:"reviewers,omitempty" json:"reviewers,omitempty"`
+}
+
+// PostBundleVersion is the current version of the post bundle format
+const PostBundleVersion = 1
+
+// Post bundle kinds
+const (
+	PostBundleKindIssue        = "issue"
+	PostBundleKindMergeRequest = "mergeRequest"
+)
+
+// PostBundle is a portable collection of posts of the same kind.
+// It is used to move posts between repositories.
+type PostBundle struct {
+
+	// Version is the version of the bundle format
+	Version int `json:"version"`
+
+	// Kind is the kind of posts in the bundle
+	Kind string `json:"kind"`
+
+	// Posts are the bundled posts ordered from the oldest to the newest
+	Posts []*BundledPost `json:"posts"`
+}
+
+// BundledPost is a post and its comments in a post bundle
+type BundledPost struct {
+
+	// Reference is the full reference name of the post
+	Reference string `json:"reference"`
+
+	// Title is the title of the post
+	Title string `json:"title"`
+
+	// Closed indicates whether the post is closed
+	Closed bool `json:"closed"`
+
+	// Comments are the comments of the post ordered from the oldest to the newest
+	Comments Comments `json:"comments"`
 }
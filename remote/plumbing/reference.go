@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"strings"
 
 	"github.com/go-git/go-git/v5/plumbing"
 )
@@ -11,6 +12,8 @@ import (
 var (
 	IssueBranchPrefix        = "issues"
 	MergeRequestBranchPrefix = "merges"
+	MetaReferencePrefix      = "refs/meta/"
+	UpstreamReferencePrefix  = "refs/upstream/"
 )
 
 // IsBranch checks whether a reference name indicates a branch
@@ -56,7 +59,7 @@ func IsMergeRequestReferencePath(name string) bool {
 
 // IsReference checks the given name is a reference path or full reference name
 func IsReference(name string) bool {
-	re := "^refs/(heads|tags|notes)((/[a-z0-9_-]+)+)?$"
+	re := "^refs/(heads|tags|notes|meta)((/[a-z0-9_-]+)+)?$"
 	return regexp.MustCompile(re).MatchString(name)
 }
 
@@ -70,6 +73,24 @@ func IsNote(name string) bool {
 	return plumbing.ReferenceName(name).IsNote()
 }
 
+// MakeUpstreamReference returns the reference under which a reference
+// of an upstream repository is staged in a fork.
+// Example: refs/heads/master -> refs/upstream/heads/master
+func MakeUpstreamReference(name string) string {
+	return UpstreamReferencePrefix + strings.TrimPrefix(name, "refs/")
+}
+
+// IsMetaReference checks whether a reference name indicates a meta reference.
+// Meta references store repo-level configuration (e.g CI and policy files).
+func IsMetaReference(name string) bool {
+	return strings.HasPrefix(name, MetaReferencePrefix) && len(name) > len(MetaReferencePrefix)
+}
+
+// MakeMetaReference creates a meta reference
+func MakeMetaReference(name string) string {
+	return MetaReferencePrefix + name
+}
+
 // MakeIssueReference creates an issue reference
 func MakeIssueReference(id interface{}) string {
 	return fmt.Sprintf("refs/heads/%s/%v", IssueBranchPrefix, id)
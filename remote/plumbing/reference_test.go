@@ -95,6 +95,22 @@ var _ = Describe("Common", func() {
 		})
 	})
 
+	Describe(".MakeUpstreamReference()", func() {
+		It("should return the staging reference of an upstream reference", func() {
+			Expect(plumbing.MakeUpstreamReference("refs/heads/master")).To(Equal("refs/upstream/heads/master"))
+			Expect(plumbing.MakeUpstreamReference("refs/tags/v1")).To(Equal("refs/upstream/tags/v1"))
+		})
+	})
+
+	Describe(".IsMetaReference()", func() {
+		Specify("that it returns true for valid meta reference or false for invalids", func() {
+			Expect(plumbing.IsMetaReference("refs/meta/ci")).To(BeTrue())
+			Expect(plumbing.IsMetaReference("refs/meta/")).To(BeFalse())
+			Expect(plumbing.IsMetaReference("refs/heads/meta/ci")).To(BeFalse())
+			Expect(plumbing.IsMetaReference("refs/notes/note1")).To(BeFalse())
+		})
+	})
+
 	Describe(".IsBranch", func() {
 		Specify("that it returns true for valid branch reference or false for invalids", func() {
 			Expect(plumbing.IsBranch("refs/heads/branch1")).To(BeTrue())
@@ -118,6 +134,8 @@ var _ = Describe("Common", func() {
 			Expect(plumbing.IsReference("refs/heads")).To(BeTrue())
 			Expect(plumbing.IsReference("refs/tags")).To(BeTrue())
 			Expect(plumbing.IsReference("refs/notes")).To(BeTrue())
+			Expect(plumbing.IsReference("refs/meta")).To(BeTrue())
+			Expect(plumbing.IsReference("refs/meta/ci")).To(BeTrue())
 		})
 	})
 
@@ -48,8 +48,8 @@ func Revert(
 		oldStateRef := findRefInCol(ref.Item.GetName(), prevState.GetReferences())
 		refname := ref.Item.GetName()
 
-		// For branch references
-		if IsBranch(refname) {
+		// For branch and meta references
+		if IsBranch(refname) || IsMetaReference(refname) {
 			acts, err := GetBranchRevertActions(ref, oldStateRef)
 			if err != nil {
 				return nil, err
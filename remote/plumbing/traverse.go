@@ -2,8 +2,10 @@ package plumbing
 
 import (
 	"fmt"
+	"io/ioutil"
 
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	types2 "github.com/make-os/kit/types"
 	"github.com/pkg/errors"
@@ -246,3 +248,136 @@ func WalkCommitHistoryWithIteratee(repo LocalRepo, args *WalkCommitHistoryArgs)
 
 	return err
 }
+
+// GetMissingObjects walks the objects reachable from the start object and
+// returns the hashes of the reachable objects that do not exist locally.
+// Missing objects cannot be traversed; Objects reachable only through a
+// missing object are not discovered.
+func GetMissingObjects(repo LocalRepo, startHash string) (missing []string, err error) {
+	var visited = map[string]struct{}{}
+	var queue = []string{startHash}
+	for len(queue) > 0 {
+		hash := queue[0]
+		queue = queue[1:]
+		if _, ok := visited[hash]; ok {
+			continue
+		}
+		visited[hash] = struct{}{}
+
+		obj, err := repo.GetObject(hash)
+		if err != nil {
+			if err == plumbing.ErrObjectNotFound {
+				missing = append(missing, hash)
+				continue
+			}
+			return nil, err
+		}
+
+		switch o := obj.(type) {
+		case *object.Commit:
+			queue = append(queue, o.TreeHash.String())
+			for _, parent := range o.ParentHashes {
+				queue = append(queue, parent.String())
+			}
+		case *object.Tree:
+			for _, entry := range o.Entries {
+				if entry.Mode == filemode.Submodule {
+					continue
+				}
+				queue = append(queue, entry.Hash.String())
+			}
+		case *object.Tag:
+			queue = append(queue, o.Target.String())
+		}
+	}
+	return missing, nil
+}
+
+// VerifyReachableObjects walks the objects reachable from the start object and
+// returns the hashes of the reachable objects that do not exist locally and
+// those whose content does not hash to their ID. Objects already in visited
+// are skipped, allowing the caller to share work across several start objects.
+// Objects reachable only through a missing or corrupt object are not discovered.
+func VerifyReachableObjects(
+	repo LocalRepo,
+	startHash string,
+	visited map[string]struct{},
+) (missing, corrupt []string) {
+	if visited == nil {
+		visited = map[string]struct{}{}
+	}
+
+	store := repo.GetObjectStore()
+	var queue = []string{startHash}
+	for len(queue) > 0 {
+
+		// Collect the unvisited objects of the current level
+		var level []string
+		for _, hash := range queue {
+			if _, ok := visited[hash]; ok {
+				continue
+			}
+			visited[hash] = struct{}{}
+			level = append(level, hash)
+		}
+		queue = nil
+
+		exist := repo.ObjectsExist(level)
+		for _, hash := range level {
+			if !exist[hash] {
+				missing = append(missing, hash)
+				continue
+			}
+
+			encObj, err := store.Get(plumbing.NewHash(hash))
+			if err != nil {
+				corrupt = append(corrupt, hash)
+				continue
+			}
+
+			ok, err := verifyObjectHash(encObj, hash)
+			if err != nil || !ok {
+				corrupt = append(corrupt, hash)
+				continue
+			}
+
+			obj, err := object.DecodeObject(repo.GetStorer(), encObj)
+			if err != nil {
+				corrupt = append(corrupt, hash)
+				continue
+			}
+
+			switch o := obj.(type) {
+			case *object.Commit:
+				queue = append(queue, o.TreeHash.String())
+				for _, parent := range o.ParentHashes {
+					queue = append(queue, parent.String())
+				}
+			case *object.Tree:
+				for _, entry := range o.Entries {
+					if entry.Mode == filemode.Submodule {
+						continue
+					}
+					queue = append(queue, entry.Hash.String())
+				}
+			case *object.Tag:
+				queue = append(queue, o.Target.String())
+			}
+		}
+	}
+	return missing, corrupt
+}
+
+// verifyObjectHash checks whether the content of an encoded object hashes to the given hash.
+func verifyObjectHash(obj plumbing.EncodedObject, hash string) (bool, error) {
+	rdr, err := obj.Reader()
+	if err != nil {
+		return false, err
+	}
+	defer rdr.Close()
+	content, err := ioutil.ReadAll(rdr)
+	if err != nil {
+		return false, err
+	}
+	return plumbing.ComputeHash(obj.Type(), content).String() == hash, nil
+}
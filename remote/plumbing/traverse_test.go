@@ -2,9 +2,11 @@ package plumbing_test
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
@@ -425,6 +427,97 @@ var _ = Describe("Traverse", func() {
 		})
 	})
 
+	Describe(".GetMissingObjects", func() {
+		removeObject := func(hash string) {
+			err := os.Remove(filepath.Join(path, ".git", "objects", hash[:2], hash[2:]))
+			Expect(err).To(BeNil())
+		}
+
+		It("should return no hash when all reachable objects exist", func() {
+			testutil2.AppendCommit(path, "file.txt", "some text", "commit msg")
+			headHash := testutil2.GetRecentCommitHash(path, "refs/heads/master")
+			missing, err := plumbing2.GetMissingObjects(testRepo, headHash)
+			Expect(err).To(BeNil())
+			Expect(missing).To(BeEmpty())
+		})
+
+		It("should return hash of a missing blob", func() {
+			testutil2.AppendCommit(path, "file.txt", "some text", "commit msg")
+			headHash := testutil2.GetRecentCommitHash(path, "refs/heads/master")
+			blobHash := strings.TrimSpace(string(testutil2.ExecGit(path, "rev-parse", "HEAD:file.txt")))
+			removeObject(blobHash)
+			missing, err := plumbing2.GetMissingObjects(testRepo, headHash)
+			Expect(err).To(BeNil())
+			Expect(missing).To(Equal([]string{blobHash}))
+		})
+
+		It("should return hash of a missing parent commit", func() {
+			testutil2.AppendCommit(path, "file.txt", "some text", "commit msg")
+			parentHash := testutil2.GetRecentCommitHash(path, "refs/heads/master")
+			testutil2.AppendCommit(path, "file.txt", "some text 2", "commit msg 2")
+			headHash := testutil2.GetRecentCommitHash(path, "refs/heads/master")
+			removeObject(parentHash)
+			missing, err := plumbing2.GetMissingObjects(testRepo, headHash)
+			Expect(err).To(BeNil())
+			Expect(missing).To(Equal([]string{parentHash}))
+		})
+
+		It("should return start hash when it is missing", func() {
+			missing, err := plumbing2.GetMissingObjects(testRepo, "8d998c7de21bbe561f7992bb983cef4b1554993b")
+			Expect(err).To(BeNil())
+			Expect(missing).To(Equal([]string{"8d998c7de21bbe561f7992bb983cef4b1554993b"}))
+		})
+	})
+
+	Describe(".VerifyReachableObjects", func() {
+		objPath := func(hash string) string {
+			return filepath.Join(path, ".git", "objects", hash[:2], hash[2:])
+		}
+
+		It("should return no hash when all reachable objects exist and are intact", func() {
+			testutil2.AppendCommit(path, "file.txt", "some text", "commit msg")
+			headHash := testutil2.GetRecentCommitHash(path, "refs/heads/master")
+			missing, corrupt := plumbing2.VerifyReachableObjects(testRepo, headHash, nil)
+			Expect(missing).To(BeEmpty())
+			Expect(corrupt).To(BeEmpty())
+		})
+
+		It("should return hash of a missing blob", func() {
+			testutil2.AppendCommit(path, "file.txt", "some text", "commit msg")
+			headHash := testutil2.GetRecentCommitHash(path, "refs/heads/master")
+			blobHash := strings.TrimSpace(string(testutil2.ExecGit(path, "rev-parse", "HEAD:file.txt")))
+			Expect(os.Remove(objPath(blobHash))).To(BeNil())
+			missing, corrupt := plumbing2.VerifyReachableObjects(testRepo, headHash, nil)
+			Expect(missing).To(Equal([]string{blobHash}))
+			Expect(corrupt).To(BeEmpty())
+		})
+
+		It("should return hash of an object whose content does not match its hash", func() {
+			testutil2.AppendCommit(path, "file.txt", "some text", "commit msg")
+			testutil2.AppendCommit(path, "file2.txt", "other text", "commit msg 2")
+			headHash := testutil2.GetRecentCommitHash(path, "refs/heads/master")
+			blobHash := strings.TrimSpace(string(testutil2.ExecGit(path, "rev-parse", "HEAD:file.txt")))
+			otherHash := strings.TrimSpace(string(testutil2.ExecGit(path, "rev-parse", "HEAD:file2.txt")))
+			data, err := ioutil.ReadFile(objPath(otherHash))
+			Expect(err).To(BeNil())
+			Expect(os.Remove(objPath(blobHash))).To(BeNil())
+			Expect(ioutil.WriteFile(objPath(blobHash), data, 0644)).To(BeNil())
+			missing, corrupt := plumbing2.VerifyReachableObjects(testRepo, headHash, nil)
+			Expect(missing).To(BeEmpty())
+			Expect(corrupt).To(Equal([]string{blobHash}))
+		})
+
+		It("should skip objects already visited", func() {
+			testutil2.AppendCommit(path, "file.txt", "some text", "commit msg")
+			headHash := testutil2.GetRecentCommitHash(path, "refs/heads/master")
+			visited := map[string]struct{}{headHash: {}}
+			Expect(os.Remove(objPath(headHash))).To(BeNil())
+			missing, corrupt := plumbing2.VerifyReachableObjects(testRepo, headHash, visited)
+			Expect(missing).To(BeEmpty())
+			Expect(corrupt).To(BeEmpty())
+		})
+	})
+
 	Describe(".WalkBack", func() {
 		startHash := "e070e3147d617e026e6ac08f1aac9ca3d0ae561a"
 		It("should return error when unable to get start object", func() {
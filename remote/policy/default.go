@@ -33,6 +33,8 @@ func AddDefaultPolicies(config *state.RepoConfig) {
 		&state.Policy{Subject: "contrib", Object: "refs/tags", Action: PolicyActionDelete},  // can delete any tags
 		&state.Policy{Subject: "contrib", Object: "refs/notes", Action: PolicyActionWrite},  // can create notes
 		&state.Policy{Subject: "contrib", Object: "refs/notes", Action: PolicyActionDelete}, // can delete any notes
+		&state.Policy{Subject: "contrib", Object: "refs/meta", Action: PolicyActionWrite},   // can create meta references
+		&state.Policy{Subject: "contrib", Object: "refs/meta", Action: PolicyActionDelete},  // can delete meta references
 
 		// Contributor default issue policies
 		&state.Policy{Subject: "contrib", Object: issueRefPath, Action: PolicyActionDelete}, // can delete issues
@@ -47,6 +47,8 @@ func CheckPolicy(enforcer EnforcerFunc, reference string, isRefCreator bool, pus
 		rootDir = rootDir + "tags"
 	} else if plumbing.IsNote(reference) {
 		rootDir = rootDir + "notes"
+	} else if plumbing.IsMetaReference(reference) {
+		rootDir = rootDir + "meta"
 	} else {
 		if plumbing.IsReference(reference) {
 			rootDir = reference
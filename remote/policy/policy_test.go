@@ -398,6 +398,15 @@ var _ = Describe("Auth", func() {
 				err = policy.CheckPolicy(enforcer, "refs/heads/master", false, pushAddrA, true, allowAction)
 				Expect(err).To(BeNil())
 			})
+
+			It("should return nil when action is allowed for subject:contrib, object:refs/meta", func() {
+				policies := [][]*state.Policy{
+					{{Subject: "contrib", Object: "refs/meta", Action: allowAction}},
+				}
+				enforcer = policy.GetPolicyEnforcer(policies)
+				err = policy.CheckPolicy(enforcer, "refs/meta/ci", false, pushAddrA, true, allowAction)
+				Expect(err).To(BeNil())
+			})
 		})
 
 		When("pusher is not a contributor", func() {
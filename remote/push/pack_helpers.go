@@ -67,6 +67,10 @@ func MakeReferenceUpdateRequestPack(note pushtypes.PushNote) (io.ReadSeeker, err
 	return bytes.NewReader(buf.Bytes()), nil
 }
 
+// GetSizeOfObjectsFunc describes a function for getting the size
+// of objects required to fulfil a push note
+type GetSizeOfObjectsFunc func(note pushtypes.PushNote) (uint64, error)
+
 // GetSizeOfObjects returns the size of objects required to fulfil the push note.
 func GetSizeOfObjects(note pushtypes.PushNote) (uint64, error) {
 	repo := note.GetTargetRepo()
@@ -28,6 +28,7 @@ type PushPool struct {
 	refNonceIdx refNonceIndex    // Helps keep track of the nonce of note's references
 	logic       core.Logic       // The application logic manager
 	seen        *cache.Cache     // Helps keep track of notes recently seen; even though they are no longer in the pool
+	now         func() time.Time // Returns the current time
 }
 
 // NewPushPool creates an instance of PushPool
@@ -41,6 +42,7 @@ func NewPushPool(cap int, logic core.Logic) *PushPool {
 		refNonceIdx: refNonceIndex(map[string]uint64{}),
 		seen:        cache.NewCache(1000),
 		logic:       logic,
+		now:         time.Now,
 	}
 
 	tick := time.NewTicker(params.PushPoolCleanUpInt)
@@ -91,7 +93,7 @@ func (p *PushPool) Add(note types.PushNote) error {
 	}
 
 	// Create new pool item
-	item := newItem(note.(*types.Note))
+	item := newItem(note.(*types.Note), p.now())
 
 	// Calculate and set fee rate
 	billableTxSize := decimal.NewFromFloat(float64(note.SizeForFeeCal()))
@@ -209,7 +211,7 @@ func (p *PushPool) removeOld() {
 	p.gmx.Lock()
 	defer p.gmx.Unlock()
 	finalTxs := funk.Filter(p.container, func(o *containerItem) bool {
-		if time.Now().Sub(o.TimeAdded).Seconds() >= params.PushPoolItemTTL.Seconds() {
+		if p.now().Sub(o.TimeAdded).Seconds() >= params.PushPoolItemTTL.Seconds() {
 			p.removeOps(o.Note)
 			return false
 		}
@@ -218,6 +220,46 @@ func (p *PushPool) removeOld() {
 	p.container = finalTxs.([]*containerItem)
 }
 
+// MarkEndorsed marks a push note as having received a quorum of endorsements
+func (p *PushPool) MarkEndorsed(noteID string) {
+	p.gmx.Lock()
+	defer p.gmx.Unlock()
+	if item := p.noteIdx.get(noteID); item != nil {
+		item.Endorsed = true
+	}
+}
+
+// RemoveUnendorsed removes and returns push notes that have stayed in the
+// pool for up to the given timeout without being marked as endorsed.
+func (p *PushPool) RemoveUnendorsed(timeout time.Duration) (removed []types.PushNote) {
+	p.gmx.Lock()
+	defer p.gmx.Unlock()
+	now := p.now()
+	finalTxs := funk.Filter(p.container, func(o *containerItem) bool {
+		if !o.Endorsed && now.Sub(o.TimeAdded) >= timeout {
+			p.removeOps(o.Note)
+			removed = append(removed, o.Note)
+			return false
+		}
+		return true
+	})
+	p.container = finalTxs.([]*containerItem)
+	return
+}
+
+// GetUnendorsed returns push notes that have not been marked as endorsed,
+// in the order they were added to the pool.
+func (p *PushPool) GetUnendorsed() (pending []*types.PendingNote) {
+	p.gmx.RLock()
+	defer p.gmx.RUnlock()
+	for _, item := range p.container {
+		if !item.Endorsed {
+			pending = append(pending, &types.PendingNote{Note: item.Note, TimeAdded: item.TimeAdded})
+		}
+	}
+	return
+}
+
 // Len returns the number of push notes in the pool
 func (p *PushPool) Len() int {
 	p.gmx.RLock()
@@ -229,6 +271,7 @@ type containerItem struct {
 	Note      *types.Note
 	FeeRate   util.String
 	TimeAdded time.Time
+	Endorsed  bool
 }
 
 // containerIndex stores hashes of push notes in the container
@@ -317,7 +360,7 @@ func (i *refNonceIndex) remove(refKey string) {
 }
 
 // newItem creates an instance of ContainerItem
-func newItem(note *types.Note) *containerItem {
-	item := &containerItem{Note: note, TimeAdded: time.Now()}
+func newItem(note *types.Note, timeAdded time.Time) *containerItem {
+	item := &containerItem{Note: note, TimeAdded: timeAdded}
 	return item
 }
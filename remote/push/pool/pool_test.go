@@ -467,6 +467,64 @@ var _ = Describe("PushPool", func() {
 			Expect(pool.HasSeen(note.ID().String())).To(BeFalse())
 		})
 	})
+
+	Describe(".RemoveUnendorsed", func() {
+		var now time.Time
+
+		BeforeEach(func() {
+			now = time.Now()
+			pool = NewPushPool(2, mockLogic)
+			pool.now = func() time.Time { return now }
+			Expect(pool.Add(note)).To(BeNil())
+		})
+
+		It("should not remove note that has not reached the timeout", func() {
+			now = now.Add(9 * time.Second)
+			Expect(pool.RemoveUnendorsed(10 * time.Second)).To(BeEmpty())
+			Expect(pool.Len()).To(Equal(1))
+		})
+
+		It("should remove and return note that has reached the timeout", func() {
+			now = now.Add(10 * time.Second)
+			removed := pool.RemoveUnendorsed(10 * time.Second)
+			Expect(removed).To(HaveLen(1))
+			Expect(removed[0].ID()).To(Equal(note.ID()))
+			Expect(pool.Len()).To(Equal(0))
+			Expect(pool.noteIdx).To(BeEmpty())
+			Expect(pool.refIdx).To(BeEmpty())
+			Expect(pool.refNonceIdx).To(BeEmpty())
+		})
+
+		It("should not remove note that was marked as endorsed", func() {
+			pool.MarkEndorsed(note.ID().String())
+			now = now.Add(time.Minute)
+			Expect(pool.RemoveUnendorsed(10 * time.Second)).To(BeEmpty())
+			Expect(pool.Len()).To(Equal(1))
+		})
+	})
+
+	Describe(".GetUnendorsed", func() {
+		var now time.Time
+
+		BeforeEach(func() {
+			now = time.Now()
+			pool = NewPushPool(2, mockLogic)
+			pool.now = func() time.Time { return now }
+			Expect(pool.Add(note)).To(BeNil())
+		})
+
+		It("should return note that has not been marked as endorsed", func() {
+			pending := pool.GetUnendorsed()
+			Expect(pending).To(HaveLen(1))
+			Expect(pending[0].Note.ID()).To(Equal(note.ID()))
+			Expect(pending[0].TimeAdded).To(Equal(now))
+		})
+
+		It("should not return note that was marked as endorsed", func() {
+			pool.MarkEndorsed(note.ID().String())
+			Expect(pool.GetUnendorsed()).To(BeEmpty())
+		})
+	})
 })
 
 var _ = Describe("refNonceIndex", func() {
@@ -39,6 +39,8 @@ type BasicHandler struct {
 	OldState             plumbing.RepoRefsState // The old state of the repo before the current push was written
 	PushReader           *Reader                // The push reader for reading pushed git objects
 	NoteID               string                 // The push note unique ID
+	PriorTxHash          string                 // The push tx hash of an identical push recently accepted
+	contentKey           string                 // The content key of the pushed references
 	reversed             bool
 	ChangeValidator      validation.ChangeValidatorFunc      // Repository state change validator
 	Reverter             plumbing.RevertFunc                 // Repository state reverser function
@@ -76,32 +78,63 @@ func NewHandler(
 }
 
 // WaitForPushTx waits for the final push transaction to be created and added to the mempool.
-// It will return error if the tx was rejected.
-// An error is returned if the tx was not successfully added to the pool after 15 minutes.
+// It will return error if the tx was rejected or the push note was dropped from the push pool.
+// An error is returned if the tx was not successfully added to the pool after 1 minute.
 // On success, it returns the tx hash
 func (h *BasicHandler) WaitForPushTx() chan interface{} {
 	ch := make(chan interface{}, 1)
+
+	// Return the tx hash of the earlier push if this push was a duplicate
+	if h.PriorTxHash != "" {
+		ch <- h.PriorTxHash
+		return ch
+	}
+
+	// Subscribe before waiting so that no event is missed between iterations
+	bus := h.Server.Cfg().G().Bus
+	added := bus.On(memtypes.EvtMempoolTxAdded)
+	rejected := bus.On(memtypes.EvtMempoolTxRejected)
+	dropped := bus.On(types.EvtPushNoteDropped)
+
 	go func() {
-		bus := h.Server.Cfg().G().Bus
-		for len(ch) == 0 && !config.GetInterrupt().IsClosed() {
+		defer func() {
+			bus.Off(memtypes.EvtMempoolTxAdded, added)
+			bus.Off(memtypes.EvtMempoolTxRejected, rejected)
+			bus.Off(types.EvtPushNoteDropped, dropped)
+		}()
+
+		timeout := time.After(1 * time.Minute)
+		for {
 			select {
-			case evt := <-bus.Once(memtypes.EvtMempoolTxAdded):
+			case evt := <-added:
 				tx := evt.Args[1].(coretypes.BaseTx)
 				if tx.Is(txns.TxTypePush) && tx.(*txns.TxPush).GetNoteID() == h.NoteID {
+					if h.contentKey != "" {
+						h.Server.RegisterRecentPush(h.contentKey, tx.GetHash().String())
+					}
 					ch <- tx.GetHash().String()
 					return
 				}
 
-			case evt := <-bus.Once(memtypes.EvtMempoolTxRejected):
+			case evt := <-rejected:
 				tx := evt.Args[1].(coretypes.BaseTx)
 				if tx.Is(txns.TxTypePush) && tx.(*txns.TxPush).GetNoteID() == h.NoteID {
 					ch <- evt.Args[0].(error)
 					return
 				}
 
-			case <-time.After(1 * time.Minute):
+			case evt := <-dropped:
+				if evt.Args[1].(string) == h.NoteID {
+					ch <- fmt.Errorf("push note was dropped (reason: %s)", evt.Args[0].(string))
+					return
+				}
+
+			case <-timeout:
 				ch <- fmt.Errorf("timed out while waiting for push tx to be added to mempool")
 				return
+
+			case <-*config.GetInterrupt():
+				return
 			}
 		}
 	}()
@@ -347,6 +380,15 @@ func (h *BasicHandler) HandleUpdate(targetNote types.PushNote) (err error) {
 	//    for re-sync.
 	var note = targetNote
 	if note == nil {
+
+		// Skip the push if it is identical to a recently accepted push
+		h.contentKey = MakePushContentKey(h.TxDetails.GetRepoName(), h.TxDetails.GetRepoNamespace(), h.PushReader.References)
+		if txHash := h.Server.GetRecentPushTx(h.contentKey); txHash != "" {
+			h.pktEnc.Encode(plumbing.SidebandInfoln("push is identical to a recent push; skipped"))
+			h.PriorTxHash = txHash
+			return nil
+		}
+
 		h.pktEnc.Encode(plumbing.SidebandInfoln("creating and validating push note"))
 		note, err = h.createPushNote()
 		if err != nil {
@@ -367,6 +409,12 @@ func (h *BasicHandler) HandleUpdate(targetNote types.PushNote) (err error) {
 	return nil
 }
 
+// MakePushContentKey returns a key that identifies a push by its target
+// repository and the old and new hashes of its references.
+func MakePushContentKey(repoName, namespace string, refs PackedReferences) string {
+	return crypto.Hash20Hex([]byte(namespace + "/" + repoName + refs.ID()))
+}
+
 // HandlePushNote implements Handler by handing incoming push note
 func (h *BasicHandler) HandlePushNote(note types.PushNote) (err error) {
 
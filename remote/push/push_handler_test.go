@@ -939,6 +939,11 @@ var _ = Describe("BasicHandler", func() {
 	})
 
 	Describe(".WaitForPushTx", func() {
+		It("should return the prior tx hash immediately when push was a duplicate", func() {
+			handler.PriorTxHash = "0x1234"
+			Expect(<-handler.WaitForPushTx()).Should(Equal("0x1234"))
+		})
+
 		It("should return tx hash when tx was added to the mempool", func(done Done) {
 			mockRemoteSrv.EXPECT().Cfg().Return(cfg)
 
@@ -972,5 +977,62 @@ var _ = Describe("BasicHandler", func() {
 				close(done)
 			}()
 		})
+
+		It("should return error when push note was dropped for lack of endorsements", func(done Done) {
+			mockRemoteSrv.EXPECT().Cfg().Return(cfg)
+
+			tx := txns.NewBareTxPush()
+			handler.NoteID = tx.Note.ID().String()
+
+			time.AfterFunc(10*time.Millisecond, func() {
+				cfg.G().Bus.Emit(pushtypes.EvtPushNoteDropped, pushtypes.ReasonEndorsementTimeout, handler.NoteID)
+			})
+
+			go func() {
+				defer GinkgoRecover()
+				err := <-handler.WaitForPushTx()
+				Expect(err).Should(Equal(fmt.Errorf("push note was dropped (reason: endorsement_timeout)")))
+				close(done)
+			}()
+		})
+
+		It("should subscribe once and unsubscribe from events after returning", func() {
+			mockRemoteSrv.EXPECT().Cfg().Return(cfg)
+			bus := cfg.G().Bus
+			numAdded := len(bus.Listeners(memtypes.EvtMempoolTxAdded))
+			numRejected := len(bus.Listeners(memtypes.EvtMempoolTxRejected))
+			numDropped := len(bus.Listeners(pushtypes.EvtPushNoteDropped))
+
+			tx := txns.NewBareTxPush()
+			handler.NoteID = tx.Note.ID().String()
+			ch := handler.WaitForPushTx()
+			Expect(bus.Listeners(memtypes.EvtMempoolTxAdded)).To(HaveLen(numAdded + 1))
+
+			for i := 0; i < 3; i++ {
+				<-bus.Emit(memtypes.EvtMempoolTxAdded, nil, txns.NewBareTxCoinTransfer())
+			}
+			Expect(bus.Listeners(memtypes.EvtMempoolTxAdded)).To(HaveLen(numAdded + 1))
+
+			bus.Emit(memtypes.EvtMempoolTxAdded, nil, tx)
+			Expect(<-ch).To(Equal(tx.GetHash().String()))
+			Eventually(func() int { return len(bus.Listeners(memtypes.EvtMempoolTxAdded)) }).Should(Equal(numAdded))
+			Expect(bus.Listeners(memtypes.EvtMempoolTxRejected)).To(HaveLen(numRejected))
+			Expect(bus.Listeners(pushtypes.EvtPushNoteDropped)).To(HaveLen(numDropped))
+		})
+	})
+
+	Describe(".MakePushContentKey", func() {
+		It("should return the same key for identical pushes", func() {
+			refs := push.PackedReferences{"refs/heads/master": {OldHash: "abc", NewHash: "def"}}
+			refs2 := push.PackedReferences{"refs/heads/master": {OldHash: "abc", NewHash: "def"}}
+			Expect(push.MakePushContentKey("repo1", "", refs)).To(Equal(push.MakePushContentKey("repo1", "", refs2)))
+		})
+
+		It("should return different keys if reference hashes or target repo differ", func() {
+			refs := push.PackedReferences{"refs/heads/master": {OldHash: "abc", NewHash: "def"}}
+			refs2 := push.PackedReferences{"refs/heads/master": {OldHash: "abc", NewHash: "xyz"}}
+			Expect(push.MakePushContentKey("repo1", "", refs)).ToNot(Equal(push.MakePushContentKey("repo1", "", refs2)))
+			Expect(push.MakePushContentKey("repo1", "", refs)).ToNot(Equal(push.MakePushContentKey("repo2", "", refs)))
+		})
 	})
 })
@@ -0,0 +1,15 @@
+package types
+
+// Events
+const (
+	// EvtPushNoteDropped is emitted when a push note is dropped from the push pool.
+	// Args: reason (string), note ID (string)
+	EvtPushNoteDropped = "push_note_dropped"
+)
+
+// Reasons for dropping a push note
+const (
+	// ReasonEndorsementTimeout indicates that a push note did
+	// not receive a quorum of endorsements in time.
+	ReasonEndorsementTimeout = "endorsement_timeout"
+)
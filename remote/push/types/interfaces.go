@@ -2,6 +2,7 @@ package types
 
 import (
 	"io"
+	"time"
 
 	"github.com/go-git/go-git/v5/plumbing/format/pktline"
 	"github.com/go-git/go-git/v5/plumbing/protocol/packp"
@@ -102,6 +103,16 @@ type PushPool interface {
 
 	// HasSeen checks whether a note with the given ID was recently added
 	HasSeen(noteID string) bool
+
+	// MarkEndorsed marks a push note as having received a quorum of endorsements
+	MarkEndorsed(noteID string)
+
+	// RemoveUnendorsed removes and returns push notes that have stayed in the
+	// pool for up to the given timeout without being marked as endorsed.
+	RemoveUnendorsed(timeout time.Duration) []PushNote
+
+	// GetUnendorsed returns push notes that have not been marked as endorsed
+	GetUnendorsed() []*PendingNote
 }
 
 type PushNote interface {
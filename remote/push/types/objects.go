@@ -1,6 +1,8 @@
 package types
 
 import (
+	"time"
+
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/make-os/kit/crypto/ed25519"
 	plumbing2 "github.com/make-os/kit/remote/plumbing"
@@ -246,9 +248,20 @@ func (pt *Note) ToMap() map[string]interface{} {
 	return util.ToJSONMap(pt)
 }
 
+// PendingNote describes a push note in the pool that has not yet
+// received a quorum of endorsements
+type PendingNote struct {
+	Note      *Note
+	TimeAdded time.Time
+}
+
 // EndorsedReference describes the current state of a reference endorsed by a host
 type EndorsedReference struct {
 	Hash []byte `json:"hash" msgpack:"hash,omitempty" mapstructure:"hash"`
+
+	// Name is the name of the endorsed reference.
+	// Only set when endorsements may cover a subset of the pushed references.
+	Name string `json:"name,omitempty" msgpack:"name,omitempty" mapstructure:"name"`
 }
 
 // EndorsedReferences is a collection of EndorsedReference
@@ -270,6 +283,13 @@ type Endorsement interface {
 	BytesAndID() ([]byte, util.Bytes32)
 }
 
+// Signature schemes of push endorsements
+const (
+	// SigSchemeBLS is the BLS signature scheme.
+	// It is assumed when an endorsement has no signature scheme.
+	SigSchemeBLS = "bls"
+)
+
 // PushEndorsement Endorsement is used to endorse a push note
 type PushEndorsement struct {
 	util.CodecUtil `json:"-" msgpack:"-" mapstructure:"-"`
@@ -285,6 +305,14 @@ type PushEndorsement struct {
 
 	// SigBLS is a 64 bytes BLS signature created using the BLS key of the endorser.
 	SigBLS []byte `json:"sigBLS" msgpack:"sigBLS,omitempty" mapstructure:"sigBLS"`
+
+	// SigScheme is the scheme of the endorsement signature (defaults to SigSchemeBLS)
+	SigScheme string `json:"sigScheme,omitempty" msgpack:"sigScheme,omitempty" mapstructure:"sigScheme"`
+
+	// Timestamp is the unix time of the endorsed push note. It is part of the
+	// signed bytes; all endorsers of a note sign the same timestamp so that
+	// their signatures can be aggregated.
+	Timestamp int64 `json:"timestamp,omitempty" msgpack:"timestamp,omitempty" mapstructure:"timestamp"`
 }
 
 // EncodeMsgpack implements msgpack.CustomEncoder
@@ -293,7 +321,9 @@ func (e *PushEndorsement) EncodeMsgpack(enc *msgpack.Encoder) error {
 		e.NoteID,
 		e.References,
 		e.EndorserPubKey.Bytes(),
-		e.SigBLS)
+		e.SigBLS,
+		e.SigScheme,
+		e.Timestamp)
 }
 
 // DecodeMsgpack implements msgpack.CustomDecoder
@@ -302,13 +332,23 @@ func (e *PushEndorsement) DecodeMsgpack(dec *msgpack.Decoder) error {
 		&e.NoteID,
 		&e.References,
 		&e.EndorserPubKey,
-		&e.SigBLS)
+		&e.SigBLS,
+		&e.SigScheme,
+		&e.Timestamp)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// GetSigScheme returns the signature scheme of the endorsement
+func (e *PushEndorsement) GetSigScheme() string {
+	if e.SigScheme == "" {
+		return SigSchemeBLS
+	}
+	return e.SigScheme
+}
+
 // ID returns the hash of the object
 func (e *PushEndorsement) ID() util.Bytes32 {
 	return util.BytesToBytes32(crypto2.Blake2b256(e.Bytes()))
@@ -351,12 +391,15 @@ func (e *PushEndorsement) Clone() *PushEndorsement {
 	cp.NoteID = e.NoteID
 	cp.EndorserPubKey = util.BytesToBytes32(e.EndorserPubKey.Bytes())
 	cp.SigBLS = e.SigBLS
+	cp.SigScheme = e.SigScheme
+	cp.Timestamp = e.Timestamp
 	cp.References = []*EndorsedReference{}
 	for _, rh := range e.References {
 		cpEndorsement := &EndorsedReference{}
 		cpHash := make([]byte, len(rh.Hash))
 		copy(cpHash, rh.Hash)
 		cpEndorsement.Hash = cpHash
+		cpEndorsement.Name = rh.Name
 		cp.References = append(cp.References, cpEndorsement)
 	}
 	return cp
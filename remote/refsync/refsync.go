@@ -226,6 +226,33 @@ func (rs *RefSync) updatedTrackInfo(task *reftypes.RefTask) (err error) {
 	return
 }
 
+// updateBranchActivity updates the activity index entry of a task's branch.
+// The entry is removed if the branch was deleted.
+func (rs *RefSync) updateBranchActivity(targetRepo plumbing.LocalRepo, task *reftypes.RefTask) error {
+	keeper := rs.keepers.RepoSyncInfoKeeper()
+	if plumbing.IsZeroHash(task.Ref.NewHash) {
+		if err := keeper.RemoveBranchActivity(task.RepoName, task.Ref.Name); err != nil {
+			return errors.Wrap(err, "unable to remove branch activity")
+		}
+		return nil
+	}
+
+	commit, err := targetRepo.CommitObject(plumbing2.NewHash(task.Ref.NewHash))
+	if err != nil {
+		return errors.Wrap(err, "unable to get branch tip commit")
+	}
+
+	err = keeper.UpdateBranchActivity(task.RepoName, task.Ref.Name, &core.BranchActivity{
+		Hash:           task.Ref.NewHash,
+		LastCommitTime: commit.Committer.When.Unix(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to update branch activity")
+	}
+
+	return nil
+}
+
 // do takes a pushed reference task and attempts to fetch the objects
 // required to update the reference's local state.
 func (rs *RefSync) do(task *reftypes.RefTask) error {
@@ -284,6 +311,9 @@ func (rs *RefSync) do(task *reftypes.RefTask) error {
 			return err
 		}
 
+		// Let listeners know the repository has changed
+		rs.cfg.G().Bus.Emit(core.EvtRepoUpdated, task.RepoName, repoPath)
+
 		// If the repository is being tracked, update its last update height
 		err = rs.updatedTrackInfo(task)
 
@@ -295,6 +325,11 @@ func (rs *RefSync) do(task *reftypes.RefTask) error {
 			}
 		}
 
+		// Update the branch activity index
+		if err == nil && plumbing.IsBranch(refName) {
+			err = rs.updateBranchActivity(targetRepo, task)
+		}
+
 		rs.log.Debug("Successfully updated reference", "Repo", task.RepoName,
 			"Ref", refName, "NewHash", task.Ref.NewHash, "OldHash", task.Ref.OldHash)
 
@@ -326,6 +361,10 @@ func (rs *RefSync) do(task *reftypes.RefTask) error {
 
 		if err != nil {
 			rs.log.Error("Failed to fetch push note objects", "Err", err.Error())
+
+			// Objects of some references may have been unpacked
+			rs.cfg.G().Bus.Emit(core.EvtRepoUpdated, task.RepoName, repoPath)
+
 			errCh <- err
 			return
 		}
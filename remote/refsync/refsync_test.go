@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/golang/mock/gomock"
 	"github.com/make-os/kit/config"
 	"github.com/make-os/kit/mocks"
@@ -240,6 +241,8 @@ var _ = Describe("RefSync", func() {
 				mockFetcher.EXPECT().OnPackReceived(gomock.Any())
 				mockRepoSyncInfoKeeper.EXPECT().GetTracked(task.RepoName).Return(nil)
 				mockRepoSyncInfoKeeper.EXPECT().UpdateRefLastSyncHeight(task.RepoName, task.Ref.Name, uint64(task.Height)).Return(nil)
+				mockRepo.EXPECT().CommitObject(plumbing.NewHash(newHash)).Return(&object.Commit{Committer: object.Signature{When: time.Unix(1000, 0)}}, nil)
+				mockRepoSyncInfoKeeper.EXPECT().UpdateBranchActivity(task.RepoName, task.Ref.Name, &core.BranchActivity{Hash: newHash, LastCommitTime: 1000}).Return(nil)
 				err := rs.do(task)
 				Expect(err).To(BeNil())
 				Expect(updated).To(BeTrue())
@@ -292,6 +295,8 @@ var _ = Describe("RefSync", func() {
 				mockFetcher.EXPECT().OnPackReceived(gomock.Any())
 				mockRepoSyncInfoKeeper.EXPECT().GetTracked(task.RepoName).Return(nil)
 				mockRepoSyncInfoKeeper.EXPECT().UpdateRefLastSyncHeight(task.RepoName, task.Ref.Name, uint64(task.Height)).Return(nil)
+				mockRepo.EXPECT().CommitObject(plumbing.NewHash(newHash)).Return(&object.Commit{Committer: object.Signature{When: time.Unix(1000, 0)}}, nil)
+				mockRepoSyncInfoKeeper.EXPECT().UpdateBranchActivity(task.RepoName, task.Ref.Name, &core.BranchActivity{Hash: newHash, LastCommitTime: 1000}).Return(nil)
 				err := rs.do(task)
 				Expect(err).To(BeNil())
 				Expect(updated).To(BeTrue())
@@ -319,11 +324,39 @@ var _ = Describe("RefSync", func() {
 				mockFetcher.EXPECT().OnPackReceived(gomock.Any())
 				mockRepoSyncInfoKeeper.EXPECT().GetTracked(task.RepoName).Return(nil)
 				mockRepoSyncInfoKeeper.EXPECT().UpdateRefLastSyncHeight(task.RepoName, task.Ref.Name, uint64(task.Height)).Return(nil)
+				mockRepo.EXPECT().CommitObject(plumbing.NewHash(newHash)).Return(&object.Commit{Committer: object.Signature{When: time.Unix(1000, 0)}}, nil)
+				mockRepoSyncInfoKeeper.EXPECT().UpdateBranchActivity(task.RepoName, task.Ref.Name, &core.BranchActivity{Hash: newHash, LastCommitTime: 1000}).Return(nil)
 				err := rs.do(task)
 				Expect(err).To(BeNil())
 				Expect(updated).To(BeTrue())
 			})
 
+			It("should emit EvtRepoUpdated event after updating the repo", func() {
+				task := &types3.RefTask{RepoName: "repo1", Ref: &types.PushedReference{Name: "refs/heads/master", OldHash: oldHash, NewHash: newHash}}
+				mockRepo := mocks.NewMockLocalRepo(ctrl)
+				rs.RepoGetter = func(gitBinPath, path string) (repo3.LocalRepo, error) { return mockRepo, nil }
+				mockRepo.EXPECT().RefGet(task.Ref.Name).Return(oldHash, nil)
+				mockRepo.EXPECT().IsAncestor(newHash, oldHash).Return(fmt.Errorf("not ancestor"))
+				rs.UpdateRepoUsingNote = func(string, push.MakeReferenceUpdateRequestPackFunc, types.PushNote) error {
+					return nil
+				}
+				mockFetcher.EXPECT().FetchAsync(gomock.Any(), gomock.Any()).Do(func(note types.PushNote, cb func(err error)) {
+					cb(nil)
+				})
+				mockPushPool.EXPECT().HasSeen(task.ID).Return(false)
+				mockFetcher.EXPECT().OnPackReceived(gomock.Any())
+				mockRepoSyncInfoKeeper.EXPECT().GetTracked(task.RepoName).Return(nil)
+				mockRepoSyncInfoKeeper.EXPECT().UpdateRefLastSyncHeight(task.RepoName, task.Ref.Name, uint64(task.Height)).Return(nil)
+				mockRepo.EXPECT().CommitObject(plumbing.NewHash(newHash)).Return(&object.Commit{Committer: object.Signature{When: time.Unix(1000, 0)}}, nil)
+				mockRepoSyncInfoKeeper.EXPECT().UpdateBranchActivity(task.RepoName, task.Ref.Name, &core.BranchActivity{Hash: newHash, LastCommitTime: 1000}).Return(nil)
+				evtCh := cfg.G().Bus.Once(core.EvtRepoUpdated)
+				err := rs.do(task)
+				Expect(err).To(BeNil())
+				evt := <-evtCh
+				Expect(evt.Args[0]).To(Equal(task.RepoName))
+				Expect(evt.Args[1]).To(Equal(filepath.Join(cfg.GetRepoRoot(), task.RepoName)))
+			})
+
 			It("should not attempt to update repo and return error if fetch attempt failed", func() {
 				task := &types3.RefTask{RepoName: "repo1", Ref: &types.PushedReference{Name: "refs/heads/master", OldHash: oldHash, NewHash: newHash}}
 				mockRepo := mocks.NewMockLocalRepo(ctrl)
@@ -367,6 +400,8 @@ var _ = Describe("RefSync", func() {
 					}
 					mockRepoSyncInfoKeeper.EXPECT().GetTracked(task.RepoName).Return(nil)
 					mockRepoSyncInfoKeeper.EXPECT().UpdateRefLastSyncHeight(task.RepoName, task.Ref.Name, uint64(task.Height)).Return(nil)
+					mockRepo.EXPECT().CommitObject(plumbing.NewHash(newHash)).Return(&object.Commit{Committer: object.Signature{When: time.Unix(1000, 0)}}, nil)
+					mockRepoSyncInfoKeeper.EXPECT().UpdateBranchActivity(task.RepoName, task.Ref.Name, &core.BranchActivity{Hash: newHash, LastCommitTime: 1000}).Return(nil)
 					mockPushPool.EXPECT().HasSeen(task.ID).Return(true)
 					err := rs.do(task)
 					Expect(err).To(BeNil())
@@ -375,6 +410,55 @@ var _ = Describe("RefSync", func() {
 			})
 		})
 
+		When("pushed reference is a branch", func() {
+			var task *types3.RefTask
+			var mockRepo *mocks.MockLocalRepo
+
+			BeforeEach(func() {
+				task = &types3.RefTask{RepoName: "repo1", Ref: &types.PushedReference{Name: "refs/heads/master", OldHash: oldHash, NewHash: newHash}}
+				mockRepo = mocks.NewMockLocalRepo(ctrl)
+				rs.RepoGetter = func(gitBinPath, path string) (repo3.LocalRepo, error) { return mockRepo, nil }
+				rs.UpdateRepoUsingNote = func(string, push.MakeReferenceUpdateRequestPackFunc, types.PushNote) error { return nil }
+				mockRepo.EXPECT().RefGet(task.Ref.Name).Return(oldHash, nil)
+				mockPushPool.EXPECT().HasSeen(task.ID).Return(true)
+				mockRepoSyncInfoKeeper.EXPECT().GetTracked(task.RepoName).Return(nil)
+				mockRepoSyncInfoKeeper.EXPECT().UpdateRefLastSyncHeight(task.RepoName, task.Ref.Name, uint64(task.Height)).Return(nil)
+			})
+
+			It("should index the branch tip hash and last commit time", func() {
+				mockRepo.EXPECT().IsAncestor(newHash, oldHash).Return(fmt.Errorf("not ancestor"))
+				mockRepo.EXPECT().CommitObject(plumbing.NewHash(newHash)).Return(&object.Commit{Committer: object.Signature{When: time.Unix(2000, 0)}}, nil)
+				mockRepoSyncInfoKeeper.EXPECT().UpdateBranchActivity(task.RepoName, task.Ref.Name, &core.BranchActivity{Hash: newHash, LastCommitTime: 2000}).Return(nil)
+				err := rs.do(task)
+				Expect(err).To(BeNil())
+			})
+
+			It("should return error when unable to get branch tip commit", func() {
+				mockRepo.EXPECT().IsAncestor(newHash, oldHash).Return(fmt.Errorf("not ancestor"))
+				mockRepo.EXPECT().CommitObject(plumbing.NewHash(newHash)).Return(nil, fmt.Errorf("error"))
+				err := rs.do(task)
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError("unable to get branch tip commit: error"))
+			})
+
+			It("should return error when unable to update branch activity", func() {
+				mockRepo.EXPECT().IsAncestor(newHash, oldHash).Return(fmt.Errorf("not ancestor"))
+				mockRepo.EXPECT().CommitObject(plumbing.NewHash(newHash)).Return(&object.Commit{}, nil)
+				mockRepoSyncInfoKeeper.EXPECT().UpdateBranchActivity(task.RepoName, task.Ref.Name, gomock.Any()).Return(fmt.Errorf("error"))
+				err := rs.do(task)
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError("unable to update branch activity: error"))
+			})
+
+			It("should remove the branch from the index when the branch was deleted", func() {
+				task.Ref.NewHash = plumbing.ZeroHash.String()
+				mockRepo.EXPECT().IsAncestor(task.Ref.NewHash, oldHash).Return(fmt.Errorf("not ancestor"))
+				mockRepoSyncInfoKeeper.EXPECT().RemoveBranchActivity(task.RepoName, task.Ref.Name).Return(nil)
+				err := rs.do(task)
+				Expect(err).To(BeNil())
+			})
+		})
+
 		When("target repo is tracked", func() {
 			It("should return error when unable to update tracked repo update height", func() {
 				task := &types3.RefTask{RepoName: "repo1", Ref: &types.PushedReference{Name: "refs/heads/master", OldHash: oldHash, NewHash: newHash}, Height: 10}
@@ -0,0 +1,161 @@
+package repo
+
+import (
+	"io"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	plumbing2 "github.com/make-os/kit/remote/plumbing"
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// GetFileBlame returns the last modification of each line of a file
+//  - ref: A commit hash, full reference name or branch name
+//  - path: The case-sensitive file path
+func (r *Repo) GetFileBlame(ref, path string) (res []*plumbing2.BlameLine, err error) {
+	commit, err := r.getRevisionCommit(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := tree.FindEntry(path)
+	if err != nil {
+		if err == object.ErrEntryNotFound {
+			return nil, ErrPathNotFound
+		}
+		return nil, err
+	} else if entry.Mode == filemode.Dir {
+		return nil, ErrPathNotAFile
+	}
+
+	return r.blameFile(commit, path)
+}
+
+// GetCodeOwnership returns the share of lines each author last modified
+// across the files under a path, sorted by ownership in descending order.
+// Binary files are not considered.
+//  - ref: A commit hash, full reference name or branch name
+//  - path: The case-sensitive path of a directory or file. Use "." or ""
+//    for the root directory.
+func (r *Repo) GetCodeOwnership(ref, path string) (res []*plumbing2.CodeOwner, err error) {
+	commit, err := r.getRevisionCommit(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect the paths of the files to blame
+	var files []string
+	path = filepath.Clean(path)
+	if path == "." {
+		path = ""
+	}
+	if path != "" {
+		entry, err := tree.FindEntry(path)
+		if err != nil {
+			if err == object.ErrEntryNotFound {
+				return nil, ErrPathNotFound
+			}
+			return nil, err
+		}
+		if entry.Mode != filemode.Dir {
+			files = append(files, path)
+			goto blame
+		}
+		if tree, err = tree.Tree(path); err != nil {
+			return nil, err
+		}
+	}
+	err = tree.Files().ForEach(func(file *object.File) error {
+		if isBin, err := file.IsBinary(); err != nil || isBin {
+			return err
+		}
+		files = append(files, filepath.Join(path, file.Name))
+		return nil
+	})
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+blame:
+	var total int
+	var lines = make(map[string]int)
+	for _, file := range files {
+		blamed, err := r.blameFile(commit, file)
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range blamed {
+			lines[line.Author]++
+			total++
+		}
+	}
+
+	res = []*plumbing2.CodeOwner{}
+	for author, n := range lines {
+		res = append(res, &plumbing2.CodeOwner{
+			Author:    author,
+			Lines:     n,
+			Ownership: float64(n) / float64(total),
+		})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Lines != res[j].Lines {
+			return res[i].Lines > res[j].Lines
+		}
+		return res[i].Author < res[j].Author
+	})
+
+	return res, nil
+}
+
+// blameFile returns the last modification of each line of a file in a commit.
+// The git binary is used because go-git fails to blame files that end with a
+// newline character.
+func (r *Repo) blameFile(commit *object.Commit, path string) ([]*plumbing2.BlameLine, error) {
+	args := []string{"--no-pager", "blame", "--line-porcelain", commit.Hash.String(), "--", path}
+	cmd := exec.Command(r.gitBinPath, args...)
+	cmd.Dir = r.path
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, errors.Wrap(err, string(out))
+	}
+
+	// Each line is described by a header with the commit hash, followed by
+	// the commit information and the content of the line prefixed by a tab.
+	var res = []*plumbing2.BlameLine{}
+	var cur *plumbing2.BlameLine
+	for _, line := range strings.Split(string(out), "\n") {
+		switch {
+		case cur == nil:
+			if fields := strings.Fields(line); len(fields) > 0 {
+				cur = &plumbing2.BlameLine{Hash: fields[0]}
+			}
+		case strings.HasPrefix(line, "\t"):
+			cur.Text = line[1:]
+			res = append(res, cur)
+			cur = nil
+		case strings.HasPrefix(line, "author-mail "):
+			cur.Author = strings.Trim(strings.TrimPrefix(line, "author-mail "), "<>")
+		case strings.HasPrefix(line, "author-time "):
+			cur.Date = cast.ToInt64(strings.TrimPrefix(line, "author-time "))
+		}
+	}
+
+	return res, nil
+}
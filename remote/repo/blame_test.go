@@ -0,0 +1,104 @@
+package repo_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/make-os/kit/remote/repo"
+	testutil2 "github.com/make-os/kit/remote/testutil"
+	"github.com/make-os/kit/util"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// appendCommitAs adds data to a file and commits it as the given author
+func appendCommitAs(path, author, file, data string) {
+	testutil2.AppendToFile(path, file, data)
+	testutil2.ExecGit(path, "add", ".")
+	testutil2.ExecGit(path, "commit", "--author", author, "-m", "commit")
+}
+
+var _ = Describe("Blame", func() {
+	var path string
+	var r *repo.Repo
+
+	BeforeEach(func() {
+		dir := os.TempDir()
+		path = filepath.Join(dir, util.RandString(5))
+		testutil2.ExecGit(dir, "init", path)
+		lr, err := repo.GetWithGitModule(gitBinPath, path)
+		Expect(err).To(BeNil())
+		r = lr.(*repo.Repo)
+
+		appendCommitAs(path, "Alice <alice@example.com>", "dir/a.txt", "line 1\nline 2\nline 3\n")
+		appendCommitAs(path, "Bob <bob@example.com>", "dir/a.txt", "line 4\n")
+		appendCommitAs(path, "Bob <bob@example.com>", "dir/sub/b.txt", "line 1\nline 2\nline 3\n")
+		appendCommitAs(path, "Alice <alice@example.com>", "c.txt", "line 1\n")
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(path)).To(BeNil())
+	})
+
+	Describe(".GetFileBlame", func() {
+		It("should return the author of each line", func() {
+			res, err := r.GetFileBlame("HEAD", "dir/a.txt")
+			Expect(err).To(BeNil())
+			Expect(res).To(HaveLen(4))
+			Expect(res[0].Author).To(Equal("alice@example.com"))
+			Expect(res[0].Text).To(Equal("line 1"))
+			Expect(res[3].Author).To(Equal("bob@example.com"))
+			Expect(res[3].Text).To(Equal("line 4"))
+			Expect(res[3].Hash).To(Equal(testutil2.GetRecentCommitHash(path, "HEAD~2")))
+		})
+
+		It("should return ErrPathNotFound when path does not exist", func() {
+			_, err := r.GetFileBlame("HEAD", "unknown.txt")
+			Expect(err).To(Equal(repo.ErrPathNotFound))
+		})
+
+		It("should return ErrPathNotAFile when path is a directory", func() {
+			_, err := r.GetFileBlame("HEAD", "dir")
+			Expect(err).To(Equal(repo.ErrPathNotAFile))
+		})
+	})
+
+	Describe(".GetCodeOwnership", func() {
+		It("should return ownership of files under a directory sorted by ownership", func() {
+			res, err := r.GetCodeOwnership("HEAD", "dir")
+			Expect(err).To(BeNil())
+			Expect(res).To(HaveLen(2))
+			Expect(res[0].Author).To(Equal("bob@example.com"))
+			Expect(res[0].Lines).To(Equal(4))
+			Expect(res[0].Ownership).To(Equal(4.0 / 7.0))
+			Expect(res[1].Author).To(Equal("alice@example.com"))
+			Expect(res[1].Lines).To(Equal(3))
+			Expect(res[1].Ownership).To(Equal(3.0 / 7.0))
+		})
+
+		It("should return ownership of all files when path is empty or '.'", func() {
+			for _, p := range []string{"", "."} {
+				res, err := r.GetCodeOwnership("HEAD", p)
+				Expect(err).To(BeNil())
+				Expect(res).To(HaveLen(2))
+				Expect(res[0].Author).To(Equal("alice@example.com"))
+				Expect(res[0].Lines).To(Equal(4))
+				Expect(res[1].Author).To(Equal("bob@example.com"))
+				Expect(res[1].Lines).To(Equal(4))
+			}
+		})
+
+		It("should return ownership of a single file when path is a file", func() {
+			res, err := r.GetCodeOwnership("HEAD", "dir/sub/b.txt")
+			Expect(err).To(BeNil())
+			Expect(res).To(HaveLen(1))
+			Expect(res[0].Author).To(Equal("bob@example.com"))
+			Expect(res[0].Ownership).To(Equal(1.0))
+		})
+
+		It("should return ErrPathNotFound when path does not exist", func() {
+			_, err := r.GetCodeOwnership("HEAD", "unknown")
+			Expect(err).To(Equal(repo.ErrPathNotFound))
+		})
+	})
+})
@@ -0,0 +1,106 @@
+package repo
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/make-os/kit/pkgs/cache"
+	plumbing2 "github.com/make-os/kit/remote/plumbing"
+)
+
+// Cache is a concurrency-safe LRU cache of opened local repository
+// handles keyed by the repository path. It allows callers that
+// repeatedly access the same repositories to avoid re-opening them.
+type Cache struct {
+	lck   *sync.Mutex
+	repos *cache.Cache
+
+	// Getter is the function used to open a repository on cache miss
+	Getter GetLocalRepoFunc
+
+	// CommitGraphSize is the max number of commits to keep in the commit
+	// graph attached to each cached repository. Disabled when zero.
+	CommitGraphSize int
+
+	// Root is the directory containing the repositories to cache.
+	// Repositories outside of it (e.g temporary clones) are not
+	// cached. All repositories are cached when empty.
+	Root string
+}
+
+// NewCache creates an instance of Cache.
+//  - capacity: The max number of repository handles to keep.
+//    If zero or negative, caching is disabled and every call
+//    to Get opens the repository.
+func NewCache(capacity int) *Cache {
+	c := &Cache{lck: &sync.Mutex{}, Getter: GetWithGitModule}
+	if capacity > 0 {
+		c.repos = cache.NewCache(capacity)
+	}
+	return c
+}
+
+// Get returns a cached handle of the repository at the given path or
+// opens and caches the repository if no handle exists. It is compatible
+// with GetLocalRepoFunc.
+//  - gitBinPath: The path to the git executable.
+//  - path: The path to the repository.
+func (c *Cache) Get(gitBinPath, path string) (plumbing2.LocalRepo, error) {
+	if c.repos == nil || !c.isCacheable(path) {
+		return c.Getter(gitBinPath, path)
+	}
+
+	key := filepath.Clean(path)
+
+	c.lck.Lock()
+	defer c.lck.Unlock()
+
+	if r := c.repos.Get(key); r != nil {
+		return r.(plumbing2.LocalRepo), nil
+	}
+
+	r, err := c.Getter(gitBinPath, path)
+	if err != nil {
+		return nil, err
+	}
+	if repo, ok := r.(*Repo); ok && c.CommitGraphSize > 0 {
+		repo.CommitGraph = NewCommitGraph(c.CommitGraphSize)
+	}
+	c.repos.Add(key, r)
+
+	return r, nil
+}
+
+// isCacheable checks whether the repository at the given path can be cached
+func (c *Cache) isCacheable(path string) bool {
+	if c.Root == "" {
+		return true
+	}
+	rel, err := filepath.Rel(c.Root, path)
+	if err != nil {
+		return false
+	}
+	return rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// Invalidate removes the handle of the repository at the given path.
+// It must be called when the repository is modified so that
+// subsequent calls to Get return a handle reflecting the change.
+//  - path: The path to the repository.
+func (c *Cache) Invalidate(path string) {
+	if c.repos == nil {
+		return
+	}
+	c.lck.Lock()
+	defer c.lck.Unlock()
+	c.repos.Remove(filepath.Clean(path))
+}
+
+// Len returns the number of cached repository handles
+func (c *Cache) Len() int {
+	if c.repos == nil {
+		return 0
+	}
+	return c.repos.Len()
+}
@@ -0,0 +1,160 @@
+package repo_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	rr "github.com/make-os/kit/remote/plumbing"
+	"github.com/make-os/kit/remote/repo"
+	testutil2 "github.com/make-os/kit/remote/testutil"
+	"github.com/make-os/kit/util"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var gitBinPath, _ = exec.LookPath("git")
+
+var _ = Describe("Cache", func() {
+	var path string
+	var dir string
+
+	BeforeEach(func() {
+		dir = os.TempDir()
+		path = filepath.Join(dir, util.RandString(5))
+		testutil2.ExecGit(dir, "init", path)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(path)).To(BeNil())
+	})
+
+	Describe(".Get", func() {
+		It("should open the repository once and return the cached handle on subsequent calls", func() {
+			c := repo.NewCache(10)
+			opened := 0
+			c.Getter = func(gitBinPath, path string) (rr.LocalRepo, error) {
+				opened++
+				return repo.GetWithGitModule(gitBinPath, path)
+			}
+			r1, err := c.Get(gitBinPath, path)
+			Expect(err).To(BeNil())
+			r2, err := c.Get(gitBinPath, path+"/")
+			Expect(err).To(BeNil())
+			Expect(r1).To(BeIdenticalTo(r2))
+			Expect(opened).To(Equal(1))
+			Expect(c.Len()).To(Equal(1))
+		})
+
+		It("should return error and not cache when repository could not be opened", func() {
+			c := repo.NewCache(10)
+			_, err := c.Get(gitBinPath, filepath.Join(dir, "unknown"))
+			Expect(err).ToNot(BeNil())
+			Expect(c.Len()).To(Equal(0))
+		})
+
+		It("should evict the least recently used handle when full", func() {
+			path2 := filepath.Join(dir, util.RandString(5))
+			testutil2.ExecGit(dir, "init", path2)
+			defer os.RemoveAll(path2)
+			c := repo.NewCache(1)
+			_, err := c.Get(gitBinPath, path)
+			Expect(err).To(BeNil())
+			_, err = c.Get(gitBinPath, path2)
+			Expect(err).To(BeNil())
+			Expect(c.Len()).To(Equal(1))
+		})
+
+		It("should always open the repository when capacity is zero", func() {
+			c := repo.NewCache(0)
+			r1, err := c.Get(gitBinPath, path)
+			Expect(err).To(BeNil())
+			r2, err := c.Get(gitBinPath, path)
+			Expect(err).To(BeNil())
+			Expect(r1).ToNot(BeIdenticalTo(r2))
+			Expect(c.Len()).To(Equal(0))
+		})
+		It("should attach a commit graph to the opened repository when CommitGraphSize is set", func() {
+			c := repo.NewCache(10)
+			c.CommitGraphSize = 100
+			r, err := c.Get(gitBinPath, path)
+			Expect(err).To(BeNil())
+			Expect(r.(*repo.Repo).CommitGraph).ToNot(BeNil())
+		})
+
+		It("should not attach a commit graph when CommitGraphSize is zero", func() {
+			c := repo.NewCache(10)
+			r, err := c.Get(gitBinPath, path)
+			Expect(err).To(BeNil())
+			Expect(r.(*repo.Repo).CommitGraph).To(BeNil())
+		})
+
+		It("should cache the repository when it is inside Root", func() {
+			c := repo.NewCache(10)
+			c.Root = dir
+			_, err := c.Get(gitBinPath, path)
+			Expect(err).To(BeNil())
+			Expect(c.Len()).To(Equal(1))
+		})
+
+		It("should not cache the repository when it is outside Root", func() {
+			c := repo.NewCache(10)
+			c.Root = filepath.Join(dir, util.RandString(5))
+			r1, err := c.Get(gitBinPath, path)
+			Expect(err).To(BeNil())
+			r2, err := c.Get(gitBinPath, path)
+			Expect(err).To(BeNil())
+			Expect(r1).ToNot(BeIdenticalTo(r2))
+			Expect(c.Len()).To(Equal(0))
+		})
+	})
+
+	Describe(".Invalidate", func() {
+		It("should remove the handle so the next call re-opens the repository", func() {
+			c := repo.NewCache(10)
+			r1, err := c.Get(gitBinPath, path)
+			Expect(err).To(BeNil())
+			c.Invalidate(path)
+			Expect(c.Len()).To(Equal(0))
+			r2, err := c.Get(gitBinPath, path)
+			Expect(err).To(BeNil())
+			Expect(r1).ToNot(BeIdenticalTo(r2))
+		})
+	})
+})
+
+// benchmarkGetCommit repeatedly gets a repository handle
+// using getter and reads the head commit from it.
+func benchmarkGetCommit(b *testing.B, getter repo.GetLocalRepoFunc) {
+	dir, err := os.MkdirTemp("", "")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	testutil2.ExecGit(dir, "init", ".")
+	for i := 0; i < 10; i++ {
+		testutil2.AppendCommit(dir, "file.txt", fmt.Sprintf("line %d", i), "commit")
+	}
+	hash := testutil2.GetRecentCommitHash(dir, "HEAD")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r, err := getter(gitBinPath, dir)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := r.GetCommit(hash); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetCommitWithoutCache(b *testing.B) {
+	benchmarkGetCommit(b, repo.GetWithGitModule)
+}
+
+func BenchmarkGetCommitWithCache(b *testing.B) {
+	benchmarkGetCommit(b, repo.NewCache(10).Get)
+}
@@ -0,0 +1,177 @@
+package repo
+
+import (
+	"sync"
+	"time"
+
+	"github.com/emirpasic/gods/trees/binaryheap"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	plumbing2 "github.com/make-os/kit/remote/plumbing"
+)
+
+// CommitNode is a commit in a commit graph
+type CommitNode struct {
+	Hash    plumbing.Hash
+	Parents []plumbing.Hash
+	Time    time.Time
+	Commit  *plumbing2.CommitResult
+}
+
+// CommitGraph is a concurrency-safe cache of the parent links of commits.
+// It allows ancestry queries to walk the history of a repository without
+// repeatedly reading and decoding commit objects.
+type CommitGraph struct {
+	lck   *sync.RWMutex
+	max   int
+	nodes map[plumbing.Hash]*CommitNode
+}
+
+// NewCommitGraph creates an instance of CommitGraph.
+//  - max: The max number of commits to keep. If zero or
+//    negative, no commit is kept.
+func NewCommitGraph(max int) *CommitGraph {
+	return &CommitGraph{lck: &sync.RWMutex{}, max: max, nodes: make(map[plumbing.Hash]*CommitNode)}
+}
+
+// Get returns the node of a commit or nil if the commit is not cached
+func (g *CommitGraph) Get(hash plumbing.Hash) *CommitNode {
+	g.lck.RLock()
+	defer g.lck.RUnlock()
+	return g.nodes[hash]
+}
+
+// Add adds a commit node to the graph.
+// The node is not added if the graph is full.
+func (g *CommitGraph) Add(node *CommitNode) {
+	g.lck.Lock()
+	defer g.lck.Unlock()
+	if len(g.nodes) >= g.max {
+		return
+	}
+	g.nodes[node.Hash] = node
+}
+
+// Len returns the number of cached commits
+func (g *CommitGraph) Len() int {
+	g.lck.RLock()
+	defer g.lck.RUnlock()
+	return len(g.nodes)
+}
+
+// Reset removes all cached commits
+func (g *CommitGraph) Reset() {
+	g.lck.Lock()
+	defer g.lck.Unlock()
+	g.nodes = make(map[plumbing.Hash]*CommitNode)
+}
+
+// newCommitNode creates a commit graph node from a commit object
+func newCommitNode(c *object.Commit) *CommitNode {
+	return &CommitNode{
+		Hash:    c.Hash,
+		Parents: c.ParentHashes,
+		Time:    c.Committer.When,
+		Commit:  newCommitResult(c),
+	}
+}
+
+// newCommitResult creates a CommitResult from a commit object
+func newCommitResult(c *object.Commit) *plumbing2.CommitResult {
+	cr := &plumbing2.CommitResult{Message: c.Message, Hash: c.Hash.String()}
+	if c.Committer != (object.Signature{}) {
+		cr.Committer = &plumbing2.CommitSignatory{
+			Name:      c.Committer.Name,
+			Email:     c.Committer.Email,
+			Timestamp: c.Committer.When.Unix(),
+		}
+	}
+	if c.Author != (object.Signature{}) {
+		cr.Author = &plumbing2.CommitSignatory{
+			Name:      c.Author.Name,
+			Email:     c.Author.Email,
+			Timestamp: c.Author.When.Unix(),
+		}
+	}
+	for _, parent := range c.ParentHashes {
+		cr.ParentHashes = append(cr.ParentHashes, parent.String())
+	}
+	return cr
+}
+
+// getCommitNode returns the graph node of a commit.
+// On cache miss, the commit object is read and added to the graph.
+func (r *Repo) getCommitNode(graph *CommitGraph, hash plumbing.Hash) (*CommitNode, error) {
+	if node := graph.Get(hash); node != nil {
+		return node, nil
+	}
+	commit, err := r.CommitObject(hash)
+	if err != nil {
+		return nil, err
+	}
+	node := newCommitNode(commit)
+	graph.Add(node)
+	return node, nil
+}
+
+// walkCommits walks the history of a commit in reverse committer time order
+// (same as object.NewCommitIterCTime) using the repository's commit graph.
+// If the repository has no commit graph, a non-caching graph is used.
+//  - start: The commit whose history will be walked.
+//  - ignore: A list of commit that will not be walked.
+//  - cb: Called for each commit. Return storer.ErrStop to end the walk.
+func (r *Repo) walkCommits(start plumbing.Hash, ignore []plumbing.Hash, cb func(*CommitNode) error) error {
+	graph := r.CommitGraph
+	if graph == nil {
+		graph = NewCommitGraph(0)
+	}
+
+	seen := make(map[plumbing.Hash]bool)
+	for _, h := range ignore {
+		seen[h] = true
+	}
+
+	node, err := r.getCommitNode(graph, start)
+	if err != nil {
+		return err
+	}
+
+	heap := binaryheap.NewWith(func(a, b interface{}) int {
+		if a.(*CommitNode).Time.Before(b.(*CommitNode).Time) {
+			return 1
+		}
+		return -1
+	})
+	heap.Push(node)
+
+	for {
+		next, ok := heap.Pop()
+		if !ok {
+			return nil
+		}
+		node := next.(*CommitNode)
+		if seen[node.Hash] {
+			continue
+		}
+		seen[node.Hash] = true
+
+		for _, h := range node.Parents {
+			if seen[h] {
+				continue
+			}
+			parent, err := r.getCommitNode(graph, h)
+			if err != nil {
+				return err
+			}
+			heap.Push(parent)
+		}
+
+		if err := cb(node); err != nil {
+			if err == storer.ErrStop {
+				return nil
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package repo_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/make-os/kit/remote/repo"
+	testutil2 "github.com/make-os/kit/remote/testutil"
+	"github.com/make-os/kit/util"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CommitGraph", func() {
+	var path string
+	var r *repo.Repo
+
+	BeforeEach(func() {
+		dir := os.TempDir()
+		path = filepath.Join(dir, util.RandString(5))
+		testutil2.ExecGit(dir, "init", path)
+		lr, err := repo.GetWithGitModule(gitBinPath, path)
+		Expect(err).To(BeNil())
+		r = lr.(*repo.Repo)
+		r.CommitGraph = repo.NewCommitGraph(100)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(path)).To(BeNil())
+	})
+
+	Describe(".Add", func() {
+		It("should add node and make it retrievable via Get", func() {
+			g := repo.NewCommitGraph(1)
+			node := &repo.CommitNode{Hash: plumbing.NewHash("01")}
+			g.Add(node)
+			Expect(g.Get(node.Hash)).To(BeIdenticalTo(node))
+			Expect(g.Len()).To(Equal(1))
+		})
+
+		It("should not add node when graph is full", func() {
+			g := repo.NewCommitGraph(1)
+			g.Add(&repo.CommitNode{Hash: plumbing.NewHash("01")})
+			g.Add(&repo.CommitNode{Hash: plumbing.NewHash("02")})
+			Expect(g.Len()).To(Equal(1))
+			Expect(g.Get(plumbing.NewHash("02"))).To(BeNil())
+		})
+
+		It("should not add node when max is zero", func() {
+			g := repo.NewCommitGraph(0)
+			g.Add(&repo.CommitNode{Hash: plumbing.NewHash("01")})
+			Expect(g.Len()).To(Equal(0))
+		})
+	})
+
+	Describe(".Reset", func() {
+		It("should remove all nodes", func() {
+			g := repo.NewCommitGraph(1)
+			g.Add(&repo.CommitNode{Hash: plumbing.NewHash("01")})
+			g.Reset()
+			Expect(g.Len()).To(Equal(0))
+		})
+	})
+
+	Describe(".GetCommitAncestors", func() {
+		It("should return same result as a repo without commit graph and populate the graph", func() {
+			for i := 0; i < 5; i++ {
+				testutil2.AppendCommit(path, "file.txt", fmt.Sprintf("line %d", i), "commit")
+			}
+			hash := testutil2.GetRecentCommitHash(path, "HEAD")
+
+			commits, err := r.GetCommitAncestors(hash, 0)
+			Expect(err).To(BeNil())
+			Expect(commits).To(HaveLen(4))
+			Expect(r.CommitGraph.Len()).To(Equal(5))
+
+			r2, err := repo.GetWithGitModule(gitBinPath, path)
+			Expect(err).To(BeNil())
+			expected, err := r2.GetCommitAncestors(hash, 0)
+			Expect(err).To(BeNil())
+			Expect(commits).To(Equal(expected))
+
+			commits, err = r.GetCommitAncestors(hash, 2)
+			Expect(err).To(BeNil())
+			Expect(commits).To(Equal(expected[:2]))
+		})
+	})
+
+	Describe(".NumCommits", func() {
+		It("should return same count as git", func() {
+			testutil2.AppendCommit(path, "file.txt", "line 1", "commit 1")
+			testutil2.ExecGit(path, "branch", "other")
+			testutil2.AppendCommit(path, "file.txt", "line 2", "commit 2")
+			testutil2.CheckoutBranch(path, "other")
+			testutil2.AppendCommit(path, "file2.txt", "line 3", "commit 3")
+			testutil2.ForceMergeOurs(path, "@{-1}")
+
+			for _, noMerges := range []bool{false, true} {
+				n, err := r.NumCommits("refs/heads/other", noMerges)
+				Expect(err).To(BeNil())
+				expected, err := r.BasicGitModule.NumCommits("refs/heads/other", noMerges)
+				Expect(err).To(BeNil())
+				Expect(n).To(Equal(expected))
+			}
+		})
+
+		It("should return zero when reference does not exist", func() {
+			n, err := r.NumCommits("refs/heads/unknown", false)
+			Expect(err).To(BeNil())
+			Expect(n).To(Equal(0))
+		})
+	})
+
+	Describe(".GetMergeBase", func() {
+		It("should return the commit both commits diverged from", func() {
+			testutil2.AppendCommit(path, "file.txt", "line 1", "commit 1")
+			base := testutil2.GetRecentCommitHash(path, "HEAD")
+			testutil2.ExecGit(path, "branch", "other")
+			testutil2.AppendCommit(path, "file.txt", "line 2", "commit 2")
+			commitA := testutil2.GetRecentCommitHash(path, "HEAD")
+			testutil2.CheckoutBranch(path, "other")
+			testutil2.AppendCommit(path, "file2.txt", "line 3", "commit 3")
+			commitB := testutil2.GetRecentCommitHash(path, "HEAD")
+
+			res, err := r.GetMergeBase(commitA, commitB)
+			Expect(err).To(BeNil())
+			Expect(res).To(Equal(base))
+
+			res, err = r.GetMergeBase(base, commitB)
+			Expect(err).To(BeNil())
+			Expect(res).To(Equal(base))
+		})
+
+		It("should return ErrNoMergeBase when commits have no common ancestor", func() {
+			testutil2.AppendCommit(path, "file.txt", "line 1", "commit 1")
+			commitA := testutil2.GetRecentCommitHash(path, "HEAD")
+			testutil2.CreateCheckoutOrphanBranch(path, "other")
+			testutil2.AppendCommit(path, "file2.txt", "line 2", "commit 2")
+			commitB := testutil2.GetRecentCommitHash(path, "HEAD")
+
+			_, err := r.GetMergeBase(commitA, commitB)
+			Expect(err).To(Equal(repo.ErrNoMergeBase))
+		})
+
+		It("should return ErrObjectNotFound when a commit does not exist", func() {
+			testutil2.AppendCommit(path, "file.txt", "line 1", "commit 1")
+			commitA := testutil2.GetRecentCommitHash(path, "HEAD")
+			_, err := r.GetMergeBase(commitA, "0000000000000000000000000000000000000001")
+			Expect(err).To(Equal(plumbing.ErrObjectNotFound))
+		})
+	})
+
+	Describe(".GetRefsContaining", func() {
+		It("should return ErrObjectNotFound when commit does not exist", func() {
+			testutil2.AppendCommit(path, "file.txt", "line 1", "commit 1")
+			_, err := r.GetRefsContaining("0000000000000000000000000000000000000001")
+			Expect(err).To(Equal(plumbing.ErrObjectNotFound))
+		})
+
+		It("should return the branches and tags whose history includes the commit", func() {
+			testutil2.AppendCommit(path, "file.txt", "line 1", "commit 1")
+			testutil2.ExecGit(path, "tag", "v0")
+			testutil2.AppendCommit(path, "file.txt", "line 2", "commit 2")
+			fix := testutil2.GetRecentCommitHash(path, "HEAD")
+			testutil2.ExecGit(path, "tag", "-a", "v1", "-m", "release v1")
+			testutil2.ExecGit(path, "branch", "before-fix", "v0")
+			testutil2.ExecGit(path, "branch", "with-fix")
+			testutil2.AppendCommit(path, "file.txt", "line 3", "commit 3")
+			testutil2.ExecGit(path, "tag", "v2")
+			testutil2.CreateCheckoutOrphanBranch(path, "orphan")
+			testutil2.AppendCommit(path, "file2.txt", "line 1", "orphan commit")
+			testutil2.ExecGit(path, "tag", "blob-tag", testutil2.CreateBlob(path, "data"))
+
+			res, err := r.GetRefsContaining(fix)
+			Expect(err).To(BeNil())
+			Expect(res.Branches).To(Equal([]string{"master", "with-fix"}))
+			Expect(res.Tags).To(Equal([]string{"v1", "v2"}))
+
+			for _, args := range [][]string{{"branch", "--contains", fix}, {"tag", "--contains", fix}} {
+				out := testutil2.ExecGit(path, append(args, "--format=%(refname:short)")...)
+				expected := strings.Fields(string(out))
+				if args[0] == "branch" {
+					Expect(res.Branches).To(Equal(expected))
+				} else {
+					Expect(res.Tags).To(Equal(expected))
+				}
+			}
+		})
+
+		It("should return empty lists when no reference includes the commit", func() {
+			testutil2.AppendCommit(path, "file.txt", "line 1", "commit 1")
+			commit := testutil2.GetRecentCommitHash(path, "HEAD")
+			testutil2.CreateCheckoutOrphanBranch(path, "orphan")
+			testutil2.AppendCommit(path, "file2.txt", "line 1", "orphan commit")
+			testutil2.ExecGit(path, "branch", "-D", "master")
+
+			res, err := r.GetRefsContaining(commit)
+			Expect(err).To(BeNil())
+			Expect(res.Branches).To(BeEmpty())
+			Expect(res.Tags).To(BeEmpty())
+		})
+	})
+})
+
+// benchmarkCommitGraph repeatedly gets the ancestors of the head commit
+// of a repository. If cold is true, the commit graph is reset on every
+// iteration so that all commit objects are read from the repository.
+func benchmarkCommitGraph(b *testing.B, cold bool) {
+	dir, err := os.MkdirTemp("", "")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	testutil2.ExecGit(dir, "init", ".")
+	for i := 0; i < 100; i++ {
+		testutil2.AppendCommit(dir, "file.txt", fmt.Sprintf("line %d", i), "commit")
+	}
+	hash := testutil2.GetRecentCommitHash(dir, "HEAD")
+
+	lr, err := repo.GetWithGitModule(gitBinPath, dir)
+	if err != nil {
+		b.Fatal(err)
+	}
+	r := lr.(*repo.Repo)
+	r.CommitGraph = repo.NewCommitGraph(1000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if cold {
+			r.CommitGraph.Reset()
+		}
+		if _, err := r.GetCommitAncestors(hash, 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCommitGraphCold(b *testing.B) {
+	benchmarkCommitGraph(b, true)
+}
+
+func BenchmarkCommitGraphWarm(b *testing.B) {
+	benchmarkCommitGraph(b, false)
+}
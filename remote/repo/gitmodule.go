@@ -552,6 +552,73 @@ func (gm *BasicGitModule) Size() (size float64, err error) {
 	return
 }
 
+// CountObjects returns the number and disk usage of loose and packed objects
+func (gm *BasicGitModule) CountObjects() (*plumbing.ObjectCount, error) {
+	args := []string{"count-objects", "-v"}
+	cmd := exec.Command(gm.gitBinPath, args...)
+	cmd.Dir = gm.path
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, errors.Wrap(err, string(out))
+	}
+
+	// Sizes are reported in KiB
+	count := &plumbing.ObjectCount{}
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		value := cast.ToInt64(strings.TrimSpace(parts[1]))
+		switch parts[0] {
+		case "count":
+			count.LooseObjects = int(value)
+		case "size":
+			count.LooseSize = value * 1024
+		case "in-pack":
+			count.PackedObjects = int(value)
+		case "packs":
+			count.Packs = int(value)
+		case "size-pack":
+			count.PackedSize = value * 1024
+		case "size-garbage":
+			count.GarbageSize = value * 1024
+		}
+	}
+
+	return count, nil
+}
+
+// ProjectedPackSize returns the size of a single pack containing all objects
+// reachable from the references, reflogs and index of the repository.
+// The pack is streamed and discarded; The repository is not modified.
+func (gm *BasicGitModule) ProjectedPackSize() (int64, error) {
+	args := []string{"pack-objects", "--revs", "--all", "--reflog", "--indexed-objects", "--stdout", "-q"}
+	cmd := exec.Command(gm.gitBinPath, args...)
+	cmd.Dir = gm.path
+	cmd.Stdin = strings.NewReader("")
+	counter := &byteCounter{}
+	cmd.Stdout = counter
+	errBuf := bytes.NewBuffer(nil)
+	cmd.Stderr = errBuf
+	if err := cmd.Run(); err != nil {
+		return 0, errors.Wrap(err, errBuf.String())
+	}
+	return counter.n, nil
+}
+
+// byteCounter is an io.Writer that counts the bytes written to it
+type byteCounter struct {
+	n int64
+}
+
+// Write implements io.Writer
+func (c *byteCounter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
+
 // GetPathLogInfo returns log info for a given path
 //  - path: The file or directory path.
 //  - revision: The references whose log is fetched (optional)
@@ -602,3 +669,57 @@ func (gm *BasicGitModule) DiffCommits(commitA, commitB string) (string, error) {
 	}
 	return strings.TrimSpace(string(out)), nil
 }
+
+// DiffCommitsStream runs a diff of the given commits and passes the diff
+// of each file to cb as soon as it is read, without buffering the entire
+// output. Joining the file diffs with a newline gives the output of DiffCommits.
+// Returning an error from cb stops the diff and returns the error.
+func (gm *BasicGitModule) DiffCommitsStream(commitA, commitB string, cb func(fileDiff string) error) error {
+	args := []string{"diff", fmt.Sprintf("%s..%s", commitA, commitB)}
+	cmd := exec.Command(gm.gitBinPath, args...)
+	cmd.Dir = gm.path
+	stderr := bytes.NewBuffer(nil)
+	cmd.Stderr = stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	var cbErr error
+	var fileDiff strings.Builder
+	flush := func() {
+		if fileDiff.Len() > 0 && cbErr == nil {
+			cbErr = cb(strings.TrimRight(fileDiff.String(), "\n"))
+		}
+		fileDiff.Reset()
+	}
+
+	rdr := bufio.NewReader(stdout)
+	for cbErr == nil {
+		line, err := rdr.ReadString('\n')
+		if strings.HasPrefix(line, "diff --git ") {
+			flush()
+		}
+		fileDiff.WriteString(line)
+		if err != nil {
+			break
+		}
+	}
+	flush()
+
+	// Stop git if the callback ended the stream early
+	if cbErr != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return cbErr
+	}
+
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf(strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
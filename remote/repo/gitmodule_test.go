@@ -1,6 +1,7 @@
 package repo_test
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -555,6 +556,42 @@ var _ = Describe("BasicGitModule", func() {
 		})
 	})
 
+	Describe(".CountObjects", func() {
+		It("should return the number and size of loose objects", func() {
+			testutil2.AppendCommit(path, "file.txt", "some text 1", "commit 1")
+			count, err := r.CountObjects()
+			Expect(err).To(BeNil())
+			Expect(count.LooseObjects).To(Equal(3))
+			Expect(count.LooseSize).To(BeNumerically(">", 0))
+			Expect(count.PackedObjects).To(BeZero())
+			Expect(count.Packs).To(BeZero())
+		})
+
+		It("should return the number and size of packed objects after a GC", func() {
+			testutil2.AppendCommit(path, "file.txt", "some text 1", "commit 1")
+			Expect(r.GC()).To(BeNil())
+			count, err := r.CountObjects()
+			Expect(err).To(BeNil())
+			Expect(count.LooseObjects).To(BeZero())
+			Expect(count.PackedObjects).To(Equal(3))
+			Expect(count.Packs).To(Equal(1))
+			Expect(count.PackedSize).To(BeNumerically(">", 0))
+		})
+	})
+
+	Describe(".ProjectedPackSize", func() {
+		It("should return a non-zero size without packing the loose objects", func() {
+			testutil2.AppendCommit(path, "file.txt", "some text 1", "commit 1")
+			size, err := r.ProjectedPackSize()
+			Expect(err).To(BeNil())
+			Expect(size).To(BeNumerically(">", 0))
+			count, err := r.CountObjects()
+			Expect(err).To(BeNil())
+			Expect(count.LooseObjects).To(Equal(3))
+			Expect(count.Packs).To(BeZero())
+		})
+	})
+
 	Describe(".GetPathLogInfo", func() {
 		BeforeEach(func() {
 			r = repo.NewGitModule(cfg.Node.GitBinPath, "testdata/repo1")
@@ -621,4 +658,52 @@ index 5e44cdd..8c3e057 100644
 \ No newline at end of file`))
 		})
 	})
+
+	Describe(".DiffCommitsStream", func() {
+		var parent, child string
+
+		BeforeEach(func() {
+			testutil2.AppendCommit(path, "file.txt", "some text 1", "commit 1")
+			parent = testutil2.GetRecentCommitHash(path, "master")
+			testutil2.AppendToFile(path, "file.txt", "some text 2")
+			testutil2.AppendToFile(path, "file2.txt", "some text 3")
+			testutil2.ExecGitAdd(path, ".")
+			testutil2.ExecGitCommit(path, "commit 2")
+			child = testutil2.GetRecentCommitHash(path, "master")
+		})
+
+		It("should pass the diff of each file to the callback", func() {
+			var diffs []string
+			err := r.DiffCommitsStream(parent, child, func(fileDiff string) error {
+				diffs = append(diffs, fileDiff)
+				return nil
+			})
+			Expect(err).To(BeNil())
+			Expect(diffs).To(HaveLen(2))
+			Expect(diffs[0]).To(HavePrefix("diff --git a/file.txt b/file.txt"))
+			Expect(diffs[1]).To(HavePrefix("diff --git a/file2.txt b/file2.txt"))
+
+			out, err := r.DiffCommits(parent, child)
+			Expect(err).To(BeNil())
+			Expect(strings.Join(diffs, "\n")).To(Equal(out))
+		})
+
+		It("should stop and return the error returned by the callback", func() {
+			var calls int
+			err := r.DiffCommitsStream(parent, child, func(fileDiff string) error {
+				calls++
+				return fmt.Errorf("stop")
+			})
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("stop"))
+			Expect(calls).To(Equal(1))
+		})
+
+		It("should return error when a commit is unknown", func() {
+			err := r.DiffCommitsStream(parent, "0000000000000000000000000000000000000001", func(string) error {
+				return nil
+			})
+			Expect(err).ToNot(BeNil())
+		})
+	})
 })
@@ -0,0 +1,107 @@
+package repo
+
+import (
+	"io/ioutil"
+	"sync"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// FSObjectStore is an ObjectStore that keeps objects in
+// the repository's object database on the local filesystem.
+type FSObjectStore struct {
+	storer storer.EncodedObjectStorer
+}
+
+// NewFSObjectStore creates an instance of FSObjectStore
+func NewFSObjectStore(s storer.EncodedObjectStorer) *FSObjectStore {
+	return &FSObjectStore{storer: s}
+}
+
+// Get returns an encoded object by its hash
+func (s *FSObjectStore) Get(hash plumbing.Hash) (plumbing.EncodedObject, error) {
+	return s.storer.EncodedObject(plumbing.AnyObject, hash)
+}
+
+// Put stores an encoded object and returns its hash
+func (s *FSObjectStore) Put(obj plumbing.EncodedObject) (plumbing.Hash, error) {
+	return s.storer.SetEncodedObject(obj)
+}
+
+// Exists checks whether an object exists
+func (s *FSObjectStore) Exists(hash plumbing.Hash) bool {
+	return s.storer.HasEncodedObject(hash) == nil
+}
+
+// Size returns the decompressed size of an object
+func (s *FSObjectStore) Size(hash plumbing.Hash) (int64, error) {
+	return s.storer.EncodedObjectSize(hash)
+}
+
+// MemObjectStore is an ObjectStore that keeps objects in memory
+type MemObjectStore struct {
+	lck     *sync.RWMutex
+	objects map[plumbing.Hash]plumbing.EncodedObject
+}
+
+// NewMemObjectStore creates an instance of MemObjectStore
+func NewMemObjectStore() *MemObjectStore {
+	return &MemObjectStore{
+		lck:     &sync.RWMutex{},
+		objects: make(map[plumbing.Hash]plumbing.EncodedObject),
+	}
+}
+
+// Get returns an encoded object by its hash
+func (s *MemObjectStore) Get(hash plumbing.Hash) (plumbing.EncodedObject, error) {
+	s.lck.RLock()
+	defer s.lck.RUnlock()
+	obj, ok := s.objects[hash]
+	if !ok {
+		return nil, plumbing.ErrObjectNotFound
+	}
+	return obj, nil
+}
+
+// Put stores an encoded object and returns its hash.
+// The object's content is copied so later changes to obj are not reflected.
+func (s *MemObjectStore) Put(obj plumbing.EncodedObject) (plumbing.Hash, error) {
+	rdr, err := obj.Reader()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	defer rdr.Close()
+	content, err := ioutil.ReadAll(rdr)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	memObj := &plumbing.MemoryObject{}
+	memObj.SetType(obj.Type())
+	if _, err := memObj.Write(content); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	s.lck.Lock()
+	defer s.lck.Unlock()
+	s.objects[memObj.Hash()] = memObj
+	return memObj.Hash(), nil
+}
+
+// Exists checks whether an object exists
+func (s *MemObjectStore) Exists(hash plumbing.Hash) bool {
+	s.lck.RLock()
+	defer s.lck.RUnlock()
+	_, ok := s.objects[hash]
+	return ok
+}
+
+// Size returns the decompressed size of an object
+func (s *MemObjectStore) Size(hash plumbing.Hash) (int64, error) {
+	obj, err := s.Get(hash)
+	if err != nil {
+		return 0, err
+	}
+	return obj.Size(), nil
+}
@@ -0,0 +1,106 @@
+package repo_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/remote/repo"
+	testutil2 "github.com/make-os/kit/remote/testutil"
+	"github.com/make-os/kit/testutil"
+	"github.com/make-os/kit/util"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func makeBlob(content string) *plumbing.MemoryObject {
+	obj := &plumbing.MemoryObject{}
+	obj.SetType(plumbing.BlobObject)
+	obj.Write([]byte(content))
+	return obj
+}
+
+var _ = Describe("ObjectStore", func() {
+	var err error
+	var cfg *config.AppConfig
+	var path string
+
+	BeforeEach(func() {
+		cfg, err = testutil.SetTestCfg()
+		Expect(err).To(BeNil())
+		repoName := util.RandString(5)
+		path = filepath.Join(cfg.GetRepoRoot(), repoName)
+		testutil2.ExecGit(cfg.GetRepoRoot(), "init", repoName)
+	})
+
+	AfterEach(func() {
+		err = os.RemoveAll(cfg.DataDir())
+		Expect(err).To(BeNil())
+	})
+
+	Describe("FSObjectStore", func() {
+		var store *repo.FSObjectStore
+
+		BeforeEach(func() {
+			r, err := git.PlainOpen(path)
+			Expect(err).To(BeNil())
+			store = repo.NewFSObjectStore(r.Storer)
+		})
+
+		It("should return false and ErrObjectNotFound when object does not exist", func() {
+			hash := makeBlob("hello world").Hash()
+			Expect(store.Exists(hash)).To(BeFalse())
+			_, err := store.Get(hash)
+			Expect(err).To(Equal(plumbing.ErrObjectNotFound))
+			_, err = store.Size(hash)
+			Expect(err).To(Equal(plumbing.ErrObjectNotFound))
+		})
+
+		It("should find object created by git", func() {
+			hash := testutil2.CreateBlob(path, "hello world")
+			Expect(store.Exists(plumbing.NewHash(hash))).To(BeTrue())
+			size, err := store.Size(plumbing.NewHash(hash))
+			Expect(err).To(BeNil())
+			Expect(size).To(Equal(int64(11)))
+		})
+
+		It("should store object in the repository's object database", func() {
+			hash, err := store.Put(makeBlob("hello world"))
+			Expect(err).To(BeNil())
+			Expect(testutil2.ExecGit(path, "cat-file", "-p", hash.String())).To(Equal([]byte("hello world")))
+		})
+	})
+
+	Describe("MemObjectStore", func() {
+		var store *repo.MemObjectStore
+
+		BeforeEach(func() {
+			store = repo.NewMemObjectStore()
+		})
+
+		It("should return false and ErrObjectNotFound when object does not exist", func() {
+			hash := makeBlob("hello world").Hash()
+			Expect(store.Exists(hash)).To(BeFalse())
+			_, err := store.Get(hash)
+			Expect(err).To(Equal(plumbing.ErrObjectNotFound))
+			_, err = store.Size(hash)
+			Expect(err).To(Equal(plumbing.ErrObjectNotFound))
+		})
+
+		It("should store and return object", func() {
+			blob := makeBlob("hello world")
+			hash, err := store.Put(blob)
+			Expect(err).To(BeNil())
+			Expect(hash).To(Equal(blob.Hash()))
+			Expect(store.Exists(hash)).To(BeTrue())
+			obj, err := store.Get(hash)
+			Expect(err).To(BeNil())
+			Expect(obj.Type()).To(Equal(plumbing.BlobObject))
+			size, err := store.Size(hash)
+			Expect(err).To(BeNil())
+			Expect(size).To(Equal(int64(11)))
+		})
+	})
+})
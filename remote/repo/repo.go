@@ -1,13 +1,19 @@
 package repo
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
+	"container/heap"
+	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -25,12 +31,15 @@ import (
 	"github.com/make-os/kit/util"
 	"github.com/pkg/errors"
 	"github.com/thoas/go-funk"
+	"golang.org/x/crypto/blake2b"
 )
 
 var (
-	ErrNotAnAncestor = fmt.Errorf("not an ancestor")
-	ErrPathNotFound  = fmt.Errorf("path not found")
-	ErrPathNotAFile  = fmt.Errorf("path is not a file")
+	ErrNotAnAncestor    = fmt.Errorf("not an ancestor")
+	ErrPathNotFound     = fmt.Errorf("path not found")
+	ErrPathNotAFile     = fmt.Errorf("path is not a file")
+	ErrOffsetOutOfRange = fmt.Errorf("offset is out of range")
+	ErrNoMergeBase      = fmt.Errorf("no merge base found")
 )
 
 // Get opens a local repository and returns a handle.
@@ -40,8 +49,9 @@ func Get(path string) (plumbing2.LocalRepo, error) {
 		return nil, err
 	}
 	return &Repo{
-		Repository: repo,
-		Path:       path,
+		Repository:  repo,
+		Path:        path,
+		ObjectStore: NewFSObjectStore(repo.Storer),
 	}, nil
 }
 
@@ -85,6 +95,30 @@ type Repo struct {
 	NamespaceName string
 	Namespace     *state.Namespace
 	State         *state.Repository
+
+	// ObjectStore is the backend where git objects are read from and written to.
+	// When nil, the repository's filesystem object database is used.
+	ObjectStore plumbing2.ObjectStore
+
+	// CommitGraph caches the parent links of commits for ancestry queries.
+	// When nil, ancestry queries read commit objects on every call.
+	CommitGraph *CommitGraph
+}
+
+// GetObjectStore returns the object store backend of the repository.
+// If no object store is set, a store backed by the repository's filesystem
+// object database is returned without being assigned to the repository, so
+// that concurrent callers sharing the handle do not race.
+func (r *Repo) GetObjectStore() plumbing2.ObjectStore {
+	if r.ObjectStore == nil {
+		return NewFSObjectStore(r.Storer)
+	}
+	return r.ObjectStore
+}
+
+// SetObjectStore sets the object store backend of the repository
+func (r *Repo) SetObjectStore(store plumbing2.ObjectStore) {
+	r.ObjectStore = store
 }
 
 // GetState returns the repository's network state
@@ -142,20 +176,28 @@ func (r *Repo) Clone(option plumbing2.CloneOptions) (plumbing2.LocalRepo, string
 
 	repoDir := filepath.Join(dir, r.GetName())
 	if err := os.Mkdir(repoDir, 0700); err != nil {
+		os.RemoveAll(dir)
 		return nil, "", err
 	}
 
+	ctx := option.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	opt := &git.CloneOptions{URL: r.Path, Depth: option.Depth}
 	if option.ReferenceName != "" {
 		opt.SingleBranch = true
 		opt.ReferenceName = plumbing.ReferenceName(option.ReferenceName)
 	}
-	if _, err = git.PlainClone(repoDir, option.Bare, opt); err != nil {
+	if _, err = git.PlainCloneContext(ctx, repoDir, option.Bare, opt); err != nil {
+		os.RemoveAll(dir)
 		return nil, "", errors.Wrap(err, "failed to clone repository")
 	}
 
 	cloned, err := GetWithGitModule(r.gitBinPath, repoDir)
 	if err != nil {
+		os.RemoveAll(dir)
 		return nil, "", err
 	}
 
@@ -325,22 +367,46 @@ func (r *Repo) GetRemoteURLs(names ...string) (urls []string) {
 
 // ObjectExist checks whether an object exist in the target repository
 func (r *Repo) ObjectExist(objHash string) bool {
-	_, err := r.Object(plumbing.AnyObject, plumbing.NewHash(objHash))
-	return err == nil
+	return r.GetObjectStore().Exists(plumbing.NewHash(objHash))
+}
+
+// ObjectsExist checks whether the given objects exist in the target repository.
+// The object store is resolved once for the whole batch and duplicate
+// hashes are checked once. Returns a map of each hash to its presence.
+func (r *Repo) ObjectsExist(hashes []string) map[string]bool {
+	store := r.GetObjectStore()
+	res := make(map[string]bool, len(hashes))
+	for _, hash := range hashes {
+		if _, ok := res[hash]; ok {
+			continue
+		}
+		res[hash] = store.Exists(plumbing.NewHash(hash))
+	}
+	return res
 }
 
 // GetObject returns an object
 func (r *Repo) GetObject(objHash string) (object.Object, error) {
-	obj, err := r.Object(plumbing.AnyObject, plumbing.NewHash(objHash))
+	encObj, err := r.GetObjectStore().Get(plumbing.NewHash(objHash))
 	if err != nil {
 		return nil, err
 	}
-	return obj, nil
+	return object.DecodeObject(r.Storer, encObj)
+}
+
+// WriteObject writes an object to the repository's object store
+// and returns the hash of the object
+func (r *Repo) WriteObject(obj plumbing.EncodedObject) (string, error) {
+	hash, err := r.GetObjectStore().Put(obj)
+	if err != nil {
+		return "", err
+	}
+	return hash.String(), nil
 }
 
 // GetObjectSize returns the size of a decompressed object
 func (r *Repo) GetObjectSize(objHash string) (int64, error) {
-	return r.Storer.EncodedObjectSize(plumbing.NewHash(objHash))
+	return r.GetObjectStore().Size(plumbing.NewHash(objHash))
 }
 
 // ObjectsOfCommit returns a hashes of objects a commit is composed of.
@@ -646,6 +712,323 @@ func (r *Repo) GetFile(ref, path string) (res string, err error) {
 	return file.Contents()
 }
 
+// GetFileChunk returns a byte range of a file.
+// The range is truncated if it extends beyond the end of the file.
+//  - ref: A full reference name or commit hash
+//  - path: The case-sensitive file path
+//  - offset: The position of the first byte to read
+//  - length: The maximum number of bytes to read
+// Returns the bytes read and the size of the file.
+func (r *Repo) GetFileChunk(ref, path string, offset, length int64) (res []byte, size int64, err error) {
+
+	var hash plumbing.Hash
+	if plumbing.IsHash(ref) && !strings.HasPrefix(strings.ToLower(ref), "refs") {
+		hash = plumbing.NewHash(ref)
+	} else {
+		reference, err := r.Reference(plumbing.ReferenceName(ref), true)
+		if err != nil {
+			return nil, 0, err
+		}
+		hash = reference.Hash()
+	}
+
+	commit, err := r.CommitObject(hash)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	targetEntry, err := tree.FindEntry(path)
+	if err != nil {
+		if err == object.ErrEntryNotFound {
+			return nil, 0, ErrPathNotFound
+		}
+		return nil, 0, err
+	} else if targetEntry.Mode == filemode.Dir {
+		return nil, 0, ErrPathNotAFile
+	}
+
+	file, err := tree.TreeEntryFile(targetEntry)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if offset < 0 || offset > file.Size {
+		return nil, file.Size, ErrOffsetOutOfRange
+	}
+
+	rdr, err := file.Reader()
+	if err != nil {
+		return nil, file.Size, err
+	}
+	defer rdr.Close()
+
+	// Skip the bytes before the offset
+	if _, err = io.CopyN(ioutil.Discard, rdr, offset); err != nil {
+		return nil, file.Size, err
+	}
+
+	var buf = bytes.NewBuffer(nil)
+	if _, err = io.CopyN(buf, rdr, length); err != nil && err != io.EOF {
+		return nil, file.Size, err
+	}
+
+	return buf.Bytes(), file.Size, nil
+}
+
+// WriteArchive writes a gzip compressed tar archive of the tree of a commit to w.
+//  - ref: A commit hash, full reference name or branch name
+//  - w: The destination of the archive
+func (r *Repo) WriteArchive(ref string, w io.Writer) error {
+
+	commit, err := r.getRevisionCommit(ref)
+	if err != nil {
+		return err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	err = tree.Files().ForEach(func(f *object.File) error {
+		mode, err := f.Mode.ToOSFileMode()
+		if err != nil {
+			return err
+		}
+
+		hdr := &tar.Header{
+			Name:     f.Name,
+			Mode:     int64(mode.Perm()),
+			Size:     f.Size,
+			ModTime:  commit.Committer.When,
+			Typeflag: tar.TypeReg,
+		}
+
+		// Symbolic links store their target as the blob content
+		if f.Mode == filemode.Symlink {
+			target, err := f.Contents()
+			if err != nil {
+				return err
+			}
+			hdr.Typeflag, hdr.Linkname, hdr.Size = tar.TypeSymlink, target, 0
+			return tw.WriteHeader(hdr)
+		}
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		rdr, err := f.Reader()
+		if err != nil {
+			return err
+		}
+		defer rdr.Close()
+
+		_, err = io.Copy(tw, rdr)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tw.Close(); err != nil {
+		return err
+	}
+
+	return gw.Close()
+}
+
+// GetContentHash returns a blake2b-256 hash of the files in the tree of a commit.
+// The hash is computed over the sorted (path, blob hash) pairs of the tree so
+// that it is independent of the commit metadata.
+//  - ref: A commit hash, full reference name or branch name
+func (r *Repo) GetContentHash(ref string) (string, error) {
+
+	commit, err := r.getRevisionCommit(ref)
+	if err != nil {
+		return "", err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return "", err
+	}
+
+	var entries []string
+	err = tree.Files().ForEach(func(f *object.File) error {
+		entries = append(entries, f.Name+"\x00"+f.Hash.String())
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(entries)
+
+	hash, _ := blake2b.New256(nil)
+	for _, entry := range entries {
+		hash.Write([]byte(entry + "\n"))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// EstimateCloneSize estimates the number and total size of objects a clone
+// of a revision would transfer. The estimate is computed from the objects
+// reachable from the revision and the size of the decompressed objects.
+//  - ref: A commit hash, full reference name or branch name
+//  - depth: The number of commits to include from the revision. 0 means all.
+//  - since: Excludes commits older than this time. Zero time means no limit.
+func (r *Repo) EstimateCloneSize(ref string, depth int, since time.Time) (*plumbing2.CloneSizeEstimate, error) {
+
+	commit, err := r.getRevisionCommit(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	store := r.GetObjectStore()
+	res := &plumbing2.CloneSizeEstimate{}
+	seen := map[plumbing.Hash]struct{}{}
+	addObject := func(hash plumbing.Hash) (bool, error) {
+		if _, ok := seen[hash]; ok {
+			return false, nil
+		}
+		seen[hash] = struct{}{}
+		size, err := store.Size(hash)
+		if err != nil {
+			return false, err
+		}
+		res.Objects++
+		res.Size += size
+		return true, nil
+	}
+
+	var addTree func(hash plumbing.Hash) error
+	addTree = func(hash plumbing.Hash) error {
+		if added, err := addObject(hash); err != nil || !added {
+			return err
+		}
+		tree, err := r.TreeObject(hash)
+		if err != nil {
+			return err
+		}
+		for _, e := range tree.Entries {
+			switch e.Mode {
+			case filemode.Dir:
+				err = addTree(e.Hash)
+			case filemode.Submodule:
+				continue
+			default:
+				_, err = addObject(e.Hash)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Walk the history breadth-first, tracking the depth of each commit
+	type queued struct {
+		commit *object.Commit
+		depth  int
+	}
+	queue := []queued{{commit, 1}}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		if !since.IsZero() && cur.commit.Committer.When.Before(since) {
+			continue
+		}
+		if added, err := addObject(cur.commit.Hash); err != nil {
+			return nil, err
+		} else if !added {
+			continue
+		}
+		if err = addTree(cur.commit.TreeHash); err != nil {
+			return nil, err
+		}
+
+		if depth > 0 && cur.depth >= depth {
+			continue
+		}
+		for _, parentHash := range cur.commit.ParentHashes {
+			parent, err := r.CommitObject(parentHash)
+			if err != nil {
+				return nil, err
+			}
+			queue = append(queue, queued{parent, cur.depth + 1})
+		}
+	}
+
+	return res, nil
+}
+
+// GetObjectStats returns the number of objects of each type in the repository.
+// Objects are enumerated from the object database without being decoded.
+func (r *Repo) GetObjectStats() (*plumbing2.ObjectStats, error) {
+	itr, err := r.Storer.IterEncodedObjects(plumbing.AnyObject)
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	res := &plumbing2.ObjectStats{}
+	err = itr.ForEach(func(obj plumbing.EncodedObject) error {
+		switch obj.Type() {
+		case plumbing.CommitObject:
+			res.Commits++
+		case plumbing.TreeObject:
+			res.Trees++
+		case plumbing.BlobObject:
+			res.Blobs++
+		case plumbing.TagObject:
+			res.Tags++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// getRevisionCommit returns the commit a revision points to.
+// If the revision points to an annotated tag, the tagged commit is returned.
+//  - ref: A commit hash, full reference name or branch name
+func (r *Repo) getRevisionCommit(ref string) (*object.Commit, error) {
+
+	var hash plumbing.Hash
+	if plumbing.IsHash(ref) && !strings.HasPrefix(strings.ToLower(ref), "refs") {
+		hash = plumbing.NewHash(ref)
+	} else {
+		var refname = plumbing.ReferenceName(ref)
+		if ref != plumbing.HEAD.String() && !strings.HasPrefix(ref, "refs/") {
+			refname = plumbing.NewBranchReferenceName(ref)
+		}
+		reference, err := r.Reference(refname, true)
+		if err != nil {
+			return nil, err
+		}
+		hash = reference.Hash()
+	}
+
+	if tag, err := r.TagObject(hash); err == nil {
+		hash = tag.Target
+	}
+
+	return r.CommitObject(hash)
+}
+
 // GetBranches returns a list of branches
 func (r *Repo) GetBranches() (branches []string, err error) {
 	itr, err := r.Branches()
@@ -670,6 +1053,7 @@ func (r *Repo) GetBranches() (branches []string, err error) {
 // GetParentAndChildCommitDiff returns the commit diff output between a
 // child commit and its parent commit(s). If the commit has more than
 // one parent, the diff will be run for all parents.
+// Changed binary files are reported in Binaries and left out of Patches.
 //  - commitHash: The child commit hash.
 func (r *Repo) GetParentAndChildCommitDiff(commitHash string) (*plumbing2.GetCommitDiffResult, error) {
 
@@ -678,19 +1062,155 @@ func (r *Repo) GetParentAndChildCommitDiff(commitHash string) (*plumbing2.GetCom
 		return nil, err
 	}
 
-	res := &plumbing2.GetCommitDiffResult{Patches: []map[string]string{}}
+	res := &plumbing2.GetCommitDiffResult{
+		Patches:  []map[string]string{},
+		Binaries: []map[string][]*plumbing2.BinaryFileDiff{},
+	}
 	commit.Parents().ForEach(func(parent *object.Commit) error {
-		out, err := r.DiffCommits(parent.Hash.String(), commit.Hash.String())
+		binaries, err := r.getBinaryFileDiffs(parent, commit)
+		if err != nil {
+			return err
+		}
+
+		var fileDiffs []string
+		err = r.DiffCommitsStream(parent.Hash.String(), commit.Hash.String(), func(fileDiff string) error {
+			if !isBinaryFileDiff(fileDiff, binaries) {
+				fileDiffs = append(fileDiffs, fileDiff)
+			}
+			return nil
+		})
 		if err != nil {
 			return err
 		}
-		res.Patches = append(res.Patches, map[string]string{parent.Hash.String(): out})
+
+		res.Patches = append(res.Patches, map[string]string{parent.Hash.String(): strings.Join(fileDiffs, "\n")})
+		res.Binaries = append(res.Binaries, map[string][]*plumbing2.BinaryFileDiff{parent.Hash.String(): binaries})
 		return nil
 	})
 
 	return res, nil
 }
 
+// StreamParentAndChildCommitDiff is like GetParentAndChildCommitDiff but
+// passes the diff of each changed file to cb as it is produced.
+//  - commitHash: The child commit hash.
+//  - cb: Called with the parent commit hash and the diff of a file.
+func (r *Repo) StreamParentAndChildCommitDiff(commitHash string, cb func(parentHash, fileDiff string) error) error {
+
+	commit, err := r.CommitObject(plumbing.NewHash(commitHash))
+	if err != nil {
+		return err
+	}
+
+	return commit.Parents().ForEach(func(parent *object.Commit) error {
+		parentHash := parent.Hash.String()
+		return r.DiffCommitsStream(parentHash, commit.Hash.String(), func(fileDiff string) error {
+			return cb(parentHash, fileDiff)
+		})
+	})
+}
+
+// GetCommitTree returns the entries of the tree of a commit and all of
+// its sub-trees, in the order they are found in a depth-first walk.
+// The size of an entry is only set for blobs.
+//  - commitHash: The commit hash.
+func (r *Repo) GetCommitTree(commitHash string) ([]*plumbing2.CommitTreeEntry, error) {
+
+	commit, err := r.CommitObject(plumbing.NewHash(commitHash))
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+
+	var res = []*plumbing2.CommitTreeEntry{}
+	for {
+		name, entry, err := walker.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		item := &plumbing2.CommitTreeEntry{
+			Path: name,
+			Mode: fmt.Sprintf("%06o", uint32(entry.Mode)),
+			Hash: entry.Hash.String(),
+		}
+
+		switch entry.Mode {
+		case filemode.Dir:
+			item.Type = "tree"
+		case filemode.Submodule:
+			item.Type = "commit"
+		default:
+			item.Type = "blob"
+			blob, err := r.BlobObject(entry.Hash)
+			if err != nil {
+				return nil, err
+			}
+			item.Size = blob.Size
+		}
+
+		res = append(res, item)
+	}
+
+	return res, nil
+}
+
+// getBinaryFileDiffs returns the binary files that changed between
+// a parent commit and its child commit.
+//  - parent: The parent commit.
+//  - child: The child commit.
+func (r *Repo) getBinaryFileDiffs(parent, child *object.Commit) ([]*plumbing2.BinaryFileDiff, error) {
+	patch, err := parent.Patch(child)
+	if err != nil {
+		return nil, err
+	}
+
+	var res = []*plumbing2.BinaryFileDiff{}
+	for _, fp := range patch.FilePatches() {
+		if !fp.IsBinary() {
+			continue
+		}
+
+		from, to := fp.Files()
+		bd := &plumbing2.BinaryFileDiff{Binary: true}
+		if from != nil {
+			bd.Path = from.Path()
+			if bd.OldSize, err = r.GetObjectSize(from.Hash().String()); err != nil {
+				return nil, err
+			}
+		}
+		if to != nil {
+			bd.Path = to.Path()
+			if bd.NewSize, err = r.GetObjectSize(to.Hash().String()); err != nil {
+				return nil, err
+			}
+		}
+		res = append(res, bd)
+	}
+
+	return res, nil
+}
+
+// isBinaryFileDiff checks whether the diff of a file belongs to one of the given binary files
+func isBinaryFileDiff(fileDiff string, binaries []*plumbing2.BinaryFileDiff) bool {
+	header := strings.SplitN(fileDiff, "\n", 2)[0]
+	for _, bd := range binaries {
+		if strings.HasPrefix(header, "diff --git a/"+bd.Path+" ") || strings.HasSuffix(header, " b/"+bd.Path) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetLatestCommit returns the recent commit of a branch
 func (r *Repo) GetLatestCommit(branch string) (*plumbing2.CommitResult, error) {
 
@@ -772,7 +1292,9 @@ func (r *Repo) GetCommit(hash string) (*plumbing2.CommitResult, error) {
 // GetCommits returns commits of a branch or commit hash
 //  - ref: The target reference name (branch or commit hash)
 //  - limit: The number of commit to return. 0 means all.
-func (r *Repo) GetCommits(ref string, limit int) (res []*plumbing2.CommitResult, err error) {
+//  - order: The order in which commits are walked. In reverse order,
+//    the limit is applied before the commits are reversed.
+func (r *Repo) GetCommits(ref string, limit int, order plumbing2.CommitOrder) (res []*plumbing2.CommitResult, err error) {
 
 	ref = strings.ToLower(ref)
 	var refname = plumbing.ReferenceName("refs/heads/" + ref)
@@ -802,7 +1324,36 @@ func (r *Repo) GetCommits(ref string, limit int) (res []*plumbing2.CommitResult,
 	if isHash {
 		skip = append(skip, hash)
 	}
-	res, err = iterCommit(commit, limit, nil, skip)
+
+	switch order {
+	case plumbing2.CommitOrderDefault, plumbing2.CommitOrderCommitterDate:
+		res, err = iterCommit(commit, limit, nil, skip)
+	case plumbing2.CommitOrderReverse:
+		res, err = iterCommit(commit, limit, nil, skip)
+		for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+			res[i], res[j] = res[j], res[i]
+		}
+	case plumbing2.CommitOrderAuthorDate:
+		if res, err = iterCommit(commit, 0, nil, skip); err != nil {
+			return nil, err
+		}
+		authorTime := func(c *plumbing2.CommitResult) int64 {
+			if c.Author == nil {
+				return 0
+			}
+			return c.Author.Timestamp
+		}
+		sort.SliceStable(res, func(i, j int) bool {
+			return authorTime(res[i]) > authorTime(res[j])
+		})
+		if limit > 0 && len(res) > limit {
+			res = res[:limit]
+		}
+	case plumbing2.CommitOrderTopological:
+		res, err = iterCommitTopo(commit, limit, skip)
+	default:
+		return nil, fmt.Errorf("unknown commit order (%s)", order)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -814,19 +1365,282 @@ func (r *Repo) GetCommits(ref string, limit int) (res []*plumbing2.CommitResult,
 //  - commitHash: The hash of the commit.
 //  - limit: The number of commit to return. 0 means all.
 func (r *Repo) GetCommitAncestors(commitHash string, limit int) (res []*plumbing2.CommitResult, err error) {
-	commit, err := r.CommitObject(plumbing.NewHash(commitHash))
+	hash := plumbing.NewHash(commitHash)
+	err = r.walkCommits(hash, nil, func(node *CommitNode) error {
+		if node.Hash == hash {
+			return nil
+		}
+		res = append(res, node.Commit)
+		if limit > 0 && len(res) == limit {
+			return storer.ErrStop
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	res, err = iterCommit(commit, limit, nil, []plumbing.Hash{commit.Hash})
+	return
+}
+
+// GetFileHistory returns the commits that changed a file, newest first.
+// A merge commit is only included when the file differs from all its parents.
+// Returns ErrPathNotFound if no commit in the history changed the file.
+//  - ref: A commit hash, full reference name or branch name
+//  - path: The case-sensitive file path
+//  - limit: The number of commits to return. 0 means all.
+func (r *Repo) GetFileHistory(ref, path string, limit int) (res []*plumbing2.FileHistoryEntry, err error) {
+	commit, err := r.getRevisionCommit(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.walkCommits(commit.Hash, nil, func(node *CommitNode) error {
+		hash, err := r.getPathHash(node.Hash, path)
+		if err != nil {
+			return err
+		}
+
+		// Compare the file against the parents; It is unchanged if
+		// it is the same as that of any parent.
+		inParent := false
+		for _, parent := range node.Parents {
+			parentHash, err := r.getPathHash(parent, path)
+			if err != nil {
+				return err
+			}
+			if parentHash == hash {
+				return nil
+			}
+			inParent = inParent || !parentHash.IsZero()
+		}
+
+		var change string
+		switch {
+		case hash.IsZero() && !inParent:
+			return nil
+		case hash.IsZero():
+			change = plumbing2.FileChangeDeleted
+		case !inParent:
+			change = plumbing2.FileChangeAdded
+		default:
+			change = plumbing2.FileChangeModified
+		}
+
+		res = append(res, &plumbing2.FileHistoryEntry{Commit: node.Commit, Change: change})
+		if limit > 0 && len(res) == limit {
+			return storer.ErrStop
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	if len(res) == 0 {
+		return nil, ErrPathNotFound
+	}
+
 	return
 }
 
+// getPathHash returns the hash of the object at a path in the tree of a commit.
+// Returns a zero hash if the path does not exist.
+func (r *Repo) getPathHash(commitHash plumbing.Hash, path string) (plumbing.Hash, error) {
+	commit, err := r.CommitObject(commitHash)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	entry, err := tree.FindEntry(path)
+	if err != nil {
+		if err == object.ErrEntryNotFound || err == object.ErrDirectoryNotFound {
+			return plumbing.ZeroHash, nil
+		}
+		return plumbing.ZeroHash, err
+	}
+	return entry.Hash, nil
+}
+
+// NumCommits counts the number of commits in a reference.
+// It uses the commit graph when available, otherwise, it falls
+// back to the git binary.
+//  - refname: The target reference name (e.g refs/heads/master)
+//  - noMerges: When true, merge commits will not be counted
+func (r *Repo) NumCommits(refname string, noMerges bool) (int, error) {
+	if r.CommitGraph == nil {
+		return r.BasicGitModule.NumCommits(refname, noMerges)
+	}
+
+	hash, err := r.ResolveRevision(plumbing.Revision(refname))
+	if err != nil {
+		if err == plumbing.ErrReferenceNotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	count := 0
+	err = r.walkCommits(*hash, nil, func(node *CommitNode) error {
+		if noMerges && len(node.Parents) > 1 {
+			return nil
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetMergeBase returns the hash of the best common ancestor of two commits.
+// The best common ancestor is the most recent (by committer time) commit
+// reachable from both commits.
+//  - commitA: The hash of the first commit.
+//  - commitB: The hash of the second commit.
+func (r *Repo) GetMergeBase(commitA, commitB string) (string, error) {
+	ancestorsOfA := make(map[plumbing.Hash]struct{})
+	err := r.walkCommits(plumbing.NewHash(commitA), nil, func(node *CommitNode) error {
+		ancestorsOfA[node.Hash] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var base string
+	err = r.walkCommits(plumbing.NewHash(commitB), nil, func(node *CommitNode) error {
+		if _, ok := ancestorsOfA[node.Hash]; ok {
+			base = node.Hash.String()
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if base == "" {
+		return "", ErrNoMergeBase
+	}
+
+	return base, nil
+}
+
+// GetRefsContaining returns the branches and tags whose history includes a
+// commit. The result of each ancestry check is remembered so that commits
+// shared by the histories of multiple references are only visited once.
+// Returns ErrObjectNotFound if the commit does not exist.
+//  - commitHash: The hash of the commit.
+func (r *Repo) GetRefsContaining(commitHash string) (*plumbing2.RefsContainingResult, error) {
+	target := plumbing.NewHash(commitHash)
+	if _, err := r.CommitObject(target); err != nil {
+		return nil, err
+	}
+
+	graph := r.CommitGraph
+	if graph == nil {
+		graph = NewCommitGraph(0)
+	}
+
+	// contains[h] indicates whether the history of commit h includes the target
+	contains := map[plumbing.Hash]bool{target: true}
+	containsTarget := func(start plumbing.Hash) (bool, error) {
+		stack := []plumbing.Hash{start}
+		for len(stack) > 0 {
+			hash := stack[len(stack)-1]
+			if _, ok := contains[hash]; ok {
+				stack = stack[:len(stack)-1]
+				continue
+			}
+
+			node, err := r.getCommitNode(graph, hash)
+			if err != nil {
+				// Parents missing from a shallow history cannot include the target
+				if err == plumbing.ErrObjectNotFound && hash != start {
+					contains[hash] = false
+					stack = stack[:len(stack)-1]
+					continue
+				}
+				return false, err
+			}
+
+			// Visit parents with unknown results before deciding the commit
+			pending, found := false, false
+			for _, parent := range node.Parents {
+				if res, ok := contains[parent]; !ok {
+					stack = append(stack, parent)
+					pending = true
+				} else if res {
+					found = true
+				}
+			}
+			if !pending {
+				contains[hash] = found
+				stack = stack[:len(stack)-1]
+			}
+		}
+		return contains[start], nil
+	}
+
+	refs, err := r.References()
+	if err != nil {
+		return nil, err
+	}
+	defer refs.Close()
+
+	res := &plumbing2.RefsContainingResult{Branches: []string{}, Tags: []string{}}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference || (!ref.Name().IsBranch() && !ref.Name().IsTag()) {
+			return nil
+		}
+
+		// Peel annotated tags until a non-tag object is found
+		hash := ref.Hash()
+		for ref.Name().IsTag() {
+			tag, err := r.TagObject(hash)
+			if err != nil {
+				if err != plumbing.ErrObjectNotFound {
+					return err
+				}
+				break
+			}
+			hash = tag.Target
+		}
+
+		yes, err := containsTarget(hash)
+		if err != nil {
+			// Tags may point to objects that are not commits
+			if err == plumbing.ErrObjectNotFound && ref.Name().IsTag() {
+				return nil
+			}
+			return err
+		} else if !yes {
+			return nil
+		}
+
+		if ref.Name().IsBranch() {
+			res.Branches = append(res.Branches, ref.Name().Short())
+		} else {
+			res.Tags = append(res.Tags, ref.Name().Short())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(res.Branches)
+	sort.Strings(res.Tags)
+
+	return res, nil
+}
+
 // iterCommit walks the history of a commit.
 //  - commit: The commit whose history will be iterated.
 // 	- limit: The max. number of commit to return and iterate.
@@ -852,35 +1666,234 @@ func iterCommit(
 			continue
 		}
 
-		cr := &plumbing2.CommitResult{Message: next.Message, Hash: next.Hash.String()}
-		if next.Committer != (object.Signature{}) {
-			cr.Committer = &plumbing2.CommitSignatory{
-				Name:      next.Committer.Name,
-				Email:     next.Committer.Email,
-				Timestamp: next.Committer.When.Unix(),
-			}
+		cr := newCommitResult(next)
+		res = append(res, cr)
+
+		if limit > 0 && len(res) == limit {
+			break
 		}
-		if next.Author != (object.Signature{}) {
-			cr.Author = &plumbing2.CommitSignatory{
-				Name:      next.Author.Name,
-				Email:     next.Author.Email,
-				Timestamp: next.Author.When.Unix(),
+	}
+	return res, nil
+}
+
+// iterCommitTopo walks the history of a commit in topological order; A commit
+// is only returned after all of its children. Among commits whose children
+// have all been returned, the most recently committed is returned first.
+func iterCommitTopo(commit *object.Commit, limit int, skip []plumbing.Hash) (res []*plumbing2.CommitResult, err error) {
+
+	// Collect the history by committer date; The position
+	// of a commit in this order is used to break ties.
+	var commits []*object.Commit
+	var pos = map[plumbing.Hash]int{}
+	err = object.NewCommitIterCTime(commit, nil, nil).ForEach(func(c *object.Commit) error {
+		pos[c.Hash] = len(commits)
+		commits = append(commits, c)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Count the children of each commit
+	var children = make([]int, len(commits))
+	for _, c := range commits {
+		for _, parent := range c.ParentHashes {
+			if i, ok := pos[parent]; ok {
+				children[i]++
 			}
 		}
+	}
 
-		for _, parent := range next.ParentHashes {
-			cr.ParentHashes = append(cr.ParentHashes, parent.String())
+	var ready = &commitPosHeap{0}
+	for ready.Len() > 0 {
+		c := commits[heap.Pop(ready).(int)]
+		for _, parent := range c.ParentHashes {
+			if i, ok := pos[parent]; ok {
+				if children[i]--; children[i] == 0 {
+					heap.Push(ready, i)
+				}
+			}
 		}
 
-		res = append(res, cr)
+		if funk.Contains(skip, c.Hash) {
+			continue
+		}
 
+		res = append(res, newCommitResult(c))
 		if limit > 0 && len(res) == limit {
 			break
 		}
 	}
+
 	return res, nil
 }
 
+// commitPosHeap is a min-heap of commit positions
+type commitPosHeap []int
+
+func (h commitPosHeap) Len() int            { return len(h) }
+func (h commitPosHeap) Less(i, j int) bool  { return h[i] < h[j] }
+func (h commitPosHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *commitPosHeap) Push(x interface{}) { *h = append(*h, x.(int)) }
+func (h *commitPosHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+// CompareTags returns the commits and changed files between two tags.
+// Commits reachable from fromTag are not included in the result.
+//  - fromTag: The name of the older tag.
+//  - toTag: The name of the newer tag.
+func (r *Repo) CompareTags(fromTag, toTag string) (*plumbing2.CompareTagsResult, error) {
+	fromCommit, err := r.getTagCommit(fromTag)
+	if err != nil {
+		return nil, err
+	}
+
+	toCommit, err := r.getTagCommit(toTag)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &plumbing2.CompareTagsResult{Files: []string{}}
+	res.Commits, err = commitsBetween(fromCommit, toCommit)
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := fromCommit.Patch(toCommit)
+	if err != nil {
+		return nil, err
+	}
+	for _, stat := range patch.Stats() {
+		res.Files = append(res.Files, stat.Name)
+	}
+
+	return res, nil
+}
+
+// GetCommitsBetween returns the commits in the history of toHash that
+// are not in the history of fromHash, most recent first.
+//  - fromHash: The hash of the commit whose history is excluded.
+//  - toHash: The hash of the commit whose history is walked.
+func (r *Repo) GetCommitsBetween(fromHash, toHash string) ([]*plumbing2.CommitResult, error) {
+	fromCommit, err := r.CommitObject(plumbing.NewHash(fromHash))
+	if err != nil {
+		return nil, err
+	}
+
+	toCommit, err := r.CommitObject(plumbing.NewHash(toHash))
+	if err != nil {
+		return nil, err
+	}
+
+	return commitsBetween(fromCommit, toCommit)
+}
+
+// emptyTreeHash is the hash of a git tree with no entries
+const emptyTreeHash = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+// ExportPatchSeries returns a git-format-patch style patch for each commit
+// in the history of toHash that is not in the history of fromHash. The
+// patches are ordered from the oldest commit to the newest.
+//  - fromHash: The hash of the commit whose history is excluded.
+//  - toHash: The hash of the commit whose history is walked.
+func (r *Repo) ExportPatchSeries(fromHash, toHash string) ([]string, error) {
+	commits, err := r.GetCommitsBetween(fromHash, toHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var patches = []string{}
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit, err := r.CommitObject(plumbing.NewHash(commits[i].Hash))
+		if err != nil {
+			return nil, err
+		}
+
+		// Diff against the first parent or, for a root commit, an empty tree
+		parentHash := emptyTreeHash
+		if commit.NumParents() > 0 {
+			parentHash = commit.ParentHashes[0].String()
+		}
+		diff, err := r.DiffCommits(parentHash, commit.Hash.String())
+		if err != nil {
+			return nil, err
+		}
+
+		subjectPrefix := "[PATCH]"
+		if len(commits) > 1 {
+			subjectPrefix = fmt.Sprintf("[PATCH %d/%d]", len(patches)+1, len(commits))
+		}
+
+		msgParts := strings.SplitN(strings.TrimSpace(commit.Message), "\n", 2)
+		var patch strings.Builder
+		patch.WriteString(fmt.Sprintf("From %s Mon Sep 17 00:00:00 2001\n", commit.Hash))
+		patch.WriteString(fmt.Sprintf("From: %s <%s>\n", commit.Author.Name, commit.Author.Email))
+		patch.WriteString(fmt.Sprintf("Date: %s\n", commit.Author.When.Format(time.RFC1123Z)))
+		patch.WriteString(fmt.Sprintf("Subject: %s %s\n\n", subjectPrefix, msgParts[0]))
+		if len(msgParts) > 1 && strings.TrimSpace(msgParts[1]) != "" {
+			patch.WriteString(strings.TrimSpace(msgParts[1]) + "\n")
+		}
+		patch.WriteString("---\n")
+		if diff != "" {
+			patch.WriteString(diff + "\n")
+		}
+
+		patches = append(patches, patch.String())
+	}
+
+	return patches, nil
+}
+
+// commitsBetween returns the commits in the history of 'to'
+// that are not in the history of 'from', most recent first.
+func commitsBetween(from, to *object.Commit) ([]*plumbing2.CommitResult, error) {
+
+	// Collect the history of 'from' so that it can
+	// be excluded when walking the history of 'to'.
+	var ignore []plumbing.Hash
+	err := object.NewCommitPreorderIter(from, nil, nil).ForEach(func(c *object.Commit) error {
+		ignore = append(ignore, c.Hash)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := iterCommit(to, 0, ignore, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]*plumbing2.CommitResult{}, commits...), nil
+}
+
+// getTagCommit returns the commit a tag points to.
+// Annotated tags are peeled until a commit is found.
+// Returns plumbing.ErrReferenceNotFound if the tag does not exist.
+func (r *Repo) getTagCommit(name string) (*object.Commit, error) {
+	ref, err := r.Reference(plumbing.NewTagReferenceName(strings.TrimPrefix(name, "refs/tags/")), true)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := ref.Hash()
+	for {
+		tag, err := r.TagObject(hash)
+		if err != nil {
+			if err != plumbing.ErrObjectNotFound {
+				return nil, err
+			}
+			return r.CommitObject(hash)
+		}
+		hash = tag.Target
+	}
+}
+
 // Push performs push to the repository
 func (r *Repo) Push(options plumbing2.PushOptions) (progress bytes.Buffer, err error) {
 	opts := &git.PushOptions{Progress: &progress}
@@ -1,6 +1,13 @@
 package repo_test
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -65,6 +72,20 @@ var _ = Describe("Repo", func() {
 		})
 	})
 
+	Describe(".ObjectsExist", func() {
+		It("should return the presence of each object in a mixed set", func() {
+			hash := testutil2.CreateBlob(path, "hello world")
+			hash2 := testutil2.CreateBlob(path, "hello world 2")
+			unknown := strings.Repeat("0", 40)
+			res := r.ObjectsExist([]string{hash, unknown, hash2, hash})
+			Expect(res).To(Equal(map[string]bool{hash: true, hash2: true, unknown: false}))
+		})
+
+		It("should return empty map when no hash is given", func() {
+			Expect(r.ObjectsExist(nil)).To(BeEmpty())
+		})
+	})
+
 	Describe(".Prune", func() {
 		It("should remove unreachable objects", func() {
 			hash := testutil2.CreateBlob(path, "hello world")
@@ -92,6 +113,58 @@ var _ = Describe("Repo", func() {
 		})
 	})
 
+	Describe(".WriteObject", func() {
+		It("should write object to the repository's object database", func() {
+			obj := &plumbing.MemoryObject{}
+			obj.SetType(plumbing.BlobObject)
+			obj.Write([]byte("hello world"))
+			hash, err := r.WriteObject(obj)
+			Expect(err).To(BeNil())
+			Expect(hash).To(Equal(obj.Hash().String()))
+			Expect(r.ObjectExist(hash)).To(BeTrue())
+			Expect(testutil2.ExecGit(path, "cat-file", "-p", hash)).To(Equal([]byte("hello world")))
+		})
+	})
+
+	Describe(".GetObjectStore", func() {
+		It("should return the filesystem object store set when the repository was opened", func() {
+			Expect(r.GetObjectStore()).To(BeAssignableToTypeOf(&repo.FSObjectStore{}))
+			Expect(r.(*repo.Repo).ObjectStore).ToNot(BeNil())
+		})
+
+		It("should not assign a store to a repository constructed without one", func() {
+			r2 := &repo.Repo{Repository: r.(*repo.Repo).Repository}
+			Expect(r2.GetObjectStore()).ToNot(BeNil())
+			Expect(r2.ObjectStore).To(BeNil())
+		})
+	})
+
+	Describe(".SetObjectStore", func() {
+		It("should read and write objects through the custom object store", func() {
+			store := repo.NewMemObjectStore()
+			r.SetObjectStore(store)
+			Expect(r.GetObjectStore()).To(Equal(store))
+
+			obj := &plumbing.MemoryObject{}
+			obj.SetType(plumbing.BlobObject)
+			obj.Write([]byte("hello world"))
+			hash, err := r.WriteObject(obj)
+			Expect(err).To(BeNil())
+			Expect(store.Exists(obj.Hash())).To(BeTrue())
+
+			Expect(r.ObjectExist(hash)).To(BeTrue())
+			res, err := r.GetObject(hash)
+			Expect(err).To(BeNil())
+			Expect(res.ID().String()).To(Equal(hash))
+			size, err := r.GetObjectSize(hash)
+			Expect(err).To(BeNil())
+			Expect(size).To(Equal(int64(11)))
+
+			By("not writing the object to the filesystem")
+			Expect(repo.NewFSObjectStore(r.GetStorer()).Exists(obj.Hash())).To(BeFalse())
+		})
+	})
+
 	Describe(".GetReferences", func() {
 		It("should return all references", func() {
 			testutil2.AppendCommit(path, "body", "content", "m1")
@@ -458,6 +531,222 @@ var _ = Describe("Repo", func() {
 		})
 	})
 
+	Describe(".GetFileChunk", func() {
+		BeforeEach(func() {
+			testutil2.AppendCommit(path, "file.txt", "Hello World\nHello Friend\nHello Degens\n", "c1")
+			testutil2.AppendDirAndCommitFile(path, "a", "file2.txt", "File 2", "c2")
+		})
+
+		It("should return error when reference is unknown", func() {
+			_, _, err := r.GetFileChunk("unknown", "file.txt", 0, 1)
+			Expect(err).To(MatchError(plumbing.ErrReferenceNotFound))
+		})
+
+		It("should return the bytes in the requested ranges", func() {
+			bz, size, err := r.GetFileChunk("HEAD", "file.txt", 0, 11)
+			Expect(err).To(BeNil())
+			Expect(size).To(Equal(int64(38)))
+			Expect(string(bz)).To(Equal("Hello World"))
+
+			bz, _, err = r.GetFileChunk("HEAD", "file.txt", 6, 12)
+			Expect(err).To(BeNil())
+			Expect(string(bz)).To(Equal("World\nHello "))
+
+			bz, _, err = r.GetFileChunk("HEAD", "file.txt", 12, 12)
+			Expect(err).To(BeNil())
+			Expect(string(bz)).To(Equal("Hello Friend"))
+		})
+
+		It("should truncate range that extends beyond the end of the file", func() {
+			bz, size, err := r.GetFileChunk("HEAD", "file.txt", 25, 100)
+			Expect(err).To(BeNil())
+			Expect(size).To(Equal(int64(38)))
+			Expect(string(bz)).To(Equal("Hello Degens\n"))
+		})
+
+		It("should return no bytes when offset is at the end of the file", func() {
+			bz, _, err := r.GetFileChunk("HEAD", "file.txt", 38, 10)
+			Expect(err).To(BeNil())
+			Expect(bz).To(BeEmpty())
+		})
+
+		It("should return error when offset is beyond the end of the file", func() {
+			_, _, err := r.GetFileChunk("HEAD", "file.txt", 39, 10)
+			Expect(err).To(MatchError(repo.ErrOffsetOutOfRange))
+		})
+
+		It("should return 'path not found' error when path is unknown", func() {
+			_, _, err := r.GetFileChunk("HEAD", "unknown", 0, 10)
+			Expect(err).To(MatchError(repo.ErrPathNotFound))
+		})
+
+		It("should return 'path is not a file' error when path is not a file", func() {
+			_, _, err := r.GetFileChunk("HEAD", "a", 0, 10)
+			Expect(err).To(MatchError(repo.ErrPathNotAFile))
+		})
+	})
+
+	Describe(".WriteArchive", func() {
+		var readArchive = func(bz []byte) map[string]string {
+			gr, err := gzip.NewReader(bytes.NewReader(bz))
+			Expect(err).To(BeNil())
+			tr := tar.NewReader(gr)
+			files := map[string]string{}
+			for {
+				hdr, err := tr.Next()
+				if err == io.EOF {
+					break
+				}
+				Expect(err).To(BeNil())
+				content, err := ioutil.ReadAll(tr)
+				Expect(err).To(BeNil())
+				files[hdr.Name] = string(content)
+			}
+			return files
+		}
+
+		BeforeEach(func() {
+			testutil2.AppendCommit(path, "file.txt", "hello", "c1")
+			testutil2.AppendDirAndCommitFile(path, "a", "file2.txt", "file 2", "c2")
+		})
+
+		It("should return error when reference is unknown", func() {
+			err := r.WriteArchive("unknown", bytes.NewBuffer(nil))
+			Expect(err).To(MatchError(plumbing.ErrReferenceNotFound))
+		})
+
+		It("should return error when commit is unknown", func() {
+			err := r.WriteArchive(strings.Repeat("0", 40), bytes.NewBuffer(nil))
+			Expect(err).To(MatchError(plumbing.ErrObjectNotFound))
+		})
+
+		It("should write archive of the files at HEAD", func() {
+			buf := bytes.NewBuffer(nil)
+			err := r.WriteArchive("HEAD", buf)
+			Expect(err).To(BeNil())
+			Expect(readArchive(buf.Bytes())).To(Equal(map[string]string{"file.txt": "hello", "a/file2.txt": "file 2"}))
+		})
+
+		It("should write archive of the files of a branch", func() {
+			testutil2.CreateCheckoutBranch(path, "dev")
+			testutil2.AppendCommit(path, "file3.txt", "file 3", "c3")
+			buf := bytes.NewBuffer(nil)
+			err := r.WriteArchive("dev", buf)
+			Expect(err).To(BeNil())
+			Expect(readArchive(buf.Bytes())).To(HaveKey("file3.txt"))
+		})
+
+		It("should write archive of the files of a commit", func() {
+			hash := testutil2.GetRecentCommitHash(path, "HEAD~1")
+			buf := bytes.NewBuffer(nil)
+			err := r.WriteArchive(hash, buf)
+			Expect(err).To(BeNil())
+			Expect(readArchive(buf.Bytes())).To(Equal(map[string]string{"file.txt": "hello"}))
+		})
+
+		It("should write archive of the files of an annotated tag", func() {
+			testutil2.CreateCommitAndAnnotatedTag(path, "file.txt", "v1", "c3", "v1")
+			buf := bytes.NewBuffer(nil)
+			err := r.WriteArchive("refs/tags/v1", buf)
+			Expect(err).To(BeNil())
+			Expect(readArchive(buf.Bytes())).To(HaveKeyWithValue("file.txt", "hellov1"))
+		})
+	})
+
+	Describe(".GetContentHash", func() {
+		BeforeEach(func() {
+			testutil2.AppendCommit(path, "file.txt", "hello", "c1")
+			testutil2.AppendDirAndCommitFile(path, "a", "file2.txt", "file 2", "c2")
+		})
+
+		It("should return error when reference is unknown", func() {
+			_, err := r.GetContentHash("unknown")
+			Expect(err).To(MatchError(plumbing.ErrReferenceNotFound))
+		})
+
+		It("should return error when commit is unknown", func() {
+			_, err := r.GetContentHash(strings.Repeat("0", 40))
+			Expect(err).To(MatchError(plumbing.ErrObjectNotFound))
+		})
+
+		It("should return same hash for identical content in different commits", func() {
+			hash, err := r.GetContentHash("HEAD")
+			Expect(err).To(BeNil())
+			Expect(hash).To(HaveLen(64))
+
+			testutil2.CreateCheckoutBranch(path, "dev")
+			testutil2.ExecGit(path, "commit", "--allow-empty", "-m", "c3")
+			hash2, err := r.GetContentHash("dev")
+			Expect(err).To(BeNil())
+			Expect(testutil2.GetRecentCommitHash(path, "dev")).ToNot(Equal(testutil2.GetRecentCommitHash(path, "master")))
+			Expect(hash2).To(Equal(hash))
+		})
+
+		It("should return a different hash when a file changes", func() {
+			hash, err := r.GetContentHash("HEAD")
+			Expect(err).To(BeNil())
+			testutil2.AppendCommit(path, "file.txt", " world", "c3")
+			hash2, err := r.GetContentHash("HEAD")
+			Expect(err).To(BeNil())
+			Expect(hash2).ToNot(Equal(hash))
+		})
+	})
+
+	Describe(".EstimateCloneSize", func() {
+		BeforeEach(func() {
+			testutil2.AppendCommit(path, "file.txt", "hello", "c1")
+			testutil2.AppendDirAndCommitFile(path, "a", "file2.txt", "file 2", "c2")
+		})
+
+		It("should return error when reference is unknown", func() {
+			_, err := r.EstimateCloneSize("unknown", 0, time.Time{})
+			Expect(err).To(MatchError(plumbing.ErrReferenceNotFound))
+		})
+
+		It("should count all objects reachable from the reference when depth is 0", func() {
+			res, err := r.EstimateCloneSize("HEAD", 0, time.Time{})
+			Expect(err).To(BeNil())
+			Expect(res.Objects).To(Equal(7))
+			Expect(res.Size).To(BeNumerically(">", 0))
+		})
+
+		It("should count only the objects of the tip commit when depth is 1", func() {
+			full, err := r.EstimateCloneSize("master", 0, time.Time{})
+			Expect(err).To(BeNil())
+			res, err := r.EstimateCloneSize("master", 1, time.Time{})
+			Expect(err).To(BeNil())
+			Expect(res.Objects).To(Equal(5))
+			Expect(res.Size).To(BeNumerically("<", full.Size))
+		})
+
+		It("should exclude commits older than the since time", func() {
+			res, err := r.EstimateCloneSize("HEAD", 0, time.Now().Add(time.Hour))
+			Expect(err).To(BeNil())
+			Expect(res.Objects).To(BeZero())
+			Expect(res.Size).To(BeZero())
+		})
+	})
+
+	Describe(".GetObjectStats", func() {
+		It("should return zero counts when repository has no objects", func() {
+			res, err := r.GetObjectStats()
+			Expect(err).To(BeNil())
+			Expect(res).To(Equal(&rr.ObjectStats{}))
+		})
+
+		It("should count objects by type", func() {
+			testutil2.AppendCommit(path, "file.txt", "hello", "c1")
+			testutil2.AppendDirAndCommitFile(path, "a", "file2.txt", "file 2", "c2")
+			testutil2.CreateCommitAndAnnotatedTag(path, "file.txt", "tagged", "c3", "v1")
+			res, err := r.GetObjectStats()
+			Expect(err).To(BeNil())
+			Expect(res.Commits).To(Equal(3))
+			Expect(res.Trees).To(Equal(4))
+			Expect(res.Blobs).To(Equal(3))
+			Expect(res.Tags).To(Equal(1))
+		})
+	})
+
 	Describe(".GetBranches", func() {
 		BeforeEach(func() {
 			r, err = repo.GetWithGitModule(cfg.Node.GitBinPath, "testdata/repo1")
@@ -472,12 +761,9 @@ var _ = Describe("Repo", func() {
 	})
 
 	Describe(".Clone", func() {
-		BeforeEach(func() {
+		It("should successfully create a worktree clone", func() {
 			r, err = repo.GetWithGitModule(cfg.Node.GitBinPath, "testdata/repo1")
 			Expect(err).To(BeNil())
-		})
-
-		It("should successfully create a worktree clone", func() {
 			clone, temp, err := r.Clone(rr.CloneOptions{
 				Bare:          true,
 				ReferenceName: "refs/heads/dev",
@@ -493,6 +779,16 @@ var _ = Describe("Repo", func() {
 			Expect(err).To(BeNil())
 			Expect(numCommit).To(Equal(1))
 		})
+
+		It("should return error when context is cancelled", func() {
+			testutil2.AppendCommit(path, "file.txt", "line 1", "commit 1")
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, temp, err := r.Clone(rr.CloneOptions{Context: ctx})
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("context canceled"))
+			Expect(temp).To(BeEmpty())
+		})
 	})
 
 	Describe(".Push", func() {
@@ -579,6 +875,74 @@ var _ = Describe("Repo", func() {
 		})
 	})
 
+	Describe(".StreamParentAndChildCommitDiff", func() {
+		It("should pass the diff of each file and its parent to the callback", func() {
+			testutil2.AppendCommit(path, "file.txt", "some text 1", "commit 1")
+			parent := testutil2.GetRecentCommitHash(path, "HEAD")
+			testutil2.AppendToFile(path, "file.txt", "some text 2")
+			testutil2.AppendToFile(path, "file2.txt", "some text 3")
+			testutil2.ExecGitAdd(path, ".")
+			testutil2.ExecGitCommit(path, "commit 2")
+			child := testutil2.GetRecentCommitHash(path, "HEAD")
+
+			var parents, diffs []string
+			err = r.StreamParentAndChildCommitDiff(child, func(parentHash, fileDiff string) error {
+				parents = append(parents, parentHash)
+				diffs = append(diffs, fileDiff)
+				return nil
+			})
+			Expect(err).To(BeNil())
+			Expect(parents).To(Equal([]string{parent, parent}))
+			Expect(diffs).To(HaveLen(2))
+
+			res, err := r.GetParentAndChildCommitDiff(child)
+			Expect(err).To(BeNil())
+			Expect(strings.Join(diffs, "\n")).To(Equal(res.Patches[0][parent]))
+		})
+
+		It("should return ErrObjectNotFound when commit does not exist", func() {
+			err = r.StreamParentAndChildCommitDiff("0000000000000000000000000000000000000001", func(string, string) error {
+				return nil
+			})
+			Expect(err).To(Equal(plumbing.ErrObjectNotFound))
+		})
+	})
+
+	Describe(".GetCommitTree", func() {
+		It("should return all entries of the commit tree including sub-trees", func() {
+			testutil2.AppendCommit(path, "file.txt", "some text", "commit 1")
+			testutil2.AppendToFile(path, "file2.txt", "some text")
+			err := os.MkdirAll(filepath.Join(path, "dir", "sub"), 0700)
+			Expect(err).To(BeNil())
+			testutil2.AppendToFile(path, "dir/sub/file3.txt", "hello")
+			testutil2.ExecGitAdd(path, ".")
+			testutil2.ExecGitCommit(path, "commit 2")
+			hash := testutil2.GetRecentCommitHash(path, "HEAD")
+
+			entries, err := r.GetCommitTree(hash)
+			Expect(err).To(BeNil())
+			var paths []string
+			for _, e := range entries {
+				paths = append(paths, e.Path)
+			}
+			Expect(paths).To(Equal([]string{"dir", "dir/sub", "dir/sub/file3.txt", "file.txt", "file2.txt"}))
+			Expect(entries[0].Type).To(Equal("tree"))
+			Expect(entries[0].Mode).To(Equal("040000"))
+			Expect(entries[0].Size).To(Equal(int64(0)))
+			Expect(entries[2].Type).To(Equal("blob"))
+			Expect(entries[2].Mode).To(Equal("100644"))
+			Expect(entries[2].Size).To(Equal(int64(5)))
+			blobHash := strings.TrimSpace(string(testutil2.ExecGit(path, "rev-parse", hash+":dir/sub/file3.txt")))
+			Expect(entries[2].Hash).To(Equal(blobHash))
+			Expect(entries[3].Hash).To(Equal(entries[4].Hash))
+		})
+
+		It("should return ErrObjectNotFound when commit does not exist", func() {
+			_, err = r.GetCommitTree("0000000000000000000000000000000000000001")
+			Expect(err).To(Equal(plumbing.ErrObjectNotFound))
+		})
+	})
+
 	Describe(".GetParentAndChildCommitDiff", func() {
 		It("should return expected patch output", func() {
 			r, err = repo.GetWithGitModule(cfg.Node.GitBinPath, "testdata/repo3")
@@ -596,6 +960,167 @@ index 0000000..3b0c2f1
 +We made games
 \ No newline at end of file`))
 		})
+
+		It("should return structured output for changed binary files", func() {
+			binFile := filepath.Join(path, "image.bin")
+			Expect(os.WriteFile(binFile, []byte{0x00, 0x01, 0x02}, 0644)).To(BeNil())
+			testutil2.ExecGitAdd(path, "image.bin")
+			testutil2.ExecGitCommit(path, "add binary")
+			parent := testutil2.GetRecentCommitHash(path, "HEAD")
+			Expect(os.WriteFile(binFile, []byte{0x00, 0x01, 0x02, 0x03, 0x04}, 0644)).To(BeNil())
+			testutil2.ExecGitAdd(path, "image.bin")
+			testutil2.ExecGitCommit(path, "modify binary")
+			child := testutil2.GetRecentCommitHash(path, "HEAD")
+
+			res, err := r.GetParentAndChildCommitDiff(child)
+			Expect(err).To(BeNil())
+			Expect(res.Patches).To(HaveLen(1))
+			Expect(res.Patches[0]).To(HaveKey(parent))
+			Expect(res.Binaries).To(HaveLen(1))
+			Expect(res.Binaries[0][parent]).To(HaveLen(1))
+			Expect(res.Binaries[0][parent][0]).To(Equal(&rr.BinaryFileDiff{Path: "image.bin", Binary: true, OldSize: 3, NewSize: 5}))
+			Expect(res.Patches[0][parent]).To(BeEmpty())
+		})
+
+		It("should leave changed binary files out of the text patch of a commit that also changes text files", func() {
+			testutil2.AppendToFile(path, "file.txt", "some text")
+			testutil2.ExecGitAdd(path, "file.txt")
+			testutil2.ExecGitCommit(path, "add text")
+			parent := testutil2.GetRecentCommitHash(path, "HEAD")
+
+			binFile := filepath.Join(path, "image.bin")
+			Expect(os.WriteFile(binFile, []byte{0x00, 0x01, 0x02}, 0644)).To(BeNil())
+			testutil2.AppendToFile(path, "file.txt", " more text")
+			testutil2.ExecGitAdd(path, ".")
+			testutil2.ExecGitCommit(path, "add binary and text")
+
+			res, err := r.GetParentAndChildCommitDiff(testutil2.GetRecentCommitHash(path, "HEAD"))
+			Expect(err).To(BeNil())
+			Expect(res.Binaries[0][parent]).To(HaveLen(1))
+			Expect(res.Patches[0][parent]).To(ContainSubstring("diff --git a/file.txt b/file.txt"))
+			Expect(res.Patches[0][parent]).ToNot(ContainSubstring("image.bin"))
+		})
+	})
+
+	Describe(".CompareTags", func() {
+		It("should return ErrReferenceNotFound if a tag is unknown", func() {
+			testutil2.CreateCommitAndAnnotatedTag(path, "file.txt", "line 1", "commit 1", "v1")
+			_, err := r.CompareTags("v1", "unknown")
+			Expect(err).ToNot(BeNil())
+			Expect(err).To(MatchError(plumbing.ErrReferenceNotFound))
+		})
+
+		It("should return commits and changed files between the tags", func() {
+			testutil2.CreateCommitAndAnnotatedTag(path, "file.txt", "line 1", "commit 1", "v1")
+			testutil2.AppendCommit(path, "file.txt", "line 2", "commit 2")
+			testutil2.CreateCommitAndLightWeightTag(path, "file2.txt", "line 1", "commit 3", "v2")
+			res, err := r.CompareTags("v1", "refs/tags/v2")
+			Expect(err).To(BeNil())
+			Expect(res.Commits).To(HaveLen(2))
+			Expect(res.Commits[0].Message).To(Equal("commit 3\n"))
+			Expect(res.Commits[1].Message).To(Equal("commit 2\n"))
+			Expect(res.Files).To(ConsistOf("file.txt", "file2.txt"))
+		})
+
+		It("should return no commits and files when both tags point to the same commit", func() {
+			testutil2.CreateCommitAndAnnotatedTag(path, "file.txt", "line 1", "commit 1", "v1")
+			testutil2.CreateTagPointedToTag(path, "v2 tag", "v2", "v1")
+			res, err := r.CompareTags("v1", "v2")
+			Expect(err).To(BeNil())
+			Expect(res.Commits).To(BeEmpty())
+			Expect(res.Files).To(BeEmpty())
+		})
+	})
+
+	Describe(".GetCommitsBetween", func() {
+		It("should return ErrObjectNotFound if a commit is unknown", func() {
+			testutil2.AppendCommit(path, "file.txt", "line 1", "commit 1")
+			hash := testutil2.GetRecentCommitHash(path, "refs/heads/master")
+			_, err := r.GetCommitsBetween(hash, "a1f8a4b6a39c2f3b7e1b5cbd2e7f6e8a9d3c4b5a")
+			Expect(err).To(Equal(plumbing.ErrObjectNotFound))
+		})
+
+		It("should return the commits of the second commit that are not in the history of the first", func() {
+			testutil2.AppendCommit(path, "file.txt", "line 1", "commit 1")
+			testutil2.CreateCheckoutBranch(path, "dev")
+			testutil2.AppendCommit(path, "file.txt", "line 2", "commit 2")
+			testutil2.AppendCommit(path, "file.txt", "line 3", "commit 3")
+			testutil2.CheckoutBranch(path, "master")
+			testutil2.AppendCommit(path, "file2.txt", "line 1", "commit 4")
+			master := testutil2.GetRecentCommitHash(path, "refs/heads/master")
+			dev := testutil2.GetRecentCommitHash(path, "refs/heads/dev")
+
+			commits, err := r.GetCommitsBetween(master, dev)
+			Expect(err).To(BeNil())
+			Expect(commits).To(HaveLen(2))
+			Expect(commits[0].Hash).To(Equal(dev))
+
+			commits, err = r.GetCommitsBetween(dev, master)
+			Expect(err).To(BeNil())
+			Expect(commits).To(HaveLen(1))
+			Expect(commits[0].Hash).To(Equal(master))
+		})
+
+		It("should return no commits when the second commit is in the history of the first", func() {
+			testutil2.AppendCommit(path, "file.txt", "line 1", "commit 1")
+			first := testutil2.GetRecentCommitHash(path, "refs/heads/master")
+			testutil2.AppendCommit(path, "file.txt", "line 2", "commit 2")
+			commits, err := r.GetCommitsBetween(testutil2.GetRecentCommitHash(path, "refs/heads/master"), first)
+			Expect(err).To(BeNil())
+			Expect(commits).To(BeEmpty())
+		})
+	})
+
+	Describe(".ExportPatchSeries", func() {
+		It("should return ErrObjectNotFound if a commit is unknown", func() {
+			testutil2.AppendCommit(path, "file.txt", "line 1", "commit 1")
+			hash := testutil2.GetRecentCommitHash(path, "refs/heads/master")
+			_, err := r.ExportPatchSeries(hash, "a1f8a4b6a39c2f3b7e1b5cbd2e7f6e8a9d3c4b5a")
+			Expect(err).To(Equal(plumbing.ErrObjectNotFound))
+		})
+
+		It("should return a patch for each commit in the range, oldest first", func() {
+			testutil2.AppendCommit(path, "file.txt", "line 1\n", "commit 1")
+			from := testutil2.GetRecentCommitHash(path, "refs/heads/master")
+			testutil2.AppendCommit(path, "file.txt", "line 2\n", "commit 2")
+			second := testutil2.GetRecentCommitHash(path, "refs/heads/master")
+			testutil2.AppendCommit(path, "file2.txt", "hello\n", "commit 3")
+			to := testutil2.GetRecentCommitHash(path, "refs/heads/master")
+
+			patches, err := r.ExportPatchSeries(from, to)
+			Expect(err).To(BeNil())
+			Expect(patches).To(HaveLen(2))
+
+			Expect(patches[0]).To(HavePrefix("From " + second + " Mon Sep 17 00:00:00 2001\n"))
+			Expect(patches[0]).To(ContainSubstring("Subject: [PATCH 1/2] commit 2\n"))
+			Expect(patches[0]).To(ContainSubstring("--- a/file.txt\n+++ b/file.txt\n"))
+			Expect(patches[0]).To(ContainSubstring("+line 2\n"))
+
+			Expect(patches[1]).To(HavePrefix("From " + to + " Mon Sep 17 00:00:00 2001\n"))
+			Expect(patches[1]).To(ContainSubstring("Subject: [PATCH 2/2] commit 3\n"))
+			Expect(patches[1]).To(ContainSubstring("new file mode 100644"))
+			Expect(patches[1]).To(ContainSubstring("+hello\n"))
+		})
+
+		It("should return a patch that applies to the commit's parent", func() {
+			testutil2.AppendCommit(path, "file.txt", "line 1\n", "commit 1")
+			from := testutil2.GetRecentCommitHash(path, "refs/heads/master")
+			testutil2.AppendCommit(path, "file.txt", "line 2\n", "commit 2")
+			to := testutil2.GetRecentCommitHash(path, "refs/heads/master")
+
+			patches, err := r.ExportPatchSeries(from, to)
+			Expect(err).To(BeNil())
+			Expect(patches).To(HaveLen(1))
+			Expect(patches[0]).To(ContainSubstring("Subject: [PATCH] commit 2\n"))
+
+			testutil2.ExecGit(path, "checkout", "-q", "-b", "apply", from)
+			patchFile := filepath.Join(cfg.DataDir(), "series.patch")
+			Expect(ioutil.WriteFile(patchFile, []byte(patches[0]), 0644)).To(BeNil())
+			testutil2.ExecGit(path, "am", "-q", patchFile)
+			applied := testutil2.GetRecentCommitHash(path, "refs/heads/apply")
+			tree := strings.TrimSpace(string(testutil2.ExecGit(path, "rev-parse", applied+"^{tree}")))
+			Expect(tree).To(Equal(strings.TrimSpace(string(testutil2.ExecGit(path, "rev-parse", to+"^{tree}")))))
+		})
 	})
 
 	Describe(".GetCommits", func() {
@@ -605,19 +1130,19 @@ index 0000000..3b0c2f1
 		})
 
 		It("should return an error if branch is unknown", func() {
-			_, err := r.GetCommits("unknown", 0)
+			_, err := r.GetCommits("unknown", 0, rr.CommitOrderDefault)
 			Expect(err).ToNot(BeNil())
 			Expect(err).To(MatchError(plumbing.ErrReferenceNotFound))
 		})
 
 		It("should return an error if branch is not branch reference", func() {
-			_, err := r.GetCommits("refs/tags/v1", 0)
+			_, err := r.GetCommits("refs/tags/v1", 0, rr.CommitOrderDefault)
 			Expect(err).ToNot(BeNil())
 			Expect(err).To(MatchError(plumbing.ErrReferenceNotFound))
 		})
 
 		It("should return a commits even when branch name is short", func() {
-			commits, err := r.GetCommits("master", 0)
+			commits, err := r.GetCommits("master", 0, rr.CommitOrderDefault)
 			Expect(err).To(BeNil())
 			Expect(commits).To(HaveLen(11))
 			Expect(commits[0].Hash).To(Equal("bc2d3657cad5fb7a3ed2f4f9b178c38587ba2fc6"))
@@ -625,7 +1150,7 @@ index 0000000..3b0c2f1
 		})
 
 		It("should return a commits even when branch name is short", func() {
-			commits, err := r.GetCommits("refs/heads/master", 0)
+			commits, err := r.GetCommits("refs/heads/master", 0, rr.CommitOrderDefault)
 			Expect(err).To(BeNil())
 			Expect(commits).To(HaveLen(11))
 			Expect(commits[0].Hash).To(Equal("bc2d3657cad5fb7a3ed2f4f9b178c38587ba2fc6"))
@@ -633,7 +1158,7 @@ index 0000000..3b0c2f1
 		})
 
 		It("should return limited number if commits when limit is > 0", func() {
-			commits, err := r.GetCommits("master", 3)
+			commits, err := r.GetCommits("master", 3, rr.CommitOrderDefault)
 			Expect(err).To(BeNil())
 			Expect(commits).To(HaveLen(3))
 			Expect(commits[0].Hash).To(Equal("bc2d3657cad5fb7a3ed2f4f9b178c38587ba2fc6"))
@@ -641,13 +1166,101 @@ index 0000000..3b0c2f1
 		})
 
 		It("should return a commits even commit hash is provided", func() {
-			commits, err := r.GetCommits("cbc329e7e912227d58edea6d6a74d550cd664adf", 0)
+			commits, err := r.GetCommits("cbc329e7e912227d58edea6d6a74d550cd664adf", 0, rr.CommitOrderDefault)
 			Expect(err).To(BeNil())
 			Expect(commits).To(HaveLen(1))
 			Expect(commits[0].Hash).To(Equal("932401fb0bf48f602c501334b773fbc3422ceb31"))
 		})
 	})
 
+	Describe(".GetCommits (order)", func() {
+		var c1, c2, f1, merge string
+
+		// commitAt runs a git command with the given author and committer times;
+		// The times are offsets in seconds from a fixed unix time.
+		commitAt := func(authorTime, committerTime int, args ...string) {
+			env := testutil2.GitEnv
+			defer func() { testutil2.GitEnv = env }()
+			testutil2.GitEnv = append(append([]string{}, env...),
+				fmt.Sprintf("GIT_AUTHOR_DATE=@%d +0000", 1600000000+authorTime),
+				fmt.Sprintf("GIT_COMMITTER_DATE=@%d +0000", 1600000000+committerTime))
+			testutil2.ExecGit(path, args...)
+		}
+
+		// The committer time of c1 is later than that of its child c2
+		BeforeEach(func() {
+			testutil2.AppendToFile(path, "a.txt", "c1")
+			testutil2.ExecGit(path, "add", ".")
+			commitAt(1000, 1800, "commit", "-m", "c1")
+			c1 = testutil2.GetRecentCommitHash(path, "HEAD")
+			testutil2.CreateCheckoutBranch(path, "feature")
+			testutil2.AppendToFile(path, "b.txt", "f1")
+			testutil2.ExecGit(path, "add", ".")
+			commitAt(2000, 2000, "commit", "-m", "f1")
+			f1 = testutil2.GetRecentCommitHash(path, "HEAD")
+			testutil2.CheckoutBranch(path, "master")
+			testutil2.AppendToFile(path, "c.txt", "c2")
+			testutil2.ExecGit(path, "add", ".")
+			commitAt(3000, 1500, "commit", "-m", "c2")
+			c2 = testutil2.GetRecentCommitHash(path, "HEAD")
+			commitAt(4000, 4000, "merge", "--no-ff", "-m", "merge", "feature")
+			merge = testutil2.GetRecentCommitHash(path, "HEAD")
+		})
+
+		hashes := func(commits []*rr.CommitResult) (res []string) {
+			for _, c := range commits {
+				res = append(res, c.Hash)
+			}
+			return
+		}
+
+		It("should return commits by committer date by default", func() {
+			for _, order := range []rr.CommitOrder{rr.CommitOrderDefault, rr.CommitOrderCommitterDate} {
+				commits, err := r.GetCommits("master", 0, order)
+				Expect(err).To(BeNil())
+				Expect(hashes(commits)).To(Equal([]string{merge, f1, c1, c2}))
+			}
+		})
+
+		It("should return commits by author date", func() {
+			commits, err := r.GetCommits("master", 0, rr.CommitOrderAuthorDate)
+			Expect(err).To(BeNil())
+			Expect(hashes(commits)).To(Equal([]string{merge, c2, f1, c1}))
+		})
+
+		It("should not return a parent before its children in topological order", func() {
+			commits, err := r.GetCommits("master", 0, rr.CommitOrderTopological)
+			Expect(err).To(BeNil())
+			Expect(hashes(commits)).To(Equal([]string{merge, f1, c2, c1}))
+		})
+
+		It("should return commits oldest first in reverse order", func() {
+			commits, err := r.GetCommits("master", 0, rr.CommitOrderReverse)
+			Expect(err).To(BeNil())
+			Expect(hashes(commits)).To(Equal([]string{c2, c1, f1, merge}))
+		})
+
+		It("should apply limit before reversing commits", func() {
+			commits, err := r.GetCommits("master", 2, rr.CommitOrderReverse)
+			Expect(err).To(BeNil())
+			Expect(hashes(commits)).To(Equal([]string{f1, merge}))
+		})
+
+		It("should apply limit to ordered commits", func() {
+			commits, err := r.GetCommits("master", 2, rr.CommitOrderAuthorDate)
+			Expect(err).To(BeNil())
+			Expect(hashes(commits)).To(Equal([]string{merge, c2}))
+			commits, err = r.GetCommits("master", 3, rr.CommitOrderTopological)
+			Expect(err).To(BeNil())
+			Expect(hashes(commits)).To(Equal([]string{merge, f1, c2}))
+		})
+
+		It("should return error when order is unknown", func() {
+			_, err := r.GetCommits("master", 0, "unknown")
+			Expect(err).To(MatchError("unknown commit order (unknown)"))
+		})
+	})
+
 	Describe(".GetCommitAncestors", func() {
 		BeforeEach(func() {
 			r, err = repo.GetWithGitModule(cfg.Node.GitBinPath, "testdata/repo1")
@@ -675,4 +1288,83 @@ index 0000000..3b0c2f1
 			Expect(commits[0].Hash).To(Equal("c28e295ca030fa4ac9537f9f583f6b4b48be302b"))
 		})
 	})
+	Describe(".GetFileHistory", func() {
+		var added, modified, branchMod, deleted, readded string
+
+		// commit stages all changes and commits them at the given time offset
+		commit := func(t int, msg string) string {
+			env := testutil2.GitEnv
+			defer func() { testutil2.GitEnv = env }()
+			testutil2.GitEnv = append(append([]string{}, env...),
+				fmt.Sprintf("GIT_AUTHOR_DATE=@%d +0000", 1600000000+t),
+				fmt.Sprintf("GIT_COMMITTER_DATE=@%d +0000", 1600000000+t))
+			testutil2.ExecGit(path, "add", "-A")
+			testutil2.ExecGit(path, "commit", "-m", msg)
+			return testutil2.GetRecentCommitHash(path, "HEAD")
+		}
+
+		BeforeEach(func() {
+			testutil2.AppendToFile(path, "file.txt", "line 1\n")
+			added = commit(1, "add file")
+			testutil2.AppendToFile(path, "other.txt", "line 1\n")
+			commit(2, "add other")
+			testutil2.AppendToFile(path, "file.txt", "line 2\n")
+			modified = commit(3, "modify file")
+			testutil2.CreateCheckoutBranch(path, "dev")
+			testutil2.AppendToFile(path, "file.txt", "line 3\n")
+			branchMod = commit(4, "modify file in dev")
+			testutil2.CheckoutBranch(path, "master")
+			testutil2.AppendToFile(path, "other.txt", "line 2\n")
+			commit(5, "modify other")
+			env := testutil2.GitEnv
+			testutil2.GitEnv = append(append([]string{}, env...), "GIT_COMMITTER_DATE=@1600000006 +0000")
+			testutil2.ExecGit(path, "merge", "--no-ff", "-m", "merge dev", "dev")
+			testutil2.GitEnv = env
+			Expect(os.Remove(filepath.Join(path, "file.txt"))).To(BeNil())
+			deleted = commit(7, "delete file")
+			testutil2.AppendToFile(path, "file.txt", "line 1\n")
+			readded = commit(8, "re-add file")
+		})
+
+		hashes := func(entries []*rr.FileHistoryEntry) (res []string) {
+			for _, e := range entries {
+				res = append(res, e.Commit.Hash)
+			}
+			return
+		}
+
+		It("should return the commits that changed the file, newest first", func() {
+			res, err := r.GetFileHistory("HEAD", "file.txt", 0)
+			Expect(err).To(BeNil())
+			Expect(hashes(res)).To(Equal([]string{readded, deleted, branchMod, modified, added}))
+			Expect(res[0].Change).To(Equal(rr.FileChangeAdded))
+			Expect(res[1].Change).To(Equal(rr.FileChangeDeleted))
+			Expect(res[2].Change).To(Equal(rr.FileChangeModified))
+			Expect(res[3].Change).To(Equal(rr.FileChangeModified))
+			Expect(res[4].Change).To(Equal(rr.FileChangeAdded))
+		})
+
+		It("should return the same commits as git log", func() {
+			res, err := r.GetFileHistory("HEAD", "file.txt", 0)
+			Expect(err).To(BeNil())
+			out := testutil2.ExecGit(path, "--no-pager", "log", "--format=%H", "--", "file.txt")
+			Expect(hashes(res)).To(Equal(strings.Fields(string(out))))
+		})
+
+		It("should return limited commits when limit is > 0", func() {
+			res, err := r.GetFileHistory("master", "file.txt", 2)
+			Expect(err).To(BeNil())
+			Expect(hashes(res)).To(Equal([]string{readded, deleted}))
+		})
+
+		It("should return ErrPathNotFound when no commit changed the path", func() {
+			_, err := r.GetFileHistory("HEAD", "unknown.txt", 0)
+			Expect(err).To(Equal(repo.ErrPathNotFound))
+		})
+
+		It("should return error when reference does not exist", func() {
+			_, err := r.GetFileHistory("unknown", "file.txt", 0)
+			Expect(err).To(Equal(plumbing.ErrReferenceNotFound))
+		})
+	})
 })
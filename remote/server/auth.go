@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/go-git/go-git/v5/config"
 	"github.com/make-os/kit/keystore/types"
@@ -26,6 +27,7 @@ import (
 
 var (
 	ErrPushTokenRequired = fmt.Errorf("push token must be provided")
+	ErrReadAccessDenied  = validation.ErrReadAccessDenied
 	fe                   = errors2.FieldErrorWithIndex
 )
 
@@ -81,6 +83,22 @@ func authenticate(
 	return policy.GetPolicyEnforcer(policy.MakePusherPolicyGroups(txDetails[0].PushKeyID, repoState, namespace)), nil
 }
 
+// ReadAccessCheckerFunc describes a function for checking whether
+// the signer of a push token can read a private repository.
+// txDetail: The transaction details presented by the requester.
+// repo: The target repository state.
+// keepers: The application states keeper
+type ReadAccessCheckerFunc func(
+	txDetail *remotetypes.TxDetail,
+	repo *state.Repository,
+	keepers core.Keepers,
+	checkTxDetail validation.TxDetailChecker) error
+
+// isPrivateRepo checks whether a repository is private
+func isPrivateRepo(repo *state.Repository) bool {
+	return repo.Config != nil && repo.Config.Access.IsPrivate()
+}
+
 // isPullRequest checks whether a request is a pull request
 func isPullRequest(r *http.Request) bool {
 	return r.Method == "GET" || strings.Contains(r.URL.Path, "git-upload-pack")
@@ -90,14 +108,18 @@ func isPullRequest(r *http.Request) bool {
 // The push request token is a base58 encode of the serialized transaction information which
 // contains the fee, account nonce and request signature.
 //
+// Pull requests are not authenticated unless the repository is private, in which
+// case only read access is checked and no transaction details or enforcer is returned.
+//
 // ARGS:
 // - r: The http request
 // - repo: The target repository
 // - namespace: The namespace object. Nil means default namespace.
 func (sv *Server) handleAuth(r *http.Request, repo *state.Repository, namespace *state.Namespace) (txDetails []*remotetypes.TxDetail, polEnforcer policy.EnforcerFunc, err error) {
 
-	// Do not require auth for pull request (yet)
-	if isPullRequest(r) {
+	// Do not require auth for pull request of a public repository
+	isPull := isPullRequest(r)
+	if isPull && !isPrivateRepo(repo) {
 		return nil, nil, nil
 	}
 
@@ -120,6 +142,11 @@ func (sv *Server) handleAuth(r *http.Request, repo *state.Repository, namespace
 		txDetails = append(txDetails, txDetail)
 	}
 
+	// For pull requests, only check whether the requester can read the repository
+	if isPull {
+		return nil, nil, sv.checkReadAccess(txDetails[0], repo, sv.logic, validation.CheckTxDetail)
+	}
+
 	// Perform authentication checks
 	polEnforcer, err = sv.authenticate(txDetails, repo, namespace, sv.logic, validation.CheckTxDetail)
 	if err != nil {
@@ -218,12 +245,12 @@ func makeAndApplyPushTokenToRepoRemote(
 
 	// Get existing push tokens from `tokens` option of the remote as long as reset was
 	// not requested. Ignore bad tokens or matching tokens of the target reference to
-	// avoid creating duplicate tokens.
+	// avoid creating duplicate tokens. Expired tokens are also ignored.
 	var existingTokens = make(map[string]struct{})
 	if !args.ResetTokens {
 		for _, t := range repoCfg.Tokens[remote.Name] {
 			detail, err := pushtoken.Decode(t)
-			if err != nil || detail.Reference == args.TxDetail.Reference {
+			if err != nil || detail.Reference == args.TxDetail.Reference || detail.IsExpired(time.Now()) {
 				continue
 			}
 			existingTokens[t] = struct{}{}
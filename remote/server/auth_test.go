@@ -40,6 +40,7 @@ var _ = Describe("Auth", func() {
 	var repoName, path string
 	var ctrl *gomock.Controller
 	var mockLogic *mocks.MockLogic
+	var mockPushKeyKeeper *mocks.MockPushKeyKeeper
 	var key, key2 *ed25519.Key
 	var svr *Server
 
@@ -59,6 +60,7 @@ var _ = Describe("Auth", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		mocksObjs := testutil.Mocks(ctrl)
 		mockLogic = mocksObjs.Logic
+		mockPushKeyKeeper = mocksObjs.PushKeyKeeper
 
 		mockDHT := mocks.NewMockDHT(ctrl)
 		mockDHT.EXPECT().RegisterChecker(announcer.ObjTypeRepoName, gomock.Any())
@@ -307,6 +309,46 @@ var _ = Describe("Auth", func() {
 			})
 		})
 
+		When("request method is GET and the repository is private", func() {
+			var repoState *state.Repository
+
+			BeforeEach(func() {
+				repoState = state.BareRepository()
+				repoState.Config.Access = &state.RepoAccess{Private: true}
+			})
+
+			It("should return ErrPushTokenRequired when a push token is not provided", func() {
+				req := httptest.NewRequest("GET", "https://127.0.0.1", bytes.NewReader(nil))
+				_, _, err := svr.handleAuth(req, repoState, &state.Namespace{})
+				Expect(err).To(Equal(ErrPushTokenRequired))
+			})
+
+			It("should return error when read access check fails", func() {
+				token := base58.Encode(util.ToBytes(&types.TxDetail{RepoName: "repo1"}))
+				req := httptest.NewRequest("GET", "https://127.0.0.1", bytes.NewReader(nil))
+				req.SetBasicAuth(token, "")
+				svr.checkReadAccess = func(*types.TxDetail, *state.Repository, core.Keepers, validation.TxDetailChecker) error {
+					return ErrReadAccessDenied
+				}
+				_, _, err := svr.handleAuth(req, repoState, &state.Namespace{})
+				Expect(err).To(Equal(ErrReadAccessDenied))
+			})
+
+			It("should return nil transaction details, enforcer and error when read access check passes", func() {
+				token := base58.Encode(util.ToBytes(&types.TxDetail{RepoName: "repo1"}))
+				req := httptest.NewRequest("GET", "https://127.0.0.1", bytes.NewReader(nil))
+				req.SetBasicAuth(token, "")
+				svr.checkReadAccess = func(txDetail *types.TxDetail, _ *state.Repository, _ core.Keepers, _ validation.TxDetailChecker) error {
+					Expect(txDetail.RepoName).To(Equal("repo1"))
+					return nil
+				}
+				txDetails, enforcer, err := svr.handleAuth(req, repoState, &state.Namespace{})
+				Expect(err).To(BeNil())
+				Expect(txDetails).To(BeNil())
+				Expect(enforcer).To(BeNil())
+			})
+		})
+
 		When("a push token is not provided", func() {
 			It("should return error", func() {
 				req := httptest.NewRequest("POST", "https://127.0.0.1", bytes.NewReader(nil))
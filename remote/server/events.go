@@ -28,6 +28,23 @@ func (sv *Server) subscribe() {
 			_ = handleFailedPushTxEvt(sv, evt)
 		}
 	}()
+
+	// On EvtMempoolTxAdded:
+	// Mark the push note of the transaction as endorsed
+	go func() {
+		for evt := range sv.cfg.G().Bus.On(types2.EvtMempoolTxAdded) {
+			_ = handleAddedPushTxEvt(sv, evt)
+		}
+	}()
+
+	// On EvtMempoolTxCommitted:
+	// Mark the push note of the transaction as endorsed and
+	// record the push of the transaction as recently accepted
+	go func() {
+		for evt := range sv.cfg.G().Bus.On(types2.EvtMempoolTxCommitted) {
+			_ = handleCommittedPushTxEvt(sv, evt)
+		}
+	}()
 }
 
 // handleFailedPushTxEvt responds to a failed push transaction
@@ -46,3 +63,41 @@ func handleFailedPushTxEvt(sv *Server, evt emitter.Event) error {
 
 	return nil
 }
+
+// handleAddedPushTxEvt responds to a push transaction added to the mempool
+// by marking its push note as endorsed. The transaction may have been
+// created by another node, in which case the endorsement quorum was not
+// observed locally.
+func handleAddedPushTxEvt(sv *Server, evt emitter.Event) error {
+	_ = util.CheckEvtArgs(evt.Args)
+
+	tx, ok := evt.Args[1].(types.BaseTx)
+	if !ok {
+		return fmt.Errorf("unexpected type (types.BaseTx)")
+	}
+
+	if tx.Is(txns.TxTypePush) {
+		sv.pushPool.MarkEndorsed(tx.(*txns.TxPush).GetNoteID())
+	}
+
+	return nil
+}
+
+// handleCommittedPushTxEvt responds to a committed push transaction
+// event by marking its push note as endorsed and recording the push
+// as recently accepted
+func handleCommittedPushTxEvt(sv *Server, evt emitter.Event) error {
+	_ = util.CheckEvtArgs(evt.Args)
+
+	tx, ok := evt.Args[1].(types.BaseTx)
+	if !ok {
+		return fmt.Errorf("unexpected type (types.BaseTx)")
+	}
+
+	if tx.Is(txns.TxTypePush) {
+		sv.pushPool.MarkEndorsed(tx.(*txns.TxPush).GetNoteID())
+		sv.onPushTxCommitted(tx.GetHash().String())
+	}
+
+	return nil
+}
@@ -23,6 +23,7 @@ import (
 	remotetypes "github.com/make-os/kit/remote/types"
 	"github.com/make-os/kit/remote/validation"
 	"github.com/make-os/kit/types"
+	"github.com/make-os/kit/types/core"
 	"github.com/make-os/kit/types/state"
 	"github.com/make-os/kit/types/txns"
 	"github.com/make-os/kit/util"
@@ -164,6 +165,9 @@ func (sv *Server) onPushNoteReceived(peer p2p.Peer, msgBytes []byte) error {
 		return errors.Wrap(err, "authorization failed")
 	}
 
+	// Track the repository if it matches an auto-track pattern
+	sv.autoTrackRepo(namespace, note.Namespace, note.RepoName)
+
 	// If the node is in validator mode or the target repository cannot
 	// be synced, we can only validate and broadcast the node.
 	if err := sv.refSyncer.CanSync(note.Namespace, note.RepoName); err != nil || sv.cfg.IsValidatorNode() {
@@ -203,6 +207,13 @@ func (sv *Server) onPushNoteReceived(peer p2p.Peer, msgBytes []byte) error {
 		return errors.Wrap(err, "failed push note validation")
 	}
 
+	// Ensure there is enough disk space to store the note's objects so
+	// that the fetch does not fail after objects were partially written.
+	if err := sv.checkDiskSpace(repoPath, note.GetSize()); err != nil {
+		sv.log.Error("Rejected push note", "ID", noteID, "Err", err.Error())
+		return err
+	}
+
 	// Register a cache entry that indicates the sender of the push note
 	sv.registerNoteSender(string(peerID), noteID)
 
@@ -221,12 +232,38 @@ func (sv *Server) onPushNoteReceived(peer p2p.Peer, msgBytes []byte) error {
 	// FetchAsync the objects for each reference in the push note.
 	// The callback is called when all objects have been fetched successfully.
 	sv.objFetcher.FetchAsync(&note, func(err error) {
+		// Let listeners know objects may have been added to the repository
+		sv.cfg.G().Bus.Emit(core.EvtRepoUpdated, repoName, repoPath)
 		_ = sv.onObjectsFetched(err, &note, txDetails, polEnforcer)
 	})
 
 	return nil
 }
 
+// ErrCodeDiskFull is the error code of a push note rejected due to insufficient disk space
+const ErrCodeDiskFull = "disk_full"
+
+// DiskSpaceGetterFunc describes a function for getting the
+// free disk space (in bytes) of the file system containing path
+type DiskSpaceGetterFunc func(path string) (uint64, error)
+
+// checkDiskSpace checks that the file system of a repository can store
+// objects of the given size while keeping the configured margin free.
+func (sv *Server) checkDiskSpace(repoPath string, size uint64) error {
+	free, err := sv.getFreeDiskSpace(repoPath)
+	if err != nil {
+		return errors.Wrap(err, "failed to get free disk space")
+	}
+
+	if margin := sv.cfg.Repo.PushDiskMargin; free < size || free-size < margin {
+		msg := fmt.Sprintf("not enough disk space for pushed objects (size: %d bytes, margin: %d bytes, available: %d bytes)",
+			size, margin, free)
+		return errors2.ReqErr(507, ErrCodeDiskFull, "size", msg)
+	}
+
+	return nil
+}
+
 // onObjectsFetched is called after all objects of the push note have been
 // completely fetched or an error occurred while fetching.
 func (sv *Server) onObjectsFetched(
@@ -459,11 +496,30 @@ func (sv *Server) createPushTx(noteID string) error {
 		return errors.Wrap(err, "failed to add push tx to mempool")
 	}
 
+	// Mark the note as endorsed so it is not dropped for lack of endorsements
+	sv.GetPushPool().MarkEndorsed(noteID)
+
 	pushTx.Note.SetTargetRepo(nil)
 
 	return nil
 }
 
+// dropUnendorsedNotes removes push notes that did not receive a quorum of
+// endorsements within the configured timeout and notifies their pushers.
+func (sv *Server) dropUnendorsedNotes() {
+	timeout := sv.cfg.Repo.EndorsementTimeout
+	if timeout <= 0 {
+		return
+	}
+
+	for _, note := range sv.GetPushPool().RemoveUnendorsed(timeout) {
+		noteID := note.ID().String()
+		sv.endorsements.Remove(noteID)
+		sv.log.Debug("Dropped push note that did not receive enough endorsements in time", "ID", noteID)
+		sv.cfg.G().Bus.Emit(pushtypes.EvtPushNoteDropped, pushtypes.ReasonEndorsementTimeout, noteID)
+	}
+}
+
 // CreateEndorsementFunc describes a function for creating an endorsement for the given push note
 type CreateEndorsementFunc func(validatorKey *ed25519.Key, note pushtypes.PushNote) (*pushtypes.PushEndorsement, error)
 
@@ -475,12 +531,17 @@ func createEndorsement(validatorKey *ed25519.Key, note pushtypes.PushNote) (*pus
 	e := &pushtypes.PushEndorsement{
 		NoteID:         note.ID().Bytes(),
 		EndorserPubKey: validatorKey.PubKey().MustBytes32(),
+		SigScheme:      pushtypes.SigSchemeBLS,
+		Timestamp:      note.GetTimestamp(),
 	}
 
 	// Set the hash of the endorsement equal the local hash of the reference
 	for _, ref := range note.GetPushedReferences() {
 		end := &pushtypes.EndorsedReference{}
 		end.Hash = util.MustFromHex(ref.OldHash)
+		if params.PushEndorseAllowRefSubset {
+			end.Name = ref.Name
+		}
 		e.References = append(e.References, end)
 	}
 
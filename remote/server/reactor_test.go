@@ -3,8 +3,10 @@ package server
 import (
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/golang/mock/gomock"
@@ -30,6 +32,7 @@ import (
 	"github.com/make-os/kit/util"
 	crypto2 "github.com/make-os/kit/util/crypto"
 	"github.com/make-os/kit/util/errors"
+	"github.com/olebedev/emitter"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/tendermint/tendermint/p2p"
@@ -224,6 +227,34 @@ var _ = Describe("Reactor", func() {
 			})
 		})
 
+		When("target repository matches an auto-track pattern", func() {
+			BeforeEach(func() {
+				cfg.Repo.AutoTrack = []string{"repo*"}
+				pn := &types.Note{RepoName: "repo1"}
+				mockService.EXPECT().GetTx(gomock.Any(), pn.ID().Bytes(), cfg.IsLightNode()).
+					Return(nil, nil, types2.ErrTxNotFound)
+				mockPeer.EXPECT().ID().Return(p2p.ID("peer-id"))
+				repoState := state.BareRepository()
+				repoState.Balance = "100"
+				mockRepoKeeper.EXPECT().Get("repo1").Return(repoState)
+				mockRepoSyncInfoKeeper.EXPECT().GetTracked("repo1").Return(nil)
+				mockRepoSyncInfoKeeper.EXPECT().Track("repo1").Return(nil)
+				mockRefSyncer := mocks.NewMockRefSync(ctrl)
+				mockRefSyncer.EXPECT().CanSync(pn.Namespace, pn.RepoName).Return(refsync.ErrUntracked)
+				svr.refSyncer = mockRefSyncer
+				svr.authenticate = func(txDetails []*remotetypes.TxDetail, repo *state.Repository, namespace *state.Namespace, keepers core.Keepers, checkTxDetail validation.TxDetailChecker) (enforcer policy.EnforcerFunc, err error) {
+					return nil, nil
+				}
+				svr.noteBroadcaster = func(pushNote types.PushNote) {}
+				svr.checkPushNote = func(tx types.PushNote, logic core.Logic) error { return nil }
+				err = svr.onPushNoteReceived(mockPeer, pn.Bytes())
+			})
+
+			It("should track the repository and return no error", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+
 		When("target repository can be synced but the node is in validator mode", func() {
 			var broadcastNote, validated bool
 			BeforeEach(func() {
@@ -408,6 +439,7 @@ var _ = Describe("Reactor", func() {
 				svr.checkPushNote = func(tx types.PushNote, logic core.Logic) error {
 					return nil
 				}
+				svr.getFreeDiskSpace = func(string) (uint64, error) { return math.MaxUint64, nil }
 				err = svr.onPushNoteReceived(mockPeer, pn.Bytes())
 			})
 
@@ -430,6 +462,93 @@ var _ = Describe("Reactor", func() {
 		})
 	})
 
+	Describe(".onPushNoteReceived (disk space guard)", func() {
+		var pn *types.Note
+
+		BeforeEach(func() {
+			pn = &types.Note{RepoName: repoName, Size: 1000}
+			mockService.EXPECT().GetTx(gomock.Any(), pn.ID().Bytes(), cfg.IsLightNode()).
+				Return(nil, nil, types2.ErrTxNotFound)
+			mockPeer.EXPECT().ID().Return(p2p.ID("peer-id"))
+			repoState := state.BareRepository()
+			repoState.Balance = "100"
+			mockRepoKeeper.EXPECT().Get(repoName).Return(repoState)
+			mockRefSyncer := mocks.NewMockRefSync(ctrl)
+			mockRefSyncer.EXPECT().CanSync(pn.Namespace, pn.RepoName).Return(nil)
+			svr.refSyncer = mockRefSyncer
+			svr.authenticate = func(txDetails []*remotetypes.TxDetail, repo *state.Repository, namespace *state.Namespace, keepers core.Keepers, checkTxDetail validation.TxDetailChecker) (policy.EnforcerFunc, error) {
+				return nil, nil
+			}
+			svr.checkPushNote = func(tx types.PushNote, logic core.Logic) error {
+				return nil
+			}
+			cfg.Repo.PushDiskMargin = 100
+		})
+
+		When("there is not enough disk space for the note's objects and the margin", func() {
+			BeforeEach(func() {
+				svr.getFreeDiskSpace = func(string) (uint64, error) { return 1050, nil }
+				err = svr.onPushNoteReceived(mockPeer, pn.Bytes())
+			})
+
+			It("should return disk_full error", func() {
+				Expect(err).ToNot(BeNil())
+				reqErr, ok := err.(*errors.ReqError)
+				Expect(ok).To(BeTrue())
+				Expect(reqErr.Code).To(Equal(ErrCodeDiskFull))
+				Expect(reqErr.HttpCode).To(Equal(507))
+			})
+
+			It("should not fetch the note's objects", func() {
+				Expect(svr.objFetcher.QueueSize()).To(Equal(0))
+				Expect(svr.isNoteSender("peer-id", pn.ID().String())).To(BeFalse())
+			})
+		})
+
+		When("there is enough disk space for the note's objects and the margin", func() {
+			BeforeEach(func() {
+				svr.getFreeDiskSpace = func(string) (uint64, error) { return 1100, nil }
+				err = svr.onPushNoteReceived(mockPeer, pn.Bytes())
+			})
+
+			It("should fetch the note's objects", func() {
+				Expect(err).To(BeNil())
+				Expect(svr.objFetcher.QueueSize()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe(".checkDiskSpace", func() {
+		BeforeEach(func() {
+			cfg.Repo.PushDiskMargin = 100
+		})
+
+		It("should return error when unable to get free disk space", func() {
+			svr.getFreeDiskSpace = func(string) (uint64, error) { return 0, fmt.Errorf("error") }
+			err := svr.checkDiskSpace(path, 1000)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("failed to get free disk space: error"))
+		})
+
+		It("should return disk_full error when objects size exceeds free disk space", func() {
+			svr.getFreeDiskSpace = func(string) (uint64, error) { return 500, nil }
+			err := svr.checkDiskSpace(path, 1000)
+			Expect(err).To(Equal(errors.ReqErr(507, ErrCodeDiskFull, "size",
+				"not enough disk space for pushed objects (size: 1000 bytes, margin: 100 bytes, available: 500 bytes)")))
+		})
+
+		It("should return disk_full error when size is so large that adding the margin overflows", func() {
+			svr.getFreeDiskSpace = func(string) (uint64, error) { return 500, nil }
+			err := svr.checkDiskSpace(path, math.MaxUint64)
+			Expect(err).ToNot(BeNil())
+		})
+
+		It("should return nil when free disk space can hold the objects and the margin", func() {
+			svr.getFreeDiskSpace = func(string) (uint64, error) { return 1100, nil }
+			Expect(svr.checkDiskSpace(path, 1000)).To(BeNil())
+		})
+	})
+
 	Describe(".onObjectsFetched", func() {
 		It("should return error when err is passed", func() {
 			polEnforcer := func(subject, object, action string) (bool, int) { return false, 0 }
@@ -514,7 +633,7 @@ var _ = Describe("Reactor", func() {
 			var refHash = "8d998c7de21bbe561f7992bb983cef4b1554993b"
 
 			BeforeEach(func() {
-				note := &types.Note{References: []*types.PushedReference{{Name: refname, OldHash: refHash}}}
+				note := &types.Note{References: []*types.PushedReference{{Name: refname, OldHash: refHash}}, Timestamp: 1600000000}
 				mockRepo := mocks.NewMockLocalRepo(ctrl)
 				note.SetTargetRepo(mockRepo)
 				end, err = createEndorsement(svr.validatorKey, note)
@@ -531,6 +650,36 @@ var _ = Describe("Reactor", func() {
 			Specify("that the reference hash is set", func() {
 				Expect(end.References[0].Hash).To(Equal(util.MustFromHex(refHash)))
 			})
+
+			Specify("that the signature scheme is BLS", func() {
+				Expect(end.SigScheme).To(Equal(types.SigSchemeBLS))
+			})
+
+			Specify("that the reference name is not set", func() {
+				Expect(end.References[0].Name).To(BeEmpty())
+			})
+
+			Specify("that the timestamp is the push note timestamp", func() {
+				Expect(end.Timestamp).To(Equal(int64(1600000000)))
+			})
+		})
+
+		When("endorsements are allowed to cover a subset of the pushed references", func() {
+			BeforeEach(func() {
+				params.PushEndorseAllowRefSubset = true
+			})
+
+			AfterEach(func() {
+				params.PushEndorseAllowRefSubset = false
+			})
+
+			It("should set the name of each endorsed reference", func() {
+				note := &types.Note{References: []*types.PushedReference{{Name: refname, OldHash: "8d998c7de21bbe561f7992bb983cef4b1554993b"}}}
+				end, err := createEndorsement(svr.validatorKey, note)
+				Expect(err).To(BeNil())
+				Expect(end.References).To(HaveLen(1))
+				Expect(end.References[0].Name).To(Equal(refname))
+			})
 		})
 	})
 
@@ -887,11 +1036,38 @@ var _ = Describe("Reactor", func() {
 	})
 
 	Describe(".markNoteAsSeen & .isNoteSeen", func() {
+		var now time.Time
+
+		BeforeEach(func() {
+			now = time.Now()
+			svr.now = func() time.Time { return now }
+			cfg.Repo.NoteDedupTTL = time.Minute
+		})
+
 		It("should return false if note was not marked as seen", func() {
 			svr.markNoteAsSeen("note1")
 			Expect(svr.isNoteSeen("note2")).To(BeFalse())
 			Expect(svr.isNoteSeen("note1")).To(BeTrue())
 		})
+
+		It("should return true if note is seen again within the dedup TTL", func() {
+			svr.markNoteAsSeen("note1")
+			now = now.Add(59 * time.Second)
+			Expect(svr.isNoteSeen("note1")).To(BeTrue())
+		})
+
+		It("should return false if note is seen again after the dedup TTL", func() {
+			svr.markNoteAsSeen("note1")
+			now = now.Add(61 * time.Second)
+			Expect(svr.isNoteSeen("note1")).To(BeFalse())
+		})
+
+		It("should not expire seen notes when dedup TTL is zero", func() {
+			cfg.Repo.NoteDedupTTL = 0
+			svr.markNoteAsSeen("note1")
+			now = now.Add(24 * time.Hour)
+			Expect(svr.isNoteSeen("note1")).To(BeTrue())
+		})
 	})
 
 	Describe(".createPushTx", func() {
@@ -1063,7 +1239,74 @@ var _ = Describe("Reactor", func() {
 				err = svr.createPushTx(pushNote.ID().String())
 
 				Expect(err).To(BeNil())
+
+				By("marking the note as endorsed")
+				Expect(svr.pushPool.RemoveUnendorsed(0)).To(BeEmpty())
 			})
 		})
 	})
+
+	Describe(".dropUnendorsedNotes", func() {
+		var mockPushPool *mocks.MockPushPool
+
+		BeforeEach(func() {
+			mockPushPool = mocks.NewMockPushPool(ctrl)
+			svr.pushPool = mockPushPool
+		})
+
+		It("should do nothing when endorsement timeout is disabled", func() {
+			cfg.Repo.EndorsementTimeout = 0
+			svr.dropUnendorsedNotes()
+		})
+
+		It("should drop timed out notes and emit EvtPushNoteDropped", func() {
+			cfg.Repo.EndorsementTimeout = 10 * time.Second
+			pushNote := &types.Note{RepoName: repoName}
+			noteID := pushNote.ID().String()
+			svr.registerNoteEndorsement(noteID, &types.PushEndorsement{SigBLS: util.RandBytes(5)})
+			mockPushPool.EXPECT().RemoveUnendorsed(10 * time.Second).Return([]types.PushNote{pushNote})
+
+			evtCh := cfg.G().Bus.Once(types.EvtPushNoteDropped)
+			svr.dropUnendorsedNotes()
+
+			evt := <-evtCh
+			Expect(evt.Args[0]).To(Equal(types.ReasonEndorsementTimeout))
+			Expect(evt.Args[1]).To(Equal(noteID))
+			Expect(svr.endorsements.Has(noteID)).To(BeFalse())
+		})
+	})
+
+	Describe(".handleAddedPushTxEvt", func() {
+		var mockPushPool *mocks.MockPushPool
+
+		BeforeEach(func() {
+			mockPushPool = mocks.NewMockPushPool(ctrl)
+			svr.pushPool = mockPushPool
+		})
+
+		It("should mark the push note of a push transaction as endorsed", func() {
+			tx := txns.NewBareTxPush()
+			tx.Note = &types.Note{RepoName: repoName}
+			mockPushPool.EXPECT().MarkEndorsed(tx.GetNoteID())
+			err := handleAddedPushTxEvt(svr, emitter.Event{Args: []interface{}{nil, tx}})
+			Expect(err).To(BeNil())
+		})
+
+		It("should ignore non-push transactions", func() {
+			err := handleAddedPushTxEvt(svr, emitter.Event{Args: []interface{}{nil, txns.NewBareTxCoinTransfer()}})
+			Expect(err).To(BeNil())
+		})
+	})
+
+	Describe(".handleCommittedPushTxEvt", func() {
+		It("should mark the push note of a push transaction as endorsed", func() {
+			mockPushPool := mocks.NewMockPushPool(ctrl)
+			svr.pushPool = mockPushPool
+			tx := txns.NewBareTxPush()
+			tx.Note = &types.Note{RepoName: repoName}
+			mockPushPool.EXPECT().MarkEndorsed(tx.GetNoteID())
+			err := handleCommittedPushTxEvt(svr, emitter.Event{Args: []interface{}{nil, tx}})
+			Expect(err).To(BeNil())
+		})
+	})
 })
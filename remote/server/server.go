@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -35,7 +36,9 @@ import (
 	"github.com/make-os/kit/rpc"
 	"github.com/make-os/kit/types/core"
 	"github.com/make-os/kit/types/state"
+	"github.com/make-os/kit/util"
 	crypto2 "github.com/make-os/kit/util/crypto"
+	"github.com/make-os/kit/util/identifier"
 	"github.com/pkg/errors"
 	"github.com/tendermint/tendermint/p2p"
 )
@@ -59,6 +62,11 @@ var services = [][]interface{}{
 	{"(.*?)/objects/[0-9a-f]{2}/[0-9a-f]{38}$", service{method: "GET", handle: getInfoPacks}},
 	{"(.*?)/objects/pack/pack-[0-9a-f]{40}\\.pack$", service{method: "GET", handle: getPackFile}},
 	{"(.*?)/objects/pack/pack-[0-9a-f]{40}\\.idx$", service{method: "GET", handle: getIdxFile}},
+	{"(.*?)/archive$", service{method: "GET", handle: serveArchive}},
+	{"(.*?)/uploads$", service{method: "POST", handle: createUpload}},
+	{"(.*?)/uploads/[0-9a-f]{32}$", service{method: "GET", handle: getUploadStatus}},
+	{"(.*?)/uploads/[0-9a-f]{32}$", service{method: "PUT", handle: appendUpload}},
+	{"(.*?)/uploads/[0-9a-f]{32}/finalize$", service{method: "POST", handle: finalizeUpload}},
 }
 
 // Server implements types.Server. It provides a system for managing
@@ -85,15 +93,21 @@ type Server struct {
 	blockGetter   core.BlockGetter            // Provides access to blocks
 	refSyncer     rstypes.RefSync             // Responsible for syncing pushed references in a push transaction
 	tmpRepoMgr    temprepomgr.TempRepoManager // The temporary repo manager
+	uploads       *UploadSessions             // Stores resumable push upload sessions
+	stop          chan struct{}               // Closed when the server is stopped
+	stopOnce      sync.Once                   // Ensures stop is closed once
 
 	// Indexes
 	noteSenders        *cache.Cache // Store senders of push notes
 	endorsementSenders *cache.Cache // Stores senders of Endorsement messages
 	endorsements       *cache.Cache // Stores push endorsements
 	notesReceived      *cache.Cache // Stores ID of push notes recently received
+	recentPushes       *cache.Cache // Stores push transaction hashes of recently accepted pushes
+	pendingPushes      *cache.Cache // Stores content keys of pushes whose transaction is yet to be included in a block
 
 	// Composable functions members
 	authenticate               AuthenticatorFunc                       // Function for performing authentication
+	checkReadAccess            ReadAccessCheckerFunc                   // Function for checking read access to a private repository
 	checkPushNote              validation.CheckPushNoteFunc            // Function for performing PushNote validation
 	makeReferenceUpdatePack    push.MakeReferenceUpdateRequestPackFunc // Function for creating a reference update pack for updating a repository
 	makePushHandler            PushHandlerFunc                         // Function for creating a push handler
@@ -105,6 +119,8 @@ type Server struct {
 	noteBroadcaster            BroadcastPushNoteFunc                   // Function for broadcasting a push note
 	endorsementCreator         CreateEndorsementFunc                   // Function for creating an endorsement for a given push note
 	tryScheduleReSync          ScheduleReSyncFunc                      // Function for scheduling a resync of a repository
+	getFreeDiskSpace           DiskSpaceGetterFunc                     // Function for getting the free disk space of a path
+	now                        func() time.Time                        // Returns the current time
 }
 
 // New creates an instance of Server
@@ -150,14 +166,21 @@ func New(
 		blockGetter:             blockGetter,
 		refSyncer:               refsync.New(cfg, pushPool, mFetcher, dht, appLogic),
 		tmpRepoMgr:              temprepomgr.New(),
+		uploads:                 NewUploadSessions(cfg.GetUploadsDir(), params.UploadSessionTTL),
+		stop:                    make(chan struct{}),
 		authenticate:            authenticate,
+		checkReadAccess:         validation.CheckReadAccess,
 		checkPushNote:           validation.CheckPushNote,
 		makeReferenceUpdatePack: push.MakeReferenceUpdateRequestPack,
 		noteSenders:             cache.NewCacheWithExpiringEntry(params.PushNotesEndorsementsCacheSize),
 		endorsementSenders:      cache.NewCacheWithExpiringEntry(params.PushObjectsSendersCacheSize),
 		endorsements:            cache.NewCacheWithExpiringEntry(params.RecentlySeenPacksCacheSize),
 		notesReceived:           cache.NewCacheWithExpiringEntry(params.NotesReceivedCacheSize),
+		recentPushes:            cache.NewCacheWithExpiringEntry(params.RecentPushesCacheSize),
+		pendingPushes:           cache.NewCacheWithExpiringEntry(params.RecentPushesCacheSize),
 		checkEndorsement:        validation.CheckEndorsement,
+		getFreeDiskSpace:        util.GetFreeDiskSpace,
+		now:                     time.Now,
 	}
 
 	// Instantiate RPC handler
@@ -215,6 +238,50 @@ func (sv *Server) applyRepoTrackingConfig() {
 	}
 }
 
+// autoTrackRepo tracks the repository targeted by a gossiped push note if it
+// matches one of the auto-track patterns in Config.Repo.AutoTrack.
+// It returns true if the repository was newly tracked.
+func (sv *Server) autoTrackRepo(namespace *state.Namespace, nsName, repoName string) bool {
+	if len(sv.cfg.Repo.AutoTrack) == 0 {
+		return false
+	}
+
+	// If the push targets a namespace, resolve the domain to the actual repository
+	candidates := []string{repoName}
+	if nsName != "" {
+		if namespace == nil || namespace.IsNil() {
+			return false
+		}
+		target, ok := namespace.Domains[repoName]
+		if !ok {
+			return false
+		}
+		candidates = []string{nsName + "/" + repoName}
+		repoName = identifier.GetDomain(target)
+		candidates = append(candidates, repoName)
+	}
+
+	if sv.logic.RepoSyncInfoKeeper().GetTracked(repoName) != nil {
+		return false
+	}
+
+	for _, pattern := range sv.cfg.Repo.AutoTrack {
+		for _, candidate := range candidates {
+			if ok, _ := path.Match(pattern, candidate); !ok {
+				continue
+			}
+			if err := sv.logic.RepoSyncInfoKeeper().Track(repoName); err != nil {
+				sv.log.Error("Failed to auto-track repository", "Repo", repoName, "Err", err.Error())
+				return false
+			}
+			sv.log.Info("Auto-tracked repository", "Repo", repoName, "Pattern", pattern)
+			return true
+		}
+	}
+
+	return false
+}
+
 // SetRootDir sets the directory where repositories are stored
 func (sv *Server) SetRootDir(dir string) {
 	sv.rootDir = dir
@@ -301,16 +368,81 @@ func (sv *Server) registerNoteEndorsement(noteID string, endorsement *pushtypes.
 	sv.endorsements.Add(noteID, entries)
 }
 
-// markNoteAsSeen marks a note as seen
+// GetNoteEndorsements returns the endorsements received for a push note
+func (sv *Server) GetNoteEndorsements(noteID string) (endorsements []*pushtypes.PushEndorsement) {
+	entries := sv.endorsements.Get(noteID)
+	if entries == nil {
+		return
+	}
+	for _, end := range entries.(map[string]*pushtypes.PushEndorsement) {
+		endorsements = append(endorsements, end)
+	}
+	return
+}
+
+// recentPush describes a recently accepted push
+type recentPush struct {
+	txHash string
+	expAt  time.Time
+}
+
+// RegisterRecentPush records the push transaction hash of a push with the given
+// content key. The push is not considered recently accepted until its transaction
+// is included in a block (see onPushTxCommitted), so that a push whose transaction
+// is evicted from the mempool can be retried.
+func (sv *Server) RegisterRecentPush(key, txHash string) {
+	if sv.cfg.Repo.DuplicatePushWindow <= 0 {
+		return
+	}
+	sv.pendingPushes.Add(txHash, key, time.Now().Add(params.PendingRecentPushTTL))
+}
+
+// onPushTxCommitted records the push of a push transaction included in a block
+// as recently accepted for the duration of the duplicate push window.
+func (sv *Server) onPushTxCommitted(txHash string) {
+	key := sv.pendingPushes.Get(txHash)
+	if key == nil {
+		return
+	}
+	sv.pendingPushes.Remove(txHash)
+
+	window := sv.cfg.Repo.DuplicatePushWindow
+	if window <= 0 {
+		return
+	}
+	expAt := time.Now().Add(window)
+	sv.recentPushes.Add(key.(string), &recentPush{txHash: txHash, expAt: expAt}, expAt)
+}
+
+// GetRecentPushTx returns the hash of the push transaction of a recently
+// accepted push with the given content key or empty string if not found.
+func (sv *Server) GetRecentPushTx(key string) string {
+	val := sv.recentPushes.Get(key)
+	if val == nil || time.Now().After(val.(*recentPush).expAt) {
+		return ""
+	}
+	return val.(*recentPush).txHash
+}
+
+// markNoteAsSeen marks a note as seen for the configured dedup TTL
 func (sv *Server) markNoteAsSeen(noteID string) {
 	key := crypto2.Hash20Hex([]byte(noteID))
-	sv.notesReceived.Add(key, struct{}{}, time.Now().Add(5*time.Minute))
+	var expAt time.Time
+	if ttl := sv.cfg.Repo.NoteDedupTTL; ttl > 0 {
+		expAt = sv.now().Add(ttl)
+	}
+	sv.notesReceived.Add(key, expAt, expAt)
 }
 
-// isNoteSeen checks if a note has been seen
+// isNoteSeen checks if a note has been seen within the dedup TTL
 func (sv *Server) isNoteSeen(noteID string) bool {
 	key := crypto2.Hash20Hex([]byte(noteID))
-	return sv.notesReceived.Get(key) == struct{}{}
+	val := sv.notesReceived.Get(key)
+	if val == nil {
+		return false
+	}
+	expAt := val.(time.Time)
+	return expAt.IsZero() || !sv.now().After(expAt)
 }
 
 // GetRPCHandler returns the RPC handler
@@ -339,9 +471,32 @@ func (sv *Server) Start() error {
 
 	go sv.subscribe()
 
+	// Periodically drop push notes that failed to receive enough endorsements in time
+	go sv.runPeriodically(params.PushNoteEndorsementCheckInt, sv.dropUnendorsedNotes)
+
+	// Periodically remove upload sessions that have expired
+	go sv.runPeriodically(params.UploadSessionCleanUpInt, sv.uploads.RemoveExpired)
+
 	return nil
 }
 
+// runPeriodically calls f at every interval until
+// the server is stopped or the program is interrupted.
+func (sv *Server) runPeriodically(interval time.Duration, f func()) {
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-tick.C:
+			f()
+		case <-sv.stop:
+			return
+		case <-*config.GetInterrupt():
+			return
+		}
+	}
+}
+
 // GetLogic returns the application logic provider
 func (sv *Server) GetLogic() core.Logic {
 	return sv.logic
@@ -403,6 +558,22 @@ func (sv *Server) gitRequestsHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 
+	// Reject request bodies larger than the allowed size. The size of a
+	// chunked request body is not known until it has been read, so such
+	// bodies are limited as they are read.
+	if maxSize := sv.cfg.Repo.MaxRequestBodySize; maxSize > 0 {
+		if r.ContentLength > maxSize {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			sv.log.Debug("Request body too large", "Size", r.ContentLength, "Max", maxSize)
+			return
+		}
+		if r.ContentLength < 0 {
+			r.Body = newMaxBodyReader(w, r.Body, maxSize)
+		} else {
+			r.Body = http.MaxBytesReader(w, r.Body, maxSize)
+		}
+	}
+
 	// De-construct the URL to get the repo name and operation
 	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
 	namespaceName := pathParts[0]
@@ -453,6 +624,11 @@ func (sv *Server) gitRequestsHandler(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusUnauthorized)
 			return
 		}
+		if isPullRequest(r) {
+			w.WriteHeader(http.StatusForbidden)
+			sv.log.Debug("Read access denied", "Name", repoName, "Err", err.Error())
+			return
+		}
 		_ = pktEnc.Encode(plumbing.SidebandInfoln("authentication has failed"))
 		_ = pktEnc.Encode(plumbing.SidebandErr(err.Error()))
 		_ = pktEnc.Flush()
@@ -480,15 +656,19 @@ func (sv *Server) gitRequestsHandler(w http.ResponseWriter, r *http.Request) {
 		Repo: &repo.Repo{
 			Repository:     targetRepo.(*repo.Repo).Repository,
 			BasicGitModule: targetRepo.(*repo.Repo).BasicGitModule,
+			ObjectStore:    targetRepo.GetObjectStore(),
 			Path:           targetRepo.GetPath(),
 			State:          repoState,
 			NamespaceName:  namespaceName,
 			Namespace:      namespace,
 		},
-		RepoDir:     targetRepo.GetPath(),
-		ServiceName: getService(r),
-		GitBinPath:  sv.gitBinPath,
-		pktEnc:      pktEnc,
+		RepoDir:        targetRepo.GetPath(),
+		ServiceName:    getService(r),
+		GitBinPath:     sv.gitBinPath,
+		Uploads:        sv.uploads,
+		MaxCloneDepth:  sv.cfg.Repo.MaxCloneDepth,
+		MaxArchiveSize: sv.cfg.Repo.MaxArchiveSize,
+		pktEnc:         pktEnc,
 	}
 
 	req.PushHandler = sv.makePushHandler(req.Repo, txDetails, polEnforcer)
@@ -503,8 +683,7 @@ func (sv *Server) gitRequestsHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		if srv.method != r.Method {
-			writeMethodNotAllowed(w, r)
-			break
+			continue
 		}
 
 		err := srv.handle(req)
@@ -562,6 +741,7 @@ func (sv *Server) Shutdown(ctx context.Context) {
 // Stop implements Reactor
 func (sv *Server) Stop() error {
 	sv.log.Info("Gracefully shutting down server")
+	sv.stopOnce.Do(func() { close(sv.stop) })
 	sv.BaseReactor.Stop()
 	sv.objFetcher.Stop()
 	ctx, cc := context.WithTimeout(context.Background(), 15*time.Second)
@@ -1,22 +1,33 @@
 package server
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/make-os/kit/config"
 	"github.com/make-os/kit/mocks"
 	"github.com/make-os/kit/net/dht/announcer"
 	"github.com/make-os/kit/remote/plumbing"
+	"github.com/make-os/kit/remote/policy"
 	"github.com/make-os/kit/remote/push/types"
 	"github.com/make-os/kit/remote/repo"
 	testutil2 "github.com/make-os/kit/remote/testutil"
+	remotetypes "github.com/make-os/kit/remote/types"
+	"github.com/make-os/kit/remote/validation"
 	"github.com/make-os/kit/testutil"
 	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/state"
 	"github.com/make-os/kit/util"
+	"github.com/mr-tron/base58"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/phayes/freeport"
@@ -234,6 +245,285 @@ var _ = Describe("Server", func() {
 		})
 	})
 
+	Describe(".GetNoteEndorsements", func() {
+		It("should return nil if note has no endorsement", func() {
+			Expect(svr.GetNoteEndorsements("abc")).To(BeNil())
+		})
+
+		It("should return the endorsements of the note", func() {
+			pushEnd := &types.PushEndorsement{SigBLS: util.RandBytes(5)}
+			svr.registerNoteEndorsement("abc", pushEnd)
+			Expect(svr.GetNoteEndorsements("abc")).To(Equal([]*types.PushEndorsement{pushEnd}))
+		})
+	})
+
+	Describe(".RegisterRecentPush", func() {
+		It("should not return the tx hash before the push transaction is included in a block", func() {
+			cfg.Repo.DuplicatePushWindow = time.Minute
+			svr.RegisterRecentPush("key1", "0x1234")
+			Expect(svr.GetRecentPushTx("key1")).To(BeEmpty())
+		})
+
+		It("should return the tx hash of a duplicate push within the window", func() {
+			cfg.Repo.DuplicatePushWindow = time.Minute
+			svr.RegisterRecentPush("key1", "0x1234")
+			svr.onPushTxCommitted("0x1234")
+			Expect(svr.GetRecentPushTx("key1")).To(Equal("0x1234"))
+			Expect(svr.GetRecentPushTx("key2")).To(BeEmpty())
+		})
+
+		It("should not return the tx hash of a duplicate push outside the window", func() {
+			cfg.Repo.DuplicatePushWindow = 10 * time.Millisecond
+			svr.RegisterRecentPush("key1", "0x1234")
+			svr.onPushTxCommitted("0x1234")
+			time.Sleep(20 * time.Millisecond)
+			Expect(svr.GetRecentPushTx("key1")).To(BeEmpty())
+		})
+
+		It("should not record the push if the window is disabled", func() {
+			cfg.Repo.DuplicatePushWindow = 0
+			svr.RegisterRecentPush("key1", "0x1234")
+			svr.onPushTxCommitted("0x1234")
+			Expect(svr.GetRecentPushTx("key1")).To(BeEmpty())
+		})
+
+		It("should not record the push when a different transaction is committed", func() {
+			cfg.Repo.DuplicatePushWindow = time.Minute
+			svr.RegisterRecentPush("key1", "0x1234")
+			svr.onPushTxCommitted("0x5678")
+			Expect(svr.GetRecentPushTx("key1")).To(BeEmpty())
+		})
+	})
+
+	Describe(".gitRequestsHandler", func() {
+		BeforeEach(func() {
+			cfg.Repo.MaxRequestBodySize = 10
+		})
+
+		It("should return 413 when request body is larger than the max request body size", func() {
+			req := httptest.NewRequest("POST", "/r/"+repoName+"/git-receive-pack", strings.NewReader(strings.Repeat("a", 11)))
+			rr := httptest.NewRecorder()
+			svr.gitRequestsHandler(rr, req)
+			Expect(rr.Code).To(Equal(http.StatusRequestEntityTooLarge))
+		})
+
+		It("should not return 413 when request body is not larger than the max request body size", func() {
+			mockObjects.RepoKeeper.EXPECT().Get(repoName).Return(state.BareRepository())
+			req := httptest.NewRequest("POST", "/r/"+repoName+"/git-receive-pack", strings.NewReader(strings.Repeat("a", 10)))
+			rr := httptest.NewRecorder()
+			svr.gitRequestsHandler(rr, req)
+			Expect(rr.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should limit a chunked request body as it is read", func() {
+			mockObjects.RepoKeeper.EXPECT().Get(repoName).Return(state.BareRepository())
+			req := httptest.NewRequest("POST", "/r/"+repoName+"/git-receive-pack", strings.NewReader(strings.Repeat("a", 11)))
+			req.ContentLength = -1
+			rr := httptest.NewRecorder()
+			svr.gitRequestsHandler(rr, req)
+			Expect(rr.Code).To(Equal(http.StatusNotFound))
+			Expect(req.Body).To(BeAssignableToTypeOf(&maxBodyReader{}))
+			Expect(req.Body.(*maxBodyReader).remaining).To(Equal(int64(10)))
+		})
+
+		It("should not limit request body when max request body size is zero", func() {
+			cfg.Repo.MaxRequestBodySize = 0
+			mockObjects.RepoKeeper.EXPECT().Get(repoName).Return(state.BareRepository())
+			req := httptest.NewRequest("POST", "/r/"+repoName+"/git-receive-pack", strings.NewReader(strings.Repeat("a", 100)))
+			rr := httptest.NewRecorder()
+			svr.gitRequestsHandler(rr, req)
+			Expect(rr.Code).To(Equal(http.StatusNotFound))
+		})
+
+		When("repository is private", func() {
+			var repoState *state.Repository
+			var token string
+
+			BeforeEach(func() {
+				repoState = state.BareRepository()
+				repoState.Balance = "10"
+				repoState.Config.Access = &state.RepoAccess{Private: true}
+				mockObjects.RepoKeeper.EXPECT().Get(repoName).Return(repoState)
+				token = base58.Encode(util.ToBytes(&remotetypes.TxDetail{RepoName: repoName}))
+			})
+
+			It("should return 401 when fetch request has no push token", func() {
+				req := httptest.NewRequest("GET", "/r/"+repoName+"/info/refs?service=git-upload-pack", nil)
+				rr := httptest.NewRecorder()
+				svr.gitRequestsHandler(rr, req)
+				Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+				Expect(rr.Header().Get("WWW-Authenticate")).To(Equal("Basic"))
+			})
+
+			It("should return 403 when requester is not permitted to fetch", func() {
+				svr.checkReadAccess = func(*remotetypes.TxDetail, *state.Repository, core.Keepers, validation.TxDetailChecker) error {
+					return ErrReadAccessDenied
+				}
+				req := httptest.NewRequest("GET", "/r/"+repoName+"/info/refs?service=git-upload-pack", nil)
+				req.SetBasicAuth(token, "")
+				rr := httptest.NewRecorder()
+				svr.gitRequestsHandler(rr, req)
+				Expect(rr.Code).To(Equal(http.StatusForbidden))
+			})
+
+			It("should advertise references when requester is permitted to fetch", func() {
+				svr.checkReadAccess = func(txDetail *remotetypes.TxDetail, _ *state.Repository, _ core.Keepers, _ validation.TxDetailChecker) error {
+					Expect(txDetail.RepoName).To(Equal(repoName))
+					return nil
+				}
+				req := httptest.NewRequest("GET", "/r/"+repoName+"/info/refs?service=git-upload-pack", nil)
+				req.SetBasicAuth(token, "")
+				rr := httptest.NewRecorder()
+				svr.gitRequestsHandler(rr, req)
+				Expect(rr.Code).To(Equal(http.StatusOK))
+				Expect(rr.Body.String()).To(ContainSubstring("# service=git-upload-pack"))
+			})
+		})
+
+		When("uploading a push request in chunks", func() {
+			var token, id string
+
+			BeforeEach(func() {
+				repoState := state.BareRepository()
+				repoState.Balance = "10"
+				mockObjects.RepoKeeper.EXPECT().Get(repoName).Return(repoState).AnyTimes()
+				token = base58.Encode(util.ToBytes(&remotetypes.TxDetail{RepoName: repoName}))
+				svr.authenticate = func([]*remotetypes.TxDetail, *state.Repository, *state.Namespace, core.Keepers, validation.TxDetailChecker) (policy.EnforcerFunc, error) {
+					return nil, nil
+				}
+
+				req := httptest.NewRequest("POST", "/r/"+repoName+"/uploads", nil)
+				req.SetBasicAuth(token, "")
+				rr := httptest.NewRecorder()
+				svr.gitRequestsHandler(rr, req)
+				Expect(rr.Code).To(Equal(http.StatusCreated))
+				var res map[string]interface{}
+				Expect(json.Unmarshal(rr.Body.Bytes(), &res)).To(Succeed())
+				Expect(res["offset"]).To(Equal(float64(0)))
+				id = res["id"].(string)
+			})
+
+			putChunk := func(offset string, body io.Reader) *httptest.ResponseRecorder {
+				req := httptest.NewRequest("PUT", "/r/"+repoName+"/uploads/"+id, body)
+				req.SetBasicAuth(token, "")
+				req.Header.Set(UploadOffsetHeader, offset)
+				rr := httptest.NewRecorder()
+				svr.gitRequestsHandler(rr, req)
+				return rr
+			}
+
+			It("should accept chunks that are individually within the max request body size", func() {
+				rr := putChunk("0", strings.NewReader("aaaaaaaaaa"))
+				Expect(rr.Code).To(Equal(http.StatusOK))
+				rr = putChunk("10", strings.NewReader("bbbbbbbbbb"))
+				Expect(rr.Code).To(Equal(http.StatusOK))
+				Expect(rr.Header().Get(UploadOffsetHeader)).To(Equal("20"))
+			})
+
+			It("should return 409 and the expected offset when chunk offset does not match", func() {
+				Expect(putChunk("0", strings.NewReader("aaaaa")).Code).To(Equal(http.StatusOK))
+				rr := putChunk("0", strings.NewReader("aaaaa"))
+				Expect(rr.Code).To(Equal(http.StatusConflict))
+				Expect(rr.Header().Get(UploadOffsetHeader)).To(Equal("5"))
+			})
+
+			It("should return 400 when chunk offset is not set", func() {
+				Expect(putChunk("", strings.NewReader("aaaaa")).Code).To(Equal(http.StatusBadRequest))
+			})
+
+			It("should return 404 when session does not exist", func() {
+				id = strings.Repeat("0", 32)
+				Expect(putChunk("0", strings.NewReader("aaaaa")).Code).To(Equal(http.StatusNotFound))
+			})
+
+			It("should resume from the reported offset after an interrupted chunk", func() {
+				rr := putChunk("0", &interruptedReader{r: strings.NewReader("aaaaaaaaaa"), n: 6})
+				Expect(rr.Code).To(Equal(http.StatusInternalServerError))
+				Expect(rr.Header().Get(UploadOffsetHeader)).To(Equal("6"))
+
+				req := httptest.NewRequest("GET", "/r/"+repoName+"/uploads/"+id, nil)
+				rr = httptest.NewRecorder()
+				svr.gitRequestsHandler(rr, req)
+				Expect(rr.Code).To(Equal(http.StatusOK))
+				Expect(rr.Header().Get(UploadOffsetHeader)).To(Equal("6"))
+
+				rr = putChunk("6", strings.NewReader("aaaa"))
+				Expect(rr.Code).To(Equal(http.StatusOK))
+				Expect(rr.Header().Get(UploadOffsetHeader)).To(Equal("10"))
+			})
+		})
+
+		When("fetching a shallow history", func() {
+			var commits []string
+
+			BeforeEach(func() {
+				cfg.Repo.MaxRequestBodySize = 0
+				repoState := state.BareRepository()
+				repoState.Balance = "10"
+				mockObjects.RepoKeeper.EXPECT().Get(repoName).Return(repoState).AnyTimes()
+				commits = nil
+				for i := 0; i < 4; i++ {
+					testutil2.AppendCommit(path, "file.txt", fmt.Sprintf("line %d", i), "commit msg")
+					commits = append(commits, testutil2.GetRecentCommitHash(path, "master"))
+				}
+			})
+
+			fetch := func(depth int) *httptest.ResponseRecorder {
+				body := string(packetWrite(fmt.Sprintf("want %s shallow\n", commits[3])))
+				body += string(packetWrite(fmt.Sprintf("deepen %d\n", depth)))
+				body += string(packetFlush()) + string(packetWrite("done\n"))
+				req := httptest.NewRequest("POST", "/r/"+repoName+"/git-upload-pack", strings.NewReader(body))
+				rr := httptest.NewRecorder()
+				svr.gitRequestsHandler(rr, req)
+				return rr
+			}
+
+			It("should truncate the history to the max clone depth when requested depth exceeds it", func() {
+				cfg.Repo.MaxCloneDepth = 2
+				rr := fetch(3)
+				Expect(rr.Code).To(Equal(http.StatusOK))
+				Expect(rr.Header().Get(CloneDepthCappedHeader)).To(Equal("2"))
+				Expect(rr.Body.String()).To(ContainSubstring("shallow " + commits[2]))
+				Expect(rr.Body.String()).ToNot(ContainSubstring("shallow " + commits[1]))
+			})
+
+			It("should not truncate the history when requested depth does not exceed the max clone depth", func() {
+				cfg.Repo.MaxCloneDepth = 3
+				rr := fetch(3)
+				Expect(rr.Code).To(Equal(http.StatusOK))
+				Expect(rr.Header().Get(CloneDepthCappedHeader)).To(BeEmpty())
+				Expect(rr.Body.String()).To(ContainSubstring("shallow " + commits[1]))
+			})
+
+			It("should not truncate the history when max clone depth is zero", func() {
+				cfg.Repo.MaxCloneDepth = 0
+				rr := fetch(3)
+				Expect(rr.Code).To(Equal(http.StatusOK))
+				Expect(rr.Header().Get(CloneDepthCappedHeader)).To(BeEmpty())
+				Expect(rr.Body.String()).To(ContainSubstring("shallow " + commits[1]))
+			})
+		})
+	})
+
+	Describe(".runPeriodically", func() {
+		It("should call the function at every interval and return when the stop channel is closed", func() {
+			calls := make(chan struct{}, 10)
+			done := make(chan struct{})
+			go func() {
+				svr.runPeriodically(time.Millisecond, func() {
+					select {
+					case calls <- struct{}{}:
+					default:
+					}
+				})
+				close(done)
+			}()
+			Eventually(calls).Should(Receive())
+			svr.stopOnce.Do(func() { close(svr.stop) })
+			Eventually(done).Should(BeClosed())
+		})
+	})
+
 	Describe(".checkRepo", func() {
 		It("should return false if error checking repo's existence", func() {
 			Expect(svr.checkRepo("", []byte("repo"))).To(BeFalse())
@@ -284,4 +574,64 @@ var _ = Describe("Server", func() {
 			svr.applyRepoTrackingConfig()
 		})
 	})
+
+	Describe(".autoTrackRepo", func() {
+		It("should not track when no auto-track pattern is configured", func() {
+			Expect(svr.autoTrackRepo(nil, "", "repo1")).To(BeFalse())
+		})
+
+		It("should track a repository whose name matches a pattern", func() {
+			cfg.Repo.AutoTrack = []string{"mirror-*"}
+			mockRepoSyncInfoKeeper.EXPECT().GetTracked("mirror-repo1").Return(nil)
+			mockRepoSyncInfoKeeper.EXPECT().Track("mirror-repo1").Return(nil)
+			Expect(svr.autoTrackRepo(nil, "", "mirror-repo1")).To(BeTrue())
+		})
+
+		It("should not track a repository whose name does not match any pattern", func() {
+			cfg.Repo.AutoTrack = []string{"mirror-*"}
+			mockRepoSyncInfoKeeper.EXPECT().GetTracked("repo1").Return(nil)
+			Expect(svr.autoTrackRepo(nil, "", "repo1")).To(BeFalse())
+		})
+
+		It("should not track a repository that is already tracked", func() {
+			cfg.Repo.AutoTrack = []string{"*"}
+			mockRepoSyncInfoKeeper.EXPECT().GetTracked("repo1").Return(&core.TrackedRepo{})
+			Expect(svr.autoTrackRepo(nil, "", "repo1")).To(BeFalse())
+		})
+
+		It("should return false if tracking failed", func() {
+			cfg.Repo.AutoTrack = []string{"*"}
+			mockRepoSyncInfoKeeper.EXPECT().GetTracked("repo1").Return(nil)
+			mockRepoSyncInfoKeeper.EXPECT().Track("repo1").Return(fmt.Errorf("error"))
+			Expect(svr.autoTrackRepo(nil, "", "repo1")).To(BeFalse())
+		})
+
+		When("the push targets a namespace", func() {
+			var ns *state.Namespace
+
+			BeforeEach(func() {
+				ns = state.BareNamespace()
+				ns.Domains["web"] = "r/repo1"
+				ns.Domains["docs"] = "r/repo2"
+			})
+
+			It("should track the resolved repository if namespace/domain matches a pattern", func() {
+				cfg.Repo.AutoTrack = []string{"myns/*"}
+				mockRepoSyncInfoKeeper.EXPECT().GetTracked("repo1").Return(nil)
+				mockRepoSyncInfoKeeper.EXPECT().Track("repo1").Return(nil)
+				Expect(svr.autoTrackRepo(ns, "myns", "web")).To(BeTrue())
+			})
+
+			It("should not track the resolved repository if the namespace does not match", func() {
+				cfg.Repo.AutoTrack = []string{"otherns/*"}
+				mockRepoSyncInfoKeeper.EXPECT().GetTracked("repo2").Return(nil)
+				Expect(svr.autoTrackRepo(ns, "myns", "docs")).To(BeFalse())
+			})
+
+			It("should not track if the domain is unknown", func() {
+				cfg.Repo.AutoTrack = []string{"myns/*"}
+				Expect(svr.autoTrackRepo(ns, "myns", "unknown")).To(BeFalse())
+			})
+		})
+	})
 })
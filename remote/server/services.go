@@ -1,10 +1,12 @@
 package server
 
 import (
+	"bufio"
 	"bytes"
 	"compress/gzip"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"os/exec"
@@ -13,6 +15,7 @@ import (
 	"strings"
 	"time"
 
+	plumbing2 "github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/format/pktline"
 	"github.com/make-os/kit/remote/plumbing"
 	"github.com/make-os/kit/remote/policy"
@@ -24,17 +27,65 @@ import (
 
 // RequestContext describes a request from the git remote server
 type RequestContext struct {
-	W           http.ResponseWriter
-	R           *http.Request
-	TxDetails   []*types.TxDetail
-	PolEnforcer policy.EnforcerFunc
-	PushHandler types2.Handler
-	Repo        plumbing.LocalRepo
-	RepoDir     string
-	Operation   string
-	ServiceName string
-	GitBinPath  string
-	pktEnc      *pktline.Encoder
+	W              http.ResponseWriter
+	R              *http.Request
+	TxDetails      []*types.TxDetail
+	PolEnforcer    policy.EnforcerFunc
+	PushHandler    types2.Handler
+	Repo           plumbing.LocalRepo
+	RepoDir        string
+	Operation      string
+	ServiceName    string
+	GitBinPath     string
+	Uploads        *UploadSessions
+	MaxCloneDepth  int
+	MaxArchiveSize int64
+	pktEnc         *pktline.Encoder
+}
+
+// CloneDepthCappedHeader is the response header that carries the max clone
+// depth when the depth requested by a clone or fetch request was reduced to it.
+const CloneDepthCappedHeader = "X-Clone-Depth-Capped"
+
+// ErrRequestBodyTooLarge is returned when reading a request body that is
+// larger than the max request body size.
+var ErrRequestBodyTooLarge = fmt.Errorf("request body too large")
+
+// maxBodyReader limits the number of bytes read from a request body of
+// unknown length. Once the limit is exceeded, it responds with 413 (Request
+// Entity Too Large) and fails all subsequent reads.
+type maxBodyReader struct {
+	io.ReadCloser
+	w         http.ResponseWriter
+	remaining int64
+	exceeded  bool
+}
+
+// newMaxBodyReader creates an instance of maxBodyReader
+func newMaxBodyReader(w http.ResponseWriter, body io.ReadCloser, max int64) *maxBodyReader {
+	return &maxBodyReader{ReadCloser: body, w: w, remaining: max}
+}
+
+// Read implements io.Reader
+func (b *maxBodyReader) Read(p []byte) (int, error) {
+	if b.exceeded {
+		return 0, ErrRequestBodyTooLarge
+	}
+
+	// Read one byte past the limit to detect an oversized body
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) <= b.remaining {
+		b.remaining -= int64(n)
+		return n, err
+	}
+
+	n, b.remaining, b.exceeded = int(b.remaining), 0, true
+	b.w.WriteHeader(http.StatusRequestEntityTooLarge)
+	return n, ErrRequestBodyTooLarge
 }
 
 // sendFile fetches a file and sends it to the requester
@@ -161,6 +212,46 @@ func getIdxFile(s *RequestContext) error {
 	return sendFile(s.Operation, "application/x-git-packed-objects-toc", s)
 }
 
+// serveArchive streams a gzip compressed tar archive of the files of the
+// repository at the revision in the 'revision' query parameter (default: HEAD).
+// The archive is written to the response as it is produced; the response is
+// cut short if the archive grows larger than the max archive size.
+func serveArchive(s *RequestContext) error {
+	rev := s.R.URL.Query().Get("revision")
+	if rev == "" {
+		rev = "HEAD"
+	}
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	go func() {
+		var w io.Writer = pw
+		if s.MaxArchiveSize > 0 {
+			w = util.NewLimitedWriter(pw, s.MaxArchiveSize)
+		}
+		pw.CloseWithError(s.Repo.WriteArchive(rev, w))
+	}()
+
+	// Wait for the first bytes of the archive before sending the
+	// headers so that a bad revision gets a proper status code
+	br := bufio.NewReader(pr)
+	if _, err := br.Peek(1); err != nil {
+		if err == plumbing2.ErrReferenceNotFound || err == plumbing2.ErrObjectNotFound {
+			endNotFound(s.W)
+			return err
+		}
+		s.W.WriteHeader(http.StatusInternalServerError)
+		return err
+	}
+
+	hdrNoCache(s.W)
+	s.W.Header().Set("Content-Type", "application/gzip")
+	s.W.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", s.Repo.GetName()+".tar.gz"))
+	s.W.WriteHeader(http.StatusOK)
+	_, err := io.Copy(s.W, br)
+	return err
+}
+
 // service describes a git service and its handler
 type service struct {
 	method string
@@ -219,17 +310,98 @@ dumbReq:
 	return sendFile(s.Operation, "text/plain; charset=utf-8", s)
 }
 
+// capDeepenRequest reduces the depth of "deepen" lines of an upload-pack
+// request body to maxDepth. It returns the rewritten body and true if at
+// least one line requested a depth beyond maxDepth.
+func capDeepenRequest(body []byte, maxDepth int) ([]byte, bool, error) {
+	var out = bytes.NewBuffer(nil)
+	var capped bool
+	for len(body) > 0 {
+		if len(body) < 4 {
+			return nil, false, fmt.Errorf("malformed packet line")
+		}
+		n, err := strconv.ParseUint(string(body[:4]), 16, 16)
+		if err != nil {
+			return nil, false, fmt.Errorf("malformed packet line length")
+		}
+
+		// Flush, delimiter and response-end packets carry no payload
+		if n < 4 {
+			out.Write(body[:4])
+			body = body[4:]
+			continue
+		}
+
+		if int(n) > len(body) {
+			return nil, false, fmt.Errorf("malformed packet line")
+		}
+
+		line := string(body[4:n])
+		body = body[n:]
+		if strings.HasPrefix(line, "deepen ") {
+			depth, err := strconv.Atoi(strings.TrimSpace(line[7:]))
+			if err == nil && depth > maxDepth {
+				line, capped = fmt.Sprintf("deepen %d\n", maxDepth), true
+			}
+		}
+		out.Write(packetWrite(line))
+	}
+	return out.Bytes(), capped, nil
+}
+
+// limitCloneDepth caps the depth requested by an upload-pack request to the
+// max clone depth, decompressing the request body if necessary. The capped
+// depth is indicated in the response headers.
+func limitCloneDepth(s *RequestContext) error {
+	var reader io.ReadCloser = s.R.Body
+	var err error
+	if s.R.Header.Get("Content-Encoding") == "gzip" {
+		if reader, err = gzip.NewReader(s.R.Body); err != nil {
+			return errors.Wrap(err, "failed to decompress request body")
+		}
+	}
+	defer reader.Close()
+
+	body, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return errors.Wrap(err, "failed to read request body")
+	}
+
+	body, capped, err := capDeepenRequest(body, s.MaxCloneDepth)
+	if err != nil {
+		return err
+	}
+
+	if capped {
+		s.W.Header().Set(CloneDepthCappedHeader, strconv.Itoa(s.MaxCloneDepth))
+	}
+
+	s.R.Header.Del("Content-Encoding")
+	s.R.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return nil
+}
+
 // serveService handles git-upload & fetch-pack requests
 func serveService(s *RequestContext) error {
 	w, r, op, dir := s.W, s.R, s.Operation, s.RepoDir
 	op = strings.ReplaceAll(op, "git-", "")
 
-	// Set response headers
+	// Cap the history depth requested by a fetch request
+	if op == "upload-pack" && s.MaxCloneDepth > 0 {
+		if err := limitCloneDepth(s); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("Bad Request"))
+			return err
+		}
+	}
+
+	// Set response headers. The status code is sent with the first write
+	// to the response so that errors found while reading the request body
+	// (e.g. an oversized chunked body) can still set it.
 	w.Header().Set("Content-Type", fmt.Sprintf("application/x-git-%s-result", op))
 	w.Header().Set("Connection", "Keep-Alive")
 	w.Header().Set("Transfer-Encoding", "chunked")
 	w.Header().Set("X-Content-Type-Options", "nosniff")
-	w.WriteHeader(http.StatusOK)
 	hdrNoCache(w)
 
 	// Construct the git command
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	plumbing2 "github.com/go-git/go-git/v5/plumbing"
+	"github.com/golang/mock/gomock"
+	"github.com/make-os/kit/mocks"
+	"github.com/make-os/kit/util"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Services", func() {
+	Describe(".maxBodyReader", func() {
+		It("should read the body when it is not larger than the limit", func() {
+			rr := httptest.NewRecorder()
+			body, err := ioutil.ReadAll(newMaxBodyReader(rr, ioutil.NopCloser(strings.NewReader("abcde")), 5))
+			Expect(err).To(BeNil())
+			Expect(string(body)).To(Equal("abcde"))
+			Expect(rr.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should respond with 413 and fail when the body is larger than the limit", func() {
+			rr := httptest.NewRecorder()
+			r := newMaxBodyReader(rr, ioutil.NopCloser(strings.NewReader("abcdef")), 5)
+			body, err := ioutil.ReadAll(r)
+			Expect(err).To(Equal(ErrRequestBodyTooLarge))
+			Expect(string(body)).To(Equal("abcde"))
+			Expect(rr.Code).To(Equal(http.StatusRequestEntityTooLarge))
+			_, err = r.Read(make([]byte, 1))
+			Expect(err).To(Equal(ErrRequestBodyTooLarge))
+		})
+	})
+
+	Describe(".serveArchive", func() {
+		var ctrl *gomock.Controller
+		var mockRepo *mocks.MockLocalRepo
+		var rr *httptest.ResponseRecorder
+		var reqCtx *RequestContext
+
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+			mockRepo = mocks.NewMockLocalRepo(ctrl)
+			mockRepo.EXPECT().GetName().Return("repo1").AnyTimes()
+			rr = httptest.NewRecorder()
+			reqCtx = &RequestContext{W: rr, R: httptest.NewRequest("GET", "/r/repo1/archive?revision=dev", nil), Repo: mockRepo}
+		})
+
+		AfterEach(func() {
+			ctrl.Finish()
+		})
+
+		It("should respond with 404 if the revision does not exist", func() {
+			mockRepo.EXPECT().WriteArchive("dev", gomock.Any()).Return(plumbing2.ErrReferenceNotFound)
+			err := serveArchive(reqCtx)
+			Expect(err).To(Equal(plumbing2.ErrReferenceNotFound))
+			Expect(rr.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should use HEAD if revision is not provided", func() {
+			reqCtx.R = httptest.NewRequest("GET", "/r/repo1/archive", nil)
+			mockRepo.EXPECT().WriteArchive("HEAD", gomock.Any()).Return(plumbing2.ErrObjectNotFound)
+			err := serveArchive(reqCtx)
+			Expect(err).To(Equal(plumbing2.ErrObjectNotFound))
+			Expect(rr.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should stream the archive", func() {
+			mockRepo.EXPECT().WriteArchive("dev", gomock.Any()).DoAndReturn(func(_ string, w io.Writer) error {
+				_, err := w.Write([]byte("archive"))
+				return err
+			})
+			err := serveArchive(reqCtx)
+			Expect(err).To(BeNil())
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Header().Get("Content-Type")).To(Equal("application/gzip"))
+			Expect(rr.Header().Get("Content-Disposition")).To(Equal(`attachment; filename="repo1.tar.gz"`))
+			Expect(rr.Body.String()).To(Equal("archive"))
+		})
+
+		It("should stop streaming once the archive exceeds the max archive size", func() {
+			reqCtx.MaxArchiveSize = 3
+			mockRepo.EXPECT().WriteArchive("dev", gomock.Any()).DoAndReturn(func(_ string, w io.Writer) error {
+				_, err := w.Write([]byte("archive"))
+				return err
+			})
+			err := serveArchive(reqCtx)
+			Expect(err).To(Equal(util.ErrWriteLimitExceeded))
+			Expect(rr.Body.String()).To(Equal("arc"))
+		})
+	})
+
+	Describe(".capDeepenRequest", func() {
+		var req []byte
+
+		BeforeEach(func() {
+			req = append(packetWrite("want abc shallow\n"), packetWrite("deepen 10\n")...)
+			req = append(req, packetFlush()...)
+			req = append(req, packetWrite("done\n")...)
+		})
+
+		It("should reduce the requested depth to the max depth", func() {
+			out, capped, err := capDeepenRequest(req, 5)
+			Expect(err).To(BeNil())
+			Expect(capped).To(BeTrue())
+			Expect(string(out)).To(Equal("0015want abc shallow\n000ddeepen 5\n00000009done\n"))
+		})
+
+		It("should not change the request when requested depth does not exceed the max depth", func() {
+			out, capped, err := capDeepenRequest(req, 10)
+			Expect(err).To(BeNil())
+			Expect(capped).To(BeFalse())
+			Expect(out).To(Equal(req))
+		})
+
+		It("should keep delimiter packets of protocol v2 requests", func() {
+			req = append(packetWrite("command=fetch\n"), []byte("0001")...)
+			req = append(req, packetWrite("deepen 10\n")...)
+			req = append(req, packetFlush()...)
+			out, capped, err := capDeepenRequest(req, 1)
+			Expect(err).To(BeNil())
+			Expect(capped).To(BeTrue())
+			Expect(string(out)).To(Equal("0012command=fetch\n0001000ddeepen 1\n0000"))
+		})
+
+		It("should return error when packet line length is malformed", func() {
+			_, _, err := capDeepenRequest([]byte("zzzzdeepen 10\n"), 5)
+			Expect(err).To(MatchError("malformed packet line length"))
+		})
+
+		It("should return error when packet line is truncated", func() {
+			_, _, err := capDeepenRequest([]byte("0020deepen 10\n"), 5)
+			Expect(err).To(MatchError("malformed packet line"))
+		})
+	})
+})
@@ -0,0 +1,311 @@
+package server
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// UploadOffsetHeader is the header that carries the offset of an upload chunk
+const UploadOffsetHeader = "Upload-Offset"
+
+var (
+	ErrUploadSessionNotFound = fmt.Errorf("upload session not found")
+	ErrUploadOffsetMismatch  = fmt.Errorf("upload offset does not match the session offset")
+)
+
+// UploadSession describes a push request body that is uploaded in chunks
+type UploadSession struct {
+	ID        string
+	RepoName  string
+	Offset    int64
+	path      string
+	touchedAt time.Time
+	lck       *sync.Mutex
+}
+
+// UploadSessions stores upload sessions keyed by session ID.
+// A session and its partially uploaded data are removed when
+// it has not been written to within the session TTL.
+type UploadSessions struct {
+	lck      *sync.Mutex
+	dir      string
+	ttl      time.Duration
+	sessions map[string]*UploadSession
+}
+
+// NewUploadSessions creates an instance of UploadSessions.
+//  - dir: The directory where the uploaded data are stored.
+//  - ttl: The duration a session remains valid after it was last written to.
+func NewUploadSessions(dir string, ttl time.Duration) *UploadSessions {
+	return &UploadSessions{
+		lck:      &sync.Mutex{},
+		dir:      dir,
+		ttl:      ttl,
+		sessions: make(map[string]*UploadSession),
+	}
+}
+
+// Create creates a new upload session for a repository
+func (u *UploadSessions) Create(repoName string) (*UploadSession, error) {
+	if err := os.MkdirAll(u.dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "failed to create uploads directory")
+	}
+
+	id := hex.EncodeToString(uuid.NewV4().Bytes())
+	filePath := filepath.Join(u.dir, id)
+	f, err := os.OpenFile(filePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create upload file")
+	}
+	f.Close()
+
+	session := &UploadSession{ID: id, RepoName: repoName, path: filePath, touchedAt: time.Now(), lck: &sync.Mutex{}}
+	u.lck.Lock()
+	u.sessions[id] = session
+	u.lck.Unlock()
+
+	return &UploadSession{ID: id, RepoName: repoName}, nil
+}
+
+// get returns a session that has not expired
+func (u *UploadSessions) get(id string) *UploadSession {
+	u.lck.Lock()
+	defer u.lck.Unlock()
+	session, ok := u.sessions[id]
+	if !ok || u.isExpired(session) {
+		return nil
+	}
+	return session
+}
+
+// isExpired checks whether a session has expired.
+// Note: not thread-safe
+func (u *UploadSessions) isExpired(session *UploadSession) bool {
+	return session.touchedAt.Add(u.ttl).Before(time.Now())
+}
+
+// Get returns a copy of an upload session or nil if it does not exist
+func (u *UploadSessions) Get(id string) *UploadSession {
+	session := u.get(id)
+	if session == nil {
+		return nil
+	}
+	session.lck.Lock()
+	defer session.lck.Unlock()
+	return &UploadSession{ID: session.ID, RepoName: session.RepoName, Offset: session.Offset}
+}
+
+// Append writes a chunk read from r to an upload session. The offset must
+// equal the number of bytes already uploaded. If reading the chunk fails,
+// the bytes received before the failure are kept so that the upload can
+// resume from the new offset.
+//
+// Returns the offset of the session after the chunk was written.
+func (u *UploadSessions) Append(id string, offset int64, r io.Reader) (int64, error) {
+	session := u.get(id)
+	if session == nil {
+		return 0, ErrUploadSessionNotFound
+	}
+
+	session.lck.Lock()
+	defer session.lck.Unlock()
+
+	if offset != session.Offset {
+		return session.Offset, ErrUploadOffsetMismatch
+	}
+
+	f, err := os.OpenFile(session.path, os.O_WRONLY, 0600)
+	if err != nil {
+		return session.Offset, errors.Wrap(err, "failed to open upload file")
+	}
+	defer f.Close()
+
+	// Discard bytes written by an earlier chunk that failed to be recorded
+	if err = f.Truncate(session.Offset); err != nil {
+		return session.Offset, errors.Wrap(err, "failed to truncate upload file")
+	}
+	if _, err = f.Seek(session.Offset, io.SeekStart); err != nil {
+		return session.Offset, errors.Wrap(err, "failed to seek upload file")
+	}
+
+	n, err := io.Copy(f, r)
+	session.Offset += n
+	u.touch(session)
+	if err != nil {
+		return session.Offset, errors.Wrap(err, "failed to read chunk")
+	}
+
+	return session.Offset, nil
+}
+
+// touch updates the last write time of a session
+func (u *UploadSessions) touch(session *UploadSession) {
+	u.lck.Lock()
+	session.touchedAt = time.Now()
+	u.lck.Unlock()
+}
+
+// Finalize removes an upload session and returns its uploaded data.
+// The caller is responsible for closing and deleting the returned file.
+func (u *UploadSessions) Finalize(id string) (*os.File, error) {
+	u.lck.Lock()
+	session, ok := u.sessions[id]
+	if !ok || u.isExpired(session) {
+		u.lck.Unlock()
+		return nil, ErrUploadSessionNotFound
+	}
+	delete(u.sessions, id)
+	u.lck.Unlock()
+
+	// Wait for an in-progress chunk to be written
+	session.lck.Lock()
+	defer session.lck.Unlock()
+
+	f, err := os.Open(session.path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open upload file")
+	}
+
+	return f, nil
+}
+
+// Remove deletes an upload session and its uploaded data
+func (u *UploadSessions) Remove(id string) error {
+	u.lck.Lock()
+	session, ok := u.sessions[id]
+	delete(u.sessions, id)
+	u.lck.Unlock()
+	if !ok {
+		return nil
+	}
+	return os.Remove(session.path)
+}
+
+// RemoveExpired deletes sessions that have expired
+func (u *UploadSessions) RemoveExpired() {
+	u.lck.Lock()
+	var expired []string
+	for id, session := range u.sessions {
+		if u.isExpired(session) {
+			expired = append(expired, id)
+		}
+	}
+	u.lck.Unlock()
+
+	for _, id := range expired {
+		_ = u.Remove(id)
+	}
+}
+
+// getUploadSession returns the upload session referenced in the request
+// URL, writing a 404 response if the session does not exist or does not
+// belong to the target repository.
+func getUploadSession(s *RequestContext, id string) *UploadSession {
+	session := s.Uploads.Get(id)
+	if session == nil || session.RepoName != s.Repo.GetName() {
+		endNotFound(s.W)
+		return nil
+	}
+	return session
+}
+
+// writeUploadSession writes the JSON representation of an upload session
+func writeUploadSession(w http.ResponseWriter, status int, session *UploadSession) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(UploadOffsetHeader, strconv.FormatInt(session.Offset, 10))
+	hdrNoCache(w)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": session.ID, "offset": session.Offset})
+}
+
+// createUpload starts a new upload session for the target repository
+func createUpload(s *RequestContext) error {
+	session, err := s.Uploads.Create(s.Repo.GetName())
+	if err != nil {
+		s.W.WriteHeader(http.StatusInternalServerError)
+		return err
+	}
+	writeUploadSession(s.W, http.StatusCreated, session)
+	return nil
+}
+
+// getUploadStatus returns the current offset of an upload session
+func getUploadStatus(s *RequestContext) error {
+	session := getUploadSession(s, path.Base(s.R.URL.Path))
+	if session == nil {
+		return ErrUploadSessionNotFound
+	}
+	writeUploadSession(s.W, http.StatusOK, session)
+	return nil
+}
+
+// appendUpload writes the request body to an upload session at the
+// offset specified in the Upload-Offset header. When the offset does not
+// match the session offset, a 409 response containing the expected
+// offset is returned so that the client can resume from it.
+func appendUpload(s *RequestContext) error {
+	w, r := s.W, s.R
+	session := getUploadSession(s, path.Base(r.URL.Path))
+	if session == nil {
+		return ErrUploadSessionNotFound
+	}
+
+	offset, err := strconv.ParseInt(r.Header.Get(UploadOffsetHeader), 10, 64)
+	if err != nil || offset < 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Bad Request"))
+		return fmt.Errorf("invalid upload offset")
+	}
+
+	curOffset, err := s.Uploads.Append(session.ID, offset, r.Body)
+	if err != nil {
+		session.Offset = curOffset
+		switch err {
+		case ErrUploadSessionNotFound:
+			endNotFound(w)
+		case ErrUploadOffsetMismatch:
+			writeUploadSession(w, http.StatusConflict, session)
+		default:
+			w.Header().Set(UploadOffsetHeader, strconv.FormatInt(curOffset, 10))
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return err
+	}
+
+	session.Offset = curOffset
+	writeUploadSession(w, http.StatusOK, session)
+	return nil
+}
+
+// finalizeUpload ends an upload session and processes the uploaded
+// data as the body of a git-receive-pack request.
+func finalizeUpload(s *RequestContext) error {
+	id := path.Base(path.Dir(s.R.URL.Path))
+	if getUploadSession(s, id) == nil {
+		return ErrUploadSessionNotFound
+	}
+
+	f, err := s.Uploads.Finalize(id)
+	if err != nil {
+		endNotFound(s.W)
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	s.R.Body = f
+	s.Operation = "git-receive-pack"
+	return serveService(s)
+}
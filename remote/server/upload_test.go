@@ -0,0 +1,174 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/testutil"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// interruptedReader returns an error after n bytes of r have been read
+type interruptedReader struct {
+	r io.Reader
+	n int
+}
+
+func (ir *interruptedReader) Read(p []byte) (int, error) {
+	if ir.n <= 0 {
+		return 0, fmt.Errorf("connection reset")
+	}
+	if len(p) > ir.n {
+		p = p[:ir.n]
+	}
+	n, err := ir.r.Read(p)
+	ir.n -= n
+	return n, err
+}
+
+var _ = Describe("UploadSessions", func() {
+	var err error
+	var cfg *config.AppConfig
+	var uploads *UploadSessions
+
+	BeforeEach(func() {
+		cfg, err = testutil.SetTestCfg()
+		Expect(err).To(BeNil())
+		uploads = NewUploadSessions(cfg.GetUploadsDir(), time.Hour)
+	})
+
+	AfterEach(func() {
+		err = os.RemoveAll(cfg.DataDir())
+		Expect(err).To(BeNil())
+	})
+
+	Describe(".Create", func() {
+		It("should create a session with zero offset and an empty upload file", func() {
+			session, err := uploads.Create("repo1")
+			Expect(err).To(BeNil())
+			Expect(session.ID).To(HaveLen(32))
+			Expect(session.RepoName).To(Equal("repo1"))
+			Expect(session.Offset).To(BeZero())
+			info, err := os.Stat(filepath.Join(cfg.GetUploadsDir(), session.ID))
+			Expect(err).To(BeNil())
+			Expect(info.Size()).To(BeZero())
+		})
+	})
+
+	Describe(".Get", func() {
+		It("should return nil if session does not exist", func() {
+			Expect(uploads.Get("unknown")).To(BeNil())
+		})
+
+		It("should return nil if session has expired", func() {
+			uploads = NewUploadSessions(cfg.GetUploadsDir(), time.Millisecond)
+			session, err := uploads.Create("repo1")
+			Expect(err).To(BeNil())
+			time.Sleep(5 * time.Millisecond)
+			Expect(uploads.Get(session.ID)).To(BeNil())
+		})
+	})
+
+	Describe(".Append", func() {
+		var session *UploadSession
+
+		BeforeEach(func() {
+			session, err = uploads.Create("repo1")
+			Expect(err).To(BeNil())
+		})
+
+		It("should return ErrUploadSessionNotFound if session does not exist", func() {
+			_, err := uploads.Append("unknown", 0, strings.NewReader("abc"))
+			Expect(err).To(Equal(ErrUploadSessionNotFound))
+		})
+
+		It("should return ErrUploadOffsetMismatch and the current offset if offset is not the session offset", func() {
+			offset, err := uploads.Append(session.ID, 0, strings.NewReader("abc"))
+			Expect(err).To(BeNil())
+			Expect(offset).To(Equal(int64(3)))
+			offset, err = uploads.Append(session.ID, 0, strings.NewReader("abc"))
+			Expect(err).To(Equal(ErrUploadOffsetMismatch))
+			Expect(offset).To(Equal(int64(3)))
+		})
+
+		It("should append chunks in order", func() {
+			offset, err := uploads.Append(session.ID, 0, strings.NewReader("abc"))
+			Expect(err).To(BeNil())
+			offset, err = uploads.Append(session.ID, offset, strings.NewReader("def"))
+			Expect(err).To(BeNil())
+			Expect(offset).To(Equal(int64(6)))
+			Expect(uploads.Get(session.ID).Offset).To(Equal(int64(6)))
+		})
+
+		It("should keep bytes received before an interruption and resume from the returned offset", func() {
+			data := "abcdefghijklmnopqrstuvwxyz"
+			offset, err := uploads.Append(session.ID, 0, strings.NewReader(data[:10]))
+			Expect(err).To(BeNil())
+
+			offset, err = uploads.Append(session.ID, offset, &interruptedReader{r: strings.NewReader(data[10:20]), n: 4})
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("connection reset"))
+			Expect(offset).To(Equal(int64(14)))
+			Expect(uploads.Get(session.ID).Offset).To(Equal(int64(14)))
+
+			offset, err = uploads.Append(session.ID, offset, strings.NewReader(data[offset:]))
+			Expect(err).To(BeNil())
+			Expect(offset).To(Equal(int64(len(data))))
+
+			f, err := uploads.Finalize(session.ID)
+			Expect(err).To(BeNil())
+			defer f.Close()
+			bz, err := ioutil.ReadAll(f)
+			Expect(err).To(BeNil())
+			Expect(string(bz)).To(Equal(data))
+		})
+	})
+
+	Describe(".Finalize", func() {
+		It("should return ErrUploadSessionNotFound if session does not exist", func() {
+			_, err := uploads.Finalize("unknown")
+			Expect(err).To(Equal(ErrUploadSessionNotFound))
+		})
+
+		It("should remove the session and return the uploaded data", func() {
+			session, err := uploads.Create("repo1")
+			Expect(err).To(BeNil())
+			_, err = uploads.Append(session.ID, 0, strings.NewReader("abc"))
+			Expect(err).To(BeNil())
+			f, err := uploads.Finalize(session.ID)
+			Expect(err).To(BeNil())
+			defer f.Close()
+			bz, err := ioutil.ReadAll(f)
+			Expect(err).To(BeNil())
+			Expect(string(bz)).To(Equal("abc"))
+			Expect(uploads.Get(session.ID)).To(BeNil())
+		})
+	})
+
+	Describe(".RemoveExpired", func() {
+		It("should remove expired sessions and their upload files", func() {
+			uploads = NewUploadSessions(cfg.GetUploadsDir(), time.Millisecond)
+			session, err := uploads.Create("repo1")
+			Expect(err).To(BeNil())
+			time.Sleep(5 * time.Millisecond)
+			uploads.RemoveExpired()
+			Expect(uploads.sessions).To(BeEmpty())
+			_, err = os.Stat(filepath.Join(cfg.GetUploadsDir(), session.ID))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+
+		It("should not remove sessions that have not expired", func() {
+			_, err := uploads.Create("repo1")
+			Expect(err).To(BeNil())
+			uploads.RemoveExpired()
+			Expect(uploads.sessions).To(HaveLen(1))
+		})
+	})
+})
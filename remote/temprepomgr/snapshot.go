@@ -0,0 +1,159 @@
+package temprepomgr
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidSnapshot indicates that a snapshot could not be restored
+var ErrInvalidSnapshot = fmt.Errorf("invalid snapshot")
+
+// WriteSnapshot writes a gzip compressed tar archive of the temporary
+// repository at path to w. Entries are relative to the parent directory
+// of path so that the name of the repository directory is preserved.
+func WriteSnapshot(path string, w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	root := filepath.Dir(path)
+	err := filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		name, err := filepath.Rel(root, file)
+		if err != nil {
+			return err
+		}
+
+		var link string
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(file); err != nil {
+				return err
+			}
+		}
+
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(name)
+		if err = tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to archive repository")
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// ReadSnapshot extracts a snapshot created by WriteSnapshot into a new
+// temporary directory and returns the path of the restored repository.
+// Returns ErrInvalidSnapshot if the snapshot is malformed.
+func ReadSnapshot(r io.Reader) (string, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return "", ErrInvalidSnapshot
+	}
+	defer gr.Close()
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create temporary directory")
+	}
+
+	path, err := extractSnapshot(tar.NewReader(gr), dir)
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+
+	return path, nil
+}
+
+// extractSnapshot extracts the entries of a snapshot into dir and returns
+// the path of the repository directory. All entries must be in the same
+// top-level directory.
+func extractSnapshot(tr *tar.Reader, dir string) (string, error) {
+	var repoDir string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", ErrInvalidSnapshot
+		}
+
+		name := filepath.Clean(filepath.FromSlash(hdr.Name))
+		if filepath.IsAbs(name) || name == "." || strings.HasPrefix(name, "..") {
+			return "", ErrInvalidSnapshot
+		}
+		top := strings.SplitN(name, string(filepath.Separator), 2)[0]
+		if repoDir == "" {
+			repoDir = top
+		} else if top != repoDir {
+			return "", ErrInvalidSnapshot
+		}
+
+		target := filepath.Join(dir, name)
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(target, os.FileMode(hdr.Mode)|0700); err != nil {
+				return "", err
+			}
+		case tar.TypeReg:
+			if err = writeSnapshotFile(target, tr, os.FileMode(hdr.Mode)); err != nil {
+				return "", err
+			}
+		case tar.TypeSymlink:
+			if err = os.Symlink(hdr.Linkname, target); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if repoDir == "" {
+		return "", ErrInvalidSnapshot
+	}
+
+	return filepath.Join(dir, repoDir), nil
+}
+
+// writeSnapshotFile writes the content of a snapshot file entry to target
+func writeSnapshotFile(target string, r io.Reader, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(f, r)
+	return err
+}
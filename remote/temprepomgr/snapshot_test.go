@@ -0,0 +1,66 @@
+package temprepomgr
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Snapshot", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "")
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(BeNil())
+	})
+
+	It("should restore the files of a snapshot into a new directory", func() {
+		path := filepath.Join(dir, "repo1")
+		Expect(os.MkdirAll(filepath.Join(path, "sub"), 0700)).To(BeNil())
+		Expect(ioutil.WriteFile(filepath.Join(path, "a.txt"), []byte("file a"), 0644)).To(BeNil())
+		Expect(ioutil.WriteFile(filepath.Join(path, "sub", "b.txt"), []byte("file b"), 0600)).To(BeNil())
+
+		buf := bytes.NewBuffer(nil)
+		Expect(WriteSnapshot(path, buf)).To(BeNil())
+
+		restored, err := ReadSnapshot(buf)
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(filepath.Dir(restored))
+		Expect(restored).ToNot(Equal(path))
+		Expect(filepath.Base(restored)).To(Equal("repo1"))
+
+		bz, err := ioutil.ReadFile(filepath.Join(restored, "a.txt"))
+		Expect(err).To(BeNil())
+		Expect(string(bz)).To(Equal("file a"))
+		bz, err = ioutil.ReadFile(filepath.Join(restored, "sub", "b.txt"))
+		Expect(err).To(BeNil())
+		Expect(string(bz)).To(Equal("file b"))
+	})
+
+	It("should return ErrInvalidSnapshot if snapshot is not a gzip archive", func() {
+		_, err := ReadSnapshot(bytes.NewBufferString("not a snapshot"))
+		Expect(err).To(Equal(ErrInvalidSnapshot))
+	})
+
+	It("should return ErrInvalidSnapshot if an entry is outside the repository directory", func() {
+		buf := bytes.NewBuffer(nil)
+		gw := gzip.NewWriter(buf)
+		tw := tar.NewWriter(gw)
+		Expect(tw.WriteHeader(&tar.Header{Name: "../evil.txt", Typeflag: tar.TypeReg, Mode: 0644})).To(BeNil())
+		Expect(tw.Close()).To(BeNil())
+		Expect(gw.Close()).To(BeNil())
+		_, err := ReadSnapshot(buf)
+		Expect(err).To(Equal(ErrInvalidSnapshot))
+	})
+})
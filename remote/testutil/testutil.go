@@ -1,13 +1,20 @@
 package testutil
 
 import (
+	"encoding/pem"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/bitfield/script"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/make-os/kit/crypto/ed25519"
 )
 
 var GitEnv = os.Environ()
@@ -47,6 +54,101 @@ func AppendCommit(path, file, fileData, commitMsg string) {
 	ExecGitCommit(path, commitMsg)
 }
 
+// AppendSignedCommit creates a commit and signs it with the given push key.
+// The signature is stored as a PEM block in the commit's signature header.
+func AppendSignedCommit(path, file, fileData, commitMsg string, key *ed25519.Key) {
+	AppendCommit(path, file, fileData, commitMsg)
+
+	r, err := git.PlainOpen(path)
+	if err != nil {
+		panic(err)
+	}
+	head, err := r.Head()
+	if err != nil {
+		panic(err)
+	}
+	commit, err := r.CommitObject(head.Hash())
+	if err != nil {
+		panic(err)
+	}
+
+	encoded := &plumbing.MemoryObject{}
+	if err = commit.EncodeWithoutSignature(encoded); err != nil {
+		panic(err)
+	}
+	rdr, _ := encoded.Reader()
+	payload, _ := ioutil.ReadAll(rdr)
+	commit.PGPSignature = string(pem.EncodeToMemory(&pem.Block{
+		Type:    "SIGNATURE",
+		Headers: map[string]string{"pkID": key.PushAddr().String()},
+		Bytes:   key.PrivKey().MustSign(payload),
+	}))
+
+	obj := r.Storer.NewEncodedObject()
+	if err = commit.Encode(obj); err != nil {
+		panic(err)
+	}
+	hash, err := r.Storer.SetEncodedObject(obj)
+	if err != nil {
+		panic(err)
+	}
+	ExecGit(path, "update-ref", head.Name().String(), hash.String())
+}
+
+// CreateSignedAnnotatedTag creates an annotated tag pointing to HEAD and
+// signs it with the given push key. The signature is a PEM block appended
+// to the tag message; headers are added to the block alongside the push key ID.
+func CreateSignedAnnotatedTag(path, tagName, msg string, key *ed25519.Key, headers map[string]string) {
+	r, err := git.PlainOpen(path)
+	if err != nil {
+		panic(err)
+	}
+	head, err := r.Head()
+	if err != nil {
+		panic(err)
+	}
+
+	// The message must end with a newline to separate it from the signature
+	if !strings.HasSuffix(msg, "\n") {
+		msg += "\n"
+	}
+
+	tag := &object.Tag{
+		Name:       tagName,
+		Tagger:     object.Signature{Name: "tagger", Email: "tagger@example.com", When: time.Now()},
+		Message:    msg,
+		TargetType: plumbing.CommitObject,
+		Target:     head.Hash(),
+	}
+
+	encoded := &plumbing.MemoryObject{}
+	if err = tag.EncodeWithoutSignature(encoded); err != nil {
+		panic(err)
+	}
+	rdr, _ := encoded.Reader()
+	payload, _ := ioutil.ReadAll(rdr)
+
+	pemHeaders := map[string]string{"pkID": key.PushAddr().String()}
+	for k, v := range headers {
+		pemHeaders[k] = v
+	}
+	tag.PGPSignature = string(pem.EncodeToMemory(&pem.Block{
+		Type:    "PGP SIGNATURE",
+		Headers: pemHeaders,
+		Bytes:   key.PrivKey().MustSign(payload),
+	}))
+
+	obj := r.Storer.NewEncodedObject()
+	if err = tag.Encode(obj); err != nil {
+		panic(err)
+	}
+	hash, err := r.Storer.SetEncodedObject(obj)
+	if err != nil {
+		panic(err)
+	}
+	ExecGit(path, "update-ref", plumbing.NewTagReferenceName(tagName).String(), hash.String())
+}
+
 func AppendDirAndCommitFile(path, targetDir, file, fileData, commitMsg string) {
 	ExecAnyCmd(path, "mkdir", targetDir)
 	AppendToFile(path, filepath.Join(targetDir, file), fileData)
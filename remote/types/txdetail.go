@@ -2,6 +2,7 @@ package types
 
 import (
 	"bytes"
+	"time"
 
 	"github.com/make-os/kit/remote/plumbing"
 	"github.com/make-os/kit/util"
@@ -104,6 +105,12 @@ func (rd *ReferenceData) ToMap() map[string]interface{} {
 	return util.ToMap(rd)
 }
 
+// ReservedTxDetailMetaKeys are the names of the TxDetail fields;
+// They cannot be used as keys of a TxDetail's metadata.
+var ReservedTxDetailMetaKeys = []string{
+	"repo", "namespace", "reference", "fee", "value", "nonce", "pkID", "sig", "mergeID", "head", "meta", "expiresAt",
+}
+
 // TxDetail represents transaction information required to generate
 // a network transaction for updating a repository. It includes
 // basic network transaction fields, flags and post validation
@@ -121,6 +128,15 @@ type TxDetail struct {
 	MergeProposalID string      `json:"mergeID" msgpack:"mergeID,omitempty" mapstructure:"mergeID"`       // Specifies a merge proposal that the push is meant to fulfil
 	Head            string      `json:"head" msgpack:"head,omitempty" mapstructure:"head"`                // Indicates the [tip] hash of the target reference
 
+	// Meta contains extra information (e.g client version, CI run id) about the
+	// transaction detail. It is covered by the signature of the transaction detail.
+	Meta map[string]string `json:"meta,omitempty" msgpack:"meta,omitempty" mapstructure:"meta"`
+
+	// ExpiresAt is the unix time after which the transaction detail is no longer
+	// accepted. It is covered by the signature of the transaction detail.
+	// Zero means the transaction detail does not expire.
+	ExpiresAt int64 `json:"expiresAt,omitempty" msgpack:"expiresAt,omitempty" mapstructure:"expiresAt"`
+
 	// FlagCheckAdminUpdatePolicy indicate the pusher's intention to perform admin update
 	// operation that will require an admin update policy specific to the reference
 	FlagCheckAdminUpdatePolicy bool `json:"-" msgpack:"-" mapstructure:"-"`
@@ -144,6 +160,11 @@ func (t *TxDetail) GetReferenceData() *ReferenceData {
 	return t.ReferenceData
 }
 
+// IsExpired checks whether the transaction detail has expired at the given time
+func (t *TxDetail) IsExpired(now time.Time) bool {
+	return t.ExpiresAt > 0 && now.Unix() >= t.ExpiresAt
+}
+
 // SignatureToByte returns the signature as byte.
 // Panics if signature could not be decoded.
 func (t *TxDetail) SignatureToByte() []byte {
@@ -198,7 +219,9 @@ func (t *TxDetail) EncodeMsgpack(enc *msgpack.Encoder) error {
 		t.PushKeyID,
 		sig,
 		t.MergeProposalID,
-		t.Head)
+		t.Head,
+		t.Meta,
+		t.ExpiresAt)
 }
 
 func (t *TxDetail) DecodeMsgpack(dec *msgpack.Decoder) (err error) {
@@ -213,7 +236,9 @@ func (t *TxDetail) DecodeMsgpack(dec *msgpack.Decoder) (err error) {
 		&t.PushKeyID,
 		&sig,
 		&t.MergeProposalID,
-		&t.Head)
+		&t.Head,
+		&t.Meta,
+		&t.ExpiresAt)
 	t.Signature = base58.Encode(sig)
 	return
 }
@@ -0,0 +1,37 @@
+package validation
+
+import (
+	"fmt"
+
+	"github.com/make-os/kit/remote/types"
+	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/state"
+	"github.com/pkg/errors"
+)
+
+// ErrReadAccessDenied is returned when a requester is not permitted
+// to read a private repository
+var ErrReadAccessDenied = fmt.Errorf("not permitted to read the repository")
+
+// CheckReadAccess validates the push token of a requester and checks whether
+// its signer is permitted to read a private repository. Owners of the
+// repository and requesters whose address or push key is in the
+// repository's access allow-list are permitted.
+func CheckReadAccess(
+	txDetail *types.TxDetail,
+	repoState *state.Repository,
+	keepers core.Keepers,
+	checkTxDetail TxDetailChecker) error {
+
+	if err := checkTxDetail(txDetail, keepers, 0); err != nil {
+		return errors.Wrap(err, "token error")
+	}
+
+	pushKey := keepers.PushKeyKeeper().Get(txDetail.PushKeyID)
+	address := pushKey.Address.String()
+	if repoState.Owners.Has(address) || repoState.Config.Access.IsAllowed(address, txDetail.PushKeyID) {
+		return nil
+	}
+
+	return ErrReadAccessDenied
+}
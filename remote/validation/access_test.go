@@ -0,0 +1,95 @@
+package validation_test
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/golang/mock/gomock"
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/crypto/ed25519"
+	"github.com/make-os/kit/mocks"
+	"github.com/make-os/kit/remote/types"
+	"github.com/make-os/kit/remote/validation"
+	"github.com/make-os/kit/testutil"
+	"github.com/make-os/kit/types/core"
+	"github.com/make-os/kit/types/state"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func testCheckTxDetail(err error) validation.TxDetailChecker {
+	return func(params *types.TxDetail, keepers core.Keepers, index int) error { return err }
+}
+
+var _ = Describe("Access", func() {
+	var err error
+	var cfg *config.AppConfig
+	var key, key2 *ed25519.Key
+	var ctrl *gomock.Controller
+	var mockLogic *mocks.MockLogic
+	var mockPushKeyKeeper *mocks.MockPushKeyKeeper
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		cfg, err = testutil.SetTestCfg()
+		Expect(err).To(BeNil())
+
+		key = ed25519.NewKeyFromIntSeed(1)
+		key2 = ed25519.NewKeyFromIntSeed(2)
+
+		mockObjs := testutil.Mocks(ctrl)
+		mockLogic = mockObjs.Logic
+		mockPushKeyKeeper = mockObjs.PushKeyKeeper
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		err = os.RemoveAll(cfg.DataDir())
+		Expect(err).To(BeNil())
+	})
+
+	Describe(".CheckReadAccess", func() {
+		var txDetail *types.TxDetail
+		var repoState *state.Repository
+
+		BeforeEach(func() {
+			txDetail = &types.TxDetail{PushKeyID: key.PushAddr().String()}
+			repoState = state.BareRepository()
+			repoState.Config.Access = &state.RepoAccess{Private: true}
+		})
+
+		It("should return error when push token is not valid", func() {
+			err := validation.CheckReadAccess(txDetail, repoState, mockLogic, testCheckTxDetail(fmt.Errorf("bad token")))
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("token error: bad token"))
+		})
+
+		It("should return ErrReadAccessDenied when signer is not an owner and not in the allow-list", func() {
+			mockPushKeyKeeper.EXPECT().Get(txDetail.PushKeyID).Return(&state.PushKey{Address: key.Addr()})
+			repoState.Config.Access.Allowed = []string{key2.Addr().String(), key2.PushAddr().String()}
+			err := validation.CheckReadAccess(txDetail, repoState, mockLogic, testCheckTxDetail(nil))
+			Expect(err).To(Equal(validation.ErrReadAccessDenied))
+		})
+
+		It("should return nil when signer is an owner of the repository", func() {
+			mockPushKeyKeeper.EXPECT().Get(txDetail.PushKeyID).Return(&state.PushKey{Address: key.Addr()})
+			repoState.AddOwner(key.Addr().String(), &state.RepoOwner{})
+			err := validation.CheckReadAccess(txDetail, repoState, mockLogic, testCheckTxDetail(nil))
+			Expect(err).To(BeNil())
+		})
+
+		It("should return nil when signer address is in the allow-list", func() {
+			mockPushKeyKeeper.EXPECT().Get(txDetail.PushKeyID).Return(&state.PushKey{Address: key.Addr()})
+			repoState.Config.Access.Allowed = []string{key.Addr().String()}
+			err := validation.CheckReadAccess(txDetail, repoState, mockLogic, testCheckTxDetail(nil))
+			Expect(err).To(BeNil())
+		})
+
+		It("should return nil when signer push key is in the allow-list", func() {
+			mockPushKeyKeeper.EXPECT().Get(txDetail.PushKeyID).Return(&state.PushKey{Address: key.Addr()})
+			repoState.Config.Access.Allowed = []string{key.PushAddr().String()}
+			err := validation.CheckReadAccess(txDetail, repoState, mockLogic, testCheckTxDetail(nil))
+			Expect(err).To(BeNil())
+		})
+	})
+})
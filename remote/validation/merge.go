@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/make-os/kit/logic/contracts/mergerequest"
+	"github.com/make-os/kit/params"
 	plumbing2 "github.com/make-os/kit/remote/plumbing"
 	"github.com/make-os/kit/types/constants"
 	"github.com/make-os/kit/types/core"
@@ -70,5 +71,34 @@ func CheckMergeCompliance(
 		return fmt.Errorf("merge error: pushed commit did not match merge proposal target hash")
 	}
 
+	// Ensure the pushed commit builds on the proposal's base hash
+	var propBaseHash = string(prop.ActionData[constants.ActionDataKeyBaseHash])
+	if err := checkMergeHistory(repo, propTargetHash, propBaseHash, params.MergeHistoryDepth); err != nil {
+		return err
+	}
+
 	return nil
 }
+
+// checkMergeHistory checks whether the base hash is the target hash or one of
+// its ancestors. To bound the work done, only the first depth ancestors of the
+// target hash are walked. The check is skipped if depth is zero or the
+// base hash is unset.
+func checkMergeHistory(repo plumbing2.LocalRepo, targetHash, baseHash string, depth int) error {
+	if depth <= 0 || baseHash == "" || baseHash == targetHash {
+		return nil
+	}
+
+	ancestors, err := repo.GetCommitAncestors(targetHash, depth)
+	if err != nil {
+		return fmt.Errorf("merge error: failed to get ancestors of pushed commit: %s", err)
+	}
+
+	for _, ancestor := range ancestors {
+		if ancestor.Hash == baseHash {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("merge error: merge proposal base hash is not within %d ancestors of the pushed commit", depth)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/make-os/kit/config"
 	mr "github.com/make-os/kit/logic/contracts/mergerequest"
 	"github.com/make-os/kit/mocks"
+	"github.com/make-os/kit/params"
 	plumbing2 "github.com/make-os/kit/remote/plumbing"
 	"github.com/make-os/kit/remote/validation"
 	"github.com/make-os/kit/testutil"
@@ -260,6 +261,7 @@ var _ = Describe("Merge", func() {
 				repo.EXPECT().GetState().Return(repoState)
 				mockPushKeyKeeper.EXPECT().Get("push_key_id").Return(&state.PushKey{})
 				mockRepoKeeper.EXPECT().IsProposalClosed("repo1", mr.MakeMergeRequestProposalID("1")).Return(false, nil)
+				repo.EXPECT().GetCommitAncestors("000hash", params.MergeHistoryDepth).Return([]*plumbing2.CommitResult{{Hash: "abc"}}, nil)
 				change := &plumbing2.ItemChange{Item: &plumbing2.Obj{Name: "refs/heads/master", Data: "000hash"}}
 				err = validation.CheckMergeCompliance(repo, change, "1", "push_key_id", mockLogic)
 			})
@@ -268,5 +270,64 @@ var _ = Describe("Merge", func() {
 				Expect(err).To(BeNil())
 			})
 		})
+
+		When("checking history of the pushed commit", func() {
+			var repo *mocks.MockLocalRepo
+			var change *plumbing2.ItemChange
+			var depth int
+
+			BeforeEach(func() {
+				depth = params.MergeHistoryDepth
+				params.MergeHistoryDepth = 2
+
+				repo = mocks.NewMockLocalRepo(ctrl)
+				repo.EXPECT().GetName().Return("repo1")
+				repoState := state.BareRepository()
+				prop := state.BareRepoProposal()
+				prop.Outcome = state.ProposalOutcomeAccepted
+				prop.ActionData = map[string]util.Bytes{
+					constants.ActionDataKeyBaseBranch: []byte("master"),
+					constants.ActionDataKeyBaseHash:   []byte("abc"),
+					constants.ActionDataKeyTargetHash: []byte("000hash"),
+				}
+				repoState.Proposals.Add(mr.MakeMergeRequestProposalID("1"), prop)
+				repo.EXPECT().GetState().Return(repoState)
+				mockPushKeyKeeper.EXPECT().Get("push_key_id").Return(&state.PushKey{})
+				mockRepoKeeper.EXPECT().IsProposalClosed("repo1", mr.MakeMergeRequestProposalID("1")).Return(false, nil)
+				change = &plumbing2.ItemChange{Item: &plumbing2.Obj{Name: "refs/heads/master", Data: "000hash"}}
+			})
+
+			AfterEach(func() {
+				params.MergeHistoryDepth = depth
+			})
+
+			It("should return no error when base hash is within the history depth", func() {
+				ancestors := []*plumbing2.CommitResult{{Hash: "parent"}, {Hash: "abc"}}
+				repo.EXPECT().GetCommitAncestors("000hash", 2).Return(ancestors, nil)
+				err = validation.CheckMergeCompliance(repo, change, "1", "push_key_id", mockLogic)
+				Expect(err).To(BeNil())
+			})
+
+			It("should return error when base hash is beyond the history depth", func() {
+				ancestors := []*plumbing2.CommitResult{{Hash: "parent"}, {Hash: "grand_parent"}}
+				repo.EXPECT().GetCommitAncestors("000hash", 2).Return(ancestors, nil)
+				err = validation.CheckMergeCompliance(repo, change, "1", "push_key_id", mockLogic)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("merge error: merge proposal base hash is not within 2 ancestors of the pushed commit"))
+			})
+
+			It("should return error when unable to get ancestors of the pushed commit", func() {
+				repo.EXPECT().GetCommitAncestors("000hash", 2).Return(nil, fmt.Errorf("error"))
+				err = validation.CheckMergeCompliance(repo, change, "1", "push_key_id", mockLogic)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("merge error: failed to get ancestors of pushed commit: error"))
+			})
+
+			It("should not walk history when history depth is zero", func() {
+				params.MergeHistoryDepth = 0
+				err = validation.CheckMergeCompliance(repo, change, "1", "push_key_id", mockLogic)
+				Expect(err).To(BeNil())
+			})
+		})
 	})
 })
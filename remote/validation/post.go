@@ -11,6 +11,7 @@ import (
 	"github.com/make-os/kit/logic/contracts/mergerequest"
 	pl "github.com/make-os/kit/remote/plumbing"
 	"github.com/make-os/kit/remote/types"
+	"github.com/make-os/kit/types/constants"
 	"github.com/make-os/kit/types/core"
 	"github.com/make-os/kit/util"
 	"github.com/make-os/kit/util/crypto"
@@ -24,7 +25,7 @@ var (
 	MaxIssueContentLen        = 1024 * 8 // 8KB
 	MaxIssueTitleLen          = 256
 	ErrCannotWriteToClosedRef = fmt.Errorf("cannot write to a closed reference")
-	mergeReqFields            = []string{"base", "baseHash", "target", "targetHash"}
+	mergeReqFields            = []string{"base", "baseHash", "target", "targetHash", "reviewers"}
 )
 
 // ValidatePostCommitArg contains arguments for ValidatePostCommit
@@ -414,6 +415,12 @@ func CheckMergeRequestPostBodyConsistency(
 
 	repoState := repo.GetState()
 
+	// For new merge request reference, ensure the repo
+	// has not reached its open proposal limit
+	if isNewRef && repoState.HasReachedOpenProposalLimit() {
+		return constants.ErrOpenProposalLimitReached
+	}
+
 	// For old merge request reference:
 	if !isNewRef {
 		// Get the merge request proposal and check if
@@ -511,6 +518,23 @@ func checkMergeRequestPostBodySanity(commit pl.Commit, body map[string]interface
 		return fe(-1, makeField("targetHash", commitHash), "expected a string value")
 	}
 
+	reviewers := obj.Get("reviewers")
+	if !reviewers.IsNil() && !reviewers.IsInterSlice() {
+		return fe(-1, makeField("reviewers", commitHash), "expected a list of string values")
+	}
+
+	// Check reviewers if set.
+	if val := reviewers.InterSlice(); len(val) > 0 {
+		if len(val) > 10 {
+			return fe(-1, makeField("reviewers", commitHash), "too many reviewers; cannot exceed 10")
+		}
+		for i, reviewer := range val {
+			if !util.IsString(reviewer) || !crypto.IsValidPushAddr(reviewer.(string)) {
+				return fe(i, makeField("reviewers", commitHash), "invalid push key ID")
+			}
+		}
+	}
+
 	// Base branch name is required for only new merge request reference
 	if base.String() == "" && isNewRef {
 		return fe(-1, makeField("base", commitHash), "base branch name is required")
@@ -530,6 +554,11 @@ func checkMergeRequestPostBodySanity(commit pl.Commit, body map[string]interface
 		return fe(-1, makeField("target", commitHash), "target branch name is required")
 	}
 
+	// Target branch must not be the base branch
+	if base.String() != "" && base.String() == target.String() {
+		return fe(-1, makeField("target", commitHash), "target branch must differ from base branch")
+	}
+
 	// Target branch hash is required for only new merge request reference
 	th := targetHash.String()
 	if th == "" && isNewRef {
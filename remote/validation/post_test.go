@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/AlekSi/pointer"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/filemode"
 	"github.com/go-git/go-git/v5/plumbing/object"
@@ -660,6 +661,20 @@ var _ = Describe("Validation", func() {
 				Expect(err.Error()).To(MatchRegexp(`"field":"<commit#.*>.targetHash","msg":"expected a string value"`))
 			})
 
+			It("should return error when 'reviewers' is not a list", func() {
+				fm := map[string]interface{}{"title": "title", "reviewers": 123}
+				err := validation.CheckPostBody(mockKeepers, nil, ref, wc, true, fm, []byte{1})
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(MatchRegexp(`"field":"<commit#.*>.reviewers","msg":"expected a list of string values"`))
+			})
+
+			It("should return error when 'reviewers' entry is not a push key", func() {
+				fm := map[string]interface{}{"title": "title", "reviewers": []interface{}{"invalid_key"}}
+				err := validation.CheckPostBody(mockKeepers, nil, ref, wc, true, fm, []byte{1})
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(MatchRegexp(`"field":"<commit#.*>.reviewers","index":"0","msg":"invalid push key ID"`))
+			})
+
 			It("should return error when 'base' branch is unset and merge request reference is new", func() {
 				fm := map[string]interface{}{"title": "title", "base": ""}
 				err := validation.CheckPostBody(mockKeepers, nil, ref, wc, true, fm, []byte{1})
@@ -688,6 +703,13 @@ var _ = Describe("Validation", func() {
 				Expect(err.Error()).To(MatchRegexp(`"field":"<commit#.*>.target","msg":"target branch name is required"`))
 			})
 
+			It("should return error when 'target' branch is the same as the 'base' branch", func() {
+				fm := map[string]interface{}{"title": "title", "base": "master", "baseHash": "7f92315bdc59a859aefd0d932173cd00fd1ec310", "target": "master"}
+				err := validation.CheckPostBody(mockKeepers, nil, ref, wc, true, fm, []byte{1})
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(MatchRegexp(`"field":"<commit#.*>.target","msg":"target branch must differ from base branch"`))
+			})
+
 			It("should return error when 'targetHash' is unsetand merge request reference is new", func() {
 				fm := map[string]interface{}{"title": "title", "base": "master", "baseHash": "7f92315bdc59a859aefd0d932173cd00fd1ec310", "target": "dev", "targetHash": ""}
 				err := validation.CheckPostBody(mockKeepers, nil, ref, wc, true, fm, []byte{1})
@@ -737,6 +759,29 @@ var _ = Describe("Validation", func() {
 			})
 		})
 
+		When("merge request reference is new", func() {
+			It("should return error when repo has reached its open proposal limit", func() {
+				repoState := state.BareRepository()
+				repoState.Config.Gov.MaxOpenProposals = pointer.ToString("1")
+				repoState.Proposals[mergerequest.MakeMergeRequestProposalID(1)] = &state.RepoProposal{}
+				mockRepo.EXPECT().GetState().Return(repoState)
+				ref := plumbing2.MakeMergeRequestReference(2)
+				err := validation.CheckMergeRequestPostBodyConsistency(mockKeepers, mockRepo, ref, true, map[string]interface{}{})
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError("repo has reached its open proposal limit"))
+			})
+
+			It("should return no error when finalized proposals keep the repo below its open proposal limit", func() {
+				repoState := state.BareRepository()
+				repoState.Config.Gov.MaxOpenProposals = pointer.ToString("1")
+				repoState.Proposals[mergerequest.MakeMergeRequestProposalID(1)] = &state.RepoProposal{Outcome: state.ProposalOutcomeAccepted}
+				mockRepo.EXPECT().GetState().Return(repoState)
+				ref := plumbing2.MakeMergeRequestReference(2)
+				err := validation.CheckMergeRequestPostBodyConsistency(mockKeepers, mockRepo, ref, true, map[string]interface{}{})
+				Expect(err).To(BeNil())
+			})
+		})
+
 		It("should return error if base branch does not exist as a reference", func() {
 			repoState := &state.Repository{}
 			mockRepo.EXPECT().GetState().Return(repoState)
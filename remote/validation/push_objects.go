@@ -2,6 +2,9 @@ package validation
 
 import (
 	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
 
 	"github.com/AlekSi/pointer"
 	"github.com/go-git/go-git/v5/plumbing"
@@ -182,6 +185,10 @@ func CheckPushNoteSanity(note pptyp.PushNote) error {
 		return errors2.FieldError("namespace", "namespace is not valid")
 	}
 
+	if len(note.GetPushedReferences()) > params.MaxPushNoteReferences {
+		return errors2.FieldError("references", "too many references in a single push")
+	}
+
 	for i, ref := range note.GetPushedReferences() {
 		if ref.Name == "" {
 			return fe(i, "references.name", "name is required")
@@ -305,8 +312,19 @@ func CheckPushNoteConsistency(note pptyp.PushNote, logic core.Logic) error {
 		return errors2.FieldError("accountNonce", msg)
 	}
 
-	// Check each references against the state
-	for i, ref := range note.GetPushedReferences() {
+	// Check each references against the state.
+	// References are checked in name order (not submission order) so that
+	// all nodes process them in the same order and return the same error.
+	refs := note.GetPushedReferences()
+	order := make([]int, len(refs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return refs[order[a]].Name < refs[order[b]].Name
+	})
+	checkRef := func(i int) error {
+		ref := refs[i]
 		if err := CheckPushedReferenceConsistency(note.GetTargetRepo(), ref, repo); err != nil {
 			return err
 		}
@@ -318,6 +336,19 @@ func CheckPushNoteConsistency(note pptyp.PushNote, logic core.Logic) error {
 			msg := fmt.Sprintf("reference (%s) signature is not valid", ref.Name)
 			return fe(i, "references", msg)
 		}
+		return nil
+	}
+
+	// References are independent of each other, so a note with many
+	// references can have them checked concurrently.
+	workers := 1
+	if len(refs) > 1 {
+		if cfg := logic.Config(); cfg != nil {
+			workers = cfg.Repo.PushValidationWorkers
+		}
+	}
+	if err := checkInOrder(order, workers, checkRef); err != nil {
+		return err
 	}
 
 	// Check whether the pusher can pay the specified transaction fee
@@ -337,6 +368,59 @@ func CheckPushNoteConsistency(note pptyp.PushNote, logic core.Logic) error {
 	return nil
 }
 
+// checkInOrder calls check for each item of order using up to the given
+// number of concurrent workers. Items are dispatched in order and the error
+// of the earliest failed item is returned, so that the result is the same
+// as when the items are checked sequentially. Items positioned after a
+// failed item may not be checked.
+func checkInOrder(order []int, workers int, check func(i int) error) error {
+	if workers <= 1 || len(order) <= 1 {
+		for _, i := range order {
+			if err := check(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if workers > len(order) {
+		workers = len(order)
+	}
+
+	errs := make([]error, len(order))
+	next, failedAt := int64(-1), int64(len(order))
+	wg := sync.WaitGroup{}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				pos := atomic.AddInt64(&next, 1)
+				if pos >= int64(len(order)) || pos > atomic.LoadInt64(&failedAt) {
+					return
+				}
+				if errs[pos] = check(order[pos]); errs[pos] == nil {
+					continue
+				}
+				for {
+					cur := atomic.LoadInt64(&failedAt)
+					if pos >= cur || atomic.CompareAndSwapInt64(&failedAt, cur, pos) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // CheckPushNoteFunc describes a function for checking a push note
 type CheckPushNoteFunc func(tx pptyp.PushNote, logic core.Logic) error
 
@@ -367,15 +451,21 @@ func CheckEndorsementConsistencyUsingHost(
 			"sender public key does not belong to an active host")
 	}
 
-	// Ensure the BLS signature can be verified using the BLS public key of the selected ticket
-	if !noBLSSigCheck {
-		blsPubKey, err := bdn.BytesToPublicKey(selected.Ticket.BLSPubKey)
-		if err != nil {
-			return errors.Wrap(err, "failed to decode bls public key of endorser")
-		}
-		if err = blsPubKey.Verify(end.SigBLS[:], end.BytesForBLSSig()); err != nil {
-			return fe(index, "endorsements.sig", "signature could not be verified")
+	// Verify the signature according to the signature scheme of the endorsement
+	switch end.GetSigScheme() {
+	case pptyp.SigSchemeBLS:
+		// Ensure the BLS signature can be verified using the BLS public key of the selected ticket
+		if !noBLSSigCheck {
+			blsPubKey, err := bdn.BytesToPublicKey(selected.Ticket.BLSPubKey)
+			if err != nil {
+				return errors.Wrap(err, "failed to decode bls public key of endorser")
+			}
+			if err = blsPubKey.Verify(end.SigBLS[:], end.BytesForBLSSig()); err != nil {
+				return fe(index, "endorsements.sig", "signature could not be verified")
+			}
 		}
+	default:
+		return fe(index, "endorsements.sig", "unsupported scheme")
 	}
 
 	return nil
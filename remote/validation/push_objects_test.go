@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"testing"
 	"time"
 
 	"github.com/AlekSi/pointer"
@@ -12,6 +13,7 @@ import (
 	"github.com/make-os/kit/config"
 	"github.com/make-os/kit/crypto/ed25519"
 	"github.com/make-os/kit/mocks"
+	"github.com/make-os/kit/params"
 	plumbing2 "github.com/make-os/kit/remote/plumbing"
 	"github.com/make-os/kit/remote/push/types"
 	"github.com/make-os/kit/remote/validation"
@@ -23,6 +25,7 @@ import (
 	crypto2 "github.com/make-os/kit/util/crypto"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/thoas/go-funk"
 )
 
 var _ = Describe("Validation", func() {
@@ -72,12 +75,14 @@ var _ = Describe("Validation", func() {
 		oldHash := util.RandString(40)
 		pkID := util.RandBytes(20)
 		now := time.Now().Unix()
+		tooManyRefs := make([]*types.PushedReference, params.MaxPushNoteReferences+1)
 
 		var cases = [][]interface{}{
 			{&types.Note{}, `"field":"repo","msg":"repo name is required"`},
 			{&types.Note{RepoName: "repo"}, `"field":"pusherKeyId","msg":"push key id is required"`},
 			{&types.Note{RepoName: "re*&po"}, `"field":"repo","msg":"repo name is not valid"`},
 			{&types.Note{RepoName: "repo", Namespace: "*&ns"}, `"field":"namespace","msg":"namespace is not valid"`},
+			{&types.Note{RepoName: "repo", References: tooManyRefs}, `"field":"references","msg":"too many references in a single push"`},
 			{&types.Note{RepoName: "repo", PushKeyID: []byte("xyz")}, `"field":"pusherKeyId","msg":"push key id is not valid"`},
 			{&types.Note{RepoName: "repo", PushKeyID: pkID, Timestamp: 0}, `"field":"timestamp","msg":"timestamp is required"`},
 			{&types.Note{RepoName: "repo", PushKeyID: pkID, Timestamp: now}, `"field":"accountNonce","msg":"account nonce must be greater than zero"`},
@@ -458,6 +463,57 @@ var _ = Describe("Validation", func() {
 			})
 		})
 
+		When("multiple references are invalid", func() {
+			check := func(refNames ...string) error {
+				tx := &types.Note{RepoName: "repo1", PushKeyID: util.RandBytes(20), PusherAddress: "address1", PusherAcctNonce: 2}
+				for _, name := range refNames {
+					tx.References = append(tx.References, &types.PushedReference{Name: name, OldHash: fmt.Sprintf("%x", util.RandBytes(20)), Nonce: 1})
+				}
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(&state.Repository{Balance: "10", References: map[string]*state.Reference{}})
+				pushKey := state.BarePushKey()
+				pushKey.Address = "address1"
+				mockPushKeyKeeper.EXPECT().Get(ed25519.BytesToPushKeyID(tx.PushKeyID)).Return(pushKey)
+				acct := state.NewBareAccount()
+				acct.Nonce = 1
+				mockAcctKeeper.EXPECT().Get(tx.PusherAddress).Return(acct)
+				mockLogic.EXPECT().Config().Return(cfg).AnyTimes()
+				return validation.CheckPushNoteConsistency(tx, mockLogic)
+			}
+
+			It("should return the same error regardless of the reference order", func() {
+				err1 := check("refs/heads/dev", "refs/heads/abc", "refs/heads/master")
+				err2 := check("refs/heads/master", "refs/heads/dev", "refs/heads/abc")
+				Expect(err1).ToNot(BeNil())
+				Expect(err1.Error()).To(Equal(`"field":"references","msg":"reference 'refs/heads/abc' is unknown"`))
+				Expect(err2).To(Equal(err1))
+			})
+		})
+
+		When("references are checked concurrently", func() {
+			check := func(workers int) error {
+				cfg.Repo.PushValidationWorkers = workers
+				tx := makeMultiRefNote(privKey, 20, "refs/heads/b07", "refs/heads/b03", "refs/heads/b15")
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(&state.Repository{Balance: "10", References: map[string]*state.Reference{}})
+				pushKey := state.BarePushKey()
+				pushKey.Address = tx.PusherAddress
+				pushKey.PubKey = privKey.PubKey().ToPublicKey()
+				mockPushKeyKeeper.EXPECT().Get(ed25519.BytesToPushKeyID(tx.PushKeyID)).Return(pushKey)
+				acct := state.NewBareAccount()
+				acct.Nonce = 1
+				mockAcctKeeper.EXPECT().Get(tx.PusherAddress).Return(acct)
+				mockLogic.EXPECT().Config().Return(cfg).AnyTimes()
+				return validation.CheckPushNoteConsistency(tx, mockLogic)
+			}
+
+			It("should return the error of the first invalid reference keyed by its index", func() {
+				for _, workers := range []int{0, 1, 4, 50} {
+					err := check(workers)
+					Expect(err).ToNot(BeNil())
+					Expect(err.Error()).To(Equal(`"field":"references","index":"16","msg":"reference (refs/heads/b03) signature is not valid"`))
+				}
+			})
+		})
+
 		When("pusher account balance not sufficient to pay fee", func() {
 			BeforeEach(func() {
 
@@ -670,5 +726,108 @@ var _ = Describe("Validation", func() {
 				Expect(err).To(BeNil())
 			})
 		})
+
+		When("BLS signature is valid", func() {
+			BeforeEach(func() {
+				key := ed25519.NewKeyFromIntSeed(1)
+				ticket := &tickettypes.Ticket{ProposerPubKey: key.PubKey().MustBytes32(), BLSPubKey: key.PrivKey().BLSKey().Public().Bytes()}
+				mockTickMgr.EXPECT().GetTopHosts(gomock.Any()).Return([]*tickettypes.SelectedTicket{{Ticket: ticket}}, nil)
+				end := &types.PushEndorsement{NoteID: []byte("id"), EndorserPubKey: key.PubKey().MustBytes32(), SigScheme: types.SigSchemeBLS}
+				end.SigBLS, err = key.PrivKey().BLSKey().Sign(end.BytesForBLSSig())
+				Expect(err).To(BeNil())
+				err = validation.CheckEndorsementConsistency(end, mockLogic, false, -1)
+			})
+
+			It("should return nil", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("signature scheme is not set and the BLS signature is valid", func() {
+			BeforeEach(func() {
+				key := ed25519.NewKeyFromIntSeed(1)
+				ticket := &tickettypes.Ticket{ProposerPubKey: key.PubKey().MustBytes32(), BLSPubKey: key.PrivKey().BLSKey().Public().Bytes()}
+				mockTickMgr.EXPECT().GetTopHosts(gomock.Any()).Return([]*tickettypes.SelectedTicket{{Ticket: ticket}}, nil)
+				end := &types.PushEndorsement{NoteID: []byte("id"), EndorserPubKey: key.PubKey().MustBytes32()}
+				end.SigBLS, err = key.PrivKey().BLSKey().Sign(end.BytesForBLSSig())
+				Expect(err).To(BeNil())
+				err = validation.CheckEndorsementConsistency(end, mockLogic, false, -1)
+			})
+
+			It("should verify the signature using the BLS scheme", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("signature scheme is unknown", func() {
+			BeforeEach(func() {
+				key := ed25519.NewKeyFromIntSeed(1)
+				ticket := &tickettypes.Ticket{ProposerPubKey: key.PubKey().MustBytes32(), BLSPubKey: key.PrivKey().BLSKey().Public().Bytes()}
+				mockTickMgr.EXPECT().GetTopHosts(gomock.Any()).Return([]*tickettypes.SelectedTicket{{Ticket: ticket}}, nil)
+				end := &types.PushEndorsement{NoteID: []byte("id"), EndorserPubKey: key.PubKey().MustBytes32(), SigScheme: "unknown"}
+				err = validation.CheckEndorsementConsistency(end, mockLogic, true, -1)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal(`"field":"endorsements.sig","msg":"unsupported scheme"`))
+			})
+		})
 	})
 })
+
+// makeMultiRefNote creates a push note with n references signed by key.
+// References are added in reverse name order and the references named in
+// badSigRefs are given an invalid signature.
+func makeMultiRefNote(key *ed25519.Key, n int, badSigRefs ...string) *types.Note {
+	note := &types.Note{RepoName: "repo1", PushKeyID: util.RandBytes(20), PusherAddress: key.Addr(), PusherAcctNonce: 2}
+	for i := n - 1; i >= 0; i-- {
+		note.References = append(note.References, &types.PushedReference{Name: fmt.Sprintf("refs/heads/b%02d", i), Nonce: 1})
+	}
+	for _, ref := range note.References {
+		ref.PushSig = util.RandBytes(64)
+		if !funk.ContainsString(badSigRefs, ref.Name) {
+			detail := validation.GetTxDetailsFromNote(note, ref.Name)[0]
+			ref.PushSig, _ = key.PrivKey().Sign(detail.BytesNoSig())
+		}
+	}
+	return note
+}
+
+func benchmarkCheckPushNoteConsistency(b *testing.B, workers int) {
+	ctrl := gomock.NewController(b)
+	defer ctrl.Finish()
+	mockObjs := testutil.Mocks(ctrl)
+	cfg := config.EmptyAppConfig()
+	cfg.Repo.PushValidationWorkers = workers
+	mockObjs.Logic.EXPECT().Config().Return(cfg).AnyTimes()
+
+	key := ed25519.NewKeyFromIntSeed(1)
+	note := makeMultiRefNote(key, 200)
+	repo := &state.Repository{Balance: "10", References: map[string]*state.Reference{}}
+	pushKey := state.BarePushKey()
+	pushKey.Address = note.PusherAddress
+	pushKey.PubKey = key.PubKey().ToPublicKey()
+	acct := state.NewBareAccount()
+	acct.Nonce = 1
+	mockObjs.RepoKeeper.EXPECT().Get(note.RepoName).Return(repo).AnyTimes()
+	mockObjs.PushKeyKeeper.EXPECT().Get(gomock.Any()).Return(pushKey).AnyTimes()
+	mockObjs.AccountKeeper.EXPECT().Get(note.PusherAddress).Return(acct).AnyTimes()
+	mockObjs.SysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 1}, nil).AnyTimes()
+	mockObjs.Logic.EXPECT().DrySend(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := validation.CheckPushNoteConsistency(note, mockObjs.Logic); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCheckPushNoteConsistencySequential(b *testing.B) {
+	benchmarkCheckPushNoteConsistency(b, 1)
+}
+
+func BenchmarkCheckPushNoteConsistencyConcurrent(b *testing.B) {
+	benchmarkCheckPushNoteConsistency(b, 4)
+}
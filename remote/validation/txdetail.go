@@ -2,6 +2,7 @@ package validation
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/asaskevich/govalidator"
 	"github.com/make-os/kit/crypto/ed25519"
@@ -14,6 +15,7 @@ import (
 	crypto2 "github.com/make-os/kit/util/crypto"
 	"github.com/make-os/kit/util/identifier"
 	"github.com/mr-tron/base58"
+	"github.com/thoas/go-funk"
 )
 
 // TxDetailChecker describes a function for checking a transaction detail
@@ -64,6 +66,13 @@ func CheckTxDetailSanity(params *types.TxDetail, index int) error {
 		return fe(index, "sig", "signature format is not valid")
 	}
 
+	// Metadata must not include reserved keys
+	for key := range params.Meta {
+		if funk.ContainsString(types.ReservedTxDetailMetaKeys, key) {
+			return fe(index, "meta", fmt.Sprintf("key (%s) is reserved", key))
+		}
+	}
+
 	// Merge proposal, if set, must be numeric and have 8 bytes length max.
 	if params.MergeProposalID != "" {
 		return CheckMergeProposalID(params.MergeProposalID, index)
@@ -132,5 +141,10 @@ func CheckTxDetailConsistency(txd *types.TxDetail, keepers core.Keepers, index i
 		return fe(index, "sig", "signature is not valid")
 	}
 
+	// Reject the transaction detail if it has expired
+	if txd.IsExpired(time.Now()) {
+		return fe(index, "expiresAt", "push token has expired")
+	}
+
 	return nil
 }
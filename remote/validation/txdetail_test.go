@@ -2,6 +2,7 @@ package validation_test
 
 import (
 	"os"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/make-os/kit/config"
@@ -127,6 +128,19 @@ var _ = Describe("TxDetail", func() {
 			Expect(err.Error()).To(Equal(`"field":"sig","index":"0","msg":"signature format is not valid"`))
 		})
 
+		It("should return error when metadata includes a reserved key", func() {
+			detail := &types.TxDetail{
+				PushKeyID: privKey.PushAddr().String(),
+				Nonce:     1,
+				Fee:       "1",
+				Signature: base58.Encode([]byte("data")),
+				Meta:      map[string]string{"ciRunId": "1", "fee": "0"},
+			}
+			err := validation.CheckTxDetailSanity(detail, 0)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal(`"field":"meta","index":"0","msg":"key (fee) is reserved"`))
+		})
+
 		It("should return error when merge proposal ID is not numeric", func() {
 			detail := &types.TxDetail{
 				PushKeyID:       privKey.PushAddr().String(),
@@ -371,6 +385,58 @@ var _ = Describe("TxDetail", func() {
 			err = validation.CheckTxDetailConsistency(detail, mockLogic, 0)
 			Expect(err).To(BeNil())
 		})
+
+		When("push token has an expiry time", func() {
+			checkWithExpiry := func(expiresAt int64) error {
+				detail := &types.TxDetail{PushKeyID: privKey.PushAddr().String(), Nonce: 9, ExpiresAt: expiresAt}
+				sig, err := privKey.PrivKey().Sign(detail.BytesNoSig())
+				Expect(err).To(BeNil())
+				detail.Signature = base58.Encode(sig)
+
+				pk := state.BarePushKey()
+				pk.Address = privKey.Addr()
+				pk.PubKey = privKey.PubKey().ToPublicKey()
+				mockPushKeyKeeper.EXPECT().Get(detail.PushKeyID).Return(pk)
+
+				acct := state.NewBareAccount()
+				acct.Nonce = 8
+				mockAcctKeeper.EXPECT().Get(pk.Address).Return(acct)
+
+				return validation.CheckTxDetailConsistency(detail, mockLogic, 0)
+			}
+
+			It("should return nil when push token has not expired", func() {
+				err = checkWithExpiry(time.Now().Add(time.Hour).Unix())
+				Expect(err).To(BeNil())
+			})
+
+			It("should return error when push token has expired", func() {
+				err = checkWithExpiry(time.Now().Add(-time.Second).Unix())
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal(`"field":"expiresAt","index":"0","msg":"push token has expired"`))
+			})
+
+			It("should return error when expiry time was changed after signing", func() {
+				detail := &types.TxDetail{PushKeyID: privKey.PushAddr().String(), Nonce: 9, ExpiresAt: time.Now().Unix()}
+				sig, err := privKey.PrivKey().Sign(detail.BytesNoSig())
+				Expect(err).To(BeNil())
+				detail.Signature = base58.Encode(sig)
+				detail.ExpiresAt = time.Now().Add(time.Hour).Unix()
+
+				pk := state.BarePushKey()
+				pk.Address = privKey.Addr()
+				pk.PubKey = privKey.PubKey().ToPublicKey()
+				mockPushKeyKeeper.EXPECT().Get(detail.PushKeyID).Return(pk)
+
+				acct := state.NewBareAccount()
+				acct.Nonce = 8
+				mockAcctKeeper.EXPECT().Get(pk.Address).Return(acct)
+
+				err = validation.CheckTxDetailConsistency(detail, mockLogic, 0)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal(`"field":"sig","index":"0","msg":"signature is not valid"`))
+			})
+		})
 	})
 
 })
@@ -1,10 +1,14 @@
 package validation
 
 import (
+	"encoding/pem"
 	"fmt"
+	"io/ioutil"
+	"regexp"
 	"strings"
 
 	"github.com/asaskevich/govalidator"
+	"github.com/make-os/kit/crypto/ed25519"
 	plumbing2 "github.com/make-os/kit/remote/plumbing"
 	"github.com/make-os/kit/remote/types"
 	"github.com/make-os/kit/types/core"
@@ -21,6 +25,13 @@ import (
 var (
 	fe                             = errors2.FieldErrorWithIndex
 	ErrPushedAndSignedHeadMismatch = fmt.Errorf("pushed object hash differs from signed reference hash")
+	ErrCommitNotSigned             = fmt.Errorf("commit is not signed")
+	ErrTagNotSigned                = fmt.Errorf("tag is not signed")
+	ErrTagAlreadyExists            = fmt.Errorf("tag already exists")
+
+	// conventionalCommitRe matches the header of a conventional commit message
+	// e.g "feat(parser): add support for arrays" or "fix!: drop node 6 support"
+	conventionalCommitRe = regexp.MustCompile(`^[a-zA-Z]+(\([^()\r\n]+\))?!?: \S.*`)
 )
 
 type ChangeValidatorFunc func(
@@ -68,6 +79,21 @@ func ValidateChange(
 
 	// Handle regular branch validation
 	if plumbing2.IsBranch(refname) {
+		commit, err := localRepo.CommitObject(plumbing.NewHash(change.Item.GetData()))
+		if err != nil {
+			return errors.Wrap(err, "unable to get commit object")
+		}
+		if err = CheckCommit(commit, detail, getPushKey); err != nil {
+			return err
+		}
+		if err = CheckCommitSignatures(localRepo, refname, commit, oldHash, getPushKey); err != nil {
+			return err
+		}
+		return CheckCommitMessages(localRepo, commit, oldHash)
+	}
+
+	// Handle meta reference validation
+	if plumbing2.IsMetaReference(refname) {
 		commit, err := localRepo.CommitObject(plumbing.NewHash(change.Item.GetData()))
 		if err != nil {
 			return errors.Wrap(err, "unable to get commit object")
@@ -77,6 +103,18 @@ func ValidateChange(
 
 	// Handle tag validation
 	if plumbing2.IsTag(change.Item.GetName()) {
+		// Reject attempt to re-point an existing tag unless the repo allows it
+		// or the tag's creator is still within the tag grace period.
+		if oldHash != "" && !plumbing2.IsZeroHash(oldHash) && !isTagUpdateAllowed(localRepo) {
+			inGrace, err := isTagInGracePeriod(keepers, localRepo, refname, detail.PushKeyID)
+			if err != nil {
+				return err
+			}
+			if !inGrace {
+				return ErrTagAlreadyExists
+			}
+		}
+
 		tagRef, err := localRepo.Tag(strings.ReplaceAll(change.Item.GetName(), "refs/tags/", ""))
 		if err != nil {
 			return errors.Wrap(err, "unable to get tag object")
@@ -91,16 +129,26 @@ func ValidateChange(
 		// Here, the tag is not an annotated tag, so we need to
 		// ensure the referenced commit is signed correctly
 		if tagObj == nil {
-			commit, err := localRepo.CommitObject(tagRef.Hash())
-			if err != nil {
-				return errors.Wrap(err, "unable to get commit")
+			if err = CheckLightweightTag(localRepo, tagRef, detail, getPushKey); err != nil {
+				return err
 			}
-			return CheckCommit(commit, detail, getPushKey)
+			if !isSignatureRequired(localRepo, refname) {
+				return nil
+			}
+			commit, _ := localRepo.CommitObject(tagRef.Hash())
+			return errors.Wrap(CheckCommitSignature(commit, getPushKey), "tag target")
 		}
 
 		// At this point, the tag is an annotated tag.
 		// We have to ensure the annotated tag object is signed.
-		return CheckAnnotatedTag(tagObj, detail, getPushKey)
+		if err = CheckAnnotatedTag(tagObj, detail, getPushKey); err != nil {
+			return err
+		}
+		if isSignatureRequired(localRepo, refname) {
+			_, err = CheckTagSignature(tagObj, getPushKey)
+			return err
+		}
+		return nil
 	}
 
 	// Handle note validation
@@ -146,6 +194,78 @@ func CheckAnnotatedTag(tag *object.Tag, txDetail *types.TxDetail, _ core.PushKey
 	return nil
 }
 
+// CheckTagSignature verifies the signature of an annotated tag.
+// The signature is a PEM block appended to the tag message whose headers
+// hold the push transaction detail the tag was signed for. The "pkID"
+// header identifies the push key that signed the tag payload.
+// It returns the transaction detail decoded from the signature headers,
+// even when the signature is not valid.
+// tag: The target annotated tag
+// getPushKey: Getter function for fetching push public key
+func CheckTagSignature(tag *object.Tag, getPushKey core.PushKeyGetter) (*types.TxDetail, error) {
+	if tag.PGPSignature == "" {
+		return nil, ErrTagNotSigned
+	}
+
+	block, _ := pem.Decode([]byte(tag.PGPSignature))
+	if block == nil {
+		return nil, fmt.Errorf("unable to decode tag signature")
+	}
+
+	var txDetail = &types.TxDetail{}
+	if err := util.DecodeMap(block.Headers, txDetail); err != nil {
+		return nil, errors.Wrap(err, "unable to decode tag signature header")
+	}
+
+	if txDetail.PushKeyID == "" {
+		return txDetail, fmt.Errorf("tag signature has no push key ID")
+	}
+
+	pubKey, err := getPushKey(txDetail.PushKeyID)
+	if err != nil {
+		return txDetail, errors.Wrapf(err, "failed to get push key (%s)", txDetail.PushKeyID)
+	}
+
+	// Get the tag payload the signature was created for
+	encoded := &plumbing.MemoryObject{}
+	if err = tag.EncodeWithoutSignature(encoded); err != nil {
+		return txDetail, errors.Wrap(err, "failed to encode tag")
+	}
+	rdr, _ := encoded.Reader()
+	payload, err := ioutil.ReadAll(rdr)
+	if err != nil {
+		return txDetail, errors.Wrap(err, "failed to read tag")
+	}
+
+	pk, _ := ed25519.PubKeyFromBytes(pubKey.Bytes())
+	if ok, err := pk.Verify(payload, block.Bytes); err != nil || !ok {
+		return txDetail, fmt.Errorf("tag signature is not valid")
+	}
+
+	return txDetail, nil
+}
+
+// CheckLightweightTag validates a lightweight tag.
+// A lightweight tag has no tag object; it points directly at a commit, so
+// the target commit is validated against the push transaction detail.
+// repo: The repo where the tag exists in.
+// tagRef: The lightweight tag reference
+// txDetail: The pusher transaction detail
+// getPushKey: Getter function for reading push key public key
+func CheckLightweightTag(
+	repo plumbing2.LocalRepo,
+	tagRef *plumbing.Reference,
+	txDetail *types.TxDetail,
+	getPushKey core.PushKeyGetter) error {
+
+	commit, err := repo.CommitObject(tagRef.Hash())
+	if err != nil {
+		return errors.Wrap(err, "unable to get tag target commit")
+	}
+
+	return CheckCommit(commit, txDetail, getPushKey)
+}
+
 // CommitChecker describes a function for checking a standard commit
 type CommitChecker func(commit *object.Commit, txDetail *types.TxDetail, getPushKey core.PushKeyGetter) error
 
@@ -164,23 +284,187 @@ func CheckCommit(commit *object.Commit, txDetail *types.TxDetail, _ core.PushKey
 	return nil
 }
 
-// IsBlockedByScope checks whether the given tx parameter satisfy a given scope
+// CheckCommitSignature verifies the signature of a commit.
+// The signature is a PEM block in the commit's signature header whose
+// "pkID" header identifies the push key that signed the commit payload.
+// commit: The target commit object
+// getPushKey: Getter function for fetching push public key
+func CheckCommitSignature(commit *object.Commit, getPushKey core.PushKeyGetter) error {
+	if commit.PGPSignature == "" {
+		return ErrCommitNotSigned
+	}
+
+	block, _ := pem.Decode([]byte(commit.PGPSignature))
+	if block == nil {
+		return fmt.Errorf("unable to decode commit signature")
+	}
+
+	pushKeyID := block.Headers["pkID"]
+	if pushKeyID == "" {
+		return fmt.Errorf("commit signature has no push key ID")
+	}
+
+	pubKey, err := getPushKey(pushKeyID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get push key (%s)", pushKeyID)
+	}
+
+	// Get the commit payload the signature was created for
+	encoded := &plumbing.MemoryObject{}
+	if err = commit.EncodeWithoutSignature(encoded); err != nil {
+		return errors.Wrap(err, "failed to encode commit")
+	}
+	rdr, _ := encoded.Reader()
+	payload, err := ioutil.ReadAll(rdr)
+	if err != nil {
+		return errors.Wrap(err, "failed to read commit")
+	}
+
+	pk, _ := ed25519.PubKeyFromBytes(pubKey.Bytes())
+	if ok, err := pk.Verify(payload, block.Bytes); err != nil || !ok {
+		return fmt.Errorf("commit signature is not valid")
+	}
+
+	return nil
+}
+
+// isSignatureRequired checks whether the repository's signature
+// policy requires objects pushed to the given reference to be signed
+func isSignatureRequired(repo plumbing2.LocalRepo, refname string) bool {
+	repoState := repo.GetState()
+	return repoState != nil && repoState.Config != nil && repoState.Config.Signature.RequiresSignature(refname)
+}
+
+// isTagUpdateAllowed checks whether the repository allows existing tags to be re-pointed
+func isTagUpdateAllowed(repo plumbing2.LocalRepo) bool {
+	repoState := repo.GetState()
+	return repoState != nil && repoState.Config != nil && repoState.Config.AllowTagUpdate
+}
+
+// isTagInGracePeriod checks whether a tag can still be re-pointed by the pusher
+// because the pusher created it and the repo's tag grace period has not elapsed.
+func isTagInGracePeriod(keepers core.Keepers, repo plumbing2.LocalRepo, refname, pushKeyID string) (bool, error) {
+	repoState := repo.GetState()
+	if repoState == nil || repoState.Config == nil || repoState.Config.TagGracePeriod == 0 {
+		return false, nil
+	}
+
+	ref := repoState.References.Get(refname)
+	if ref.IsNil() || ref.Creator.String() != pushKeyID {
+		return false, nil
+	}
+
+	bi, err := keepers.SysKeeper().GetLastBlockInfo()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to fetch current block info")
+	}
+
+	return uint64(bi.Height) < ref.CreatedAt.UInt64()+repoState.Config.TagGracePeriod, nil
+}
+
+// CheckCommitSignatures checks that the pushed commits of a reference are
+// signed when required by the repository's signature policy. The pushed
+// commits are the commit and its first-parent ancestors up to (but excluding)
+// the commit of oldHash.
+// repo: The target repo
+// refname: The name of the pushed reference
+// commit: The pushed head commit
+// oldHash: The hash of the reference prior to the push
+// getPushKey: Getter function for fetching push public key
+func CheckCommitSignatures(
+	repo plumbing2.LocalRepo,
+	refname string,
+	commit *object.Commit,
+	oldHash string,
+	getPushKey core.PushKeyGetter) error {
+
+	if !isSignatureRequired(repo, refname) {
+		return nil
+	}
+
+	ancestors, err := repo.GetAncestors(commit, oldHash, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to get pushed commits")
+	}
+
+	for _, c := range append([]*object.Commit{commit}, ancestors...) {
+		if err := CheckCommitSignature(c, getPushKey); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("commit (%s)", c.Hash.String()[:7]))
+		}
+	}
+
+	return nil
+}
+
+// CheckCommitMessages checks that the messages of the pushed commits satisfy
+// the repository's commit message policy. The pushed commits are the commit
+// and its first-parent ancestors up to (but excluding) the commit of oldHash.
+// repo: The target repo
+// commit: The pushed head commit
+// oldHash: The hash of the reference prior to the push
+func CheckCommitMessages(repo plumbing2.LocalRepo, commit *object.Commit, oldHash string) error {
+	repoState := repo.GetState()
+	if repoState == nil || repoState.Config == nil || repoState.Config.CommitMsg.IsEmpty() {
+		return nil
+	}
+
+	ancestors, err := repo.GetAncestors(commit, oldHash, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to get pushed commits")
+	}
+
+	for _, c := range append([]*object.Commit{commit}, ancestors...) {
+		if err := CheckCommitMessage(c.Message, repoState.Config.CommitMsg); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("commit (%s)", c.Hash.String()[:7]))
+		}
+	}
+
+	return nil
+}
+
+// CheckCommitMessage checks that msg satisfies the given commit message policy
+func CheckCommitMessage(msg string, policy *state.CommitMsgPolicy) error {
+	if policy.IsEmpty() {
+		return nil
+	}
+
+	msg = strings.TrimSpace(msg)
+
+	if policy.Conventional && !conventionalCommitRe.MatchString(msg) {
+		return fmt.Errorf("commit message does not follow the conventional commits format")
+	}
+
+	if policy.Pattern != "" {
+		re, err := regexp.Compile(policy.Pattern)
+		if err != nil {
+			return errors.Wrap(err, "bad commit message pattern")
+		}
+		if !re.MatchString(msg) {
+			return fmt.Errorf("commit message does not match the required pattern (%s)", policy.Pattern)
+		}
+	}
+
+	return nil
+}
+
+// IsBlockedByScope checks whether the given tx parameter satisfy a given scope.
+// The repo name or domain part of a scope can be a glob pattern (e.g team-*, ns1/team-*).
 func IsBlockedByScope(scopes []string, params *types.TxDetail, namespaceFromParams *state.Namespace) bool {
 	blocked := true
 	for _, scope := range scopes {
-		if identifier.IsNamespaceURI(scope) {
+		if identifier.IsNamespaceURI(scope) || (identifier.IsScopePattern(scope) && strings.Contains(scope, "/")) {
 			ns, domain, _ := util.SplitNamespaceDomain(scope)
 
 			// If scope is r/repo-name, make sure tx info namespace is unset and repo name is 'repo-name'.
 			// If scope is r/ only, make sure only tx info namespace is set
-			if ns == types.DefaultNS && params.RepoNamespace == "" && (domain == "" || domain == params.RepoName) {
+			if ns == types.DefaultNS && params.RepoNamespace == "" && (domain == "" || identifier.MatchScope(domain, params.RepoName)) {
 				blocked = false
 				break
 			}
 
 			// If scope is some_ns/repo-name, make sure tx info namespace and repo name matches the scope
 			// namespace and repo name.
-			if ns != types.DefaultNS && ns == params.RepoNamespace && domain == params.RepoName {
+			if ns != types.DefaultNS && ns == params.RepoNamespace && identifier.MatchScope(domain, params.RepoName) {
 				blocked = false
 				break
 			}
@@ -194,14 +478,14 @@ func IsBlockedByScope(scopes []string, params *types.TxDetail, namespaceFromPara
 
 		// At this point, the scope is just a target repo name.
 		// e.g unblock if tx info namespace is default and the repo name matches the scope
-		if params.RepoNamespace == "" && params.RepoName == scope {
+		if params.RepoNamespace == "" && identifier.MatchScope(scope, params.RepoName) {
 			blocked = false
 			break
 		}
 
 		// But if the scope's repo name is set, ensure the domain target matches the sc
 		if params.RepoNamespace != "" {
-			if target := namespaceFromParams.Domains[params.RepoName]; target != "" && target[2:] == scope {
+			if target := namespaceFromParams.Domains[params.RepoName]; target != "" && identifier.MatchScope(scope, target[2:]) {
 				blocked = false
 				break
 			}
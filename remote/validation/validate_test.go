@@ -142,6 +142,34 @@ var _ = Describe("Validation", func() {
 		})
 	})
 
+	Describe(".CheckLightweightTag", func() {
+		var tagRef *plumbing.Reference
+
+		BeforeEach(func() {
+			testutil2.CreateCommitAndLightWeightTag(path, "file.txt", "first file", "commit message", "v1")
+			tagRef, _ = testRepo.Tag("v1")
+		})
+
+		It("should return err when the target commit does not exist", func() {
+			ref := plumbing.NewHashReference("refs/tags/v2", plumbing.NewHash("unknown_hash"))
+			err = validation.CheckLightweightTag(testRepo, ref, testTxDetail, testPushKeyGetter(pubKey, nil))
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("unable to get tag target commit: object not found"))
+		})
+
+		It("should return err when the target commit hash and the signed head do not match", func() {
+			testTxDetail := &types.TxDetail{PushKeyID: privKey.PushAddr().String(), Head: "hash1"}
+			err = validation.CheckLightweightTag(testRepo, tagRef, testTxDetail, testPushKeyGetter(pubKey, nil))
+			Expect(err).To(Equal(validation.ErrPushedAndSignedHeadMismatch))
+		})
+
+		It("should return nil when the target commit hash and the signed head match", func() {
+			testTxDetail := &types.TxDetail{PushKeyID: privKey.PushAddr().String(), Head: tagRef.Hash().String()}
+			err = validation.CheckLightweightTag(testRepo, tagRef, testTxDetail, testPushKeyGetter(pubKey, nil))
+			Expect(err).To(BeNil())
+		})
+	})
+
 	Describe(".CheckNote", func() {
 		var err error
 
@@ -176,6 +204,143 @@ var _ = Describe("Validation", func() {
 		})
 	})
 
+	Describe(".CheckCommitMessage", func() {
+		It("should return nil when policy is empty", func() {
+			Expect(validation.CheckCommitMessage("anything", &state.CommitMsgPolicy{})).To(BeNil())
+			Expect(validation.CheckCommitMessage("anything", nil)).To(BeNil())
+		})
+
+		It("should return error when message does not follow the conventional commits format", func() {
+			err := validation.CheckCommitMessage("added a feature", &state.CommitMsgPolicy{Conventional: true})
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("commit message does not follow the conventional commits format"))
+		})
+
+		It("should return nil when message follows the conventional commits format", func() {
+			policy := &state.CommitMsgPolicy{Conventional: true}
+			Expect(validation.CheckCommitMessage("feat: add a feature", policy)).To(BeNil())
+			Expect(validation.CheckCommitMessage("fix(parser)!: handle arrays\n\nbody", policy)).To(BeNil())
+		})
+
+		It("should return error when message does not match the pattern", func() {
+			err := validation.CheckCommitMessage("added a feature", &state.CommitMsgPolicy{Pattern: "^JIRA-[0-9]+ "})
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("commit message does not match the required pattern (^JIRA-[0-9]+ )"))
+		})
+
+		It("should return nil when message matches the pattern", func() {
+			Expect(validation.CheckCommitMessage("JIRA-12 added a feature", &state.CommitMsgPolicy{Pattern: "^JIRA-[0-9]+ "})).To(BeNil())
+		})
+	})
+
+	Describe(".CheckCommitSignature", func() {
+		var getCommit = func() *object.Commit {
+			commit, _ := testRepo.CommitObject(plumbing.NewHash(testutil2.GetRecentCommitHash(path, "HEAD")))
+			return commit
+		}
+
+		It("should return ErrCommitNotSigned when commit has no signature", func() {
+			testutil2.AppendCommit(path, "file.txt", "line 1", "commit 1")
+			err = validation.CheckCommitSignature(getCommit(), testPushKeyGetter(pubKey, nil))
+			Expect(err).To(Equal(validation.ErrCommitNotSigned))
+		})
+
+		It("should return nil when commit is signed by the push key", func() {
+			testutil2.AppendSignedCommit(path, "file.txt", "line 1", "commit 1", privKey)
+			err = validation.CheckCommitSignature(getCommit(), testPushKeyGetter(pubKey, nil))
+			Expect(err).To(BeNil())
+		})
+
+		It("should return err when the push key could not be found", func() {
+			testutil2.AppendSignedCommit(path, "file.txt", "line 1", "commit 1", privKey)
+			err = validation.CheckCommitSignature(getCommit(), testPushKeyGetter(nil, fmt.Errorf("push key does not exist")))
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal(fmt.Sprintf("failed to get push key (%s): push key does not exist", privKey.PushAddr())))
+		})
+
+		It("should return err when commit is signed by a different key", func() {
+			testutil2.AppendSignedCommit(path, "file.txt", "line 1", "commit 1", privKey)
+			otherKey := ed25519.NewKeyFromIntSeed(2)
+			err = validation.CheckCommitSignature(getCommit(), testPushKeyGetter(otherKey.PubKey(), nil))
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("commit signature is not valid"))
+		})
+	})
+
+	Describe(".CheckTagSignature", func() {
+		var getTag = func(name string) *object.Tag {
+			ref, _ := testRepo.Tag(name)
+			tag, _ := testRepo.TagObject(ref.Hash())
+			return tag
+		}
+
+		BeforeEach(func() {
+			testutil2.AppendCommit(path, "file.txt", "line 1", "commit 1")
+		})
+
+		It("should return ErrTagNotSigned when tag has no signature", func() {
+			testutil2.ExecGit(path, "tag", "-a", "v1", "-m", "v1")
+			_, err = validation.CheckTagSignature(getTag("v1"), testPushKeyGetter(pubKey, nil))
+			Expect(err).To(Equal(validation.ErrTagNotSigned))
+		})
+
+		It("should return the decoded tx detail when tag is signed by the push key", func() {
+			testutil2.CreateSignedAnnotatedTag(path, "v1", "v1", privKey, map[string]string{"reference": "refs/tags/v1", "nonce": "2", "fee": "1.5"})
+			txDetail, err := validation.CheckTagSignature(getTag("v1"), testPushKeyGetter(pubKey, nil))
+			Expect(err).To(BeNil())
+			Expect(txDetail.PushKeyID).To(Equal(privKey.PushAddr().String()))
+			Expect(txDetail.Reference).To(Equal("refs/tags/v1"))
+			Expect(txDetail.Nonce).To(Equal(uint64(2)))
+			Expect(txDetail.Fee).To(Equal(util.String("1.5")))
+		})
+
+		It("should return err and the decoded tx detail when tag is signed by a different key", func() {
+			testutil2.CreateSignedAnnotatedTag(path, "v1", "v1", privKey, nil)
+			otherKey := ed25519.NewKeyFromIntSeed(2)
+			txDetail, err := validation.CheckTagSignature(getTag("v1"), testPushKeyGetter(otherKey.PubKey(), nil))
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("tag signature is not valid"))
+			Expect(txDetail.PushKeyID).To(Equal(privKey.PushAddr().String()))
+		})
+	})
+
+	Describe(".CheckCommitMessages", func() {
+		var oldHash string
+
+		BeforeEach(func() {
+			testutil2.AppendCommit(path, "file.txt", "line 1", "initial commit")
+			oldHash, _ = testRepo.GetRecentCommitHash()
+			testutil2.AppendCommit(path, "file.txt", "line 2", "feat: add line 2")
+		})
+
+		It("should return nil when repo has no commit message policy", func() {
+			testutil2.AppendCommit(path, "file.txt", "line 3", "add line 3")
+			testRepo.SetState(&state.Repository{Config: state.BareRepoConfig()})
+			commitHash, _ := testRepo.GetRecentCommitHash()
+			commit, _ := testRepo.CommitObject(plumbing.NewHash(commitHash))
+			Expect(validation.CheckCommitMessages(testRepo, commit, oldHash)).To(BeNil())
+		})
+
+		It("should return error when a pushed commit message does not satisfy the policy", func() {
+			testutil2.AppendCommit(path, "file.txt", "line 3", "add line 3")
+			testutil2.AppendCommit(path, "file.txt", "line 4", "feat: add line 4")
+			testRepo.SetState(&state.Repository{Config: &state.RepoConfig{CommitMsg: &state.CommitMsgPolicy{Conventional: true}}})
+			commitHash, _ := testRepo.GetRecentCommitHash()
+			commit, _ := testRepo.CommitObject(plumbing.NewHash(commitHash))
+			err := validation.CheckCommitMessages(testRepo, commit, oldHash)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("commit message does not follow the conventional commits format"))
+		})
+
+		It("should not check commits that were not pushed", func() {
+			testutil2.AppendCommit(path, "file.txt", "line 3", "fix: add line 3")
+			testRepo.SetState(&state.Repository{Config: &state.RepoConfig{CommitMsg: &state.CommitMsgPolicy{Conventional: true}}})
+			commitHash, _ := testRepo.GetRecentCommitHash()
+			commit, _ := testRepo.CommitObject(plumbing.NewHash(commitHash))
+			Expect(validation.CheckCommitMessages(testRepo, commit, oldHash)).To(BeNil())
+		})
+	})
+
 	Describe(".validation.ValidateChange", func() {
 		var err error
 
@@ -214,6 +379,227 @@ var _ = Describe("Validation", func() {
 				Expect(err.Error()).To(Equal("unable to get tag object: tag not found"))
 			})
 		})
+
+		When("change item is a lightweight tag", func() {
+			var commitHash string
+
+			BeforeEach(func() {
+				testutil2.CreateCommitAndLightWeightTag(path, "file.txt", "first file", "commit message", "v1")
+				commitHash, _ = testRepo.GetRecentCommitHash()
+			})
+
+			It("should return err when the target commit hash and the signed head do not match", func() {
+				change := &plumbing2.ItemChange{Item: &plumbing2.Obj{Name: "refs/tags/v1", Data: commitHash}}
+				err = validation.ValidateChange(mockKeepers, testRepo, "", change, testTxDetail, testPushKeyGetter(pubKey, nil))
+				Expect(err).To(Equal(validation.ErrPushedAndSignedHeadMismatch))
+			})
+
+			It("should return nil when the target commit hash and the signed head match", func() {
+				change := &plumbing2.ItemChange{Item: &plumbing2.Obj{Name: "refs/tags/v1", Data: commitHash}}
+				detail := &types.TxDetail{Fee: "0", PushKeyID: pubKey.PushAddr().String(), Head: commitHash}
+				err = validation.ValidateChange(mockKeepers, testRepo, "", change, detail, testPushKeyGetter(pubKey, nil))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("change item updates an existing tag", func() {
+			var commitHash, oldHash string
+			var detail *types.TxDetail
+			var change *plumbing2.ItemChange
+
+			BeforeEach(func() {
+				testutil2.CreateCommitAndLightWeightTag(path, "file.txt", "first file", "commit message", "v1")
+				oldHash, _ = testRepo.GetRecentCommitHash()
+				testutil2.AppendCommit(path, "file.txt", "second line", "second commit")
+				commitHash, _ = testRepo.GetRecentCommitHash()
+				testutil2.ExecGit(path, "tag", "-f", "v1")
+				change = &plumbing2.ItemChange{Item: &plumbing2.Obj{Name: "refs/tags/v1", Data: commitHash}}
+				detail = &types.TxDetail{Fee: "0", PushKeyID: pubKey.PushAddr().String(), Head: commitHash}
+			})
+
+			It("should return nil when the tag is being created", func() {
+				err = validation.ValidateChange(mockKeepers, testRepo, plumbing.ZeroHash.String(), change, detail, testPushKeyGetter(pubKey, nil))
+				Expect(err).To(BeNil())
+			})
+
+			It("should return ErrTagAlreadyExists when the tag is being re-pointed", func() {
+				err = validation.ValidateChange(mockKeepers, testRepo, oldHash, change, detail, testPushKeyGetter(pubKey, nil))
+				Expect(err).To(Equal(validation.ErrTagAlreadyExists))
+				Expect(err.Error()).To(Equal("tag already exists"))
+			})
+
+			It("should return nil when the tag is being re-pointed and the repo allows tag updates", func() {
+				testRepo.SetState(&state.Repository{Config: &state.RepoConfig{AllowTagUpdate: true}})
+				err = validation.ValidateChange(mockKeepers, testRepo, oldHash, change, detail, testPushKeyGetter(pubKey, nil))
+				Expect(err).To(BeNil())
+			})
+
+			When("the repo has a tag grace period", func() {
+				var mockSysKeeper *mocks.MockSystemKeeper
+
+				BeforeEach(func() {
+					mockSysKeeper = mocks.NewMockSystemKeeper(ctrl)
+					mockKeepers.EXPECT().SysKeeper().Return(mockSysKeeper).AnyTimes()
+					testRepo.SetState(&state.Repository{
+						Config: &state.RepoConfig{TagGracePeriod: 10},
+						References: map[string]*state.Reference{
+							"refs/tags/v1": {Creator: pubKey.AddrRaw(), CreatedAt: 100, Hash: []byte("hash")},
+						},
+					})
+				})
+
+				It("should return nil when the creator re-points the tag within the grace period", func() {
+					mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 105}, nil)
+					err = validation.ValidateChange(mockKeepers, testRepo, oldHash, change, detail, testPushKeyGetter(pubKey, nil))
+					Expect(err).To(BeNil())
+				})
+
+				It("should return ErrTagAlreadyExists when the creator re-points the tag after the grace period", func() {
+					mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 110}, nil)
+					err = validation.ValidateChange(mockKeepers, testRepo, oldHash, change, detail, testPushKeyGetter(pubKey, nil))
+					Expect(err).To(Equal(validation.ErrTagAlreadyExists))
+				})
+
+				It("should return ErrTagAlreadyExists when a non-creator re-points the tag within the grace period", func() {
+					detail.PushKeyID = ed25519.NewKeyFromIntSeed(2).PushAddr().String()
+					err = validation.ValidateChange(mockKeepers, testRepo, oldHash, change, detail, testPushKeyGetter(pubKey, nil))
+					Expect(err).To(Equal(validation.ErrTagAlreadyExists))
+				})
+
+				It("should return error when current block info cannot be fetched", func() {
+					mockSysKeeper.EXPECT().GetLastBlockInfo().Return(nil, fmt.Errorf("error"))
+					err = validation.ValidateChange(mockKeepers, testRepo, oldHash, change, detail, testPushKeyGetter(pubKey, nil))
+					Expect(err).ToNot(BeNil())
+					Expect(err.Error()).To(Equal("failed to fetch current block info: error"))
+				})
+			})
+		})
+
+		When("change item is a meta reference", func() {
+			var commitHash string
+
+			BeforeEach(func() {
+				testutil2.AppendCommit(path, "ci.yml", "steps: []", "add ci config")
+				commitHash, _ = testRepo.GetRecentCommitHash()
+				testutil2.ExecGit(path, "update-ref", "refs/meta/ci", commitHash)
+			})
+
+			It("should return err when the meta reference commit is unknown", func() {
+				change := &plumbing2.ItemChange{Item: &plumbing2.Obj{Name: "refs/meta/ci", Data: "unknown_hash"}}
+				err = validation.ValidateChange(mockKeepers, testRepo, "", change, testTxDetail, testPushKeyGetter(pubKey, nil))
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("unable to get commit object: object not found"))
+			})
+
+			It("should return err when the commit hash and the signed head do not match", func() {
+				change := &plumbing2.ItemChange{Item: &plumbing2.Obj{Name: "refs/meta/ci", Data: commitHash}}
+				err = validation.ValidateChange(mockKeepers, testRepo, "", change, testTxDetail, testPushKeyGetter(pubKey, nil))
+				Expect(err).To(Equal(validation.ErrPushedAndSignedHeadMismatch))
+			})
+
+			It("should return nil when the commit hash and the signed head match", func() {
+				change := &plumbing2.ItemChange{Item: &plumbing2.Obj{Name: "refs/meta/ci", Data: commitHash}}
+				detail := &types.TxDetail{Fee: "0", PushKeyID: pubKey.PushAddr().String(), Head: commitHash}
+				err = validation.ValidateChange(mockKeepers, testRepo, "", change, detail, testPushKeyGetter(pubKey, nil))
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Describe(".validation.ValidateChange (signature policy)", func() {
+		var commitHash string
+		var detail *types.TxDetail
+
+		BeforeEach(func() {
+			testRepo.SetState(&state.Repository{Config: &state.RepoConfig{
+				Signature: &state.SignaturePolicy{Required: true, Unsigned: []string{"refs/heads/wip/*"}},
+			}})
+		})
+
+		When("the pushed commit is not signed", func() {
+			BeforeEach(func() {
+				testutil2.AppendCommit(path, "file.txt", "line 1", "commit 1")
+				commitHash, _ = testRepo.GetRecentCommitHash()
+				detail = &types.TxDetail{Fee: "0", PushKeyID: pubKey.PushAddr().String(), Head: commitHash}
+			})
+
+			It("should return nil when the reference accepts unsigned commits", func() {
+				change := &plumbing2.ItemChange{Item: &plumbing2.Obj{Name: "refs/heads/wip/feature", Data: commitHash}}
+				err = validation.ValidateChange(mockKeepers, testRepo, "", change, detail, testPushKeyGetter(pubKey, nil))
+				Expect(err).To(BeNil())
+			})
+
+			It("should return err when the reference requires signed commits", func() {
+				change := &plumbing2.ItemChange{Item: &plumbing2.Obj{Name: "refs/heads/master", Data: commitHash}}
+				err = validation.ValidateChange(mockKeepers, testRepo, "", change, detail, testPushKeyGetter(pubKey, nil))
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal(fmt.Sprintf("commit (%s): commit is not signed", commitHash[:7])))
+			})
+
+			It("should return nil when the repository has no signature policy", func() {
+				testRepo.SetState(&state.Repository{Config: state.BareRepoConfig()})
+				change := &plumbing2.ItemChange{Item: &plumbing2.Obj{Name: "refs/heads/master", Data: commitHash}}
+				err = validation.ValidateChange(mockKeepers, testRepo, "", change, detail, testPushKeyGetter(pubKey, nil))
+				Expect(err).To(BeNil())
+			})
+
+			It("should return err when a lightweight tag targets it", func() {
+				testutil2.ExecGit(path, "tag", "v1")
+				change := &plumbing2.ItemChange{Item: &plumbing2.Obj{Name: "refs/tags/v1", Data: commitHash}}
+				err = validation.ValidateChange(mockKeepers, testRepo, "", change, detail, testPushKeyGetter(pubKey, nil))
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("tag target: commit is not signed"))
+			})
+		})
+
+		When("the pushed commits are signed", func() {
+			var oldHash string
+
+			BeforeEach(func() {
+				testutil2.AppendCommit(path, "file.txt", "line 1", "commit 1")
+				oldHash, _ = testRepo.GetRecentCommitHash()
+				testutil2.AppendSignedCommit(path, "file.txt", "line 2", "commit 2", privKey)
+				commitHash, _ = testRepo.GetRecentCommitHash()
+				detail = &types.TxDetail{Fee: "0", PushKeyID: pubKey.PushAddr().String(), Head: commitHash}
+			})
+
+			It("should return nil when the reference requires signed commits", func() {
+				change := &plumbing2.ItemChange{Item: &plumbing2.Obj{Name: "refs/heads/master", Data: commitHash}}
+				err = validation.ValidateChange(mockKeepers, testRepo, oldHash, change, detail, testPushKeyGetter(pubKey, nil))
+				Expect(err).To(BeNil())
+			})
+
+			It("should return err when an earlier pushed commit is not signed", func() {
+				change := &plumbing2.ItemChange{Item: &plumbing2.Obj{Name: "refs/heads/master", Data: commitHash}}
+				err = validation.ValidateChange(mockKeepers, testRepo, "", change, detail, testPushKeyGetter(pubKey, nil))
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal(fmt.Sprintf("commit (%s): commit is not signed", oldHash[:7])))
+			})
+		})
+
+		When("the pushed tag is an annotated tag", func() {
+			BeforeEach(func() {
+				testutil2.AppendCommit(path, "file.txt", "line 1", "commit 1")
+			})
+
+			It("should return err when the tag is not signed", func() {
+				testutil2.ExecGit(path, "tag", "-a", "v1", "-m", "v1")
+				ref, _ := testRepo.Tag("v1")
+				detail = &types.TxDetail{Fee: "0", PushKeyID: pubKey.PushAddr().String(), Head: ref.Hash().String()}
+				change := &plumbing2.ItemChange{Item: &plumbing2.Obj{Name: "refs/tags/v1", Data: ref.Hash().String()}}
+				err = validation.ValidateChange(mockKeepers, testRepo, "", change, detail, testPushKeyGetter(pubKey, nil))
+				Expect(err).To(Equal(validation.ErrTagNotSigned))
+			})
+
+			It("should return nil when the tag is signed", func() {
+				testutil2.CreateSignedAnnotatedTag(path, "v1", "v1", privKey, nil)
+				ref, _ := testRepo.Tag("v1")
+				detail = &types.TxDetail{Fee: "0", PushKeyID: pubKey.PushAddr().String(), Head: ref.Hash().String()}
+				change := &plumbing2.ItemChange{Item: &plumbing2.Obj{Name: "refs/tags/v1", Data: ref.Hash().String()}}
+				err = validation.ValidateChange(mockKeepers, testRepo, "", change, detail, testPushKeyGetter(pubKey, nil))
+				Expect(err).To(BeNil())
+			})
+		})
 	})
 
 	Describe(".IsBlockedByScope", func() {
@@ -292,5 +678,57 @@ var _ = Describe("Validation", func() {
 			ns.Domains["repo2"] = "r/repo1"
 			Expect(validation.IsBlockedByScope(scopes, detail, ns)).To(BeFalse())
 		})
+
+		It("should return false when scopes has team-* and tx repo=team-a and namespace=''", func() {
+			scopes := []string{"team-*"}
+			detail := &types.TxDetail{RepoName: "team-a", RepoNamespace: ""}
+			ns := state.BareNamespace()
+			Expect(validation.IsBlockedByScope(scopes, detail, ns)).To(BeFalse())
+		})
+
+		It("should return true when scopes has team-* and tx repo=repo1 and namespace=''", func() {
+			scopes := []string{"team-*"}
+			detail := &types.TxDetail{RepoName: "repo1", RepoNamespace: ""}
+			ns := state.BareNamespace()
+			Expect(validation.IsBlockedByScope(scopes, detail, ns)).To(BeTrue())
+		})
+
+		It("should return false when scopes has r/team-? and tx repo=team-a and namespace=''", func() {
+			scopes := []string{"r/team-?"}
+			detail := &types.TxDetail{RepoName: "team-a", RepoNamespace: ""}
+			ns := state.BareNamespace()
+			Expect(validation.IsBlockedByScope(scopes, detail, ns)).To(BeFalse())
+		})
+
+		It("should return true when scopes has r/team-? and tx repo=team-ab and namespace=''", func() {
+			scopes := []string{"r/team-?"}
+			detail := &types.TxDetail{RepoName: "team-ab", RepoNamespace: ""}
+			ns := state.BareNamespace()
+			Expect(validation.IsBlockedByScope(scopes, detail, ns)).To(BeTrue())
+		})
+
+		It("should return false when scopes has ns1/team-* and tx repo=team-a and namespace=ns1", func() {
+			scopes := []string{"ns1/team-*"}
+			detail := &types.TxDetail{RepoName: "team-a", RepoNamespace: "ns1"}
+			ns := state.BareNamespace()
+			Expect(validation.IsBlockedByScope(scopes, detail, ns)).To(BeFalse())
+		})
+
+		It("should return true when scopes has ns1/team-* and tx repo=team-a and namespace=ns2", func() {
+			scopes := []string{"ns1/team-*"}
+			detail := &types.TxDetail{RepoName: "team-a", RepoNamespace: "ns2"}
+			ns := state.BareNamespace()
+			Expect(validation.IsBlockedByScope(scopes, detail, ns)).To(BeTrue())
+		})
+
+		It("should return false when scopes has team-* and tx repo=repo2 and "+
+			"namespace='ns1' "+
+			"and ns1/repo2 points to r/team-a", func() {
+			scopes := []string{"team-*"}
+			detail := &types.TxDetail{RepoName: "repo2", RepoNamespace: "ns1"}
+			ns := state.BareNamespace()
+			ns.Domains["repo2"] = "r/team-a"
+			Expect(validation.IsBlockedByScope(scopes, detail, ns)).To(BeFalse())
+		})
 	})
 })
@@ -19,6 +19,13 @@ func NewRepoAPI(mods *modulestypes.Modules) *RepoAPI {
 	return &RepoAPI{mods: mods}
 }
 
+// reader returns the repo module used to serve a read request. The content
+// of a private repository is only served if the request carries the push
+// token of a requester permitted to read it.
+func (a *RepoAPI) reader(m objx.Map) modulestypes.RepoModule {
+	return a.mods.Repo.WithReadToken(m.Get("token").Str())
+}
+
 // createRepo creates a transaction to create a repository
 func (a *RepoAPI) createRepo(params interface{}) (resp *rpc.Response) {
 	return rpc.Success(a.mods.Repo.Create(cast.ToStringMap(params)))
@@ -94,7 +101,7 @@ func (a *RepoAPI) ls(params interface{}) (resp *rpc.Response) {
 		revision = []string{rev}
 	}
 	return rpc.Success(util.Map{
-		"entries": a.mods.Repo.ListPath(m.Get("name").Str(), m.Get("path").Str(), revision...),
+		"entries": a.reader(m).ListPath(m.Get("name").Str(), m.Get("path").Str(), revision...),
 	})
 }
 
@@ -106,7 +113,7 @@ func (a *RepoAPI) readFileLines(params interface{}) (resp *rpc.Response) {
 		revision = []string{rev}
 	}
 	return rpc.Success(util.Map{
-		"lines": a.mods.Repo.ReadFileLines(m.Get("name").Str(), m.Get("path").Str(), revision...),
+		"lines": a.reader(m).ReadFileLines(m.Get("name").Str(), m.Get("path").Str(), revision...),
 	})
 }
 
@@ -118,33 +125,44 @@ func (a *RepoAPI) readFile(params interface{}) (resp *rpc.Response) {
 		revision = []string{rev}
 	}
 	return rpc.Success(util.Map{
-		"content": a.mods.Repo.ReadFile(m.Get("name").Str(), m.Get("path").Str(), revision...),
+		"content": a.reader(m).ReadFile(m.Get("name").Str(), m.Get("path").Str(), revision...),
+	})
+}
+
+// archive returns a base64 encoded tar.gz archive of the files of a repository
+func (a *RepoAPI) archive(params interface{}) (resp *rpc.Response) {
+	m := objx.New(cast.ToStringMap(params))
+	var revision []string
+	if rev := m.Get("revision").Str(); rev != "" {
+		revision = []string{rev}
+	}
+	return rpc.Success(util.Map{
+		"archive": a.reader(m).Archive(m.Get("name").Str(), revision...),
 	})
 }
 
 // getBranches returns a list of branches in a repository
 func (a *RepoAPI) getBranches(params interface{}) (resp *rpc.Response) {
 	m := objx.New(cast.ToStringMap(params))
-	return rpc.Success(util.Map{"branches": a.mods.Repo.GetBranches(m.Get("name").Str())})
+	return rpc.Success(util.Map{"branches": a.reader(m).GetBranches(m.Get("name").Str())})
 }
 
 // getLatestCommit gets the latest commit of a branch in a repository
 func (a *RepoAPI) getLatestCommit(params interface{}) (resp *rpc.Response) {
 	m := objx.New(cast.ToStringMap(params))
 	return rpc.Success(util.Map{
-		"commit": a.mods.Repo.GetLatestBranchCommit(m.Get("name").Str(), m.Get("branch").Str()),
+		"commit": a.reader(m).GetLatestBranchCommit(m.Get("name").Str(), m.Get("branch").Str()),
 	})
 }
 
 // getCommits gets a list of commits of a branch or ancestors of a commit of a repository
 func (a *RepoAPI) getCommits(params interface{}) (resp *rpc.Response) {
 	m := objx.New(cast.ToStringMap(params))
-	var limit []int
-	if l := m.Get("limit").Float64(); l > 0 {
-		limit = []int{int(l)}
-	}
 	return rpc.Success(util.Map{
-		"commits": a.mods.Repo.GetCommits(m.Get("name").Str(), m.Get("reference").Str(), limit...),
+		"commits": a.reader(m).GetCommits(m.Get("name").Str(), m.Get("reference").Str(), modulestypes.GetCommitsOptions{
+			Limit: int(m.Get("limit").Float64()),
+			Order: m.Get("order").Str(),
+		}),
 	})
 }
 
@@ -152,7 +170,7 @@ func (a *RepoAPI) getCommits(params interface{}) (resp *rpc.Response) {
 func (a *RepoAPI) getCommit(params interface{}) (resp *rpc.Response) {
 	m := objx.New(cast.ToStringMap(params))
 	return rpc.Success(util.Map{
-		"commit": a.mods.Repo.GetCommit(m.Get("name").Str(), m.Get("hash").Str()),
+		"commit": a.reader(m).GetCommit(m.Get("name").Str(), m.Get("hash").Str()),
 	})
 }
 
@@ -160,14 +178,27 @@ func (a *RepoAPI) getCommit(params interface{}) (resp *rpc.Response) {
 func (a *RepoAPI) countCommits(params interface{}) (resp *rpc.Response) {
 	m := objx.New(cast.ToStringMap(params))
 	return rpc.Success(util.Map{
-		"count": a.mods.Repo.CountCommits(m.Get("name").Str(), m.Get("branch").Str()),
+		"count": a.reader(m).CountCommits(m.Get("name").Str(), m.Get("branch").Str()),
 	})
 }
 
 // getDiffOfCommitAndParents gets the diff output between a commit and its parent(s).
 func (a *RepoAPI) getDiffOfCommitAndParents(params interface{}) (resp *rpc.Response) {
 	m := objx.New(cast.ToStringMap(params))
-	return rpc.Success(a.mods.Repo.GetParentsAndCommitDiff(m.Get("name").Str(), m.Get("commitHash").Str()))
+	return rpc.Success(a.reader(m).GetParentsAndCommitDiff(m.Get("name").Str(), m.Get("commitHash").Str()))
+}
+
+// streamDiffOfCommitAndParents streams the diff output between a commit and
+// its parent(s). Each part of the result contains the diff of a single file.
+func (a *RepoAPI) streamDiffOfCommitAndParents(params interface{}, send func(part util.Map) error) (resp *rpc.Response) {
+	m := objx.New(cast.ToStringMap(params))
+	var numFiles int
+	a.reader(m).StreamParentsAndCommitDiff(m.Get("name").Str(), m.Get("commitHash").Str(),
+		func(parent, fileDiff string) error {
+			numFiles++
+			return send(util.Map{"parent": parent, "diff": fileDiff})
+		})
+	return rpc.Success(util.Map{"numFiles": numFiles})
 }
 
 // getAncestors gets ancestors of a commit in a repository
@@ -178,7 +209,7 @@ func (a *RepoAPI) getAncestors(params interface{}) (resp *rpc.Response) {
 		limit = []int{int(l)}
 	}
 	return rpc.Success(util.Map{
-		"commits": a.mods.Repo.GetCommitAncestors(m.Get("name").Str(), m.Get("commitHash").Str(), limit...),
+		"commits": a.reader(m).GetCommitAncestors(m.Get("name").Str(), m.Get("commitHash").Str(), limit...),
 	})
 }
 
@@ -226,8 +257,9 @@ func (a *RepoAPI) reopenIssue(params interface{}) (resp *rpc.Response) {
 func (a *RepoAPI) listIssues(params interface{}) (resp *rpc.Response) {
 	m := objx.New(cast.ToStringMap(params))
 	name := m.Get("name").Str()
+	opts := modulestypes.ListPostsOptions{IncludeArchived: m.Get("includeArchived").Bool()}
 	return rpc.Success(util.Map{
-		"data": a.mods.Repo.ListIssues(name),
+		"data": a.reader(m).ListIssues(name, opts),
 	})
 }
 
@@ -237,7 +269,7 @@ func (a *RepoAPI) readIssue(params interface{}) (resp *rpc.Response) {
 	name := m.Get("name").Str()
 	reference := m.Get("reference").Str()
 	return rpc.Success(util.Map{
-		"data": a.mods.Repo.ReadIssue(name, reference),
+		"data": a.reader(m).ReadIssue(name, reference),
 	})
 }
 
@@ -275,8 +307,9 @@ func (a *RepoAPI) reopenMergeRequest(params interface{}) (resp *rpc.Response) {
 func (a *RepoAPI) listMergeRequests(params interface{}) (resp *rpc.Response) {
 	m := objx.New(cast.ToStringMap(params))
 	name := m.Get("name").Str()
+	opts := modulestypes.ListPostsOptions{IncludeArchived: m.Get("includeArchived").Bool()}
 	return rpc.Success(util.Map{
-		"data": a.mods.Repo.ListIssues(name),
+		"data": a.reader(m).ListMergeRequests(name, opts),
 	})
 }
 
@@ -286,7 +319,7 @@ func (a *RepoAPI) readMergeRequest(params interface{}) (resp *rpc.Response) {
 	name := m.Get("name").Str()
 	reference := m.Get("reference").Str()
 	return rpc.Success(util.Map{
-		"data": a.mods.Repo.ReadIssue(name, reference),
+		"data": a.reader(m).ReadIssue(name, reference),
 	})
 }
 
@@ -308,6 +341,7 @@ func (a *RepoAPI) APIs() rpc.APISet {
 		{Name: "ls", Namespace: ns, Func: a.ls, Desc: "List files and directories of a repository"},
 		{Name: "readFileLines", Namespace: ns, Func: a.readFileLines, Desc: "Gets the lines of a file in a repository"},
 		{Name: "readFile", Namespace: ns, Func: a.readFile, Desc: "Get the string content of a file in a repository"},
+		{Name: "archive", Namespace: ns, Func: a.archive, Desc: "Get a tar.gz archive of the files of a repository"},
 		{Name: "getBranches", Namespace: ns, Func: a.getBranches, Desc: "Get a list of branches in a repository"},
 		{Name: "getLatestCommit", Namespace: ns, Func: a.getLatestCommit, Desc: "Gets the latest commit of a branch in a repository"},
 		{Name: "getCommits", Namespace: ns, Func: a.getCommits, Desc: "Get a list of commits in a branch of a repository"},
@@ -315,6 +349,7 @@ func (a *RepoAPI) APIs() rpc.APISet {
 		{Name: "countCommits", Namespace: ns, Func: a.countCommits, Desc: "Get the number of commits in a reference"},
 		{Name: "getAncestors", Namespace: ns, Func: a.getAncestors, Desc: "Get ancestors of a commit in a repository"},
 		{Name: "getDiffOfCommitAndParents", Namespace: ns, Func: a.getDiffOfCommitAndParents, Desc: "Get the diff output between a commit and its parent(s)."},
+		{Name: "streamDiffOfCommitAndParents", Namespace: ns, Func: a.streamDiffOfCommitAndParents, Desc: "Stream the diff output between a commit and its parent(s), one file at a time."},
 		{Name: "push", Namespace: ns, Func: a.push, Desc: "Sign and push a commit, tag or note in a temporary worktree"},
 		{Name: "createIssue", Namespace: ns, Func: a.createIssue, Desc: "Create, add comment or edit an issue"},
 		{Name: "closeIssue", Namespace: ns, Func: a.closeIssue, Desc: "Close an issue"},
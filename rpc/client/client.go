@@ -4,8 +4,10 @@ import (
 	"bytes"
 	encJson "encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"math/rand"
+	"net"
 	"net/http"
 	"time"
 
@@ -27,6 +29,14 @@ const (
 	ErrCodeBadParam     = "bad_param_error"
 )
 
+const (
+	// DefaultMaxIdleConns is the default maximum number of idle connections kept for reuse
+	DefaultMaxIdleConns = 10
+
+	// DefaultIdleConnTimeout is the default duration an idle connection is kept for reuse
+	DefaultIdleConnTimeout = 90 * time.Second
+)
+
 type callerFunc func(method string, params interface{}) (res util.Map, statusCode int, err error)
 
 // RPCClient provides the ability to interact with a JSON-RPC 2.0 service
@@ -47,12 +57,41 @@ func NewClient(opts *types.Options) *RPCClient {
 		opts.Host = "0.0.0.0"
 	}
 
-	client := &RPCClient{c: new(http.Client), opts: opts}
+	client := &RPCClient{c: newHTTPClient(opts), opts: opts}
 	client.call = client.Call
 
 	return client
 }
 
+// newHTTPClient creates an http client whose transport keeps a pool of
+// idle connections to the server so that consecutive calls reuse them.
+// The client is safe for concurrent use.
+func newHTTPClient(opts *types.Options) *http.Client {
+	maxIdleConns := opts.MaxIdleConns
+	if maxIdleConns <= 0 {
+		maxIdleConns = DefaultMaxIdleConns
+	}
+
+	idleConnTimeout := opts.IdleConnTimeout
+	if idleConnTimeout <= 0 {
+		idleConnTimeout = DefaultIdleConnTimeout
+	}
+
+	return &http.Client{
+		Timeout: Timeout,
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   Timeout,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			MaxIdleConns:        maxIdleConns,
+			MaxIdleConnsPerHost: maxIdleConns,
+			IdleConnTimeout:     idleConnTimeout,
+		},
+	}
+}
+
 // SetCallFunc sets the RPC call function
 func (c *RPCClient) SetCallFunc(f callerFunc) {
 	c.call = f
@@ -142,13 +181,17 @@ func (c *RPCClient) Call(method string, params interface{}) (res util.Map, statu
 		req.SetBasicAuth(c.opts.User, c.opts.Password)
 	}
 
-	c.c.Timeout = Timeout
 	req.Header.Set("Content-Type", "application/json")
 	resp, err := c.c.Do(req)
 	if err != nil {
 		return nil, 500, errors.ReqErr(500, ErrCodeConnect, "", err.Error())
 	}
-	defer resp.Body.Close()
+
+	// Drain the body before closing it so the connection can be reused
+	defer func() {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}()
 
 	// When status is not 200 or 201, return body as error
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
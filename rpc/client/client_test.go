@@ -2,17 +2,29 @@ package client
 
 import (
 	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/make-os/kit/config"
 	"github.com/make-os/kit/crypto/ed25519"
 	types2 "github.com/make-os/kit/modules/types"
+	"github.com/make-os/kit/pkgs/logger"
 	"github.com/make-os/kit/rpc"
 	"github.com/make-os/kit/rpc/types"
+	types3 "github.com/make-os/kit/types"
 	"github.com/make-os/kit/types/api"
+	"github.com/make-os/kit/types/constants"
 	"github.com/make-os/kit/types/state"
 	"github.com/make-os/kit/util"
 	"github.com/make-os/kit/util/errors"
+	"github.com/olebedev/emitter"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -41,6 +53,67 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe(".Call (connection reuse)", func() {
+		var server *httptest.Server
+		var newConns int32
+
+		BeforeEach(func() {
+			newConns = 0
+			server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"ok":true}}`))
+			}))
+			server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+				if state == http.StateNew {
+					atomic.AddInt32(&newConns, 1)
+				}
+			}
+			server.Start()
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should reuse a connection across sequential calls", func() {
+			c := NewClient(&types.Options{Host: server.URL})
+			for i := 0; i < 5; i++ {
+				res, _, err := c.Call("test_method", nil)
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(util.Map{"ok": true}))
+			}
+			Expect(atomic.LoadInt32(&newConns)).To(Equal(int32(1)))
+		})
+
+		It("should not open more connections than concurrent callers", func() {
+			c := NewClient(&types.Options{Host: server.URL, MaxIdleConns: 2})
+			for round := 0; round < 3; round++ {
+				wg := sync.WaitGroup{}
+				for i := 0; i < 2; i++ {
+					wg.Add(1)
+					go func() {
+						defer GinkgoRecover()
+						defer wg.Done()
+						_, _, err := c.Call("test_method", nil)
+						Expect(err).To(BeNil())
+					}()
+				}
+				wg.Wait()
+			}
+			Expect(atomic.LoadInt32(&newConns)).To(BeNumerically("<=", 2))
+		})
+
+		It("should close idle connections after the idle timeout", func() {
+			c := NewClient(&types.Options{Host: server.URL, IdleConnTimeout: 10 * time.Millisecond})
+			_, _, err := c.Call("test_method", nil)
+			Expect(err).To(BeNil())
+			time.Sleep(50 * time.Millisecond)
+			_, _, err = c.Call("test_method", nil)
+			Expect(err).To(BeNil())
+			Expect(atomic.LoadInt32(&newConns)).To(Equal(int32(2)))
+		})
+	})
+
 	Describe(".GetOptions", func() {
 		It("should return options", func() {
 			opts := &types.Options{Host: "hostA", Port: 9000}
@@ -457,6 +530,76 @@ var _ = Describe("RepoAPI", func() {
 			Expect(resp.Hash).To(Equal("0x123"))
 		})
 	})
+
+	Describe(".StreamDiffOfCommitAndParents", func() {
+		var server *httptest.Server
+		var handler *rpc.Handler
+
+		BeforeEach(func() {
+			cfg := config.EmptyAppConfig()
+			cfg.RPC.On = true
+			cfg.G().Log = logger.NewLogrusNoOp()
+			mux := http.NewServeMux()
+			handler = rpc.New(mux, cfg)
+			server = httptest.NewServer(mux)
+			client = NewClient(&types.Options{Host: server.URL})
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should return error when unable to connect", func() {
+			client = NewClient(&types.Options{Host: "127.0.0.1", Port: 1})
+			_, err := client.Repo().StreamDiffOfCommitAndParents("repo1", "abc")
+			Expect(err).ToNot(BeNil())
+			Expect(err.(*errors.ReqError).Code).To(Equal(ErrCodeConnect))
+		})
+
+		It("should iterate over the diff of each file", func() {
+			handler.MergeAPISet(rpc.APISet{{Name: "streamDiffOfCommitAndParents", Namespace: constants.NamespaceRepo,
+				Func: func(params interface{}, send func(part util.Map) error) *rpc.Response {
+					Expect(params).To(Equal(map[string]interface{}{"name": "repo1", "commitHash": "abc"}))
+					_ = send(util.Map{"parent": "p1", "diff": "diff 1"})
+					_ = send(util.Map{"parent": "p1", "diff": "diff 2"})
+					return rpc.Success(util.Map{"numFiles": 2})
+				},
+			}})
+
+			it, err := client.Repo().StreamDiffOfCommitAndParents("repo1", "abc")
+			Expect(err).To(BeNil())
+			defer it.Close()
+
+			res, err := it.Next()
+			Expect(err).To(BeNil())
+			Expect(res).To(Equal(&api.ResultFileDiff{Parent: "p1", Diff: "diff 1"}))
+			res, err = it.Next()
+			Expect(err).To(BeNil())
+			Expect(res).To(Equal(&api.ResultFileDiff{Parent: "p1", Diff: "diff 2"}))
+			_, err = it.Next()
+			Expect(err).To(Equal(io.EOF))
+		})
+
+		It("should return error when the method fails", func() {
+			handler.MergeAPISet(rpc.APISet{{Name: "streamDiffOfCommitAndParents", Namespace: constants.NamespaceRepo,
+				Func: func(params interface{}, send func(part util.Map) error) *rpc.Response {
+					_ = send(util.Map{"parent": "p1", "diff": "diff 1"})
+					panic(errors.ReqErr(404, "commit_not_found", "commitHash", "commit not found"))
+				},
+			}})
+
+			it, err := client.Repo().StreamDiffOfCommitAndParents("repo1", "abc")
+			Expect(err).To(BeNil())
+			defer it.Close()
+
+			_, err = it.Next()
+			Expect(err).To(BeNil())
+			_, err = it.Next()
+			Expect(err).ToNot(BeNil())
+			Expect(err.(*errors.ReqError).Code).To(Equal("commit_not_found"))
+			Expect(err.(*errors.ReqError).Msg).To(Equal("commit not found"))
+		})
+	})
 })
 
 var _ = Describe("RPCAPI", func() {
@@ -699,4 +842,48 @@ var _ = Describe("UserAPI", func() {
 			Expect(resp.Hash).To(Equal("0x123"))
 		})
 	})
+
+	Describe(".Subscribe", func() {
+		var cfg *config.AppConfig
+		var server *httptest.Server
+		var client *RPCClient
+
+		BeforeEach(func() {
+			cfg = config.EmptyAppConfig()
+			cfg.RPC.On = true
+			cfg.G().Log = logger.NewLogrusNoOp()
+			cfg.G().Bus = emitter.New(10)
+			mux := http.NewServeMux()
+			rpc.New(mux, cfg)
+			server = httptest.NewServer(mux)
+			client = NewClient(&types.Options{Host: server.URL})
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should return error when unable to connect", func() {
+			client = NewClient(&types.Options{Host: "127.0.0.1", Port: 1})
+			_, err := client.Subscribe()
+			Expect(err).ToNot(BeNil())
+			Expect(err.(*errors.ReqError).Code).To(Equal(ErrCodeConnect))
+		})
+
+		It("should receive streamed events and close events channel after unsubscribing", func() {
+			sub, err := client.Subscribe(rpc.EventTopicBlock)
+			Expect(err).To(BeNil())
+			Eventually(func() int { return len(cfg.G().Bus.Listeners(constants.EvtBlockCommitted)) }).Should(Equal(1))
+
+			bi := &state.BlockInfo{Height: 10, Hash: []byte("hash")}
+			cfg.G().Bus.Emit(constants.EvtBlockCommitted, bi, []types3.BaseTx{})
+			evt := <-sub.Events()
+			Expect(evt.Topic).To(Equal(rpc.EventTopicBlock))
+			Expect(evt.Data["height"]).To(Equal("10"))
+
+			Expect(sub.Unsubscribe()).To(Succeed())
+			Eventually(sub.Events()).Should(BeClosed())
+			Expect(sub.Err()).To(BeNil())
+		})
+	})
 })
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/make-os/kit/rpc"
+	"github.com/make-os/kit/util/errors"
+)
+
+// Subscription represents a subscription to events streamed by the node
+type Subscription struct {
+	conn   *websocket.Conn
+	events chan *rpc.Event
+	done   chan struct{}
+	once   sync.Once
+	err    error
+}
+
+// Events returns a channel that receives the streamed events.
+// The channel is closed when the subscription ends.
+func (s *Subscription) Events() <-chan *rpc.Event {
+	return s.events
+}
+
+// Err returns the error that ended the subscription, if any.
+// It should be called after the events channel is closed.
+func (s *Subscription) Err() error {
+	return s.err
+}
+
+// Unsubscribe ends the subscription and closes the connection to the node
+func (s *Subscription) Unsubscribe() (err error) {
+	s.once.Do(func() {
+		close(s.done)
+		msg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+		_ = s.conn.WriteMessage(websocket.CloseMessage, msg)
+		err = s.conn.Close()
+	})
+	return
+}
+
+// read reads events from the connection until it is closed
+func (s *Subscription) read() {
+	defer close(s.events)
+	for {
+		var evt rpc.Event
+		if err := s.conn.ReadJSON(&evt); err != nil {
+			select {
+			case <-s.done:
+			default:
+				if !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+					s.err = err
+				}
+			}
+			return
+		}
+		select {
+		case s.events <- &evt:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// Subscribe subscribes to events streamed by the node.
+//  - topics: The event topics to subscribe to (default: all topics)
+func (c *RPCClient) Subscribe(topics ...string) (*Subscription, error) {
+
+	url := c.opts.EventsURL()
+	if len(topics) > 0 {
+		url += "?topics=" + strings.Join(topics, ",")
+	}
+
+	var header = http.Header{}
+	if c.opts.User != "" && c.opts.Password != "" {
+		auth := base64.StdEncoding.EncodeToString([]byte(c.opts.User + ":" + c.opts.Password))
+		header.Set("Authorization", "Basic "+auth)
+	}
+
+	dialer := &websocket.Dialer{HandshakeTimeout: Timeout}
+	conn, resp, err := dialer.Dial(url, header)
+	if err != nil {
+		statusCode := 500
+		if resp != nil {
+			statusCode = resp.StatusCode
+		}
+		return nil, errors.ReqErr(statusCode, ErrCodeConnect, "", err.Error())
+	}
+
+	sub := &Subscription{conn: conn, events: make(chan *rpc.Event, 100), done: make(chan struct{})}
+	go sub.read()
+
+	return sub, nil
+}
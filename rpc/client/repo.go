@@ -3,6 +3,7 @@ package client
 import (
 	"time"
 
+	"github.com/make-os/kit/rpc/types"
 	"github.com/make-os/kit/types/api"
 	"github.com/make-os/kit/types/state"
 	"github.com/make-os/kit/types/txns"
@@ -155,3 +156,39 @@ func (c *RepoAPI) VoteProposal(body *api.BodyRepoVote) (*api.ResultHash, error)
 
 	return &r, nil
 }
+
+// StreamDiffOfCommitAndParents streams the diff between a commit
+// and its parent(s), one file at a time.
+func (c *RepoAPI) StreamDiffOfCommitAndParents(name, commitHash string) (types.CommitDiffIterator, error) {
+	stream, err := c.c.CallStream("repo_streamDiffOfCommitAndParents", util.Map{"name": name, "commitHash": commitHash})
+	if err != nil {
+		return nil, err
+	}
+	return &CommitDiffIterator{stream: stream}, nil
+}
+
+// CommitDiffIterator implements types.CommitDiffIterator
+type CommitDiffIterator struct {
+	stream *ResponseStream
+}
+
+// Next returns the diff of the next file.
+// It returns io.EOF when there are no more files.
+func (it *CommitDiffIterator) Next() (*api.ResultFileDiff, error) {
+	part, err := it.stream.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	var r api.ResultFileDiff
+	if err = util.DecodeMap(part, &r); err != nil {
+		return nil, errors.ReqErr(500, ErrCodeDecodeFailed, "", err.Error())
+	}
+
+	return &r, nil
+}
+
+// Close ends the stream
+func (it *CommitDiffIterator) Close() error {
+	return it.stream.Close()
+}
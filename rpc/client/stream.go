@@ -0,0 +1,111 @@
+package client
+
+import (
+	"bytes"
+	encJson "encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+
+	"github.com/make-os/kit/rpc"
+	"github.com/make-os/kit/util"
+	"github.com/make-os/kit/util/errors"
+)
+
+// ResponseStream reads the parts of a streamed RPC response
+type ResponseStream struct {
+	body   io.ReadCloser
+	dec    *encJson.Decoder
+	result util.Map
+	done   bool
+}
+
+// Next returns the next part of the streamed result.
+// It returns io.EOF when there are no more parts.
+func (s *ResponseStream) Next() (util.Map, error) {
+	if s.done {
+		return nil, io.EOF
+	}
+
+	var resp rpc.Response
+	if err := s.dec.Decode(&resp); err != nil {
+		s.done = true
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, errors.ReqErr(500, ErrCodeDecodeFailed, "", err.Error())
+	}
+
+	if resp.IsError() {
+		s.done = true
+		data, _ := resp.Err.Data.(string)
+		return nil, errors.ReqErr(500, resp.Err.Code, data, resp.Err.Message)
+	}
+
+	// The final response ends the stream
+	if !resp.More {
+		s.done = true
+		s.result = resp.Result
+		return nil, io.EOF
+	}
+
+	return resp.Result, nil
+}
+
+// Result returns the final result of the stream.
+// It is set after Next returns io.EOF.
+func (s *ResponseStream) Result() util.Map {
+	return s.result
+}
+
+// Close ends the stream and closes the connection to the node
+func (s *ResponseStream) Close() error {
+	s.done = true
+	return s.body.Close()
+}
+
+// CallStream calls a streaming method on the RPCClient service.
+// Unlike Call, the response is not read at once; the returned stream
+// must be read part by part and closed when no longer needed.
+func (c *RPCClient) CallStream(method string, params interface{}) (*ResponseStream, error) {
+
+	var request = map[string]interface{}{
+		"method":  method,
+		"params":  params,
+		"id":      uint64(rand.Int63()),
+		"jsonrpc": "2.0",
+	}
+
+	msg, err := encJson.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.opts.URL(), bytes.NewBuffer(msg))
+	if err != nil {
+		return nil, err
+	}
+
+	if c.opts.User != "" && c.opts.Password != "" {
+		req.SetBasicAuth(c.opts.User, c.opts.Password)
+	}
+
+	// Do not set a timeout on the request as it would
+	// also limit the time available for reading the stream.
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := (&http.Client{Transport: c.c.Transport}).Do(req)
+	if err != nil {
+		return nil, errors.ReqErr(500, ErrCodeConnect, "", err.Error())
+	}
+
+	// When status is not 200 or 201, return body as error
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, makeReqErrFromCallErr(resp.StatusCode, fmt.Errorf("%s", string(body)))
+	}
+
+	return &ResponseStream{body: resp.Body, dec: encJson.NewDecoder(resp.Body)}, nil
+}
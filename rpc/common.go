@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/make-os/kit/types"
 	"github.com/make-os/kit/util"
 )
 
@@ -26,6 +27,11 @@ type CallContext struct {
 type Method func(params interface{}) *Response
 type MethodWithContext func(params interface{}, ctx *CallContext) *Response
 
+// StreamMethod describes a method that sends its result in parts.
+// Each call to send delivers a part of the result to the client
+// before the final response returned by the method.
+type StreamMethod func(params interface{}, send func(part util.Map) error) *Response
+
 // MethodInfo describes an RPC method.
 type MethodInfo struct {
 
@@ -122,6 +128,10 @@ type Response struct {
 	Result         util.Map    `json:"result"`
 	Err            *Err        `json:"error,omitempty"`
 	ID             interface{} `json:"id,omitempty"` // string or float64
+
+	// More indicates that the response is a part of a streamed
+	// result and that more responses will follow.
+	More bool `json:"more,omitempty"`
 }
 
 // IsError checks whether r is an error response
@@ -129,9 +139,18 @@ func (r Response) IsError() bool {
 	return r.Err != nil
 }
 
-// ToJSON returns the JSON encoding of r
+// ToJSON returns the JSON encoding of r.
+// The keys of the result map (and maps nested in it) are sorted by the
+// encoder, so the same response always produces the same bytes, allowing
+// clients to hash or cache responses. If r cannot be encoded, the encoding
+// of a server error response is returned instead.
 func (r Response) ToJSON() []byte {
-	bz, _ := json.Marshal(r)
+	bz, err := json.Marshal(r)
+	if err != nil {
+		errResp := Error(types.ErrRPCServerError, "failed to encode response: "+err.Error(), nil)
+		errResp.ID = r.ID
+		bz, _ = json.Marshal(errResp)
+	}
 	return bz
 }
 
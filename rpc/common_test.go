@@ -1,6 +1,9 @@
 package rpc
 
 import (
+	"encoding/json"
+
+	"github.com/make-os/kit/util"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -35,4 +38,24 @@ var _ = Describe("API", func() {
 		})
 	})
 
+	Describe("Response.ToJSON", func() {
+		It("should return the same bytes across runs with map keys sorted and HTML escaped", func() {
+			resp := Success(util.Map{"b": 1, "a": util.Map{"y": "<a>", "x": []interface{}{util.Map{"d": 1, "c": 2}}}})
+			expected := `{"jsonrpc":"2.0","result":{"a":{"x":[{"c":2,"d":1}],"y":"\u003ca\u003e"},"b":1}}`
+			for i := 0; i < 10; i++ {
+				Expect(string(resp.ToJSON())).To(Equal(expected))
+			}
+		})
+
+		It("should return a server error response when the response cannot be encoded", func() {
+			resp := Success(util.Map{"ch": make(chan int)})
+			resp.ID = "1"
+			var decoded Response
+			Expect(json.Unmarshal(resp.ToJSON(), &decoded)).To(BeNil())
+			Expect(decoded.Err).ToNot(BeNil())
+			Expect(decoded.Err.Code).To(Equal("50000"))
+			Expect(decoded.Err.Message).To(ContainSubstring("failed to encode response"))
+			Expect(decoded.ID).To(Equal("1"))
+		})
+	})
 })
@@ -0,0 +1,121 @@
+package rpc
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/make-os/kit/types"
+	"github.com/make-os/kit/types/constants"
+	"github.com/make-os/kit/types/state"
+	"github.com/make-os/kit/util"
+	"github.com/olebedev/emitter"
+)
+
+// Event topics
+const (
+	EventTopicBlock = "block"
+	EventTopicTx    = "tx"
+)
+
+// Event describes a notification streamed to event subscribers
+type Event struct {
+	Topic string   `json:"topic"`
+	Data  util.Map `json:"data"`
+}
+
+// registerEventsHandler registers the handler that streams events to websocket clients
+func (s *Handler) registerEventsHandler(mux *http.ServeMux, path string) {
+	if !s.cfg.RPC.On || s.eventsHandlerSet {
+		return
+	}
+	mux.Handle(path, http.HandlerFunc(s.handleEvents))
+	s.eventsHandlerSet = true
+}
+
+// handleEvents upgrades a request to a websocket connection and streams
+// block and transaction events to it until the connection is closed.
+//
+// The topics to subscribe to can be set via the 'topics' query parameter
+// as a comma-separated list (e.g ?topics=block,tx). All topics are
+// subscribed to if the parameter is not set.
+func (s *Handler) handleEvents(w http.ResponseWriter, r *http.Request) {
+
+	if !s.cfg.RPC.DisableAuth && s.cfg.RPC.AuthPubMethod {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != s.cfg.RPC.User || password != s.cfg.RPC.Password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	}
+
+	var topics = map[string]bool{}
+	for _, t := range strings.Split(r.URL.Query().Get("topics"), ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			topics[t] = true
+		}
+	}
+	if len(topics) == 0 {
+		topics = map[string]bool{EventTopicBlock: true, EventTopicTx: true}
+	}
+
+	c, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.log.Debug("Failed to upgrade events connection", "Err", err.Error())
+		return
+	}
+	defer c.Close()
+
+	bus := s.cfg.G().Bus
+	evtCh := bus.On(constants.EvtBlockCommitted)
+	defer bus.Off(constants.EvtBlockCommitted, evtCh)
+
+	// Read from the connection to detect when the client closes it.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := c.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case evt := <-evtCh:
+			for _, e := range makeBlockEvents(evt, topics) {
+				if err := c.WriteJSON(e); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+// makeBlockEvents creates events of the given topics from an EvtBlockCommitted event
+func makeBlockEvents(evt emitter.Event, topics map[string]bool) (events []*Event) {
+	bi := evt.Args[0].(*state.BlockInfo)
+
+	if topics[EventTopicBlock] {
+		events = append(events, &Event{Topic: EventTopicBlock, Data: util.Map{
+			"height": bi.Height,
+			"hash":   bi.Hash.HexStr(),
+			"time":   bi.Time,
+		}})
+	}
+
+	if topics[EventTopicTx] {
+		for i, tx := range evt.Args[1].([]types.BaseTx) {
+			events = append(events, &Event{Topic: EventTopicTx, Data: util.Map{
+				"type":   tx.GetType(),
+				"hash":   tx.GetHash().String(),
+				"height": bi.Height,
+				"index":  i,
+			}})
+		}
+	}
+
+	return
+}
@@ -0,0 +1,96 @@
+package rpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gorilla/websocket"
+	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/pkgs/logger"
+	"github.com/make-os/kit/types"
+	"github.com/make-os/kit/types/constants"
+	"github.com/make-os/kit/types/state"
+	"github.com/make-os/kit/types/txns"
+	"github.com/olebedev/emitter"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Events", func() {
+	var cfg *config.AppConfig
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		cfg = config.EmptyAppConfig()
+		cfg.RPC.On = true
+		cfg.G().Log = logger.NewLogrusNoOp()
+		cfg.G().Bus = emitter.New(10)
+		mux := http.NewServeMux()
+		New(mux, cfg)
+		server = httptest.NewServer(mux)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	var subscribe = func(query string) *websocket.Conn {
+		url := "ws" + strings.TrimPrefix(server.URL, "http") + "/events" + query
+		c, _, err := websocket.DefaultDialer.Dial(url, nil)
+		Expect(err).To(BeNil())
+		Eventually(func() int { return len(cfg.G().Bus.Listeners(constants.EvtBlockCommitted)) }).Should(Equal(1))
+		return c
+	}
+
+	var emitBlock = func() (*state.BlockInfo, types.BaseTx) {
+		bi := &state.BlockInfo{Height: 10, Hash: []byte("hash")}
+		tx := txns.NewBareTxCoinTransfer()
+		cfg.G().Bus.Emit(constants.EvtBlockCommitted, bi, []types.BaseTx{tx})
+		return bi, tx
+	}
+
+	It("should stream block and tx events when no topic is specified", func() {
+		c := subscribe("")
+		defer c.Close()
+		bi, tx := emitBlock()
+
+		var evt map[string]interface{}
+		Expect(c.ReadJSON(&evt)).To(Succeed())
+		Expect(evt["topic"]).To(Equal(EventTopicBlock))
+		Expect(evt["data"]).To(HaveKeyWithValue("height", "10"))
+		Expect(evt["data"]).To(HaveKeyWithValue("hash", bi.Hash.String()))
+
+		Expect(c.ReadJSON(&evt)).To(Succeed())
+		Expect(evt["topic"]).To(Equal(EventTopicTx))
+		Expect(evt["data"]).To(HaveKeyWithValue("type", float64(tx.GetType())))
+		Expect(evt["data"]).To(HaveKeyWithValue("hash", tx.GetHash().String()))
+		Expect(evt["data"]).To(HaveKeyWithValue("index", float64(0)))
+	})
+
+	It("should stream only events of the requested topics", func() {
+		c := subscribe("?topics=tx")
+		defer c.Close()
+		_, tx := emitBlock()
+
+		var evt map[string]interface{}
+		Expect(c.ReadJSON(&evt)).To(Succeed())
+		Expect(evt["topic"]).To(Equal(EventTopicTx))
+		Expect(evt["data"]).To(HaveKeyWithValue("hash", tx.GetHash().String()))
+	})
+
+	It("should stop listening for events when the connection is closed", func() {
+		c := subscribe("")
+		Expect(c.Close()).To(Succeed())
+		Eventually(func() int { return len(cfg.G().Bus.Listeners(constants.EvtBlockCommitted)) }).Should(Equal(0))
+	})
+
+	It("should reject connection without valid credentials when public methods require authentication", func() {
+		cfg.RPC.AuthPubMethod = true
+		cfg.RPC.User, cfg.RPC.Password = "user", "pass"
+		url := "ws" + strings.TrimPrefix(server.URL, "http") + "/events"
+		_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+		Expect(err).ToNot(BeNil())
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+	})
+})
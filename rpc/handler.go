@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"compress/gzip"
 	"encoding/json"
 	goerrors "errors"
 	"fmt"
@@ -18,6 +19,10 @@ import (
 	"github.com/pkg/errors"
 )
 
+// GzipMinResponseSize is the minimum size of a response body
+// before it is gzip-encoded for clients that accept gzip.
+var GzipMinResponseSize = 1024
+
 // Handler is responsible for handling incoming RPC requests
 // by routing to a method that can handle the request and
 // return a response.
@@ -31,6 +36,9 @@ type Handler struct {
 	// handlerSet lets us know when the request handler has been configured
 	handlerSet bool
 
+	// eventsHandlerSet lets us know when the events handler has been configured
+	eventsHandlerSet bool
+
 	upgrader *websocket.Upgrader
 }
 
@@ -45,6 +53,7 @@ func New(mux *http.ServeMux, cfg *config.AppConfig) *Handler {
 	}
 	jsonrpc.MergeAPISet(jsonrpc.APIs())
 	jsonrpc.registerHandler(mux, "/rpc")
+	jsonrpc.registerEventsHandler(mux, "/events")
 	return jsonrpc
 }
 
@@ -128,12 +137,20 @@ func (s *Handler) handle(w http.ResponseWriter, r *http.Request) (resp *Response
 		}
 	}
 
+	// streamed is set when a method has started writing parts of its result.
+	// The final response of a streamed HTTP request must not be gzip-encoded
+	// since the parts before it were written as-is.
+	var streamed bool
 	writeResp := func() {
 		if c != nil {
 			c.WriteMessage(websocket.BinaryMessage, resp.ToJSON())
 			return
 		}
-		json.NewEncoder(w).Encode(resp)
+		if streamed {
+			_, _ = w.Write(resp.ToJSON())
+			return
+		}
+		writeHTTPResponse(w, r, resp.ToJSON())
 	}
 
 	// Handle panics gracefully
@@ -231,6 +248,13 @@ func (s *Handler) handle(w http.ResponseWriter, r *http.Request) (resp *Response
 				apiCtx := &CallContext{IsLocal: strings.HasPrefix(r.RemoteAddr, "127.0.0.1")}
 				in := []reflect.Value{params, reflect.ValueOf(apiCtx)}
 				resp = funcVal.Call(in)[0].Interface().(*Response)
+			} else if funcVal.Type().ConvertibleTo(reflect.TypeOf((StreamMethod)(nil))) {
+				send := makeStreamSender(w, c, newReq.ID)
+				in := []reflect.Value{params, reflect.ValueOf(func(part util.Map) error {
+					streamed = true
+					return send(part)
+				})}
+				resp = funcVal.Call(in)[0].Interface().(*Response)
 			} else {
 				resp = Error(types.ErrRPCServerError, "invalid method function signature", nil)
 				writeResp()
@@ -259,3 +283,55 @@ func (s *Handler) handle(w http.ResponseWriter, r *http.Request) (resp *Response
 
 	return resp
 }
+
+// makeStreamSender returns a function that writes a part of a streamed result
+// as a message of a websocket connection or, if c is nil, as a line of a
+// newline-delimited HTTP response body that is flushed immediately.
+func makeStreamSender(w http.ResponseWriter, c *websocket.Conn, id interface{}) func(part util.Map) error {
+	return func(part util.Map) error {
+		resp := &Response{JSONRPCVersion: "2.0", Result: part, ID: id, More: true}
+		if c != nil {
+			return c.WriteMessage(websocket.BinaryMessage, resp.ToJSON())
+		}
+		if _, err := w.Write(append(resp.ToJSON(), '\n')); err != nil {
+			return err
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		return nil
+	}
+}
+
+// writeHTTPResponse writes a response body to an HTTP response writer.
+// The body is gzip-encoded if it is not smaller than GzipMinResponseSize
+// and the client accepts gzip encoding.
+func writeHTTPResponse(w http.ResponseWriter, r *http.Request, body []byte) {
+	w.Header().Add("Vary", "Accept-Encoding")
+	if len(body) < GzipMinResponseSize || !acceptsGzip(r) {
+		_, _ = w.Write(body)
+		return
+	}
+
+	w.Header().Set("Content-Encoding", "gzip")
+	gz := gzip.NewWriter(w)
+	_, _ = gz.Write(body)
+	_ = gz.Close()
+}
+
+// acceptsGzip checks whether the Accept-Encoding header of a request
+// permits gzip encoding.
+func acceptsGzip(r *http.Request) bool {
+	for _, enc := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		parts := strings.Split(enc, ";")
+		name := strings.TrimSpace(parts[0])
+		if name != "gzip" && name != "*" {
+			continue
+		}
+		if len(parts) > 1 && strings.ReplaceAll(parts[1], " ", "") == "q=0" {
+			return false
+		}
+		return true
+	}
+	return false
+}
@@ -2,8 +2,10 @@ package rpc
 
 import (
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -473,6 +475,78 @@ var _ = Describe("RPC", func() {
 		})
 	})
 
+	When("target method streams its result", func() {
+		BeforeEach(func() {
+			rpc.apiSet.Add(MethodInfo{Name: "count", Namespace: "math",
+				Func: func(params interface{}, send func(part util.Map) error) *Response {
+					for i := 1; i <= 2; i++ {
+						if err := send(util.Map{"n": i}); err != nil {
+							return Error("-1", err.Error(), nil)
+						}
+					}
+					return Success(util.Map{"total": 2})
+				},
+			})
+		})
+
+		It("should write each part as a line of the HTTP response followed by the final response", func() {
+			data, _ := json.Marshal(Request{JSONRPCVersion: "2.0", ID: "123", Method: "math_count"})
+			req, _ := http.NewRequest("POST", "/rpc", bytes.NewReader(data))
+			req.Header.Set("Accept-Encoding", "gzip")
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				resp := rpc.handle(w, r)
+				Expect(resp.Err).To(BeNil())
+				Expect(resp.More).To(BeFalse())
+			})
+			handler.ServeHTTP(rr, req)
+			Expect(rr.Header().Get("Content-Encoding")).To(BeEmpty())
+
+			dec := json.NewDecoder(rr.Body)
+			var parts []Response
+			for dec.More() {
+				var resp Response
+				Expect(dec.Decode(&resp)).To(Succeed())
+				parts = append(parts, resp)
+			}
+			Expect(parts).To(HaveLen(3))
+			Expect(parts[0].More).To(BeTrue())
+			Expect(parts[0].ID).To(Equal("123"))
+			Expect(parts[0].Result["n"]).To(Equal(1.0))
+			Expect(parts[1].More).To(BeTrue())
+			Expect(parts[1].Result["n"]).To(Equal(2.0))
+			Expect(parts[2].More).To(BeFalse())
+			Expect(parts[2].ID).To(Equal("123"))
+			Expect(parts[2].Result["total"]).To(Equal(2.0))
+		})
+
+		It("should write each part as a websocket message followed by the final response", func() {
+			body, _ := json.Marshal(Request{JSONRPCVersion: "2.0", ID: 1, Method: "math_count"})
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				rpc.handle(w, r)
+			}))
+			defer server.Close()
+			url := "ws" + strings.TrimPrefix(server.URL, "http")
+			ws, _, err := websocket.DefaultDialer.Dial(url, nil)
+			Expect(err).To(BeNil())
+			defer ws.Close()
+
+			ws.WriteMessage(websocket.BinaryMessage, body)
+			var parts []Response
+			for i := 0; i < 3; i++ {
+				_, msg, err := ws.ReadMessage()
+				Expect(err).To(BeNil())
+				var resp Response
+				Expect(json.Unmarshal(msg, &resp)).To(Succeed())
+				parts = append(parts, resp)
+			}
+			Expect(parts[0].More).To(BeTrue())
+			Expect(parts[1].More).To(BeTrue())
+			Expect(parts[2].More).To(BeFalse())
+			Expect(parts[2].Result["total"]).To(Equal(2.0))
+		})
+	})
+
 	When("target method returns nil response", func() {
 		It("should return nil result", func() {
 			rpc.apiSet.Add(MethodInfo{Name: "add", Namespace: "math",
@@ -723,6 +797,65 @@ var _ = Describe("RPC", func() {
 		})
 	})
 
+	Describe("gzip response encoding", func() {
+		var req *http.Request
+		var rr *httptest.ResponseRecorder
+
+		BeforeEach(func() {
+			rpc.apiSet.Add(MethodInfo{
+				Name:      "echo",
+				Namespace: "test",
+				Func: func(params interface{}) *Response {
+					return Success(util.Map{"result": params})
+				},
+			})
+			rr = httptest.NewRecorder()
+		})
+
+		makeReq := func(value string) *http.Request {
+			data, _ := json.Marshal(Request{
+				JSONRPCVersion: "2.0",
+				Method:         "test_echo",
+				Params:         value,
+				ID:             1,
+			})
+			req, _ := http.NewRequest("POST", "/rpc", bytes.NewReader(data))
+			req.Header.Set("Accept-Encoding", "gzip, deflate")
+			return req
+		}
+
+		It("should gzip-encode a large response", func() {
+			value := strings.Repeat("a", GzipMinResponseSize)
+			req = makeReq(value)
+			resp := rpc.handle(rr, req)
+			Expect(resp.Err).To(BeNil())
+			Expect(rr.Header().Get("Content-Encoding")).To(Equal("gzip"))
+
+			gz, err := gzip.NewReader(rr.Body)
+			Expect(err).To(BeNil())
+			body, err := ioutil.ReadAll(gz)
+			Expect(err).To(BeNil())
+			Expect(body).To(Equal(resp.ToJSON()))
+		})
+
+		It("should not gzip-encode a small response", func() {
+			req = makeReq("abc")
+			resp := rpc.handle(rr, req)
+			Expect(resp.Err).To(BeNil())
+			Expect(rr.Header().Get("Content-Encoding")).To(BeEmpty())
+			Expect(rr.Body.Bytes()).To(Equal(resp.ToJSON()))
+		})
+
+		It("should not gzip-encode a large response when client does not accept gzip", func() {
+			req = makeReq(strings.Repeat("a", GzipMinResponseSize))
+			req.Header.Set("Accept-Encoding", "gzip;q=0, deflate")
+			resp := rpc.handle(rr, req)
+			Expect(resp.Err).To(BeNil())
+			Expect(rr.Header().Get("Content-Encoding")).To(BeEmpty())
+			Expect(rr.Body.Bytes()).To(Equal(resp.ToJSON()))
+		})
+	})
+
 	Describe(".MergeAPISet", func() {
 		It("should add API", func() {
 			apiSet1 := APISet([]MethodInfo{
@@ -3,6 +3,7 @@ package types
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/make-os/kit/rpc"
 	"github.com/make-os/kit/types/api"
@@ -117,6 +118,20 @@ type Repo interface {
 
 	// VoteProposal creates transaction to vote for/against a repository's proposal
 	VoteProposal(body *api.BodyRepoVote) (*api.ResultHash, error)
+
+	// StreamDiffOfCommitAndParents streams the diff between a commit
+	// and its parent(s), one file at a time.
+	StreamDiffOfCommitAndParents(name, commitHash string) (CommitDiffIterator, error)
+}
+
+// CommitDiffIterator iterates over the file diffs of a streamed commit diff
+type CommitDiffIterator interface {
+	// Next returns the diff of the next file.
+	// It returns io.EOF when there are no more files.
+	Next() (*api.ResultFileDiff, error)
+
+	// Close ends the stream
+	Close() error
 }
 
 // RPC provides access to the rpc server-related methods
@@ -189,6 +204,14 @@ type Options struct {
 	Port     int
 	User     string
 	Password string
+
+	// MaxIdleConns is the maximum number of idle (keep-alive) connections
+	// kept for reuse. Zero means the client default is used.
+	MaxIdleConns int
+
+	// IdleConnTimeout is the maximum duration an idle connection is kept
+	// before it is closed. Zero means the client default is used.
+	IdleConnTimeout time.Duration
 }
 
 // URL returns a fully formed url to use for making requests
@@ -202,3 +225,9 @@ func (o *Options) URL() string {
 	}
 	return host + "/rpc"
 }
+
+// EventsURL returns a fully formed url to use for subscribing to events
+func (o *Options) EventsURL() string {
+	url := strings.TrimSuffix(o.URL(), "/rpc") + "/events"
+	return "ws" + strings.TrimPrefix(url, "http")
+}
@@ -20,16 +20,22 @@ type BadgerStore struct {
 	lck    *sync.Mutex
 	db     *badger.DB
 	closed bool
+	opts   Options
 }
 
 // Init starts the database.
 // If dir is unset, an in-memory DB is initialized.
 func (b *BadgerStore) init(dir string) error {
 
+	if err := checkCompression(b.opts.Compression); err != nil {
+		return errors.Wrap(err, "bad compression option")
+	}
+
 	opts := badger.DefaultOptions(dir)
 	if dir == "" {
 		opts = opts.WithInMemory(true)
 	}
+	opts = opts.WithCompression(getTableCompression(b.opts.Compression))
 	opts.Logger = &common.NoopLogger{}
 	db, err := badger.Open(opts)
 	if err != nil {
@@ -43,7 +49,7 @@ func (b *BadgerStore) init(dir string) error {
 	// on success ops or discards on failure.
 	// It also enables the renewal of the underlying transaction
 	// after executing a read/write operation
-	b.Tx = NewTx(db, true, true)
+	b.Tx = NewTxWithCompression(db, true, true, b.opts.Compression)
 
 	return nil
 }
@@ -61,7 +67,7 @@ func (b *BadgerStore) GetDB() *badger.DB {
 // renew: re-initializes the transaction after each operation. Requires
 // autoFinish to be enabled.
 func (b *BadgerStore) NewTx(autoFinish, renew bool) types.Tx {
-	return NewTx(b.db, autoFinish, renew)
+	return NewTxWithCompression(b.db, autoFinish, renew, b.opts.Compression)
 }
 
 // Closed checks whether the DB has been closed
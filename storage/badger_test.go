@@ -1,6 +1,9 @@
 package storage_test
 
 import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
 	"os"
 	"testing"
 
@@ -11,6 +14,7 @@ import (
 	"github.com/make-os/kit/storage"
 
 	"github.com/dgraph-io/badger/v2"
+	"github.com/dgraph-io/badger/v2/y"
 
 	"github.com/make-os/kit/config"
 	. "github.com/onsi/ginkgo"
@@ -172,4 +176,103 @@ var _ = Describe("BadgerStore", func() {
 			})
 		})
 	})
+
+	Describe("Compression", func() {
+		var value = bytes.Repeat([]byte("compressible value "), 1000)
+
+		It("should return error when compression algorithm is unknown", func() {
+			_, err := storage.NewBadger("", storage.Options{Compression: "lz4"})
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("unknown compression algorithm"))
+		})
+
+		for _, compression := range []string{storage.CompressionNone, storage.CompressionSnappy, storage.CompressionZSTD} {
+			compression := compression
+			It(fmt.Sprintf("should write and read values with compression=%s", compression), func() {
+				if compression == storage.CompressionZSTD && !y.CgoEnabled {
+					Skip("zstd requires cgo")
+				}
+				db, err := storage.NewBadger("", storage.Options{Compression: compression})
+				Expect(err).To(BeNil())
+				defer db.Close()
+
+				Expect(db.Put(common.NewRecord([]byte("key"), value))).To(BeNil())
+				Expect(db.Put(common.NewRecord([]byte("key2"), []byte("v")))).To(BeNil())
+
+				rec, err := db.Get([]byte("key"))
+				Expect(err).To(BeNil())
+				Expect(rec.Value).To(Equal(value))
+
+				rec, err = db.Get([]byte("key2"))
+				Expect(err).To(BeNil())
+				Expect(rec.Value).To(Equal([]byte("v")))
+
+				var recs []*common.Record
+				db.NewTx(true, true).Iterate([]byte("key"), true, func(rec *common.Record) bool {
+					recs = append(recs, rec)
+					return false
+				})
+				Expect(recs).To(HaveLen(2))
+				Expect(recs[0].Value).To(Equal(value))
+			})
+		}
+
+		It("should skip records whose value cannot be decompressed when iterating", func() {
+			db, err := storage.NewBadger("", storage.Options{Compression: storage.CompressionSnappy})
+			Expect(err).To(BeNil())
+			defer db.Close()
+			Expect(db.Put(common.NewRecord([]byte("key"), value))).To(BeNil())
+
+			tx := db.NewTx(true, true).(*storage.Tx).GetTx()
+			Expect(tx.SetEntry(badger.NewEntry([]byte("key2"), []byte("v")).WithMeta(0xFF))).To(BeNil())
+			Expect(tx.Commit()).To(BeNil())
+
+			var recs []*common.Record
+			db.NewTx(true, true).Iterate([]byte("key"), true, func(rec *common.Record) bool {
+				recs = append(recs, rec)
+				return false
+			})
+			Expect(recs).To(HaveLen(1))
+			Expect(recs[0].Value).To(Equal(value))
+		})
+
+		It("should store compressed values in fewer bytes", func() {
+			db, err := storage.NewBadger("", storage.Options{Compression: storage.CompressionSnappy})
+			Expect(err).To(BeNil())
+			defer db.Close()
+			Expect(db.Put(common.NewRecord([]byte("key"), value))).To(BeNil())
+			item, err := db.NewTx(true, true).(*storage.Tx).GetTx().Get([]byte("key"))
+			Expect(err).To(BeNil())
+			Expect(item.ValueSize()).To(BeNumerically("<", len(value)))
+		})
+
+		It("should read existing values after compression is enabled or disabled", func() {
+			dir, err := ioutil.TempDir("", "")
+			Expect(err).To(BeNil())
+			defer os.RemoveAll(dir)
+
+			db, err := storage.NewBadger(dir)
+			Expect(err).To(BeNil())
+			Expect(db.Put(common.NewRecord([]byte("uncompressed"), value))).To(BeNil())
+			Expect(db.Close()).To(BeNil())
+
+			db, err = storage.NewBadger(dir, storage.Options{Compression: storage.CompressionSnappy})
+			Expect(err).To(BeNil())
+			rec, err := db.Get([]byte("uncompressed"))
+			Expect(err).To(BeNil())
+			Expect(rec.Value).To(Equal(value))
+			Expect(db.Put(common.NewRecord([]byte("compressed"), value))).To(BeNil())
+			Expect(db.Close()).To(BeNil())
+
+			db, err = storage.NewBadger(dir)
+			Expect(err).To(BeNil())
+			defer db.Close()
+			rec, err = db.Get([]byte("compressed"))
+			Expect(err).To(BeNil())
+			Expect(rec.Value).To(Equal(value))
+			rec, err = db.Get([]byte("uncompressed"))
+			Expect(err).To(BeNil())
+			Expect(rec.Value).To(Equal(value))
+		})
+	})
 })
@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2/options"
+	"github.com/dgraph-io/badger/v2/y"
+	"github.com/golang/snappy"
+)
+
+// Supported value compression algorithms
+const (
+	CompressionNone   = "none"
+	CompressionSnappy = "snappy"
+	CompressionZSTD   = "zstd"
+)
+
+// Value meta flags stored in the user meta byte of a badger entry.
+// They record how a value was written, allowing values written with
+// or without compression to be read regardless of the current setting.
+const (
+	metaUncompressed byte = iota
+	metaSnappy
+	metaZSTD
+)
+
+// ErrUnknownCompression indicates an unsupported compression algorithm
+var ErrUnknownCompression = fmt.Errorf("unknown compression algorithm")
+
+// Options describes options for creating a BadgerStore
+type Options struct {
+
+	// Compression is the algorithm used to compress values (none, snappy or zstd).
+	// Defaults to none.
+	Compression string
+}
+
+// checkCompression checks whether a compression algorithm is supported
+func checkCompression(compression string) error {
+	switch compression {
+	case "", CompressionNone, CompressionSnappy:
+		return nil
+	case CompressionZSTD:
+		if !y.CgoEnabled {
+			return y.ErrZstdCgo
+		}
+		return nil
+	default:
+		return ErrUnknownCompression
+	}
+}
+
+// getTableCompression returns the badger table compression type of a compression algorithm
+func getTableCompression(compression string) options.CompressionType {
+	switch compression {
+	case CompressionSnappy:
+		return options.Snappy
+	case CompressionZSTD:
+		return options.ZSTD
+	default:
+		return options.None
+	}
+}
+
+// compressValue compresses a value using the given algorithm.
+// It returns the value to store and the meta flag describing it.
+// The value is stored uncompressed if compression does not reduce its size.
+func compressValue(compression string, val []byte) ([]byte, byte, error) {
+	var out []byte
+	var meta byte
+	switch compression {
+	case CompressionSnappy:
+		out, meta = snappy.Encode(nil, val), metaSnappy
+	case CompressionZSTD:
+		var err error
+		if out, err = y.ZSTDCompress(nil, val, 1); err != nil {
+			return nil, 0, err
+		}
+		meta = metaZSTD
+	default:
+		return val, metaUncompressed, nil
+	}
+
+	if len(out) >= len(val) {
+		return val, metaUncompressed, nil
+	}
+
+	return out, meta, nil
+}
+
+// decompressValue decompresses a value according to its meta flag
+func decompressValue(meta byte, val []byte) ([]byte, error) {
+	switch meta {
+	case metaUncompressed:
+		return val, nil
+	case metaSnappy:
+		return snappy.Decode(nil, val)
+	case metaZSTD:
+		return y.ZSTDDecompress(nil, val)
+	default:
+		return nil, ErrUnknownCompression
+	}
+}
@@ -16,7 +16,10 @@ func NewBadgerTMDB(dir string) (tmdb.DB, error) {
 }
 
 // NewBadger creates an instance of BadgerStore.
-func NewBadger(dir string) (*BadgerStore, error) {
+func NewBadger(dir string, opts ...Options) (*BadgerStore, error) {
 	s := &BadgerStore{lck: &sync.Mutex{}}
+	if len(opts) > 0 {
+		s.opts = opts[0]
+	}
 	return s, s.init(dir)
 }
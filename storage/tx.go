@@ -26,6 +26,9 @@ type Tx struct {
 	// renew determines whether the tx is renewed after successful
 	// commit/discard
 	renew bool
+
+	// compression is the algorithm used to compress values
+	compression string
 }
 
 // NewTx returns an instance of Tx
@@ -33,6 +36,14 @@ func NewTx(db *badger.DB, finish, renew bool) *Tx {
 	return &Tx{db: db, tx: db.NewTransaction(true), finish: finish, renew: renew}
 }
 
+// NewTxWithCompression returns an instance of Tx that compresses
+// values using the given compression algorithm.
+func NewTxWithCompression(db *badger.DB, finish, renew bool, compression string) *Tx {
+	tx := NewTx(db, finish, renew)
+	tx.compression = compression
+	return tx
+}
+
 // GetTx get the underlying transaction
 func (t *Tx) GetTx() *badger.Txn {
 	t.Lock()
@@ -46,7 +57,7 @@ func (t *Tx) GetTx() *badger.Txn {
 // renew: reinitialize the transaction after each operation. Requires
 // autoFinish to be enabled.
 func (t *Tx) NewTx(autoFinish, renew bool) types.Tx {
-	return NewTx(t.db, autoFinish, renew)
+	return NewTxWithCompression(t.db, autoFinish, renew, t.compression)
 }
 
 // CanFinish checks whether transaction is automatically committed
@@ -98,9 +109,14 @@ func (t *Tx) Discard() {
 // Put adds a record to the database.
 // It will discard the transaction if an error occurred.
 func (t *Tx) Put(record *common.Record) error {
+	val, meta, err := compressValue(t.compression, record.Value)
+	if err != nil {
+		return errors.Wrap(err, "failed to compress value")
+	}
+
 	t.renewTx()
 	t.Lock()
-	err := t.tx.Set(record.GetKey(), record.Value)
+	err = t.tx.SetEntry(badger.NewEntry(record.GetKey(), val).WithMeta(meta))
 	if err != nil {
 		t.Unlock()
 		t.Discard()
@@ -129,6 +145,10 @@ func (t *Tx) Get(key []byte) (*common.Record, error) {
 		return nil, errors.Wrap(err, "failed to read value")
 	}
 
+	if val, err = decompressValue(item.UserMeta(), val); err != nil {
+		return nil, errors.Wrap(err, "failed to decompress value")
+	}
+
 	return common.NewFromKeyValue(key, val), nil
 }
 
@@ -160,7 +180,9 @@ func (t *Tx) Del(key []byte) error {
 // If iterFunc returns true, the iterator is stopped and immediately released.
 //
 // If first is set to true, it begins from the first record, otherwise,
-// it will begin from the last record
+// it will begin from the last record.
+//
+// Records whose value cannot be read or decompressed are skipped.
 func (t *Tx) Iterate(prefix []byte, first bool, iterFunc func(rec *common.Record) bool) {
 	t.renewTx()
 	opts := badger.DefaultIteratorOptions
@@ -180,7 +202,13 @@ func (t *Tx) Iterate(prefix []byte, first bool, iterFunc func(rec *common.Record
 	for it.Seek(prefixKey); it.ValidForPrefix(prefix); it.Next() {
 		item := it.Item()
 		k := item.Key()
-		v, _ := item.ValueCopy(nil)
+		v, err := item.ValueCopy(nil)
+		if err != nil {
+			continue
+		}
+		if v, err = decompressValue(item.UserMeta(), v); err != nil {
+			continue
+		}
 		if iterFunc(common.NewFromKeyValue(k, v)) {
 			return
 		}
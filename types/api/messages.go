@@ -90,6 +90,12 @@ type ResultRepository struct {
 	*state.Repository `json:",flatten"`
 }
 
+// ResultFileDiff is a part of a streamed commit diff
+type ResultFileDiff struct {
+	Parent string `json:"parent"`
+	Diff   string `json:"diff"`
+}
+
 // GetRepoOpts contains arguments for fetching a repository
 type GetRepoOpts struct {
 	Height      uint64 `json:"height"`
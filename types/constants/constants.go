@@ -40,3 +40,10 @@ const (
 	AddrHRP     = "os"
 	PushAddrHRP = "pk"
 )
+
+// Events
+const (
+	// EvtBlockCommitted is emitted after a block is committed.
+	// Args: *state.BlockInfo, []types.BaseTx (the transactions accepted in the block)
+	EvtBlockCommitted = "block_committed"
+)
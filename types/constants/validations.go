@@ -3,6 +3,7 @@ package constants
 import "fmt"
 
 var (
-	ErrProposalFeeNotExpected  = fmt.Errorf("proposal fee is not expected")
-	ErrFullProposalFeeRequired = fmt.Errorf("full proposal fee is required")
+	ErrProposalFeeNotExpected   = fmt.Errorf("proposal fee is not expected")
+	ErrFullProposalFeeRequired  = fmt.Errorf("full proposal fee is required")
+	ErrOpenProposalLimitReached = fmt.Errorf("repo has reached its open proposal limit")
 )
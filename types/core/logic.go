@@ -108,6 +108,12 @@ type TrackedRepo struct {
 	UpdatedAt util.UInt64 `json:"updatedAt" msgpack:"updatedAt"`
 }
 
+// BranchActivity stores the tip and last activity time of a repository branch
+type BranchActivity struct {
+	Hash           string `json:"hash" msgpack:"hash"`
+	LastCommitTime int64  `json:"lastCommitTime" msgpack:"lastCommitTime"`
+}
+
 // RepoSyncInfoKeeper describes an interface for managing tracking and
 // synchronization state of repositories.
 type RepoSyncInfoKeeper interface {
@@ -117,6 +123,9 @@ type RepoSyncInfoKeeper interface {
 	UnTrack(repos string) error
 	UpdateRefLastSyncHeight(repo, ref string, height uint64) error
 	GetRefLastSyncHeight(repo, ref string) (uint64, error)
+	UpdateBranchActivity(repo, branch string, activity *BranchActivity) error
+	RemoveBranchActivity(repo, branch string) error
+	GetBranchActivities(repo string) (map[string]*BranchActivity, error)
 }
 
 // RepoKeeper describes an interface for accessing repository data
@@ -209,6 +218,13 @@ type RepoKeeper interface {
 	// ARGS:
 	// - address: A 20 byte address
 	GetReposCreatedByAddress(address []byte) (res []string, err error)
+
+	// Iterate calls fn for each repository in the state tree.
+	// Iteration stops if fn returns true.
+	//
+	// ARGS:
+	// - fn: The function called with the name and object of each repository
+	Iterate(fn func(name string, repo *state.Repository) bool)
 }
 
 // EndingProposals describes a proposal ending height
@@ -238,6 +254,11 @@ type NamespaceKeeper interface {
 	//  - name: The name of the namespace to update
 	//  - udp: The updated namespace object to replace the existing object.
 	Update(name string, upd *state.Namespace)
+
+	// GetRepoNamespaces returns the namespace domains that target a repo
+	//  ARGS:
+	//  - repo: The name of the repository
+	GetRepoNamespaces(repo string) []*state.RepoNamespace
 }
 
 // PushKeyKeeper describes an interface for accessing push public key information
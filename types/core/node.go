@@ -15,4 +15,5 @@ type BlockGetter interface {
 const (
 	EvtTxPushProcessed = "tx_push_added"
 	EvtNewEpoch        = "new_epoch"
+	EvtRepoUpdated     = "repo_updated"
 )
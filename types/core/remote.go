@@ -65,6 +65,9 @@ type RemoteServer interface {
 	// InitRepository creates a local git repository
 	InitRepository(name string) error
 
+	// HasRepository returns true if a valid local repository exist for the given name
+	HasRepository(name string) bool
+
 	// BroadcastMsg broadcast messages to peers
 	BroadcastMsg(ch byte, msg []byte)
 
@@ -91,6 +94,19 @@ type RemoteServer interface {
 	// GetDHT returns the dht service
 	GetDHT() dht2.DHT
 
+	// GetNoteEndorsements returns the endorsements received for a push note
+	GetNoteEndorsements(noteID string) []*pushtypes.PushEndorsement
+
+	// GetRecentPushTx returns the hash of the push transaction of a recently
+	// accepted push with the given content key or empty string if not found.
+	GetRecentPushTx(key string) string
+
+	// RegisterRecentPush records the push transaction hash of a push with the
+	// given content key. The push is considered recently accepted for the
+	// duration of the duplicate push window once the transaction is included
+	// in a block.
+	RegisterRecentPush(key, txHash string)
+
 	// Shutdown shuts down the server
 	Shutdown(ctx context.Context)
 
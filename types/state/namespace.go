@@ -25,6 +25,12 @@ func (nd *NamespaceDomains) Get(domain string) string {
 	return (*nd)[domain]
 }
 
+// RepoNamespace describes a namespace domain that targets a repository
+type RepoNamespace struct {
+	Namespace string `json:"namespace" mapstructure:"namespace" msgpack:"namespace"`
+	Domain    string `json:"domain" mapstructure:"domain" msgpack:"domain"`
+}
+
 // BareNamespace returns an empty namespace object
 func BareNamespace() *Namespace {
 	return &Namespace{
@@ -24,6 +24,19 @@ const (
 	VoterNetStakersAndVetoOwner                  // Only network stakeholders and veto owners can vote.
 )
 
+// String returns the name of the voter type
+func (p VoterType) String() string {
+	switch p {
+	case VoterOwner:
+		return "Owner"
+	case VoterNetStakers:
+		return "NetStakers"
+	case VoterNetStakersAndVetoOwner:
+		return "NetStakersAndVetoOwner"
+	}
+	return "Unknown"
+}
+
 // IsValidVoterType checks if v is a valid VoterType
 func IsValidVoterType(v *int) bool {
 	return funk.Contains([]VoterType{
@@ -46,6 +59,17 @@ const (
 	ProposalCreatorOwner
 )
 
+// String returns the name of the proposal creator type
+func (p ProposalCreatorType) String() string {
+	switch p {
+	case ProposalCreatorAny:
+		return "Any"
+	case ProposalCreatorOwner:
+		return "Owner"
+	}
+	return "Unknown"
+}
+
 // IsValidProposalCreatorType checks if v is a valid ProposalCreatorType
 func IsValidProposalCreatorType(v *int) bool {
 	return funk.Contains([]ProposalCreatorType{
@@ -73,6 +97,29 @@ const (
 	ProposalFeeRefundOnBelowThresholdAcceptAllReject
 )
 
+// String returns the name of the proposal fee refund type
+func (p PropFeeRefundType) String() string {
+	switch p {
+	case ProposalFeeRefundNo:
+		return "No"
+	case ProposalFeeRefundOnAccept:
+		return "OnAccept"
+	case ProposalFeeRefundOnAcceptReject:
+		return "OnAcceptReject"
+	case ProposalFeeRefundOnAcceptAllReject:
+		return "OnAcceptAllReject"
+	case ProposalFeeRefundOnBelowThreshold:
+		return "OnBelowThreshold"
+	case ProposalFeeRefundOnBelowThresholdAccept:
+		return "OnBelowThresholdAccept"
+	case ProposalFeeRefundOnBelowThresholdAcceptReject:
+		return "OnBelowThresholdAcceptReject"
+	case ProposalFeeRefundOnBelowThresholdAcceptAllReject:
+		return "OnBelowThresholdAcceptAllReject"
+	}
+	return "Unknown"
+}
+
 // IsValidPropFeeRefundTypeType checks if v is a valid PropFeeRefundTypeType
 func IsValidPropFeeRefundTypeType(v *int) bool {
 	return funk.Contains([]PropFeeRefundType{
@@ -87,6 +134,82 @@ func IsValidPropFeeRefundTypeType(v *int) bool {
 	}, PropFeeRefundType(pointer.GetInt(v)))
 }
 
+// ProposalQuorumMode represents a type for how the total voting power
+// used to compute the quorum of a repo proposal is determined
+type ProposalQuorumMode int
+
+func (p ProposalQuorumMode) Ptr() *int {
+	v := int(p)
+	return &v
+}
+
+const (
+	// ProposalQuorumModeLive computes the quorum from the total voting power
+	// at the time the proposal is tallied.
+	ProposalQuorumModeLive ProposalQuorumMode = iota
+
+	// ProposalQuorumModeSnapshot computes the quorum from the total voting
+	// power at the time the proposal was created.
+	ProposalQuorumModeSnapshot
+)
+
+// String returns the name of the quorum mode
+func (p ProposalQuorumMode) String() string {
+	switch p {
+	case ProposalQuorumModeLive:
+		return "Live"
+	case ProposalQuorumModeSnapshot:
+		return "Snapshot"
+	}
+	return "Unknown"
+}
+
+// IsValidProposalQuorumMode checks if v is a valid ProposalQuorumMode
+func IsValidProposalQuorumMode(v *int) bool {
+	return funk.Contains([]ProposalQuorumMode{
+		ProposalQuorumModeLive,
+		ProposalQuorumModeSnapshot,
+	}, ProposalQuorumMode(pointer.GetInt(v)))
+}
+
+// ProposalCloseTrigger represents a type for what causes a finalized
+// repo proposal to be marked as closed
+type ProposalCloseTrigger int
+
+func (p ProposalCloseTrigger) Ptr() *int {
+	v := int(p)
+	return &v
+}
+
+const (
+	// ProposalCloseTriggerManual leaves a proposal open until an action
+	// (e.g. a merge push) closes it.
+	ProposalCloseTriggerManual ProposalCloseTrigger = iota
+
+	// ProposalCloseTriggerDeadline closes a proposal at the end of the
+	// block in which its voting period ends.
+	ProposalCloseTriggerDeadline
+)
+
+// String returns the name of the close trigger
+func (p ProposalCloseTrigger) String() string {
+	switch p {
+	case ProposalCloseTriggerManual:
+		return "Manual"
+	case ProposalCloseTriggerDeadline:
+		return "Deadline"
+	}
+	return "Unknown"
+}
+
+// IsValidProposalCloseTrigger checks if v is a valid ProposalCloseTrigger
+func IsValidProposalCloseTrigger(v *int) bool {
+	return funk.Contains([]ProposalCloseTrigger{
+		ProposalCloseTriggerManual,
+		ProposalCloseTriggerDeadline,
+	}, ProposalCloseTrigger(pointer.GetInt(v)))
+}
+
 // ProposalTallyMethod represents a type for repo proposal counting method
 type ProposalTallyMethod int
 
@@ -103,6 +226,23 @@ const (
 	ProposalTallyMethodNetStakeOfDelegators
 )
 
+// String returns the name of the tally method
+func (p ProposalTallyMethod) String() string {
+	switch p {
+	case ProposalTallyMethodIdentity:
+		return "Identity"
+	case ProposalTallyMethodCoinWeighted:
+		return "CoinWeighted"
+	case ProposalTallyMethodNetStake:
+		return "NetStake"
+	case ProposalTallyMethodNetStakeNonDelegated:
+		return "NetStakeNonDelegated"
+	case ProposalTallyMethodNetStakeOfDelegators:
+		return "NetStakeOfDelegators"
+	}
+	return "Unknown"
+}
+
 // IsValidProposalTallyMethod checks if v is a valid ProposalTallyMethod
 func IsValidProposalTallyMethod(v *int) bool {
 	return funk.Contains([]ProposalTallyMethod{
@@ -161,6 +301,8 @@ type Proposal interface {
 	GetPowerAge() uint64
 	GetEndAt() uint64
 	GetQuorum() float64
+	GetQuorumMode() ProposalQuorumMode
+	GetQuorumPower() float64
 	GetTallyMethod() ProposalTallyMethod
 	GetAction() types.TxCode
 	GetActionData() map[string]util.Bytes
@@ -219,6 +361,7 @@ type RepoProposal struct {
 	Abstain            float64               `json:"abstain" mapstructure:"abstain" msgpack:"abstain"`                                  // Count of explicit "abstain" votes
 	Fees               ProposalFees          `json:"fees" mapstructure:"fees" msgpack:"fees"`                                           // Count of explicit "abstain" votes
 	Outcome            ProposalOutcome       `json:"outcome" mapstructure:"outcome" msgpack:"outcome"`                                  // The outcome of the proposal vote.
+	QuorumPower        float64               `json:"quorumPower" mapstructure:"quorumPower" msgpack:"quorumPower"`                      // The total voting power at creation (only in snapshot quorum mode).
 }
 
 // ProposalActionData represents action data of a proposal
@@ -259,6 +402,20 @@ func (p *RepoProposal) IsDepositedFeeOK() bool {
 	return p.Fees.Total().GreaterThanOrEqual(propFee)
 }
 
+// WouldExceedFeeCap checks whether depositing the given amount would cause
+// the fees deposited to the proposal to exceed the proposal fee cap.
+// A cap of zero means the deposits are not capped.
+func (p *RepoProposal) WouldExceedFeeCap(amount decimal.Decimal) bool {
+	if p.Config == nil {
+		return false
+	}
+	feeCap := decimal.NewFromFloat(cast.ToFloat64(pointer.GetString(p.Config.PropFeeDepositCap)))
+	if feeCap.LessThanOrEqual(decimal.Zero) {
+		return false
+	}
+	return p.Fees.Total().Add(amount).GreaterThan(feeCap)
+}
+
 // GetCreator implements Proposal
 func (p *RepoProposal) GetCreator() string {
 	return p.Creator
@@ -282,7 +439,8 @@ func (p *RepoProposal) EncodeMsgpack(enc *msgpack.Encoder) error {
 		p.NoWithVetoByOwners,
 		p.Abstain,
 		p.Fees,
-		p.Outcome)
+		p.Outcome,
+		p.QuorumPower)
 }
 
 // DecodeMsgpack implements msgpack.CustomDecoder
@@ -303,7 +461,8 @@ func (p *RepoProposal) DecodeMsgpack(dec *msgpack.Decoder) error {
 		&p.NoWithVetoByOwners,
 		&p.Abstain,
 		&p.Fees,
-		&p.Outcome)
+		&p.Outcome,
+		&p.QuorumPower)
 }
 
 // IsFinalized implements Proposal
@@ -356,6 +515,16 @@ func (p *RepoProposal) GetQuorum() float64 {
 	return cast.ToFloat64(pointer.GetString(p.Config.PropQuorum))
 }
 
+// GetQuorumMode implements Proposal
+func (p *RepoProposal) GetQuorumMode() ProposalQuorumMode {
+	return ProposalQuorumMode(pointer.GetInt(p.Config.PropQuorumMode))
+}
+
+// GetQuorumPower implements Proposal
+func (p *RepoProposal) GetQuorumPower() float64 {
+	return p.QuorumPower
+}
+
 // GetTallyMethod implements Proposal
 func (p *RepoProposal) GetTallyMethod() ProposalTallyMethod {
 	return ProposalTallyMethod(*p.Config.PropTallyMethod)
@@ -424,6 +593,17 @@ func (p *RepoProposals) Get(id string) *RepoProposal {
 	return (*p)[id]
 }
 
+// CountOpen returns the number of proposals that have not been finalized
+func (p *RepoProposals) CountOpen() int {
+	count := 0
+	for _, prop := range *p {
+		if !prop.IsFinalized() {
+			count++
+		}
+	}
+	return count
+}
+
 // ForEach iterates through items, passing each to the callback function
 func (p *RepoProposals) ForEach(itr func(prop *RepoProposal, id string) error) error {
 	for id, prop := range *p {
@@ -3,6 +3,9 @@ package state
 import (
 	"fmt"
 
+	"github.com/AlekSi/pointer"
+	"github.com/shopspring/decimal"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -69,4 +72,23 @@ var _ = Describe("RepoProposals", func() {
 			Expect(err).To(Equal(expectedErr))
 		})
 	})
+
+	Describe("RepoProposal.WouldExceedFeeCap", func() {
+		It("should return false if proposal has no fee cap", func() {
+			prop := &RepoProposal{Config: &RepoConfigGovernance{}, Fees: map[string]string{"addr1": "100"}}
+			Expect(prop.WouldExceedFeeCap(decimal.NewFromFloat(1000))).To(BeFalse())
+			prop.Config.PropFeeDepositCap = pointer.ToString("0")
+			Expect(prop.WouldExceedFeeCap(decimal.NewFromFloat(1000))).To(BeFalse())
+		})
+
+		It("should return false if deposited fees and amount do not exceed the cap", func() {
+			prop := &RepoProposal{Config: &RepoConfigGovernance{PropFeeDepositCap: pointer.ToString("150")}, Fees: map[string]string{"addr1": "100"}}
+			Expect(prop.WouldExceedFeeCap(decimal.NewFromFloat(50))).To(BeFalse())
+		})
+
+		It("should return true if deposited fees and amount exceed the cap", func() {
+			prop := &RepoProposal{Config: &RepoConfigGovernance{PropFeeDepositCap: pointer.ToString("150")}, Fees: map[string]string{"addr1": "100"}}
+			Expect(prop.WouldExceedFeeCap(decimal.NewFromFloat(50.5))).To(BeTrue())
+		})
+	})
 })
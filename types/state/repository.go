@@ -2,12 +2,15 @@ package state
 
 import (
 	"encoding/json"
+	"path"
+	"time"
 
 	"github.com/AlekSi/pointer"
 	"github.com/make-os/kit/crypto/ed25519"
 	"github.com/make-os/kit/params"
 	"github.com/make-os/kit/util"
 	"github.com/spf13/cast"
+	"github.com/thoas/go-funk"
 	"github.com/vmihailenco/msgpack"
 )
 
@@ -61,6 +64,9 @@ type Reference struct {
 
 	// Hash is the current hash of the reference
 	Hash util.Bytes `json:"hash" mapstructure:"hash" msgpack:"hash,omitempty"`
+
+	// CreatedAt is the block height at which the reference was created
+	CreatedAt util.UInt64 `json:"createdAt" mapstructure:"createdAt" msgpack:"createdAt,omitempty"`
 }
 
 // IsNil checks whether the reference fields are all empty
@@ -69,11 +75,11 @@ func (r *Reference) IsNil() bool {
 }
 
 func (r *Reference) EncodeMsgpack(enc *msgpack.Encoder) error {
-	return r.EncodeMulti(enc, r.Creator, r.Nonce, r.Hash, r.Data)
+	return r.EncodeMulti(enc, r.Creator, r.Nonce, r.Hash, r.Data, r.CreatedAt)
 }
 
 func (r *Reference) DecodeMsgpack(dec *msgpack.Decoder) error {
-	return r.DecodeMulti(dec, &r.Creator, &r.Nonce, &r.Hash, &r.Data)
+	return r.DecodeMulti(dec, &r.Creator, &r.Nonce, &r.Hash, &r.Data, &r.CreatedAt)
 }
 
 // References represents a collection of references
@@ -128,7 +134,10 @@ type RepoConfigGovernance struct {
 	PropDuration         *string `json:"propDur,omitempty" mapstructure:"propDur,omitempty" msgpack:"propDur,omitempty"`
 	PropFee              *string `json:"propFee,omitempty" mapstructure:"propFee,omitempty" msgpack:"propFee,omitempty"`
 	PropFeeDepositDur    *string `json:"propFeeDepDur,omitempty" mapstructure:"propFeeDepDur,omitempty" msgpack:"propFeeDepDur,omitempty"`
+	PropFeeDepositCap    *string `json:"propFeeDepCap,omitempty" mapstructure:"propFeeDepCap,omitempty" msgpack:"propFeeDepCap,omitempty"`
 	PropQuorum           *string `json:"propQuorum,omitempty" mapstructure:"propQuorum,omitempty" msgpack:"propQuorum,omitempty"`
+	PropQuorumMode       *int    `json:"propQuorumMode,omitempty" mapstructure:"propQuorumMode,omitempty" msgpack:"propQuorumMode,omitempty"`
+	PropCloseTrigger     *int    `json:"propCloseTrigger,omitempty" mapstructure:"propCloseTrigger,omitempty" msgpack:"propCloseTrigger,omitempty"`
 	PropVetoQuorum       *string `json:"propVetoQuorum,omitempty" mapstructure:"propVetoQuorum,omitempty" msgpack:"propVetoQuorum,omitempty"`
 	PropVetoOwnersQuorum *string `json:"propVetoOwnersQuorum,omitempty" mapstructure:"propVetoOwnersQuorum,omitempty" msgpack:"propVetoOwnersQuorum,omitempty"`
 	PropThreshold        *string `json:"propThreshold,omitempty" mapstructure:"propThreshold,omitempty" msgpack:"propThreshold,omitempty"`
@@ -137,6 +146,7 @@ type RepoConfigGovernance struct {
 	UsePowerAge          *bool   `json:"usePowerAge,omitempty" mapstructure:"usePowerAge,omitempty" msgpack:"usePowerAge,omitempty"`
 	CreatorAsContributor *bool   `json:"creatorAsContrib,omitempty" mapstructure:"creatorAsContrib,omitempty" msgpack:"creatorAsContrib,omitempty"`
 	NoPropFeeForMergeReq *bool   `json:"noPropFeeForMergeReq,omitempty" mapstructure:"noPropFeeForMergeReq,omitempty" msgpack:"noPropFeeForMergeReq,omitempty"`
+	MaxOpenProposals     *string `json:"maxOpenProps,omitempty" mapstructure:"maxOpenProps,omitempty" msgpack:"maxOpenProps,omitempty"`
 }
 
 func (b RepoConfigGovernance) MarshalJSON() ([]byte, error) {
@@ -153,6 +163,12 @@ func (b RepoConfigGovernance) MarshalJSON() ([]byte, error) {
 	if _, ok := m["propTallyMethod"]; ok {
 		m["propTallyMethod"] = cast.ToString(m["propTallyMethod"])
 	}
+	if _, ok := m["propQuorumMode"]; ok {
+		m["propQuorumMode"] = cast.ToString(m["propQuorumMode"])
+	}
+	if _, ok := m["propCloseTrigger"]; ok {
+		m["propCloseTrigger"] = cast.ToString(m["propCloseTrigger"])
+	}
 	return json.Marshal(m)
 }
 
@@ -174,23 +190,192 @@ type ContributorPolicy struct {
 // key is policy id
 type RepoPolicies []*Policy
 
+// CommitMsgPolicy describes the format pushed commit messages must follow
+type CommitMsgPolicy struct {
+	// Pattern is a regular expression the commit message must match
+	Pattern string `json:"pattern,omitempty" mapstructure:"pattern,omitempty" msgpack:"pattern,omitempty"`
+
+	// Conventional requires commit messages to follow the conventional commits format
+	Conventional bool `json:"conventional,omitempty" mapstructure:"conventional,omitempty" msgpack:"conventional,omitempty"`
+}
+
+// IsEmpty checks whether the policy has no rule set
+func (p *CommitMsgPolicy) IsEmpty() bool {
+	return p == nil || (p.Pattern == "" && !p.Conventional)
+}
+
+// RepoAccess describes who can read (clone/fetch) a repository
+type RepoAccess struct {
+	// Private prevents requesters that are not owners or
+	// in the allow-list from reading the repository
+	Private bool `json:"private,omitempty" mapstructure:"private,omitempty" msgpack:"private,omitempty"`
+
+	// Allowed contains user addresses and push key IDs permitted to read a private repository
+	Allowed []string `json:"allowed,omitempty" mapstructure:"allowed,omitempty" msgpack:"allowed,omitempty"`
+}
+
+// IsPrivate checks whether the repository is private
+func (a *RepoAccess) IsPrivate() bool {
+	return a != nil && a.Private
+}
+
+// IsAllowed checks whether any of the given user addresses or push key IDs is in the allow-list
+func (a *RepoAccess) IsAllowed(ids ...string) bool {
+	if a == nil {
+		return false
+	}
+	for _, id := range ids {
+		if id != "" && funk.ContainsString(a.Allowed, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEmpty checks whether no access rule is set
+func (a *RepoAccess) IsEmpty() bool {
+	return a == nil || (!a.Private && len(a.Allowed) == 0)
+}
+
+// Post retention actions
+const (
+	// PostRetentionArchive hides expired posts from default listings
+	PostRetentionArchive = "archive"
+
+	// PostRetentionPrune deletes the references of expired posts
+	PostRetentionPrune = "prune"
+)
+
+// Merge strategies
+const (
+	// MergeStrategyMergeCommit lands a merge request with a merge commit
+	MergeStrategyMergeCommit = "merge-commit"
+
+	// MergeStrategySquash lands a merge request as a single squashed commit
+	MergeStrategySquash = "squash"
+
+	// MergeStrategyRebase lands a merge request by rebasing its commits onto the base
+	MergeStrategyRebase = "rebase"
+)
+
+// IsValidMergeStrategy checks whether strategy is a known merge strategy
+func IsValidMergeStrategy(strategy string) bool {
+	return strategy == MergeStrategyMergeCommit || strategy == MergeStrategySquash || strategy == MergeStrategyRebase
+}
+
+// PostRetentionPolicy describes how long closed issues and merge requests are kept
+type PostRetentionPolicy struct {
+	// ClosedTTL is the number of seconds a post must have been closed for it to expire
+	ClosedTTL uint64 `json:"closedTTL,omitempty" mapstructure:"closedTTL,omitempty" msgpack:"closedTTL,omitempty"`
+
+	// Action is the action applied to expired posts (archive or prune)
+	Action string `json:"action,omitempty" mapstructure:"action,omitempty" msgpack:"action,omitempty"`
+}
+
+// IsEmpty checks whether no retention rule is set
+func (p *PostRetentionPolicy) IsEmpty() bool {
+	return p == nil || (p.ClosedTTL == 0 && p.Action == "")
+}
+
+// IsExpired checks whether a post closed at closedAt has expired at the given time
+func (p *PostRetentionPolicy) IsExpired(closedAt, now time.Time) bool {
+	if p == nil || p.ClosedTTL == 0 || closedAt.IsZero() {
+		return false
+	}
+	return now.Sub(closedAt) >= time.Duration(p.ClosedTTL)*time.Second
+}
+
+// SignaturePolicy describes which pushed references require signed commits and tags
+type SignaturePolicy struct {
+	// Required requires commits and tags pushed to any reference to be signed
+	Required bool `json:"required,omitempty" mapstructure:"required,omitempty" msgpack:"required,omitempty"`
+
+	// Unsigned contains glob patterns (e.g refs/heads/wip/*) of
+	// references that accept unsigned commits and tags
+	Unsigned []string `json:"unsigned,omitempty" mapstructure:"unsigned,omitempty" msgpack:"unsigned,omitempty"`
+}
+
+// IsEmpty checks whether no signature rule is set
+func (p *SignaturePolicy) IsEmpty() bool {
+	return p == nil || (!p.Required && len(p.Unsigned) == 0)
+}
+
+// RequiresSignature checks whether commits and tags pushed to the given reference must be signed
+func (p *SignaturePolicy) RequiresSignature(ref string) bool {
+	if p == nil || !p.Required {
+		return false
+	}
+	for _, pattern := range p.Unsigned {
+		if ok, _ := path.Match(pattern, ref); ok {
+			return false
+		}
+	}
+	return true
+}
+
 // RepoConfig contains repo-specific configuration settings
 type RepoConfig struct {
 	util.CodecUtil `json:"-" mapstructure:"-" msgpack:"-"`
 	Gov            *RepoConfigGovernance `json:"governance,omitempty" mapstructure:"governance,omitempty" msgpack:"governance,omitempty"`
 	Policies       RepoPolicies          `json:"policies,omitempty" mapstructure:"policies,omitempty" msgpack:"policies,omitempty"`
+	CommitMsg      *CommitMsgPolicy      `json:"commitMsg,omitempty" mapstructure:"commitMsg,omitempty" msgpack:"commitMsg,omitempty"`
+
+	// Upstream is the name of the repository this repository was forked from
+	Upstream string `json:"upstream,omitempty" mapstructure:"upstream,omitempty" msgpack:"upstream,omitempty"`
+
+	// Access describes who can read the repository
+	Access *RepoAccess `json:"access,omitempty" mapstructure:"access,omitempty" msgpack:"access,omitempty"`
+
+	// DefaultReviewers contains push key IDs assigned as reviewers
+	// to new merge requests that do not specify any reviewer
+	DefaultReviewers []string `json:"defaultReviewers,omitempty" mapstructure:"defaultReviewers,omitempty" msgpack:"defaultReviewers,omitempty"`
+
+	// PostRetention describes how long closed issues and merge requests are kept
+	PostRetention *PostRetentionPolicy `json:"postRetention,omitempty" mapstructure:"postRetention,omitempty" msgpack:"postRetention,omitempty"`
+
+	// Signature describes which references require signed commits and tags
+	Signature *SignaturePolicy `json:"signature,omitempty" mapstructure:"signature,omitempty" msgpack:"signature,omitempty"`
+
+	// AllowTagUpdate allows existing tags to be re-pointed to a different object
+	AllowTagUpdate bool `json:"allowTagUpdate,omitempty" mapstructure:"allowTagUpdate,omitempty" msgpack:"allowTagUpdate,omitempty"`
+
+	// TagGracePeriod is the number of blocks after a tag is created during
+	// which its creator can still re-point it. Once it elapses, the tag is immutable.
+	TagGracePeriod uint64 `json:"tagGracePeriod,omitempty" mapstructure:"tagGracePeriod,omitempty" msgpack:"tagGracePeriod,omitempty"`
+
+	// MergeStrategy is the preferred way merge requests are landed (merge-commit, squash or rebase).
+	// It records the team's preference; it is not enforced by the network.
+	MergeStrategy string `json:"mergeStrategy,omitempty" mapstructure:"mergeStrategy,omitempty" msgpack:"mergeStrategy,omitempty"`
 }
 
 func (c *RepoConfig) EncodeMsgpack(enc *msgpack.Encoder) error {
 	return c.EncodeMulti(enc,
 		c.Gov,
-		c.Policies)
+		c.Policies,
+		c.CommitMsg,
+		c.Upstream,
+		c.Access,
+		c.DefaultReviewers,
+		c.PostRetention,
+		c.Signature,
+		c.AllowTagUpdate,
+		c.TagGracePeriod,
+		c.MergeStrategy)
 }
 
 func (c *RepoConfig) DecodeMsgpack(dec *msgpack.Decoder) error {
 	return c.DecodeMulti(dec,
 		&c.Gov,
-		&c.Policies)
+		&c.Policies,
+		&c.CommitMsg,
+		&c.Upstream,
+		&c.Access,
+		&c.DefaultReviewers,
+		&c.PostRetention,
+		&c.Signature,
+		&c.AllowTagUpdate,
+		&c.TagGracePeriod,
+		&c.MergeStrategy)
 }
 
 // Clone clones c
@@ -209,7 +394,10 @@ func (c *RepoConfig) Merge(upd map[string]interface{}) error {
 
 // IsEmpty checks if c considered empty
 func (c *RepoConfig) IsEmpty() bool {
-	return (c.Gov == nil || len(util.ToMap(c.Gov)) == 0) && len(c.Policies) == 0
+	return (c.Gov == nil || len(util.ToMap(c.Gov)) == 0) && len(c.Policies) == 0 && c.CommitMsg.IsEmpty() &&
+		c.Upstream == "" && c.Access.IsEmpty() && len(c.DefaultReviewers) == 0 && c.PostRetention.IsEmpty() &&
+		c.Signature.IsEmpty() && !c.AllowTagUpdate && c.TagGracePeriod == 0 &&
+		c.MergeStrategy == ""
 }
 
 // ToJSONToMap converts c to a JSON map and the map to go map.
@@ -241,13 +429,17 @@ func MakeDefaultRepoConfig() *RepoConfig {
 			PropDuration:         pointer.ToString(cast.ToString(params.RepoProposalTTL)),
 			PropTallyMethod:      ProposalTallyMethodIdentity.Ptr(),
 			PropQuorum:           pointer.ToString(cast.ToString(params.DefaultRepoProposalQuorum)),
+			PropQuorumMode:       ProposalQuorumModeLive.Ptr(),
+			PropCloseTrigger:     ProposalCloseTriggerManual.Ptr(),
 			PropThreshold:        pointer.ToString(cast.ToString(params.DefaultRepoProposalThreshold)),
 			PropVetoQuorum:       pointer.ToString(cast.ToString(params.DefaultRepoProposalVetoQuorum)),
 			PropVetoOwnersQuorum: pointer.ToString(cast.ToString(params.DefaultRepoProposalVetoOwnersQuorum)),
 			PropFee:              pointer.ToString(cast.ToString(params.DefaultMinProposalFee)),
 			PropFeeRefundType:    ProposalFeeRefundNo.Ptr(),
 			PropFeeDepositDur:    pointer.ToString("0"),
+			PropFeeDepositCap:    pointer.ToString("0"),
 			NoPropFeeForMergeReq: pointer.ToBool(true),
+			MaxOpenProposals:     pointer.ToString("0"),
 		},
 		Policies: []*Policy{},
 	}
@@ -264,13 +456,17 @@ func MakeZeroValueRepoConfig() *RepoConfig {
 			PropDuration:         pointer.ToString("0"),
 			PropTallyMethod:      pointer.ToInt(0),
 			PropQuorum:           pointer.ToString("0"),
+			PropQuorumMode:       pointer.ToInt(0),
+			PropCloseTrigger:     pointer.ToInt(0),
 			PropThreshold:        pointer.ToString("0"),
 			PropVetoQuorum:       pointer.ToString("0"),
 			PropVetoOwnersQuorum: pointer.ToString("0"),
 			PropFee:              pointer.ToString("0"),
 			PropFeeRefundType:    pointer.ToInt(0),
 			PropFeeDepositDur:    pointer.ToString("0"),
+			PropFeeDepositCap:    pointer.ToString("0"),
 			NoPropFeeForMergeReq: pointer.ToBool(false),
+			MaxOpenProposals:     pointer.ToString("0"),
 		},
 		Policies: []*Policy{},
 	}
@@ -380,6 +576,17 @@ func (r *Repository) AddOwner(ownerAddress string, owner *RepoOwner) {
 	r.Owners[ownerAddress] = owner
 }
 
+// HasReachedOpenProposalLimit checks whether the number of unfinalized
+// proposals has reached the limit set in the governance config.
+// A zero or unset limit means the number of open proposals is unlimited.
+func (r *Repository) HasReachedOpenProposalLimit() bool {
+	if r.Config == nil || r.Config.Gov == nil {
+		return false
+	}
+	limit := util.PtrStrToUInt64(r.Config.Gov.MaxOpenProposals)
+	return limit > 0 && uint64(r.Proposals.CountOpen()) >= limit
+}
+
 // IsEmpty returns true if the repo is considered empty
 func (r *Repository) IsEmpty() bool {
 	return r.Balance.IsZero() &&
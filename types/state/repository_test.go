@@ -2,8 +2,10 @@ package state
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/AlekSi/pointer"
+	"github.com/make-os/kit/util"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -98,6 +100,127 @@ var _ = Describe("Repository", func() {
 				Expect(r.Bytes()).To(Equal(res.Bytes()))
 			})
 		})
+
+		Context("Decode Config with upstream", func() {
+			BeforeEach(func() {
+				r = BareRepository()
+				config := BareRepoConfig()
+				config.Upstream = "repo1"
+				r.Config = config
+				expectedBz = r.Bytes()
+			})
+
+			It("should return object with upstream recorded", func() {
+				res, err := NewRepositoryFromBytes(expectedBz)
+				Expect(err).To(BeNil())
+				Expect(res.Config.Upstream).To(Equal("repo1"))
+				Expect(res.Config.IsEmpty()).To(BeFalse())
+			})
+		})
+
+		Context("Decode Config with post retention policy", func() {
+			BeforeEach(func() {
+				r = BareRepository()
+				config := BareRepoConfig()
+				config.PostRetention = &PostRetentionPolicy{ClosedTTL: 3600, Action: PostRetentionPrune}
+				r.Config = config
+				expectedBz = r.Bytes()
+			})
+
+			It("should return object with post retention policy recorded", func() {
+				res, err := NewRepositoryFromBytes(expectedBz)
+				Expect(err).To(BeNil())
+				Expect(res.Config.PostRetention).To(Equal(&PostRetentionPolicy{ClosedTTL: 3600, Action: PostRetentionPrune}))
+				Expect(res.Config.IsEmpty()).To(BeFalse())
+			})
+		})
+
+		Context("Decode Config with signature policy", func() {
+			BeforeEach(func() {
+				r = BareRepository()
+				config := BareRepoConfig()
+				config.Signature = &SignaturePolicy{Required: true, Unsigned: []string{"refs/heads/wip/*"}}
+				r.Config = config
+				expectedBz = r.Bytes()
+			})
+
+			It("should return object with signature policy recorded", func() {
+				res, err := NewRepositoryFromBytes(expectedBz)
+				Expect(err).To(BeNil())
+				Expect(res.Config.Signature).To(Equal(&SignaturePolicy{Required: true, Unsigned: []string{"refs/heads/wip/*"}}))
+				Expect(res.Config.IsEmpty()).To(BeFalse())
+			})
+		})
+
+		Context("Decode Config with access rules", func() {
+			BeforeEach(func() {
+				r = BareRepository()
+				config := BareRepoConfig()
+				config.Access = &RepoAccess{Private: true, Allowed: []string{"addr1"}}
+				r.Config = config
+				expectedBz = r.Bytes()
+			})
+
+			It("should return object with access rules recorded", func() {
+				res, err := NewRepositoryFromBytes(expectedBz)
+				Expect(err).To(BeNil())
+				Expect(res.Config.Access).To(Equal(&RepoAccess{Private: true, Allowed: []string{"addr1"}}))
+				Expect(res.Config.IsEmpty()).To(BeFalse())
+			})
+		})
+
+		Context("Decode Config with default reviewers", func() {
+			BeforeEach(func() {
+				r = BareRepository()
+				config := BareRepoConfig()
+				config.DefaultReviewers = []string{"pk1"}
+				r.Config = config
+				expectedBz = r.Bytes()
+			})
+
+			It("should return object with default reviewers recorded", func() {
+				res, err := NewRepositoryFromBytes(expectedBz)
+				Expect(err).To(BeNil())
+				Expect(res.Config.DefaultReviewers).To(Equal([]string{"pk1"}))
+				Expect(res.Config.IsEmpty()).To(BeFalse())
+			})
+		})
+
+		Context("Decode Config with tag update allowed", func() {
+			BeforeEach(func() {
+				r = BareRepository()
+				config := BareRepoConfig()
+				config.AllowTagUpdate = true
+				r.Config = config
+				expectedBz = r.Bytes()
+			})
+
+			It("should return object with tag update allowed", func() {
+				res, err := NewRepositoryFromBytes(expectedBz)
+				Expect(err).To(BeNil())
+				Expect(res.Config.AllowTagUpdate).To(BeTrue())
+				Expect(res.Config.IsEmpty()).To(BeFalse())
+			})
+		})
+
+		Context("Decode Config with tag grace period", func() {
+			BeforeEach(func() {
+				r = BareRepository()
+				config := BareRepoConfig()
+				config.TagGracePeriod = 10
+				r.Config = config
+				r.References = map[string]*Reference{"refs/tags/v1": {Hash: []byte("hash"), CreatedAt: 100}}
+				expectedBz = r.Bytes()
+			})
+
+			It("should return object with tag grace period and reference creation height", func() {
+				res, err := NewRepositoryFromBytes(expectedBz)
+				Expect(err).To(BeNil())
+				Expect(res.Config.TagGracePeriod).To(Equal(uint64(10)))
+				Expect(res.Config.IsEmpty()).To(BeFalse())
+				Expect(res.References.Get("refs/tags/v1").CreatedAt).To(Equal(util.UInt64(100)))
+			})
+		})
 	})
 
 	Describe("BareRepository.IsEmpty", func() {
@@ -199,6 +322,115 @@ var _ = Describe("Repository", func() {
 		})
 	})
 
+	Describe("RepoAccess", func() {
+		Describe(".IsPrivate", func() {
+			It("should return false when access rules are not set", func() {
+				var a *RepoAccess
+				Expect(a.IsPrivate()).To(BeFalse())
+				Expect((&RepoAccess{}).IsPrivate()).To(BeFalse())
+			})
+
+			It("should return true when private flag is set", func() {
+				Expect((&RepoAccess{Private: true}).IsPrivate()).To(BeTrue())
+			})
+		})
+
+		Describe(".IsAllowed", func() {
+			a := &RepoAccess{Private: true, Allowed: []string{"addr1", "pk1"}}
+
+			It("should return false when access rules are not set", func() {
+				var a *RepoAccess
+				Expect(a.IsAllowed("addr1")).To(BeFalse())
+			})
+
+			It("should return false when none of the ids is in the allow-list", func() {
+				Expect(a.IsAllowed("addr2", "pk2")).To(BeFalse())
+				Expect(a.IsAllowed("")).To(BeFalse())
+			})
+
+			It("should return true when any of the ids is in the allow-list", func() {
+				Expect(a.IsAllowed("addr2", "pk1")).To(BeTrue())
+				Expect(a.IsAllowed("addr1")).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("PostRetentionPolicy", func() {
+		closedAt := time.Unix(1600000000, 0)
+
+		Describe(".IsEmpty", func() {
+			It("should return true when no rule is set", func() {
+				var p *PostRetentionPolicy
+				Expect(p.IsEmpty()).To(BeTrue())
+				Expect((&PostRetentionPolicy{}).IsEmpty()).To(BeTrue())
+			})
+
+			It("should return false when a rule is set", func() {
+				Expect((&PostRetentionPolicy{ClosedTTL: 10}).IsEmpty()).To(BeFalse())
+				Expect((&PostRetentionPolicy{Action: PostRetentionArchive}).IsEmpty()).To(BeFalse())
+			})
+		})
+
+		Describe(".IsExpired", func() {
+			p := &PostRetentionPolicy{ClosedTTL: 3600, Action: PostRetentionArchive}
+
+			It("should return false when policy is not set or has no period", func() {
+				var nilPolicy *PostRetentionPolicy
+				Expect(nilPolicy.IsExpired(closedAt, closedAt.Add(time.Hour))).To(BeFalse())
+				Expect((&PostRetentionPolicy{}).IsExpired(closedAt, closedAt.Add(time.Hour))).To(BeFalse())
+			})
+
+			It("should return false when post is not closed", func() {
+				Expect(p.IsExpired(time.Time{}, closedAt.Add(time.Hour))).To(BeFalse())
+			})
+
+			It("should return false when post has not been closed for the period", func() {
+				Expect(p.IsExpired(closedAt, closedAt.Add(time.Hour-time.Second))).To(BeFalse())
+			})
+
+			It("should return true when post has been closed for the period", func() {
+				Expect(p.IsExpired(closedAt, closedAt.Add(time.Hour))).To(BeTrue())
+				Expect(p.IsExpired(closedAt, closedAt.Add(2*time.Hour))).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("SignaturePolicy", func() {
+		Describe(".IsEmpty", func() {
+			It("should return true when no rule is set", func() {
+				var p *SignaturePolicy
+				Expect(p.IsEmpty()).To(BeTrue())
+				Expect((&SignaturePolicy{}).IsEmpty()).To(BeTrue())
+			})
+
+			It("should return false when a rule is set", func() {
+				Expect((&SignaturePolicy{Required: true}).IsEmpty()).To(BeFalse())
+				Expect((&SignaturePolicy{Unsigned: []string{"refs/heads/wip/*"}}).IsEmpty()).To(BeFalse())
+			})
+		})
+
+		Describe(".RequiresSignature", func() {
+			It("should return false when signatures are not required", func() {
+				var p *SignaturePolicy
+				Expect(p.RequiresSignature("refs/heads/master")).To(BeFalse())
+				Expect((&SignaturePolicy{}).RequiresSignature("refs/heads/master")).To(BeFalse())
+			})
+
+			It("should return false when reference matches an unsigned pattern", func() {
+				p := &SignaturePolicy{Required: true, Unsigned: []string{"refs/heads/wip/*", "refs/tags/rc-?"}}
+				Expect(p.RequiresSignature("refs/heads/wip/feature")).To(BeFalse())
+				Expect(p.RequiresSignature("refs/tags/rc-1")).To(BeFalse())
+			})
+
+			It("should return true when reference matches no unsigned pattern", func() {
+				p := &SignaturePolicy{Required: true, Unsigned: []string{"refs/heads/wip/*"}}
+				Expect(p.RequiresSignature("refs/heads/master")).To(BeTrue())
+				Expect(p.RequiresSignature("refs/heads/wip/a/b")).To(BeTrue())
+				Expect(p.RequiresSignature("refs/tags/v1")).To(BeTrue())
+			})
+		})
+	})
+
 	Describe("RepoConfig.Clone", func() {
 		base := &RepoConfig{
 			Gov: &RepoConfigGovernance{
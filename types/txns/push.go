@@ -145,6 +145,12 @@ func (tx *TxPush) GetNonce() uint64 {
 	return tx.Note.GetPusherAccountNonce()
 }
 
+// GetFee returns the transaction fee.
+// Because TxPush is a wrapper transaction, we use the total fee of the push note.
+func (tx *TxPush) GetFee() util.String {
+	return tx.Note.GetFee()
+}
+
 // GetFrom returns the address of the transaction sender
 // Because TxPush is a wrapper transaction, we use the pusher's address.
 func (tx *TxPush) GetFrom() identifier.Address {
@@ -253,6 +253,26 @@ func ToJSONMap(s interface{}) (m map[string]interface{}) {
 	return
 }
 
+// ToSortedJSON returns a deterministic JSON encoding of s.
+// Structs are first normalized into maps so that object keys at every
+// level are sorted, regardless of struct field order. Numbers are kept
+// as-is and HTML characters are escaped like json.Marshal does.
+func ToSortedJSON(s interface{}) ([]byte, error) {
+	bz, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized interface{}
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.UseNumber()
+	if err = dec.Decode(&normalized); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(normalized)
+}
+
 // ToMap converts a struct to a map.
 // If tagName is not provided, 'json' tag is used as a default.
 func ToMap(s interface{}, tagName ...string) map[string]interface{} {
@@ -718,3 +738,34 @@ func PtrStrToFloatE(inp *string) (float64, error) {
 func PtrStrToUInt64(inp *string) uint64 {
 	return cast.ToUint64(pointer.GetString(inp))
 }
+
+// ErrWriteLimitExceeded is returned by LimitedWriter when a write would
+// exceed its limit.
+var ErrWriteLimitExceeded = fmt.Errorf("write limit exceeded")
+
+// LimitedWriter writes to W but fails with ErrWriteLimitExceeded once more
+// than N bytes have been written. Bytes beyond the limit are not written.
+type LimitedWriter struct {
+	W io.Writer
+	N int64
+}
+
+// NewLimitedWriter creates an instance of LimitedWriter
+func NewLimitedWriter(w io.Writer, max int64) *LimitedWriter {
+	return &LimitedWriter{W: w, N: max}
+}
+
+// Write implements io.Writer
+func (l *LimitedWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) > l.N {
+		n, err := l.W.Write(p[:l.N])
+		l.N -= int64(n)
+		if err != nil {
+			return n, err
+		}
+		return n, ErrWriteLimitExceeded
+	}
+	n, err := l.W.Write(p)
+	l.N -= int64(n)
+	return n, err
+}
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"net/http/httptest"
@@ -93,6 +94,19 @@ var _ = Describe("Common", func() {
 		})
 	})
 
+	Describe(".GetFreeDiskSpace", func() {
+		It("should return free space of an existing path", func() {
+			free, err := GetFreeDiskSpace(os.TempDir())
+			Expect(err).To(BeNil())
+			Expect(free).ToNot(BeZero())
+		})
+
+		It("should return error when path does not exist", func() {
+			_, err := GetFreeDiskSpace("./abcxyz")
+			Expect(err).ToNot(BeNil())
+		})
+	})
+
 	Describe(".IsFileOk", func() {
 
 		BeforeEach(func() {
@@ -250,6 +264,39 @@ var _ = Describe("Common", func() {
 		})
 	})
 
+	Describe(".ToSortedJSON", func() {
+		type inner struct {
+			Zeta  string `json:"zeta"`
+			Alpha uint64 `json:"alpha"`
+		}
+		type outer struct {
+			Name  string                 `json:"name"`
+			Inner inner                  `json:"inner"`
+			Extra map[string]interface{} `json:"extra"`
+			HTML  string                 `json:"html"`
+		}
+
+		It("should return the same bytes across runs with keys sorted at every level", func() {
+			s := outer{
+				Name:  "odion",
+				Inner: inner{Zeta: "z", Alpha: 18446744073709551615},
+				Extra: map[string]interface{}{"b": 2, "a": []interface{}{map[string]interface{}{"y": 1, "x": 2}}},
+				HTML:  "<a>",
+			}
+			expected := `{"extra":{"a":[{"x":2,"y":1}],"b":2},"html":"\u003ca\u003e","inner":{"alpha":18446744073709551615,"zeta":"z"},"name":"odion"}`
+			for i := 0; i < 10; i++ {
+				bz, err := ToSortedJSON(s)
+				Expect(err).To(BeNil())
+				Expect(string(bz)).To(Equal(expected))
+			}
+		})
+
+		It("should return error when value cannot be encoded", func() {
+			_, err := ToSortedJSON(map[string]interface{}{"ch": make(chan int)})
+			Expect(err).ToNot(BeNil())
+		})
+	})
+
 	Describe(".StructSliceToMap", func() {
 
 		type testStruct struct {
@@ -641,4 +688,26 @@ var _ = Describe("Common", func() {
 			Expect(ParseGitVersion("git version 2.26.2")).To(Equal("git version 2.26.2"))
 		})
 	})
+
+	Describe(".LimitedWriter", func() {
+		It("should write all bytes when within the limit", func() {
+			buf := bytes.NewBuffer(nil)
+			w := NewLimitedWriter(buf, 5)
+			n, err := w.Write([]byte("hello"))
+			Expect(err).To(BeNil())
+			Expect(n).To(Equal(5))
+			Expect(buf.String()).To(Equal("hello"))
+		})
+
+		It("should write up to the limit and return ErrWriteLimitExceeded when the limit is exceeded", func() {
+			buf := bytes.NewBuffer(nil)
+			w := NewLimitedWriter(buf, 5)
+			_, err := w.Write([]byte("hel"))
+			Expect(err).To(BeNil())
+			n, err := w.Write([]byte("lo world"))
+			Expect(err).To(Equal(ErrWriteLimitExceeded))
+			Expect(n).To(Equal(2))
+			Expect(buf.String()).To(Equal("hello"))
+		})
+	})
 })
@@ -0,0 +1,16 @@
+//go:build !windows
+// +build !windows
+
+package util
+
+import "syscall"
+
+// GetFreeDiskSpace returns the number of bytes available to
+// unprivileged users on the file system containing path.
+func GetFreeDiskSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return stat.Bavail * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,10 @@
+package util
+
+import "math"
+
+// GetFreeDiskSpace returns the number of bytes available to
+// unprivileged users on the file system containing path.
+// Free disk space is not determined on windows; The max value is returned.
+func GetFreeDiskSpace(path string) (uint64, error) {
+	return math.MaxUint64, nil
+}
@@ -1,6 +1,7 @@
 package identifier
 
 import (
+	"path"
 	"regexp"
 	"strings"
 )
@@ -113,5 +114,41 @@ func IsFullNativeNamespaceURI(str string) bool {
 
 // IsValidScope checks whether and address can be used as a scope
 func IsValidScope(addr string) bool {
+	if IsScopePattern(addr) {
+		return IsValidScopePattern(addr)
+	}
 	return IsUserNamespaceURI(addr) || IsWholeNativeRepoURI(addr) || IsValidResourceName(addr) == nil
 }
+
+// IsScopePattern checks whether a scope contains glob wildcard characters.
+//
+// Example: team-*, ns1/repo-?, r/team-*
+func IsScopePattern(scope string) bool {
+	return strings.ContainsAny(scope, "*?")
+}
+
+// IsValidScopePattern checks whether a scope is a valid glob pattern.
+// Wildcards (* and ?) are only allowed in the repo name or domain part of the scope.
+//
+// Example: team-*, ns1/repo-?, r/team-*
+func IsValidScopePattern(scope string) bool {
+	if _, err := path.Match(scope, ""); err != nil {
+		return false
+	}
+	if i := strings.Index(scope, "/"); i != -1 && IsScopePattern(scope[:i]) {
+		return false
+	}
+	literal := strings.NewReplacer("*", "a", "?", "a").Replace(scope)
+	return IsUserNamespaceURI(literal) || IsWholeNativeRepoURI(literal) || IsValidResourceName(literal) == nil
+}
+
+// MatchScope checks whether a name matches a scope's repo name or domain part.
+// If the scope is a glob pattern, the name is matched against it; otherwise,
+// the name must be equal to the scope.
+func MatchScope(scope, name string) bool {
+	if !IsScopePattern(scope) {
+		return scope == name
+	}
+	ok, _ := path.Match(scope, name)
+	return ok
+}
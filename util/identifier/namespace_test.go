@@ -73,4 +73,34 @@ var _ = Describe("Address", func() {
 			Expect(IsNamespaceURI("namespace/")).To(BeTrue())
 		})
 	})
+	Describe(".IsValidScope", func() {
+		It("should accept namespace paths, repo names and valid glob patterns", func() {
+			Expect(IsValidScope("repo1")).To(BeTrue())
+			Expect(IsValidScope("r/repo1")).To(BeTrue())
+			Expect(IsValidScope("ns1/repo1")).To(BeTrue())
+			Expect(IsValidScope("team-*")).To(BeTrue())
+			Expect(IsValidScope("r/team-?")).To(BeTrue())
+			Expect(IsValidScope("ns1/team-*")).To(BeTrue())
+		})
+
+		It("should reject invalid scopes and glob patterns", func() {
+			Expect(IsValidScope("repo_&*")).To(BeFalse())
+			Expect(IsValidScope("team-[")).To(BeFalse())
+			Expect(IsValidScope("ns*/repo1")).To(BeFalse())
+			Expect(IsValidScope("*")).To(BeFalse())
+		})
+	})
+
+	Describe(".MatchScope", func() {
+		It("should match name exactly when scope is not a pattern", func() {
+			Expect(MatchScope("repo1", "repo1")).To(BeTrue())
+			Expect(MatchScope("repo1", "repo10")).To(BeFalse())
+		})
+
+		It("should match name against scope when scope is a pattern", func() {
+			Expect(MatchScope("team-*", "team-a")).To(BeTrue())
+			Expect(MatchScope("team-*", "repo1")).To(BeFalse())
+			Expect(MatchScope("team-?", "team-ab")).To(BeFalse())
+		})
+	})
 })
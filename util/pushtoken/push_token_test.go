@@ -2,6 +2,7 @@ package pushtoken
 
 import (
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	crypto2 "github.com/make-os/kit/crypto/ed25519"
@@ -77,6 +78,67 @@ var _ = Describe("TxDetail", func() {
 		})
 	})
 
+	Describe(".MakeFromKey", func() {
+		var txDetail *types.TxDetail
+
+		BeforeEach(func() {
+			txDetail = &types.TxDetail{RepoName: "repo1", Meta: map[string]string{
+				"clientVersion": "v0.1.0",
+				"ciRunId":       "1234",
+			}}
+		})
+
+		It("should preserve metadata in the decoded token", func() {
+			token := MakeFromKey(key, txDetail)
+			txD, err := Decode(token)
+			Expect(err).To(BeNil())
+			Expect(txD.Meta).To(Equal(txDetail.Meta))
+			Expect(txD.Equal(txDetail)).To(BeTrue())
+		})
+
+		It("should include metadata in the signed bytes", func() {
+			token := MakeFromKey(key, txDetail)
+			txD, err := Decode(token)
+			Expect(err).To(BeNil())
+			ok, err := key.PubKey().Verify(txD.BytesNoSig(), txD.SignatureToByte())
+			Expect(err).To(BeNil())
+			Expect(ok).To(BeTrue())
+
+			txD.Meta["ciRunId"] = "5678"
+			ok, _ = key.PubKey().Verify(txD.BytesNoSig(), txD.SignatureToByte())
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe(".MakeFromKey with expiry", func() {
+		It("should preserve a set expiry time and include it in the signed bytes", func() {
+			txDetail := &types.TxDetail{RepoName: "repo1", ExpiresAt: time.Now().Add(time.Hour).Unix()}
+			token := MakeFromKey(key, txDetail)
+			txD, err := Decode(token)
+			Expect(err).To(BeNil())
+			Expect(txD.ExpiresAt).To(Equal(txDetail.ExpiresAt))
+			Expect(txD.IsExpired(time.Now())).To(BeFalse())
+
+			txD.ExpiresAt++
+			ok, _ := key.PubKey().Verify(txD.BytesNoSig(), txD.SignatureToByte())
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should report an expired token", func() {
+			txDetail := &types.TxDetail{RepoName: "repo1", ExpiresAt: time.Now().Add(-time.Hour).Unix()}
+			txD, err := Decode(MakeFromKey(key, txDetail))
+			Expect(err).To(BeNil())
+			Expect(txD.IsExpired(time.Now())).To(BeTrue())
+		})
+
+		It("should not expire a token with no expiry time", func() {
+			txD, err := Decode(MakeFromKey(key, &types.TxDetail{RepoName: "repo1"}))
+			Expect(err).To(BeNil())
+			Expect(txD.ExpiresAt).To(BeZero())
+			Expect(txD.IsExpired(time.Now().Add(100 * 365 * 24 * time.Hour))).To(BeFalse())
+		})
+	})
+
 	Describe(".IsValid", func() {
 		It("should return false if token is invalid", func() {
 			Expect(IsValid("invalid")).To(BeFalse())
@@ -3,12 +3,14 @@ package validation
 import (
 	"bytes"
 	"fmt"
+	"time"
 
 	plumbing2 "github.com/go-git/go-git/v5/plumbing"
 	"github.com/make-os/kit/crypto/bdn"
 	"github.com/make-os/kit/crypto/ed25519"
 	"github.com/make-os/kit/params"
 	"github.com/make-os/kit/remote/plumbing"
+	pushtypes "github.com/make-os/kit/remote/push/types"
 	"github.com/make-os/kit/remote/validation"
 	"github.com/make-os/kit/types"
 	"github.com/make-os/kit/types/constants"
@@ -249,7 +251,7 @@ func CheckTxUpDelPushKeyConsistency(
 
 // CheckTxPushConsistency performs consistency checks on TxPush.
 // EXPECTS: sanity check using CheckTxPush to have been performed.
-func CheckTxPushConsistency(tx *txns.TxPush, _ int, logic core.Logic) error {
+func CheckTxPushConsistency(tx *txns.TxPush, index int, logic core.Logic) error {
 
 	repoState := logic.RepoKeeper().Get(tx.Note.GetRepoName())
 	if repoState.IsEmpty() {
@@ -280,8 +282,12 @@ func CheckTxPushConsistency(tx *txns.TxPush, _ int, logic core.Logic) error {
 		endPubKeys = append(endPubKeys, blsPubKey)
 
 		// Verify the endorsements
+		endorsedRefs, err := getEndorsedPushedReferences(tx.Note.GetPushedReferences(), end, index)
+		if err != nil {
+			return err
+		}
 		for i, endorsement := range end.References {
-			ref := tx.Note.GetPushedReferences()[i]
+			ref := endorsedRefs[i]
 
 			// If reference doesnt exist in the repo state, we don't expect
 			// the endorsement to include a hash.
@@ -305,6 +311,11 @@ func CheckTxPushConsistency(tx *txns.TxPush, _ int, logic core.Logic) error {
 		}
 	}
 
+	// An endorsement cannot be created before the push note it endorses.
+	if params.MaxEndorsementAge > 0 && tx.Endorsements[0].Timestamp < tx.Note.GetTimestamp() {
+		return feI(index, "endorsements", "endorsement timestamp is before the push note timestamp")
+	}
+
 	// Temporarily set the endorser's NoteID to be the note ID.
 	// Endorsements are not expected to transmit the note ID but we need
 	// it to properly verify the BLS signature.
@@ -319,6 +330,19 @@ func CheckTxPushConsistency(tx *txns.TxPush, _ int, logic core.Logic) error {
 		return errors.Wrap(err, "could not verify aggregated endorsers' signature")
 	}
 
+	// Reject stale endorsements. The timestamp of the first endorsement is covered
+	// by the aggregated signature, so it is the one checked.
+	if params.MaxEndorsementAge > 0 {
+		bi, err := logic.SysKeeper().GetLastBlockInfo()
+		if err != nil {
+			return errors.Wrap(err, "failed to fetch current block info")
+		}
+		endTime := time.Unix(tx.Endorsements[0].Timestamp, 0)
+		if time.Unix(bi.Time.Int64(), 0).Sub(endTime) > params.MaxEndorsementAge {
+			return feI(index, "endorsements", "endorsement is stale")
+		}
+	}
+
 	// Copy tx meta into the note's meta for use in CheckPushNoteConsistency
 	tx.Note.Join(tx.GetMeta())
 
@@ -330,6 +354,53 @@ func CheckTxPushConsistency(tx *txns.TxPush, _ int, logic core.Logic) error {
 	return nil
 }
 
+// getEndorsedPushedReferences returns the pushed references endorsed by the
+// references of the given endorsement, in the order they were endorsed.
+//
+// By default, the endorsed references must match the pushed references one-to-one
+// and in order. If params.PushEndorseAllowRefSubset is true, the endorsement may
+// cover a subset of the pushed references, each identified by name.
+func getEndorsedPushedReferences(
+	pushed pushtypes.PushedReferences,
+	end *pushtypes.PushEndorsement,
+	index int,
+) (pushtypes.PushedReferences, error) {
+
+	// Endorsements without references inherit those of the first endorsement
+	if len(end.References) == 0 {
+		return nil, nil
+	}
+
+	if !params.PushEndorseAllowRefSubset {
+		if len(end.References) != len(pushed) {
+			return nil, feI(index, "endorsements.refs", "references of endorsement must match the pushed references")
+		}
+		return pushed, nil
+	}
+
+	var res pushtypes.PushedReferences
+	var seen = map[string]struct{}{}
+	for _, endorsement := range end.References {
+		if endorsement.Name == "" {
+			return nil, feI(index, "endorsements.refs", "endorsed reference name is required")
+		}
+		if _, ok := seen[endorsement.Name]; ok {
+			return nil, feI(index, "endorsements.refs",
+				fmt.Sprintf("reference (%s) was endorsed more than once", endorsement.Name))
+		}
+		seen[endorsement.Name] = struct{}{}
+
+		ref := pushed.GetByName(endorsement.Name)
+		if ref == nil {
+			return nil, feI(index, "endorsements.refs",
+				fmt.Sprintf("endorsed reference (%s) was not pushed", endorsement.Name))
+		}
+		res = append(res, ref)
+	}
+
+	return res, nil
+}
+
 // CheckTxNSAcquireConsistency performs consistency checks on TxNamespaceRegister
 func CheckTxNSAcquireConsistency(tx *txns.TxNamespaceRegister, index int, logic core.Logic) error {
 
@@ -418,6 +489,11 @@ func CheckProposalCommonConsistency(
 		return nil, feI(index, "id", "proposal id has been used, choose another")
 	}
 
+	// Ensure the repo has not reached its open proposal limit
+	if repo.HasReachedOpenProposalLimit() {
+		return nil, feI(index, "id", constants.ErrOpenProposalLimitReached.Error())
+	}
+
 	repoPropFee := repo.Config.Gov.PropFee
 	propFeeDec := decimal.NewFromFloat(util.PtrStrToFloat(repoPropFee))
 
@@ -501,6 +577,12 @@ func CheckTxVoteConsistency(
 		return errors.Wrap(err, "failed to fetch current block info")
 	}
 
+	// Ensure the proposal's voting period has not ended. Votes included
+	// in the block at the proposal's end height are still counted.
+	if proposal.EndAt != 0 && uint64(bi.Height+1) > proposal.EndAt.UInt64() {
+		return feI(index, "id", "proposal voting period has ended")
+	}
+
 	// Ensure repo is not currently within a fee deposit period
 	if proposal.IsDepositPeriod(uint64(bi.Height + 1)) {
 		return feI(index, "id", "proposal is currently in fee deposit period")
@@ -586,6 +668,11 @@ func CheckTxRepoProposalSendFeeConsistency(
 		return feI(index, "id", "proposal fee deposit period has closed")
 	}
 
+	// Ensure the deposit does not exceed the proposal fee cap
+	if proposal.WouldExceedFeeCap(tx.Value.Decimal()) {
+		return feI(index, "value", "deposit would exceed proposal fee cap")
+	}
+
 	pubKey, _ := ed25519.PubKeyFromBytes(tx.GetSenderPubKey().Bytes())
 	if err = logic.DrySend(pubKey, "0",
 		tx.Fee,
@@ -3,6 +3,7 @@ package validation_test
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/AlekSi/pointer"
 	"github.com/make-os/kit/remote/push/types"
@@ -19,6 +20,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/make-os/kit/config"
+	"github.com/make-os/kit/crypto/bdn"
 	"github.com/make-os/kit/crypto/ed25519"
 	"github.com/make-os/kit/mocks"
 	"github.com/make-os/kit/testutil"
@@ -942,6 +944,198 @@ var _ = Describe("TxValidator", func() {
 				Expect(err.Error()).To(ContainSubstring("could not verify aggregated endorsers' signature"))
 			})
 		})
+
+		When("the number of endorsed references does not match the pushed references", func() {
+			BeforeEach(func() {
+				params.NumTopHostsLimit = 10
+
+				hosts := []*tickettypes.SelectedTicket{
+					{Ticket: &tickettypes.Ticket{ProposerPubKey: key.PubKey().MustBytes32(), BLSPubKey: key.PrivKey().BLSKey().Public().Bytes()}},
+				}
+				mockTickMgr.EXPECT().GetTopHosts(params.NumTopHostsLimit).Return(hosts, nil)
+
+				tx := txns.NewBareTxPush()
+				tx.Note.(*types.Note).RepoName = "repo1"
+				tx.Note.(*types.Note).References = append(tx.Note.(*types.Note).References,
+					&types.PushedReference{Name: "refs/heads/master"},
+					&types.PushedReference{Name: "refs/heads/dev"})
+				tx.Endorsements = append(tx.Endorsements, &types.PushEndorsement{
+					EndorserPubKey: util.BytesToBytes32(key.PubKey().MustBytes()),
+					References:     []*types.EndorsedReference{{Hash: util.RandBytes(20)}},
+				})
+
+				repo := state.BareRepository()
+				repo.Balance = "100"
+				mockRepoKeeper.EXPECT().Get(tx.Note.(*types.Note).RepoName).Return(repo)
+
+				err = validation.CheckTxPushConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(ContainSubstring("references of endorsement must match the pushed references"))
+			})
+		})
+
+		When("the aggregated signature is valid", func() {
+			var tx *txns.TxPush
+			var endTime = time.Now().Unix()
+			var oldMaxEndorsementAge time.Duration
+
+			BeforeEach(func() {
+				oldMaxEndorsementAge = params.MaxEndorsementAge
+				params.MaxEndorsementAge = 10 * time.Minute
+				params.NumTopHostsLimit = 10
+				hosts := []*tickettypes.SelectedTicket{
+					{Ticket: &tickettypes.Ticket{ProposerPubKey: key.PubKey().MustBytes32(), BLSPubKey: key.PrivKey().BLSKey().Public().Bytes()}},
+				}
+				mockTickMgr.EXPECT().GetTopHosts(params.NumTopHostsLimit).Return(hosts, nil)
+
+				refHash := util.RandBytes(20)
+				tx = txns.NewBareTxPush()
+				tx.Note.(*types.Note).RepoName = "repo1"
+				tx.Note.(*types.Note).Timestamp = endTime
+				tx.Note.(*types.Note).References = append(tx.Note.(*types.Note).References, &types.PushedReference{Name: "refs/heads/master"})
+				end := &types.PushEndorsement{
+					NoteID:         tx.Note.ID().Bytes(),
+					EndorserPubKey: util.BytesToBytes32(key.PubKey().MustBytes()),
+					References:     []*types.EndorsedReference{{Hash: refHash}},
+					Timestamp:      endTime,
+				}
+				sig, err := key.PrivKey().BLSKey().Sign(end.BytesForBLSSig())
+				Expect(err).To(BeNil())
+				tx.AggregatedSig, err = bdn.AggregateSignatures([]*bdn.PublicKey{key.PrivKey().BLSKey().Public()}, [][]byte{sig})
+				Expect(err).To(BeNil())
+				end.NoteID = nil
+				tx.Endorsements = append(tx.Endorsements, end)
+
+				repo := state.BareRepository()
+				repo.References["refs/heads/master"] = &state.Reference{Hash: refHash}
+				mockRepoKeeper.EXPECT().Get(tx.Note.(*types.Note).RepoName).Return(repo)
+			})
+
+			AfterEach(func() {
+				params.MaxEndorsementAge = oldMaxEndorsementAge
+			})
+
+			It("should return err when the endorsement timestamp is before the push note timestamp", func() {
+				tx.Note.(*types.Note).Timestamp = endTime + 1
+				err = validation.CheckTxPushConsistency(tx, -1, mockLogic)
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError(`"field":"endorsements","msg":"endorsement timestamp is before the push note timestamp"`))
+			})
+
+			It("should return err when the endorsement is older than the max endorsement age", func() {
+				lastBlockTime := time.Unix(endTime, 0).Add(params.MaxEndorsementAge + time.Second).Unix()
+				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Time: util.Int64(lastBlockTime)}, nil)
+				err = validation.CheckTxPushConsistency(tx, -1, mockLogic)
+				Expect(err).ToNot(BeNil())
+				Expect(err).To(MatchError(`"field":"endorsements","msg":"endorsement is stale"`))
+			})
+
+			It("should proceed to check the push note when the endorsement is fresh", func() {
+				lastBlockTime := time.Unix(endTime, 0).Add(params.MaxEndorsementAge - time.Second).Unix()
+				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Time: util.Int64(lastBlockTime)}, nil)
+				mockRepoKeeper.EXPECT().Get(tx.Note.(*types.Note).RepoName).Return(state.BareRepository())
+				err = validation.CheckTxPushConsistency(tx, -1, mockLogic)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(ContainSubstring("repository named 'repo1' is unknown"))
+			})
+
+			It("should not check the endorsement age when the max endorsement age is zero", func() {
+				params.MaxEndorsementAge = 0
+				mockRepoKeeper.EXPECT().Get(tx.Note.(*types.Note).RepoName).Return(state.BareRepository())
+				err = validation.CheckTxPushConsistency(tx, -1, mockLogic)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(ContainSubstring("repository named 'repo1' is unknown"))
+			})
+		})
+
+		When("endorsements are allowed to cover a subset of the pushed references", func() {
+			var tx *txns.TxPush
+			var repo *state.Repository
+			var masterHash []byte
+
+			BeforeEach(func() {
+				params.PushEndorseAllowRefSubset = true
+				params.NumTopHostsLimit = 10
+
+				hosts := []*tickettypes.SelectedTicket{
+					{Ticket: &tickettypes.Ticket{ProposerPubKey: key.PubKey().MustBytes32(), BLSPubKey: key.PrivKey().BLSKey().Public().Bytes()}},
+				}
+				mockTickMgr.EXPECT().GetTopHosts(params.NumTopHostsLimit).Return(hosts, nil)
+
+				tx = txns.NewBareTxPush()
+				tx.Note.(*types.Note).RepoName = "repo1"
+				tx.Note.(*types.Note).References = append(tx.Note.(*types.Note).References,
+					&types.PushedReference{Name: "refs/heads/master"},
+					&types.PushedReference{Name: "refs/heads/dev"})
+
+				masterHash = util.RandBytes(20)
+				repo = state.BareRepository()
+				repo.References["refs/heads/master"] = &state.Reference{Hash: masterHash}
+				repo.References["refs/heads/dev"] = &state.Reference{Hash: util.RandBytes(20)}
+				mockRepoKeeper.EXPECT().Get(tx.Note.(*types.Note).RepoName).Return(repo)
+			})
+
+			AfterEach(func() {
+				params.PushEndorseAllowRefSubset = false
+			})
+
+			It("should accept endorsed references that are a subset of the pushed references", func() {
+				tx.Endorsements = append(tx.Endorsements, &types.PushEndorsement{
+					EndorserPubKey: util.BytesToBytes32(key.PubKey().MustBytes()),
+					References:     []*types.EndorsedReference{{Name: "refs/heads/master", Hash: masterHash}},
+				})
+				err = validation.CheckTxPushConsistency(tx, -1, mockLogic)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(ContainSubstring("could not verify aggregated endorsers' signature"))
+			})
+
+			It("should return err if an endorsed reference hash does not match the named reference", func() {
+				tx.Endorsements = append(tx.Endorsements, &types.PushEndorsement{
+					EndorserPubKey: util.BytesToBytes32(key.PubKey().MustBytes()),
+					References:     []*types.EndorsedReference{{Name: "refs/heads/dev", Hash: masterHash}},
+				})
+				err = validation.CheckTxPushConsistency(tx, -1, mockLogic)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(ContainSubstring("endorsed reference (refs/heads/dev)"))
+				Expect(err.Error()).To(ContainSubstring("not the expected hash"))
+			})
+
+			It("should return err if an endorsed reference has no name", func() {
+				tx.Endorsements = append(tx.Endorsements, &types.PushEndorsement{
+					EndorserPubKey: util.BytesToBytes32(key.PubKey().MustBytes()),
+					References:     []*types.EndorsedReference{{Hash: masterHash}},
+				})
+				err = validation.CheckTxPushConsistency(tx, -1, mockLogic)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(ContainSubstring("endorsed reference name is required"))
+			})
+
+			It("should return err if an endorsed reference was not pushed", func() {
+				tx.Endorsements = append(tx.Endorsements, &types.PushEndorsement{
+					EndorserPubKey: util.BytesToBytes32(key.PubKey().MustBytes()),
+					References:     []*types.EndorsedReference{{Name: "refs/heads/unknown", Hash: masterHash}},
+				})
+				err = validation.CheckTxPushConsistency(tx, -1, mockLogic)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(ContainSubstring("endorsed reference (refs/heads/unknown) was not pushed"))
+			})
+
+			It("should return err if a reference was endorsed more than once", func() {
+				tx.Endorsements = append(tx.Endorsements, &types.PushEndorsement{
+					EndorserPubKey: util.BytesToBytes32(key.PubKey().MustBytes()),
+					References: []*types.EndorsedReference{
+						{Name: "refs/heads/master", Hash: masterHash},
+						{Name: "refs/heads/master", Hash: masterHash},
+					},
+				})
+				err = validation.CheckTxPushConsistency(tx, -1, mockLogic)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(ContainSubstring("reference (refs/heads/master) was endorsed more than once"))
+			})
+		})
 	})
 
 	Describe(".CheckTxVoteConsistency", func() {
@@ -1022,6 +1216,56 @@ var _ = Describe("TxValidator", func() {
 			})
 		})
 
+		When("the proposal's voting period has ended", func() {
+			BeforeEach(func() {
+				tx := txns.NewBareRepoProposalVote()
+				tx.RepoName = "repo1"
+				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+				tx.ProposalID = "proposal1"
+
+				repo := state.BareRepository()
+				repo.Proposals.Add("proposal1", &state.RepoProposal{
+					Config: repo.Config.Gov,
+					EndAt:  100,
+				})
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 100}, nil)
+
+				err = validation.CheckTxVoteConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal(`"field":"id","msg":"proposal voting period has ended"`))
+			})
+		})
+
+		When("the vote is included in the block at the proposal's end height", func() {
+			BeforeEach(func() {
+				tx := txns.NewBareRepoProposalVote()
+				tx.RepoName = "repo1"
+				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+				tx.ProposalID = "proposal1"
+
+				repo := state.BareRepository()
+				repo.Config.Gov.Voter = state.VoterNetStakers.Ptr()
+				repo.Proposals.Add("proposal1", &state.RepoProposal{
+					Config: repo.Config.Gov,
+					EndAt:  100,
+				})
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 99}, nil)
+				mockRepoKeeper.EXPECT().GetProposalVote(tx.RepoName, tx.ProposalID, key.Addr().String()).Return(0, false, nil)
+				mockLogic.EXPECT().DrySend(key.PubKey(), util.String("0"), tx.Fee, tx.Nonce, false, uint64(99)).Return(nil)
+
+				err = validation.CheckTxVoteConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return no error", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+
 		When("a proposal is in proposal deposit fee period", func() {
 			BeforeEach(func() {
 				tx := txns.NewBareRepoProposalVote()
@@ -1329,6 +1573,61 @@ var _ = Describe("TxValidator", func() {
 				Expect(err).To(MatchError("error"))
 			})
 		})
+
+		When("deposit would exceed the proposal fee cap", func() {
+			BeforeEach(func() {
+				tx := txns.NewBareRepoProposalFeeSend()
+				tx.RepoName = "repo1"
+				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+				tx.ID = "proposal1"
+				tx.Value = "51"
+
+				repo := state.BareRepository()
+				repo.Proposals.Add("proposal1", &state.RepoProposal{
+					Config:          &state.RepoConfigGovernance{PropFeeDepositCap: pointer.ToString("100")},
+					FeeDepositEndAt: 100,
+					Fees:            map[string]string{"addr1": "50"},
+				})
+
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(&state.BlockInfo{Height: 10}, nil)
+
+				err = validation.CheckTxRepoProposalSendFeeConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal(`"field":"value","msg":"deposit would exceed proposal fee cap"`))
+			})
+		})
+
+		When("deposit does not exceed the proposal fee cap", func() {
+			BeforeEach(func() {
+				tx := txns.NewBareRepoProposalFeeSend()
+				tx.RepoName = "repo1"
+				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+				tx.ID = "proposal1"
+				tx.Value = "50"
+
+				repo := state.BareRepository()
+				repo.Proposals.Add("proposal1", &state.RepoProposal{
+					Config:          &state.RepoConfigGovernance{PropFeeDepositCap: pointer.ToString("100")},
+					FeeDepositEndAt: 100,
+					Fees:            map[string]string{"addr1": "50"},
+				})
+
+				mockRepoKeeper.EXPECT().Get(tx.RepoName).Return(repo)
+				bi := &state.BlockInfo{Height: 10}
+				mockSysKeeper.EXPECT().GetLastBlockInfo().Return(bi, nil)
+				mockLogic.EXPECT().DrySend(key.PubKey(), util.String("0"), tx.Fee, tx.Nonce, false, uint64(bi.Height)).Return(nil)
+
+				err = validation.CheckTxRepoProposalSendFeeConsistency(tx, -1, mockLogic)
+			})
+
+			It("should return no error", func() {
+				Expect(err).To(BeNil())
+			})
+		})
 	})
 
 	Describe(".CheckProposalCommonConsistency", func() {
@@ -1368,6 +1667,48 @@ var _ = Describe("TxValidator", func() {
 			})
 		})
 
+		When("repo has reached its open proposal limit", func() {
+			BeforeEach(func() {
+				txProposal := &txns.TxProposalCommon{RepoName: "repo1", ID: "3"}
+				txCommon := &txns.TxCommon{}
+				txCommon.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+				repo := state.BareRepository()
+				repo.Config.Gov.MaxOpenProposals = pointer.ToString("2")
+				repo.Proposals["1"] = &state.RepoProposal{EndAt: 1000}
+				repo.Proposals["2"] = &state.RepoProposal{EndAt: 1000}
+
+				mockRepoKeeper.EXPECT().Get(txProposal.RepoName).Return(repo)
+				_, err = validation.CheckProposalCommonConsistency(txProposal, txCommon, -1, mockLogic)
+			})
+
+			It("should return err", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal(`"field":"id","msg":"repo has reached its open proposal limit"`))
+			})
+		})
+
+		When("repo has not reached its open proposal limit because some proposals are finalized", func() {
+			BeforeEach(func() {
+				txProposal := &txns.TxProposalCommon{RepoName: "repo1", ID: "3", Value: "101"}
+				txCommon := &txns.TxCommon{}
+				txCommon.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+				repo := state.BareRepository()
+				repo.Config = state.MakeZeroValueRepoConfig()
+				repo.Config.Gov.PropFee = pointer.ToString("100")
+				repo.Config.Gov.MaxOpenProposals = pointer.ToString("2")
+				repo.Proposals["1"] = &state.RepoProposal{EndAt: 1000}
+				repo.Proposals["2"] = &state.RepoProposal{EndAt: 1000, Outcome: state.ProposalOutcomeAccepted}
+
+				mockRepoKeeper.EXPECT().Get(txProposal.RepoName).Return(repo)
+				mockLogic.EXPECT().DrySend(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				_, err = validation.CheckProposalCommonConsistency(txProposal, txCommon, -1, mockLogic)
+			})
+
+			It("should return no error", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+
 		When("proposal does not need a proposal fee but it is set", func() {
 			BeforeEach(func() {
 				txProposal := &txns.TxProposalCommon{RepoName: "repo1", ID: "1", Value: "10"}
@@ -2,6 +2,9 @@ package validation
 
 import (
 	"fmt"
+	"path"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/AlekSi/pointer"
@@ -257,6 +260,16 @@ func CheckRepoConfig(cfg *state.RepoConfig, index int) error {
 		return feI(index, "governance.propTallyMethod", fmt.Sprintf("unknown value"))
 	}
 
+	// Ensure the quorum mode is known
+	if govCfg.PropQuorumMode != nil && !state.IsValidProposalQuorumMode(govCfg.PropQuorumMode) {
+		return feI(index, "governance.propQuorumMode", fmt.Sprintf("unknown value"))
+	}
+
+	// Ensure the close trigger is known
+	if govCfg.PropCloseTrigger != nil && !state.IsValidProposalCloseTrigger(govCfg.PropCloseTrigger) {
+		return feI(index, "governance.propCloseTrigger", fmt.Sprintf("unknown value"))
+	}
+
 	// Ensure the refund type method is known
 	if govCfg.PropFeeRefundType != nil && !state.IsValidPropFeeRefundTypeType(govCfg.PropFeeRefundType) {
 		return feI(index, "governance.propFeeRefundType", fmt.Sprintf("unknown value"))
@@ -313,6 +326,23 @@ func CheckRepoConfig(cfg *state.RepoConfig, index int) error {
 		}
 	}
 
+	// A non-zero fee deposit cap must allow the proposal fee to be paid
+	if govCfg.PropFeeDepositCap != nil {
+		propFeeDepCap, err := util.PtrStrToFloatE(govCfg.PropFeeDepositCap)
+		if err != nil || propFeeDepCap < 0 {
+			return feI(index, "governance.propFeeDepCap", fmt.Sprintf("must be a non-negative number"))
+		} else if propFeeDepCap > 0 && propFeeDepCap < util.PtrStrToFloat(govCfg.PropFee) {
+			return feI(index, "governance.propFeeDepCap", fmt.Sprintf("cannot be lower than the proposal fee"))
+		}
+	}
+
+	// A non-zero open proposal limit caps the number of unfinalized proposals
+	if govCfg.MaxOpenProposals != nil {
+		if _, err := strconv.ParseUint(*govCfg.MaxOpenProposals, 10, 64); err != nil {
+			return feI(index, "governance.maxOpenProps", fmt.Sprintf("must be a non-negative integer"))
+		}
+	}
+
 	// When proposer is ProposerOwner, tally method cannot be CoinWeighted or Identity
 	if govCfg.Voter != nil && govCfg.PropTallyMethod != nil {
 		tallyMethod := govCfg.PropTallyMethod
@@ -329,6 +359,66 @@ func CheckRepoConfig(cfg *state.RepoConfig, index int) error {
 policy:
 	// TODO: policy validation here
 
+	// Ensure the commit message pattern is a valid regular expression
+	if cfg.CommitMsg != nil && cfg.CommitMsg.Pattern != "" {
+		if _, err := regexp.Compile(cfg.CommitMsg.Pattern); err != nil {
+			return feI(index, "commitMsg.pattern", "must be a valid regular expression")
+		}
+	}
+
+	// Ensure the upstream is a valid repository name
+	if cfg.Upstream != "" {
+		if err := identifier.IsValidResourceName(cfg.Upstream); err != nil {
+			return feI(index, "upstream", err.Error())
+		}
+	}
+
+	// Ensure the access allow-list contains only user addresses or push key IDs
+	if cfg.Access != nil {
+		for i, id := range cfg.Access.Allowed {
+			if identifier.IsValidUserAddr(id) != nil && !crypto2.IsValidPushAddr(id) {
+				return feI(index, fmt.Sprintf("access.allowed[%d]", i), "expected a user address or push key id")
+			}
+		}
+	}
+
+	// Ensure the default reviewers are push key IDs
+	for i, id := range cfg.DefaultReviewers {
+		if !crypto2.IsValidPushAddr(id) {
+			return feI(index, fmt.Sprintf("defaultReviewers[%d]", i), "expected a push key id")
+		}
+	}
+
+	// Ensure the post retention policy has a known action and a period
+	if !cfg.PostRetention.IsEmpty() {
+		action := cfg.PostRetention.Action
+		if action != state.PostRetentionArchive && action != state.PostRetentionPrune {
+			return feI(index, "postRetention.action", "expected 'archive' or 'prune'")
+		}
+		if cfg.PostRetention.ClosedTTL == 0 {
+			return feI(index, "postRetention.closedTTL", "must be greater than zero")
+		}
+	}
+
+	// Ensure the unsigned reference patterns are valid glob patterns of references
+	if cfg.Signature != nil {
+		for i, pattern := range cfg.Signature.Unsigned {
+			if _, err := path.Match(pattern, ""); err != nil || !strings.HasPrefix(pattern, "refs/") {
+				return feI(index, fmt.Sprintf("signature.unsigned[%d]", i), "expected a valid reference pattern")
+			}
+		}
+	}
+
+	// Ensure the tag grace period does not exceed the network maximum
+	if cfg.TagGracePeriod > params.MaxTagGracePeriod {
+		return feI(index, "tagGracePeriod", fmt.Sprintf("cannot exceed %d blocks", params.MaxTagGracePeriod))
+	}
+
+	// Ensure the merge strategy is known
+	if cfg.MergeStrategy != "" && !state.IsValidMergeStrategy(cfg.MergeStrategy) {
+		return feI(index, "mergeStrategy", "expected 'merge-commit', 'squash' or 'rebase'")
+	}
+
 	return nil
 }
 
@@ -342,6 +432,11 @@ func CheckTxRepoCreate(tx *txns.TxRepoCreate, index int) error {
 		return err
 	}
 
+	if params.RepoCreateFee.IsPositive() && !tx.Value.Decimal().Equal(params.RepoCreateFee) {
+		return feI(index, "value", fmt.Sprintf("invalid value; has %s, want %s",
+			tx.Value, params.RepoCreateFee.String()))
+	}
+
 	if err := v.Validate(tx.Name,
 		v.Required.Error(feI(index, "name", "requires a unique name").Error()),
 		v.By(validObjectNameRule("name", index)),
@@ -557,7 +652,12 @@ func CheckTxNamespaceAcquire(tx *txns.TxNamespaceRegister, index int) error {
 		}
 	}
 
-	if !tx.Value.Decimal().Equal(params.NamespaceRegFee) {
+	if tier := params.GetNamespacePriceTier(tx.Name); tier != nil {
+		if !tx.Value.Decimal().Equal(tier.Fee) {
+			return feI(index, "value", fmt.Sprintf("invalid value; has %s, want %s (price of names "+
+				"with at most %d characters)", tx.Value, tier.Fee.String(), tier.MaxLen))
+		}
+	} else if !tx.Value.Decimal().Equal(params.NamespaceRegFee) {
 		return feI(index, "value", fmt.Sprintf("invalid value; has %s, want %s",
 			tx.Value, params.NamespaceRegFee.String()))
 	}
@@ -589,6 +689,10 @@ func CheckTxNamespaceDomainUpdate(tx *txns.TxNamespaceDomainUpdate, index int) e
 		return err
 	}
 
+	if len(tx.Domains) > params.MaxDomainsPerNamespace {
+		return feI(index, "domains", "namespace domain limit exceeded")
+	}
+
 	if len(tx.Domains) > 0 {
 		if err := CheckNamespaceDomains(tx.Domains, index); err != nil {
 			return err
@@ -247,6 +247,22 @@ var _ = Describe("TxValidator", func() {
 				Expect(err.Error()).To(Equal(`"field":"value","msg":"invalid value; has 1, want 5"`))
 			})
 
+			It("has a short name and value not equal to the name's tier price", func() {
+				tx.Name = "abc"
+				tx.Value = "5"
+				err := validation.CheckTxNamespaceAcquire(tx, -1)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal(`"field":"value","msg":"invalid value; has 5, want 100 (price of names with at most 3 characters)"`))
+			})
+
+			It("has a name in the second tier and value not equal to the tier price", func() {
+				tx.Name = "abcd"
+				tx.Value = "100"
+				err := validation.CheckTxNamespaceAcquire(tx, -1)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal(`"field":"value","msg":"invalid value; has 100, want 10 (price of names with at most 5 characters)"`))
+			})
+
 			It("has domain target with invalid format", func() {
 				tx.Value = "5"
 				tx.Domains["domain"] = "invalid:format"
@@ -295,6 +311,31 @@ var _ = Describe("TxValidator", func() {
 		})
 	})
 
+	Describe(".CheckTxNamespaceAcquire (price tiers)", func() {
+		var tx *txns.TxNamespaceRegister
+		BeforeEach(func() {
+			params.NamespaceRegFee = decimal.NewFromFloat(5)
+			tx = txns.NewBareTxNamespaceRegister()
+			tx.Fee = "1"
+			tx.Nonce = 1
+			tx.Timestamp = time.Now().Unix()
+			tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+		})
+
+		for name, value := range map[string]string{"abc": "100", "abcd": "10", "abcde": "10", "abcdef": "5"} {
+			name, value := name, value
+			It(fmt.Sprintf("should accept name=%s with value=%s", name, value), func() {
+				tx.Name = name
+				tx.Value = util.String(value)
+				Expect(params.GetNamespaceRegFee(name).String()).To(Equal(value))
+				sig, err := tx.Sign(key.PrivKey().Base58())
+				Expect(err).To(BeNil())
+				tx.Sig = sig
+				Expect(validation.CheckTxNamespaceAcquire(tx, -1)).To(BeNil())
+			})
+		}
+	})
+
 	Describe(".checkNamespaceDomains", func() {
 		When("map include a domain that is not valid", func() {
 			It("should return err", func() {
@@ -467,6 +508,20 @@ var _ = Describe("TxValidator", func() {
 					"propTallyMethod": 1000,
 				}},
 			},
+			{
+				"desc": "invalid governance.propQuorumMode value",
+				"err":  `"field":"governance.propQuorumMode","msg":"unknown value"`,
+				"data": map[string]interface{}{"governance": map[string]interface{}{
+					"propQuorumMode": 1000,
+				}},
+			},
+			{
+				"desc": "invalid governance.propCloseTrigger value",
+				"err":  `"field":"governance.propCloseTrigger","msg":"unknown value"`,
+				"data": map[string]interface{}{"governance": map[string]interface{}{
+					"propCloseTrigger": 1000,
+				}},
+			},
 			{
 				"desc": "proposal duration has negative value",
 				"err":  `"field":"governance.propDur","msg":"must be a non-negative number"`,
@@ -565,6 +620,49 @@ var _ = Describe("TxValidator", func() {
 					"propFee":         "100",
 				}},
 			},
+			{
+				"desc": "proposal fee deposit cap has negative value",
+				"err":  `"field":"governance.propFeeDepCap","msg":"must be a non-negative number"`,
+				"data": map[string]interface{}{"governance": map[string]interface{}{
+					"propFeeDepCap": "-1",
+				}},
+			},
+			{
+				"desc": "proposal fee deposit cap is below the proposal fee",
+				"err":  `"field":"governance.propFeeDepCap","msg":"cannot be lower than the proposal fee"`,
+				"before": func() {
+					params.DefaultMinProposalFee = float64(400)
+				},
+				"data": map[string]interface{}{"governance": map[string]interface{}{
+					"propFee":       "500",
+					"propFeeDepCap": "450",
+				}},
+			},
+			{
+				"desc": "proposal fee deposit cap is not below the proposal fee",
+				"err":  "",
+				"before": func() {
+					params.DefaultMinProposalFee = float64(400)
+				},
+				"data": map[string]interface{}{"governance": map[string]interface{}{
+					"propFee":       "500",
+					"propFeeDepCap": "500",
+				}},
+			},
+			{
+				"desc": "max open proposals is negative",
+				"err":  `"field":"governance.maxOpenProps","msg":"must be a non-negative integer"`,
+				"data": map[string]interface{}{"governance": map[string]interface{}{
+					"maxOpenProps": "-1",
+				}},
+			},
+			{
+				"desc": "max open proposals is not an integer",
+				"err":  `"field":"governance.maxOpenProps","msg":"must be a non-negative integer"`,
+				"data": map[string]interface{}{"governance": map[string]interface{}{
+					"maxOpenProps": "1.5",
+				}},
+			},
 			{
 				"desc": "when voter type is not ProposerOwner and tally method is CoinWeighted",
 				"err":  `"field":"config","msg":"when proposer is not 'ProposerOwner', tally methods 'CoinWeighted' and 'Identity' are not allowed"`,
@@ -588,6 +686,109 @@ var _ = Describe("TxValidator", func() {
 					"propFeeRefundType": 12345,
 				}},
 			},
+			{
+				"desc": "when commit message pattern is not a valid regular expression",
+				"err":  `"field":"commitMsg.pattern","msg":"must be a valid regular expression"`,
+				"data": map[string]interface{}{"commitMsg": map[string]interface{}{
+					"pattern": "^(feat",
+				}},
+			},
+			{
+				"desc": "when commit message pattern is a valid regular expression",
+				"err":  "",
+				"data": map[string]interface{}{"commitMsg": map[string]interface{}{
+					"pattern": "^(feat|fix): .+", "conventional": true,
+				}},
+			},
+			{
+				"desc": "when upstream is not a valid repository name",
+				"err":  `"field":"upstream","msg":"invalid identifier; only alphanumeric, _, and - characters are allowed"`,
+				"data": map[string]interface{}{"upstream": "repo/1"},
+			},
+			{
+				"desc": "when upstream is a valid repository name",
+				"err":  "",
+				"data": map[string]interface{}{"upstream": "repo1"},
+			},
+			{
+				"desc": "when access allow-list contains an invalid entry",
+				"err":  `"field":"access.allowed[1]","msg":"expected a user address or push key id"`,
+				"data": map[string]interface{}{"access": map[string]interface{}{
+					"private": true, "allowed": []interface{}{key.Addr().String(), "abc"},
+				}},
+			},
+			{
+				"desc": "when access allow-list contains user addresses and push key ids",
+				"err":  "",
+				"data": map[string]interface{}{"access": map[string]interface{}{
+					"private": true, "allowed": []interface{}{key.Addr().String(), key.PushAddr().String()},
+				}},
+			},
+			{
+				"desc": "when default reviewers contain an invalid push key id",
+				"err":  `"field":"defaultReviewers[1]","msg":"expected a push key id"`,
+				"data": map[string]interface{}{"defaultReviewers": []interface{}{key.PushAddr().String(), key.Addr().String()}},
+			},
+			{
+				"desc": "when default reviewers are push key ids",
+				"err":  "",
+				"data": map[string]interface{}{"defaultReviewers": []interface{}{key.PushAddr().String()}},
+			},
+			{
+				"desc": "when post retention action is unknown",
+				"err":  `"field":"postRetention.action","msg":"expected 'archive' or 'prune'"`,
+				"data": map[string]interface{}{"postRetention": map[string]interface{}{"closedTTL": 3600, "action": "delete"}},
+			},
+			{
+				"desc": "when post retention action is not set",
+				"err":  `"field":"postRetention.action","msg":"expected 'archive' or 'prune'"`,
+				"data": map[string]interface{}{"postRetention": map[string]interface{}{"closedTTL": 3600}},
+			},
+			{
+				"desc": "when post retention period is not set",
+				"err":  `"field":"postRetention.closedTTL","msg":"must be greater than zero"`,
+				"data": map[string]interface{}{"postRetention": map[string]interface{}{"action": "prune"}},
+			},
+			{
+				"desc": "when post retention policy is valid",
+				"err":  "",
+				"data": map[string]interface{}{"postRetention": map[string]interface{}{"closedTTL": 3600, "action": "archive"}},
+			},
+			{
+				"desc": "when an unsigned reference pattern is not a valid glob pattern",
+				"err":  `"field":"signature.unsigned[0]","msg":"expected a valid reference pattern"`,
+				"data": map[string]interface{}{"signature": map[string]interface{}{"required": true, "unsigned": []interface{}{"refs/heads/[wip"}}},
+			},
+			{
+				"desc": "when an unsigned reference pattern is not a reference",
+				"err":  `"field":"signature.unsigned[1]","msg":"expected a valid reference pattern"`,
+				"data": map[string]interface{}{"signature": map[string]interface{}{"required": true, "unsigned": []interface{}{"refs/heads/wip/*", "wip/*"}}},
+			},
+			{
+				"desc": "when signature policy is valid",
+				"err":  "",
+				"data": map[string]interface{}{"signature": map[string]interface{}{"required": true, "unsigned": []interface{}{"refs/heads/wip/*"}}},
+			},
+			{
+				"desc": "when tag grace period exceeds the network maximum",
+				"err":  `"field":"tagGracePeriod","msg":"cannot exceed 8640 blocks"`,
+				"data": map[string]interface{}{"tagGracePeriod": 8641},
+			},
+			{
+				"desc": "when tag grace period is valid",
+				"err":  "",
+				"data": map[string]interface{}{"tagGracePeriod": 100},
+			},
+			{
+				"desc": "when merge strategy is unknown",
+				"err":  `"field":"mergeStrategy","msg":"expected 'merge-commit', 'squash' or 'rebase'"`,
+				"data": map[string]interface{}{"mergeStrategy": "fast-forward"},
+			},
+			{
+				"desc": "when merge strategy is valid",
+				"err":  "",
+				"data": map[string]interface{}{"mergeStrategy": "squash"},
+			},
 		}
 
 		for index, c := range cases {
@@ -703,6 +904,35 @@ var _ = Describe("TxValidator", func() {
 				Expect(err).To(BeNil())
 			})
 		})
+
+		When("repo creation has a price", func() {
+			BeforeEach(func() {
+				params.RepoCreateFee = decimal.NewFromFloat(5)
+				tx.Nonce = 1
+				tx.Timestamp = time.Now().Unix()
+				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+			})
+
+			AfterEach(func() {
+				params.RepoCreateFee = decimal.Zero
+			})
+
+			It("should return error when value does not match the repo creation fee", func() {
+				tx.Value = "4"
+				err := validation.CheckTxRepoCreate(tx, -1)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal(`"field":"value","msg":"invalid value; has 4, want 5"`))
+			})
+
+			It("should return no error when value matches the repo creation fee", func() {
+				tx.Value = "5"
+				sig, err := tx.Sign(key.PrivKey().Base58())
+				Expect(err).To(BeNil())
+				tx.Sig = sig
+				err = validation.CheckTxRepoCreate(tx, -1)
+				Expect(err).To(BeNil())
+			})
+		})
 	})
 
 	Describe(".CheckScopes", func() {
@@ -746,6 +976,8 @@ var _ = Describe("TxValidator", func() {
 				scopes := []string{
 					"repo_&*",
 					"a/os13463exprf3fdq44eth4lkf99dy6z5ajuk4ln4z",
+					"team-[",
+					"ns*/repo1",
 				}
 				for _, s := range scopes {
 					tx.Scopes = []string{s}
@@ -780,6 +1012,18 @@ var _ = Describe("TxValidator", func() {
 				err = validation.CheckTxRegisterPushKey(tx, -1)
 				Expect(err).To(BeNil())
 			})
+
+			It("should return no error when scopes contain glob patterns", func() {
+				tx.Nonce = 1
+				tx.Timestamp = time.Now().Unix()
+				tx.Scopes = []string{"team-*", "r/team-?", "ns1/team-*"}
+				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+				sig, err := tx.Sign(key.PrivKey().Base58())
+				Expect(err).To(BeNil())
+				tx.Sig = sig
+				err = validation.CheckTxRegisterPushKey(tx, -1)
+				Expect(err).To(BeNil())
+			})
 		})
 	})
 
@@ -815,14 +1059,14 @@ var _ = Describe("TxValidator", func() {
 			})
 
 			It("has invalid entry in addScopes", func() {
-				tx.AddScopes = []string{"inv*alid"}
+				tx.AddScopes = []string{"inv&alid"}
 				err := validation.CheckTxUpDelPushKey(tx, -1)
 				Expect(err).ToNot(BeNil())
 				Expect(err.Error()).To(Equal(`"field":"scopes[0]","msg":"scope is invalid. Expected a namespace path or repository name"`))
 			})
 
 			It("has invalid entry in addScopes", func() {
-				tx.AddScopes = []string{"inv*alid"}
+				tx.AddScopes = []string{"inv&alid"}
 				err := validation.CheckTxUpDelPushKey(tx, -1)
 				Expect(err).ToNot(BeNil())
 				Expect(err.Error()).To(Equal(`"field":"scopes[0]","msg":"scope is invalid. Expected a namespace path or repository name"`))
@@ -915,11 +1159,17 @@ var _ = Describe("TxValidator", func() {
 	Describe(".CheckTxNamespaceDomainUpdate", func() {
 		var tx *txns.TxNamespaceDomainUpdate
 
+		var maxDomains = params.MaxDomainsPerNamespace
+
 		BeforeEach(func() {
 			tx = txns.NewBareTxNamespaceDomainUpdate()
 			tx.Fee = "1"
 		})
 
+		AfterEach(func() {
+			params.MaxDomainsPerNamespace = maxDomains
+		})
+
 		When("it has invalid fields, it should return error when", func() {
 			It("should return error='type is invalid'", func() {
 				tx.Type = -10
@@ -964,6 +1214,33 @@ var _ = Describe("TxValidator", func() {
 				Expect(err.Error()).To(Equal(`"field":"domains","msg":"domains.domain: target is invalid"`))
 			})
 		})
+
+		When("the number of domains exceeds the namespace domain limit", func() {
+			It("should return err", func() {
+				params.MaxDomainsPerNamespace = 2
+				tx.Name = "name1"
+				tx.Domains = map[string]string{"domain1": "r/repo1", "domain2": "r/repo2", "domain3": "r/repo3"}
+				err := validation.CheckTxNamespaceDomainUpdate(tx, -1)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal(`"field":"domains","msg":"namespace domain limit exceeded"`))
+			})
+		})
+
+		When("the number of domains is within the namespace domain limit", func() {
+			It("should return no error", func() {
+				params.MaxDomainsPerNamespace = 2
+				tx.Name = "name1"
+				tx.Domains = map[string]string{"domain1": "r/repo1", "domain2": "r/repo2"}
+				tx.Nonce = 1
+				tx.Timestamp = time.Now().Unix()
+				tx.SenderPubKey = ed25519.BytesToPublicKey(key.PubKey().MustBytes())
+				sig, err := tx.Sign(key.PrivKey().Base58())
+				Expect(err).To(BeNil())
+				tx.Sig = sig
+				err = validation.CheckTxNamespaceDomainUpdate(tx, -1)
+				Expect(err).To(BeNil())
+			})
+		})
 	})
 
 	Describe(".CheckTxPush", func() {